@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"opentask/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+// expandAliases replaces the alias that args (the arguments after the
+// program name) start with, after any root flags, by the command it stands
+// for, keeping the arguments that follow it. An alias may start with
+// another alias. Commands always take precedence over aliases of the same
+// name. Aliases are read from the configuration file at configPath.
+func expandAliases(root *cobra.Command, args []string, configPath string) ([]string, error) {
+	manager := config.NewManager()
+	if err := manager.Load(configPath); err != nil {
+		// The command itself reports configuration errors.
+		return args, nil
+	}
+	aliases := manager.GetConfig().Aliases
+	if len(aliases) == 0 {
+		return args, nil
+	}
+
+	i := commandIndex(root, args)
+	if i < len(args) && args[i] == cobra.ShellCompRequestCmd {
+		// Complete the arguments of the command the alias stands for.
+		i += 1 + commandIndex(root, args[i+1:])
+	}
+
+	seen := make(map[string]bool)
+	for i < len(args) {
+		name := args[i]
+		expansion, ok := aliases[name]
+		if !ok {
+			break
+		}
+		if isCommand(root, name) {
+			fmt.Fprintf(os.Stderr, "⚠ alias %q is ignored: opentask has a command of that name\n", name)
+			break
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("alias %q refers to itself", name)
+		}
+		seen[name] = true
+
+		words, err := splitWords(expansion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alias %q: %w", name, err)
+		}
+		if len(words) == 0 {
+			return nil, fmt.Errorf("alias %q is empty", name)
+		}
+		args = append(append(append([]string{}, args[:i]...), words...), args[i+1:]...)
+	}
+	return args, nil
+}
+
+// commandIndex returns the index of the first argument that is not a root
+// flag or a root flag's value.
+func commandIndex(root *cobra.Command, args []string) int {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return i
+		}
+		if arg == "--" || strings.Contains(arg, "=") {
+			continue
+		}
+
+		var name string
+		if strings.HasPrefix(arg, "--") {
+			name = strings.TrimPrefix(arg, "--")
+		} else if len(arg) == 2 {
+			if flag := root.PersistentFlags().ShorthandLookup(arg[1:]); flag != nil {
+				name = flag.Name
+			}
+		}
+		if flag := root.PersistentFlags().Lookup(name); flag != nil && flag.NoOptDefVal == "" {
+			i++
+		}
+	}
+	return len(args)
+}
+
+func isCommand(root *cobra.Command, name string) bool {
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return name == "help" || name == cobra.ShellCompRequestCmd || name == cobra.ShellCompNoDescRequestCmd
+}
+
+// splitWords splits an alias into arguments at spaces, keeping text in
+// single or double quotes together.
+func splitWords(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"opentask/cmd/task"
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/progress"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+	"opentask/pkg/taskfile"
+	"opentask/pkg/terminal"
+
+	"github.com/spf13/cobra"
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply <dir>",
+	Short: "Reconcile a directory of task definitions with a platform",
+	Long: `Apply task definitions stored as Markdown (with YAML front-matter) or
+YAML files to a platform.
+
+Each definition has a stable id (the 'id' key, or the file name). The first
+apply creates the task and records its platform ID in a state file next to
+the definitions (` + taskfile.StateFile + `). Later runs compare each task
+with its definition and update any drifted fields.
+
+Use --dry-run to only report what would change. Otherwise the planned
+changes are listed and confirmed before any is made, unless --yes is given;
+more than ui.bulk_confirm_threshold changes ask for a typed phrase, which
+--force skips.
+
+Examples:
+  opentask apply ./tasks
+  opentask apply ./tasks --yes
+  opentask apply ./tasks --platform jira --project API --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runApply,
+}
+
+var (
+	applyPlatform string
+	applyProject  string
+	applyState    string
+	applyDryRun   bool
+	applyYes      bool
+	applyForce    bool
+)
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().StringVarP(&applyPlatform, "platform", "p", "", "platform to apply to (default: configured default)")
+	applyCmd.Flags().StringVar(&applyProject, "project", "", "project for definitions that do not set one")
+	applyCmd.Flags().StringVar(&applyState, "state", "", "state file (default: <dir>/"+taskfile.StateFile+")")
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "report changes without applying them")
+	applyCmd.Flags().BoolVarP(&applyYes, "yes", "y", false, "do not ask for confirmation before applying the changes")
+	applyCmd.Flags().BoolVar(&applyForce, "force", false, "do not ask for the confirmation phrase when applying many changes")
+}
+
+// applyAction is what apply decided to do with one definition.
+type applyAction int
+
+const (
+	applyUnchanged applyAction = iota
+	applyCreate
+	applyUpdate
+)
+
+func runApply(cmd *cobra.Command, args []string) error {
+	dir := args[0]
+
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+
+	platformName := applyPlatform
+	if platformName == "" {
+		platformName = cfg.Defaults.Platform
+	}
+	if platformName == "" {
+		return fmt.Errorf("no platform specified. Use --platform or set a default platform")
+	}
+
+	platform, exists := cfg.GetPlatform(platformName)
+	if !exists {
+		return fmt.Errorf("platform %s not configured", platformName)
+	}
+	if !platform.Enabled {
+		return fmt.Errorf("platform %s is disabled", platformName)
+	}
+
+	defs, err := taskfile.LoadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(defs) == 0 {
+		fmt.Printf("No task definitions found in %s\n", dir)
+		return nil
+	}
+
+	statePath := applyState
+	if statePath == "" {
+		statePath = filepath.Join(dir, taskfile.StateFile)
+	}
+	state, err := taskfile.LoadState(statePath)
+	if err != nil {
+		return err
+	}
+
+	client, err := service.NewClient(platformName, platform)
+	if err != nil {
+		return err
+	}
+
+	project := cfg.ResolveProject(applyProject)
+	if project == "" {
+		project = cfg.DefaultProject(platformName)
+	}
+
+	interactive, err := terminal.Interactive(cfg.UI.Interactive)
+	if err != nil {
+		return err
+	}
+
+	// Plan every definition first, so the changes can be confirmed before
+	// any of them is made.
+	tracker := progress.New(os.Stdout, "Planning", len(defs), interactive)
+	var plans []applyPlan
+	var created, updated, unchanged, failed int
+	for _, def := range defs {
+		def.Project = cfg.ResolveProject(def.Project)
+		ctx, cancel := service.WithRequestTimeout(context.Background())
+		plan, err := planDefinition(ctx, client, platformName, def, state)
+		cancel()
+
+		if err != nil {
+			failed++
+			tracker.Done(def.ID, err)
+			continue
+		}
+
+		switch plan.action {
+		case applyCreate:
+			created++
+			tracker.Printf("+ %s: create %q\n", def.ID, def.Title)
+			plans = append(plans, plan)
+		case applyUpdate:
+			updated++
+			tracker.Printf("~ %s: drifted %s (%s)\n", def.ID, plan.current.ID, strings.Join(plan.drift, ", "))
+			plans = append(plans, plan)
+		default:
+			unchanged++
+			tracker.Printf("= %s: %s up to date\n", def.ID, plan.current.ID)
+		}
+		tracker.Done(def.ID, nil)
+	}
+	tracker.Finish()
+
+	if applyDryRun {
+		fmt.Printf("\nPlan: %d to create, %d to update, %d unchanged", created, updated, unchanged)
+		return applySummary(failed, created+updated+unchanged+failed)
+	}
+
+	if len(plans) > 0 {
+		tasks := make([]*models.Task, len(plans))
+		for i, plan := range plans {
+			tasks[i] = plan.task(platformName, project)
+		}
+		ok, err := task.Confirm(cfg, task.Confirmation{
+			Verb:     "apply",
+			Question: fmt.Sprintf("Create %d and update %d task(s) on %s?", created, updated, platformName),
+			Tasks:    tasks,
+			Listed:   true,
+			Phrase:   true,
+		}, applyYes, applyForce)
+		if !ok {
+			return err
+		}
+	}
+
+	tracker = progress.New(os.Stdout, "Applying", len(plans), interactive)
+	created, updated = 0, 0
+	audit := make(map[string][]store.AuditTask)
+	for _, plan := range plans {
+		ctx, cancel := service.WithRequestTimeout(context.Background())
+		result, err := executePlan(ctx, client, platformName, project, plan, state, audit)
+		cancel()
+
+		if err != nil {
+			failed++
+			tracker.Done(plan.def.ID, err)
+			continue
+		}
+
+		if plan.action == applyCreate {
+			created++
+			tracker.Printf("+ %s: created %s\n", plan.def.ID, result.ID)
+		} else {
+			updated++
+			tracker.Printf("~ %s: updated %s (%s)\n", plan.def.ID, result.ID, strings.Join(plan.drift, ", "))
+		}
+		tracker.Done(plan.def.ID, nil)
+	}
+
+	tracker.Finish()
+	recordAudit(store.AuditCreate, audit[store.AuditCreate])
+	recordAudit(store.AuditUpdate, audit[store.AuditUpdate])
+
+	if created+updated > 0 {
+		if err := state.Save(statePath); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("\nApplied: %d created, %d updated, %d unchanged", created, updated, unchanged)
+	return applySummary(failed, created+updated+unchanged+failed)
+}
+
+// applySummary ends the summary line, reporting the definitions that
+// failed.
+func applySummary(failed, total int) error {
+	if failed > 0 {
+		fmt.Printf(", %d failed\n", failed)
+		return exitcode.WrapPartial(failed, total, fmt.Errorf("%d definition(s) could not be applied", failed))
+	}
+	fmt.Println()
+	return nil
+}
+
+// applyPlan is the change apply makes for one definition.
+type applyPlan struct {
+	def    *taskfile.Definition
+	action applyAction
+	// current is the task the definition was applied to before, or nil
+	// if it is to be created.
+	current *models.Task
+	drift   []string
+}
+
+// task returns the task the plan creates or updates.
+func (p applyPlan) task(platformName, project string) *models.Task {
+	if p.current != nil {
+		return p.current
+	}
+	task := models.NewTask(p.def.Title, models.Platform(platformName))
+	task.ProjectID = project
+	p.def.ApplyTo(task)
+	return task
+}
+
+// planDefinition compares a single definition with the platform and
+// decides whether to create or update its task, and which fields drifted.
+func planDefinition(ctx context.Context, client platforms.PlatformClient, platformName string, def *taskfile.Definition, state *taskfile.State) (applyPlan, error) {
+	plan := applyPlan{def: def, action: applyCreate}
+	entry, tracked := state.Tasks[def.ID]
+	if tracked && entry.Platform != platformName {
+		return plan, fmt.Errorf("already applied to %s as %s", entry.Platform, entry.TaskID)
+	}
+	if !tracked {
+		return plan, nil
+	}
+
+	current, err := client.GetTask(ctx, entry.TaskID)
+	switch {
+	case platforms.IsNotFoundError(err):
+		// Deleted on the platform; recreate it.
+		fmt.Printf("⚠ %s: %s no longer exists on %s\n", def.ID, entry.TaskID, platformName)
+		return plan, nil
+	case err != nil:
+		return plan, err
+	}
+
+	plan.current = current
+	plan.drift = def.Diff(current)
+	plan.action = applyUnchanged
+	if len(plan.drift) > 0 {
+		plan.action = applyUpdate
+	}
+	return plan, nil
+}
+
+// executePlan creates or updates the task of a planned definition and
+// records it in state. Tasks it creates or updates are added to audit
+// under the action.
+func executePlan(ctx context.Context, client platforms.PlatformClient, platformName, project string, plan applyPlan, state *taskfile.State, audit map[string][]store.AuditTask) (*models.Task, error) {
+	def := plan.def
+	if plan.action == applyUpdate {
+		current := plan.current
+		before := *current
+		before.Labels = slices.Clone(current.Labels)
+		def.ApplyTo(current)
+		result, err := client.UpdateTask(ctx, current)
+		audit[store.AuditUpdate] = append(audit[store.AuditUpdate], store.NewAuditUpdate(&before, current, err))
+		if err != nil {
+			return nil, err
+		}
+		entry := state.Tasks[def.ID]
+		entry.AppliedAt = time.Now()
+		state.Tasks[def.ID] = entry
+		return result, nil
+	}
+
+	task := plan.task(platformName, project)
+	result, err := client.CreateTask(ctx, task)
+	if err != nil {
+		audit[store.AuditCreate] = append(audit[store.AuditCreate], store.NewAuditTask(task, err))
+		return nil, err
+	}
+	audit[store.AuditCreate] = append(audit[store.AuditCreate], store.NewAuditTask(result, nil))
+
+	state.Tasks[def.ID] = taskfile.StateEntry{
+		Platform:  platformName,
+		TaskID:    result.ID,
+		AppliedAt: time.Now(),
+	}
+	return result, nil
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"opentask/pkg/benchmark"
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+
+	"github.com/spf13/cobra"
+)
+
+var benchmarkCmd = &cobra.Command{
+	Use:   "benchmark",
+	Short: "Measure platform API latency",
+	Long: `Measure how long list, get and create calls take on each enabled platform.
+
+Every operation is repeated --iterations times and reported as percentiles,
+which helps when choosing timeouts and how many requests to run at once.
+
+Creating tasks is only measured against a sandbox project given with
+--sandbox-project; each task created is deleted again. Without it the
+create row is skipped (a dry run).
+
+Examples:
+  opentask benchmark
+  opentask benchmark --platform jira --iterations 50
+  opentask benchmark --platform jira --sandbox-project SANDBOX`,
+	RunE: runBenchmark,
+}
+
+var (
+	benchmarkPlatform   string
+	benchmarkProject    string
+	benchmarkSandbox    string
+	benchmarkIterations int
+)
+
+func init() {
+	rootCmd.AddCommand(benchmarkCmd)
+
+	benchmarkCmd.Flags().StringVarP(&benchmarkPlatform, "platform", "p", "", "platform to measure (default: all enabled platforms)")
+	benchmarkCmd.Flags().StringVar(&benchmarkProject, "project", "", "project to list and get tasks from")
+	benchmarkCmd.Flags().StringVar(&benchmarkSandbox, "sandbox-project", "", "project to create (and delete) test tasks in")
+	benchmarkCmd.Flags().IntVarP(&benchmarkIterations, "iterations", "n", 10, "number of calls per operation")
+}
+
+func runBenchmark(cmd *cobra.Command, args []string) error {
+	if benchmarkIterations < 1 {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("--iterations must be at least 1"))
+	}
+
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+
+	names := cfg.GetEnabledPlatforms()
+	if benchmarkPlatform != "" {
+		platform, exists := cfg.GetPlatform(benchmarkPlatform)
+		if !exists {
+			return fmt.Errorf("platform %s not configured", benchmarkPlatform)
+		}
+		if !platform.Enabled {
+			return fmt.Errorf("platform %s is disabled", benchmarkPlatform)
+		}
+		names = []string{benchmarkPlatform}
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no platforms configured or enabled")
+	}
+	sort.Strings(names)
+
+	project := cfg.ResolveProject(benchmarkProject)
+	sandbox := cfg.ResolveProject(benchmarkSandbox)
+
+	fmt.Printf("Running %d iterations per operation\n\n", benchmarkIterations)
+	fmt.Printf("%-12s %-8s %6s %6s %9s %9s %9s %9s\n", "PLATFORM", "OP", "OK", "ERRORS", "P50", "P90", "P99", "MAX")
+
+	var failed []string
+	for _, name := range names {
+		platform, _ := cfg.GetPlatform(name)
+		client, err := service.NewClient(name, platform)
+		if err != nil {
+			fmt.Printf("%-12s ✗ %v\n", name, err)
+			continue
+		}
+
+		results := benchmarkPlatformClient(client, name, project, sandbox)
+		for _, result := range results {
+			printBenchmarkResult(name, result)
+			if result.stats.Err != nil {
+				failed = append(failed, fmt.Sprintf("%s %s: %v", name, result.op, result.stats.Err))
+			}
+		}
+	}
+
+	if sandbox == "" {
+		fmt.Println("\nCreate was not measured. Use --sandbox-project to create test tasks in a sandbox project.")
+	}
+	if len(failed) > 0 {
+		fmt.Println()
+		for _, failure := range failed {
+			fmt.Printf("⚠ %s\n", failure)
+		}
+	}
+
+	return nil
+}
+
+// benchmarkResult is the latency of one operation, or why it was skipped.
+type benchmarkResult struct {
+	op      string
+	stats   benchmark.Stats
+	skipped string
+}
+
+func benchmarkPlatformClient(client platforms.PlatformClient, platformName, project, sandbox string) []benchmarkResult {
+	ctx := context.Background()
+
+	var taskID string
+	list := benchmark.Run(ctx, benchmarkIterations, func(ctx context.Context) error {
+		ctx, cancel := service.WithRequestTimeout(ctx)
+		defer cancel()
+		tasks, err := client.ListTasks(ctx, &models.TaskFilter{ProjectID: project, Limit: 50})
+		if err == nil && taskID == "" && len(tasks) > 0 {
+			taskID = tasks[0].ID
+		}
+		return err
+	})
+	results := []benchmarkResult{{op: "list", stats: list}}
+
+	if taskID == "" {
+		results = append(results, benchmarkResult{op: "get", skipped: "no tasks to get"})
+	} else {
+		get := benchmark.Run(ctx, benchmarkIterations, func(ctx context.Context) error {
+			ctx, cancel := service.WithRequestTimeout(ctx)
+			defer cancel()
+			_, err := client.GetTask(ctx, taskID)
+			return err
+		})
+		results = append(results, benchmarkResult{op: "get", stats: get})
+	}
+
+	if sandbox == "" {
+		return append(results, benchmarkResult{op: "create", skipped: "dry run"})
+	}
+
+	var created []*models.Task
+	create := benchmark.Run(ctx, benchmarkIterations, func(ctx context.Context) error {
+		task := models.NewTask(fmt.Sprintf("opentask benchmark %d", len(created)+1), models.Platform(platformName))
+		task.ProjectID = sandbox
+		task.Description = "Created by opentask benchmark and deleted when it finishes."
+
+		ctx, cancel := service.WithRequestTimeout(ctx)
+		defer cancel()
+		result, err := client.CreateTask(ctx, task)
+		if err != nil {
+			return err
+		}
+		created = append(created, result)
+		return nil
+	})
+
+	// Deleting is not measured, so it happens once all creates are done.
+	createdAudit := make([]store.AuditTask, 0, len(created))
+	deletedAudit := make([]store.AuditTask, 0, len(created))
+	for _, task := range created {
+		ctx, cancel := service.WithRequestTimeout(ctx)
+		err := client.DeleteTask(ctx, task.ID)
+		if err != nil {
+			fmt.Printf("⚠ could not delete benchmark task %s: %v\n", task.ID, err)
+		}
+		cancel()
+		createdAudit = append(createdAudit, store.NewAuditTask(task, nil))
+		deletedAudit = append(deletedAudit, store.NewAuditTask(task, err))
+	}
+	recordAudit(store.AuditCreate, createdAudit)
+	recordAudit(store.AuditDelete, deletedAudit)
+	return append(results, benchmarkResult{op: "create", stats: create})
+}
+
+func printBenchmarkResult(platformName string, result benchmarkResult) {
+	if result.skipped != "" {
+		fmt.Printf("%-12s %-8s skipped (%s)\n", platformName, result.op, result.skipped)
+		return
+	}
+
+	stats := result.stats
+	if stats.Count == 0 {
+		fmt.Printf("%-12s %-8s %6d %6d %9s %9s %9s %9s\n", platformName, result.op, 0, stats.Errors, "-", "-", "-", "-")
+		return
+	}
+	fmt.Printf("%-12s %-8s %6d %6d %9s %9s %9s %9s\n", platformName, result.op, stats.Count, stats.Errors,
+		formatLatency(stats.P50), formatLatency(stats.P90), formatLatency(stats.P99), formatLatency(stats.Max))
+}
+
+func formatLatency(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"opentask/pkg/calendar"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/service"
+
+	"github.com/spf13/cobra"
+)
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Export task due dates to calendars",
+	Long: `Export the due dates of the tasks assigned to you as an iCalendar feed,
+so deadlines show up in Google Calendar, Apple Calendar or Outlook.
+
+'opentask serve' publishes the same feed at /calendar.ics for calendar
+apps to subscribe to.`,
+}
+
+var calendarExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write an iCalendar file of your tasks' due dates",
+	Long: `Write an all-day event on the due date of every open task assigned to
+you, on every enabled platform, as an iCalendar (.ics) file. Import it
+into a calendar app, or regenerate it from cron into a directory the app
+subscribes to.
+
+Events are identified by task, so importing a newer file moves the events
+of tasks whose due date changed instead of adding new ones.
+
+Examples:
+  opentask calendar export > tasks.ics
+  opentask calendar export --platform jira -o ~/Calendars/jira.ics
+  opentask calendar export --include-closed`,
+	RunE: runCalendarExport,
+}
+
+var (
+	calendarFormat        string
+	calendarOutput        string
+	calendarPlatform      string
+	calendarLimit         int
+	calendarIncludeClosed bool
+	calendarName          string
+)
+
+func init() {
+	rootCmd.AddCommand(calendarCmd)
+	calendarCmd.AddCommand(calendarExportCmd)
+
+	calendarExportCmd.Flags().StringVar(&calendarFormat, "format", "ics", "output format (ics)")
+	calendarExportCmd.Flags().StringVarP(&calendarOutput, "output", "o", "", "write the calendar to this file instead of stdout")
+	calendarExportCmd.Flags().StringVarP(&calendarPlatform, "platform", "p", "", "limit to platform")
+	calendarExportCmd.Flags().IntVar(&calendarLimit, "limit", 500, "maximum number of tasks to fetch per platform")
+	calendarExportCmd.Flags().BoolVar(&calendarIncludeClosed, "include-closed", false, "include done and cancelled tasks")
+	calendarExportCmd.Flags().StringVar(&calendarName, "name", "OpenTask", "calendar name shown by calendar apps")
+}
+
+func runCalendarExport(cmd *cobra.Command, args []string) error {
+	if calendarFormat != "ics" {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("unknown format %q (use ics)", calendarFormat))
+	}
+
+	svc, err := service.Load(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	if len(svc.Config().GetEnabledPlatforms()) == 0 {
+		return fmt.Errorf("no platforms configured or enabled")
+	}
+
+	opts := calendar.Options{Limit: calendarLimit, IncludeClosed: calendarIncludeClosed}
+	if calendarPlatform != "" {
+		opts.Platforms = []string{calendarPlatform}
+		if len(svc.Platforms(opts.Platforms)) == 0 {
+			return fmt.Errorf("platform %s is not configured or enabled", calendarPlatform)
+		}
+	}
+	tasks, failures := calendar.Tasks(context.Background(), svc, opts)
+	failures.Report(os.Stderr, "list tasks")
+	if len(failures) > 0 && len(failures) >= len(svc.Platforms(opts.Platforms)) {
+		return fmt.Errorf("no platform answered")
+	}
+
+	var buf bytes.Buffer
+	if err := calendar.Write(&buf, calendarName, tasks, time.Now()); err != nil {
+		return err
+	}
+
+	if calendarOutput == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(calendarOutput, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write calendar: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✓ Wrote %d due date(s) to %s\n", len(tasks), calendarOutput)
+	return nil
+}
@@ -0,0 +1,69 @@
+package completion
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// CompletionCmd generates shell completion scripts. It replaces cobra's
+// default completion command so the install instructions match opentask.
+var CompletionCmd = &cobra.Command{
+	Use:   "completion <bash|zsh|fish|powershell>",
+	Short: "Generate shell completion scripts",
+	Long: `Generate the completion script for your shell.
+
+Besides commands and flags, the scripts complete platform names, project
+keys, statuses, priorities, labels and the IDs of tasks you have recently
+listed. Project keys are listed from each platform once a day and kept in
+~/.opentask/projects.json; task IDs come from ~/.opentask/recent.json.
+
+Bash (requires bash-completion):
+  source <(opentask completion bash)
+  # or, for every session:
+  opentask completion bash > /etc/bash_completion.d/opentask
+
+Zsh:
+  opentask completion zsh > "${fpath[1]}/_opentask"
+  # compinit must be enabled: echo "autoload -U compinit; compinit" >> ~/.zshrc
+
+Fish:
+  opentask completion fish > ~/.config/fish/completions/opentask.fish
+
+PowerShell:
+  opentask completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []cobra.Completion{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE:                  runCompletion,
+}
+
+var completionNoDescriptions bool
+
+func init() {
+	CompletionCmd.Flags().BoolVar(&completionNoDescriptions, "no-descriptions", false, "leave descriptions out of the completions")
+}
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	root := cmd.Root()
+	descriptions := !completionNoDescriptions
+
+	switch args[0] {
+	case "bash":
+		return root.GenBashCompletionV2(os.Stdout, descriptions)
+	case "zsh":
+		if descriptions {
+			return root.GenZshCompletion(os.Stdout)
+		}
+		return root.GenZshCompletionNoDesc(os.Stdout)
+	case "fish":
+		return root.GenFishCompletion(os.Stdout, descriptions)
+	case "powershell":
+		if descriptions {
+			return root.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+		return root.GenPowerShellCompletion(os.Stdout)
+	}
+	return fmt.Errorf("unsupported shell %q", args[0])
+}
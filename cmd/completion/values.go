@@ -0,0 +1,298 @@
+package completion
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+
+	"github.com/spf13/cobra"
+)
+
+// timeout bounds listing projects or refreshing a stale vocabulary while
+// the shell waits for completions.
+const timeout = 5 * time.Second
+
+// flagValues says how the flags Register finds are completed, by flag
+// name.
+var flagValues = map[string]func(cmd *cobra.Command) []cobra.Completion{
+	"platform": platformNames,
+	"sync-to":  platformNames,
+	"project":  projectKeys,
+	"status":   statuses,
+	"priority": priorities,
+	"labels":   labels,
+}
+
+// Register completes the values of the --platform, --sync-to, --project,
+// --status, --priority, --labels and --field flags of cmd and every
+// command below it.
+func Register(cmd *cobra.Command) {
+	for name, values := range flagValues {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			flag = cmd.PersistentFlags().Lookup(name)
+		}
+		if flag == nil {
+			continue
+		}
+		multiple := strings.HasSuffix(flag.Value.Type(), "Slice") || strings.HasSuffix(flag.Value.Type(), "Array")
+		cmd.RegisterFlagCompletionFunc(name, complete(values, multiple))
+	}
+	if cmd.Flags().Lookup("field") != nil {
+		cmd.RegisterFlagCompletionFunc("field", Fields)
+	}
+	for _, child := range cmd.Commands() {
+		Register(child)
+	}
+}
+
+// complete returns a completion function offering values. When the flag
+// takes a comma-separated list, only the value after the last comma is
+// completed.
+func complete(values func(cmd *cobra.Command) []cobra.Completion, multiple bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		done, partial := "", toComplete
+		if i := strings.LastIndex(toComplete, ","); multiple && i >= 0 {
+			done, partial = toComplete[:i+1], toComplete[i+1:]
+		}
+		return matching(values(cmd), done, partial), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Projects completes a project argument from the project keys of the
+// platform given by --platform, or of every enabled platform.
+func Projects(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return matching(projectKeys(cmd), "", toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// TaskID completes the task ID argument of a command taking one task.
+func TaskID(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return TaskIDs(cmd, args, toComplete)
+}
+
+// TaskIDs completes task ID arguments from the tasks recently listed, on
+// the platform given by --platform when it is set, leaving out the IDs
+// already given.
+func TaskIDs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	st, err := store.Open()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	recent, err := st.RecentTasks()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	platformType := ""
+	if name, _ := cmd.Flags().GetString("platform"); name != "" {
+		if cfg := loadConfig(cmd); cfg != nil {
+			if platform, ok := cfg.GetPlatform(name); ok {
+				platformType = platform.Type
+			}
+		}
+	}
+
+	given := make(map[string]bool)
+	for _, arg := range args {
+		given[strings.ToUpper(arg)] = true
+	}
+
+	var completions []cobra.Completion
+	for _, task := range recent {
+		if platformType != "" && task.Platform != platformType {
+			continue
+		}
+		if given[strings.ToUpper(task.ID)] || !strings.HasPrefix(strings.ToUpper(task.ID), strings.ToUpper(toComplete)) {
+			continue
+		}
+		given[strings.ToUpper(task.ID)] = true
+		completions = append(completions, cobra.CompletionWithDesc(task.ID, task.Title))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// fieldNames are the Jira fields --field completes the names of.
+var fieldNames = []cobra.Completion{"components=", "fixVersions=", "reporter=", "sprint="}
+
+// Fields completes the well-known field names of --field, and the value of
+// --field components=... from the components of the project the command
+// targets.
+func Fields(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	const prefix = "components="
+	if !strings.HasPrefix(toComplete, prefix) {
+		var names []cobra.Completion
+		for _, name := range fieldNames {
+			if strings.HasPrefix(strings.ToLower(name), strings.ToLower(toComplete)) {
+				names = append(names, name)
+			}
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+
+	var components []string
+	for _, vocabulary := range targetVocabularies(cmd) {
+		components = append(components, vocabulary.Components...)
+	}
+	return matching(components, prefix, strings.TrimPrefix(toComplete, prefix)), cobra.ShellCompDirectiveNoFileComp
+}
+
+func platformNames(cmd *cobra.Command) []cobra.Completion {
+	cfg := loadConfig(cmd)
+	if cfg == nil {
+		return nil
+	}
+	var names []cobra.Completion
+	for _, name := range cfg.GetEnabledPlatforms() {
+		platform, _ := cfg.GetPlatform(name)
+		names = append(names, cobra.CompletionWithDesc(name, platform.Type))
+	}
+	return names
+}
+
+func statuses(cmd *cobra.Command) []cobra.Completion {
+	return []cobra.Completion{
+		string(models.StatusOpen),
+		string(models.StatusInProgress),
+		string(models.StatusDone),
+		string(models.StatusCancelled),
+	}
+}
+
+func priorities(cmd *cobra.Command) []cobra.Completion {
+	return []cobra.Completion{
+		string(models.PriorityLow),
+		string(models.PriorityMedium),
+		string(models.PriorityHigh),
+		string(models.PriorityUrgent),
+	}
+}
+
+func labels(cmd *cobra.Command) []cobra.Completion {
+	var labels []cobra.Completion
+	for _, vocabulary := range targetVocabularies(cmd) {
+		labels = append(labels, vocabulary.Labels...)
+	}
+	return labels
+}
+
+// projectKeys returns the project aliases and the project keys of the
+// platform given by --platform, or of every enabled platform. Projects are
+// taken from the project cache while it is fresh; when listing them again
+// fails the stale ones are used.
+func projectKeys(cmd *cobra.Command) []cobra.Completion {
+	cfg := loadConfig(cmd)
+	if cfg == nil {
+		return nil
+	}
+
+	var keys []cobra.Completion
+	for alias, target := range cfg.ProjectAliases {
+		keys = append(keys, cobra.CompletionWithDesc(alias, "alias for "+target))
+	}
+
+	svc := service.New(cfg)
+	svc.Timeout = timeout
+	for _, project := range svc.Projects.List(context.Background(), targetPlatforms(cmd)).Projects {
+		key := project.Key
+		if key == "" {
+			key = project.ID
+		}
+		keys = append(keys, cobra.CompletionWithDesc(key, project.Name))
+	}
+	return keys
+}
+
+// targetVocabularies returns the cached (or, when stale, freshly listed)
+// vocabularies of the platform given by the command's --platform flag (or
+// the default platform) and the project given by --project.
+func targetVocabularies(cmd *cobra.Command) []*platforms.Vocabulary {
+	cfg := loadConfig(cmd)
+	if cfg == nil {
+		return nil
+	}
+
+	names := cfg.GetEnabledPlatforms()
+	if platform, _ := cmd.Flags().GetString("platform"); platform != "" {
+		names = []string{platform}
+	} else if cfg.Defaults.Platform != "" {
+		names = []string{cfg.Defaults.Platform}
+	}
+
+	project, _ := cmd.Flags().GetString("project")
+	project = cfg.ResolveProject(project)
+
+	svc := service.New(cfg)
+	svc.Timeout = timeout
+
+	var vocabularies []*platforms.Vocabulary
+	for _, name := range svc.Platforms(names) {
+		vocabulary, err := svc.Vocabulary.Get(context.Background(), name, project)
+		if err == nil && vocabulary != nil {
+			vocabularies = append(vocabularies, vocabulary)
+		}
+	}
+	return vocabularies
+}
+
+// targetPlatforms returns the platform given by --platform, or nil for
+// every enabled platform.
+func targetPlatforms(cmd *cobra.Command) []string {
+	if platform, _ := cmd.Flags().GetString("platform"); platform != "" {
+		return []string{platform}
+	}
+	return nil
+}
+
+func loadConfig(cmd *cobra.Command) *config.Config {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return nil
+	}
+	return manager.GetConfig()
+}
+
+// matching returns the completions whose value starts with partial,
+// ignoring case, each prefixed with done. Descriptions are kept, and values
+// already in the comma-separated done are left out.
+func matching(completions []cobra.Completion, done, partial string) []cobra.Completion {
+	seen := make(map[string]bool)
+	for _, value := range strings.Split(done, ",") {
+		seen[value] = true
+	}
+	var matches []cobra.Completion
+	for _, completion := range platforms.SortedUnique(completions) {
+		value, _, _ := strings.Cut(completion, "\t")
+		if seen[value] || !strings.HasPrefix(strings.ToLower(value), strings.ToLower(partial)) {
+			continue
+		}
+		seen[value] = true
+		matches = append(matches, done+completion)
+	}
+	return matches
+}
+
+// ConfiguredPlatforms completes a platform name argument from every
+// configured platform, enabled or not.
+func ConfiguredPlatforms(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	cfg := loadConfig(cmd)
+	if len(args) > 0 || cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []cobra.Completion
+	for name, platform := range cfg.Platforms {
+		names = append(names, cobra.CompletionWithDesc(name, platform.Type))
+	}
+	return matching(names, "", toComplete), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the configuration in effect",
+	Long: `Print the configuration in effect, with a repository's .opentask.yaml
+applied. Credentials are shown as [redacted], so the output can be shared.
+
+Examples:
+  opentask config show
+  opentask config show --format json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigShow,
+}
+
+var configShowFormat string
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+
+	configShowCmd.Flags().StringVarP(&configShowFormat, "format", "f", "yaml", "output format (yaml, json)")
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg := manager.GetConfig().Redacted()
+
+	switch configShowFormat {
+	case "yaml":
+		if path := manager.GetConfigPath(); path != "" {
+			fmt.Printf("# %s\n", path)
+		}
+		if path := manager.LocalConfigPath(); path != "" {
+			fmt.Printf("# %s\n", path)
+		}
+		encoder := yaml.NewEncoder(os.Stdout)
+		encoder.SetIndent(2)
+		defer encoder.Close()
+		return encoder.Encode(cfg)
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(cfg)
+	default:
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("unknown format %q (use yaml or json)", configShowFormat))
+	}
+}
@@ -1,11 +1,28 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/git"
+	"opentask/pkg/platforms"
+	"opentask/pkg/platforms/jira"
+	"opentask/pkg/platforms/mock"
+	"opentask/pkg/prompt"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var connectCmd = &cobra.Command{
@@ -14,15 +31,38 @@ var connectCmd = &cobra.Command{
 	Long: `Connect to various task management platforms like Linear, Jira, Slack, or GitHub.
 	
 This command helps you authenticate and configure connections to different platforms.
-Use --list to see all available platforms.`,
+Use --list to see all available platforms.
+
+Values not given as flags are read from the environment (JIRA_SERVER,
+JIRA_EMAIL, JIRA_USERNAME, JIRA_API_TOKEN, JIRA_ACCESS_TOKEN,
+LINEAR_API_KEY, SLACK_BOT_TOKEN, GITHUB_TOKEN) and otherwise asked for.
+With --no-input nothing is asked, so provisioning scripts fail instead of
+waiting for input.
+
+After saving, the connection is checked by asking the platform who the
+credentials belong to. --test runs only that check for a connected
+platform, or for every enabled platform when none is named.
+
+Examples:
+  opentask connect jira --server https://company.atlassian.net --email me@company.com
+  JIRA_API_TOKEN=... opentask connect jira --server https://company.atlassian.net --email me@company.com --no-input
+  opentask connect --test`,
 	RunE: runConnect,
 }
 
 var (
-	connectList   bool
-	connectServer string
-	connectToken  string
-	connectForce  bool
+	connectList     bool
+	connectServer   string
+	connectToken    string
+	connectForce    bool
+	connectNoVerify bool
+	connectOAuth    bool
+	connectSites    []string
+	connectAuthType string
+	connectEmail    string
+	connectUsername string
+	connectNoInput  bool
+	connectTest     bool
 )
 
 func init() {
@@ -32,35 +72,45 @@ func init() {
 	connectCmd.Flags().StringVarP(&connectServer, "server", "s", "", "server URL (for self-hosted platforms)")
 	connectCmd.Flags().StringVarP(&connectToken, "token", "t", "", "authentication token")
 	connectCmd.Flags().BoolVarP(&connectForce, "force", "f", false, "force reconnection")
+	connectCmd.Flags().BoolVar(&connectNoVerify, "no-verify", false, "skip checking which features the token can use")
+	connectCmd.Flags().BoolVar(&connectOAuth, "oauth", false, "the token is an OAuth 2.0 access token (Jira Cloud); connects the sites it can reach")
+	connectCmd.Flags().StringSliceVar(&connectSites, "site", []string{}, "Jira sites to connect with --oauth, by name or URL, or \"all\"")
+	connectCmd.Flags().StringVar(&connectAuthType, "auth-type", "", "Jira Server/Data Center authentication: pat (personal access token) or basic (username and password)")
+	connectCmd.Flags().StringVar(&connectEmail, "email", "", "account email (Jira Cloud)")
+	connectCmd.Flags().StringVar(&connectUsername, "username", "", "username (Jira Server/Data Center with --auth-type basic)")
+	connectCmd.Flags().BoolVar(&connectNoInput, "no-input", false, "never prompt; fail when a required value is missing")
+	connectCmd.Flags().BoolVar(&connectTest, "test", false, "check the saved connection without changing it")
 }
 
 func runConnect(cmd *cobra.Command, args []string) error {
 	if connectList {
-		return listPlatforms()
+		return listPlatforms(cmd.Flags())
 	}
 
-	if len(args) == 0 {
-		return fmt.Errorf("platform name is required. Use --list to see available platforms")
-	}
-
-	platformName := args[0]
-
 	manager := config.NewManager()
-	if err := manager.Load(""); err != nil {
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
 	cfg := manager.GetConfig()
 
+	if connectTest {
+		return testConnections(cfg, args)
+	}
+
+	if len(args) == 0 {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("platform name is required. Use --list to see available platforms"))
+	}
+
+	platformName := args[0]
+
 	if !connectForce {
 		if platform, exists := cfg.GetPlatform(platformName); exists && platform.Enabled {
+			if connectNoInput {
+				return fmt.Errorf("platform %s is already connected; pass --force to reconnect", platformName)
+			}
 			fmt.Printf("Platform %s is already connected.\n", platformName)
-			fmt.Print("Do you want to reconnect? [y/N]: ")
-
-			var response string
-			fmt.Scanln(&response)
-
-			if response != "y" && response != "Y" {
+			if !prompt.Confirm("Do you want to reconnect?", false) {
 				fmt.Println("Connection cancelled.")
 				return nil
 			}
@@ -70,18 +120,20 @@ func runConnect(cmd *cobra.Command, args []string) error {
 	return connectToPlatform(platformName, cfg, manager)
 }
 
-func listPlatforms() error {
-	fmt.Println("Available platforms:")
-	fmt.Println("  linear   - Linear (https://linear.app)")
-	fmt.Println("  jira     - Jira (https://www.atlassian.com/software/jira)")
-	fmt.Println("  slack    - Slack (https://slack.com)")
-	fmt.Println("  github   - GitHub Issues (https://github.com)")
+func listPlatforms(flags *pflag.FlagSet) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(flags); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	printPlatforms(manager.GetConfig())
 	fmt.Println()
 	fmt.Println("Usage:")
+	fmt.Println("  opentask connect mock")
 	fmt.Println("  opentask connect linear")
 	fmt.Println("  opentask connect jira --server https://company.atlassian.net")
-	fmt.Println("  opentask connect slack --token xoxb-...")
-	fmt.Println("  opentask connect github --token ghp_...")
+	fmt.Println("  opentask connect jira --server https://company.atlassian.net --email me@company.com --token <api-token> --no-input")
+	fmt.Println("  opentask connect jira --oauth --token <access-token> --site all")
 
 	return nil
 }
@@ -96,6 +148,8 @@ func connectToPlatform(platformName string, cfg *config.Config, manager *config.
 		return connectSlack(cfg, manager)
 	case "github":
 		return connectGitHub(cfg, manager)
+	case "mock":
+		return connectMock(cfg, manager)
 	default:
 		return fmt.Errorf("unsupported platform: %s", platformName)
 	}
@@ -104,14 +158,9 @@ func connectToPlatform(platformName string, cfg *config.Config, manager *config.
 func connectLinear(cfg *config.Config, manager *config.Manager) error {
 	fmt.Println("Connecting to Linear...")
 
-	token := connectToken
-	if token == "" {
-		fmt.Print("Enter your Linear API token: ")
-		fmt.Scanln(&token)
-	}
-
-	if token == "" {
-		return fmt.Errorf("API token is required for Linear")
+	token, err := connectValue(connectToken, "--token", "LINEAR_API_KEY", "Linear API token", true)
+	if err != nil {
+		return err
 	}
 
 	platform := config.Platform{
@@ -125,72 +174,254 @@ func connectLinear(cfg *config.Config, manager *config.Manager) error {
 		},
 	}
 
-	cfg.AddPlatform("linear", platform)
+	return savePlatform("linear", "Linear", platform, cfg, manager)
+}
 
-	if err := manager.Save(); err != nil {
-		return fmt.Errorf("failed to save configuration: %w", err)
+// connectMock adds the mock platform, which keeps sample tasks in the data
+// directory and needs no credentials.
+func connectMock(cfg *config.Config, manager *config.Manager) error {
+	dir, err := store.DefaultDir()
+	if err != nil {
+		return err
 	}
 
-	fmt.Println("✓ Successfully connected to Linear")
-	return nil
+	platform := config.Platform{
+		Type:        "mock",
+		Enabled:     true,
+		Credentials: map[string]string{},
+		Settings: map[string]any{
+			mock.FileKey: filepath.Join(dir, "mock.json"),
+		},
+	}
+
+	return savePlatform("mock", "Mock", platform, cfg, manager)
 }
 
 func connectJira(cfg *config.Config, manager *config.Manager) error {
+	if connectOAuth {
+		return connectJiraOAuth(cfg, manager)
+	}
+
 	fmt.Println("Connecting to Jira...")
 
-	server := connectServer
-	if server == "" {
-		fmt.Print("Enter your Jira server URL: ")
-		fmt.Scanln(&server)
+	server, err := connectValue(connectServer, "--server", "JIRA_SERVER", "Jira server URL", false)
+	if err != nil {
+		return err
 	}
 
-	token := connectToken
-	if token == "" {
-		fmt.Print("Enter your Jira API token: ")
-		fmt.Scanln(&token)
+	cloud := isJiraCloud(server)
+	authType, err := jiraAuthType(cloud)
+	if err != nil {
+		return err
 	}
 
-	if server == "" || token == "" {
-		return fmt.Errorf("server URL and API token are required for Jira")
+	tokenLabel := "Jira API token"
+	switch {
+	case authType == jira.AuthPAT:
+		tokenLabel = "personal access token"
+	case !cloud:
+		tokenLabel = "Jira password"
 	}
 
-	var email string
-	fmt.Print("Enter your Jira email: ")
-	fmt.Scanln(&email)
+	token, err := connectValue(connectToken, "--token", "JIRA_API_TOKEN", tokenLabel, true)
+	if err != nil {
+		return err
+	}
 
 	platform := config.Platform{
 		Type:    "jira",
 		Enabled: true,
 		Credentials: map[string]string{
 			"token": token,
-			"email": email,
 		},
 		Settings: map[string]any{
 			"base_url": server,
 		},
 	}
 
-	cfg.AddPlatform("jira", platform)
+	switch {
+	case authType == jira.AuthPAT:
+		platform.Settings[jira.AuthTypeKey] = jira.AuthPAT
+	case cloud:
+		email, err := connectValue(connectEmail, "--email", "JIRA_EMAIL", "Jira email", false)
+		if err != nil {
+			return err
+		}
+		platform.Credentials["email"] = email
+	default:
+		username, err := connectValue(connectUsername, "--username", "JIRA_USERNAME", "Jira username", false)
+		if err != nil {
+			return err
+		}
+		platform.Credentials["username"] = username
+		platform.Settings[jira.AuthTypeKey] = jira.AuthBasic
+	}
 
-	if err := manager.Save(); err != nil {
-		return fmt.Errorf("failed to save configuration: %w", err)
+	return savePlatform("jira", "Jira", platform, cfg, manager)
+}
+
+// isJiraCloud reports whether server is a Jira Cloud site. Cloud sites
+// authenticate with an email and API token; anything else is taken to be
+// Jira Server or Data Center.
+func isJiraCloud(server string) bool {
+	host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	host, _, _ = strings.Cut(host, "/")
+	return strings.HasSuffix(strings.ToLower(host), ".atlassian.net")
+}
+
+// jiraAuthType returns the authentication to use, from --auth-type or by
+// asking for self-hosted servers. Cloud sites use basic auth.
+func jiraAuthType(cloud bool) (string, error) {
+	switch strings.ToLower(connectAuthType) {
+	case jira.AuthPAT:
+		return jira.AuthPAT, nil
+	case jira.AuthBasic:
+		return jira.AuthBasic, nil
+	case "":
+	default:
+		return "", exitcode.Wrap(exitcode.Usage, fmt.Errorf("invalid --auth-type %q (use pat or basic)", connectAuthType))
+	}
+
+	// Without input, Server uses the recommended personal access token.
+	if cloud || connectNoInput {
+		if cloud {
+			return jira.AuthBasic, nil
+		}
+		return jira.AuthPAT, nil
+	}
+
+	fmt.Println("Jira Server/Data Center can authenticate with:")
+	fmt.Println("  1. Personal access token (recommended)")
+	fmt.Println("  2. Username and password")
+
+	choice, err := prompt.Line("Choose [1]: ")
+	if err != nil {
+		return "", err
+	}
+	switch strings.TrimSpace(choice) {
+	case "", "1":
+		return jira.AuthPAT, nil
+	case "2":
+		return jira.AuthBasic, nil
+	}
+	return "", fmt.Errorf("invalid choice %q", choice)
+}
+
+// connectJiraOAuth lists the Jira Cloud sites an OAuth access token can
+// reach and saves each selected site as its own platform.
+func connectJiraOAuth(cfg *config.Config, manager *config.Manager) error {
+	fmt.Println("Connecting to Jira Cloud with OAuth...")
+
+	token, err := connectValue(connectToken, "--token", "JIRA_ACCESS_TOKEN", "Jira OAuth access token", true)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := service.WithRequestTimeout(context.Background())
+	defer cancel()
+
+	sites, err := jira.ListSites(ctx, token)
+	if err != nil {
+		return err
+	}
+	if len(sites) == 0 {
+		return fmt.Errorf("the token cannot reach any Jira sites. Check that the app was granted a Jira scope")
+	}
+
+	selected, err := selectJiraSites(sites)
+	if err != nil {
+		return err
+	}
+
+	for _, site := range selected {
+		name := "jira"
+		if len(selected) > 1 {
+			name = "jira-" + git.Slugify(site.Name)
+		}
+
+		platform := config.Platform{
+			Type:    "jira",
+			Enabled: true,
+			Credentials: map[string]string{
+				"access_token": token,
+			},
+			Settings: map[string]any{
+				"base_url": site.APIURL(),
+				"site_url": site.URL,
+			},
+		}
+
+		if err := savePlatform(name, fmt.Sprintf("Jira site %s as %s", site.URL, name), platform, cfg, manager); err != nil {
+			return err
+		}
 	}
 
-	fmt.Println("✓ Successfully connected to Jira")
 	return nil
 }
 
-func connectSlack(cfg *config.Config, manager *config.Manager) error {
-	fmt.Println("Connecting to Slack...")
+// selectJiraSites picks the sites named by --site, or asks which to
+// connect when the token reaches more than one.
+func selectJiraSites(sites []jira.Site) ([]jira.Site, error) {
+	if len(connectSites) > 0 {
+		if len(connectSites) == 1 && connectSites[0] == "all" {
+			return sites, nil
+		}
+
+		var selected []jira.Site
+		for _, want := range connectSites {
+			found := false
+			for _, site := range sites {
+				if strings.EqualFold(site.Name, want) || strings.EqualFold(strings.TrimSuffix(site.URL, "/"), strings.TrimSuffix(want, "/")) || site.ID == want {
+					selected = append(selected, site)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("site %s is not accessible with this token", want)
+			}
+		}
+		return selected, nil
+	}
+
+	if len(sites) == 1 {
+		return sites, nil
+	}
+	if connectNoInput {
+		return nil, fmt.Errorf("the token can reach %d Jira sites; choose them with --site", len(sites))
+	}
+
+	fmt.Println("The token can reach these Jira sites:")
+	for i, site := range sites {
+		fmt.Printf("  %d. %s (%s)\n", i+1, site.Name, site.URL)
+	}
+
+	response, err := prompt.Line("Sites to connect (e.g. 1,3 or all): ")
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(response) == "all" {
+		return sites, nil
+	}
 
-	token := connectToken
-	if token == "" {
-		fmt.Print("Enter your Slack Bot Token: ")
-		fmt.Scanln(&token)
+	var selected []jira.Site
+	for _, part := range strings.Split(response, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 1 || n > len(sites) {
+			return nil, fmt.Errorf("invalid site selection %q", response)
+		}
+		selected = append(selected, sites[n-1])
 	}
+	return selected, nil
+}
+
+func connectSlack(cfg *config.Config, manager *config.Manager) error {
+	fmt.Println("Connecting to Slack...")
 
-	if token == "" {
-		return fmt.Errorf("bot token is required for Slack")
+	token, err := connectValue(connectToken, "--token", "SLACK_BOT_TOKEN", "Slack bot token", true)
+	if err != nil {
+		return err
 	}
 
 	platform := config.Platform{
@@ -204,27 +435,15 @@ func connectSlack(cfg *config.Config, manager *config.Manager) error {
 		},
 	}
 
-	cfg.AddPlatform("slack", platform)
-
-	if err := manager.Save(); err != nil {
-		return fmt.Errorf("failed to save configuration: %w", err)
-	}
-
-	fmt.Println("✓ Successfully connected to Slack")
-	return nil
+	return savePlatform("slack", "Slack", platform, cfg, manager)
 }
 
 func connectGitHub(cfg *config.Config, manager *config.Manager) error {
 	fmt.Println("Connecting to GitHub...")
 
-	token := connectToken
-	if token == "" {
-		fmt.Print("Enter your GitHub Personal Access Token: ")
-		fmt.Scanln(&token)
-	}
-
-	if token == "" {
-		return fmt.Errorf("personal access token is required for GitHub")
+	token, err := connectValue(connectToken, "--token", "GITHUB_TOKEN", "GitHub personal access token", true)
+	if err != nil {
+		return err
 	}
 
 	platform := config.Platform{
@@ -238,12 +457,205 @@ func connectGitHub(cfg *config.Config, manager *config.Manager) error {
 		},
 	}
 
-	cfg.AddPlatform("github", platform)
+	return savePlatform("github", "GitHub", platform, cfg, manager)
+}
+
+// savePlatform checks what the token can do, records the result in the
+// platform settings and saves the connection.
+func savePlatform(name, label string, platform config.Platform, cfg *config.Config, manager *config.Manager) error {
+	// Reconnecting replaces the credentials but keeps the mappings set up
+	// for the platform's workflow.
+	if existing, exists := cfg.GetPlatform(name); exists {
+		for _, key := range []string{platforms.StatusMapKey, platforms.PriorityMapKey, platforms.LabelMapKey} {
+			if value, ok := existing.Settings[key]; ok {
+				platform.Settings[key] = value
+			}
+		}
+	}
+
+	if !connectNoVerify {
+		if err := verifyPriorityMap(name, platform); err != nil {
+			return err
+		}
+
+		checks, err := verifyCapabilities(name, platform)
+		if err != nil {
+			if connectNoInput {
+				return fmt.Errorf("could not verify the %s token: %w. Use --no-verify to save it anyway", label, err)
+			}
+			fmt.Printf("⚠ Could not verify the %s token: %v\n", label, err)
+			if !prompt.Confirm("Save the connection anyway?", false) {
+				fmt.Println("Connection cancelled.")
+				return nil
+			}
+		} else if checks != nil {
+			platform.Settings[platforms.CapabilitiesKey] = platforms.CapabilitySettings(checks)
+		}
+	}
+
+	cfg.AddPlatform(name, platform)
 
 	if err := manager.Save(); err != nil {
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
 
-	fmt.Println("✓ Successfully connected to GitHub")
+	fmt.Printf("✓ Successfully connected to %s\n", label)
+	if connectNoVerify {
+		return nil
+	}
+	return testConnection(name, platform)
+}
+
+// connectValue returns a connection setting from its flag, then from the
+// environment variable env, and otherwise asks for it. With --no-input a
+// missing value is an error instead.
+func connectValue(value, flag, env, label string, secret bool) (string, error) {
+	if value == "" {
+		value = os.Getenv(env)
+	}
+	if value == "" && !connectNoInput {
+		read := prompt.Line
+		if secret {
+			read = prompt.Secret
+		}
+		var err error
+		if value, err = read(fmt.Sprintf("Enter your %s: ", label)); err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("%s is required; pass %s or set %s", label, flag, env)
+	}
+	return value, nil
+}
+
+// testConnections checks the named platform, or every enabled platform.
+func testConnections(cfg *config.Config, names []string) error {
+	if len(names) == 0 {
+		names = cfg.GetEnabledPlatforms()
+		sort.Strings(names)
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no platforms configured. Use 'opentask connect' to add platforms")
+	}
+
+	failed := 0
+	for _, name := range names {
+		platform, exists := cfg.GetPlatform(name)
+		if !exists {
+			return fmt.Errorf("platform %s is not connected", name)
+		}
+		if err := testConnection(name, platform); err != nil {
+			fmt.Printf("✗ %v\n", err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return exitcode.WrapPartial(failed, len(names), fmt.Errorf("%d of %d connection(s) failed", failed, len(names)))
+	}
+	return nil
+}
+
+// testConnection asks the platform who the credentials belong to and
+// reports it.
+func testConnection(name string, platform config.Platform) error {
+	client, err := service.NewClient(name, platform)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	ctx, cancel := service.WithRequestTimeout(context.Background())
+	defer cancel()
+
+	user, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		if healthErr := client.HealthCheck(ctx); healthErr != nil {
+			return fmt.Errorf("%s rejected the credentials: %w", name, healthErr)
+		}
+		fmt.Printf("✓ %s is reachable, but did not say who the credentials belong to: %v\n", name, err)
+		return nil
+	}
+
+	who := user.Name
+	if user.Email != "" {
+		who = fmt.Sprintf("%s <%s>", user.Name, user.Email)
+	}
+	fmt.Printf("✓ %s: authenticated as %s\n", name, who)
+	return nil
+}
+
+// verifyCapabilities probes the platform and prints which OpenTask features
+// the token can use. It returns nil checks if the platform cannot be probed.
+func verifyCapabilities(name string, platform config.Platform) ([]platforms.CapabilityCheck, error) {
+	client, err := service.NewClient(name, platform)
+	if err != nil {
+		return nil, nil
+	}
+
+	prober, ok := client.(platforms.CapabilityProber)
+	if !ok {
+		return nil, nil
+	}
+
+	fmt.Println("Checking token permissions...")
+
+	ctx, cancel := service.WithRequestTimeout(context.Background())
+	defer cancel()
+
+	checks, err := prober.ProbeCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := 0
+	for _, check := range checks {
+		if check.Allowed {
+			fmt.Printf("  ✓ %s\n", check.Capability.Feature())
+			continue
+		}
+		missing++
+		fmt.Printf("  ✗ %s (needs %s)\n", check.Capability.Feature(), check.Required)
+	}
+
+	if missing > 0 {
+		fmt.Printf("⚠ %d feature(s) will not work with this token. Grant the listed permissions and run 'opentask connect %s --force' to re-check.\n", missing, name)
+	}
+
+	return checks, nil
+}
+
+// verifyPriorityMap checks the configured priority_map against the
+// priorities the platform actually has.
+func verifyPriorityMap(name string, platform config.Platform) error {
+	if _, ok := platform.Settings[platforms.PriorityMapKey]; !ok {
+		return nil
+	}
+
+	client, err := service.NewClient(name, platform)
+	if err != nil {
+		return err
+	}
+
+	lister, ok := client.(platforms.PriorityLister)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := service.WithRequestTimeout(context.Background())
+	defer cancel()
+
+	available, err := lister.ListPriorities(ctx)
+	if err != nil {
+		fmt.Printf("⚠ Could not check %s: %v\n", platforms.PriorityMapKey, err)
+		return nil
+	}
+
+	if err := platforms.ValidatePriorityMap(platform.Settings, available); err != nil {
+		return fmt.Errorf("%w. Fix the mapping in the config file and reconnect, or use --no-verify", err)
+	}
+
+	fmt.Printf("  ✓ %s matches the %s priorities\n", platforms.PriorityMapKey, name)
 	return nil
 }
@@ -0,0 +1,114 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+	"opentask/pkg/report"
+	"opentask/pkg/store"
+
+	"github.com/spf13/cobra"
+)
+
+var buildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Render the dashboard to a directory",
+	Long: `Render open tasks by project, overdue tasks and recent completions from
+the latest snapshot into index.html, with the same data in dashboard.json.
+
+Examples:
+  opentask dashboard build --output ./site
+  opentask dashboard build --output ./site --project TEST --days 14`,
+	RunE: runBuild,
+}
+
+var (
+	buildOutput      string
+	buildTitle       string
+	buildPlatform    string
+	buildProject     string
+	buildAllProjects bool
+	buildDays        int
+)
+
+func init() {
+	buildCmd.Flags().StringVarP(&buildOutput, "output", "o", "site", "directory to write the site to")
+	buildCmd.Flags().StringVar(&buildTitle, "title", "OpenTask Dashboard", "dashboard title")
+	buildCmd.Flags().StringVarP(&buildPlatform, "platform", "p", "", "limit to platform")
+	buildCmd.Flags().StringVar(&buildProject, "project", "", "limit to project")
+	buildCmd.Flags().BoolVar(&buildAllProjects, "all-projects", false, "include all projects (ignore default project)")
+	buildCmd.Flags().IntVar(&buildDays, "days", 7, "show tasks completed within this many days")
+}
+
+func runBuild(cmd *cobra.Command, args []string) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+
+	s, err := store.Open()
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := s.LatestSnapshot()
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return fmt.Errorf("no snapshots recorded yet. Run 'opentask report snapshot' first")
+	}
+
+	filter := &models.TaskFilter{}
+	if buildPlatform != "" {
+		platform := models.Platform(buildPlatform)
+		filter.Platform = &platform
+	}
+	switch {
+	case buildProject != "":
+		filter.ProjectID = cfg.ResolveProject(buildProject)
+	case !buildAllProjects:
+		filter.DefaultProjects = cfg.DefaultProjectsByType()
+	}
+
+	var tasks []*models.Task
+	for _, task := range snapshot.Tasks {
+		if filter.Matches(task) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	d := report.BuildDashboard(buildTitle, tasks, snapshot.TakenAt, time.Now(), buildDays)
+
+	if err := os.MkdirAll(buildOutput, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", buildOutput, err)
+	}
+
+	index, err := os.Create(filepath.Join(buildOutput, "index.html"))
+	if err != nil {
+		return fmt.Errorf("failed to create index.html: %w", err)
+	}
+	defer index.Close()
+
+	if err := report.RenderDashboard(index, d); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode dashboard data: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(buildOutput, "dashboard.json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write dashboard.json: %w", err)
+	}
+
+	fmt.Printf("✓ Dashboard for %d task(s) written to %s\n", len(tasks), buildOutput)
+	return nil
+}
@@ -0,0 +1,19 @@
+package dashboard
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var DashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Build a static task dashboard",
+	Long: `Build a static HTML dashboard from the local snapshot history.
+
+The generated site has no external dependencies and can be published with
+GitHub Pages or any static host. Record snapshots regularly with
+"opentask report snapshot" to keep it current.`,
+}
+
+func init() {
+	DashboardCmd.AddCommand(buildCmd)
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/models"
+	"opentask/pkg/notify"
+	"opentask/pkg/report"
+	"opentask/pkg/service"
+
+	"github.com/spf13/cobra"
+)
+
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Summarize the tasks of the past day or week in Markdown",
+	Long: `Summarize, for each platform and project, the tasks created and closed
+over the past day or week, and the open tasks that are overdue or stale
+(not updated in --stale-days days), as Markdown.
+
+The digest is printed, written to a file with --output, or sent with
+--notify to notifiers configured under notifications, which makes it
+suitable for cron:
+
+  0 8 * * 1  opentask digest --period week --notify team
+
+Tasks have no closing time, so a closed task counts as closed in the
+period it was last updated in.
+
+Examples:
+  opentask digest
+  opentask digest --period day --project TEST
+  opentask digest --period week --stale-days 30 -o digest.md`,
+	RunE: runDigest,
+}
+
+var (
+	digestPeriod      string
+	digestStaleDays   int
+	digestPlatform    string
+	digestProject     string
+	digestAllProjects bool
+	digestLimit       int
+	digestTitle       string
+	digestOutput      string
+	digestNotify      []string
+)
+
+// digestPeriods are the periods a digest covers, in days.
+var digestPeriods = map[string]int{"day": 1, "week": 7}
+
+func init() {
+	rootCmd.AddCommand(digestCmd)
+
+	digestCmd.Flags().StringVar(&digestPeriod, "period", "week", "period to summarize (day, week)")
+	digestCmd.Flags().IntVar(&digestStaleDays, "stale-days", 14, "days without an update after which an open task is stale")
+	digestCmd.Flags().StringVarP(&digestPlatform, "platform", "p", "", "limit to platform")
+	digestCmd.Flags().StringVar(&digestProject, "project", "", "limit to project")
+	digestCmd.Flags().BoolVar(&digestAllProjects, "all-projects", false, "include all projects (ignore default project)")
+	digestCmd.Flags().IntVar(&digestLimit, "limit", 500, "maximum number of tasks to fetch per platform")
+	digestCmd.Flags().StringVar(&digestTitle, "title", "", "digest title (default \"Daily digest\" or \"Weekly digest\")")
+	digestCmd.Flags().StringVarP(&digestOutput, "output", "o", "", "write the digest to this file instead of stdout")
+	digestCmd.Flags().StringSliceVar(&digestNotify, "notify", []string{}, "send the digest to the named notifiers (repeatable)")
+}
+
+func runDigest(cmd *cobra.Command, args []string) error {
+	days, ok := digestPeriods[digestPeriod]
+	if !ok {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("invalid period %q; use day or week", digestPeriod))
+	}
+	if digestStaleDays < 1 {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("--stale-days must be at least 1"))
+	}
+
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+	if len(cfg.GetEnabledPlatforms()) == 0 {
+		return fmt.Errorf("no platforms configured or enabled")
+	}
+
+	// Resolve the notifiers first, so a typo fails before the platforms
+	// are queried.
+	var dispatcher *notify.Dispatcher
+	if len(digestNotify) > 0 {
+		var err error
+		if dispatcher, err = notify.FromConfig(cfg); err != nil {
+			return fmt.Errorf("invalid notifications configuration: %w", err)
+		}
+	}
+
+	var names []string
+	if digestPlatform != "" {
+		names = []string{digestPlatform}
+	}
+	filter := &models.TaskFilter{Limit: digestLimit}
+	if digestProject != "" {
+		filter.ProjectID = cfg.ResolveProject(digestProject)
+	}
+
+	svc := service.New(cfg)
+	listFn := svc.Tasks.ListInDefaultProjects
+	if digestAllProjects {
+		listFn = svc.Tasks.List
+	}
+	list := listFn(context.Background(), names, filter)
+	list.Failures.Report(os.Stderr, "list tasks")
+	if len(list.Searches) == 0 {
+		return fmt.Errorf("no platform answered")
+	}
+
+	tasks := make(map[string][]*models.Task, len(list.Searches))
+	for name, result := range list.Searches {
+		tasks[name] = result.Tasks
+	}
+
+	title := digestTitle
+	if title == "" {
+		title = "Weekly digest"
+		if digestPeriod == "day" {
+			title = "Daily digest"
+		}
+	}
+	to := time.Now()
+	digest := report.BuildDigest(title, tasks, to.AddDate(0, 0, -days), to, digestStaleDays)
+
+	var markdown bytes.Buffer
+	if err := report.RenderDigestMarkdown(&markdown, digest); err != nil {
+		return err
+	}
+
+	if dispatcher != nil {
+		ctx, cancel := service.WithRequestTimeout(context.Background())
+		defer cancel()
+		failures, err := dispatcher.SendReport(ctx, digestNotify, notify.Report{Title: title, Body: markdown.String()})
+		if err != nil {
+			return err
+		}
+		for _, failure := range failures {
+			fmt.Fprintf(os.Stderr, "⚠ Failed to send the digest to %s: %v\n", failure.Notifier, failure.Err)
+		}
+		if len(failures) == len(digestNotify) {
+			return fmt.Errorf("the digest could not be sent")
+		}
+		if digestOutput == "" {
+			fmt.Fprintf(os.Stderr, "✓ Sent %s to %d notifier(s)\n", title, len(digestNotify)-len(failures))
+			return nil
+		}
+	}
+
+	if digestOutput == "" {
+		_, err := os.Stdout.Write(markdown.Bytes())
+		return err
+	}
+	if err := os.WriteFile(digestOutput, markdown.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write digest: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✓ Wrote %s to %s\n", title, digestOutput)
+	return nil
+}
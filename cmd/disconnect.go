@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+
+	"opentask/cmd/completion"
+	"opentask/pkg/config"
+	"opentask/pkg/platforms"
+	"opentask/pkg/prompt"
+
+	"github.com/spf13/cobra"
+)
+
+var disconnectCmd = &cobra.Command{
+	Use:   "disconnect <platform>",
+	Short: "Remove a connected platform",
+	Long: `Remove a platform and its saved credentials from the configuration.
+
+The platform's default project is removed with it, and it stops being the
+default platform. A webhook registered with 'opentask webhook register' is
+not removed from the platform; unregister it first. To stop using a platform
+for a while without losing its credentials, use 'opentask platform disable'.
+
+Examples:
+  opentask disconnect jira
+  opentask disconnect linear --yes`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.ConfiguredPlatforms,
+	RunE:              runDisconnect,
+}
+
+var disconnectYes bool
+
+func init() {
+	rootCmd.AddCommand(disconnectCmd)
+
+	disconnectCmd.Flags().BoolVarP(&disconnectYes, "yes", "y", false, "do not ask for confirmation")
+}
+
+func runDisconnect(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg := manager.GetConfig()
+
+	platform, exists := cfg.GetPlatform(name)
+	if !exists {
+		return fmt.Errorf("platform %s is not connected", name)
+	}
+
+	if webhook, ok := platforms.RegisteredWebhook(platform.Settings); ok {
+		fmt.Printf("⚠ Webhook %s on %s stays registered. Run 'opentask webhook unregister --platform %s' first to remove it.\n", webhook.ID, name, name)
+	}
+
+	if !disconnectYes {
+		if !prompt.IsInteractive() {
+			return fmt.Errorf("refusing to disconnect %s without confirmation; pass --yes", name)
+		}
+		if !prompt.Confirm(fmt.Sprintf("Remove %s and its credentials?", name), false) {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	cfg.RemovePlatform(name)
+	if cfg.Defaults.Platform == name {
+		cfg.Defaults.Platform = ""
+	}
+
+	if err := manager.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Printf("✓ Disconnected %s\n", name)
+	return nil
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"opentask/pkg/exitcode"
+	"opentask/pkg/humanize"
+	"opentask/pkg/rules"
+	"opentask/pkg/store"
+	"opentask/pkg/styles"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [task]",
+	Short: "Show the tasks opentask created, changed and deleted",
+	Long: `Show the audit log of the changes opentask made to tasks, newest first.
+
+Every create, update, close, delete and archive, and every copy made with
+--sync-to, is appended to ~/.opentask/audit.log with the time, where it was
+done from (cli, tui or serve), the local user, and each task's platform,
+ID, previous status and changed fields. Tasks the operation failed for are
+recorded with the error, so a bulk command that went wrong can be reviewed
+and undone.
+
+Pass a task, such as API-123 or jira:API-123, to show only its history.
+
+Examples:
+  opentask history
+  opentask history jira:API-123
+  opentask history --action delete --since 7d
+  opentask history --failed --format json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHistory,
+}
+
+var (
+	historyPlatform string
+	historyAction   string
+	historySince    string
+	historyLimit    int
+	historyFailed   bool
+	historyFormat   string
+)
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().StringVarP(&historyPlatform, "platform", "p", "", "only show tasks on this platform")
+	historyCmd.Flags().StringVarP(&historyAction, "action", "a", "", "only show this action (create, update, close, delete, archive, sync)")
+	historyCmd.Flags().StringVar(&historySince, "since", "", "only show changes made within this long, such as 24h, 7d or 2w")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "l", 50, "maximum number of tasks to show (0 for all)")
+	historyCmd.Flags().BoolVar(&historyFailed, "failed", false, "only show tasks the operation failed for")
+	historyCmd.Flags().StringVarP(&historyFormat, "format", "f", "table", "output format (table, json)")
+}
+
+// auditActions are the actions --action accepts.
+var auditActions = []string{store.AuditCreate, store.AuditUpdate, store.AuditClose, store.AuditDelete, store.AuditArchive, store.AuditSync}
+
+// historyRow is one task of an audit entry.
+type historyRow struct {
+	Time   time.Time       `json:"time"`
+	Action string          `json:"action"`
+	Source string          `json:"source"`
+	Actor  string          `json:"actor,omitempty"`
+	Task   store.AuditTask `json:"task"`
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	if historyFormat != "table" && historyFormat != "json" {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("unsupported format %q (use table or json)", historyFormat))
+	}
+	if historyAction != "" && !slices.Contains(auditActions, strings.ToLower(historyAction)) {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("unknown action %q (use %s)", historyAction, strings.Join(auditActions, ", ")))
+	}
+	var since time.Time
+	if historySince != "" {
+		age, err := rules.ParseAge(historySince)
+		if err != nil {
+			return exitcode.Wrap(exitcode.Usage, fmt.Errorf("invalid --since: %w", err))
+		}
+		since = time.Now().Add(-age)
+	}
+
+	st, err := store.Open()
+	if err != nil {
+		return err
+	}
+	entries, err := st.ReadAudit()
+	if err != nil {
+		return err
+	}
+
+	var ref string
+	if len(args) == 1 {
+		ref = args[0]
+	}
+
+	var rows []historyRow
+	for _, entry := range slices.Backward(entries) {
+		if entry.Time.Before(since) || (historyAction != "" && !strings.EqualFold(entry.Action, historyAction)) {
+			continue
+		}
+		for _, task := range entry.Tasks {
+			if historyPlatform != "" && !strings.EqualFold(task.Platform, historyPlatform) {
+				continue
+			}
+			if (historyFailed && task.Error == "") || (ref != "" && !matchesTaskRef(task, ref)) {
+				continue
+			}
+			rows = append(rows, historyRow{Time: entry.Time, Action: entry.Action, Source: entry.Source, Actor: entry.Actor, Task: task})
+		}
+	}
+	if historyLimit > 0 && len(rows) > historyLimit {
+		rows = rows[:historyLimit]
+	}
+
+	if historyFormat == "json" {
+		if rows == nil {
+			rows = []historyRow{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("No changes recorded")
+		return nil
+	}
+
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(styles.Current().Accent)).
+		Headers("WHEN", "ACTION", "SOURCE", "ACTOR", "TASK", "TITLE", "CHANGES")
+	for _, row := range rows {
+		task := row.Task.ID
+		if task == "" {
+			task = "-"
+		}
+		t.Row(humanize.Time(row.Time), row.Action, row.Source, row.Actor,
+			row.Task.Platform+":"+task, row.Task.Title, describeAuditTask(row.Task))
+	}
+	fmt.Println(t)
+	return nil
+}
+
+// matchesTaskRef reports whether task is the one ref names, such as
+// "API-123" or "jira:API-123".
+func matchesTaskRef(task store.AuditTask, ref string) bool {
+	if platform, id, ok := strings.Cut(ref, ":"); ok {
+		return strings.EqualFold(task.Platform, platform) && strings.EqualFold(task.ID, id)
+	}
+	return strings.EqualFold(task.ID, ref)
+}
+
+// describeAuditTask summarizes what happened to a task, such as
+// "status open → done, priority low → high", or the first line of the
+// error it failed with.
+func describeAuditTask(task store.AuditTask) string {
+	if task.Error != "" {
+		// Platforms can answer with a whole HTML page.
+		line, _, _ := strings.Cut(task.Error, "\n")
+		return "✗ " + line
+	}
+	parts := make([]string, 0, len(task.Changes))
+	for _, change := range task.Changes {
+		if change.From == "" && change.To == "" {
+			parts = append(parts, change.Field+" changed")
+			continue
+		}
+		from, to := change.From, change.To
+		if from == "" {
+			from = "none"
+		}
+		if to == "" {
+			to = "none"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s → %s", change.Field, from, to))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// recordAudit appends an operation to the audit log, warning if it cannot
+// be written.
+func recordAudit(action string, tasks []store.AuditTask) {
+	if err := store.Audit(action, "cli", tasks); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Failed to write the audit log: %v\n", err)
+	}
+}
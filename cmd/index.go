@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/models"
+	"opentask/pkg/progress"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+	"opentask/pkg/terminal"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Manage the local search index",
+	Long: `Manage the local full-text index of tasks from all enabled platforms.
+
+'index rebuild' fetches every task again. 'index update' only fetches tasks
+changed since the last rebuild or update, so it is cheap to run often (for
+example from cron); tasks deleted on a platform stay in the index until the
+next rebuild.`,
+}
+
+var indexRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Rebuild the search index from all platforms",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runIndex(cmd.Flags(), true)
+	},
+}
+
+var indexUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Add tasks changed since the last update to the search index",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runIndex(cmd.Flags(), false)
+	},
+}
+
+var indexSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search the local index",
+	Long: `Search the title, description, ID, labels and assignee of indexed tasks.
+Every word must match; the last one may be the start of a word.
+
+Examples:
+  opentask index search "rate limit"
+  opentask index search login --limit 5`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runIndexSearch,
+}
+
+var (
+	indexLimit       int
+	indexSearchLimit int
+)
+
+// indexSyncOverlap is how far before the last sync point an update starts.
+// Jira compares update times in the user's profile timezone at minute
+// precision, so the overlap covers any offset; tasks seen twice are
+// replaced, not duplicated.
+const indexSyncOverlap = 24 * time.Hour
+
+func init() {
+	rootCmd.AddCommand(indexCmd)
+	indexCmd.AddCommand(indexRebuildCmd)
+	indexCmd.AddCommand(indexUpdateCmd)
+	indexCmd.AddCommand(indexSearchCmd)
+
+	indexCmd.PersistentFlags().IntVar(&indexLimit, "limit", 1000, "maximum number of tasks to fetch per platform")
+	indexSearchCmd.Flags().IntVarP(&indexSearchLimit, "limit", "n", 20, "maximum number of results")
+}
+
+func runIndex(flags *pflag.FlagSet, rebuild bool) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(flags); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+
+	names := cfg.GetEnabledPlatforms()
+	if len(names) == 0 {
+		return fmt.Errorf("no platforms configured or enabled")
+	}
+	sort.Strings(names)
+
+	interactive, err := terminal.Interactive(cfg.UI.Interactive)
+	if err != nil {
+		return err
+	}
+
+	s, err := store.Open()
+	if err != nil {
+		return err
+	}
+
+	index, err := s.LoadSearchIndex()
+	if err != nil {
+		return fmt.Errorf("failed to load search index: %w", err)
+	}
+
+	label := "Updating index"
+	if rebuild {
+		label = "Rebuilding index"
+	}
+	tracker := progress.New(os.Stdout, label, len(names), interactive)
+
+	for _, name := range names {
+		platform, _ := cfg.GetPlatform(name)
+
+		client, err := service.NewClient(name, platform)
+		if err != nil {
+			tracker.Done(name, err)
+			continue
+		}
+
+		filter := &models.TaskFilter{Limit: indexLimit}
+		synced, incremental := index.Synced[name]
+		if rebuild || !incremental {
+			incremental = false
+		} else {
+			since := synced.Add(-indexSyncOverlap)
+			filter.UpdatedSince = &since
+		}
+
+		ctx, cancel := service.WithRequestTimeout(context.Background())
+		tasks, err := client.ListTasks(ctx, filter)
+		cancel()
+		if err != nil {
+			tracker.Done(name, err)
+			continue
+		}
+
+		if !incremental {
+			index.ResetPlatform(name)
+		}
+		for _, task := range tasks {
+			index.Add(name, task)
+		}
+
+		if incremental {
+			tracker.Printf("~ %s: %d task(s) changed since %s\n", name, len(tasks), synced.Local().Format("2006-01-02 15:04"))
+		} else {
+			tracker.Printf("+ %s: %d task(s) indexed\n", name, len(tasks))
+		}
+		tracker.Done(name, nil)
+	}
+	tracker.Finish()
+
+	if rebuild || index.BuiltAt.IsZero() {
+		index.BuiltAt = time.Now()
+	}
+	if err := s.SaveSearchIndex(index); err != nil {
+		return fmt.Errorf("failed to save search index: %w", err)
+	}
+
+	fmt.Printf("\n✓ Search index holds %d task(s)\n", index.Len())
+	if failed := len(tracker.Failures()); failed > 0 {
+		return exitcode.WrapPartial(failed, len(names), fmt.Errorf("%d platform(s) could not be indexed", failed))
+	}
+	return nil
+}
+
+func runIndexSearch(cmd *cobra.Command, args []string) error {
+	s, err := store.Open()
+	if err != nil {
+		return err
+	}
+
+	index, err := s.LoadSearchIndex()
+	if err != nil {
+		return fmt.Errorf("failed to load search index: %w", err)
+	}
+	if index.Len() == 0 {
+		return fmt.Errorf("the search index is empty. Run 'opentask index rebuild' first")
+	}
+
+	results := index.Search(strings.Join(args, " "), indexSearchLimit)
+	if len(results) == 0 {
+		fmt.Println("No matching tasks")
+		return nil
+	}
+
+	for _, result := range results {
+		task := result.Task
+		fmt.Printf("%-12s %-8s %-12s %s\n", task.ID, task.Platform, task.Status, task.Title)
+	}
+	return nil
+}
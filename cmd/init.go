@@ -82,6 +82,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 }
 
 func getConfigPath() string {
+	if cfgFile != "" {
+		return cfgFile
+	}
 	if initGlobal {
 		home, err := os.UserHomeDir()
 		if err != nil {
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"opentask/pkg/config"
+	"opentask/pkg/logging"
+
+	"github.com/spf13/cobra"
+)
+
+var closeLog = func() error { return nil }
+
+// startLogging sets up the log from --verbose, --debug and --log-file, or
+// else log.level and log.file. Logging problems never stop the command.
+// Credentials are hidden from everything logged and from error messages.
+func startLogging(cmd *cobra.Command, cfg *config.Config) {
+	logging.AddSecrets(knownSecrets(cfg)...)
+
+	opts := logging.Options{File: cfg.Log.File}
+	switch cfg.Log.Level {
+	case "":
+	case "verbose":
+		opts.Verbose = true
+	case "debug":
+		opts.Debug = true
+	default:
+		fmt.Fprintf(os.Stderr, "⚠ invalid log.level %q; use verbose or debug\n", cfg.Log.Level)
+	}
+
+	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+		opts.Verbose = true
+	}
+	if debug, _ := cmd.Flags().GetBool("debug"); debug {
+		opts.Debug = true
+	}
+	if file, _ := cmd.Flags().GetString("log-file"); file != "" {
+		opts.File = file
+	}
+
+	closeFile, err := logging.Setup(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Logging disabled: %v\n", err)
+		return
+	}
+	closeLog = closeFile
+
+	logging.Logger().Info("command started", "command", cmd.CommandPath())
+}
+
+// finishLogging closes the log file, if any.
+func finishLogging() {
+	if err := closeLog(); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Failed to close log file: %v\n", err)
+	}
+}
+
+// knownSecrets returns the credentials of every configured platform and
+// the values of environment variables that look like credentials.
+func knownSecrets(cfg *config.Config) []string {
+	secrets := cfg.Secrets()
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		upper := strings.ToUpper(name)
+		for _, marker := range []string{"TOKEN", "SECRET", "PASSWORD", "API_KEY", "ACCESS_KEY"} {
+			if strings.Contains(upper, marker) {
+				secrets = append(secrets, value)
+				break
+			}
+		}
+	}
+	return secrets
+}
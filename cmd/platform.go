@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"opentask/cmd/completion"
+	"opentask/pkg/config"
+	"opentask/pkg/platforms"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var platformCmd = &cobra.Command{
+	Use:   "platform",
+	Short: "Manage connected platforms",
+	Long: `Manage the platforms added with 'opentask connect'.
+
+A disabled platform keeps its credentials and settings but is left out of
+every command until it is enabled again.`,
+}
+
+var platformListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List supported platforms and their connections",
+	Long: `List every platform OpenTask supports and which of them are connected.
+
+Examples:
+  opentask platform list`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager := config.NewManager()
+		if err := manager.LoadFlags(cmd.Flags()); err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		printPlatforms(manager.GetConfig())
+		return nil
+	},
+}
+
+var platformEnableCmd = &cobra.Command{
+	Use:   "enable <platform>",
+	Short: "Use a disabled platform again",
+	Long: `Enable a platform disabled with 'opentask platform disable'.
+
+Examples:
+  opentask platform enable jira`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.ConfiguredPlatforms,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPlatformEnabled(cmd.Flags(), args[0], true)
+	},
+}
+
+var platformDisableCmd = &cobra.Command{
+	Use:   "disable <platform>",
+	Short: "Stop using a platform without removing it",
+	Long: `Disable a platform, keeping its credentials and settings.
+
+Examples:
+  opentask platform disable jira`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.ConfiguredPlatforms,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPlatformEnabled(cmd.Flags(), args[0], false)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(platformCmd)
+	platformCmd.AddCommand(platformListCmd)
+	platformCmd.AddCommand(platformEnableCmd)
+	platformCmd.AddCommand(platformDisableCmd)
+}
+
+func setPlatformEnabled(flags *pflag.FlagSet, name string, enabled bool) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(flags); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg := manager.GetConfig()
+
+	platform, exists := cfg.GetPlatform(name)
+	if !exists {
+		return fmt.Errorf("platform %s not configured. Use 'opentask connect %s' first", name, name)
+	}
+
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+	if platform.Enabled == enabled {
+		fmt.Printf("Platform %s is already %s.\n", name, state)
+		return nil
+	}
+
+	platform.Enabled = enabled
+	cfg.AddPlatform(name, platform)
+	if err := manager.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Printf("✓ Platform %s %s\n", name, state)
+	if !enabled && cfg.Defaults.Platform == name {
+		fmt.Printf("⚠ %s is still the default platform. Commands that use the default platform will fail until it is enabled again.\n", name)
+	}
+	return nil
+}
+
+// printPlatforms lists the platform types in the registry with the
+// configured platforms of each type.
+func printPlatforms(cfg *config.Config) {
+	connected := make(map[string][]string)
+	for name, platform := range cfg.Platforms {
+		state := name
+		if !platform.Enabled {
+			state += " (disabled)"
+		}
+		connected[platform.Type] = append(connected[platform.Type], state)
+	}
+
+	fmt.Println("Available platforms:")
+	for _, platformType := range platforms.DefaultRegistry.GetSupportedPlatforms() {
+		factory, _ := platforms.DefaultRegistry.GetFactory(platformType)
+		description := factory.GetName()
+		if describer, ok := factory.(platforms.Describer); ok {
+			description = describer.GetDescription()
+		}
+
+		status := "not connected"
+		if names := connected[platformType]; len(names) > 0 {
+			sort.Strings(names)
+			status = "connected: " + strings.Join(names, ", ")
+		}
+		fmt.Printf("  %-8s %s\n", platformType, description)
+		fmt.Printf("  %-8s %s\n", "", status)
+		delete(connected, platformType)
+	}
+
+	unsupported := make([]string, 0, len(connected))
+	for platformType, names := range connected {
+		for _, name := range names {
+			unsupported = append(unsupported, fmt.Sprintf("%s (type %q)", name, platformType))
+		}
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		fmt.Println()
+		fmt.Printf("⚠ Not supported by this build: %s\n", strings.Join(unsupported, ", "))
+	}
+}
@@ -0,0 +1,128 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"opentask/cmd/completion"
+	"opentask/pkg/config"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+	"opentask/pkg/styles"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/spf13/cobra"
+)
+
+var boardColumnsCmd = &cobra.Command{
+	Use:   "board-columns [project]",
+	Short: "Show a project's workflow states and their status mapping",
+	Long: `Show the workflow columns/states configured for a project on its
+platform and the unified status (open, in_progress, done, cancelled) each
+one maps to.
+
+Use this to write the platform's status_map setting, which overrides the
+mapping for individual states:
+
+  platforms:
+    jira:
+      settings:
+        status_map:
+          "In Review": in_progress
+          "Won't Do": cancelled
+
+For Jira the project is a project key; for Linear it is a team key.
+If no project is given, the default project is used.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completion.Projects,
+	RunE:              runBoardColumns,
+}
+
+var boardColumnsPlatform string
+
+func init() {
+	boardColumnsCmd.Flags().StringVarP(&boardColumnsPlatform, "platform", "p", "", "platform to inspect (default: configured default)")
+}
+
+func runBoardColumns(cmd *cobra.Command, args []string) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+
+	platformName := boardColumnsPlatform
+	if platformName == "" {
+		platformName = cfg.Defaults.Platform
+	}
+	if platformName == "" {
+		if enabled := cfg.GetEnabledPlatforms(); len(enabled) > 0 {
+			platformName = enabled[0]
+		}
+	}
+	if platformName == "" {
+		return fmt.Errorf("no platforms configured or enabled")
+	}
+
+	projectID := cfg.DefaultProject(platformName)
+	if len(args) > 0 {
+		projectID = cfg.ResolveProject(args[0])
+	}
+
+	platform, exists := cfg.GetPlatform(platformName)
+	if !exists {
+		return fmt.Errorf("platform %s not configured", platformName)
+	}
+
+	client, err := service.NewClient(platformName, platform)
+	if err != nil {
+		return err
+	}
+
+	provider, ok := client.(platforms.WorkflowProvider)
+	if !ok {
+		return fmt.Errorf("%s does not support listing workflow states", platformName)
+	}
+
+	ctx, cancel := service.WithRequestTimeout(context.Background())
+	defer cancel()
+
+	states, err := provider.ListWorkflowStates(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	if len(states) == 0 {
+		fmt.Println("No workflow states found.")
+		return nil
+	}
+
+	scopeHeader := "ISSUE TYPES"
+	if platform.Type == "linear" {
+		scopeHeader = "TEAM"
+	}
+
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(styles.Current().Accent)).
+		Headers("STATE", "CATEGORY", scopeHeader, "STATUS", "SOURCE")
+
+	for _, state := range states {
+		source := "default"
+		if state.Mapped {
+			source = "status_map"
+		}
+		t.Row(state.Name, state.Category, state.Scope, state.Status.String(), source)
+	}
+
+	title := platformName
+	if projectID != "" {
+		title = fmt.Sprintf("%s / %s", platformName, projectID)
+	}
+	fmt.Printf("Workflow states for %s\n", title)
+	fmt.Println(t)
+
+	return nil
+}
@@ -1,43 +1,228 @@
 package project
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
 
+	"opentask/cmd/completion"
 	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/models"
+	"opentask/pkg/output"
+	"opentask/pkg/service"
 
 	"github.com/spf13/cobra"
 )
 
 var getCmd = &cobra.Command{
-	Use:   "get",
-	Short: "Get current default project",
-	Long: `Display the current default project configuration.
-	
-Shows the project ID that is currently set as the default for 
-the current workspace.`,
-	RunE: runProjectGet,
+	Use:   "get [project-id]",
+	Short: "Show a project",
+	Long: `Show a project's details from its platform: name, key, platform, web
+URL, lead and how many tasks are open and in progress.
+
+Without a project ID the default project of --platform, of the default
+platform or of the first platform that has one is shown.
+
+Examples:
+  opentask project get
+  opentask project get TEST --platform jira
+  opentask project get TEST --format json
+  opentask project get TEST --template '{{.Name}}: {{.Open}} open'`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completion.Projects,
+	RunE:              runProjectGet,
+}
+
+var (
+	getPlatform string
+	getFormat   string
+	getOutput   output.Options
+)
+
+// countLimit bounds how many tasks are listed to count a project's open
+// work; larger counts are shown as at least countLimit.
+const countLimit = 500
+
+func init() {
+	getCmd.Flags().StringVarP(&getPlatform, "platform", "p", "", "specify platform for project lookup")
+	getCmd.Flags().StringVarP(&getFormat, "format", "f", "text", "output format (text, json)")
+	getOutput.AddFlags(getCmd.Flags())
+	getCmd.MarkFlagsMutuallyExclusive("format", "template", "jsonpath")
+}
+
+// projectDetails is a project as 'project get --format json' prints it.
+type projectDetails struct {
+	ID          string       `json:"id"`
+	Key         string       `json:"key,omitempty"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Platform    string       `json:"platform"`
+	Type        string       `json:"type"`
+	URL         string       `json:"url,omitempty"`
+	Lead        *models.User `json:"lead,omitempty"`
+	Open        int          `json:"open"`
+	InProgress  int          `json:"in_progress"`
+	// Truncated is set when a count reached countLimit.
+	Truncated bool `json:"truncated,omitempty"`
+	Default   bool `json:"default"`
 }
 
 func runProjectGet(cmd *cobra.Command, args []string) error {
-	manager := config.NewManager()
-	if err := manager.Load(""); err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+	if getFormat != "text" && getFormat != "json" {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("unknown format %q (use text or json)", getFormat))
+	}
+	formatter, err := getOutput.Formatter()
+	if err != nil {
+		return err
+	}
+
+	svc, err := service.Load(cmd.Flags())
+	if err != nil {
+		return err
 	}
+	cfg := svc.Config()
 
-	cfg := manager.GetConfig()
+	var names []string
+	if getPlatform != "" {
+		names = []string{getPlatform}
+	}
 
-	if cfg.Defaults.Project == "" {
+	var projectID string
+	if len(args) > 0 {
+		projectID = cfg.ResolveProject(args[0])
+	} else {
+		platformName := getPlatform
+		if platformName == "" {
+			platformName = defaultProjectPlatform(cfg)
+		}
+		if platformName != "" {
+			projectID = cfg.DefaultProject(platformName)
+			names = []string{platformName}
+		}
+	}
+	if projectID == "" {
 		fmt.Println("No default project is currently set.")
 		fmt.Println("Use 'opentask project set <project-id>' to set a default project.")
 		return nil
 	}
 
-	fmt.Printf("Default project: %s\n", cfg.Defaults.Project)
+	ctx := context.Background()
+	name, project, failures, err := svc.Projects.Find(ctx, names, projectID)
+	failures.Report(os.Stderr, "get project")
+	if err != nil {
+		return err
+	}
 
-	// Show workspace info
-	if cfg.Workspace != "" {
-		fmt.Printf("Workspace: %s\n", cfg.Workspace)
+	details := projectDetails{
+		ID:          project.ID,
+		Key:         project.Key,
+		Name:        project.Name,
+		Description: project.Description,
+		Platform:    name,
+		Type:        string(project.Platform),
+		URL:         projectURL(project),
+		Lead:        project.Lead,
+		Default:     project.Key == cfg.DefaultProject(name) || project.ID == cfg.DefaultProject(name),
 	}
+	details.Open, details.InProgress, details.Truncated = countOpenTasks(ctx, svc, name, project)
 
+	if formatter != nil {
+		return formatter.Write(os.Stdout, details)
+	}
+	if getFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(details)
+	}
+	printProjectDetails(details)
+	if cfg.Workspace != "" {
+		fmt.Printf("Workspace:   %s\n", cfg.Workspace)
+	}
 	return nil
 }
+
+// defaultProjectPlatform returns the platform whose default project
+// 'project get' shows without arguments: the default platform, or else the
+// first enabled platform with a default project.
+func defaultProjectPlatform(cfg *config.Config) string {
+	if cfg.Defaults.Platform != "" && cfg.DefaultProject(cfg.Defaults.Platform) != "" {
+		return cfg.Defaults.Platform
+	}
+	names := cfg.GetEnabledPlatforms()
+	sort.Strings(names)
+	for _, name := range names {
+		if cfg.DefaultProject(name) != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// countOpenTasks counts the project's open and in-progress tasks. A count
+// that cannot be listed is reported and left at zero.
+func countOpenTasks(ctx context.Context, svc *service.Service, name string, project *models.Project) (int, int, bool) {
+	filter := project.Key
+	if filter == "" {
+		filter = project.ID
+	}
+
+	counts := make([]int, 2)
+	truncated := false
+	for i, status := range []models.TaskStatus{models.StatusOpen, models.StatusInProgress} {
+		list := svc.Tasks.List(ctx, []string{name}, &models.TaskFilter{ProjectID: filter, Status: &status, Limit: countLimit})
+		list.Failures.Report(os.Stderr, "count tasks")
+		counts[i] = len(list.Tasks)
+		truncated = truncated || counts[i] >= countLimit
+	}
+	return counts[0], counts[1], truncated
+}
+
+func printProjectDetails(details projectDetails) {
+	title := details.Name
+	if details.Key != "" {
+		title = fmt.Sprintf("%s (%s)", details.Name, details.Key)
+	}
+	if details.Default {
+		title += " — default project"
+	}
+	fmt.Println(title)
+	if details.Description != "" {
+		fmt.Println(details.Description)
+	}
+	fmt.Println()
+
+	fmt.Printf("ID:          %s\n", details.ID)
+	fmt.Printf("Platform:    %s (%s)\n", details.Platform, details.Type)
+	if details.URL != "" {
+		fmt.Printf("URL:         %s\n", details.URL)
+	}
+	if details.Lead != nil {
+		fmt.Printf("Lead:        %s\n", details.Lead.Name)
+	}
+	fmt.Printf("Open:        %s\n", countString(details.Open))
+	fmt.Printf("In progress: %s\n", countString(details.InProgress))
+}
+
+func countString(n int) string {
+	if n >= countLimit {
+		return strconv.Itoa(countLimit) + "+"
+	}
+	return strconv.Itoa(n)
+}
+
+// projectURL returns the web URL of a project, if the platform exposes
+// one.
+func projectURL(project *models.Project) string {
+	for _, key := range []string{"linear_url", "jira_url"} {
+		if url, ok := project.GetMetadata(key); ok {
+			if s, ok := url.(string); ok && s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
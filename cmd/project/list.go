@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"time"
 
 	"opentask/pkg/config"
 	"opentask/pkg/models"
-	"opentask/pkg/platforms"
+	"opentask/pkg/output"
+	"opentask/pkg/service"
+	"opentask/pkg/styles"
+	"opentask/pkg/terminal"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -21,65 +23,75 @@ var listCmd = &cobra.Command{
 	Short: "List projects",
 	Long: `List projects from configured platforms.
 	
-You can filter projects by platform or show projects from all enabled platforms.`,
+You can filter projects by platform or show projects from all enabled platforms.
+
+Listed projects are kept in ~/.opentask/projects.json for a day, which
+--project completion uses too. Use --refresh to list them again.
+
+--quiet prints only the key of each project (its ID when it has none),
+one per line, as --project accepts it.`,
 	RunE: runProjectList,
 }
 
 var (
 	listPlatform string
 	listFormat   string
+	listOutput   output.Options
 	listPlain    bool
+	listRefresh  bool
+	listQuiet    bool
 )
 
 func init() {
 	listCmd.Flags().StringVarP(&listPlatform, "platform", "p", "", "filter by platform")
 	listCmd.Flags().StringVarP(&listFormat, "format", "f", "table", "output format (table, json, csv)")
+	listOutput.AddFlags(listCmd.Flags())
 	listCmd.Flags().BoolVar(&listPlain, "plain", false, "disable interactive mode and output plain text")
+	listCmd.Flags().BoolVar(&listRefresh, "refresh", false, "list projects from the platforms instead of the cache")
+	listCmd.Flags().BoolVarP(&listQuiet, "quiet", "q", false, "print only project keys, one per line")
+	listCmd.MarkFlagsMutuallyExclusive("format", "template", "jsonpath", "quiet")
 }
 
 func runProjectList(cmd *cobra.Command, args []string) error {
-	manager := config.NewManager()
-	if err := manager.Load(""); err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+	svc, err := service.Load(cmd.Flags())
+	if err != nil {
+		return err
 	}
+	cfg := svc.Config()
 
-	cfg := manager.GetConfig()
+	formatter, err := listOutput.Formatter()
+	if err != nil {
+		return err
+	}
 
 	platforms := determinePlatformsForProjectList(cfg)
 	if len(platforms) == 0 {
 		return fmt.Errorf("no platforms configured or enabled")
 	}
 
-	var allProjects []*models.Project
-
-	for _, platformName := range platforms {
-		platform, exists := cfg.GetPlatform(platformName)
-		if !exists {
-			continue
-		}
-
-		if !platform.Enabled {
-			continue
-		}
-
-		// Create platform client
-		client, err := createPlatformClient(platformName, platform)
-		if err != nil {
-			fmt.Printf("⚠ Failed to create %s client: %v\n", platformName, err)
-			continue
+	list := svc.Projects.List
+	if listRefresh {
+		list = svc.Projects.Refresh
+	}
+	projects := list(context.Background(), platforms)
+	if listQuiet {
+		projects.Failures.Report(os.Stderr, "list projects")
+		for _, project := range projects.Projects {
+			fmt.Println(projectRef(project))
 		}
-
-		// Fetch projects from platform
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-
-		projects, err := client.ListProjects(ctx)
-		if err != nil {
-			fmt.Printf("⚠ Failed to list projects from %s: %v\n", platformName, err)
-			continue
+		return nil
+	}
+	projects.Failures.Report(os.Stdout, "list projects")
+	allProjects := projects.Projects
+
+	if formatter != nil {
+		list := formatter.List(os.Stdout)
+		for _, project := range allProjects {
+			if err := list.Add(project); err != nil {
+				return err
+			}
 		}
-
-		allProjects = append(allProjects, projects...)
+		return list.Close()
 	}
 
 	if len(allProjects) == 0 {
@@ -93,10 +105,19 @@ func runProjectList(cmd *cobra.Command, args []string) error {
 	case "csv":
 		return printProjectsCSV(allProjects)
 	default:
-		return printProjectsTable(allProjects)
+		return printProjectsTable(cfg, allProjects)
 	}
 }
 
+// projectRef is what --project accepts for project: its key, or its ID
+// on platforms without keys.
+func projectRef(project *models.Project) string {
+	if project.Key != "" {
+		return project.Key
+	}
+	return project.ID
+}
+
 func determinePlatformsForProjectList(cfg *config.Config) []string {
 	if listPlatform != "" {
 		return []string{listPlatform}
@@ -106,8 +127,14 @@ func determinePlatformsForProjectList(cfg *config.Config) []string {
 	return cfg.GetEnabledPlatforms()
 }
 
-func printProjectsTable(projects []*models.Project) error {
-	if listPlain {
+func printProjectsTable(cfg *config.Config, projects []*models.Project) error {
+	interactive, err := terminal.Interactive(cfg.UI.Interactive)
+	if err != nil {
+		return err
+	}
+
+	// Print the table directly when the output is piped or --plain is set.
+	if listPlain || !interactive {
 		return printProjectsPlainTable(projects)
 	}
 
@@ -124,7 +151,7 @@ func printProjectsPlainTable(projects []*models.Project) error {
 	// Create table
 	t := table.New().
 		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
+		BorderStyle(lipgloss.NewStyle().Foreground(styles.Current().Accent)).
 		Headers("ID", "KEY", "NAME", "PLATFORM", "ACTIVE")
 
 	for _, project := range projects {
@@ -226,13 +253,10 @@ func (m ProjectListModel) View() string {
 	// Create table
 	t := table.New().
 		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
+		BorderStyle(lipgloss.NewStyle().Foreground(styles.Current().Accent)).
 		Headers("ID", "KEY", "NAME", "PLATFORM", "ACTIVE")
 
-	selectedStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("212")).
-		Background(lipgloss.Color("57")).
-		Bold(true)
+	selectedStyle := styles.SelectedRow().Bold(true)
 
 	for i, project := range m.projects {
 		activeStr := "✓"
@@ -263,27 +287,3 @@ func (m ProjectListModel) View() string {
 
 	return s
 }
-
-// Helper function to create platform client (copied from task package)
-func createPlatformClient(platformName string, platform config.Platform) (platforms.PlatformClient, error) {
-	// Prepare configuration for platform factory
-	clientConfig := make(map[string]any)
-
-	// Copy credentials
-	for key, value := range platform.Credentials {
-		clientConfig[key] = value
-	}
-
-	// Copy settings
-	for key, value := range platform.Settings {
-		clientConfig[key] = value
-	}
-
-	// Create client using registry
-	client, err := platforms.DefaultRegistry.Create(platform.Type, clientConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create %s client: %w", platformName, err)
-	}
-
-	return client, nil
-}
@@ -19,4 +19,5 @@ func init() {
 	ProjectCmd.AddCommand(setCmd)
 	ProjectCmd.AddCommand(getCmd)
 	ProjectCmd.AddCommand(unsetCmd)
+	ProjectCmd.AddCommand(boardColumnsCmd)
 }
@@ -3,11 +3,12 @@ package project
 import (
 	"context"
 	"fmt"
-	"strings"
-	"time"
+	"os"
 
+	"opentask/cmd/completion"
 	"opentask/pkg/config"
-	"opentask/pkg/platforms"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/service"
 
 	"github.com/spf13/cobra"
 )
@@ -15,13 +16,18 @@ import (
 var setCmd = &cobra.Command{
 	Use:   "set <project-id>",
 	Short: "Set default project",
-	Long: `Set the default project for the current workspace.
+	Long: `Set the default project of a platform.
 	
 The project ID should be a valid project identifier from one of your 
 configured platforms. You can use "opentask project list" to see 
-available projects.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runProjectSet,
+available projects.
+
+Each platform keeps its own default project, since project IDs differ
+between platforms. The project is set for --platform, or else for the
+platform it was found on.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.Projects,
+	RunE:              runProjectSet,
 }
 
 var (
@@ -30,29 +36,42 @@ var (
 )
 
 func init() {
-	setCmd.Flags().StringVarP(&setPlatform, "platform", "p", "", "specify platform for project lookup")
+	setCmd.Flags().StringVarP(&setPlatform, "platform", "p", "", "platform to set the default project of")
 	setCmd.Flags().BoolVar(&setValidate, "validate", true, "validate project exists before setting")
 }
 
 func runProjectSet(cmd *cobra.Command, args []string) error {
-	projectID := args[0]
-
 	manager := config.NewManager()
-	if err := manager.Load(""); err != nil {
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
 	cfg := manager.GetConfig()
+	projectID := cfg.ResolveProject(args[0])
 
+	platformName := setPlatform
 	// Validate project exists if validation is enabled
 	if setValidate {
-		if err := validateProjectExists(cfg, projectID, setPlatform); err != nil {
+		found, err := validateProjectExists(cfg, projectID, setPlatform)
+		if err != nil {
 			return fmt.Errorf("project validation failed: %w", err)
 		}
+		platformName = found
+	}
+	if platformName == "" {
+		platformName = cfg.Defaults.Platform
+	}
+	if platformName == "" {
+		if enabled := cfg.GetEnabledPlatforms(); len(enabled) == 1 {
+			platformName = enabled[0]
+		}
+	}
+	if platformName == "" {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("choose the platform to set the default project of with --platform"))
 	}
 
 	// Set the default project
-	cfg.Defaults.Project = projectID
+	cfg.SetDefaultProject(platformName, projectID)
 
 	// Update the configuration
 	manager.SetConfig(cfg)
@@ -62,90 +81,26 @@ func runProjectSet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
 
-	fmt.Printf("✓ Default project set to: %s\n", projectID)
+	fmt.Printf("✓ Default project of %s set to: %s\n", platformName, projectID)
 	return nil
 }
 
-func validateProjectExists(cfg *config.Config, projectID string, platformFilter string) error {
-	platforms := cfg.GetEnabledPlatforms()
-
+// validateProjectExists looks projectID up and returns the platform it was
+// found on.
+func validateProjectExists(cfg *config.Config, projectID string, platformFilter string) (string, error) {
+	var platforms []string
 	if platformFilter != "" {
 		platforms = []string{platformFilter}
 	}
 
-	if len(platforms) == 0 {
-		return fmt.Errorf("no platforms configured or enabled")
-	}
-
-	for _, platformName := range platforms {
-		platform, exists := cfg.GetPlatform(platformName)
-		if !exists {
-			continue
-		}
-
-		if !platform.Enabled {
-			continue
-		}
-
-		// Create platform client
-		client, err := createPlatformClientForProject(platformName, platform)
-		if err != nil {
-			fmt.Printf("⚠ Failed to create %s client: %v\n", platformName, err)
-			continue
-		}
-
-		// Check if project exists
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-		defer cancel()
-
-		project, err := client.GetProject(ctx, projectID)
-		if err != nil {
-			// Check if it's a "not found" error
-			if isNotFoundError(err) {
-				continue // Try next platform
-			}
-			fmt.Printf("⚠ Failed to check project in %s: %v\n", platformName, err)
-			continue
-		}
-
-		if project != nil {
-			fmt.Printf("✓ Project found: %s (%s) on %s\n", project.DisplayName(), project.Name, platformName)
-			return nil
-		}
-	}
-
-	return fmt.Errorf("project '%s' not found in any configured platform", projectID)
-}
-
-func isNotFoundError(err error) bool {
-	// Check if the error is a "not found" type error
-	// This would need to be implemented based on your platform error types
-	errorMsg := strings.ToLower(err.Error())
-	return strings.Contains(errorMsg, "not found") ||
-		strings.Contains(errorMsg, "404") ||
-		strings.Contains(errorMsg, "does not exist")
-}
-
-// Helper function to create platform client
-func createPlatformClientForProject(platformName string, platform config.Platform) (platforms.PlatformClient, error) {
-	// Prepare configuration for platform factory
-	clientConfig := make(map[string]any)
-
-	// Copy credentials
-	for key, value := range platform.Credentials {
-		clientConfig[key] = value
-	}
-
-	// Copy settings
-	for key, value := range platform.Settings {
-		clientConfig[key] = value
-	}
+	svc := service.New(cfg)
 
-	// Create client using registry
-	client, err := platforms.DefaultRegistry.Create(platform.Type, clientConfig)
+	platformName, project, failures, err := svc.Projects.Find(context.Background(), platforms, projectID)
+	failures.Report(os.Stdout, "get project")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create %s client: %w", platformName, err)
+		return "", err
 	}
 
-	return client, nil
+	fmt.Printf("✓ Project found: %s (%s) on %s\n", project.DisplayName(), project.Name, platformName)
+	return platformName, nil
 }
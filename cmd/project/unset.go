@@ -2,6 +2,7 @@ package project
 
 import (
 	"fmt"
+	"sort"
 
 	"opentask/pkg/config"
 
@@ -11,30 +12,48 @@ import (
 var unsetCmd = &cobra.Command{
 	Use:   "unset",
 	Short: "Unset default project",
-	Long: `Remove the default project configuration.
+	Long: `Remove the default project of --platform, or of every platform.
 	
 After unsetting the default project, you will need to specify
 the project explicitly when listing or creating tasks.`,
 	RunE: runProjectUnset,
 }
 
+var unsetPlatform string
+
+func init() {
+	unsetCmd.Flags().StringVarP(&unsetPlatform, "platform", "p", "", "platform to unset the default project of")
+}
+
 func runProjectUnset(cmd *cobra.Command, args []string) error {
 	manager := config.NewManager()
-	if err := manager.Load(""); err != nil {
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
 	cfg := manager.GetConfig()
 
-	if cfg.Defaults.Project == "" {
+	previous := make(map[string]string)
+	for platform, project := range cfg.Defaults.Projects {
+		if unsetPlatform == "" || platform == unsetPlatform {
+			previous[platform] = project
+		}
+	}
+	if unsetPlatform == "" && cfg.Defaults.Project != "" {
+		previous["every platform"] = cfg.Defaults.Project
+	}
+	if len(previous) == 0 {
 		fmt.Println("No default project is currently set.")
 		return nil
 	}
 
-	previousProject := cfg.Defaults.Project
-
-	// Clear the default project
-	cfg.Defaults.Project = ""
+	// Clear the default projects
+	for platform := range previous {
+		cfg.SetDefaultProject(platform, "")
+	}
+	if unsetPlatform == "" {
+		cfg.Defaults.Project = ""
+	}
 
 	// Update the configuration
 	manager.SetConfig(cfg)
@@ -44,6 +63,13 @@ func runProjectUnset(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
 
-	fmt.Printf("✓ Default project unset (was: %s)\n", previousProject)
+	platforms := make([]string, 0, len(previous))
+	for platform := range previous {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+	for _, platform := range platforms {
+		fmt.Printf("✓ Default project of %s unset (was: %s)\n", platform, previous[platform])
+	}
 	return nil
 }
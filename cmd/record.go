@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/record"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var recordCmd = &cobra.Command{
+	Use:   "record [flags] -- <command> [args...]",
+	Short: "Record a session for bug reports and demos",
+	Long: `Run an opentask command and record it as an asciicast, the format
+asciinema plays, to attach to a bug report or show in a demo.
+
+The command runs as usual, interactive views included. Credentials from
+the configuration and from environment variables named like tokens, keys
+or secrets are masked in the recording, and so is your home directory.
+--redact-titles also masks the titles of the tasks the command lists.
+Keys you type are only recorded with --stdin.
+
+Examples:
+  opentask record -- task list
+  opentask record --redact-titles -o bug.cast -- task view
+  asciinema play bug.cast`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRecord,
+}
+
+var (
+	recordOutput        string
+	recordRedactSecrets bool
+	recordRedactTitles  bool
+	recordStdin         bool
+)
+
+func init() {
+	rootCmd.AddCommand(recordCmd)
+
+	// Flags after the command belong to it.
+	recordCmd.Flags().SetInterspersed(false)
+	recordCmd.Flags().StringVarP(&recordOutput, "output", "o", "", "file to write the recording to (default opentask-<time>.cast)")
+	recordCmd.Flags().BoolVar(&recordRedactSecrets, "redact-secrets", true, "mask credentials and tokens")
+	recordCmd.Flags().BoolVar(&recordRedactTitles, "redact-titles", false, "mask the titles of listed tasks")
+	recordCmd.Flags().BoolVar(&recordStdin, "stdin", false, "also record the keys typed")
+}
+
+func runRecord(cmd *cobra.Command, args []string) error {
+	if args[0] == "record" {
+		return fmt.Errorf("cannot record a recording")
+	}
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("record needs a terminal to show the session on")
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the opentask executable: %w", err)
+	}
+
+	redactor := record.NewRedactor()
+	if home, err := os.UserHomeDir(); err == nil {
+		redactor.HideHome(home)
+	}
+	if recordRedactSecrets {
+		manager := config.NewManager()
+		if err := manager.LoadFlags(cmd.Flags()); err != nil {
+			manager.Reset()
+		}
+		redactor.AddSecrets(knownSecrets(manager.GetConfig())...)
+	}
+
+	child := exec.Command(executable, args...)
+	child.Env = os.Environ()
+
+	if recordRedactTitles {
+		titles, err := os.CreateTemp("", "opentask-titles-*")
+		if err != nil {
+			return fmt.Errorf("failed to create titles file: %w", err)
+		}
+		titles.Close()
+		defer os.Remove(titles.Name())
+
+		redactor.WatchTitles(titles.Name())
+		child.Env = append(child.Env, record.TitlesEnv+"="+titles.Name())
+	}
+
+	output := recordOutput
+	if output == "" {
+		output = fmt.Sprintf("opentask-%s.cast", time.Now().Format("20060102-150405"))
+	}
+	f, err := os.OpenFile(output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create recording: %w", err)
+	}
+	defer f.Close()
+
+	width, height := record.Size()
+	cast, err := record.NewWriter(f, record.Header{
+		Width:     width,
+		Height:    height,
+		Timestamp: time.Now().Unix(),
+		Title:     redactor.RedactString("opentask " + strings.Join(args, " ")),
+		Env:       map[string]string{"TERM": os.Getenv("TERM"), "SHELL": os.Getenv("SHELL")},
+	})
+	if err != nil {
+		return err
+	}
+
+	session := &record.Session{Cast: cast, Redactor: redactor, Input: recordStdin}
+	runErr := session.Run(child)
+
+	fmt.Printf("✓ Session recorded to %s (play it with: asciinema play %s)\n", output, output)
+	return runErr
+}
@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/humanize"
+	"opentask/pkg/models"
+	"opentask/pkg/recurring"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+	"opentask/pkg/styles"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/spf13/cobra"
+)
+
+var recurringCmd = &cobra.Command{
+	Use:   "recurring",
+	Short: "Create tasks on a schedule",
+	Long: `Manage tasks that are created on a cron schedule, such as a weekly report.
+
+Recurring tasks are kept in ~/.opentask/recurring.json. 'opentask recurring
+run' creates the ones that are due; run it from cron or a systemd timer, or
+let 'opentask serve' run it after every refresh. Each scheduled time
+creates one task, however often the schedule is run; a task that missed
+several runs is created once.`,
+}
+
+var recurringAddCmd = &cobra.Command{
+	Use:   "add <title>",
+	Short: "Add a recurring task",
+	Long: `Add a task to create on a cron schedule.
+
+--cron takes five fields (minute hour day-of-month month day-of-week), in
+local time, or @hourly, @daily, @weekly, @monthly and @yearly.
+
+Examples:
+  opentask recurring add "Weekly report" --cron "0 9 * * MON" --platform jira --project OPS
+  opentask recurring add "Rotate on-call" --cron "0 10 1,15 * *" --labels oncall --priority high`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRecurringAdd,
+}
+
+var recurringListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recurring tasks and when they next run",
+	RunE:  runRecurringList,
+}
+
+var recurringRemoveCmd = &cobra.Command{
+	Use:   "remove <id>...",
+	Short: "Remove recurring tasks",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runRecurringRemove,
+}
+
+var recurringRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Create the recurring tasks that are due",
+	Long: `Create the recurring tasks whose scheduled time has passed since they
+were last created.
+
+Examples:
+  opentask recurring run --dry-run
+  # crontab: check every 15 minutes
+  */15 * * * * opentask recurring run`,
+	RunE: runRecurringRun,
+}
+
+var (
+	recurringCron        string
+	recurringPlatform    string
+	recurringProject     string
+	recurringPriority    string
+	recurringLabels      []string
+	recurringDescription string
+	recurringDryRun      bool
+)
+
+func init() {
+	rootCmd.AddCommand(recurringCmd)
+	recurringCmd.AddCommand(recurringAddCmd)
+	recurringCmd.AddCommand(recurringListCmd)
+	recurringCmd.AddCommand(recurringRemoveCmd)
+	recurringCmd.AddCommand(recurringRunCmd)
+
+	recurringAddCmd.Flags().StringVar(&recurringCron, "cron", "", "cron schedule, such as \"0 9 * * MON\"")
+	recurringAddCmd.Flags().StringVarP(&recurringPlatform, "platform", "p", "", "platform to create the task on (default: configured default)")
+	recurringAddCmd.Flags().StringVar(&recurringProject, "project", "", "project to create the task in (default: configured default)")
+	recurringAddCmd.Flags().StringVar(&recurringPriority, "priority", "", "task priority (low, medium, high, urgent)")
+	recurringAddCmd.Flags().StringSliceVarP(&recurringLabels, "labels", "l", []string{}, "task labels")
+	recurringAddCmd.Flags().StringVar(&recurringDescription, "description", "", "task description")
+	recurringAddCmd.MarkFlagRequired("cron")
+
+	recurringRunCmd.Flags().BoolVar(&recurringDryRun, "dry-run", false, "show the tasks that are due without creating them")
+}
+
+func runRecurringAdd(cmd *cobra.Command, args []string) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg := manager.GetConfig()
+
+	schedule, err := recurring.Parse(recurringCron)
+	if err != nil {
+		return err
+	}
+	if recurringPriority != "" && !models.Priority(recurringPriority).IsValid() {
+		return fmt.Errorf("invalid priority %q (low, medium, high, urgent)", recurringPriority)
+	}
+
+	platformName := recurringPlatform
+	if platformName == "" {
+		platformName = cfg.Defaults.Platform
+	}
+	if platformName == "" {
+		return fmt.Errorf("no platform given; pass --platform or set defaults.platform")
+	}
+	if platform, ok := cfg.GetPlatform(platformName); !ok || !platform.Enabled {
+		return fmt.Errorf("platform %s is not configured or enabled", platformName)
+	}
+
+	project := recurringProject
+	if project == "" {
+		project = cfg.DefaultProject(platformName)
+	}
+
+	st, err := store.Open()
+	if err != nil {
+		return err
+	}
+	tasks, err := st.LoadRecurring()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	task := store.RecurringTask{
+		ID:          recurring.NewID(args[0], tasks),
+		Title:       args[0],
+		Description: recurringDescription,
+		Cron:        recurringCron,
+		Platform:    platformName,
+		Project:     cfg.ResolveProject(project),
+		Priority:    recurringPriority,
+		Labels:      recurringLabels,
+		CreatedAt:   now,
+	}
+	if err := st.SaveRecurring(append(tasks, task)); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Added %s; first run %s\n", task.ID, schedule.Next(now).Format("Mon 2006-01-02 15:04"))
+	return nil
+}
+
+func runRecurringList(cmd *cobra.Command, args []string) error {
+	st, err := store.Open()
+	if err != nil {
+		return err
+	}
+	tasks, err := st.LoadRecurring()
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		fmt.Println("No recurring tasks; add one with 'opentask recurring add'")
+		return nil
+	}
+
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(styles.Current().Accent)).
+		Headers("ID", "SCHEDULE", "NEXT RUN", "PLATFORM", "PROJECT", "TITLE", "LAST TASK")
+
+	now := time.Now()
+	for _, task := range tasks {
+		next := "invalid schedule"
+		if schedule, err := recurring.Parse(task.Cron); err == nil {
+			if at := schedule.Next(now); !at.IsZero() {
+				next = humanize.Time(at)
+			} else {
+				next = "never"
+			}
+		}
+		t.Row(task.ID, task.Cron, next, task.Platform, task.Project, task.Title, task.LastTaskID)
+	}
+
+	fmt.Println(t)
+	return nil
+}
+
+func runRecurringRemove(cmd *cobra.Command, args []string) error {
+	st, err := store.Open()
+	if err != nil {
+		return err
+	}
+	tasks, err := st.LoadRecurring()
+	if err != nil {
+		return err
+	}
+
+	remove := make(map[string]bool, len(args))
+	for _, id := range args {
+		remove[id] = true
+	}
+	kept := tasks[:0]
+	for _, task := range tasks {
+		if remove[task.ID] {
+			delete(remove, task.ID)
+			continue
+		}
+		kept = append(kept, task)
+	}
+	if len(remove) > 0 {
+		var unknown []string
+		for _, id := range args {
+			if remove[id] {
+				unknown = append(unknown, id)
+			}
+		}
+		return fmt.Errorf("no recurring task %s", strings.Join(unknown, ", "))
+	}
+
+	if err := st.SaveRecurring(kept); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Removed %s\n", strings.Join(args, ", "))
+	return nil
+}
+
+func runRecurringRun(cmd *cobra.Command, args []string) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg := manager.GetConfig()
+
+	st, err := store.Open()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	if recurringDryRun {
+		tasks, err := st.LoadRecurring()
+		if err != nil {
+			return err
+		}
+		due, err := recurring.Due(tasks, now)
+		if err != nil {
+			return err
+		}
+		if len(due) == 0 {
+			fmt.Println("No recurring tasks due")
+			return nil
+		}
+		for _, occurrence := range due {
+			fmt.Printf("%s: would create %q on %s (due %s)\n", occurrence.Recurring.ID, occurrence.Recurring.Title, occurrence.Recurring.Platform, humanize.Time(occurrence.At))
+		}
+		fmt.Printf("\n%d recurring task(s) due\n", len(due))
+		return nil
+	}
+
+	results, err := recurring.Run(context.Background(), service.New(cfg), st, now)
+	recordAudit(store.AuditCreate, recurring.Audit(results))
+	errs := 0
+	for _, result := range results {
+		recurringTask := result.Occurrence.Recurring
+		if result.Err != nil {
+			errs++
+			fmt.Printf("✗ %s: %v\n", recurringTask.ID, result.Err)
+			continue
+		}
+		fmt.Printf("✓ %s: created %s %s\n", recurringTask.ID, result.Task.ID, result.Task.Title)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		fmt.Println("No recurring tasks due")
+	}
+	if errs > 0 {
+		return exitcode.WrapPartial(errs, len(results), fmt.Errorf("%d of %d recurring task(s) could not be created", errs, len(results)))
+	}
+	return nil
+}
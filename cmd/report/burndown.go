@@ -0,0 +1,87 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/report"
+	"opentask/pkg/store"
+
+	"github.com/spf13/cobra"
+)
+
+var burndownCmd = &cobra.Command{
+	Use:   "burndown",
+	Short: "Show a sprint burndown chart",
+	Long: `Render remaining open work per day for a sprint as a terminal chart.
+
+Sprint windows are derived from reports.sprint_start and
+reports.sprint_length (days) in the configuration.
+
+Examples:
+  opentask report burndown --sprint current
+  opentask report burndown --sprint previous --project TEST
+  opentask report burndown --sprint 2026-10-05..2026-10-18 --output burndown.svg`,
+	RunE: runBurndown,
+}
+
+var (
+	burndownSprint      string
+	burndownPlatform    string
+	burndownProject     string
+	burndownAllProjects bool
+	burndownLabels      []string
+	burndownOutput      string
+	burndownWidth       int
+)
+
+func init() {
+	burndownCmd.Flags().StringVar(&burndownSprint, "sprint", "current", "sprint to report on (current, previous, next, or YYYY-MM-DD..YYYY-MM-DD)")
+	burndownCmd.Flags().StringVarP(&burndownPlatform, "platform", "p", "", "limit to platform")
+	burndownCmd.Flags().StringVar(&burndownProject, "project", "", "limit to project")
+	burndownCmd.Flags().BoolVar(&burndownAllProjects, "all-projects", false, "include all projects (ignore default project)")
+	burndownCmd.Flags().StringSliceVarP(&burndownLabels, "labels", "l", []string{}, "limit to tasks with labels")
+	burndownCmd.Flags().StringVarP(&burndownOutput, "output", "o", "", "export chart to file (.svg or .png)")
+	burndownCmd.Flags().IntVar(&burndownWidth, "width", 80, "chart width in columns")
+}
+
+func runBurndown(cmd *cobra.Command, args []string) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+	now := time.Now()
+
+	window, err := report.ResolveSprint(burndownSprint, cfg.Reports, now)
+	if err != nil {
+		return err
+	}
+
+	s, err := store.Open()
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := s.LoadSnapshots(window.Start, window.End)
+	if err != nil {
+		return err
+	}
+
+	filter := scopeFilter(cfg, burndownPlatform, burndownProject, burndownAllProjects, burndownLabels)
+	points := report.Burndown(snapshots, window, filter, now)
+	title := fmt.Sprintf("Burndown — sprint %s (%s), %s", window.Name, window, scopeDescription(filter))
+
+	if burndownOutput != "" {
+		if err := report.ExportBurndown(burndownOutput, title, points); err != nil {
+			return fmt.Errorf("failed to export chart: %w", err)
+		}
+		fmt.Printf("✓ Burndown chart written to %s\n", burndownOutput)
+		return nil
+	}
+
+	fmt.Print(report.RenderBurndown(title, points, burndownWidth))
+	return nil
+}
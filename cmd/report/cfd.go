@@ -0,0 +1,94 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/report"
+	"opentask/pkg/store"
+
+	"github.com/spf13/cobra"
+)
+
+var cfdCmd = &cobra.Command{
+	Use:     "cfd",
+	Aliases: []string{"flow"},
+	Short:   "Show a cumulative flow diagram",
+	Long: `Render task counts per status over time as a stacked terminal chart.
+
+By default the last 30 days are shown; use --sprint to report on a sprint
+window instead.
+
+Examples:
+  opentask report cfd
+  opentask report cfd --days 60 --platform jira
+  opentask report cfd --sprint current --output flow.png`,
+	RunE: runCFD,
+}
+
+var (
+	cfdSprint      string
+	cfdDays        int
+	cfdPlatform    string
+	cfdProject     string
+	cfdAllProjects bool
+	cfdLabels      []string
+	cfdOutput      string
+	cfdWidth       int
+)
+
+func init() {
+	cfdCmd.Flags().StringVar(&cfdSprint, "sprint", "", "sprint to report on (current, previous, next, or YYYY-MM-DD..YYYY-MM-DD)")
+	cfdCmd.Flags().IntVar(&cfdDays, "days", 30, "number of days to show when no sprint is given")
+	cfdCmd.Flags().StringVarP(&cfdPlatform, "platform", "p", "", "limit to platform")
+	cfdCmd.Flags().StringVar(&cfdProject, "project", "", "limit to project")
+	cfdCmd.Flags().BoolVar(&cfdAllProjects, "all-projects", false, "include all projects (ignore default project)")
+	cfdCmd.Flags().StringSliceVarP(&cfdLabels, "labels", "l", []string{}, "limit to tasks with labels")
+	cfdCmd.Flags().StringVarP(&cfdOutput, "output", "o", "", "export chart to file (.svg or .png)")
+	cfdCmd.Flags().IntVar(&cfdWidth, "width", 80, "chart width in columns")
+}
+
+func runCFD(cmd *cobra.Command, args []string) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+	now := time.Now()
+
+	window := report.LastDays(cfdDays, now)
+	if cfdSprint != "" {
+		var err error
+		window, err = report.ResolveSprint(cfdSprint, cfg.Reports, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	s, err := store.Open()
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := s.LoadSnapshots(window.Start, window.End)
+	if err != nil {
+		return err
+	}
+
+	filter := scopeFilter(cfg, cfdPlatform, cfdProject, cfdAllProjects, cfdLabels)
+	points := report.CumulativeFlow(snapshots, window, filter, now)
+	title := fmt.Sprintf("Cumulative flow — %s (%s), %s", window.Name, window, scopeDescription(filter))
+
+	if cfdOutput != "" {
+		if err := report.ExportCumulativeFlow(cfdOutput, title, points); err != nil {
+			return fmt.Errorf("failed to export chart: %w", err)
+		}
+		fmt.Printf("✓ Cumulative flow diagram written to %s\n", cfdOutput)
+		return nil
+	}
+
+	fmt.Print(report.RenderCumulativeFlow(title, points, cfdWidth))
+	return nil
+}
@@ -0,0 +1,53 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+)
+
+// scopeFilter builds the filter that limits which snapshot tasks a report counts.
+func scopeFilter(cfg *config.Config, platform, project string, allProjects bool, labels []string) *models.TaskFilter {
+	filter := &models.TaskFilter{Labels: labels}
+
+	if platform != "" {
+		p := models.Platform(platform)
+		filter.Platform = &p
+	}
+
+	switch {
+	case project != "":
+		filter.ProjectID = cfg.ResolveProject(project)
+	case !allProjects:
+		filter.DefaultProjects = cfg.DefaultProjectsByType()
+	}
+
+	return filter
+}
+
+func scopeDescription(filter *models.TaskFilter) string {
+	var parts []string
+	if filter.Platform != nil {
+		parts = append(parts, "platform "+filter.Platform.String())
+	}
+	if filter.ProjectID != "" {
+		parts = append(parts, "project "+filter.ProjectID)
+	} else if len(filter.DefaultProjects) > 0 {
+		var projects []string
+		for platformType, project := range filter.DefaultProjects {
+			projects = append(projects, platformType+" "+project)
+		}
+		sort.Strings(projects)
+		parts = append(parts, "default projects ("+strings.Join(projects, ", ")+")")
+	}
+	if len(filter.Labels) > 0 {
+		parts = append(parts, fmt.Sprintf("labels %v", filter.Labels))
+	}
+	if len(parts) == 0 {
+		return "all tasks"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,21 @@
+package report
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var ReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate reports from task history",
+	Long: `Generate trend reports such as burndown and cumulative flow charts.
+
+Reports are built from the local snapshot history. Record a snapshot
+regularly (for example from cron) with "opentask report snapshot".`,
+}
+
+func init() {
+	ReportCmd.AddCommand(snapshotCmd)
+	ReportCmd.AddCommand(burndownCmd)
+	ReportCmd.AddCommand(cfdCmd)
+	ReportCmd.AddCommand(shareCmd)
+}
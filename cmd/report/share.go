@@ -0,0 +1,230 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"os"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/models"
+	"opentask/pkg/report"
+	"opentask/pkg/share"
+	"opentask/pkg/store"
+
+	"github.com/spf13/cobra"
+)
+
+var shareCmd = &cobra.Command{
+	Use:   "share",
+	Short: "Publish a read-only HTML report",
+	Long: `Render the latest snapshot as a static HTML page with summary counts,
+a sprint burndown and the task list, for stakeholders without tracker access.
+
+The page is written locally and can optionally be uploaded:
+  gist  a secret GitHub gist (token from GITHUB_TOKEN or the github platform)
+  s3    an S3 bucket from reports.share; the link is a presigned URL that
+        expires after reports.share.expiry (at most 7 days)
+
+Examples:
+  opentask report share --project TEST
+  opentask report share --status open --status in_progress --upload gist
+  opentask report share --upload s3 --expiry 72h`,
+	RunE: runShare,
+}
+
+var (
+	shareTitle       string
+	sharePlatform    string
+	shareProject     string
+	shareAllProjects bool
+	shareLabels      []string
+	shareStatuses    []string
+	shareSprint      string
+	shareNoChart     bool
+	shareOutput      string
+	shareUpload      string
+	shareExpiry      time.Duration
+)
+
+func init() {
+	shareCmd.Flags().StringVar(&shareTitle, "title", "Task Report", "report title")
+	shareCmd.Flags().StringVarP(&sharePlatform, "platform", "p", "", "limit to platform")
+	shareCmd.Flags().StringVar(&shareProject, "project", "", "limit to project")
+	shareCmd.Flags().BoolVar(&shareAllProjects, "all-projects", false, "include all projects (ignore default project)")
+	shareCmd.Flags().StringSliceVarP(&shareLabels, "labels", "l", []string{}, "limit to tasks with labels")
+	shareCmd.Flags().StringSliceVar(&shareStatuses, "status", []string{}, "limit the task list to statuses (repeatable)")
+	shareCmd.Flags().StringVar(&shareSprint, "sprint", "current", "sprint for the burndown chart")
+	shareCmd.Flags().BoolVar(&shareNoChart, "no-chart", false, "leave out the burndown chart")
+	shareCmd.Flags().StringVarP(&shareOutput, "output", "o", "", "write the page to this file (default opentask-report-<date>.html)")
+	shareCmd.Flags().StringVar(&shareUpload, "upload", "", "upload the page (gist, s3)")
+	shareCmd.Flags().DurationVar(&shareExpiry, "expiry", 0, "how long an S3 link stays valid (default reports.share.expiry or 7 days)")
+}
+
+func runShare(cmd *cobra.Command, args []string) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+	now := time.Now()
+
+	uploader, err := shareUploader(cfg)
+	if err != nil {
+		return err
+	}
+
+	s, err := store.Open()
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := s.LatestSnapshot()
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return fmt.Errorf("no snapshots recorded yet. Run 'opentask report snapshot' first")
+	}
+
+	filter := scopeFilter(cfg, sharePlatform, shareProject, shareAllProjects, shareLabels)
+
+	var scoped, listed []*models.Task
+	for _, task := range snapshot.Tasks {
+		if !filter.Matches(task) {
+			continue
+		}
+		scoped = append(scoped, task)
+		if statusSelected(task.Status) {
+			listed = append(listed, task)
+		}
+	}
+
+	page := &report.HTMLReport{
+		Title:       shareTitle,
+		Scope:       fmt.Sprintf("%s, snapshot of %s", scopeDescription(filter), snapshot.Day().Format("2006-01-02")),
+		GeneratedAt: now,
+		Stats:       report.Summarize(scoped, now),
+		Tasks:       listed,
+	}
+
+	if !shareNoChart {
+		chart, err := burndownChart(s, cfg, filter, now)
+		if err != nil {
+			fmt.Printf("⚠ Skipping burndown chart: %v\n", err)
+		}
+		page.Chart = chart
+	}
+
+	var buf bytes.Buffer
+	if err := report.RenderHTML(&buf, page); err != nil {
+		return err
+	}
+
+	output := shareOutput
+	if output == "" && uploader == nil {
+		output = fmt.Sprintf("opentask-report-%s.html", now.Format("2006-01-02"))
+	}
+	if output != "" {
+		if err := os.WriteFile(output, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		fmt.Printf("✓ Report written to %s\n", output)
+	}
+
+	if uploader != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		name := fmt.Sprintf("opentask-report-%s.html", now.Format("20060102-150405"))
+		link, err := uploader.Upload(ctx, name, buf.Bytes())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✓ Share link: %s\n", link)
+	}
+
+	return nil
+}
+
+// shareUploader returns the uploader selected with --upload, or nil if the
+// report is only written locally.
+func shareUploader(cfg *config.Config) (share.Uploader, error) {
+	switch shareUpload {
+	case "":
+		return nil, nil
+	case "gist":
+		token := os.Getenv("GITHUB_TOKEN")
+		if token == "" {
+			if platform, ok := cfg.GetPlatform("github"); ok {
+				token = platform.Credentials["token"]
+			}
+		}
+		if token == "" {
+			return nil, fmt.Errorf("set GITHUB_TOKEN or run 'opentask connect github' to upload gists")
+		}
+		return &share.GistUploader{Token: token, Description: shareTitle}, nil
+	case "s3":
+		settings := cfg.Reports.Share
+		expiry := shareExpiry
+		if expiry == 0 && settings.Expiry != "" {
+			parsed, err := time.ParseDuration(settings.Expiry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid reports.share.expiry %q: %w", settings.Expiry, err)
+			}
+			expiry = parsed
+		}
+		if expiry > share.MaxLinkExpiry {
+			return nil, fmt.Errorf("link expiry %s exceeds the S3 maximum of 7 days", expiry)
+		}
+
+		return &share.S3Uploader{
+			Bucket:          settings.Bucket,
+			Region:          settings.Region,
+			Prefix:          settings.Prefix,
+			Endpoint:        settings.Endpoint,
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+			Expiry:          expiry,
+		}, nil
+	default:
+		return nil, exitcode.Wrap(exitcode.Usage, fmt.Errorf("unknown upload target %q. Use gist or s3", shareUpload))
+	}
+}
+
+func statusSelected(status models.TaskStatus) bool {
+	if len(shareStatuses) == 0 {
+		return true
+	}
+	for _, s := range shareStatuses {
+		if models.TaskStatus(s) == status {
+			return true
+		}
+	}
+	return false
+}
+
+func burndownChart(s *store.Store, cfg *config.Config, filter *models.TaskFilter, now time.Time) (template.HTML, error) {
+	window, err := report.ResolveSprint(shareSprint, cfg.Reports, now)
+	if err != nil {
+		return "", err
+	}
+
+	snapshots, err := s.LoadSnapshots(window.Start, window.End)
+	if err != nil {
+		return "", err
+	}
+
+	svg, err := report.BurndownSVG(fmt.Sprintf("Sprint %s (%s)", window.Name, window), report.Burndown(snapshots, window, filter, now))
+	if err != nil {
+		return "", err
+	}
+
+	// The SVG is generated by us with all text escaped.
+	return template.HTML(svg), nil
+}
@@ -0,0 +1,81 @@
+package report
+
+import (
+	"context"
+	"fmt"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+
+	"github.com/spf13/cobra"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Record a snapshot of all tasks",
+	Long: `Fetch tasks from all enabled platforms and store them in the local
+snapshot history. One snapshot is kept per day; running the command again
+on the same day replaces that day's snapshot.
+
+Schedule this daily (cron, systemd timer) to feed burndown and
+cumulative flow reports.`,
+	RunE: runSnapshot,
+}
+
+var snapshotLimit int
+
+func init() {
+	snapshotCmd.Flags().IntVar(&snapshotLimit, "limit", 500, "maximum number of tasks to fetch per platform")
+}
+
+func runSnapshot(cmd *cobra.Command, args []string) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+
+	platforms := cfg.GetEnabledPlatforms()
+	if len(platforms) == 0 {
+		return fmt.Errorf("no platforms configured or enabled")
+	}
+
+	var allTasks []*models.Task
+
+	for _, platformName := range platforms {
+		platform, _ := cfg.GetPlatform(platformName)
+
+		client, err := service.NewClient(platformName, platform)
+		if err != nil {
+			fmt.Printf("⚠ Failed to create %s client: %v\n", platformName, err)
+			continue
+		}
+
+		ctx, cancel := service.WithRequestTimeout(context.Background())
+		defer cancel()
+
+		tasks, err := client.ListTasks(ctx, &models.TaskFilter{Limit: snapshotLimit})
+		if err != nil {
+			fmt.Printf("⚠ Failed to list tasks from %s: %v\n", platformName, err)
+			continue
+		}
+
+		allTasks = append(allTasks, tasks...)
+	}
+
+	s, err := store.Open()
+	if err != nil {
+		return err
+	}
+
+	snapshot := store.NewSnapshot(allTasks)
+	if err := s.SaveSnapshot(snapshot); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	fmt.Printf("✓ Recorded snapshot of %d task(s) for %s\n", len(allTasks), snapshot.Day().Format("2006-01-02"))
+	return nil
+}
@@ -3,18 +3,37 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
+	"opentask/cmd/completion"
+	"opentask/cmd/dashboard"
 	"opentask/cmd/project"
+	"opentask/cmd/report"
 	"opentask/cmd/task"
+	"opentask/cmd/tui"
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/humanize"
+	"opentask/pkg/logging"
+	"opentask/pkg/platforms"
+	"opentask/pkg/record"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+	"opentask/pkg/styles"
 
 	"github.com/charmbracelet/fang"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var cfgFile string
 
+// commandStarted is set once cobra has checked the command line and runs
+// the command, so errors returned before it are usage errors.
+var commandStarted bool
+
 var rootCmd = &cobra.Command{
 	Use:   "opentask",
 	Short: "OpenTask - Multi-Platform Task Management CLI",
@@ -24,50 +43,258 @@ multiple platforms including Linear, Jira, Slack, and GitHub Issues.
 Unlike existing single-platform CLI tools, OpenTask provides a seamless 
 developer experience by integrating all task management workflows into 
 a single, consistent interface.`,
-	Version: "0.1.0",
+	Version:          "0.1.0",
+	PersistentPreRun: setupCommand,
 }
 
 func Execute() {
-	if err := fang.Execute(context.Background(), rootCmd); err != nil {
-		os.Exit(1)
+	completion.Register(rootCmd)
+	// Aliases and upgrades are handled before cobra parses the flags.
+	configPath := flagValue(os.Args[1:], config.ConfigFlag)
+	upgradeConfig(configPath)
+
+	args, err := expandAliases(rootCmd, os.Args[1:], configPath)
+	if err != nil {
+		exit(flagValue(os.Args[1:], "error-format"), err)
+	}
+	rootCmd.SetArgs(args)
+	markStart(rootCmd)
+
+	errorFormat := flagValue(args, "error-format")
+	switch errorFormat {
+	case "", "text":
+	case "json":
+		// fang prints the error to the command's stderr; exit prints
+		// it as JSON instead.
+		rootCmd.SetErr(io.Discard)
+	default:
+		exit("", exitcode.Wrap(exitcode.Usage, fmt.Errorf("unknown error format %q (use text or json)", errorFormat)))
+	}
+
+	err = fang.Execute(context.Background(), rootCmd, fang.WithErrorHandler(func(w io.Writer, styles fang.Styles, err error) {
+		fang.DefaultErrorHandler(w, styles, logging.RedactError(err))
+	}))
+	if err != nil && !commandStarted {
+		// Cobra fails before running the command only when the command
+		// line is wrong: an unknown command or flag, bad arguments or
+		// conflicting flags.
+		err = exitcode.Wrap(exitcode.Usage, err)
+	}
+	finishTracing(err)
+	finishTrafficRecording()
+	finishLogging()
+	if err != nil {
+		if errorFormat == "json" {
+			exitcode.WriteJSON(os.Stderr, err)
+		}
+		os.Exit(exitcode.Of(err))
 	}
 }
 
-func init() {
-	cobra.OnInitialize(initConfig)
+// markStart sets commandStarted when cmd, or a command under it, starts to
+// run. Cobra checks arguments, required flags and flag groups just before.
+func markStart(cmd *cobra.Command) {
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			commandStarted = true
+			return run(cmd, args)
+		}
+	}
+	if run := cmd.Run; run != nil {
+		cmd.Run = func(cmd *cobra.Command, args []string) {
+			commandStarted = true
+			run(cmd, args)
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		markStart(sub)
+	}
+}
 
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.opentask.yaml)")
-	rootCmd.PersistentFlags().StringP("workspace", "w", "", "workspace to use")
-	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
-	rootCmd.PersistentFlags().BoolP("debug", "d", false, "debug mode")
+// exit reports an error found before the command runs and exits with its
+// exit code.
+func exit(errorFormat string, err error) {
+	if errorFormat == "json" {
+		exitcode.WriteJSON(os.Stderr, err)
+	} else {
+		fmt.Fprintf(os.Stderr, "✗ %v\n", err)
+	}
+	os.Exit(exitcode.Of(err))
+}
 
-	viper.BindPFlag("workspace", rootCmd.PersistentFlags().Lookup("workspace"))
-	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
-	viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
+func init() {
+	rootCmd.PersistentFlags().StringVar(&cfgFile, config.ConfigFlag, "", "config file (default is $HOME/.opentask.yaml)")
+	rootCmd.PersistentFlags().StringP(config.WorkspaceFlag, "w", "", "workspace to use")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "log the operations run against each platform")
+	rootCmd.PersistentFlags().BoolP("debug", "d", false, "also log every HTTP request, with credentials redacted")
+	rootCmd.PersistentFlags().String("log-file", "", "write the log to this file as JSON lines instead of stderr (default is log.file)")
+	rootCmd.PersistentFlags().Bool("no-color", false, "disable colored output (also set by NO_COLOR)")
+	rootCmd.PersistentFlags().Bool("absolute-times", false, "show timestamps and dates instead of relative times such as \"2h ago\"")
+	rootCmd.PersistentFlags().String("record", "", "save the platform API requests of this run, with credentials masked, as a HAR file in this directory")
+	rootCmd.PersistentFlags().String("error-format", "text", "print errors as text or as JSON on stderr (text, json)")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "timeout for each platform request, such as 45s (default is defaults.timeout or 30s)")
 
 	// Add subcommands
 	rootCmd.AddCommand(task.TaskCmd)
+	rootCmd.AddCommand(task.BoardCmd)
 	rootCmd.AddCommand(project.ProjectCmd)
+	rootCmd.AddCommand(report.ReportCmd)
+	rootCmd.AddCommand(dashboard.DashboardCmd)
+	rootCmd.AddCommand(tui.TUICmd)
+	rootCmd.AddCommand(completion.CompletionCmd)
 }
 
-func initConfig() {
-	if cfgFile != "" {
-		viper.SetConfigFile(cfgFile)
-	} else {
-		home, err := os.UserHomeDir()
-		cobra.CheckErr(err)
+// flagValue returns the value of the flag --name in args, or "". It reads
+// flags needed before cobra parses the command line, or when cobra fails
+// to.
+func flagValue(args []string, name string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, ok := strings.CutPrefix(arg, "--"+name+"="); ok {
+			return value
+		}
+		if arg == "--"+name && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
 
-		viper.AddConfigPath(home)
-		viper.AddConfigPath(".")
-		viper.SetConfigType("yaml")
-		viper.SetConfigName(".opentask")
+// setupCommand runs before every command. It sets up logging and request
+// recording, applies the color theme, the time format and the request
+// timeout, keeps detected platform versions and listed tasks on disk,
+// reports task titles to a recording session and starts tracing.
+func setupCommand(cmd *cobra.Command, args []string) {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		// The command itself reports configuration errors.
+		manager.Reset()
 	}
 
-	viper.AutomaticEnv()
+	cfg := manager.GetConfig()
+	if debug, _ := cmd.Flags().GetBool("debug"); debug {
+		if _, err := os.Stat(manager.GetConfigPath()); err == nil {
+			fmt.Fprintln(os.Stderr, "Using config file:", manager.GetConfigPath())
+		}
+		if path := manager.LocalConfigPath(); path != "" {
+			fmt.Fprintln(os.Stderr, "Using repository config file:", path)
+		}
+	}
+	startLogging(cmd, cfg)
+	startTrafficRecording(cmd, cfg)
+	applyTheme(cmd, cfg)
+	if absolute, _ := cmd.Flags().GetBool("absolute-times"); absolute {
+		humanize.SetAbsolute(true)
+	}
+	applyTimeout(cmd, cfg)
+	useCaches()
+	rememberTasks()
+	reportTitles()
+	startTracing(cmd, cfg)
+}
+
+// upgradeConfig migrates the configuration file at path, when written by an
+// older release, before any command reads it, and says what changed.
+func upgradeConfig(path string) {
+	manager := config.NewManager()
+	if err := manager.Load(path); err != nil {
+		return
+	}
+	changes, backup := manager.Migrated()
+	if len(changes) == 0 {
+		return
+	}
+
+	if backup == "" {
+		fmt.Fprintf(os.Stderr, "⚠ %s is from an older release and could not be upgraded; it is upgraded in memory on every run\n", manager.GetConfigPath())
+		return
+	}
+	fmt.Fprintf(os.Stderr, "✓ Upgraded %s to version %s (the old file is kept at %s):\n", manager.GetConfigPath(), config.DefaultConfigVersion, backup)
+	for _, change := range changes {
+		fmt.Fprintf(os.Stderr, "  - %s\n", change)
+	}
+}
 
-	if err := viper.ReadInConfig(); err == nil {
-		if viper.GetBool("debug") {
-			fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+// rememberTasks keeps the tasks this run lists in the data directory, so
+// shell completion can offer their IDs and commands given a bare ID know
+// which platform to ask.
+func rememberTasks() {
+	st, err := store.Open()
+	if err != nil {
+		return
+	}
+	service.ObserveTasks(func(list *service.TaskList) {
+		st.RememberTasks(list.Tasks)
+		for platform, result := range list.Searches {
+			ids := make([]string, 0, len(result.Tasks))
+			for _, task := range result.Tasks {
+				ids = append(ids, task.ID)
+			}
+			st.RememberLocations(platform, ids)
+		}
+	})
+}
+
+// reportTitles hands the titles of the tasks this run loads to
+// "opentask record" when it is recording the run, so it can mask them.
+func reportTitles() {
+	if os.Getenv(record.TitlesEnv) == "" {
+		return
+	}
+	service.ObserveTasks(func(list *service.TaskList) {
+		titles := make([]string, 0, len(list.Tasks))
+		for _, task := range list.Tasks {
+			titles = append(titles, task.Title)
 		}
+		record.AppendTitles(titles)
+	})
+}
+
+// applyTimeout sets how long platform requests may take, from --timeout or
+// else defaults.timeout.
+func applyTimeout(cmd *cobra.Command, cfg *config.Config) {
+	if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout > 0 {
+		service.SetRequestTimeout(timeout)
+		return
+	}
+	if cfg.Defaults.Timeout == "" {
+		return
+	}
+
+	timeout, err := time.ParseDuration(cfg.Defaults.Timeout)
+	if err != nil || timeout <= 0 {
+		fmt.Fprintf(os.Stderr, "⚠ invalid defaults.timeout %q; using %s\n", cfg.Defaults.Timeout, service.DefaultTimeout)
+		return
+	}
+	service.SetRequestTimeout(timeout)
+}
+
+// useCaches keeps the API versions detected for platform instances, the
+// projects of each platform and their labels and components in the data
+// directory, so they are not fetched again on every command. Without a
+// data directory they are only kept for this run.
+func useCaches() {
+	st, err := store.Open()
+	if err != nil {
+		return
+	}
+	platforms.SetVersionCache(st.VersionCache())
+	platforms.SetVocabularyCache(st.VocabularyCache())
+	platforms.SetProjectCache(st.ProjectCache())
+}
+
+// applyTheme activates the color scheme from ui.theme, and turns colors off
+// for --no-color or when NO_COLOR is set.
+func applyTheme(cmd *cobra.Command, cfg *config.Config) {
+	if noColor, _ := cmd.Flags().GetBool("no-color"); noColor || os.Getenv("NO_COLOR") != "" {
+		styles.DisableColor()
+	}
+
+	theme, err := styles.FromConfig(cfg.UI.Theme)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ %v; using the default theme\n", err)
 	}
+	styles.Use(theme)
 }
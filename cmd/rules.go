@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"opentask/cmd/task"
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/models"
+	"opentask/pkg/rules"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+
+	"github.com/spf13/cobra"
+)
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Run escalation rules",
+	Long: `Run the rules configured under rules, which escalate tasks that stay
+open too long.
+
+An aging rule raises a task's priority, adds a label or comments on it once
+it has been open longer than the rule's threshold. Each rule acts on a task
+once; the tasks it has acted on are kept in ~/.opentask/rules.json.
+'opentask serve' runs the rules after every refresh.`,
+}
+
+var rulesRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Apply the rules to open tasks",
+	Long: `Apply the configured rules to the open tasks of every enabled platform.
+
+The tasks to escalate are listed and confirmed first unless --yes is given;
+more than ui.bulk_confirm_threshold tasks ask for a typed phrase, which
+--force skips.
+
+Examples:
+  opentask rules run --dry-run
+  opentask rules run --yes`,
+	RunE: runRules,
+}
+
+var (
+	rulesDryRun bool
+	rulesLimit  int
+	rulesYes    bool
+	rulesForce  bool
+)
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+	rulesCmd.AddCommand(rulesRunCmd)
+
+	rulesRunCmd.Flags().BoolVar(&rulesDryRun, "dry-run", false, "show what the rules would change without changing it")
+	rulesRunCmd.Flags().BoolVarP(&rulesYes, "yes", "y", false, "do not ask for confirmation before escalating the tasks")
+	rulesRunCmd.Flags().BoolVar(&rulesForce, "force", false, "do not ask for the confirmation phrase when escalating many tasks")
+	rulesRunCmd.Flags().IntVar(&rulesLimit, "limit", 500, "maximum number of tasks to check per platform and status")
+}
+
+func runRules(cmd *cobra.Command, args []string) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg := manager.GetConfig()
+
+	ruleSet, err := rules.FromConfig(cfg.Rules)
+	if err != nil {
+		return err
+	}
+	if len(ruleSet) == 0 {
+		return fmt.Errorf("no rules configured; add aging rules under rules.aging")
+	}
+
+	st, err := store.Open()
+	if err != nil {
+		return err
+	}
+	state, err := st.LoadRulesState()
+	if err != nil {
+		return err
+	}
+
+	svc := service.New(cfg)
+	ctx := context.Background()
+
+	var tasks []*models.Task
+	var failures service.Failures
+	failed := make(map[string]bool)
+	for _, status := range []models.TaskStatus{models.StatusOpen, models.StatusInProgress} {
+		list := svc.Tasks.List(ctx, nil, &models.TaskFilter{Status: &status, Limit: rulesLimit})
+		tasks = append(tasks, list.Tasks...)
+		for _, failure := range list.Failures {
+			if !failed[failure.Platform] {
+				failed[failure.Platform] = true
+				failures = append(failures, failure)
+			}
+		}
+	}
+	failures.Report(os.Stderr, "list tasks")
+
+	now := time.Now()
+	actions, err := rules.Plan(ruleSet, tasks, state, now)
+	if err != nil {
+		return err
+	}
+	if len(actions) == 0 {
+		fmt.Println("No tasks to escalate")
+		return nil
+	}
+
+	for _, action := range actions {
+		fmt.Printf("%s %s: %s (%s)\n", action.Task.ID, action.Task.Title, action.Describe(), joinRules(action.Rules))
+	}
+	if rulesDryRun {
+		fmt.Printf("\n%d task(s) would be escalated\n", len(actions))
+		return nil
+	}
+
+	escalated := make([]*models.Task, len(actions))
+	for i, action := range actions {
+		escalated[i] = action.Task
+	}
+	ok, err := task.Confirm(cfg, task.Confirmation{
+		Verb:     "escalate",
+		Question: fmt.Sprintf("Escalate %d task(s)?", len(actions)),
+		Tasks:    escalated,
+		Listed:   true,
+		Phrase:   true,
+	}, rulesYes, rulesForce)
+	if !ok {
+		return err
+	}
+	fmt.Println()
+
+	results := rules.Apply(ctx, svc, actions, state, now)
+	recordAudit(store.AuditUpdate, rules.Audit(results))
+	if err := st.SaveRulesState(state); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Failed to save rules state: %v\n", err)
+	}
+
+	errs := 0
+	for _, result := range results {
+		action := result.Action
+		if result.Err != nil {
+			errs++
+			fmt.Printf("✗ %s: %v\n", action.Task.ID, result.Err)
+			continue
+		}
+		fmt.Printf("✓ %s %s: %s (%s)\n", action.Task.ID, action.Task.Title, action.Describe(), joinRules(action.Rules))
+	}
+
+	if errs > 0 {
+		return exitcode.WrapPartial(errs, len(results), fmt.Errorf("%d of %d task(s) could not be escalated", errs, len(results)))
+	}
+	return nil
+}
+
+func joinRules(names []string) string {
+	if len(names) == 1 {
+		return "rule " + names[0]
+	}
+	return "rules " + strings.Join(names, ", ")
+}
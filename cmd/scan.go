@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/progress"
+	"opentask/pkg/scan"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+	"opentask/pkg/terminal"
+
+	"github.com/spf13/cobra"
+)
+
+var scanCmd = &cobra.Command{
+	Use:   "scan [dir]",
+	Short: "Find TODO and FIXME comments and check their task references",
+	Long: `Scan source files for TODO and FIXME comments.
+
+Comments that reference a task, such as "// TODO(API-42): retry" or
+"# FIXME API-42 handle empty pages", are looked up on the enabled
+platforms; references to done or cancelled tasks are reported as orphaned.
+Comments without a reference are listed as untracked.
+
+With --create-missing a task is created for every untracked comment and
+its ID is written back into the comment.
+
+Examples:
+  opentask scan
+  opentask scan ./src --create-missing --platform jira --project API`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runScan,
+}
+
+var (
+	scanCreateMissing bool
+	scanPlatform      string
+	scanProject       string
+)
+
+func init() {
+	rootCmd.AddCommand(scanCmd)
+
+	scanCmd.Flags().BoolVar(&scanCreateMissing, "create-missing", false, "create tasks for untracked comments and write their IDs back")
+	scanCmd.Flags().StringVarP(&scanPlatform, "platform", "p", "", "platform to create tasks on (default: configured default)")
+	scanCmd.Flags().StringVar(&scanProject, "project", "", "project to create tasks in (default: configured default)")
+}
+
+func runScan(cmd *cobra.Command, args []string) error {
+	root := "."
+	if len(args) > 0 {
+		root = args[0]
+	}
+
+	items, err := scan.Dir(root)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		fmt.Printf("No TODO or FIXME comments found in %s\n", root)
+		return nil
+	}
+
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+
+	var tracked, untracked []scan.Item
+	for _, item := range items {
+		if item.Tracked() {
+			tracked = append(tracked, item)
+		} else {
+			untracked = append(untracked, item)
+		}
+	}
+
+	orphaned := 0
+	if len(tracked) > 0 {
+		orphaned = checkReferences(cfg, tracked)
+	}
+
+	created := 0
+	if scanCreateMissing && len(untracked) > 0 {
+		created, err = createScanTasks(cfg, untracked)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, item := range untracked {
+			fmt.Printf("• %s: %s %s\n", item.Location(), item.Keyword, item.Text)
+		}
+	}
+
+	fmt.Printf("\nFound %d comments: %d tracked, %d untracked, %d referencing closed tasks\n",
+		len(items), len(tracked), len(untracked), orphaned)
+	if scanCreateMissing {
+		fmt.Printf("Created %d tasks\n", created)
+		if created < len(untracked) {
+			return exitcode.WrapPartial(len(untracked)-created, len(untracked), fmt.Errorf("%d comment(s) could not be tracked", len(untracked)-created))
+		}
+	}
+
+	return nil
+}
+
+// checkReferences reports comments that reference missing or closed tasks
+// and returns how many reference closed ones.
+func checkReferences(cfg *config.Config, items []scan.Item) int {
+	clients := scanClients(cfg)
+	if len(clients) == 0 {
+		fmt.Println("⚠ No platforms enabled; task references were not checked")
+		return 0
+	}
+
+	orphaned := 0
+	tasks := lookupReferencedTasks(clients, items)
+	for _, item := range items {
+		task, found := tasks[item.TaskID]
+		switch {
+		case !found:
+			fmt.Printf("⚠ %s: %s %s was not found\n", item.Location(), item.Keyword, item.TaskID)
+		case task.Status == models.StatusDone || task.Status == models.StatusCancelled:
+			orphaned++
+			fmt.Printf("✗ %s: %s references %s, which is %s\n", item.Location(), item.Keyword, item.TaskID, task.Status)
+		}
+	}
+
+	return orphaned
+}
+
+// scanClients creates a client for each enabled platform.
+func scanClients(cfg *config.Config) map[string]platforms.PlatformClient {
+	clients := make(map[string]platforms.PlatformClient)
+	for _, platformName := range cfg.GetEnabledPlatforms() {
+		platform, _ := cfg.GetPlatform(platformName)
+		client, err := service.NewClient(platformName, platform)
+		if err != nil {
+			fmt.Printf("⚠ %v\n", err)
+			continue
+		}
+		clients[platformName] = client
+	}
+	return clients
+}
+
+// lookupReferencedTasks fetches each referenced task once, trying the
+// platforms in name order.
+func lookupReferencedTasks(clients map[string]platforms.PlatformClient, items []scan.Item) map[string]*models.Task {
+	names := make([]string, 0, len(clients))
+	for name := range clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tasks := make(map[string]*models.Task)
+	checked := make(map[string]bool)
+	for _, item := range items {
+		if checked[item.TaskID] {
+			continue
+		}
+		checked[item.TaskID] = true
+
+		for _, name := range names {
+			ctx, cancel := service.WithRequestTimeout(context.Background())
+			task, err := clients[name].GetTask(ctx, item.TaskID)
+			cancel()
+
+			if err == nil {
+				tasks[item.TaskID] = task
+				break
+			}
+			if !platforms.IsNotFoundError(err) {
+				fmt.Printf("⚠ Failed to look up %s on %s: %v\n", item.TaskID, name, err)
+			}
+		}
+	}
+
+	return tasks
+}
+
+// createScanTasks creates a task for each untracked comment and writes its
+// ID back into the source file. It returns how many comments were tracked.
+func createScanTasks(cfg *config.Config, items []scan.Item) (int, error) {
+	platformName := scanPlatform
+	if platformName == "" {
+		platformName = cfg.Defaults.Platform
+	}
+	if platformName == "" {
+		return 0, fmt.Errorf("no platform specified. Use --platform or set a default platform")
+	}
+
+	platform, exists := cfg.GetPlatform(platformName)
+	if !exists {
+		return 0, fmt.Errorf("platform %s not configured", platformName)
+	}
+	if !platform.Enabled {
+		return 0, fmt.Errorf("platform %s is disabled", platformName)
+	}
+
+	client, err := service.NewClient(platformName, platform)
+	if err != nil {
+		return 0, err
+	}
+
+	project := cfg.ResolveProject(scanProject)
+	if project == "" {
+		project = cfg.DefaultProject(platformName)
+	}
+
+	interactive, err := terminal.Interactive(cfg.UI.Interactive)
+	if err != nil {
+		return 0, err
+	}
+	tracker := progress.New(os.Stdout, "Creating", len(items), interactive)
+
+	created := 0
+	var audit []store.AuditTask
+	for _, item := range items {
+		title := item.Text
+		if title == "" {
+			title = fmt.Sprintf("%s in %s", item.Keyword, item.Location())
+		}
+
+		task := models.NewTask(title, models.Platform(platformName))
+		task.ProjectID = project
+		task.Description = fmt.Sprintf("Created from a %s comment in %s.", item.Keyword, item.Location())
+
+		ctx, cancel := service.WithRequestTimeout(context.Background())
+		result, err := client.CreateTask(ctx, task)
+		cancel()
+		if err != nil {
+			audit = append(audit, store.NewAuditTask(task, err))
+			tracker.Done(item.Location(), err)
+			continue
+		}
+		audit = append(audit, store.NewAuditTask(result, nil))
+
+		if err := scan.AddReference(item, result.ID); err != nil {
+			tracker.Done(item.Location(), fmt.Errorf("created %s but could not update the comment: %w", result.ID, err))
+			continue
+		}
+
+		created++
+		tracker.Printf("+ %s: created %s\n", item.Location(), result.ID)
+		tracker.Done(item.Location(), nil)
+	}
+	tracker.Finish()
+	recordAudit(store.AuditCreate, audit)
+
+	return created, nil
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/notify"
+	"opentask/pkg/rules"
+	"opentask/pkg/server"
+
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the OpenTask daemon",
+	Long: `Run OpenTask as a long-lived daemon.
+
+The daemon refreshes tasks from all enabled platforms on an interval and
+exposes Prometheus metrics at /metrics, including:
+
+  opentask_open_tasks{platform,project,priority}
+  opentask_overdue_tasks{platform,project}
+  opentask_operations_total{operation,platform}
+  opentask_operation_errors_total{operation,platform,code}
+  opentask_platform_requests_total{platform,method,status}
+  opentask_platform_request_duration_seconds{platform,method}
+
+Traces of each refresh and webhook delivery are exported over OTLP when
+telemetry.endpoint is configured.
+
+Each refresh also records the day's snapshot for reports unless
+--snapshot=false is given, applies the rules configured under rules
+unless --rules=false is given, and creates the recurring tasks that are due
+unless --recurring=false is given. Tasks that changed on the platforms since
+the previous refresh are posted as synced events to the notifiers
+configured under notifications, and the email digests that are due are
+sent, unless --notify=false is given.
+
+Webhook deliveries are accepted at POST /webhooks/<platform> (see
+'opentask webhook register'). The daemon fetches the task they name,
+updates it in the latest tasks, the metrics and the search index, and
+passes it on to any running 'opentask tui', which shows the change without
+reloading.
+
+GET /calendar.ics serves the due dates of the tasks assigned to you as an
+iCalendar feed for calendar apps to subscribe to (see 'opentask calendar
+export'). Pass --calendar-token to require ?token=<token> on it.`,
+	RunE: runServe,
+}
+
+var (
+	serveAddr          string
+	serveInterval      time.Duration
+	serveLimit         int
+	serveSnapshot      bool
+	serveRules         bool
+	serveRecurring     bool
+	serveNotify        bool
+	serveCalendarToken string
+)
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":9090", "address to listen on")
+	serveCmd.Flags().DurationVar(&serveInterval, "interval", 5*time.Minute, "task refresh interval")
+	serveCmd.Flags().IntVar(&serveLimit, "limit", 500, "maximum number of tasks to fetch per platform on each refresh; metrics count only these")
+	serveCmd.Flags().BoolVar(&serveSnapshot, "snapshot", true, "record a daily snapshot on each refresh")
+	serveCmd.Flags().BoolVar(&serveRules, "rules", true, "apply the configured rules on each refresh")
+	serveCmd.Flags().BoolVar(&serveRecurring, "recurring", true, "create due recurring tasks on each refresh")
+	serveCmd.Flags().BoolVar(&serveNotify, "notify", true, "post task changes to the configured notifiers")
+	serveCmd.Flags().StringVar(&serveCalendarToken, "calendar-token", "", "token required to read /calendar.ics")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+	if len(cfg.GetEnabledPlatforms()) == 0 {
+		return fmt.Errorf("no platforms configured or enabled")
+	}
+
+	var ruleSet []rules.Rule
+	if serveRules {
+		var err error
+		if ruleSet, err = rules.FromConfig(cfg.Rules); err != nil {
+			return err
+		}
+	}
+
+	var notifier *notify.Dispatcher
+	if serveNotify {
+		dispatcher, err := notify.FromConfig(cfg)
+		if err != nil {
+			return err
+		}
+		if dispatcher.Len() > 0 {
+			notifier = dispatcher
+		}
+	}
+
+	srv, err := server.New(cfg, server.Options{
+		Addr:          serveAddr,
+		Interval:      serveInterval,
+		Limit:         serveLimit,
+		Snapshot:      serveSnapshot,
+		Rules:         ruleSet,
+		Recurring:     serveRecurring,
+		Notifier:      notifier,
+		CalendarToken: serveCalendarToken,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("OpenTask daemon listening on %s (refresh every %s)\n", serveAddr, serveInterval)
+	return srv.Run(ctx)
+}
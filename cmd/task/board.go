@@ -0,0 +1,70 @@
+package task
+
+import (
+	"fmt"
+	"os"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+// BoardCmd is registered at the top level as 'opentask board'.
+var BoardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Show tasks as a kanban board",
+	Long: `Show tasks in columns by status.
+
+Move between cards with the arrow keys and move the focused card to the
+previous or next column with H and L (or shift+arrow). Moving a card updates
+the task on its platform, running the matching workflow transition.`,
+	RunE: runBoard,
+}
+
+var (
+	boardPlatform string
+	boardProject  string
+	boardAssignee string
+	boardLimit    int
+)
+
+func init() {
+	BoardCmd.Flags().StringVarP(&boardPlatform, "platform", "p", "", "show tasks from a single platform")
+	BoardCmd.Flags().StringVar(&boardProject, "project", "", "filter by project (defaults to the configured default project)")
+	BoardCmd.Flags().StringVarP(&boardAssignee, "assignee", "a", "", "filter by assignee")
+	BoardCmd.Flags().IntVar(&boardLimit, "limit", 100, "maximum number of tasks per platform")
+}
+
+func runBoard(cmd *cobra.Command, args []string) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+
+	platformNames := cfg.GetEnabledPlatforms()
+	if boardPlatform != "" {
+		platformNames = []string{boardPlatform}
+	}
+	if len(platformNames) == 0 {
+		return fmt.Errorf("no platforms configured or enabled")
+	}
+
+	filter := &models.TaskFilter{
+		Limit:     boardLimit,
+		Assignee:  boardAssignee,
+		ProjectID: cfg.ResolveProject(boardProject),
+	}
+
+	list := fetchTasks(cfg, platformNames, filter)
+	list.Failures.Report(os.Stderr, "list tasks")
+
+	m := NewTaskListModel(list.Tasks, false, cfg).WithConfigPath(config.FlagPath(cmd.Flags())).openBoard()
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("failed to run board: %w", err)
+	}
+	return nil
+}
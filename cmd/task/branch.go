@@ -0,0 +1,108 @@
+package task
+
+import (
+	"fmt"
+
+	"opentask/cmd/completion"
+	"opentask/pkg/config"
+	"opentask/pkg/git"
+
+	"github.com/spf13/cobra"
+)
+
+var branchCmd = &cobra.Command{
+	Use:   "branch <task-id>",
+	Short: "Create a git branch for a task",
+	Long: `Create a git branch named after a task and check it out.
+
+The branch name is rendered from git.branch_template in the configuration
+(default "feat/{{.ID}}-{{.Slug}}"). Available fields: .ID, .Slug, .Title,
+.Platform and .Type.
+
+Examples:
+  opentask task branch TEST-123
+  opentask task branch ENG-45 --template "fix/{{.ID}}"
+  opentask task branch TEST-123 --dry-run`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.TaskID,
+	RunE:              runBranch,
+}
+
+var (
+	branchPlatform   string
+	branchTemplate   string
+	branchNoCheckout bool
+	branchDryRun     bool
+)
+
+func init() {
+	branchCmd.Flags().StringVarP(&branchPlatform, "platform", "p", "", "specify platform if task ID is ambiguous")
+	branchCmd.Flags().StringVarP(&branchTemplate, "template", "t", "", "branch name template (overrides git.branch_template)")
+	branchCmd.Flags().BoolVar(&branchNoCheckout, "no-checkout", false, "create the branch without switching to it")
+	branchCmd.Flags().BoolVar(&branchDryRun, "dry-run", false, "print the branch name without creating it")
+}
+
+func runBranch(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+
+	task, platform, err := findTaskByID(cfg, taskID, branchPlatform)
+	if err != nil {
+		return err
+	}
+
+	tmpl := branchTemplate
+	if tmpl == "" {
+		tmpl = cfg.Git.BranchTemplate
+	}
+
+	data := git.BranchData{
+		ID:       task.ID,
+		Slug:     git.Slugify(task.Title),
+		Title:    task.Title,
+		Platform: platform,
+	}
+	if issueType, ok := task.GetMetadata("issue_type"); ok {
+		data.Type = fmt.Sprint(issueType)
+	}
+
+	name, err := git.BranchName(tmpl, data)
+	if err != nil {
+		return err
+	}
+
+	if branchDryRun {
+		fmt.Println(name)
+		return nil
+	}
+
+	if git.BranchExists(name) {
+		if branchNoCheckout {
+			fmt.Printf("Branch %s already exists\n", name)
+			return nil
+		}
+		if err := git.Checkout(name); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Switched to existing branch %s\n", name)
+		return nil
+	}
+
+	if err := git.CreateBranch(name, !branchNoCheckout); err != nil {
+		return err
+	}
+
+	if branchNoCheckout {
+		fmt.Printf("✓ Created branch %s for %s\n", name, task.ID)
+	} else {
+		fmt.Printf("✓ Created and switched to branch %s for %s\n", name, task.ID)
+	}
+
+	return nil
+}
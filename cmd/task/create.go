@@ -2,22 +2,82 @@ package task
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"time"
+	"io"
+	"os"
+	"slices"
+	"strings"
 
 	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
 	"opentask/pkg/models"
+	"opentask/pkg/notify"
+	"opentask/pkg/platforms"
+	"opentask/pkg/prompt"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+	"opentask/pkg/taskfile"
 
 	"github.com/spf13/cobra"
 )
 
 var createCmd = &cobra.Command{
-	Use:   "create [title]",
+	Use:   "create [title] [description]",
 	Short: "Create a new task",
 	Long: `Create a new task on the specified platform.
 	
 If no platform is specified, the default platform from configuration will be used.
-You can specify multiple platforms to create the task on all of them.`,
+You can specify multiple platforms to create the task on all of them.
+
+Platform-specific fields can be set with --field, using field IDs such as
+customfield_10011 or names from the platform's field_map setting. On Jira,
+--field also sets components and fixVersions (comma-separated names), the
+reporter (an email, name or "me") and the sprint (a sprint name or ID,
+"active" or "next"). --type
+picks the Jira issue type; the default is the platform's issue_type setting,
+or "Task". If the platform rejects the
+task because required fields are missing, you will be prompted for them
+(when running in a terminal) and the request is retried.
+
+Before creating, the resolved target (platform, project name, issue type
+and assignee) is shown for confirmation when running in a terminal; pass
+--yes to skip it, or set ui.confirm_create to always or never.
+
+Labels are checked against the labels already used in the project
+(cached for a day in ~/.opentask/vocabulary.json). A label that looks
+like a typo of a known one is refused with a suggestion; pass --new-labels
+to use it anyway.
+
+Long descriptions can be read from a file with --description-file (use
+- for stdin), or written in your editor ($VISUAL or $EDITOR) with --edit,
+which opens the description given so far or a Markdown template.
+
+A task can also be read from a Markdown file with --file. YAML front-matter
+sets title, labels, priority, assignee, project, due and fields; the body
+becomes the description. Command-line flags override the file.
+
+Many tasks can be created at once with --batch, which reads one JSON
+object per line (NDJSON) from a file or stdin (-), with the keys title,
+description, labels, priority, assignee, project, due, type and fields.
+Flags such as --project and --labels fill in keys a line leaves out. The
+tasks are created on one platform, --concurrency at a time, and a JSON
+result with the created ID or the error is printed for each line as it
+finishes; "ref" is copied from the input line to its result.
+
+--quiet prints only the ID of each created task on stdout, and warnings on
+stderr, so the ID can be captured or piped. It creates without asking for
+confirmation or prompting for fields, as --yes does. With --batch it
+prints the IDs of the created tasks and the errors of the others on stderr.
+
+Examples:
+  opentask task create "Fix login bug" --platform jira --project TEST
+  opentask task create --file tasks/rate-limiting.md
+  opentask task create "Fix login bug" --edit
+  id=$(opentask task create "Fix login bug" --quiet)
+  git log --oneline v1.2..v1.3 | opentask task create "Release notes for 1.3" --description-file -
+  jq -c '.[] | {title, labels}' todo.json | opentask task create --batch - --platform jira --project OPS
+  opentask task create "Checkout revamp" --type Epic --field customfield_10011="Checkout" --field components=API`,
 	RunE: runCreate,
 }
 
@@ -30,8 +90,25 @@ var (
 	createLabels    []string
 	createDueDate   string
 	createSyncTo    []string
+	createFields    []string
+	createFile      string
+	createType      string
+	createYes       bool
+	createNewLabels bool
+	createQuiet     bool
+
+	createDescription     string
+	createDescriptionFile string
+	createEdit            bool
+
+	createBatch       string
+	createConcurrency int
 )
 
+// maxFieldPrompts bounds how often create is retried after prompting for
+// required fields, in case the platform keeps asking for more.
+const maxFieldPrompts = 3
+
 func init() {
 	createCmd.Flags().StringVarP(&createPlatform, "platform", "p", "", "platform to create task on")
 	createCmd.Flags().StringSliceVar(&createPlatforms, "platforms", []string{}, "platforms to create task on")
@@ -41,77 +118,215 @@ func init() {
 	createCmd.Flags().StringSliceVarP(&createLabels, "labels", "l", []string{}, "task labels")
 	createCmd.Flags().StringVar(&createDueDate, "due", "", "due date (YYYY-MM-DD)")
 	createCmd.Flags().StringSliceVar(&createSyncTo, "sync-to", []string{}, "sync task to additional platforms")
+	createCmd.Flags().StringArrayVar(&createFields, "field", []string{}, "platform field as key=value (repeatable, JSON values allowed)")
+	createCmd.Flags().StringVarP(&createFile, "file", "f", "", "read the task from a Markdown file with YAML front-matter")
+	createCmd.Flags().StringVar(&createType, "type", "", "issue type, such as Bug or Story (Jira)")
+	createCmd.Flags().BoolVarP(&createYes, "yes", "y", false, "create without asking for confirmation")
+	createCmd.Flags().StringVar(&createDescription, "description", "", "task description")
+	createCmd.Flags().StringVar(&createDescriptionFile, "description-file", "", "read the description from a file (- for stdin)")
+	createCmd.Flags().BoolVar(&createEdit, "edit", false, "write the description in $EDITOR")
+	createCmd.Flags().StringVar(&createBatch, "batch", "", "create a task for each JSON line of a file (- for stdin)")
+	createCmd.Flags().IntVar(&createConcurrency, "concurrency", 4, "number of tasks --batch creates at a time")
+	createCmd.Flags().BoolVar(&createNewLabels, "new-labels", false, "allow labels that look like typos of existing ones")
+	createCmd.Flags().BoolVarP(&createQuiet, "quiet", "q", false, "print only the IDs of the created tasks")
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("task title is required")
+	if createBatch != "" {
+		return runBatchCreate(cmd, args)
 	}
 
-	title := args[0]
+	title := ""
 	description := ""
-	if len(args) > 1 {
-		description = args[1]
+	fields := map[string]string{}
+
+	if createFile != "" {
+		def, err := taskfile.Load(createFile)
+		if err != nil {
+			return err
+		}
+		title, description = def.Title, def.Description
+		for key, value := range def.Fields {
+			fields[key] = value
+		}
+		applyTaskFileDefaults(cmd, def)
+	}
+
+	if len(args) > 0 {
+		title = args[0]
+	}
+	if title == "" {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("task title is required"))
+	}
+
+	description, err := readDescription(cmd, title, args, description)
+	if err != nil {
+		return err
+	}
+
+	flagFields, err := parseFieldFlags(createFields)
+	if err != nil {
+		return err
+	}
+	for key, value := range flagFields {
+		fields[key] = value
 	}
 
 	manager := config.NewManager()
-	if err := manager.Load(""); err != nil {
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
 	cfg := manager.GetConfig()
+	createProject = cfg.ResolveProject(createProject)
+	if len(createLabels) == 0 {
+		createLabels = cfg.Defaults.Labels
+	}
 
-	platforms := determinePlatforms(cfg)
-	if len(platforms) == 0 {
+	targets := determinePlatforms(cfg)
+	if len(targets) == 0 {
 		return fmt.Errorf("no platforms configured. Use 'opentask connect' to add platforms")
 	}
 
+	confirm, err := shouldConfirmCreate(cfg)
+	if err != nil {
+		return err
+	}
+
 	priority := determinePriority(cfg)
 	assignee := determineAssignee(cfg)
+	svc := service.New(cfg)
 
-	var createdTasks []*models.Task
+	// With --quiet, stdout carries only the created IDs.
+	var status io.Writer = os.Stdout
+	if createQuiet {
+		status = os.Stderr
+	}
+
+	// Looking up assignees and projects shares one timeout.
+	lookupCtx, cancelLookup := service.WithRequestTimeout(context.Background())
+	defer cancelLookup()
 
-	for _, platformName := range platforms {
+	var ready []createTarget
+	for _, platformName := range targets {
 		platform, exists := cfg.GetPlatform(platformName)
 		if !exists {
-			fmt.Printf("⚠ Platform %s not configured, skipping\n", platformName)
+			fmt.Fprintf(status, "⚠ Platform %s not configured, skipping\n", platformName)
 			continue
 		}
 
 		if !platform.Enabled {
-			fmt.Printf("⚠ Platform %s is disabled, skipping\n", platformName)
+			fmt.Fprintf(status, "⚠ Platform %s is disabled, skipping\n", platformName)
 			continue
 		}
 
 		task := createTask(title, description, platformName, priority, assignee)
+		if task.ProjectID == "" {
+			task.ProjectID = cfg.DefaultProject(platformName)
+		}
+		if len(fields) > 0 {
+			task.SetMetadata("custom_fields", copyFields(fields))
+		}
+		if createType != "" {
+			task.SetMetadata("issue_type", createType)
+		}
+		if err := task.Validate(); err != nil {
+			return err
+		}
+
+		if err := platforms.RequireCapability(platformName, platform.Settings, platforms.CapabilityCreateTask); err != nil {
+			fmt.Fprintf(status, "⚠ Skipping %s: %v\n", platformName, err)
+			continue
+		}
 
 		// Create platform client
-		client, err := createPlatformClient(platformName, platform)
+		client, err := service.NewClient(platformName, platform)
 		if err != nil {
-			fmt.Printf("⚠ Failed to create %s client: %v\n", platformName, err)
+			fmt.Fprintf(status, "⚠ Failed to create %s client: %v\n", platformName, err)
 			continue
 		}
 
-		// Create task on platform
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+		if !createNewLabels {
+			if err := checkLabels(lookupCtx, status, svc, platformName, task); err != nil {
+				return err
+			}
+		}
+
+		if warning := resolveAssignee(lookupCtx, client, task); warning != "" {
+			fmt.Fprintf(status, "⚠ %s: %s; the task will be unassigned\n", platformName, warning)
+		}
+
+		ready = append(ready, createTarget{platform: platformName, client: client, task: task})
+	}
+
+	if confirm && len(ready) > 0 && !confirmTargets(lookupCtx, title, ready) {
+		fmt.Println("Cancelled")
+		return nil
+	}
 
-		createdTask, err := client.CreateTask(ctx, task)
+	var createdTasks []*models.Task
+	var events []notify.Event
+	audits := make(map[string][]store.AuditTask)
+	for _, target := range ready {
+		platformName, client, task := target.platform, target.client, target.task
+
+		// Create task on platform
+		createdTask, err := createWithFields(client, task, func(missing *platforms.RequiredFieldsError) bool {
+			return promptForFields(platformName, task, missing)
+		})
+		// Copies made for --sync-to are audited as syncs.
+		action := store.AuditCreate
+		if slices.Contains(createSyncTo, platformName) {
+			action = store.AuditSync
+		}
+		if err == nil {
+			audits[action] = append(audits[action], store.NewAuditTask(createdTask, nil))
+		} else {
+			audits[action] = append(audits[action], store.NewAuditTask(task, err))
+		}
 		if err != nil {
-			fmt.Printf("⚠ Failed to create task on %s: %v\n", platformName, err)
+			var missing *platforms.RequiredFieldsError
+			if errors.As(err, &missing) {
+				printMissingFields(status, platformName, missing)
+				continue
+			}
+			fmt.Fprintf(status, "⚠ Failed to create task on %s: %v\n", platformName, err)
 			continue
 		}
 
+		svc.Vocabulary.Learn(platformName, task.ProjectID, task.Labels)
 		createdTasks = append(createdTasks, createdTask)
+		events = append(events, notify.Event{Kind: notify.KindCreated, Platform: platformName, Task: createdTask})
+		if createQuiet {
+			fmt.Println(createdTask.ID)
+			continue
+		}
 		fmt.Printf("✓ Created task %s on %s: %s\n", createdTask.ID, platformName, createdTask.Title)
 	}
+	warnAudit(status, store.AuditCreate, "cli", audits[store.AuditCreate]...)
+	warnAudit(status, store.AuditSync, "cli", audits[store.AuditSync]...)
+	notifyTasks(status, cfg, events...)
 
 	if len(createdTasks) == 0 {
 		return fmt.Errorf("failed to create task on any platform")
 	}
 
-	fmt.Printf("\nSuccessfully created %d task(s)\n", len(createdTasks))
+	if !createQuiet {
+		fmt.Printf("\nSuccessfully created %d task(s)\n", len(createdTasks))
+	}
+
+	return nil
+}
 
+// checkLabels refuses labels that look like typos of labels the project
+// already uses, and warns about labels that are new to it.
+func checkLabels(ctx context.Context, w io.Writer, svc *service.Service, platformName string, task *models.Task) error {
+	for _, unknown := range svc.Vocabulary.CheckLabels(ctx, platformName, task.ProjectID, task.Labels) {
+		if unknown.Suggestion != "" {
+			return fmt.Errorf("label %q is not used on %s; did you mean %q? (pass --new-labels to use it anyway)", unknown.Label, platformName, unknown.Suggestion)
+		}
+		fmt.Fprintf(w, "⚠ %s: label %q is new and will be created\n", platformName, unknown.Label)
+	}
 	return nil
 }
 
@@ -192,8 +407,116 @@ func createTask(title, description, platformName string, priority models.Priorit
 	}
 
 	if createDueDate != "" {
-		task.SetMetadata("due_date_string", createDueDate)
+		task.SetMetadata(platforms.DueDateMetadataKey, createDueDate)
 	}
 
 	return task
 }
+
+// applyTaskFileDefaults fills create flags from a task file, leaving any
+// flag given on the command line untouched.
+func applyTaskFileDefaults(cmd *cobra.Command, def *taskfile.Definition) {
+	flags := cmd.Flags()
+	if !flags.Changed("priority") && def.Priority != "" {
+		createPriority = def.Priority
+	}
+	if !flags.Changed("assignee") && def.Assignee != "" {
+		createAssignee = def.Assignee
+	}
+	if !flags.Changed("project") && def.Project != "" {
+		createProject = def.Project
+	}
+	if !flags.Changed("labels") && len(def.Labels) > 0 {
+		createLabels = def.Labels
+	}
+	if !flags.Changed("due") && def.Due != "" {
+		createDueDate = def.Due
+	}
+}
+
+func parseFieldFlags(values []string) (map[string]string, error) {
+	fields := make(map[string]string, len(values))
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, exitcode.Wrap(exitcode.Usage, fmt.Errorf("invalid --field %q, expected key=value", value))
+		}
+		fields[key] = val
+	}
+	return fields, nil
+}
+
+func copyFields(fields map[string]string) map[string]string {
+	copied := make(map[string]string, len(fields))
+	for key, value := range fields {
+		copied[key] = value
+	}
+	return copied
+}
+
+// promptForFields asks the user for each missing field and stores the
+// answers on the task. It returns false if prompting is not possible or
+// the user leaves a value empty.
+// createWithFields creates task, asking for the fields the platform says
+// are missing and retrying up to maxFieldPrompts times. Each attempt gets
+// its own request timeout, so time spent answering does not count against
+// the retry.
+func createWithFields(client platforms.PlatformClient, task *models.Task, ask func(missing *platforms.RequiredFieldsError) bool) (*models.Task, error) {
+	create := func() (*models.Task, error) {
+		ctx, cancel := service.WithRequestTimeout(context.Background())
+		defer cancel()
+		return client.CreateTask(ctx, task)
+	}
+
+	createdTask, err := create()
+	for attempt := 0; err != nil && attempt < maxFieldPrompts; attempt++ {
+		var missing *platforms.RequiredFieldsError
+		if !errors.As(err, &missing) || !ask(missing) {
+			break
+		}
+		createdTask, err = create()
+	}
+	return createdTask, err
+}
+
+func promptForFields(platformName string, task *models.Task, missing *platforms.RequiredFieldsError) bool {
+	if createQuiet || !prompt.IsInteractive() {
+		return false
+	}
+
+	fields := map[string]string{}
+	if existing, ok := task.GetMetadata("custom_fields"); ok {
+		if m, ok := existing.(map[string]string); ok {
+			fields = m
+		}
+	}
+
+	fmt.Printf("%s requires additional fields (leave empty to cancel):\n", platformName)
+	for _, id := range missing.FieldIDs() {
+		value, err := prompt.Line(fmt.Sprintf("  %s [%s]: ", fieldLabel(missing.Fields[id]), id))
+		if err != nil || strings.TrimSpace(value) == "" {
+			return false
+		}
+		fields[id] = value
+	}
+
+	task.SetMetadata("custom_fields", fields)
+	return true
+}
+
+func printMissingFields(w io.Writer, platformName string, missing *platforms.RequiredFieldsError) {
+	fmt.Fprintf(w, "⚠ Failed to create task on %s: required fields are missing\n", platformName)
+	for _, id := range missing.FieldIDs() {
+		fmt.Fprintf(w, "    %s: %s\n", id, missing.Fields[id])
+	}
+	fmt.Fprintf(w, "  Supply them with --field, e.g. --field %s=<value>\n", missing.FieldIDs()[0])
+}
+
+// fieldLabel turns a message like "Epic Name is required." into "Epic Name".
+func fieldLabel(message string) string {
+	if i := strings.Index(strings.ToLower(message), " is required"); i > 0 {
+		return message[:i]
+	}
+	return message
+}
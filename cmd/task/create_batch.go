@@ -0,0 +1,281 @@
+package task
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/models"
+	"opentask/pkg/notify"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+	"opentask/pkg/taskfile"
+
+	"github.com/spf13/cobra"
+)
+
+// maxBatchLine bounds the length of one NDJSON line read by --batch.
+const maxBatchLine = 1 << 20
+
+// batchTask is one line of --batch input. Ref is not sent to the platform;
+// it is echoed in the result so callers can match results to their input.
+type batchTask struct {
+	Ref         string                     `json:"ref,omitempty"`
+	Title       string                     `json:"title"`
+	Description string                     `json:"description,omitempty"`
+	Labels      []string                   `json:"labels,omitempty"`
+	Priority    string                     `json:"priority,omitempty"`
+	Assignee    string                     `json:"assignee,omitempty"`
+	Project     string                     `json:"project,omitempty"`
+	Due         string                     `json:"due,omitempty"`
+	Type        string                     `json:"type,omitempty"`
+	Fields      map[string]json.RawMessage `json:"fields,omitempty"`
+}
+
+// batchResult is one line of --batch output.
+type batchResult struct {
+	Line     int    `json:"line"`
+	Ref      string `json:"ref,omitempty"`
+	ID       string `json:"id,omitempty"`
+	Platform string `json:"platform"`
+	Title    string `json:"title,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Warning  string `json:"warning,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// runBatchCreate creates a task for every NDJSON line of the --batch input
+// on one platform, createConcurrency at a time, and writes a result line
+// for each as it finishes.
+func runBatchCreate(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 || createFile != "" || createEdit || cmd.Flags().Changed("description") || cmd.Flags().Changed("description-file") {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("--batch reads titles and descriptions from its input; it cannot be combined with a title, --file, --edit or --description"))
+	}
+	if len(createPlatforms) > 0 || len(createSyncTo) > 0 {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("--batch creates tasks on one platform; use --platform"))
+	}
+	if createConcurrency < 1 {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("--concurrency must be at least 1"))
+	}
+
+	flagFields, err := parseFieldFlags(createFields)
+	if err != nil {
+		return err
+	}
+
+	input := io.Reader(os.Stdin)
+	if createBatch != "-" {
+		file, err := os.Open(createBatch)
+		if err != nil {
+			return fmt.Errorf("failed to open batch input: %w", err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg := manager.GetConfig()
+
+	targets := determinePlatforms(cfg)
+	if len(targets) == 0 {
+		return fmt.Errorf("no platforms configured. Use 'opentask connect' to add platforms")
+	}
+	platformName := targets[0]
+	platform, exists := cfg.GetPlatform(platformName)
+	if !exists || !platform.Enabled {
+		return fmt.Errorf("platform %s is not configured or enabled", platformName)
+	}
+	if err := platforms.RequireCapability(platformName, platform.Settings, platforms.CapabilityCreateTask); err != nil {
+		return err
+	}
+	client, err := service.NewClient(platformName, platform)
+	if err != nil {
+		return fmt.Errorf("failed to create %s client: %w", platformName, err)
+	}
+
+	labels := createLabels
+	if len(labels) == 0 {
+		labels = cfg.Defaults.Labels
+	}
+	defaults := taskfile.Definition{
+		Labels:   labels,
+		Priority: string(determinePriority(cfg)),
+		Assignee: determineAssignee(cfg),
+		Project:  createProject,
+		Due:      createDueDate,
+	}
+	if defaults.Project == "" {
+		defaults.Project = cfg.DefaultProject(platformName)
+	}
+
+	var (
+		mu      sync.Mutex
+		encoder = json.NewEncoder(os.Stdout)
+		wg      sync.WaitGroup
+		total   int
+		failed  int
+		events  []notify.Event
+		audit   []store.AuditTask
+	)
+	emit := func(result batchResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		if result.Error != "" {
+			failed++
+		}
+		if createQuiet {
+			writeBatchID(result)
+			return
+		}
+		encoder.Encode(result)
+	}
+
+	sem := make(chan struct{}, createConcurrency)
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBatchLine)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		total++
+
+		var input batchTask
+		if err := json.Unmarshal(data, &input); err != nil {
+			emit(batchResult{Line: line, Platform: platformName, Error: fmt.Sprintf("invalid JSON: %v", err)})
+			continue
+		}
+		task, err := batchTaskFor(cfg, platformName, input, defaults, flagFields)
+		if err != nil {
+			emit(batchResult{Line: line, Ref: input.Ref, Platform: platformName, Error: err.Error()})
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(line int, ref string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result, created := createBatchTask(client, platformName, task, line, ref)
+			emit(result)
+			mu.Lock()
+			defer mu.Unlock()
+			if created == nil {
+				audit = append(audit, store.AuditTask{Platform: platformName, Title: task.Title, Error: result.Error})
+				return
+			}
+			audit = append(audit, store.NewAuditTask(created, nil))
+			events = append(events, notify.Event{Kind: notify.KindCreated, Platform: platformName, Task: created})
+		}(line, input.Ref)
+	}
+	wg.Wait()
+	// Results are written to stdout as NDJSON, so warnings go to stderr.
+	warnAudit(os.Stderr, store.AuditCreate, "cli", audit...)
+	notifyTasks(os.Stderr, cfg, events...)
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read batch input: %w", err)
+	}
+	if failed > 0 {
+		return exitcode.WrapPartial(failed, total, fmt.Errorf("%d of %d task(s) could not be created", failed, total))
+	}
+	return nil
+}
+
+// writeBatchID writes the ID of a created task for --quiet, and the error
+// of a failed line to stderr.
+func writeBatchID(result batchResult) {
+	if result.Error != "" {
+		fmt.Fprintf(os.Stderr, "✗ line %d: %s\n", result.Line, result.Error)
+		return
+	}
+	if result.Warning != "" {
+		fmt.Fprintf(os.Stderr, "⚠ line %d: %s\n", result.Line, result.Warning)
+	}
+	fmt.Println(result.ID)
+}
+
+// batchTaskFor builds the task for one input line. Values on the line take
+// precedence over the command-line flags in defaults and fields.
+func batchTaskFor(cfg *config.Config, platformName string, input batchTask, defaults taskfile.Definition, fields map[string]string) (*models.Task, error) {
+	def := taskfile.Definition{
+		Title:       strings.TrimSpace(input.Title),
+		Description: strings.TrimSpace(input.Description),
+		Labels:      input.Labels,
+		Priority:    strings.ToLower(input.Priority),
+		Assignee:    input.Assignee,
+		Project:     input.Project,
+		Due:         input.Due,
+		Fields:      copyFields(fields),
+	}
+	if len(def.Labels) == 0 {
+		def.Labels = defaults.Labels
+	}
+	if def.Priority == "" {
+		def.Priority = defaults.Priority
+	}
+	if def.Assignee == "" {
+		def.Assignee = defaults.Assignee
+	}
+	if def.Project == "" {
+		def.Project = defaults.Project
+	}
+	if def.Due == "" {
+		def.Due = defaults.Due
+	}
+	for key, raw := range input.Fields {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			value = string(raw)
+		}
+		def.Fields[key] = value
+	}
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	task := models.NewTask(def.Title, models.Platform(platformName))
+	task.ProjectID = cfg.ResolveProject(def.Project)
+	def.Project = task.ProjectID
+	def.ApplyTo(task)
+
+	issueType := input.Type
+	if issueType == "" {
+		issueType = createType
+	}
+	if issueType != "" {
+		task.SetMetadata("issue_type", issueType)
+	}
+	return task, nil
+}
+
+func createBatchTask(client platforms.PlatformClient, platformName string, task *models.Task, line int, ref string) (batchResult, *models.Task) {
+	result := batchResult{Line: line, Ref: ref, Platform: platformName}
+
+	ctx, cancel := service.WithRequestTimeout(context.Background())
+	defer cancel()
+
+	result.Warning = resolveAssignee(ctx, client, task)
+	created, err := client.CreateTask(ctx, task)
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	result.ID = created.ID
+	result.Title = created.Title
+	result.URL = created.URL()
+	return result, created
+}
@@ -0,0 +1,150 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/prompt"
+	"opentask/pkg/terminal"
+)
+
+// createTarget is a task ready to be created on one platform.
+type createTarget struct {
+	platform string
+	client   platforms.PlatformClient
+	task     *models.Task
+}
+
+// issueTyper is implemented by platforms with issue types, such as Jira.
+type issueTyper interface {
+	IssueType(task *models.Task) string
+}
+
+// shouldConfirmCreate decides from ui.confirm_create whether to ask before
+// creating. It fails when confirmation is required but cannot be asked.
+func shouldConfirmCreate(cfg *config.Config) (bool, error) {
+	if createYes || createQuiet {
+		return false, nil
+	}
+
+	switch cfg.UI.ConfirmCreate {
+	case "", terminal.ModeAuto:
+		return prompt.IsInteractive(), nil
+	case terminal.ModeAlways:
+		if !prompt.IsInteractive() {
+			return false, fmt.Errorf("ui.confirm_create is always but stdin is not a terminal; pass --yes to create without confirmation")
+		}
+		return true, nil
+	case terminal.ModeNever:
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid ui.confirm_create %q, expected auto, always or never", cfg.UI.ConfirmCreate)
+	}
+}
+
+// resolveAssignee looks up the user named by the task's assignee query
+// ("me", an email or a name) and assigns the task to them. It returns a
+// warning when the user cannot be found or is ambiguous.
+func resolveAssignee(ctx context.Context, client platforms.PlatformClient, task *models.Task) string {
+	raw, ok := task.GetMetadata("assignee_query")
+	if !ok {
+		return ""
+	}
+	query, _ := raw.(string)
+	if query == "" {
+		return ""
+	}
+
+	if query == "me" {
+		user, err := client.GetCurrentUser(ctx)
+		if err != nil {
+			return fmt.Sprintf("could not look up the current user: %v", err)
+		}
+		task.Assignee = user
+		return ""
+	}
+
+	users, err := client.SearchUsers(ctx, query)
+	if err != nil {
+		return fmt.Sprintf("could not look up assignee %q: %v", query, err)
+	}
+
+	var match *models.User
+	for _, user := range users {
+		if strings.EqualFold(user.Email, query) || strings.EqualFold(user.Name, query) {
+			match = user
+			break
+		}
+	}
+	if match == nil && len(users) == 1 {
+		match = users[0]
+	}
+
+	switch {
+	case match != nil:
+		task.Assignee = match
+		return ""
+	case len(users) == 0:
+		return fmt.Sprintf("no user matches assignee %q", query)
+	default:
+		return fmt.Sprintf("assignee %q matches %d users; use their email", query, len(users))
+	}
+}
+
+// describeTarget lists where and how the task will be created, with the
+// project's name so a stale default project stands out.
+func describeTarget(ctx context.Context, target createTarget) []string {
+	task := target.task
+	lines := []string{fmt.Sprintf("Platform:   %s", target.platform)}
+
+	switch {
+	case task.ProjectID == "":
+		lines = append(lines, "Project:    (platform default)")
+	default:
+		project, err := target.client.GetProject(ctx, task.ProjectID)
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("Project:    %s (⚠ could not be found: %v)", task.ProjectID, err))
+		} else {
+			lines = append(lines, fmt.Sprintf("Project:    %s (%s)", project.Name, task.ProjectID))
+		}
+	}
+
+	if typer, ok := target.client.(issueTyper); ok {
+		lines = append(lines, fmt.Sprintf("Issue type: %s", typer.IssueType(task)))
+	}
+
+	switch {
+	case task.Assignee != nil && task.Assignee.Email != "":
+		lines = append(lines, fmt.Sprintf("Assignee:   %s <%s>", task.Assignee.Name, task.Assignee.Email))
+	case task.Assignee != nil:
+		lines = append(lines, fmt.Sprintf("Assignee:   %s", task.Assignee.Name))
+	default:
+		lines = append(lines, "Assignee:   (unassigned)")
+	}
+
+	lines = append(lines, fmt.Sprintf("Priority:   %s", task.Priority))
+	if len(task.Labels) > 0 {
+		lines = append(lines, fmt.Sprintf("Labels:     %s", strings.Join(task.Labels, ", ")))
+	}
+
+	return lines
+}
+
+// confirmTargets shows every target and asks once whether to create the
+// task on all of them.
+func confirmTargets(ctx context.Context, title string, targets []createTarget) bool {
+	fmt.Printf("About to create %q:\n", title)
+	for _, target := range targets {
+		fmt.Println()
+		for _, line := range describeTarget(ctx, target) {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+	fmt.Println()
+
+	return prompt.Confirm("Create?", true)
+}
@@ -0,0 +1,88 @@
+package task
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"opentask/pkg/exitcode"
+	"opentask/pkg/prompt"
+
+	"github.com/spf13/cobra"
+)
+
+// descriptionTemplate is what --edit opens when there is no description
+// yet. Comments are removed from the saved text.
+const descriptionTemplate = `<!--
+Describe %q in Markdown.
+Comments like this one are removed; save an empty file to create the task
+without a description.
+-->
+`
+
+var commentPattern = regexp.MustCompile(`(?s)<!--.*?-->\n?`)
+
+// readDescription returns the description given by --description or
+// --description-file, or the positional one, and opens it in the editor
+// when --edit is given. fallback is the description from --file.
+func readDescription(cmd *cobra.Command, title string, args []string, fallback string) (string, error) {
+	flags := cmd.Flags()
+	given := 0
+	for _, changed := range []bool{len(args) > 1, flags.Changed("description"), flags.Changed("description-file")} {
+		if changed {
+			given++
+		}
+	}
+	if given > 1 {
+		return "", exitcode.Wrap(exitcode.Usage, fmt.Errorf("give the description once: as an argument, with --description or with --description-file"))
+	}
+
+	description := fallback
+	switch {
+	case len(args) > 1:
+		description = args[1]
+	case flags.Changed("description"):
+		description = createDescription
+	case flags.Changed("description-file"):
+		data, err := readDescriptionFile(createDescriptionFile)
+		if err != nil {
+			return "", err
+		}
+		description = strings.TrimRight(string(data), "\n")
+	}
+
+	if !createEdit {
+		return description, nil
+	}
+	if !prompt.IsInteractive() {
+		return "", exitcode.Wrap(exitcode.Usage, fmt.Errorf("--edit needs a terminal"))
+	}
+
+	text := description
+	if text == "" {
+		text = fmt.Sprintf(descriptionTemplate, title)
+	}
+	edited, err := prompt.Edit(text, "opentask-*.md")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(commentPattern.ReplaceAllString(edited, "")), nil
+}
+
+// readDescriptionFile reads path, or stdin when path is "-".
+func readDescriptionFile(path string) ([]byte, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read description from stdin: %w", err)
+		}
+		return data, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read description: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,73 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/platforms/mock"
+	"opentask/pkg/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requiredFieldsClient asks for a custom field until the task has one.
+type requiredFieldsClient struct {
+	platforms.PlatformClient
+	calls int
+}
+
+func (c *requiredFieldsClient) CreateTask(ctx context.Context, task *models.Task) (*models.Task, error) {
+	c.calls++
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if _, ok := task.GetMetadata("custom_fields"); !ok {
+		return nil, &platforms.RequiredFieldsError{Fields: map[string]string{"customfield_10011": "Epic Name is required."}}
+	}
+	created := *task
+	created.ID = "API-1"
+	return &created, nil
+}
+
+func TestCreateWithFields_SlowPrompt(t *testing.T) {
+	service.SetRequestTimeout(20 * time.Millisecond)
+	defer service.SetRequestTimeout(0)
+
+	client := &requiredFieldsClient{}
+	task := &models.Task{Title: "Plan Q3"}
+	created, err := createWithFields(client, task, func(missing *platforms.RequiredFieldsError) bool {
+		// Answering takes longer than a request may.
+		time.Sleep(50 * time.Millisecond)
+		task.SetMetadata("custom_fields", map[string]string{"customfield_10011": "Q3"})
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "API-1", created.ID)
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestCreateWithFields_Declined(t *testing.T) {
+	client := &requiredFieldsClient{}
+	_, err := createWithFields(client, &models.Task{Title: "Plan Q3"}, func(*platforms.RequiredFieldsError) bool { return false })
+
+	var missing *platforms.RequiredFieldsError
+	assert.ErrorAs(t, err, &missing)
+	assert.Equal(t, 1, client.calls)
+}
+
+func TestCreateTask_NamedPlatform(t *testing.T) {
+	// Extra sites added by connect are named after their type, such as
+	// "jira-acme", and tasks carry that name.
+	task := createTask("Fix login", "", "jira-acme", models.PriorityMedium, "")
+	require.NoError(t, task.Validate())
+
+	client, err := mock.NewClient(mock.Config{Empty: true})
+	require.NoError(t, err)
+	created, err := createWithFields(client, task, func(*platforms.RequiredFieldsError) bool { return false })
+	require.NoError(t, err)
+	assert.Equal(t, "Fix login", created.Title)
+}
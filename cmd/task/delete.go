@@ -0,0 +1,181 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"opentask/cmd/completion"
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+
+	"github.com/spf13/cobra"
+)
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <task-id>...",
+	Short: "Archive or delete tasks",
+	Long: `Archive tasks, or delete them permanently with --hard.
+
+Archiving keeps the task recoverable and uses the platform's own semantics:
+- Linear archives the issue
+- Jira moves the issue to Done and labels it "` + platforms.ArchivedLabel + `"
+
+Both ask for confirmation, listing the tasks, unless --yes is given.
+Deleting cannot be undone, so it needs --hard and is refused when the
+connected token lacks the permission to delete.
+
+Archiving or deleting more tasks than ui.bulk_confirm_threshold (10 by
+default) asks you to type a phrase such as "delete 25 tasks" instead,
+unless --force is given. Every run is recorded in ~/.opentask/audit.log
+with each task's status beforehand; see 'opentask history'.
+
+Examples:
+  opentask task delete ENG-123
+  opentask task delete jira:TASK-123 --hard --yes
+  opentask task delete ENG-1 ENG-2 ENG-3`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completion.TaskIDs,
+	RunE:              runDelete,
+}
+
+var (
+	deleteArchive  bool
+	deleteHard     bool
+	deleteYes      bool
+	deleteForce    bool
+	deletePlatform string
+)
+
+func init() {
+	deleteCmd.Flags().BoolVar(&deleteArchive, "archive", true, "archive the tasks instead of deleting them")
+	deleteCmd.Flags().BoolVar(&deleteHard, "hard", false, "delete the tasks permanently")
+	deleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "do not ask for confirmation before archiving or deleting")
+	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "do not ask for the confirmation phrase when archiving or deleting many tasks")
+	deleteCmd.Flags().StringVarP(&deletePlatform, "platform", "p", "", "specify platform if task ID is ambiguous")
+}
+
+// deleteTarget is a task to archive or delete and the platform it is on.
+type deleteTarget struct {
+	task     *models.Task
+	platform string
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	if deleteHard && cmd.Flags().Changed("archive") && deleteArchive {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("--archive and --hard cannot be used together"))
+	}
+	if !deleteHard && !deleteArchive {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("use --hard to delete the task permanently"))
+	}
+
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+
+	capability := platforms.CapabilityUpdateTask
+	action := "archive"
+	if deleteHard {
+		capability = platforms.CapabilityDeleteTask
+		action = "delete"
+	}
+
+	// Look every task up before changing any, so a typo does not leave
+	// the batch half done.
+	var targets []deleteTarget
+	for _, taskID := range args {
+		task, platform, err := findTaskByID(cfg, taskID, deletePlatform)
+		if err != nil {
+			return err
+		}
+		if err := platforms.RequireCapability(platform, cfg.Platforms[platform].Settings, capability); err != nil {
+			return err
+		}
+		targets = append(targets, deleteTarget{task: task, platform: platform})
+	}
+
+	tasks := make([]*models.Task, len(targets))
+	for i, target := range targets {
+		tasks[i] = target.task
+	}
+	question := fmt.Sprintf("Archive %d tasks?", len(tasks))
+	switch {
+	case deleteHard && len(tasks) == 1:
+		question = fmt.Sprintf("Permanently delete %s %q on %s?", args[0], targets[0].task.Title, targets[0].platform)
+	case deleteHard:
+		question = fmt.Sprintf("Permanently delete %d tasks?", len(tasks))
+	case len(tasks) == 1:
+		question = fmt.Sprintf("Archive %s %q on %s?", args[0], targets[0].task.Title, targets[0].platform)
+	}
+	ok, err := Confirm(cfg, Confirmation{Verb: action, Question: question, Tasks: tasks, Listed: len(tasks) == 1, Phrase: true}, deleteYes, deleteForce)
+	if !ok {
+		return err
+	}
+
+	clients := make(map[string]platforms.PlatformClient)
+	audit := make([]store.AuditTask, 0, len(targets))
+	var failed []error
+	for i, target := range targets {
+		err := deleteTask(clients, cfg, target, args[i])
+		audit = append(audit, store.NewAuditTask(target.task, err))
+		if err != nil {
+			failed = append(failed, err)
+			if len(targets) > 1 {
+				fmt.Printf("✗ %s: %v\n", args[i], err)
+			}
+			continue
+		}
+		fmt.Printf("✓ Task %s %sd\n", args[i], action)
+	}
+
+	warnAudit(os.Stderr, action, "cli", audit...)
+
+	switch {
+	case len(failed) == 0:
+		return nil
+	case len(targets) == 1:
+		return failed[0]
+	default:
+		return exitcode.WrapPartial(len(failed), len(targets), fmt.Errorf("failed to %s %d of %d tasks", action, len(failed), len(targets)))
+	}
+}
+
+// deleteTask archives or deletes one task, reusing the platform clients
+// already created for the batch.
+func deleteTask(clients map[string]platforms.PlatformClient, cfg *config.Config, target deleteTarget, taskID string) error {
+	client, ok := clients[target.platform]
+	if !ok {
+		var err error
+		client, err = service.NewClient(target.platform, cfg.Platforms[target.platform])
+		if err != nil {
+			return fmt.Errorf("failed to create %s client: %w", target.platform, err)
+		}
+		clients[target.platform] = client
+	}
+
+	ctx, cancel := service.WithRequestTimeout(context.Background())
+	defer cancel()
+
+	if !deleteHard {
+		archiver, ok := client.(platforms.Archiver)
+		if !ok {
+			return fmt.Errorf("%s cannot archive tasks; use --hard to delete %s permanently", target.platform, taskID)
+		}
+		if err := archiver.ArchiveTask(ctx, target.task); err != nil {
+			return fmt.Errorf("failed to archive task: %w", err)
+		}
+		return nil
+	}
+
+	if err := client.DeleteTask(ctx, target.task.ID); err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package task
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"opentask/pkg/config"
+	"opentask/pkg/logging"
+	"opentask/pkg/models"
+	"opentask/pkg/prompt"
+	"opentask/pkg/store"
+)
+
+// defaultBulkConfirmThreshold is used when ui.bulk_confirm_threshold is not
+// set.
+const defaultBulkConfirmThreshold = 10
+
+// bulkConfirmThreshold returns how many tasks a bulk delete or close may
+// affect before the confirmation phrase is required.
+func bulkConfirmThreshold(cfg *config.Config) int {
+	if cfg == nil || cfg.UI.BulkConfirmThreshold <= 0 {
+		return defaultBulkConfirmThreshold
+	}
+	return cfg.UI.BulkConfirmThreshold
+}
+
+// confirmationPhrase is what has to be typed to confirm a large bulk
+// action, such as "delete 25 tasks".
+func confirmationPhrase(action string, count int) string {
+	return fmt.Sprintf("%s %d tasks", action, count)
+}
+
+// maxConfirmListed bounds how many tasks a confirmation lists.
+const maxConfirmListed = 10
+
+// Confirmation describes a change to tasks that cannot easily be undone,
+// for Confirm.
+type Confirmation struct {
+	// Verb names the change in the confirmation phrase and in errors,
+	// such as "archive", "delete" or "close".
+	Verb string
+	// Question asks for the change, such as "Archive 3 tasks?".
+	Question string
+	Tasks    []*models.Task
+	// Listed is set when the command has already listed the tasks.
+	Listed bool
+	// Phrase requires the confirmation phrase for more than
+	// ui.bulk_confirm_threshold tasks.
+	Phrase bool
+}
+
+// Confirm asks before a destructive or bulk change, so every command that
+// deletes, archives, closes or changes many tasks asks the same way: it
+// lists the tasks and asks the question, or for more than
+// ui.bulk_confirm_threshold tasks has the confirmation phrase typed
+// instead. yes skips the question and force the phrase. Without a terminal
+// to ask on, it fails unless they were given. It reports whether to go
+// ahead, printing "Cancelled" if not.
+func Confirm(cfg *config.Config, c Confirmation, yes, force bool) (bool, error) {
+	threshold := bulkConfirmThreshold(cfg)
+	bulk := c.Phrase && len(c.Tasks) > threshold && !force
+	if !bulk && yes {
+		return true, nil
+	}
+
+	if !prompt.IsInteractive() {
+		if bulk {
+			return false, fmt.Errorf("refusing to %s %d tasks (more than ui.bulk_confirm_threshold=%d) without confirmation; pass --force", c.Verb, len(c.Tasks), threshold)
+		}
+		return false, fmt.Errorf("refusing to %s %s without confirmation; pass --yes", c.Verb, taskRefs(c.Tasks))
+	}
+
+	if !c.Listed {
+		listAffected(os.Stdout, c.Tasks)
+	}
+	if bulk {
+		phrase := confirmationPhrase(c.Verb, len(c.Tasks))
+		answer, err := prompt.Line(fmt.Sprintf("Type %q to continue: ", phrase))
+		if err != nil || strings.TrimSpace(answer) != phrase {
+			fmt.Println("Cancelled")
+			return false, nil
+		}
+		return true, nil
+	}
+	if !prompt.Confirm(c.Question, false) {
+		fmt.Println("Cancelled")
+		return false, nil
+	}
+	return true, nil
+}
+
+// listAffected writes the tasks a confirmation is about, one per line, up to
+// maxConfirmListed of them.
+func listAffected(w io.Writer, tasks []*models.Task) {
+	for i, task := range tasks {
+		if i == maxConfirmListed {
+			fmt.Fprintf(w, "  … and %d more\n", len(tasks)-i)
+			break
+		}
+		fmt.Fprintf(w, "  %s  %s (%s)\n", task.Ref(), task.Title, task.Status)
+	}
+}
+
+// taskRefs names tasks in an error, such as "jira:API-1, jira:API-2", or
+// counts them when there are many. Tasks yet to be created are named by
+// their title.
+func taskRefs(tasks []*models.Task) string {
+	if len(tasks) > maxConfirmListed {
+		return fmt.Sprintf("%d tasks", len(tasks))
+	}
+	refs := make([]string, len(tasks))
+	for i, task := range tasks {
+		refs[i] = task.Ref()
+		if task.ID == "" {
+			// Not created yet.
+			refs[i] = strconv.Quote(task.Title)
+		}
+	}
+	return strings.Join(refs, ", ")
+}
+
+// recordAudit appends an operation to the audit log in the data directory.
+func recordAudit(action, source string, tasks []store.AuditTask) error {
+	return store.Audit(action, source, tasks)
+}
+
+// warnAudit records an operation in the audit log, warning on w if it
+// cannot be written.
+func warnAudit(w io.Writer, action, source string, tasks ...store.AuditTask) {
+	if err := recordAudit(action, source, tasks); err != nil {
+		fmt.Fprintf(w, "⚠ Failed to write the audit log: %v\n", err)
+	}
+}
+
+// logAudit records an operation in the audit log from the terminal app,
+// which has nowhere to print a warning, so a failure is only logged.
+func logAudit(action string, tasks ...store.AuditTask) {
+	if err := recordAudit(action, "tui", tasks); err != nil {
+		logging.Logger().Warn("failed to write the audit log", "error", err)
+	}
+}
@@ -1,30 +1,19 @@
 package task
 
 import (
-	"fmt"
-	"opentask/pkg/config"
-	"opentask/pkg/platforms"
+	"os/exec"
+	"runtime"
 )
 
-func createPlatformClient(platformName string, platform config.Platform) (platforms.PlatformClient, error) {
-	// Prepare configuration for platform factory
-	clientConfig := make(map[string]any)
-	
-	// Copy credentials
-	for key, value := range platform.Credentials {
-		clientConfig[key] = value
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
 	}
-	
-	// Copy settings
-	for key, value := range platform.Settings {
-		clientConfig[key] = value
-	}
-	
-	// Create client using registry
-	client, err := platforms.DefaultRegistry.Create(platform.Type, clientConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create %s client: %w", platformName, err)
-	}
-	
-	return client, nil
-}
\ No newline at end of file
+	return cmd.Start()
+}
@@ -3,11 +3,17 @@ package task
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
 	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
 	"opentask/pkg/models"
+	"opentask/pkg/output"
+	"opentask/pkg/service"
+	"opentask/pkg/terminal"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -19,7 +25,37 @@ var listCmd = &cobra.Command{
 	Long: `List tasks from configured platforms.
 	
 You can filter tasks by platform, status, assignee, and other criteria.
-By default, tasks from all enabled platforms are shown.`,
+By default, tasks from all enabled platforms are shown.
+
+--mine (or --assignee me) shows the tasks assigned to the user each
+platform is connected as.
+
+--query searches task titles and descriptions. Platforms that cannot
+search text themselves, or whose search fails, fall back to filtering
+their most recent tasks locally; --explain shows which platforms did.
+
+A platform that fails does not stop the others: its error is listed
+after the table, or in the "errors" array of --format json. Pass
+--strict to exit non-zero when any platform failed.
+
+Use --export-view to turn the current filters into a view token, and
+--view <token> to show the tasks a teammate's token selects, read-only.
+
+--format json, ndjson and csv write each platform's tasks as soon as the
+platform answers, so large exports start flowing at once; tasks then come
+in the order the platforms answer. ndjson prints one task per line.
+
+--template formats each task with a Go template, such as
+'{{.ID}} {{.Title}}', and --jsonpath prints the fields a JSONPath
+expression selects in the list, such as '$[*].id', one per line.
+
+--quiet prints only the task IDs, one per line, for composing with other
+commands (-q is --query):
+  opentask task list --quiet --labels cleanup | xargs -n1 opentask task update --status done
+
+--watch lists the tasks again every --interval, marking new tasks with +
+and changed ones with ~, for wall dashboards and on-call rotations.
+Without a terminal the table is printed again after each refresh.`,
 	RunE: runList,
 }
 
@@ -27,103 +63,191 @@ var (
 	listPlatform    string
 	listStatus      string
 	listAssignee    string
+	listMine        bool
 	listProject     string
 	listLabels      []string
+	listQuery       string
+	listExplain     bool
 	listLimit       int
 	listOffset      int
 	listFormat      string
+	listOutput      output.Options
+	listQuiet       bool
 	listAll         bool
 	listPlain       bool
 	listAllProjects bool
+	listStrict      bool
+	listWatch       bool
+	listInterval    time.Duration
+
+	listView            string
+	listExportView      bool
+	listViewName        string
+	listViewCredentials []string
 )
 
 func init() {
 	listCmd.Flags().StringVarP(&listPlatform, "platform", "p", "", "filter by platform")
 	listCmd.Flags().StringVarP(&listStatus, "status", "s", "", "filter by status (open, in_progress, done, cancelled)")
 	listCmd.Flags().StringVarP(&listAssignee, "assignee", "a", "", "filter by assignee")
+	listCmd.Flags().BoolVar(&listMine, "mine", false, "show only tasks assigned to you")
 	listCmd.Flags().StringVar(&listProject, "project", "", "filter by project")
 	listCmd.Flags().StringSliceVarP(&listLabels, "labels", "l", []string{}, "filter by labels")
+	listCmd.Flags().StringVarP(&listQuery, "query", "q", "", "search task titles and descriptions")
+	listCmd.Flags().BoolVar(&listExplain, "explain", false, "show how each platform ran the query")
 	listCmd.Flags().IntVar(&listLimit, "limit", 20, "maximum number of tasks to show")
 	listCmd.Flags().IntVar(&listOffset, "offset", 0, "number of tasks to skip")
-	listCmd.Flags().StringVarP(&listFormat, "format", "f", "table", "output format (table, json, csv)")
+	listCmd.Flags().StringVarP(&listFormat, "format", "f", "table", "output format (table, json, ndjson, csv)")
+	listOutput.AddFlags(listCmd.Flags())
+	listCmd.Flags().BoolVar(&listQuiet, "quiet", false, "print only task IDs, one per line")
 	listCmd.Flags().BoolVar(&listAll, "all", false, "show tasks from all platforms")
 	listCmd.Flags().BoolVar(&listPlain, "plain", false, "disable interactive mode and output plain text")
 	listCmd.Flags().BoolVar(&listAllProjects, "all-projects", false, "show tasks from all projects (ignore default project)")
+	listCmd.Flags().BoolVar(&listStrict, "strict", false, "exit non-zero if any platform fails")
+	listCmd.Flags().StringVar(&listView, "view", "", "show the tasks selected by a view token (read-only)")
+	listCmd.Flags().BoolVar(&listExportView, "export-view", false, "print a view token for these filters instead of listing tasks")
+	listCmd.Flags().StringVar(&listViewName, "view-name", "", "title shown to guests opening the exported view")
+	listCmd.Flags().StringArrayVar(&listViewCredentials, "view-credential", nil, "credential the guest reads from an environment variable (key=ENV_VAR, repeatable)")
+
+	listCmd.Flags().BoolVar(&listWatch, "watch", false, "refresh the table every --interval")
+	listCmd.Flags().DurationVar(&listInterval, "interval", time.Minute, "how often --watch refreshes the table")
+
+	listCmd.MarkFlagsMutuallyExclusive("mine", "assignee")
+	listCmd.MarkFlagsMutuallyExclusive("format", "template", "jsonpath", "quiet")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
 	manager := config.NewManager()
-	if err := manager.Load(""); err != nil {
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
 	cfg := manager.GetConfig()
 
+	if listView != "" {
+		return runListView(cmd, cfg)
+	}
+	if listExportView {
+		return exportView(cfg)
+	}
+
 	platforms := determinePlatformsForList(cfg)
 	if len(platforms) == 0 {
 		return fmt.Errorf("no platforms configured or enabled")
 	}
 
-	filter := createTaskFilter()
-
-	var allTasks []*models.Task
+	filter := createTaskFilter(cfg)
 
-	for _, platformName := range platforms {
-		platform, exists := cfg.GetPlatform(platformName)
-		if !exists {
-			continue
+	if listWatch {
+		if listFormat != "table" {
+			return exitcode.Wrap(exitcode.Usage, fmt.Errorf("--watch shows a table; it cannot be used with --format %s", listFormat))
 		}
-
-		if !platform.Enabled {
-			continue
+		if listOutput.IsSet() || listQuiet {
+			return exitcode.Wrap(exitcode.Usage, fmt.Errorf("--watch shows a table; it cannot be used with --quiet, --template or --jsonpath"))
 		}
-
-		// Create platform client
-		client, err := createPlatformClient(platformName, platform)
-		if err != nil {
-			fmt.Printf("⚠ Failed to create %s client: %v\n", platformName, err)
-			continue
+		if listInterval < minWatchInterval {
+			return exitcode.Wrap(exitcode.Usage, fmt.Errorf("--interval must be at least %s", minWatchInterval))
 		}
+		return watchTasks(cfg, config.FlagPath(cmd.Flags()), platforms, filter)
+	}
 
-		// Fetch tasks from platform
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+	writer, err := newTaskWriter(os.Stdout, listFormat)
+	if err != nil {
+		return err
+	}
 
-		tasks, err := client.ListTasks(ctx, filter)
+	var failures service.Failures
+	if writer != nil {
+		var listed, written int
+		failures, listed, written, err = streamTasks(cfg, platforms, filter, writer)
 		if err != nil {
-			fmt.Printf("⚠ Failed to list tasks from %s: %v\n", platformName, err)
-			continue
+			return err
+		}
+		if listFormat == "csv" && !listOutput.IsSet() && !listQuiet {
+			// Keep stdout to the header alone.
+			printNoTasks(os.Stderr, listed, written)
 		}
+	} else {
+		list, paginatedTasks := listTasks(cfg, platforms, filter)
+		failures = list.Failures
+		if len(paginatedTasks) == 0 {
+			printNoTasks(os.Stdout, len(list.Tasks), 0)
+		} else if err := printBubbleTasksTable(paginatedTasks, cfg, config.FlagPath(cmd.Flags())); err != nil {
+			return err
+		}
+	}
 
-		allTasks = append(allTasks, tasks...)
+	if listFormat != "json" || listOutput.IsSet() || listQuiet {
+		printFailuresFooter(failures)
+	}
+	if listStrict && len(failures) > 0 {
+		err := fmt.Errorf("failed to list tasks from %s", strings.Join(failures.Platforms(), ", "))
+		if len(failures) < len(platforms) {
+			return exitcode.Wrap(exitcode.Partial, err)
+		}
+		// Nothing was listed, so the exit code is that of the error.
+		return exitcode.Wrap(exitcode.Of(failures[0].Err), err)
 	}
+	return nil
+}
 
-	if len(allTasks) == 0 {
-		fmt.Println("No tasks found matching the criteria.")
-		return nil
+// printNoTasks says why nothing was shown when no task was.
+func printNoTasks(w io.Writer, listed, shown int) {
+	switch {
+	case listed == 0:
+		fmt.Fprintln(w, "No tasks found matching the criteria.")
+	case shown == 0:
+		fmt.Fprintln(w, "No more tasks to show.")
 	}
+}
 
-	// Apply pagination
-	start := listOffset
-	end := start + listLimit
-	if end > len(allTasks) {
-		end = len(allTasks)
+// listTasks fetches the tasks and returns them with the page --offset and
+// --limit select.
+func listTasks(cfg *config.Config, platformNames []string, filter *models.TaskFilter) (*service.TaskList, []*models.Task) {
+	list := fetchTasks(cfg, platformNames, filter)
+
+	var page []*models.Task
+	if start := listOffset; start < len(list.Tasks) {
+		end := min(start+listLimit, len(list.Tasks))
+		page = list.Tasks[start:end]
 	}
+	return list, page
+}
 
-	if start >= len(allTasks) {
-		fmt.Println("No more tasks to show.")
-		return nil
+// fetchTasks lists tasks matching filter from each enabled platform, in
+// each platform's default project unless the filter names a project or
+// --all-projects is set. A platform that fails is skipped and returned in
+// the list's failures.
+func fetchTasks(cfg *config.Config, platformNames []string, filter *models.TaskFilter) *service.TaskList {
+	svc := service.New(cfg)
+	listFn := svc.Tasks.ListInDefaultProjects
+	if listAllProjects {
+		listFn = svc.Tasks.List
+	}
+	list := listFn(context.Background(), platformNames, filter)
+
+	if listExplain {
+		for _, platformName := range svc.Platforms(platformNames) {
+			if result, ok := list.Searches[platformName]; ok {
+				fmt.Fprintf(os.Stderr, "%s: %d task(s), %s\n", platformName, len(result.Tasks), result.Explain())
+			}
+		}
 	}
 
-	paginatedTasks := allTasks[start:end]
+	return list
+}
+
+// printFailuresFooter lists the platforms that failed after the output, on
+// stderr so piped output stays clean.
+func printFailuresFooter(failures service.Failures) {
+	if len(failures) == 0 {
+		return
+	}
 
-	switch listFormat {
-	case "json":
-		return printTasksJSON(paginatedTasks)
-	case "csv":
-		return printTasksCSV(paginatedTasks)
-	default:
-		return printBubbleTasksTable(paginatedTasks)
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintf(os.Stderr, "⚠ %d platform(s) failed, results are incomplete:\n", len(failures))
+	for _, failure := range failures {
+		fmt.Fprintf(os.Stderr, "  %s [%s]: %v\n", failure.Platform, failure.Code(), failure.Err)
 	}
 }
 
@@ -140,7 +264,7 @@ func determinePlatformsForList(cfg *config.Config) []string {
 	return cfg.GetEnabledPlatforms()
 }
 
-func createTaskFilter() *models.TaskFilter {
+func createTaskFilter(cfg *config.Config) *models.TaskFilter {
 	filter := &models.TaskFilter{
 		Limit:  listLimit,
 		Offset: listOffset,
@@ -156,51 +280,50 @@ func createTaskFilter() *models.TaskFilter {
 		filter.Status = &status
 	}
 
-	if listAssignee != "" {
+	if listMine {
+		filter.Assignee = models.AssigneeMe
+	} else if listAssignee != "" {
 		filter.Assignee = listAssignee
 	}
 
 	// Apply project filter logic
-	filter.ProjectID = determineProjectFilter()
+	filter.ProjectID = determineProjectFilter(cfg)
 
 	if len(listLabels) > 0 {
 		filter.Labels = listLabels
 	}
 
+	filter.Query = listQuery
+
 	return filter
 }
 
-func determineProjectFilter() string {
-	// Use explicit project flag if provided
-	if listProject != "" {
-		return listProject
-	}
-
-	// Skip default project if --all-projects flag is set
-	if listAllProjects {
+// determineProjectFilter returns the project given by --project, with
+// aliases resolved. Without it, fetchTasks narrows each platform to its
+// default project.
+func determineProjectFilter(cfg *config.Config) string {
+	if listProject == "" {
 		return ""
 	}
+	return cfg.ResolveProject(listProject)
+}
 
-	// Try to use default project from config
-	manager := config.NewManager()
-	if err := manager.Load(""); err == nil {
-		cfg := manager.GetConfig()
-		if cfg.Defaults.Project != "" {
-			return cfg.Defaults.Project
-		}
+// printBubbleTasksTable shows tasks in the interactive table, or prints it
+// when the output is not a terminal. Column changes are saved to the
+// configuration file at configPath.
+func printBubbleTasksTable(tasks []*models.Task, cfg *config.Config, configPath string) error {
+	interactive, err := terminal.Interactive(cfg.UI.Interactive)
+	if err != nil {
+		return err
 	}
 
-	return ""
-}
-
-func printBubbleTasksTable(tasks []*models.Task) error {
-	manager := config.NewManager()
-	if err := manager.Load(""); err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+	// Print the table directly when the output is piped or --plain is set.
+	if listPlain || !interactive {
+		fmt.Println(NewTaskListModel(tasks, true, cfg).View())
+		return nil
 	}
-	cfg := manager.GetConfig()
 
-	m := NewTaskListModel(tasks, listPlain, cfg)
+	m := NewTaskListModel(tasks, false, cfg).WithConfigPath(configPath)
 
 	p := tea.NewProgram(m)
 	if _, err := p.Run(); err != nil {
@@ -210,36 +333,13 @@ func printBubbleTasksTable(tasks []*models.Task) error {
 	return nil
 }
 
-func printTasksJSON(tasks []*models.Task) error {
-	// In a real implementation, we would use json.Marshal
-	fmt.Println("[")
-	for i, task := range tasks {
-		fmt.Printf(`  {"id": "%s", "title": "%s", "status": "%s", "platform": "%s"}`,
-			task.ID, task.Title, task.Status, task.Platform)
-		if i < len(tasks)-1 {
-			fmt.Println(",")
-		} else {
-			fmt.Println()
-		}
-	}
-	fmt.Println("]")
-
-	return nil
-}
-
-func printTasksCSV(tasks []*models.Task) error {
-	// Print header
-	fmt.Println("ID,Platform,Status,Priority,Title")
-
-	// Print tasks
-	for _, task := range tasks {
-		fmt.Printf("%s,%s,%s,%s,%s\n",
-			task.ID,
-			task.Platform,
-			task.Status,
-			task.Priority,
-			task.Title)
-	}
-
-	return nil
+// taskJSON is a task as --format json and ndjson print it. json prints
+// {"tasks": [...], "errors": [...]}, with the platforms that failed to
+// return theirs under errors.
+type taskJSON struct {
+	ID       string            `json:"id"`
+	Ref      string            `json:"ref"`
+	Title    string            `json:"title"`
+	Status   models.TaskStatus `json:"status"`
+	Platform models.Platform   `json:"platform"`
 }
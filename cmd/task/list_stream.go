@@ -0,0 +1,221 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+	"opentask/pkg/output"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+)
+
+// taskWriter writes tasks in one of the machine-readable formats as they
+// are handed to it, so a long listing is not held in memory and a pipe can
+// start reading before the last platform answers.
+type taskWriter interface {
+	Write(task *models.Task) error
+	// Close ends the output; failures are the platforms that did not
+	// answer.
+	Close(failures service.Failures) error
+}
+
+// newTaskWriter returns the writer for --quiet, --template or --jsonpath,
+// or else for --format, or nil for the table.
+func newTaskWriter(w io.Writer, format string) (taskWriter, error) {
+	if listQuiet {
+		return &idTaskWriter{w: w}, nil
+	}
+	formatter, err := listOutput.Formatter()
+	if err != nil {
+		return nil, err
+	}
+	if formatter != nil {
+		return &formatTaskWriter{list: formatter.List(w)}, nil
+	}
+
+	switch format {
+	case "json":
+		return &jsonTaskWriter{w: w}, nil
+	case "ndjson":
+		return &ndjsonTaskWriter{encoder: json.NewEncoder(w)}, nil
+	case "csv":
+		return &csvTaskWriter{w: csv.NewWriter(w)}, nil
+	}
+	return nil, nil
+}
+
+func newTaskJSON(task *models.Task) taskJSON {
+	return taskJSON{ID: task.ID, Ref: task.Ref(), Title: task.Title, Status: task.Status, Platform: task.Platform}
+}
+
+// jsonTaskWriter writes the {"tasks": [...], "errors": [...]} document a
+// piece at a time, laid out as json.Encoder with a two-space indent would.
+type jsonTaskWriter struct {
+	w       io.Writer
+	written int
+}
+
+func (j *jsonTaskWriter) Write(task *models.Task) error {
+	data, err := json.MarshalIndent(newTaskJSON(task), "    ", "  ")
+	if err != nil {
+		return err
+	}
+	prefix := ",\n    "
+	if j.written == 0 {
+		prefix = "{\n  \"tasks\": [\n    "
+	}
+	j.written++
+	_, err = fmt.Fprintf(j.w, "%s%s", prefix, data)
+	return err
+}
+
+func (j *jsonTaskWriter) Close(failures service.Failures) error {
+	if failures == nil {
+		failures = service.Failures{}
+	}
+	data, err := json.MarshalIndent(failures, "  ", "  ")
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	if j.written == 0 {
+		out.WriteString("{\n  \"tasks\": [],\n")
+	} else {
+		out.WriteString("\n  ],\n")
+	}
+	fmt.Fprintf(&out, "  \"errors\": %s\n}\n", data)
+	_, err = j.w.Write(out.Bytes())
+	return err
+}
+
+// ndjsonTaskWriter writes one task per line. Failed platforms are left to
+// the footer on stderr, so every line stays a task.
+type ndjsonTaskWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonTaskWriter) Write(task *models.Task) error {
+	return n.encoder.Encode(newTaskJSON(task))
+}
+
+func (n *ndjsonTaskWriter) Close(failures service.Failures) error {
+	return nil
+}
+
+// csvTaskWriter writes a header, then each task as a CSV record. The
+// header is written even when there are no tasks.
+type csvTaskWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (c *csvTaskWriter) writeHeader() error {
+	if c.header {
+		return nil
+	}
+	c.header = true
+	return c.w.Write([]string{"ID", "Platform", "Status", "Priority", "Title"})
+}
+
+func (c *csvTaskWriter) Write(task *models.Task) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	if err := c.w.Write([]string{
+		task.ID,
+		string(task.Platform),
+		string(task.Status),
+		string(task.Priority),
+		task.Title,
+	}); err != nil {
+		return err
+	}
+	// Each record goes out as soon as it is known.
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvTaskWriter) Close(failures service.Failures) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// idTaskWriter writes the ID of each task on its own line, for xargs.
+type idTaskWriter struct {
+	w io.Writer
+}
+
+func (i *idTaskWriter) Write(task *models.Task) error {
+	_, err := fmt.Fprintln(i.w, task.ID)
+	return err
+}
+
+func (i *idTaskWriter) Close(failures service.Failures) error {
+	return nil
+}
+
+// formatTaskWriter writes tasks with the user's template or JSONPath
+// expression. Failed platforms are left to the footer on stderr.
+type formatTaskWriter struct {
+	list *output.ListWriter
+}
+
+func (f *formatTaskWriter) Write(task *models.Task) error {
+	return f.list.Add(task)
+}
+
+func (f *formatTaskWriter) Close(failures service.Failures) error {
+	return f.list.Close()
+}
+
+// writeTasks writes tasks with writer and ends the output.
+func writeTasks(writer taskWriter, tasks []*models.Task, failures service.Failures) error {
+	for _, task := range tasks {
+		if err := writer.Write(task); err != nil {
+			return err
+		}
+	}
+	return writer.Close(failures)
+}
+
+// streamTasks writes the tasks of each platform in --format as the
+// platform answers, keeping to the page --offset and --limit select across
+// platforms. It returns how many tasks the platforms listed and how many
+// were written.
+func streamTasks(cfg *config.Config, platformNames []string, filter *models.TaskFilter, writer taskWriter) (service.Failures, int, int, error) {
+	svc := service.New(cfg)
+	streamFn := svc.Tasks.StreamInDefaultProjects
+	if listAllProjects {
+		streamFn = svc.Tasks.Stream
+	}
+
+	listed, written := 0, 0
+	failures := streamFn(context.Background(), platformNames, filter, func(name string, result *platforms.SearchResult) error {
+		if listExplain {
+			fmt.Fprintf(os.Stderr, "%s: %d task(s), %s\n", name, len(result.Tasks), result.Explain())
+		}
+		for _, task := range result.Tasks {
+			listed++
+			if listed <= listOffset || written >= listLimit {
+				continue
+			}
+			if err := writer.Write(task); err != nil {
+				return err
+			}
+			written++
+		}
+		return nil
+	})
+	return failures, listed, written, writer.Close(failures)
+}
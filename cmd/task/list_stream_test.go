@@ -0,0 +1,40 @@
+package task
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"opentask/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVTaskWriter(t *testing.T) {
+	var out bytes.Buffer
+	writer, err := newTaskWriter(&out, "csv")
+	require.NoError(t, err)
+
+	require.NoError(t, writeTasks(writer, []*models.Task{
+		{ID: "API-1", Platform: "jira", Status: models.StatusOpen, Priority: models.PriorityHigh, Title: `Fix login, then "logout"`},
+		{ID: "ENG-2", Platform: "linear", Status: models.StatusDone, Priority: models.PriorityLow, Title: "Two\nlines"},
+	}, nil))
+
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"ID", "Platform", "Status", "Priority", "Title"},
+		{"API-1", "jira", "open", "high", `Fix login, then "logout"`},
+		{"ENG-2", "linear", "done", "low", "Two\nlines"},
+	}, records)
+}
+
+func TestCSVTaskWriter_NoTasks(t *testing.T) {
+	var out bytes.Buffer
+	writer, err := newTaskWriter(&out, "csv")
+	require.NoError(t, err)
+
+	require.NoError(t, writeTasks(writer, nil, nil))
+	assert.Equal(t, "ID,Platform,Status,Priority,Title\n", out.String())
+}
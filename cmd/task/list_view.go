@@ -0,0 +1,226 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+	"opentask/pkg/styles"
+	"opentask/pkg/viewtoken"
+
+	"github.com/spf13/cobra"
+)
+
+// viewFilterFlags select tasks and so cannot be combined with --view, whose
+// token already carries the filter.
+var viewFilterFlags = []string{"platform", "status", "assignee", "project", "labels", "query", "all", "all-projects", "limit"}
+
+// exportView prints a view token for the current list filters.
+func exportView(cfg *config.Config) error {
+	platformName := listPlatform
+	if platformName == "" {
+		platformName = cfg.Defaults.Platform
+	}
+	if platformName == "" {
+		if enabled := cfg.GetEnabledPlatforms(); len(enabled) == 1 {
+			platformName = enabled[0]
+		}
+	}
+	if platformName == "" {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("a view covers one platform; choose it with --platform"))
+	}
+
+	platform, exists := cfg.GetPlatform(platformName)
+	if !exists {
+		return fmt.Errorf("platform %s not configured", platformName)
+	}
+
+	credentials, err := parseViewCredentials(listViewCredentials)
+	if err != nil {
+		return err
+	}
+
+	filter := createTaskFilter(cfg)
+	if filter.ProjectID == "" && !listAllProjects {
+		filter.ProjectID = cfg.DefaultProject(platformName)
+	}
+	view := viewtoken.View{
+		Name:        listViewName,
+		Platform:    platform.Type,
+		Settings:    viewSettings(platform.Settings),
+		Project:     filter.ProjectID,
+		Status:      listStatus,
+		Assignee:    filter.Assignee,
+		Labels:      listLabels,
+		Limit:       listLimit,
+		Credentials: credentials,
+	}
+
+	token, err := viewtoken.Encode(view)
+	if err != nil {
+		return err
+	}
+
+	// Only the token goes to stdout so it can be piped or copied as is.
+	fmt.Println(token)
+	fmt.Fprintf(os.Stderr, "✓ View token for %s; share it and run: opentask task list --view <token>\n", platformName)
+	if len(credentials) == 0 {
+		fmt.Fprintf(os.Stderr, "⚠ No --view-credential given; guests need %s connected themselves\n", platform.Type)
+	} else {
+		for _, key := range view.CredentialKeys() {
+			fmt.Fprintf(os.Stderr, "  guests set %s to a read-only %s\n", credentials[key], key)
+		}
+	}
+	return nil
+}
+
+// parseViewCredentials reads key=ENV_VAR credential references.
+func parseViewCredentials(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	credentials := make(map[string]string, len(values))
+	for _, value := range values {
+		key, env, ok := strings.Cut(value, "=")
+		if !ok || key == "" || env == "" {
+			return nil, exitcode.Wrap(exitcode.Usage, fmt.Errorf("invalid --view-credential %q (use key=ENV_VAR, e.g. token=JIRA_READONLY_TOKEN)", value))
+		}
+		credentials[key] = env
+	}
+	return credentials, nil
+}
+
+// viewSettings copies the platform settings a guest needs to read tasks the
+// same way. The registered webhook only matters to the owner.
+func viewSettings(settings map[string]any) map[string]any {
+	if len(settings) == 0 {
+		return nil
+	}
+
+	copied := make(map[string]any, len(settings))
+	for key, value := range settings {
+		if key == platforms.WebhookKey {
+			continue
+		}
+		copied[key] = value
+	}
+	return copied
+}
+
+// runListView lists the tasks selected by a view token. Guest views are
+// read-only, so tasks are always printed rather than opened in the
+// interactive list.
+func runListView(cmd *cobra.Command, cfg *config.Config) error {
+	for _, name := range viewFilterFlags {
+		if cmd.Flags().Changed(name) {
+			return exitcode.Wrap(exitcode.Usage, fmt.Errorf("--view cannot be combined with --%s; the token sets the filter", name))
+		}
+	}
+
+	view, err := viewtoken.Decode(listView)
+	if err != nil {
+		return err
+	}
+
+	platformName, platform, err := viewPlatform(cfg, view)
+	if err != nil {
+		return err
+	}
+
+	client, err := service.NewClient(platformName, platform)
+	if err != nil {
+		return err
+	}
+
+	filter := view.Filter()
+	if filter.Limit == 0 {
+		filter.Limit = listLimit
+	}
+	filter.Offset = listOffset
+
+	ctx, cancel := service.WithRequestTimeout(context.Background())
+	defer cancel()
+
+	tasks, err := client.ListTasks(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks from %s: %w", platformName, err)
+	}
+	if len(tasks) > filter.Limit {
+		tasks = tasks[:filter.Limit]
+	}
+
+	writer, err := newTaskWriter(os.Stdout, listFormat)
+	if err != nil {
+		return err
+	}
+	if writer != nil {
+		return writeTasks(writer, tasks, nil)
+	}
+
+	if view.Name != "" {
+		fmt.Println(styles.Title().Render(view.Name))
+	}
+	if len(tasks) == 0 {
+		fmt.Println("No tasks found matching the criteria.")
+		return nil
+	}
+	fmt.Println(NewTaskListModel(tasks, true, cfg).View())
+	return nil
+}
+
+// viewPlatform returns the platform to read a view from. Credentials
+// referenced by the token are read from the environment; otherwise the
+// guest's own connection to the same platform is used.
+func viewPlatform(cfg *config.Config, view viewtoken.View) (string, config.Platform, error) {
+	if len(view.Credentials) > 0 {
+		platform := config.Platform{
+			Type:        view.Platform,
+			Enabled:     true,
+			Credentials: make(map[string]string, len(view.Credentials)),
+			Settings:    view.Settings,
+		}
+
+		var missing []string
+		for _, key := range view.CredentialKeys() {
+			env := view.Credentials[key]
+			value := os.Getenv(env)
+			if value == "" {
+				missing = append(missing, env)
+				continue
+			}
+			platform.Credentials[key] = value
+		}
+		if len(missing) > 0 {
+			return "", config.Platform{}, fmt.Errorf("this view reads credentials from the environment; set %s", strings.Join(missing, ", "))
+		}
+		return view.Platform, platform, nil
+	}
+
+	baseURL, _ := view.Settings["base_url"].(string)
+	for _, name := range cfg.GetEnabledPlatforms() {
+		platform, _ := cfg.GetPlatform(name)
+		if platform.Type != view.Platform {
+			continue
+		}
+		if configured, _ := platform.Settings["base_url"].(string); baseURL != "" && !sameURL(configured, baseURL) {
+			continue
+		}
+		return name, platform, nil
+	}
+
+	server := view.Platform
+	if baseURL != "" {
+		server = fmt.Sprintf("%s (%s)", view.Platform, baseURL)
+	}
+	return "", config.Platform{}, fmt.Errorf("this view has no credential references; connect to %s first", server)
+}
+
+func sameURL(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "/"), strings.TrimSuffix(b, "/"))
+}
@@ -0,0 +1,100 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+	"opentask/pkg/terminal"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+)
+
+// minWatchInterval keeps --watch from hammering the platforms.
+const minWatchInterval = 5 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// watchTasks shows the tasks and lists them again every --interval until
+// interrupted: in the interactive table when there is a terminal, and
+// otherwise by printing the table after each refresh.
+func watchTasks(cfg *config.Config, configPath string, platformNames []string, filter *models.TaskFilter) error {
+	interactive, err := terminal.Interactive(cfg.UI.Interactive)
+	if err != nil {
+		return err
+	}
+	if listPlain || !interactive {
+		return printWatchedTasks(cfg, platformNames, filter)
+	}
+
+	list, page := listTasks(cfg, platformNames, filter)
+	m := NewTaskListModel(page, false, cfg).WithConfigPath(configPath)
+	m.watch = listInterval
+	m.load = func() tasksLoadedMsg {
+		list, page := listTasks(cfg, platformNames, filter)
+		return tasksLoadedMsg{tasks: page, failed: list.Failures.Platforms()}
+	}
+	if len(list.Failures) > 0 {
+		m, _ = m.showToast(fmt.Sprintf("⚠ Failed to list tasks from %s", strings.Join(list.Failures.Platforms(), ", ")), true)
+	}
+
+	if _, err := tea.NewProgram(m).Run(); err != nil {
+		return fmt.Errorf("failed to run watch view: %w", err)
+	}
+	return nil
+}
+
+// printWatchedTasks prints the table after every refresh, clearing the
+// screen first when stdout is a terminal.
+func printWatchedTasks(cfg *config.Config, platformNames []string, filter *models.TaskFilter) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	tty := term.IsTerminal(int(os.Stdout.Fd()))
+	ticker := time.NewTicker(listInterval)
+	defer ticker.Stop()
+
+	var previous []*models.Task
+	for first := true; ; first = false {
+		list, page := listTasks(cfg, platformNames, filter)
+
+		m := NewTaskListModel(page, true, cfg)
+		m.watch = listInterval
+		if !first {
+			m.changes = taskChanges(previous, page)
+		}
+		previous = page
+
+		switch {
+		case tty:
+			fmt.Print(clearScreen)
+		case !first:
+			fmt.Println()
+		}
+		header := fmt.Sprintf("Every %s: %s", listInterval, time.Now().Format("15:04:05"))
+		if !first {
+			header += ", " + changeSummary(m.changes)
+		}
+		fmt.Printf("%s\n\n", header)
+		if len(page) == 0 {
+			fmt.Println("No tasks found matching the criteria.")
+		} else {
+			fmt.Println(m.View())
+		}
+		printFailuresFooter(list.Failures)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"opentask/pkg/config"
+	"opentask/pkg/notify"
+	"opentask/pkg/service"
+)
+
+// notifyTasks posts events to the notifiers configured under
+// notifications, warning on w about the posts that fail. A notification
+// that cannot be sent never fails the command that made the change.
+func notifyTasks(w io.Writer, cfg *config.Config, events ...notify.Event) {
+	if len(events) == 0 {
+		return
+	}
+	dispatcher, err := notify.FromConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(w, "⚠ Notifications are not sent: %v\n", err)
+		return
+	}
+	if dispatcher.Len() == 0 {
+		return
+	}
+
+	ctx, cancel := service.WithRequestTimeout(context.Background())
+	defer cancel()
+	for _, failure := range dispatcher.Notify(ctx, events...) {
+		fmt.Fprintf(w, "⚠ Failed to notify %s about %s: %v\n", failure.Notifier, failure.About(), failure.Err)
+	}
+}
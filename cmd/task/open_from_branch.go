@@ -0,0 +1,70 @@
+package task
+
+import (
+	"fmt"
+
+	"opentask/pkg/config"
+	"opentask/pkg/git"
+
+	"github.com/spf13/cobra"
+)
+
+var openFromBranchCmd = &cobra.Command{
+	Use:   "open-from-branch",
+	Short: "Show the task referenced by the current git branch",
+	Long: `Detect the task ID in the current git branch name (for example
+feat/TEST-123-fix-login) and show the task. Use --web to open it in
+the browser instead.`,
+	Args: cobra.NoArgs,
+	RunE: runOpenFromBranch,
+}
+
+var (
+	openFromBranchPlatform string
+	openFromBranchWeb      bool
+)
+
+func init() {
+	openFromBranchCmd.Flags().StringVarP(&openFromBranchPlatform, "platform", "p", "", "specify platform if task ID is ambiguous")
+	openFromBranchCmd.Flags().BoolVarP(&openFromBranchWeb, "web", "w", false, "open the task in the browser")
+}
+
+func runOpenFromBranch(cmd *cobra.Command, args []string) error {
+	branch, err := git.CurrentBranch()
+	if err != nil {
+		return err
+	}
+
+	taskID, ok := git.ExtractTaskID(branch)
+	if !ok {
+		return fmt.Errorf("no task ID found in branch %q", branch)
+	}
+
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+
+	task, _, err := findTaskByID(cfg, taskID, openFromBranchPlatform)
+	if err != nil {
+		return err
+	}
+
+	if openFromBranchWeb {
+		url := task.URL()
+		if url == "" {
+			return fmt.Errorf("no web URL available for task %s", task.ID)
+		}
+		fmt.Printf("Opening %s\n", url)
+		return openBrowser(url)
+	}
+
+	fmt.Print(formatTaskDetail(task))
+	if url := task.URL(); url != "" {
+		fmt.Printf("\nURL: %s\n", url)
+	}
+
+	return nil
+}
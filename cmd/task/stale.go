@@ -0,0 +1,375 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/models"
+	"opentask/pkg/notify"
+	"opentask/pkg/platforms"
+	"opentask/pkg/report"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+
+	"github.com/spf13/cobra"
+)
+
+var staleCmd = &cobra.Command{
+	Use:   "stale",
+	Short: "List and clean up tasks without recent updates",
+	Long: `List the open tasks of every enabled platform that have not been
+updated in --days days, least recently updated first, to groom backlogs
+across platforms in one pass.
+
+Any of these actions are then applied to every stale task:
+  --label    add a label ("stale" unless one is given, as in --label=rotting)
+  --ping     comment asking the assignee whether the task is still needed;
+             unassigned tasks are skipped
+  --close    close the task as cancelled
+
+The actions are confirmed first unless --yes is given. Closing more tasks
+than ui.bulk_confirm_threshold (10 by default) asks you to type a phrase
+such as "close 25 tasks" instead, unless --force is given.
+Labels and closes are recorded in ~/.opentask/audit.log; see 'opentask
+history'. --dry-run shows what would change without changing it.
+
+--quiet prints only the IDs of the stale tasks, one per line, to pipe
+them into other commands.
+
+Examples:
+  opentask task stale --days 30
+  opentask task stale --days 30 --label --ping --yes
+  opentask task stale --days 90 --project API --close --dry-run`,
+	RunE: runStale,
+}
+
+var (
+	staleDays        int
+	stalePlatform    string
+	staleProject     string
+	staleAllProjects bool
+	staleLimit       int
+	staleLabel       string
+	stalePing        bool
+	staleMessage     string
+	staleClose       bool
+	staleDryRun      bool
+	staleYes         bool
+	staleForce       bool
+	staleQuiet       bool
+)
+
+// defaultStaleLabel is the label --label adds without a value.
+const defaultStaleLabel = "stale"
+
+func init() {
+	staleCmd.Flags().IntVar(&staleDays, "days", 30, "days without an update after which a task is stale")
+	staleCmd.Flags().StringVarP(&stalePlatform, "platform", "p", "", "limit to platform")
+	staleCmd.Flags().StringVar(&staleProject, "project", "", "limit to project")
+	staleCmd.Flags().BoolVar(&staleAllProjects, "all-projects", false, "include all projects (ignore default project)")
+	staleCmd.Flags().IntVar(&staleLimit, "limit", 500, "maximum number of stale tasks to find per platform and status, least recently updated first")
+	staleCmd.Flags().StringVar(&staleLabel, "label", "", "add a label to the stale tasks (default \""+defaultStaleLabel+"\")")
+	staleCmd.Flags().Lookup("label").NoOptDefVal = defaultStaleLabel
+	staleCmd.Flags().BoolVar(&stalePing, "ping", false, "comment asking each assignee whether the task is still needed")
+	staleCmd.Flags().StringVar(&staleMessage, "message", "", "comment to ping with instead of the default")
+	staleCmd.Flags().BoolVar(&staleClose, "close", false, "close the stale tasks as cancelled")
+	staleCmd.Flags().BoolVar(&staleDryRun, "dry-run", false, "show what would change without changing it")
+	staleCmd.Flags().BoolVarP(&staleYes, "yes", "y", false, "do not ask for confirmation before changing the stale tasks")
+	staleCmd.Flags().BoolVar(&staleForce, "force", false, "do not ask for the confirmation phrase when closing many tasks")
+	staleCmd.Flags().BoolVarP(&staleQuiet, "quiet", "q", false, "print only the IDs of the stale tasks, one per line")
+}
+
+// staleTask is a stale task and the configured platform it is on.
+type staleTask struct {
+	task     *models.Task
+	platform string
+}
+
+func runStale(cmd *cobra.Command, args []string) error {
+	if staleDays < 1 {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("--days must be at least 1"))
+	}
+	if staleMessage != "" && !stalePing {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("--message is only used with --ping"))
+	}
+	if staleQuiet && (staleLabel != "" || stalePing || staleClose) {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("--quiet only lists the stale tasks; it cannot be used with --label, --ping or --close"))
+	}
+
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+	if len(cfg.GetEnabledPlatforms()) == 0 {
+		return fmt.Errorf("no platforms configured or enabled")
+	}
+
+	svc := service.New(cfg)
+	now := time.Now()
+	tasks := findStaleTasks(svc, now.AddDate(0, 0, -staleDays))
+	if staleQuiet {
+		for _, stale := range tasks {
+			fmt.Println(stale.task.ID)
+		}
+		return nil
+	}
+	if len(tasks) == 0 {
+		fmt.Printf("No open tasks without an update in %d days\n", staleDays)
+		return nil
+	}
+
+	for _, stale := range tasks {
+		fmt.Printf("%s %s (%s, %s)\n", stale.task.ID, stale.task.Title, idleFor(stale.task, now), assigneeName(stale.task))
+	}
+	fmt.Printf("\n%d task(s) without an update in %d days\n", len(tasks), staleDays)
+
+	if staleLabel == "" && !stalePing && !staleClose {
+		return nil
+	}
+	if staleDryRun {
+		fmt.Printf("Would %s\n", describeStaleActions())
+		return nil
+	}
+
+	actions := describeStaleActions()
+	verb := actions
+	if staleClose {
+		verb = "close"
+	}
+	staleTasks := make([]*models.Task, len(tasks))
+	for i, stale := range tasks {
+		staleTasks[i] = stale.task
+	}
+	ok, err := Confirm(cfg, Confirmation{
+		Verb:     verb,
+		Question: fmt.Sprintf("%s%s %d task(s)?", strings.ToUpper(actions[:1]), actions[1:], len(tasks)),
+		Tasks:    staleTasks,
+		Listed:   true,
+		Phrase:   staleClose,
+	}, staleYes, staleForce)
+	if !ok {
+		return err
+	}
+
+	fmt.Println()
+	var (
+		audit  []store.AuditTask
+		events []notify.Event
+		failed int
+	)
+	for _, stale := range tasks {
+		previous := *stale.task
+		updated, err := cleanUpStaleTask(svc, stale, now)
+		if change := staleUpdate(&previous); change != nil {
+			audit = append(audit, store.NewAuditUpdate(&previous, change, err))
+		}
+		if err != nil {
+			failed++
+			fmt.Printf("✗ %s: %v\n", stale.task.ID, err)
+			continue
+		}
+		fmt.Printf("✓ %s %s\n", stale.task.ID, stale.task.Title)
+		if updated != nil {
+			events = append(events, notify.Event{Kind: notify.KindUpdated, Platform: stale.platform, Task: updated, Previous: &previous})
+		}
+	}
+
+	action := store.AuditUpdate
+	if staleClose {
+		action = store.AuditClose
+	}
+	warnAudit(os.Stdout, action, "cli", audit...)
+	notifyTasks(os.Stdout, cfg, events...)
+
+	if failed > 0 {
+		return exitcode.WrapPartial(failed, len(tasks), fmt.Errorf("failed to clean up %d of %d tasks", failed, len(tasks)))
+	}
+	return nil
+}
+
+// findStaleTasks lists the open and in-progress tasks of the selected
+// platforms and returns those last updated before before.
+func findStaleTasks(svc *service.Service, before time.Time) []staleTask {
+	var names []string
+	if stalePlatform != "" {
+		names = []string{stalePlatform}
+	}
+
+	listFn := svc.Tasks.ListInDefaultProjects
+	if staleAllProjects {
+		listFn = svc.Tasks.List
+	}
+
+	var (
+		tasks    []*models.Task
+		failures service.Failures
+	)
+	platformOf := make(map[*models.Task]string)
+	seen := make(map[string]bool)
+	failed := make(map[string]bool)
+	for _, status := range []models.TaskStatus{models.StatusOpen, models.StatusInProgress} {
+		// The platforms filter by update time so the limit applies to the
+		// stale tasks rather than to the most recent ones.
+		filter := &models.TaskFilter{Status: &status, Limit: staleLimit, UpdatedBefore: &before}
+		if staleProject != "" {
+			filter.ProjectID = svc.Config().ResolveProject(staleProject)
+		}
+
+		list := listFn(context.Background(), names, filter)
+		for name, result := range list.Searches {
+			for _, task := range result.Tasks {
+				// A platform whose statuses map loosely may list a task
+				// under both.
+				if key := name + ":" + task.ID; !seen[key] {
+					seen[key] = true
+					platformOf[task] = name
+					tasks = append(tasks, task)
+				}
+			}
+		}
+		for _, failure := range list.Failures {
+			if !failed[failure.Platform] {
+				failed[failure.Platform] = true
+				failures = append(failures, failure)
+			}
+		}
+	}
+	failures.Report(os.Stderr, "list tasks")
+
+	var stale []staleTask
+	for _, task := range report.Stale(tasks, before) {
+		stale = append(stale, staleTask{task: task, platform: platformOf[task]})
+	}
+	return stale
+}
+
+// cleanUpStaleTask applies the chosen actions to one task: the label and
+// the new status in one update, then the comment. It returns the updated
+// task, or nil when only a comment was added.
+func cleanUpStaleTask(svc *service.Service, stale staleTask, now time.Time) (*models.Task, error) {
+	client, err := svc.Client(stale.platform)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := service.WithRequestTimeout(context.Background())
+	defer cancel()
+
+	settings := svc.Config().Platforms[stale.platform].Settings
+	if staleLabel != "" {
+		if err := platforms.RequireCapability(stale.platform, settings, platforms.CapabilityUpdateTask); err != nil {
+			return nil, err
+		}
+	}
+	if staleClose {
+		if err := platforms.RequireCapability(stale.platform, settings, platforms.CapabilityTransitionTask); err != nil {
+			return nil, err
+		}
+	}
+
+	var commenter platforms.Commenter
+	ping := stalePing && stale.task.Assignee != nil
+	if ping {
+		var ok bool
+		if commenter, ok = client.(platforms.Commenter); !ok {
+			return nil, fmt.Errorf("%s cannot add comments", stale.platform)
+		}
+	}
+
+	var updated *models.Task
+	if task := staleUpdate(stale.task); task != nil {
+		if staleClose {
+			if err := platforms.ValidateTransition(ctx, client, stale.task, models.StatusCancelled); err != nil {
+				var invalid *platforms.InvalidTransitionError
+				if errors.As(err, &invalid) {
+					return nil, invalid
+				}
+				return nil, fmt.Errorf("failed to check allowed transitions: %w", err)
+			}
+		}
+		if updated, err = client.UpdateTask(ctx, task); err != nil {
+			return nil, fmt.Errorf("failed to update task: %w", err)
+		}
+	}
+
+	if ping {
+		if err := commenter.AddComment(ctx, stale.task.ID, staleComment(stale.task, now, commenter)); err != nil {
+			return updated, fmt.Errorf("failed to add comment: %w", err)
+		}
+	}
+	return updated, nil
+}
+
+// staleUpdate returns task with the stale label added and, with --close,
+// cancelled, or nil if neither changes it.
+func staleUpdate(task *models.Task) *models.Task {
+	if (staleLabel == "" || task.HasLabel(staleLabel)) && !staleClose {
+		return nil
+	}
+	updated := *task
+	updated.Labels = append([]string(nil), task.Labels...)
+	if staleLabel != "" {
+		updated.AddLabel(staleLabel)
+	}
+	if staleClose {
+		updated.SetStatus(models.StatusCancelled)
+	}
+	return &updated
+}
+
+// staleComment is the comment --ping adds, addressed to the assignee with
+// a mention the platform notifies them of.
+func staleComment(task *models.Task, now time.Time, commenter platforms.Commenter) string {
+	message := staleMessage
+	if message == "" {
+		message = fmt.Sprintf("this task has had no update in %d days. Is it still needed? If not, it may be closed.",
+			int(now.Sub(task.UpdatedAt).Hours()/24))
+	}
+	return platforms.Mention(commenter, task.Assignee) + " " + message
+}
+
+// describeStaleActions names the chosen actions, such as "label stale,
+// ping assignees and close".
+func describeStaleActions() string {
+	var actions []string
+	if staleLabel != "" {
+		actions = append(actions, "label "+staleLabel)
+	}
+	if stalePing {
+		actions = append(actions, "ping assignees")
+	}
+	if staleClose {
+		actions = append(actions, "close")
+	}
+	if len(actions) == 1 {
+		return actions[0]
+	}
+	return strings.Join(actions[:len(actions)-1], ", ") + " and " + actions[len(actions)-1]
+}
+
+// idleFor describes how long ago task was updated, such as "42 days".
+func idleFor(task *models.Task, now time.Time) string {
+	days := int(now.Sub(task.UpdatedAt).Hours() / 24)
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
+
+func assigneeName(task *models.Task) string {
+	if task.Assignee == nil {
+		return "unassigned"
+	}
+	if task.Assignee.Name != "" {
+		return task.Assignee.Name
+	}
+	return task.Assignee.Email
+}
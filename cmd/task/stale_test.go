@@ -0,0 +1,98 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/platforms/jira"
+	"opentask/pkg/platforms/linear"
+	"opentask/pkg/platforms/mock"
+	"opentask/pkg/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// plainCommenter is a commenter without mentions.
+type plainCommenter struct{}
+
+func (plainCommenter) AddComment(context.Context, string, string) error { return nil }
+
+func TestStaleComment(t *testing.T) {
+	jiraClient, err := jira.NewFactory().Create(map[string]any{
+		"base_url": "https://example.atlassian.net",
+		"email":    "test@example.com",
+		"token":    "token123",
+	})
+	require.NoError(t, err)
+	linearClient, err := linear.NewClient(linear.Config{Token: "lin_api_test"})
+	require.NoError(t, err)
+
+	now := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		commenter platforms.Commenter
+		assignee  *models.User
+		want      string
+	}{
+		{
+			name:      "jira",
+			commenter: jiraClient.(platforms.Commenter),
+			assignee:  &models.User{Name: "Jane Doe", Metadata: map[string]any{"jira_account_id": "5b10ac8d82e05b22cc7d4ef5"}},
+			want:      "[~accountid:5b10ac8d82e05b22cc7d4ef5] ",
+		},
+		{
+			name:      "linear",
+			commenter: linearClient,
+			assignee:  (&linear.LinearUser{ID: "user-1", DisplayName: "jane", URL: "https://linear.app/acme/profiles/jane"}).ToUser(),
+			want:      "https://linear.app/acme/profiles/jane ",
+		},
+		{
+			name:      "no mentions",
+			commenter: plainCommenter{},
+			assignee:  &models.User{Name: "Jane Doe"},
+			want:      "@Jane Doe ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &models.Task{Assignee: tt.assignee, UpdatedAt: now.AddDate(0, 0, -30)}
+			assert.Equal(t, tt.want+"this task has had no update in 30 days. Is it still needed? If not, it may be closed.",
+				staleComment(task, now, tt.commenter))
+		})
+	}
+}
+
+func TestFindStaleTasks_BeyondFirstPage(t *testing.T) {
+	now := time.Now()
+	tasks := []*models.Task{{ID: "DEMO-1", Title: "Forgotten", Status: models.StatusOpen, ProjectID: "DEMO", UpdatedAt: now.AddDate(0, 0, -90)}}
+	for i := 2; i <= 5; i++ {
+		tasks = append(tasks, &models.Task{ID: fmt.Sprintf("DEMO-%d", i), Title: "Recent", Status: models.StatusOpen, ProjectID: "DEMO", UpdatedAt: now.AddDate(0, 0, -i)})
+	}
+	data, err := json.Marshal(map[string]any{"next_id": 6, "tasks": tasks})
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "tasks.json")
+	require.NoError(t, os.WriteFile(file, data, 0o600))
+
+	cfg := &config.Config{Platforms: map[string]config.Platform{
+		"mock": {Type: "mock", Enabled: true, Settings: map[string]any{mock.FileKey: file}},
+	}}
+
+	// The stale task is older than a page of the most recent tasks.
+	defer func(limit int, all bool) { staleLimit, staleAllProjects = limit, all }(staleLimit, staleAllProjects)
+	staleLimit, staleAllProjects = 2, true
+
+	stale := findStaleTasks(service.New(cfg), now.AddDate(0, 0, -30))
+	require.Len(t, stale, 1)
+	assert.Equal(t, "DEMO-1", stale[0].task.ID)
+	assert.Equal(t, "mock", stale[0].platform)
+}
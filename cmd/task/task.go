@@ -9,11 +9,19 @@ var TaskCmd = &cobra.Command{
 	Short: "Manage tasks across platforms",
 	Long: `Manage tasks across multiple platforms including Linear, Jira, Slack, and GitHub.
 	
-This command provides subcommands for creating, listing, updating, and deleting tasks.`,
+This command provides subcommands for creating, listing, updating, and deleting tasks.
+
+Commands that take a task ID also accept a reference that names the
+platform, such as jira:TEST-123 or linear:ENG-45. A bare ID is looked up on
+every enabled platform, which fails if more than one has it.`,
 }
 
 func init() {
 	TaskCmd.AddCommand(createCmd)
 	TaskCmd.AddCommand(listCmd)
 	TaskCmd.AddCommand(updateCmd)
+	TaskCmd.AddCommand(deleteCmd)
+	TaskCmd.AddCommand(staleCmd)
+	TaskCmd.AddCommand(branchCmd)
+	TaskCmd.AddCommand(openFromBranchCmd)
 }
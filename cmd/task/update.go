@@ -2,11 +2,20 @@ package task
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"time"
+	"os"
+	"slices"
+	"sort"
 
+	"opentask/cmd/completion"
 	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
 	"opentask/pkg/models"
+	"opentask/pkg/notify"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
 
 	"github.com/spf13/cobra"
 )
@@ -14,7 +23,13 @@ import (
 var updateCmd = &cobra.Command{
 	Use:   "update <task-id>",
 	Short: "Update a task",
-	Long: `Update a task by ID. Currently supports updating task status.
+	Long: `Update a task by ID. Supports updating the status and platform fields.
+
+On platforms with restricted workflows, such as Jira, only the statuses the
+task's workflow allows from its current status are accepted.
+
+Platform-specific fields are set with --field key=value, as with
+"task create".
 
 Available statuses:
 - open
@@ -24,36 +39,46 @@ Available statuses:
 
 Examples:
   opentask task update TASK-123 --status done
-  opentask task update LIN-456 --status in_progress`,
-	Args: cobra.ExactArgs(1),
-	RunE: runUpdate,
+  opentask task update linear:LIN-456 --status in_progress
+  opentask task update TASK-123 --field customfield_10011="Checkout"
+  opentask task update TASK-123 --field sprint=next`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.TaskID,
+	RunE:              runUpdate,
 }
 
 var (
 	updateStatus   string
 	updatePlatform string
+	updateFields   []string
 )
 
 func init() {
 	updateCmd.Flags().StringVarP(&updateStatus, "status", "s", "", "update task status (open, in_progress, done, cancelled)")
 	updateCmd.Flags().StringVarP(&updatePlatform, "platform", "p", "", "specify platform if task ID is ambiguous")
+	updateCmd.Flags().StringArrayVar(&updateFields, "field", []string{}, "platform field as key=value (repeatable, JSON values allowed)")
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
 	taskID := args[0]
 
-	if updateStatus == "" {
-		return fmt.Errorf("no updates specified. Use --status to update task status")
+	if updateStatus == "" && len(updateFields) == 0 {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("no updates specified. Use --status or --field"))
 	}
 
 	// Validate status
 	status := models.TaskStatus(updateStatus)
-	if !status.IsValid() {
-		return fmt.Errorf("invalid status: %s. Valid statuses: open, in_progress, done, cancelled", updateStatus)
+	if updateStatus != "" && !status.IsValid() {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("invalid status: %s. Valid statuses: open, in_progress, done, cancelled", updateStatus))
+	}
+
+	fields, err := parseFieldFlags(updateFields)
+	if err != nil {
+		return err
 	}
 
 	manager := config.NewManager()
-	if err := manager.Load(""); err != nil {
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
@@ -65,79 +90,215 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if updateStatus != "" {
+		if err := platforms.RequireCapability(platform, cfg.Platforms[platform].Settings, platforms.CapabilityTransitionTask); err != nil {
+			return err
+		}
+	}
+
 	// Create platform client
-	client, err := createPlatformClient(platform, cfg.Platforms[platform])
+	client, err := service.NewClient(platform, cfg.Platforms[platform])
 	if err != nil {
 		return fmt.Errorf("failed to create %s client: %w", platform, err)
 	}
 
-	// Update the task
+	ctx, cancel := service.WithRequestTimeout(context.Background())
+	defer cancel()
+
+	previous := *task
+
+	// Check the workflow allows the change before applying it
 	originalStatus := task.Status
-	task.SetStatus(status)
+	if updateStatus != "" {
+		if err := platforms.ValidateTransition(ctx, client, task, status); err != nil {
+			var invalid *platforms.InvalidTransitionError
+			if errors.As(err, &invalid) {
+				return invalid
+			}
+			return fmt.Errorf("failed to check allowed transitions: %w", err)
+		}
+		task.SetStatus(status)
+	}
+	if len(fields) > 0 {
+		task.SetMetadata("custom_fields", fields)
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	// Update the task
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
 	updatedTask, err := client.UpdateTask(ctx, task)
+	audit := store.NewAuditUpdate(&previous, task, err)
+	for _, key := range keys {
+		audit.Changes = append(audit.Changes, store.AuditChange{Field: key, To: fields[key]})
+	}
+	warnAudit(os.Stderr, store.AuditUpdate, "cli", audit)
 	if err != nil {
 		return fmt.Errorf("failed to update task: %w", err)
 	}
 
 	fmt.Printf("✅ Task %s updated successfully\n", taskID)
-	fmt.Printf("   Status: %s → %s\n", originalStatus, updatedTask.Status)
+	if updateStatus != "" {
+		fmt.Printf("   Status: %s → %s\n", originalStatus, updatedTask.Status)
+	}
+	for _, key := range keys {
+		fmt.Printf("   %s: %s\n", key, fields[key])
+	}
 
+	notifyTasks(os.Stdout, cfg, notify.Event{Kind: notify.KindUpdated, Platform: platform, Task: updatedTask, Previous: &previous})
 	return nil
 }
 
+// findTaskByID looks a task up by a reference such as "jira:TEST-123",
+// which names its platform, or by a bare ID. A bare ID is looked up on the
+// platforms it was last seen on (see store.TaskLocation), and on every
+// enabled platform if none of them has it; --platform restricts the
+// search to one platform.
 func findTaskByID(cfg *config.Config, taskID string, preferredPlatform string) (*models.Task, string, error) {
-	var foundTasks []*models.Task
-	var foundPlatforms []string
-
 	// If platform is specified, only search in that platform
 	platforms := cfg.GetEnabledPlatforms()
-	if preferredPlatform != "" {
+	refPlatform, id := models.ParseRef(taskID)
+	if refPlatform != "" {
+		if preferredPlatform != "" && preferredPlatform != refPlatform {
+			return nil, "", fmt.Errorf("task %s is on %s, not %s", taskID, refPlatform, preferredPlatform)
+		}
+		platforms = refPlatforms(cfg, refPlatform)
+		if len(platforms) == 0 {
+			return nil, "", fmt.Errorf("platform %s not configured", refPlatform)
+		}
+	} else if preferredPlatform != "" {
 		if _, exists := cfg.GetPlatform(preferredPlatform); !exists {
 			return nil, "", fmt.Errorf("platform %s not configured", preferredPlatform)
 		}
 		platforms = []string{preferredPlatform}
 	}
 
-	for _, platformName := range platforms {
+	st, err := store.Open()
+	if err != nil {
+		st = nil
+	}
+
+	foundTasks, foundPlatforms, probeErr := locateTask(st, id, platforms, func(names []string) ([]*models.Task, []string, error) {
+		return probeTask(st, cfg, id, names)
+	})
+
+	if len(foundTasks) == 0 {
+		if probeErr != nil {
+			// A platform that could not be asked may have the task.
+			return nil, "", fmt.Errorf("failed to look up task %s: %w", taskID, probeErr)
+		}
+		if refPlatform != "" {
+			return nil, "", exitcode.Wrap(exitcode.NotFound, fmt.Errorf("task %s not found on %s", id, refPlatform))
+		}
+		return nil, "", exitcode.Wrap(exitcode.NotFound, fmt.Errorf("task %s not found in any configured platform", taskID))
+	}
+
+	if len(foundTasks) > 1 {
+		fmt.Fprintf(os.Stderr, "Multiple tasks found with ID %s:\n", taskID)
+		for i, task := range foundTasks {
+			fmt.Fprintf(os.Stderr, "  %d. %s - %s\n", i+1, models.FormatRef(foundPlatforms[i], task.ID), task.Title)
+		}
+		return nil, "", exitcode.Wrap(exitcode.Usage, fmt.Errorf("ambiguous task ID. Use a platform:ID reference such as %s, or --platform", models.FormatRef(foundPlatforms[0], id)))
+	}
+
+	return foundTasks[0], foundPlatforms[0], nil
+}
+
+// locateTask finds task id on the candidate platforms with probe. The
+// platforms the location cache last saw the ID on are asked first, and all
+// of the candidates only when none of them has it any more.
+//
+// An ID seen on several platforms is still reported on each of them, so
+// the caller can refuse it as ambiguous. An ID that also exists on a
+// platform it has never been seen on is taken to be the cached one, since
+// finding out would mean asking every platform each time; listing that
+// platform's tasks records the ID there too. st may be nil.
+func locateTask(st *store.Store, id string, candidates []string, probe func(names []string) ([]*models.Task, []string, error)) ([]*models.Task, []string, error) {
+	if st != nil && len(candidates) > 1 {
+		if cached := cachedPlatforms(st, id, candidates); len(cached) > 0 {
+			if tasks, names, _ := probe(cached); len(tasks) > 0 {
+				return tasks, names, nil
+			}
+		}
+	}
+	return probe(candidates)
+}
+
+// cachedPlatforms returns the platforms among candidates that task id was
+// last seen on.
+func cachedPlatforms(st *store.Store, id string, candidates []string) []string {
+	var cached []string
+	for _, platformName := range st.LocateTask(id) {
+		if slices.Contains(candidates, platformName) {
+			cached = append(cached, platformName)
+		}
+	}
+	return cached
+}
+
+// probeTask asks each of the named platforms for task id, recording where
+// it was found in the location cache and forgetting the platforms that no
+// longer have it. st may be nil. The error is the first one other than
+// the task not being found.
+func probeTask(st *store.Store, cfg *config.Config, id string, names []string) ([]*models.Task, []string, error) {
+	var foundTasks []*models.Task
+	var foundPlatforms []string
+	var firstErr error
+
+	for _, platformName := range names {
 		platform, exists := cfg.GetPlatform(platformName)
 		if !exists || !platform.Enabled {
 			continue
 		}
 
-		client, err := createPlatformClient(platformName, platform)
+		client, err := service.NewClient(platformName, platform)
 		if err != nil {
-			fmt.Printf("⚠ Failed to create %s client: %v\n", platformName, err)
+			fmt.Fprintf(os.Stderr, "⚠ Failed to create %s client: %v\n", platformName, err)
 			continue
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-
-		task, err := client.GetTask(ctx, taskID)
+		ctx, cancel := service.WithRequestTimeout(context.Background())
+		task, err := client.GetTask(ctx, id)
+		cancel()
 		if err != nil {
 			// Task not found in this platform, continue to next
+			if !platforms.IsNotFoundError(err) {
+				if firstErr == nil {
+					firstErr = err
+				}
+			} else if st != nil {
+				st.ForgetLocation(id, platformName)
+			}
 			continue
 		}
 
+		if st != nil {
+			st.RememberLocations(platformName, []string{task.ID})
+		}
 		foundTasks = append(foundTasks, task)
 		foundPlatforms = append(foundPlatforms, platformName)
 	}
 
-	if len(foundTasks) == 0 {
-		return nil, "", fmt.Errorf("task %s not found in any configured platform", taskID)
-	}
+	return foundTasks, foundPlatforms, firstErr
+}
 
-	if len(foundTasks) > 1 {
-		fmt.Printf("Multiple tasks found with ID %s:\n", taskID)
-		for i, task := range foundTasks {
-			fmt.Printf("  %d. %s (%s) - %s\n", i+1, task.ID, foundPlatforms[i], task.Title)
+// refPlatforms returns the platforms a reference's platform part names:
+// the configured platform of that name or, failing that, the enabled
+// platforms of that type, so "jira:TEST-1" finds a Jira platform
+// configured under another name.
+func refPlatforms(cfg *config.Config, name string) []string {
+	if _, exists := cfg.GetPlatform(name); exists {
+		return []string{name}
+	}
+	var names []string
+	for _, platformName := range cfg.GetEnabledPlatforms() {
+		if cfg.Platforms[platformName].Type == name {
+			names = append(names, platformName)
 		}
-		return nil, "", fmt.Errorf("ambiguous task ID. Use --platform to specify which platform")
 	}
-
-	return foundTasks[0], foundPlatforms[0], nil
+	return names
 }
@@ -0,0 +1,110 @@
+package task
+
+import (
+	"slices"
+	"testing"
+
+	"opentask/pkg/exitcode"
+	"opentask/pkg/models"
+	"opentask/pkg/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocateTask(t *testing.T) {
+	candidates := []string{"jira", "linear"}
+	tests := []struct {
+		name      string
+		seenOn    []string
+		hasIt     []string
+		wantAsked [][]string
+		wantFound []string
+	}{
+		{
+			name:      "not cached",
+			hasIt:     []string{"jira"},
+			wantAsked: [][]string{{"jira", "linear"}},
+			wantFound: []string{"jira"},
+		},
+		{
+			name:      "cached",
+			seenOn:    []string{"linear"},
+			hasIt:     []string{"linear"},
+			wantAsked: [][]string{{"linear"}},
+			wantFound: []string{"linear"},
+		},
+		{
+			name:      "stale cache falls back to every platform",
+			seenOn:    []string{"jira"},
+			hasIt:     []string{"linear"},
+			wantAsked: [][]string{{"jira"}, {"jira", "linear"}},
+			wantFound: []string{"linear"},
+		},
+		{
+			name:      "seen on both stays ambiguous",
+			seenOn:    []string{"jira", "linear"},
+			hasIt:     []string{"jira", "linear"},
+			wantAsked: [][]string{{"jira", "linear"}},
+			wantFound: []string{"jira", "linear"},
+		},
+		{
+			// The documented trade-off: a platform the ID was never seen
+			// on is not asked while the cached one has it.
+			name:      "unseen platform is not asked",
+			seenOn:    []string{"jira"},
+			hasIt:     []string{"jira", "linear"},
+			wantAsked: [][]string{{"jira"}},
+			wantFound: []string{"jira"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, err := store.New(t.TempDir())
+			require.NoError(t, err)
+			for _, platform := range tt.seenOn {
+				require.NoError(t, st.RememberLocations(platform, []string{"API-1"}))
+			}
+
+			var asked [][]string
+			_, found, err := locateTask(st, "API-1", candidates, func(names []string) ([]*models.Task, []string, error) {
+				asked = append(asked, names)
+				var tasks []*models.Task
+				var platforms []string
+				for _, name := range names {
+					if slices.Contains(tt.hasIt, name) {
+						tasks = append(tasks, &models.Task{ID: "API-1"})
+						platforms = append(platforms, name)
+					}
+				}
+				return tasks, platforms, nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAsked, asked)
+			assert.Equal(t, tt.wantFound, found)
+		})
+	}
+}
+
+func TestRunUpdate_UsageErrors(t *testing.T) {
+	t.Cleanup(func() { updateStatus, updateFields = "", nil })
+
+	tests := []struct {
+		name   string
+		status string
+		fields []string
+	}{
+		{name: "no updates"},
+		{name: "invalid status", status: "started"},
+		{name: "invalid field", fields: []string{"points"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updateStatus, updateFields = tt.status, tt.fields
+
+			err := runUpdate(updateCmd, []string{"PROJ-1"})
+			require.Error(t, err)
+			assert.Equal(t, exitcode.Usage, exitcode.Of(err))
+		})
+	}
+}
@@ -3,25 +3,51 @@ package task
 import (
 	"context"
 	"fmt"
+	"opentask/pkg/activity"
 	"opentask/pkg/config"
+	"opentask/pkg/humanize"
 	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+	"opentask/pkg/styles"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-var baseStyle = lipgloss.NewStyle().
-	BorderStyle(lipgloss.NormalBorder()).
-	BorderForeground(lipgloss.Color("240"))
+func baseStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(styles.Current().Border)
+}
+
+func detailStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Current().Accent).
+		Padding(1, 2)
+}
+
+func toastStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(styles.Current().Success)
+}
+
+func toastErrorStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(styles.Current().Error)
+}
 
-var detailStyle = lipgloss.NewStyle().
-	BorderStyle(lipgloss.RoundedBorder()).
-	BorderForeground(lipgloss.Color("62")).
-	Padding(1, 2)
+// toastDuration is how long status messages stay in the footer.
+const toastDuration = 4 * time.Second
+
+const listHelp = "Enter: details • d:delete • 1:open 2:in_progress 3:done 4:cancelled • space:select a:all L:label • /:filter s:status p:platform • b:board c:columns • r:refresh • q:quit"
 
 type viewState int
 
@@ -29,6 +55,10 @@ const (
 	viewList viewState = iota
 	viewDetail
 	viewDeleteConfirm
+	viewEdit
+	viewBulkSummary
+	viewBoard
+	viewColumns
 )
 
 type model struct {
@@ -39,8 +69,88 @@ type model struct {
 	currentView   viewState
 	selectedTask  *models.Task
 	config        *config.Config
+	configPath    string
 	deleteTask    *models.Task
 	deleteMessage string
+
+	spinner      spinner.Model
+	pending      int
+	busyText     string
+	refreshing   bool
+	deleting     bool
+	toast        string
+	toastIsError bool
+	toastID      int
+
+	filterInput    textinput.Model
+	filtering      bool
+	statusFilter   models.TaskStatus
+	platformFilter models.Platform
+
+	edit *editForm
+
+	selected        map[string]bool
+	bulk            *bulkJob
+	bulkSeq         int
+	bulkDeleteTasks []*models.Task
+	progress        progress.Model
+	labelInput      textinput.Model
+	labeling        bool
+	guard           *bulkGuard
+
+	columns []config.Column
+	picker  *columnPicker
+
+	// markdownStyle is the glamour style for descriptions; showSource
+	// shows the raw description instead.
+	markdownStyle string
+	showSource    bool
+
+	// transitions caches the status changes allowed for each task, for
+	// platforms whose workflows restrict them.
+	transitions map[string][]platforms.Transition
+
+	// watch refreshes the tasks at this interval with load, which lists
+	// them the way the command did; changes marks the rows that are new
+	// or changed since the previous refresh.
+	watch   time.Duration
+	watchID int
+	load    func() tasksLoadedMsg
+	changes map[string]activity.Kind
+
+	// home is the view that detail and delete screens return to.
+	home   viewState
+	board  boardCursor
+	width  int
+	height int
+}
+
+// Messages delivered by background operations started from the list view.
+type (
+	tasksLoadedMsg struct {
+		tasks  []*models.Task
+		failed []string
+	}
+
+	taskUpdatedMsg struct {
+		task *models.Task
+		err  error
+	}
+
+	taskDeletedMsg struct {
+		task *models.Task
+		err  error
+	}
+
+	toastExpiredMsg struct {
+		id int
+	}
+)
+
+// SetTasksMsg replaces the tasks shown by a list model, for hosts that load
+// tasks themselves, such as the full-screen app.
+type SetTasksMsg struct {
+	Tasks []*models.Task
 }
 
 func (m model) Init() tea.Cmd {
@@ -48,30 +158,129 @@ func (m model) Init() tea.Cmd {
 		// In plain mode, immediately quit after initial render
 		return tea.Quit
 	}
+	if m.watch > 0 {
+		return m.watchTick()
+	}
 	return nil
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
-	case tea.WindowSizeMsg:
-		if m.currentView == viewDetail {
-			m.viewport.Width = msg.Width - 4
-			m.viewport.Height = msg.Height - 6
+	case spinner.TickMsg:
+		if m.pending == 0 {
+			return m, nil
 		}
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	case tasksLoadedMsg:
+		return m.handleTasksLoaded(msg)
+	case watchTickMsg:
+		return m.handleWatchTick(msg)
+	case SetTasksMsg:
+		m.tasks = msg.Tasks
+		return m.refreshTable(), nil
+	case taskUpdatedMsg:
+		return m.handleTaskUpdated(msg)
+	case taskDeletedMsg:
+		return m.handleTaskDeleted(msg)
+	case userSearchTickMsg:
+		return m.searchUsers(msg)
+	case usersFoundMsg:
+		return m.handleUsersFound(msg)
+	case bulkResultMsg:
+		return m.handleBulkResult(msg)
+	case columnsSavedMsg:
+		return m.handleColumnsSaved(msg)
+	case transitionsLoadedMsg:
+		return m.handleTransitionsLoaded(msg)
+	case labelSuggestionsMsg:
+		return m.handleLabelSuggestions(msg)
+	case toastExpiredMsg:
+		if msg.id == m.toastID {
+			m.toast = ""
+		}
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m.layout(), nil
 	case tea.KeyMsg:
+		if m.filtering {
+			return m.updateFilterInput(msg)
+		}
+		if m.labeling {
+			return m.updateLabelInput(msg)
+		}
+		if m.guard != nil {
+			return m.updateBulkGuard(msg)
+		}
+		if m.currentView == viewEdit {
+			return m.updateEditForm(msg)
+		}
+		if m.currentView == viewBoard {
+			return m.updateBoard(msg)
+		}
+		if m.currentView == viewColumns {
+			return m.updateColumnPicker(msg)
+		}
+		if m.currentView == viewBulkSummary {
+			m.bulk = nil
+			m.currentView = viewList
+			return m, nil
+		}
+
 		switch msg.String() {
+		case "/":
+			if m.currentView == viewList {
+				m.filtering = true
+				m.table.Blur()
+				return m, m.filterInput.Focus()
+			}
+		case "s":
+			if m.currentView == viewList {
+				return m.cycleStatusFilter(), nil
+			}
+			if m.currentView == viewDetail && m.selectedTask != nil {
+				m.showSource = !m.showSource
+				return m.setDetailContent(), nil
+			}
+		case "p":
+			if m.currentView == viewList {
+				return m.cyclePlatformFilter(), nil
+			}
+		case " ":
+			if m.currentView == viewList {
+				return m.toggleSelection(), nil
+			}
+		case "a":
+			if m.currentView == viewList {
+				return m.toggleSelectAll(), nil
+			}
+		case "L":
+			if m.currentView == viewList && len(m.selected) > 0 {
+				m.labeling = true
+				m.table.Blur()
+				return m, tea.Batch(m.labelInput.Focus(), m.loadLabelSuggestions())
+			}
 		case "esc":
 			if m.currentView == viewDetail {
-				m.currentView = viewList
+				m.currentView = m.home
 				return m, nil
 			}
-			if m.currentView == viewDeleteConfirm {
-				m.currentView = viewList
+			if m.currentView == viewDeleteConfirm && !m.deleting {
+				m.currentView = m.home
 				m.deleteTask = nil
+				m.bulkDeleteTasks = nil
 				m.deleteMessage = ""
 				return m, nil
 			}
+			if m.filterActive() {
+				return m.clearFilters(), nil
+			}
+			if len(m.selected) > 0 {
+				m.selected = make(map[string]bool)
+				return m.refreshTable(), nil
+			}
 			if m.table.Focused() {
 				m.table.Blur()
 			} else {
@@ -81,72 +290,103 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case "enter":
 			if m.currentView == viewList {
-				selectedRow := m.table.SelectedRow()
-				if len(selectedRow) > 0 {
-					taskID := selectedRow[0]
-					for _, task := range m.tasks {
-						if task.ID == taskID {
-							m.selectedTask = task
-							m.currentView = viewDetail
-							m.viewport.SetContent(m.formatTaskDetail())
-							return m, nil
-						}
-					}
+				if task := m.taskForSelectedRow(); task != nil {
+					m.selectedTask = task
+					m.currentView = viewDetail
+					m = m.setDetailContent()
+					return m, m.loadTransitions(task)
 				}
 			}
 		case "d":
-			if m.currentView == viewList {
-				selectedRow := m.table.SelectedRow()
-				if len(selectedRow) > 0 {
-					taskID := selectedRow[0]
-					for _, task := range m.tasks {
-						if task.ID == taskID {
-							m.deleteTask = task
-							m.currentView = viewDeleteConfirm
-							return m, nil
-						}
-					}
+			if m.currentView == viewList && len(m.selected) > 0 {
+				tasks := m.selectedTasks()
+				if len(tasks) > bulkConfirmThreshold(m.config) {
+					return m.guardBulk("delete", "Delete Tasks", tasks, func(m model) (tea.Model, tea.Cmd) {
+						m.bulkDeleteTasks = tasks
+						return m.bulkDelete()
+					})
+				}
+				m.bulkDeleteTasks = tasks
+				m.currentView = viewDeleteConfirm
+				return m, nil
+			} else if m.currentView == viewList {
+				if task := m.taskForSelectedRow(); task != nil {
+					m.deleteTask = task
+					m.currentView = viewDeleteConfirm
+					return m, nil
 				}
 			} else if m.currentView == viewDetail && m.selectedTask != nil {
 				m.deleteTask = m.selectedTask
 				m.currentView = viewDeleteConfirm
 				return m, nil
 			}
+		case "e":
+			if m.currentView == viewDetail && m.selectedTask != nil {
+				return m.openEditForm()
+			}
 		case "y":
+			if m.currentView == viewDeleteConfirm && len(m.bulkDeleteTasks) > 0 {
+				return m.bulkDelete()
+			}
 			if m.currentView == viewDeleteConfirm && m.deleteTask != nil {
 				return m.confirmDelete()
 			}
 		case "n":
-			if m.currentView == viewDeleteConfirm {
-				m.currentView = viewList
+			if m.currentView == viewDeleteConfirm && !m.deleting {
+				m.currentView = m.home
 				m.deleteTask = nil
+				m.bulkDeleteTasks = nil
 				m.deleteMessage = ""
 				return m, nil
 			}
 		case "1":
-			if m.currentView == viewList {
+			if m.currentView == viewList && len(m.selected) > 0 {
+				return m.guardBulk("update", "Reopen Tasks", m.selectedTasks(), func(m model) (tea.Model, tea.Cmd) {
+					return m.bulkUpdateStatus("open")
+				})
+			} else if m.currentView == viewList {
 				return m.updateSelectedTaskStatus("open")
 			} else if m.currentView == viewDetail && m.selectedTask != nil {
 				return m.updateTaskStatus("open")
 			}
 		case "2":
-			if m.currentView == viewList {
+			if m.currentView == viewList && len(m.selected) > 0 {
+				return m.guardBulk("update", "Start Tasks", m.selectedTasks(), func(m model) (tea.Model, tea.Cmd) {
+					return m.bulkUpdateStatus("in_progress")
+				})
+			} else if m.currentView == viewList {
 				return m.updateSelectedTaskStatus("in_progress")
 			} else if m.currentView == viewDetail && m.selectedTask != nil {
 				return m.updateTaskStatus("in_progress")
 			}
 		case "3":
-			if m.currentView == viewList {
+			if m.currentView == viewList && len(m.selected) > 0 {
+				return m.guardBulk("close", "Close Tasks", m.selectedTasks(), func(m model) (tea.Model, tea.Cmd) {
+					return m.bulkUpdateStatus("done")
+				})
+			} else if m.currentView == viewList {
 				return m.updateSelectedTaskStatus("done")
 			} else if m.currentView == viewDetail && m.selectedTask != nil {
 				return m.updateTaskStatus("done")
 			}
 		case "4":
-			if m.currentView == viewList {
+			if m.currentView == viewList && len(m.selected) > 0 {
+				return m.guardBulk("close", "Close Tasks", m.selectedTasks(), func(m model) (tea.Model, tea.Cmd) {
+					return m.bulkUpdateStatus("cancelled")
+				})
+			} else if m.currentView == viewList {
 				return m.updateSelectedTaskStatus("cancelled")
 			} else if m.currentView == viewDetail && m.selectedTask != nil {
 				return m.updateTaskStatus("cancelled")
 			}
+		case "c":
+			if m.currentView == viewList {
+				return m.openColumnPicker(), nil
+			}
+		case "b":
+			if m.currentView == viewList {
+				return m.openBoard(), nil
+			}
 		case "r":
 			if m.currentView == viewList {
 				return m.refreshTasks()
@@ -155,7 +395,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	if m.currentView == viewList {
+	if m.filtering {
+		m.filterInput, cmd = m.filterInput.Update(msg)
+	} else if m.currentView == viewEdit && m.edit != nil {
+		// Forward cursor blink and other non-key messages to the focused field.
+		cmd = m.edit.updateFocused(msg)
+	} else if m.currentView == viewList {
 		m.table, cmd = m.table.Update(msg)
 	} else if m.currentView == viewDetail {
 		m.viewport, cmd = m.viewport.Update(msg)
@@ -167,7 +412,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m model) View() string {
 	if m.plain {
 		// In plain mode, return just the table content without styling
-		return m.table.View()
+		return m.tableView()
 	}
 
 	switch m.currentView {
@@ -175,8 +420,29 @@ func (m model) View() string {
 		return m.renderTaskDetail()
 	case viewDeleteConfirm:
 		return m.renderDeleteConfirm()
+	case viewEdit:
+		return m.renderEditForm()
+	case viewBulkSummary:
+		return m.renderBulkSummary()
+	case viewBoard:
+		return m.renderBoard()
+	case viewColumns:
+		return m.renderColumnPicker()
 	default:
-		return baseStyle.Render(m.table.View()) + "\n" + "Enter: details • d:delete • 1:open 2:in_progress 3:done 4:cancelled • r:refresh • q:quit"
+		if m.guard != nil {
+			return m.renderBulkGuard()
+		}
+		view := baseStyle().Render(m.tableView()) + "\n" + m.wrap(listHelp)
+		if bar := m.renderFilterBar(); bar != "" {
+			view = bar + "\n" + view
+		}
+		if m.labeling {
+			view = m.labelInput.View() + "\n" + view
+		}
+		if status := m.statusLine(); status != "" {
+			view += "\n" + status
+		}
+		return view
 	}
 }
 
@@ -185,8 +451,16 @@ func (m model) formatTaskDetail() string {
 		return "No task selected"
 	}
 
-	task := m.selectedTask
+	return taskDetail(m.selectedTask, m.renderDescription())
+}
+
+func formatTaskDetail(task *models.Task) string {
+	return taskDetail(task, task.Description)
+}
 
+// taskDetail formats the task fields followed by the given, already
+// formatted, description.
+func taskDetail(task *models.Task, description string) string {
 	var details strings.Builder
 	details.WriteString(fmt.Sprintf("Task ID: %s\n", task.ID))
 	details.WriteString(fmt.Sprintf("Platform: %s\n", task.Platform))
@@ -208,15 +482,19 @@ func (m model) formatTaskDetail() string {
 		details.WriteString(fmt.Sprintf("Labels: %s\n", strings.Join(task.Labels, ", ")))
 	}
 
-	details.WriteString(fmt.Sprintf("Created: %s\n", task.CreatedAt.Format("2006-01-02 15:04:05")))
-	details.WriteString(fmt.Sprintf("Updated: %s\n", task.UpdatedAt.Format("2006-01-02 15:04:05")))
+	details.WriteString(fmt.Sprintf("Created: %s\n", humanize.Time(task.CreatedAt)))
+	details.WriteString(fmt.Sprintf("Updated: %s\n", humanize.Time(task.UpdatedAt)))
 
 	if task.DueDate != nil {
-		details.WriteString(fmt.Sprintf("Due Date: %s\n", task.DueDate.Format("2006-01-02 15:04:05")))
+		due := humanize.Due(*task.DueDate)
+		if humanize.Overdue(task) {
+			due = overdueStyle().Render(due)
+		}
+		details.WriteString(fmt.Sprintf("Due Date: %s\n", due))
 	}
 
-	if task.Description != "" {
-		details.WriteString(fmt.Sprintf("\nDescription:\n%s\n", task.Description))
+	if description != "" {
+		details.WriteString(fmt.Sprintf("\nDescription:\n%s\n", description))
 	}
 
 	if len(task.Metadata) > 0 {
@@ -234,54 +512,32 @@ func (m model) renderTaskDetail() string {
 		return "No task selected"
 	}
 
-	header := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("62")).
+	header := styles.Title().
 		MarginBottom(1).
-		Render(fmt.Sprintf("Task Details: %s", m.selectedTask.Title))
+		Render(truncate(fmt.Sprintf("Task Details: %s", m.selectedTask.Title), m.width))
 
-	footer := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+	footer := styles.Help().
 		MarginTop(1).
-		Render("↑↓ scroll • d:delete • 1:open 2:in_progress 3:done 4:cancelled • ESC back • q quit")
+		Render(m.wrap(fmt.Sprintf("↑↓ scroll • e:edit • d:delete • s:%s • %s • ESC back • q quit", m.sourceToggleLabel(), m.statusKeyHelp(m.selectedTask))))
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		header,
-		detailStyle.Render(m.viewport.View()),
+		detailStyle().Render(m.viewport.View()),
 		footer,
+		m.statusLine(),
 	)
 }
 
-func NewTaskListModel(tasks []*models.Task, plain bool, cfg *config.Config) model {
-	columns := []table.Column{
-		{Title: "ID", Width: 4},
-		{Title: "PLATFORM", Width: 10},
-		{Title: "STATUS", Width: 12},
-		{Title: "PRIORITY", Width: 10},
-		{Title: "TITLE", Width: 50},
-		{Title: "ASSIGNEE", Width: 10},
-	}
-
-	rows := make([]table.Row, len(tasks))
-	for i, task := range tasks {
-		assignee := "none"
-		if task.Assignee != nil {
-			assignee = task.Assignee.Name
-		}
-		rows[i] = table.Row{
-			task.ID,
-			task.Platform.String(),
-			task.Status.String(),
-			task.Priority.String(),
-			task.Title,
-			assignee,
-		}
-	}
+// WithConfigPath returns the model saving its settings, such as the column
+// layout, to the configuration file at path ("" for the default one).
+func (m model) WithConfigPath(path string) model {
+	m.configPath = path
+	return m
+}
 
+func NewTaskListModel(tasks []*models.Task, plain bool, cfg *config.Config) model {
 	t := table.New(
-		table.WithColumns(columns),
-		table.WithRows(rows),
 		table.WithFocused(true),
 		table.WithHeight(10),
 	)
@@ -289,25 +545,56 @@ func NewTaskListModel(tasks []*models.Task, plain bool, cfg *config.Config) mode
 	s := table.DefaultStyles()
 	s.Header = s.Header.
 		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(styles.Current().Border).
 		BorderBottom(true).
 		Bold(false)
 	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("57")).
+		Foreground(styles.Current().Selected).
+		Background(styles.Current().SelectedBackground).
 		Bold(false)
 	t.SetStyles(s)
 
 	vp := viewport.New(100, 30)
 
-	return model{
+	fi := textinput.New()
+	fi.Prompt = "/"
+	fi.Placeholder = "title, label, assignee, platform"
+	fi.CharLimit = 100
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(styles.Current().Accent)
+
+	li := textinput.New()
+	li.Prompt = "Add label to selected: "
+	li.CharLimit = 100
+	li.ShowSuggestions = true
+
+	m := model{
 		table:       t,
 		viewport:    vp,
 		plain:       plain,
 		tasks:       tasks,
 		currentView: viewList,
+		home:        viewList,
 		config:      cfg,
+		spinner:     sp,
+		filterInput: fi,
+		selected:    make(map[string]bool),
+		progress:    newBulkProgress(),
+		labelInput:  li,
+
+		markdownStyle: markdownStyle(plain),
+		transitions:   make(map[string][]platforms.Transition),
+	}
+
+	m = m.setColumns(configuredColumns(cfg))
+	if plain {
+		// Plain output is printed once, so every row has to fit below the
+		// header and its border.
+		m.table.SetHeight(len(tasks) + 2)
 	}
+	return m
 }
 
 func (m model) updateTaskStatus(statusStr string) (tea.Model, tea.Cmd) {
@@ -315,204 +602,232 @@ func (m model) updateTaskStatus(statusStr string) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	status := models.TaskStatus(statusStr)
-	if !status.IsValid() {
+	return m.updateStatus(m.selectedTask, statusStr)
+}
+
+func (m model) updateSelectedTaskStatus(statusStr string) (tea.Model, tea.Cmd) {
+	targetTask := m.taskForSelectedRow()
+	if targetTask == nil {
 		return m, nil
 	}
 
-	// Load configuration to find platform client
-	manager := config.NewManager()
-	if err := manager.Load(""); err != nil {
+	return m.updateStatus(targetTask, statusStr)
+}
+
+// updateStatus starts an asynchronous status change. The task in the list is
+// replaced once the platform confirms the update.
+func (m model) updateStatus(task *models.Task, statusStr string) (tea.Model, tea.Cmd) {
+	status := models.TaskStatus(statusStr)
+	if m.config == nil || !status.IsValid() || task.Status == status {
 		return m, nil
 	}
 
-	cfg := manager.GetConfig()
-	platformName := string(m.selectedTask.Platform)
-	platform, exists := cfg.GetPlatform(platformName)
+	platformName := string(task.Platform)
+	platform, exists := m.config.GetPlatform(platformName)
 	if !exists || !platform.Enabled {
-		return m, nil
+		return m.showToast(fmt.Sprintf("Platform %s not found or not enabled", platformName), true)
 	}
-
-	// Create platform client
-	client, err := createPlatformClient(platformName, platform)
-	if err != nil {
-		return m, nil
+	if err := platforms.RequireCapability(platformName, platform.Settings, platforms.CapabilityTransitionTask); err != nil {
+		return m.showToast(fmt.Sprintf("✗ %v", err), true)
+	}
+	if err := m.checkTransition(task, status); err != nil {
+		m.board.follow = ""
+		return m.showToast(fmt.Sprintf("✗ %v", err), true)
 	}
 
-	// Update task status
-	originalStatus := m.selectedTask.Status
-	m.selectedTask.SetStatus(status)
+	// Work on a copy so the UI never observes a half-applied change.
+	updated := *task
+	updated.SetStatus(status)
 
-	// Update task via API
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	update := func() tea.Msg {
+		client, err := service.NewClient(platformName, platform)
+		if err != nil {
+			return taskUpdatedMsg{task: task, err: err}
+		}
 
-	updatedTask, err := client.UpdateTask(ctx, m.selectedTask)
-	if err != nil {
-		// Revert status on error
-		m.selectedTask.SetStatus(originalStatus)
-		return m, nil
-	}
+		ctx, cancel := service.WithRequestTimeout(context.Background())
+		defer cancel()
 
-	// Update the task in our local list
-	for i, task := range m.tasks {
-		if task.ID == updatedTask.ID {
-			m.tasks[i] = updatedTask
-			m.selectedTask = updatedTask
-			break
+		result, err := client.UpdateTask(ctx, &updated)
+		logAudit(store.AuditUpdate, store.NewAuditUpdate(task, &updated, err))
+		if err != nil {
+			return taskUpdatedMsg{task: task, err: err}
 		}
+		return taskUpdatedMsg{task: result}
 	}
 
-	// Refresh the viewport content
-	m.viewport.SetContent(m.formatTaskDetail())
-
-	return m, nil
+	return m.startOperation(fmt.Sprintf("Updating %s to %s...", task.ID, status), update)
 }
 
-func (m model) updateSelectedTaskStatus(statusStr string) (tea.Model, tea.Cmd) {
-	selectedRow := m.table.SelectedRow()
-	if len(selectedRow) == 0 {
+func (m model) refreshTasks() (tea.Model, tea.Cmd) {
+	if m.config == nil || m.refreshing {
 		return m, nil
 	}
+	m.refreshing = true
 
-	taskID := selectedRow[0]
-	var targetTask *models.Task
-
-	for _, task := range m.tasks {
-		if task.ID == taskID {
-			targetTask = task
-			break
-		}
+	if m.load != nil {
+		load := m.load
+		return m.startOperation("Refreshing tasks...", func() tea.Msg { return load() })
 	}
 
-	if targetTask == nil {
-		return m, nil
-	}
+	cfg := m.config
+	refresh := func() tea.Msg {
+		var msg tasksLoadedMsg
 
-	status := models.TaskStatus(statusStr)
-	if !status.IsValid() {
-		return m, nil
-	}
+		for _, platformName := range cfg.GetEnabledPlatforms() {
+			platform, exists := cfg.GetPlatform(platformName)
+			if !exists || !platform.Enabled {
+				continue
+			}
 
-	platformName := string(targetTask.Platform)
-	platform, exists := m.config.GetPlatform(platformName)
-	if !exists || !platform.Enabled {
-		return m, nil
-	}
+			client, err := service.NewClient(platformName, platform)
+			if err != nil {
+				msg.failed = append(msg.failed, platformName)
+				continue
+			}
 
-	// Create platform client
-	client, err := createPlatformClient(platformName, platform)
-	if err != nil {
-		return m, nil
-	}
+			ctx, cancel := service.WithRequestTimeout(context.Background())
+			// Use a basic filter for refresh
+			tasks, err := client.ListTasks(ctx, &models.TaskFilter{Limit: 100})
+			cancel()
+			if err != nil {
+				msg.failed = append(msg.failed, platformName)
+				continue
+			}
+
+			msg.tasks = append(msg.tasks, tasks...)
+		}
 
-	// Update task status
-	originalStatus := targetTask.Status
-	targetTask.SetStatus(status)
+		return msg
+	}
 
-	// Update task via API
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	return m.startOperation("Refreshing tasks...", refresh)
+}
 
-	updatedTask, err := client.UpdateTask(ctx, targetTask)
-	if err != nil {
-		// Revert status on error
-		targetTask.SetStatus(originalStatus)
+// updateFilterInput routes keys to the filter prompt while it is open. The
+// table is narrowed as the query is typed.
+func (m model) updateFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.filtering = false
+		m.filterInput.Blur()
+		m.table.Focus()
+		m.filterInput.Reset()
+		return m.refreshTable(), nil
+	case "enter":
+		m.filtering = false
+		m.filterInput.Blur()
+		m.table.Focus()
 		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
 	}
 
-	// Update the task in our local list
-	for i, task := range m.tasks {
-		if task.ID == updatedTask.ID {
-			m.tasks[i] = updatedTask
-			break
-		}
-	}
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	return m.refreshTable(), cmd
+}
 
-	// Refresh the table
-	m = m.refreshTable()
+func (m model) refreshTable() model {
+	visible := m.visibleTasks()
+	rows := make([]table.Row, len(visible))
+	for i, task := range visible {
+		rows[i] = m.taskRow(task)
+	}
 
-	return m, nil
+	m.table.SetRows(rows)
+	if len(rows) > 0 && m.table.Cursor() >= len(rows) {
+		m.table.SetCursor(len(rows) - 1)
+	}
+	return m
 }
 
-func (m model) refreshTasks() (tea.Model, tea.Cmd) {
-	if m.config == nil {
-		return m, nil
+// tableView renders the table with the due dates of overdue tasks in red.
+// The table cannot style single cells (it measures escape codes as text),
+// so their text is colored in the rendered rows; the cursor row keeps the
+// selection colors.
+func (m model) tableView() string {
+	view := m.table.View()
+	if styles.ColorDisabled() || !m.showsColumn("due") {
+		return view
 	}
 
-	platforms := m.config.GetEnabledPlatforms()
-	var allTasks []*models.Task
-
-	for _, platformName := range platforms {
-		platform, exists := m.config.GetPlatform(platformName)
-		if !exists || !platform.Enabled {
-			continue
+	overdue := make(map[string]bool)
+	for _, task := range m.visibleTasks() {
+		if humanize.Overdue(task) {
+			overdue[humanize.Due(*task.DueDate)] = true
 		}
+	}
+	if len(overdue) == 0 {
+		return view
+	}
 
-		client, err := createPlatformClient(platformName, platform)
-		if err != nil {
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		if strings.Contains(line, "\x1b") {
 			continue
 		}
-
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-
-		// Use a basic filter for refresh
-		filter := &models.TaskFilter{
-			Limit: 100, // Get more tasks for refresh
+		for due := range overdue {
+			line = strings.Replace(line, " "+due+" ", " "+overdueStyle().Render(due)+" ", 1)
 		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
 
-		tasks, err := client.ListTasks(ctx, filter)
-		if err != nil {
-			continue
+func (m model) showsColumn(name string) bool {
+	for _, column := range m.columns {
+		if column.Name == name {
+			return true
 		}
-
-		allTasks = append(allTasks, tasks...)
 	}
+	return false
+}
 
-	// Update model with new tasks
-	m.tasks = allTasks
-	m = m.refreshTable()
+// hasMarkers reports whether rows start with a marker column, showing the
+// selection for bulk actions and, while watching, new and changed tasks.
+func (m model) hasMarkers() bool {
+	return !m.plain || m.watch > 0
+}
 
-	return m, nil
+func overdueStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(styles.Current().Error)
 }
 
-func (m model) refreshTable() model {
-	rows := make([]table.Row, len(m.tasks))
-	for i, task := range m.tasks {
-		assignee := "none"
-		if task.Assignee != nil {
-			assignee = task.Assignee.Name
-		}
-		rows[i] = table.Row{
-			task.ID,
-			task.Platform.String(),
-			task.Status.String(),
-			task.Priority.String(),
-			task.Title,
-			assignee,
-		}
+func (m model) taskRow(task *models.Task) table.Row {
+	row := make(table.Row, 0, len(m.columns)+1)
+	for _, column := range m.columns {
+		row = append(row, taskColumns[column.Name].Value(task))
+	}
+	if !m.hasMarkers() {
+		return row
 	}
 
-	m.table.SetRows(rows)
-	return m
+	marker := m.changeMarker(task)
+	if m.selected[task.ID] {
+		marker = "●"
+	}
+	return append(table.Row{marker}, row...)
 }
 
 func (m model) renderDeleteConfirm() string {
+	if len(m.bulkDeleteTasks) > 0 {
+		return m.renderBulkDeleteConfirm()
+	}
 	if m.deleteTask == nil {
 		return "No task selected for deletion"
 	}
 
 	style := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("196")).
+		BorderForeground(styles.Current().Error).
 		Padding(1, 2).
 		MarginTop(5).
 		MarginLeft(10)
 
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("196"))
+		Foreground(styles.Current().Error)
 
 	content := fmt.Sprintf(
 		"%s\n\n%s\n\n%s\n\n%s",
@@ -522,7 +837,9 @@ func (m model) renderDeleteConfirm() string {
 		"Press 'y' to confirm, 'n' to cancel, or ESC to go back",
 	)
 
-	if m.deleteMessage != "" {
+	if m.deleting {
+		content += fmt.Sprintf("\n\n%s %s", m.spinner.View(), m.busyText)
+	} else if m.deleteMessage != "" {
 		content += fmt.Sprintf("\n\n%s", m.deleteMessage)
 	}
 
@@ -530,55 +847,195 @@ func (m model) renderDeleteConfirm() string {
 }
 
 func (m model) confirmDelete() (tea.Model, tea.Cmd) {
-	if m.deleteTask == nil {
+	if m.config == nil || m.deleteTask == nil || m.deleting {
 		return m, nil
 	}
 
-	// Find platform for the task
-	platformName := string(m.deleteTask.Platform)
+	task := m.deleteTask
+	platformName := string(task.Platform)
 	platform, exists := m.config.GetPlatform(platformName)
 	if !exists || !platform.Enabled {
 		m.deleteMessage = "Platform not found or not enabled"
 		return m, nil
 	}
-
-	// Create platform client
-	client, err := createPlatformClient(platformName, platform)
-	if err != nil {
-		m.deleteMessage = fmt.Sprintf("Failed to create client: %v", err)
+	if err := platforms.RequireCapability(platformName, platform.Settings, platforms.CapabilityDeleteTask); err != nil {
+		m.deleteMessage = err.Error()
 		return m, nil
 	}
 
-	// Delete task via API
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	m.deleting = true
+	m.deleteMessage = ""
 
-	err = client.DeleteTask(ctx, m.deleteTask.ID)
-	if err != nil {
-		m.deleteMessage = fmt.Sprintf("Failed to delete task: %v", err)
-		return m, nil
+	remove := func() tea.Msg {
+		client, err := service.NewClient(platformName, platform)
+		if err != nil {
+			return taskDeletedMsg{task: task, err: fmt.Errorf("failed to create client: %w", err)}
+		}
+
+		ctx, cancel := service.WithRequestTimeout(context.Background())
+		defer cancel()
+
+		err = client.DeleteTask(ctx, task.ID)
+		logAudit(store.AuditDelete, store.NewAuditTask(task, err))
+		if err != nil {
+			return taskDeletedMsg{task: task, err: fmt.Errorf("failed to delete task: %w", err)}
+		}
+		return taskDeletedMsg{task: task}
+	}
+
+	return m.startOperation(fmt.Sprintf("Deleting %s...", task.ID), remove)
+}
+
+// startOperation runs op in the background and keeps the spinner turning
+// until its result message arrives.
+func (m model) startOperation(description string, op tea.Cmd) (model, tea.Cmd) {
+	m.pending++
+	m.busyText = description
+	return m, tea.Batch(op, m.spinner.Tick)
+}
+
+func (m model) finishOperation() model {
+	if m.pending > 0 {
+		m.pending--
+	}
+	if m.pending == 0 {
+		m.busyText = ""
+	}
+	return m
+}
+
+// showToast displays a short-lived message in the footer.
+func (m model) showToast(text string, isError bool) (model, tea.Cmd) {
+	m.toastID++
+	m.toast = text
+	m.toastIsError = isError
+
+	id := m.toastID
+	return m, tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+func (m model) handleTaskUpdated(msg taskUpdatedMsg) (tea.Model, tea.Cmd) {
+	m = m.finishOperation()
+	if msg.err != nil {
+		m.board.follow = ""
+		return m.showToast(fmt.Sprintf("✗ Failed to update %s: %v", msg.task.ID, msg.err), true)
+	}
+
+	for i, task := range m.tasks {
+		if task.ID == msg.task.ID {
+			m.tasks[i] = msg.task
+			break
+		}
+	}
+	// The allowed transitions depend on the status that just changed.
+	delete(m.transitions, msg.task.ID)
+	var reload tea.Cmd
+	if m.selectedTask != nil && m.selectedTask.ID == msg.task.ID {
+		m.selectedTask = msg.task
+		m = m.setDetailContent()
+		if m.currentView == viewDetail {
+			reload = m.loadTransitions(msg.task)
+		}
+	}
+	m = m.refreshTable()
+	if m.board.follow == msg.task.ID {
+		m.board.follow = ""
+		m = m.focusBoardTask(msg.task.ID)
+	}
+
+	m, toast := m.showToast(fmt.Sprintf("✓ %s is now %s", msg.task.ID, msg.task.Status), false)
+	return m, tea.Batch(toast, reload)
+}
+
+func (m model) handleTaskDeleted(msg taskDeletedMsg) (tea.Model, tea.Cmd) {
+	m = m.finishOperation()
+	m.deleting = false
+	if msg.err != nil {
+		if m.currentView == viewDeleteConfirm {
+			m.deleteMessage = msg.err.Error()
+			return m, nil
+		}
+		return m.showToast(fmt.Sprintf("✗ %v", msg.err), true)
 	}
 
 	// Remove task from local list
 	for i, task := range m.tasks {
-		if task.ID == m.deleteTask.ID {
+		if task.ID == msg.task.ID {
 			m.tasks = append(m.tasks[:i], m.tasks[i+1:]...)
 			break
 		}
 	}
 
 	// Clear selected task if it was the one being deleted
-	if m.selectedTask != nil && m.selectedTask.ID == m.deleteTask.ID {
+	if m.selectedTask != nil && m.selectedTask.ID == msg.task.ID {
 		m.selectedTask = nil
 	}
 
 	// Reset delete state
 	m.deleteTask = nil
 	m.deleteMessage = ""
-	m.currentView = viewList
+	m.currentView = m.home
+	m = m.refreshTable()
 
-	// Refresh the table
+	return m.showToast(fmt.Sprintf("✓ Deleted %s", msg.task.ID), false)
+}
+
+func (m model) handleTasksLoaded(msg tasksLoadedMsg) (tea.Model, tea.Cmd) {
+	m = m.finishOperation()
+	m.refreshing = false
+
+	var next tea.Cmd
+	if m.watch > 0 {
+		m, next = m.scheduleWatch()
+	}
+
+	if len(msg.failed) > 0 && len(msg.tasks) == 0 {
+		toast, cmd := m.showToast(fmt.Sprintf("✗ Refresh failed for %s", strings.Join(msg.failed, ", ")), true)
+		return toast, tea.Batch(cmd, next)
+	}
+
+	loaded := fmt.Sprintf("Loaded %d task(s)", len(msg.tasks))
+	if m.watch > 0 {
+		m = m.markChanges(msg.tasks)
+		loaded += fmt.Sprintf(" at %s, %s", time.Now().Format("15:04:05"), changeSummary(m.changes))
+	}
+	m.tasks = msg.tasks
 	m = m.refreshTable()
 
-	return m, nil
+	if len(msg.failed) > 0 {
+		toast, cmd := m.showToast(fmt.Sprintf("⚠ %s; refresh failed for %s", loaded, strings.Join(msg.failed, ", ")), true)
+		return toast, tea.Batch(cmd, next)
+	}
+	toast, cmd := m.showToast("✓ "+loaded, false)
+	return toast, tea.Batch(cmd, next)
+}
+
+// statusLine renders the spinner for in-flight operations and the latest toast.
+func (m model) statusLine() string {
+	var parts []string
+	if m.pending > 0 {
+		parts = append(parts, m.spinner.View()+" "+m.busyText)
+	}
+	if bar := m.bulkProgress(); bar != "" {
+		parts = append(parts, bar)
+	}
+	if m.toast != "" {
+		style := toastStyle()
+		if m.toastIsError {
+			style = toastErrorStyle()
+		}
+		parts = append(parts, style.Render(m.toast))
+	}
+	return strings.Join(parts, "  ")
+}
+
+func (m model) taskForSelectedRow() *models.Task {
+	visible := m.visibleTasks()
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(visible) {
+		return nil
+	}
+	return visible[cursor]
 }
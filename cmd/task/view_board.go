@@ -0,0 +1,241 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"opentask/pkg/models"
+	"opentask/pkg/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// boardStatuses are the board columns, left to right.
+var boardStatuses = []models.TaskStatus{
+	models.StatusOpen,
+	models.StatusInProgress,
+	models.StatusDone,
+	models.StatusCancelled,
+}
+
+// boardCursor tracks the focused card. follow holds the ID of a card being
+// moved so focus can stay on it once the platform confirms the move.
+type boardCursor struct {
+	column int
+	rows   [4]int
+	follow string
+}
+
+// openBoard switches to the board and focuses the task under the list cursor.
+func (m model) openBoard() model {
+	m.home = viewBoard
+	m.currentView = viewBoard
+	if task := m.taskForSelectedRow(); task != nil {
+		m = m.focusBoardTask(task.ID)
+	}
+	return m
+}
+
+func (m model) closeBoard() model {
+	m.home = viewList
+	m.currentView = viewList
+	return m
+}
+
+// boardColumns groups the visible tasks by status, keeping list order.
+func (m model) boardColumns() [][]*models.Task {
+	columns := make([][]*models.Task, len(boardStatuses))
+	for _, task := range m.visibleTasks() {
+		for i, status := range boardStatuses {
+			if task.Status == status {
+				columns[i] = append(columns[i], task)
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// boardTask returns the focused card, or nil if its column is empty.
+func (m model) boardTask() *models.Task {
+	column := m.boardColumns()[m.board.column]
+	row := m.board.rows[m.board.column]
+	if row < 0 || row >= len(column) {
+		return nil
+	}
+	return column[row]
+}
+
+func (m model) focusBoardTask(id string) model {
+	for c, column := range m.boardColumns() {
+		for r, task := range column {
+			if task.ID == id {
+				m.board.column = c
+				m.board.rows[c] = r
+				return m
+			}
+		}
+	}
+	return m
+}
+
+func (m model) clampBoard() model {
+	columns := m.boardColumns()
+	m.board.column = max(0, min(m.board.column, len(boardStatuses)-1))
+	for c, column := range columns {
+		m.board.rows[c] = max(0, min(m.board.rows[c], len(column)-1))
+	}
+	return m
+}
+
+// updateBoard handles keys while the board is shown.
+func (m model) updateBoard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "b", "esc":
+		return m.closeBoard(), nil
+	case "left", "h":
+		m.board.column--
+	case "right", "l":
+		m.board.column++
+	case "up", "k":
+		m.board.rows[m.board.column]--
+	case "down", "j":
+		m.board.rows[m.board.column]++
+	case "shift+left", "H":
+		return m.moveCard(m.board.column - 1)
+	case "shift+right", "L":
+		return m.moveCard(m.board.column + 1)
+	case "1", "2", "3", "4":
+		return m.moveCard(int(msg.String()[0] - '1'))
+	case "enter":
+		if task := m.boardTask(); task != nil {
+			m.selectedTask = task
+			m.currentView = viewDetail
+			m = m.setDetailContent()
+			return m, m.loadTransitions(task)
+		}
+		return m, nil
+	case "d":
+		if task := m.boardTask(); task != nil {
+			m.deleteTask = task
+			m.currentView = viewDeleteConfirm
+		}
+		return m, nil
+	case "r":
+		return m.refreshTasks()
+	}
+
+	return m.clampBoard(), nil
+}
+
+// moveCard changes the focused task's status to the given column. Focus
+// follows the card once the platform accepts the transition.
+func (m model) moveCard(column int) (tea.Model, tea.Cmd) {
+	task := m.boardTask()
+	if task == nil || column < 0 || column >= len(boardStatuses) || column == m.board.column {
+		return m, nil
+	}
+
+	m.board.follow = task.ID
+	return m.updateStatus(task, string(boardStatuses[column]))
+}
+
+func (m model) renderBoard() string {
+	m = m.clampBoard()
+
+	width := m.width
+	if width == 0 {
+		width = 120
+	}
+	columnWidth := max(20, width/len(boardStatuses)-2)
+
+	// Each card takes four lines: two of text and two of border.
+	maxCards := 6
+	if m.height > 0 {
+		maxCards = max(1, (m.height-8)/4)
+	}
+
+	columns := m.boardColumns()
+	rendered := make([]string, len(columns))
+	for c, tasks := range columns {
+		rendered[c] = m.renderBoardColumn(c, tasks, columnWidth, maxCards)
+	}
+
+	view := lipgloss.JoinHorizontal(lipgloss.Top, rendered...) + "\n" +
+		"←→/hl: column • ↑↓/jk: card • H/L: move card • 1-4: set status • enter: details • d:delete • r:refresh • b/esc: list • q:quit"
+	if bar := m.renderFilterBar(); bar != "" {
+		view = bar + "\n" + view
+	}
+	if status := m.statusLine(); status != "" {
+		view += "\n" + status
+	}
+	return view
+}
+
+func (m model) renderBoardColumn(c int, tasks []*models.Task, width, maxCards int) string {
+	status := boardStatuses[c]
+	color := styles.Current().StatusColor(status)
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(color).
+		Width(width).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(color)
+	if c == m.board.column {
+		headerStyle = headerStyle.Underline(true)
+	}
+
+	lines := []string{headerStyle.Render(fmt.Sprintf("%s (%d)", strings.ToUpper(status.String()), len(tasks)))}
+
+	cursor := m.board.rows[c]
+	start := max(0, cursor-maxCards+1)
+	end := min(len(tasks), start+maxCards)
+
+	dim := styles.Help()
+	if start > 0 {
+		lines = append(lines, dim.Render(fmt.Sprintf("↑ %d more", start)))
+	}
+	for r := start; r < end; r++ {
+		focused := c == m.board.column && r == cursor
+		lines = append(lines, renderCard(tasks[r], width, focused))
+	}
+	if end < len(tasks) {
+		lines = append(lines, dim.Render(fmt.Sprintf("↓ %d more", len(tasks)-end)))
+	}
+
+	return lipgloss.NewStyle().Width(width).MarginRight(2).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func renderCard(task *models.Task, width int, focused bool) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Current().Border).
+		Width(width-2).
+		Padding(0, 1)
+	if focused {
+		style = style.BorderForeground(styles.Current().Accent).Bold(true)
+	}
+
+	textWidth := width - 6
+	meta := task.ID
+	if task.Priority != "" {
+		meta += " · " + task.Priority.String()
+	}
+	meta = styles.Help().Render(truncate(meta, textWidth))
+
+	return style.Render(meta + "\n" + truncate(task.Title, textWidth))
+}
+
+// truncate shortens s to at most n runes, marking the cut with an ellipsis.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if n <= 0 || len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
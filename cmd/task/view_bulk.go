@@ -0,0 +1,518 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+	"opentask/pkg/styles"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// bulkConcurrency bounds how many platform requests a bulk action runs at
+// once.
+const bulkConcurrency = 4
+
+// bulkOperation is a single action applied to one task as part of a bulk
+// action. It returns the updated task, or nil if the task was removed.
+type bulkOperation func(ctx context.Context, task *models.Task) (*models.Task, error)
+
+// bulkJob tracks a running bulk action and collects per-task results.
+type bulkJob struct {
+	id      int
+	action  string
+	total   int
+	results []bulkResultMsg
+
+	// audit is the action written to the audit log when the job
+	// finishes, and edit the change it makes to each task, or nil if it
+	// removes them.
+	audit    string
+	edit     func(*models.Task) *models.Task
+	auditErr error
+}
+
+type bulkResultMsg struct {
+	job    int
+	task   *models.Task
+	result *models.Task
+	err    error
+}
+
+// labelSuggestionsMsg carries the labels used in the projects of the
+// selected tasks, offered as suggestions by the bulk label prompt.
+type labelSuggestionsMsg struct {
+	labels []string
+}
+
+// toggleSelection marks or unmarks the task under the cursor and moves down.
+func (m model) toggleSelection() model {
+	task := m.taskForSelectedRow()
+	if task == nil {
+		return m
+	}
+
+	if m.selected[task.ID] {
+		delete(m.selected, task.ID)
+	} else {
+		m.selected[task.ID] = true
+	}
+
+	m = m.refreshTable()
+	m.table.MoveDown(1)
+	return m
+}
+
+// toggleSelectAll selects every visible task, or clears the selection if
+// all of them are already selected.
+func (m model) toggleSelectAll() model {
+	visible := m.visibleTasks()
+
+	all := len(visible) > 0
+	for _, task := range visible {
+		if !m.selected[task.ID] {
+			all = false
+			break
+		}
+	}
+
+	for _, task := range visible {
+		if all {
+			delete(m.selected, task.ID)
+		} else {
+			m.selected[task.ID] = true
+		}
+	}
+	return m.refreshTable()
+}
+
+// selectedTasks returns the selected tasks in list order.
+func (m model) selectedTasks() []*models.Task {
+	var tasks []*models.Task
+	for _, task := range m.tasks {
+		if m.selected[task.ID] {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+func (m model) bulkUpdateStatus(statusStr string) (tea.Model, tea.Cmd) {
+	status := models.TaskStatus(statusStr)
+	if !status.IsValid() {
+		return m, nil
+	}
+
+	audit := store.AuditUpdate
+	if status == models.StatusDone || status == models.StatusCancelled {
+		audit = store.AuditClose
+	}
+
+	return m.bulkUpdate(fmt.Sprintf("Set status to %s", status), audit, func(task *models.Task) *models.Task {
+		updated := *task
+		updated.SetStatus(status)
+		return &updated
+	})
+}
+
+func (m model) bulkDelete() (tea.Model, tea.Cmd) {
+	m.currentView = viewList
+	return m.startBulk("Delete", store.AuditDelete, m.bulkDeleteTasks, nil,
+		func(ctx context.Context, task *models.Task) (*models.Task, error) {
+			client, err := m.clientFor(task)
+			if err != nil {
+				return nil, err
+			}
+			return nil, client.DeleteTask(ctx, task.ID)
+		})
+}
+
+func (m model) bulkAddLabel(label string) (tea.Model, tea.Cmd) {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return m, nil
+	}
+
+	return m.bulkUpdate(fmt.Sprintf("Add label %q", label), store.AuditUpdate, func(task *models.Task) *models.Task {
+		if task.HasLabel(label) {
+			return nil
+		}
+		updated := *task
+		updated.Labels = append(append([]string(nil), task.Labels...), label)
+		return &updated
+	})
+}
+
+// bulkUpdate updates every selected task to what edit returns for it.
+// Tasks edit returns nil for are left as they are.
+func (m model) bulkUpdate(action, audit string, edit func(task *models.Task) *models.Task) (tea.Model, tea.Cmd) {
+	return m.startBulk(action, audit, m.selectedTasks(), edit,
+		func(ctx context.Context, task *models.Task) (*models.Task, error) {
+			updated := edit(task)
+			if updated == nil {
+				return task, nil
+			}
+
+			client, err := m.clientFor(task)
+			if err != nil {
+				return nil, err
+			}
+			return client.UpdateTask(ctx, updated)
+		})
+}
+
+// startBulk runs op for every task concurrently, bounded by
+// bulkConcurrency. Each task reports back with a bulkResultMsg. audit is the
+// action the finished job is recorded under in the audit log, and edit, if
+// not nil, the change op makes to each task.
+func (m model) startBulk(action, audit string, tasks []*models.Task, edit func(*models.Task) *models.Task, op bulkOperation) (tea.Model, tea.Cmd) {
+	if len(tasks) == 0 || m.bulk != nil {
+		return m, nil
+	}
+
+	m.bulkSeq++
+	job := &bulkJob{id: m.bulkSeq, action: action, total: len(tasks), audit: audit, edit: edit}
+	m.bulk = job
+
+	sem := make(chan struct{}, bulkConcurrency)
+	cmds := make([]tea.Cmd, 0, len(tasks))
+	for _, task := range tasks {
+		cmds = append(cmds, func() tea.Msg {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			ctx, cancel := service.WithRequestTimeout(context.Background())
+			defer cancel()
+
+			result, err := op(ctx, task)
+			return bulkResultMsg{job: job.id, task: task, result: result, err: err}
+		})
+	}
+
+	m, cmd := m.startOperation(fmt.Sprintf("%s on %d task(s)...", action, len(tasks)), tea.Batch(cmds...))
+	return m, cmd
+}
+
+func (m model) handleBulkResult(msg bulkResultMsg) (tea.Model, tea.Cmd) {
+	job := m.bulk
+	if job == nil || msg.job != job.id {
+		return m, nil
+	}
+
+	job.results = append(job.results, msg)
+	if msg.err == nil {
+		delete(m.selected, msg.task.ID)
+		for i, task := range m.tasks {
+			if task.ID != msg.task.ID {
+				continue
+			}
+			if msg.result == nil {
+				m.tasks = append(m.tasks[:i], m.tasks[i+1:]...)
+			} else {
+				m.tasks[i] = msg.result
+			}
+			break
+		}
+		m = m.refreshTable()
+	}
+
+	if len(job.results) < job.total {
+		return m, nil
+	}
+
+	audit := make([]store.AuditTask, 0, len(job.results))
+	for _, result := range job.results {
+		if job.edit == nil {
+			audit = append(audit, store.NewAuditTask(result.task, result.err))
+			continue
+		}
+		// The platform's answer can leave fields out, so the change is
+		// taken from what was asked for.
+		if updated := job.edit(result.task); updated != nil {
+			audit = append(audit, store.NewAuditUpdate(result.task, updated, result.err))
+		}
+	}
+	job.auditErr = recordAudit(job.audit, "tui", audit)
+
+	m = m.finishOperation()
+	m.bulkDeleteTasks = nil
+	m.currentView = viewBulkSummary
+	return m, nil
+}
+
+// bulkProgress renders the progress bar shown while a bulk action runs.
+func (m model) bulkProgress() string {
+	job := m.bulk
+	if job == nil || len(job.results) >= job.total {
+		return ""
+	}
+
+	percent := float64(len(job.results)) / float64(job.total)
+	return fmt.Sprintf("%s %d/%d", m.progress.ViewAs(percent), len(job.results), job.total)
+}
+
+func (m model) renderBulkSummary() string {
+	job := m.bulk
+	if job == nil {
+		return ""
+	}
+
+	failed := 0
+	var lines []string
+	for _, result := range job.results {
+		if result.err != nil {
+			failed++
+			lines = append(lines, toastErrorStyle().Render(fmt.Sprintf("✗ %s  %v", result.task.ID, result.err)))
+			continue
+		}
+		lines = append(lines, toastStyle().Render(fmt.Sprintf("✓ %s", result.task.ID))+"  "+result.task.Title)
+	}
+
+	header := styles.Title().
+		MarginBottom(1).
+		Render(fmt.Sprintf("%s: %d succeeded, %d failed", job.action, job.total-failed, failed))
+
+	footer := styles.Help().
+		MarginTop(1).
+		Render("Press any key to return to the list")
+	if job.auditErr != nil {
+		footer = toastErrorStyle().MarginTop(1).Render(fmt.Sprintf("⚠ Failed to write the audit log: %v", job.auditErr)) + "\n" + footer
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		detailStyle().Render(strings.Join(lines, "\n")),
+		footer,
+	)
+}
+
+func (m model) renderBulkDeleteConfirm() string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Current().Error).
+		Padding(1, 2).
+		MarginTop(5).
+		MarginLeft(10)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Current().Error)
+
+	var ids []string
+	for _, task := range m.bulkDeleteTasks {
+		ids = append(ids, task.ID)
+	}
+
+	content := fmt.Sprintf(
+		"%s\n\n%s\n\n%s\n\n%s",
+		titleStyle.Render("⚠ Delete Tasks"),
+		fmt.Sprintf("Are you sure you want to delete %d selected task(s)?", len(m.bulkDeleteTasks)),
+		strings.Join(ids, ", "),
+		"Press 'y' to confirm, 'n' to cancel, or ESC to go back",
+	)
+
+	return style.Render(content)
+}
+
+// bulkGuard asks before a bulk action: with 'y' for up to
+// ui.bulk_confirm_threshold tasks, and with a typed confirmation phrase for
+// more.
+type bulkGuard struct {
+	title string
+	tasks []*models.Task
+	// phrase is the confirmation phrase to type, or empty if 'y' confirms.
+	phrase string
+	input  textinput.Model
+	start  func(m model) (tea.Model, tea.Cmd)
+}
+
+// guardBulk opens the guard that confirms a bulk action on tasks before
+// start runs it.
+func (m model) guardBulk(action, title string, tasks []*models.Task, start func(m model) (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	if len(tasks) == 0 {
+		return m, nil
+	}
+
+	m.guard = &bulkGuard{
+		title: title,
+		tasks: tasks,
+		start: start,
+	}
+	m.table.Blur()
+	if len(tasks) <= bulkConfirmThreshold(m.config) {
+		return m, nil
+	}
+
+	input := textinput.New()
+	input.Prompt = "> "
+	input.CharLimit = 100
+	m.guard.phrase = confirmationPhrase(action, len(tasks))
+	m.guard.input = input
+	return m, m.guard.input.Focus()
+}
+
+// updateBulkGuard routes keys to the guard while it is open.
+func (m model) updateBulkGuard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	guard := m.guard
+	switch key := msg.String(); {
+	case key == "esc" || (guard.phrase == "" && key == "n"):
+		m.guard = nil
+		m.table.Focus()
+		return m, nil
+	case key == "enter" || (guard.phrase == "" && key == "y"):
+		if guard.phrase != "" && strings.TrimSpace(guard.input.Value()) != guard.phrase {
+			return m, nil
+		}
+		m.guard = nil
+		m.table.Focus()
+		return guard.start(m)
+	case key == "ctrl+c":
+		return m, tea.Quit
+	}
+
+	if guard.phrase == "" {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	guard.input, cmd = guard.input.Update(msg)
+	return m, cmd
+}
+
+func (m model) renderBulkGuard() string {
+	guard := m.guard
+
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Current().Error).
+		Padding(1, 2).
+		MarginTop(5).
+		MarginLeft(10)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Current().Error)
+
+	var ids []string
+	for _, task := range guard.tasks {
+		ids = append(ids, task.ID)
+	}
+
+	confirm := "Press 'y' to confirm, 'n' to cancel, or ESC to go back"
+	if guard.phrase != "" {
+		confirm = fmt.Sprintf("Type %q to confirm:\n%s\n\nPress Enter to confirm or ESC to cancel", guard.phrase, guard.input.View())
+	}
+
+	content := fmt.Sprintf(
+		"%s\n\n%s\n\n%s\n\n%s",
+		titleStyle.Render("⚠ "+guard.title),
+		fmt.Sprintf("This affects %d selected tasks:", len(guard.tasks)),
+		strings.Join(ids, ", "),
+		confirm,
+	)
+
+	return style.Render(content)
+}
+
+func newBulkProgress() progress.Model {
+	opts := []progress.Option{
+		progress.WithSolidFill(string(styles.Current().Accent)),
+		progress.WithWidth(30),
+		progress.WithoutPercentage(),
+	}
+	if styles.ColorDisabled() {
+		opts = append(opts, progress.WithColorProfile(termenv.Ascii))
+	}
+	return progress.New(opts...)
+}
+
+// clientFor returns a client for the platform a task belongs to.
+func (m model) clientFor(task *models.Task) (platforms.PlatformClient, error) {
+	if m.config == nil {
+		return nil, fmt.Errorf("no configuration loaded")
+	}
+
+	platformName := string(task.Platform)
+	platform, exists := m.config.GetPlatform(platformName)
+	if !exists || !platform.Enabled {
+		return nil, fmt.Errorf("platform %s not found or not enabled", platformName)
+	}
+	return service.NewClient(platformName, platform)
+}
+
+// loadLabelSuggestions lists the labels used in the projects of the
+// selected tasks, from the vocabulary cache where it is fresh.
+func (m model) loadLabelSuggestions() tea.Cmd {
+	if m.config == nil {
+		return nil
+	}
+
+	type target struct{ platform, project string }
+	seen := make(map[target]bool)
+	var targets []target
+	for _, task := range m.selectedTasks() {
+		t := target{string(task.Platform), task.ProjectID}
+		if !seen[t] {
+			seen[t] = true
+			targets = append(targets, t)
+		}
+	}
+
+	svc := service.New(m.config)
+	return func() tea.Msg {
+		var labels []string
+		for _, t := range targets {
+			ctx, cancel := service.WithRequestTimeout(context.Background())
+			vocabulary, err := svc.Vocabulary.Get(ctx, t.platform, t.project)
+			cancel()
+			if err == nil && vocabulary != nil {
+				labels = append(labels, vocabulary.Labels...)
+			}
+		}
+		return labelSuggestionsMsg{labels: platforms.SortedUnique(labels)}
+	}
+}
+
+func (m model) handleLabelSuggestions(msg labelSuggestionsMsg) (tea.Model, tea.Cmd) {
+	m.labelInput.SetSuggestions(msg.labels)
+	return m, nil
+}
+
+// updateLabelInput routes keys to the bulk label prompt while it is open.
+func (m model) updateLabelInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.labeling = false
+		m.labelInput.Blur()
+		m.labelInput.Reset()
+		m.table.Focus()
+		return m, nil
+	case "enter":
+		label := m.labelInput.Value()
+		m.labeling = false
+		m.labelInput.Blur()
+		m.labelInput.Reset()
+		m.table.Focus()
+		if strings.TrimSpace(label) == "" {
+			return m, nil
+		}
+		return m.guardBulk("update", "Add Label", m.selectedTasks(), func(m model) (tea.Model, tea.Cmd) {
+			return m.bulkAddLabel(label)
+		})
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	m.labelInput, cmd = m.labelInput.Update(msg)
+	return m, cmd
+}
@@ -0,0 +1,290 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"opentask/pkg/config"
+	"opentask/pkg/humanize"
+	"opentask/pkg/models"
+	"opentask/pkg/styles"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	minColumnWidth = 4
+	maxColumnWidth = 120
+)
+
+// taskColumn is a column the task table can show.
+type taskColumn struct {
+	Title string
+	Width int
+	Value func(task *models.Task) string
+}
+
+// taskColumns holds every available column, keyed by its config name.
+var taskColumns = map[string]taskColumn{
+	"id":       {Title: "ID", Width: 4, Value: func(t *models.Task) string { return t.ID }},
+	"ref":      {Title: "REF", Width: 16, Value: func(t *models.Task) string { return t.Ref() }},
+	"platform": {Title: "PLATFORM", Width: 10, Value: func(t *models.Task) string { return t.Platform.String() }},
+	"status":   {Title: "STATUS", Width: 12, Value: func(t *models.Task) string { return t.Status.String() }},
+	"priority": {Title: "PRIORITY", Width: 10, Value: func(t *models.Task) string { return t.Priority.String() }},
+	"title":    {Title: "TITLE", Width: 50, Value: func(t *models.Task) string { return t.Title }},
+	"assignee": {Title: "ASSIGNEE", Width: 10, Value: func(t *models.Task) string {
+		if t.Assignee == nil {
+			return "none"
+		}
+		return t.Assignee.Name
+	}},
+	"due": {Title: "DUE", Width: 12, Value: func(t *models.Task) string {
+		if t.DueDate == nil {
+			return ""
+		}
+		return humanize.Due(*t.DueDate)
+	}},
+	"project": {Title: "PROJECT", Width: 12, Value: func(t *models.Task) string { return t.ProjectID }},
+	"labels":  {Title: "LABELS", Width: 20, Value: func(t *models.Task) string { return strings.Join(t.Labels, ",") }},
+	"updated": {Title: "UPDATED", Width: 16, Value: func(t *models.Task) string { return humanize.Time(t.UpdatedAt) }},
+}
+
+// columnOrder lists the columns in the order the picker offers them.
+var columnOrder = []string{"id", "ref", "platform", "status", "priority", "title", "assignee", "due", "project", "labels", "updated"}
+
+// defaultColumns is shown when ui.columns is not configured.
+var defaultColumns = []string{"id", "platform", "status", "priority", "title", "assignee"}
+
+// configuredColumns returns the columns set in ui.columns, skipping unknown
+// names, or the default columns if none are set.
+func configuredColumns(cfg *config.Config) []config.Column {
+	var columns []config.Column
+	if cfg != nil {
+		for _, column := range cfg.UI.Columns {
+			name := strings.ToLower(column.Name)
+			if _, ok := taskColumns[name]; ok {
+				columns = append(columns, config.Column{Name: name, Width: column.Width})
+			}
+		}
+	}
+
+	if len(columns) == 0 {
+		for _, name := range defaultColumns {
+			columns = append(columns, config.Column{Name: name})
+		}
+	}
+	return columns
+}
+
+func columnWidth(column config.Column) int {
+	if column.Width > 0 {
+		return column.Width
+	}
+	return taskColumns[column.Name].Width
+}
+
+// tableColumns builds the table header for the configured columns.
+func (m model) tableColumns() []table.Column {
+	var columns []table.Column
+	if m.hasMarkers() {
+		// Selection marker for bulk actions
+		columns = append(columns, table.Column{Title: " ", Width: 1})
+	}
+	flex, othersWidth := -1, 0
+	for _, column := range m.columns {
+		// A title column without a configured width fills the terminal.
+		if column.Name == "title" && column.Width == 0 && m.width > 0 {
+			flex = len(columns)
+		}
+		columns = append(columns, table.Column{Title: taskColumns[column.Name].Title, Width: columnWidth(column)})
+	}
+
+	if flex >= 0 {
+		for i, column := range columns {
+			if i != flex {
+				othersWidth += column.Width + cellPadding
+			}
+		}
+		columns[flex].Width = m.titleWidth(othersWidth)
+	}
+	return columns
+}
+
+// setColumns replaces the table columns and rebuilds the rows to match.
+func (m model) setColumns(columns []config.Column) model {
+	m.columns = columns
+	// Rows must never be longer or shorter than the header while rendering.
+	m.table.SetRows(nil)
+	m.table.SetColumns(m.tableColumns())
+	return m.refreshTable().layout()
+}
+
+// columnPicker edits the column list. Enabled columns keep their order and
+// come first; the rest follow in columnOrder.
+type columnPicker struct {
+	items  []pickerItem
+	cursor int
+}
+
+type pickerItem struct {
+	column  config.Column
+	enabled bool
+}
+
+type columnsSavedMsg struct {
+	err error
+}
+
+func newColumnPicker(current []config.Column) *columnPicker {
+	picker := &columnPicker{}
+	seen := make(map[string]bool)
+	for _, column := range current {
+		seen[column.Name] = true
+		picker.items = append(picker.items, pickerItem{column: column, enabled: true})
+	}
+	for _, name := range columnOrder {
+		if !seen[name] {
+			picker.items = append(picker.items, pickerItem{column: config.Column{Name: name}})
+		}
+	}
+	return picker
+}
+
+func (p *columnPicker) result() []config.Column {
+	var columns []config.Column
+	for _, item := range p.items {
+		if item.enabled {
+			columns = append(columns, item.column)
+		}
+	}
+	return columns
+}
+
+func (p *columnPicker) resize(delta int) {
+	item := &p.items[p.cursor]
+	item.column.Width = max(minColumnWidth, min(maxColumnWidth, columnWidth(item.column)+delta))
+}
+
+func (p *columnPicker) swap(delta int) {
+	target := p.cursor + delta
+	if target < 0 || target >= len(p.items) {
+		return
+	}
+	p.items[p.cursor], p.items[target] = p.items[target], p.items[p.cursor]
+	p.cursor = target
+}
+
+func (m model) openColumnPicker() model {
+	m.picker = newColumnPicker(m.columns)
+	m.currentView = viewColumns
+	return m
+}
+
+// updateColumnPicker handles keys while the column picker is open.
+func (m model) updateColumnPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	picker := m.picker
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q":
+		m.picker = nil
+		m.currentView = viewList
+		return m, nil
+	case "up", "k":
+		picker.cursor = max(0, picker.cursor-1)
+	case "down", "j":
+		picker.cursor = min(len(picker.items)-1, picker.cursor+1)
+	case "K", "shift+up":
+		picker.swap(-1)
+	case "J", "shift+down":
+		picker.swap(1)
+	case " ", "x":
+		picker.items[picker.cursor].enabled = !picker.items[picker.cursor].enabled
+	case "+", "=", "right", "l":
+		picker.resize(2)
+	case "-", "left", "h":
+		picker.resize(-2)
+	case "R":
+		m.picker = newColumnPicker(configuredColumns(nil))
+	case "enter", "ctrl+s":
+		columns := picker.result()
+		if len(columns) == 0 {
+			return m.showToast("✗ Select at least one column", true)
+		}
+		m.picker = nil
+		m.currentView = viewList
+		m = m.setColumns(columns)
+		return m.startOperation("Saving columns...", saveColumns(m.configPath, columns))
+	}
+
+	return m, nil
+}
+
+// saveColumns persists the column layout to ui.columns in the config file
+// at path.
+func saveColumns(path string, columns []config.Column) tea.Cmd {
+	return func() tea.Msg {
+		manager := config.NewManager()
+		if err := manager.Load(path); err != nil {
+			return columnsSavedMsg{err: err}
+		}
+
+		manager.GetConfig().UI.Columns = columns
+		return columnsSavedMsg{err: manager.Save()}
+	}
+}
+
+func (m model) handleColumnsSaved(msg columnsSavedMsg) (tea.Model, tea.Cmd) {
+	m = m.finishOperation()
+	if m.config != nil {
+		m.config.UI.Columns = m.columns
+	}
+	if msg.err != nil {
+		return m.showToast(fmt.Sprintf("✗ Columns applied but not saved: %v", msg.err), true)
+	}
+	return m.showToast("✓ Column layout saved", false)
+}
+
+func (m model) renderColumnPicker() string {
+	picker := m.picker
+
+	titleStyle := styles.Title().
+		MarginBottom(1)
+	cursorStyle := styles.SelectedRow()
+	dim := styles.Help()
+
+	var lines []string
+	for i, item := range picker.items {
+		check := "[ ]"
+		if item.enabled {
+			check = "[x]"
+		}
+		line := fmt.Sprintf("%s %-10s %3d", check, item.column.Name, columnWidth(item.column))
+		switch {
+		case i == picker.cursor:
+			line = cursorStyle.Render(line)
+		case !item.enabled:
+			line = dim.Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	footer := dim.MarginTop(1).Render(
+		"space: show/hide • ←→/+-: width • J/K: reorder • R: defaults • enter: apply and save • esc: cancel")
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		titleStyle.Render("Table Columns"),
+		strings.Join(lines, "\n"),
+		footer,
+	)
+
+	view := detailStyle().Render(content)
+	if status := m.statusLine(); status != "" {
+		view += "\n" + status
+	}
+	return view
+}
@@ -0,0 +1,390 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"opentask/pkg/models"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+	"opentask/pkg/styles"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Edit form fields, in tab order.
+const (
+	editTitle = iota
+	editDescription
+	editPriority
+	editLabels
+	editAssignee
+	editFieldCount
+)
+
+// userSearchDelay debounces assignee autocomplete while typing.
+const userSearchDelay = 300 * time.Millisecond
+
+// editForm holds the state of the task editor opened from the detail view.
+type editForm struct {
+	task        *models.Task
+	title       textinput.Model
+	description textarea.Model
+	priority    textinput.Model
+	labels      textinput.Model
+	assignee    textinput.Model
+	focus       int
+	err         string
+
+	searchSeq   int
+	suggestions []*models.User
+	suggestion  int
+	chosen      *models.User
+}
+
+type userSearchTickMsg struct {
+	seq int
+}
+
+type usersFoundMsg struct {
+	seq   int
+	users []*models.User
+	err   error
+}
+
+func newEditForm(task *models.Task) *editForm {
+	form := &editForm{task: task}
+
+	form.title = textinput.New()
+	form.title.SetValue(task.Title)
+	form.title.CharLimit = 255
+	form.title.Width = 60
+
+	form.description = textarea.New()
+	form.description.SetValue(task.Description)
+	form.description.SetWidth(60)
+	form.description.SetHeight(6)
+	form.description.ShowLineNumbers = false
+
+	form.priority = textinput.New()
+	form.priority.SetValue(task.Priority.String())
+	form.priority.Placeholder = "low, medium, high, urgent"
+	form.priority.Width = 30
+
+	form.labels = textinput.New()
+	form.labels.SetValue(strings.Join(task.Labels, ", "))
+	form.labels.Placeholder = "comma separated"
+	form.labels.Width = 60
+
+	form.assignee = textinput.New()
+	form.assignee.Placeholder = "type to search users"
+	form.assignee.Width = 40
+	if task.Assignee != nil {
+		form.assignee.SetValue(task.Assignee.Name)
+	}
+
+	form.setFocus(editTitle)
+	return form
+}
+
+func (f *editForm) setFocus(field int) tea.Cmd {
+	f.focus = (field + editFieldCount) % editFieldCount
+
+	f.title.Blur()
+	f.description.Blur()
+	f.priority.Blur()
+	f.labels.Blur()
+	f.assignee.Blur()
+
+	switch f.focus {
+	case editTitle:
+		return f.title.Focus()
+	case editDescription:
+		return f.description.Focus()
+	case editPriority:
+		return f.priority.Focus()
+	case editLabels:
+		return f.labels.Focus()
+	default:
+		return f.assignee.Focus()
+	}
+}
+
+// updateFocused passes msg to the focused field.
+func (f *editForm) updateFocused(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	switch f.focus {
+	case editTitle:
+		f.title, cmd = f.title.Update(msg)
+	case editDescription:
+		f.description, cmd = f.description.Update(msg)
+	case editPriority:
+		f.priority, cmd = f.priority.Update(msg)
+	case editLabels:
+		f.labels, cmd = f.labels.Update(msg)
+	case editAssignee:
+		f.assignee, cmd = f.assignee.Update(msg)
+	}
+	return cmd
+}
+
+// result applies the form values to a copy of the edited task.
+func (f *editForm) result() (*models.Task, error) {
+	title := strings.TrimSpace(f.title.Value())
+	if title == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+
+	priority := models.Priority(strings.ToLower(strings.TrimSpace(f.priority.Value())))
+	if priority != "" && !priority.IsValid() {
+		return nil, fmt.Errorf("invalid priority %q (use low, medium, high, urgent)", priority)
+	}
+
+	updated := *f.task
+	updated.Title = title
+	updated.Description = f.description.Value()
+	if priority != "" {
+		updated.Priority = priority
+	}
+
+	updated.Labels = nil
+	for _, label := range strings.Split(f.labels.Value(), ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			updated.Labels = append(updated.Labels, label)
+		}
+	}
+
+	if f.chosen != nil {
+		updated.Assignee = f.chosen
+	}
+
+	updated.UpdatedAt = time.Now()
+	return &updated, nil
+}
+
+func (m model) openEditForm() (tea.Model, tea.Cmd) {
+	if m.selectedTask == nil {
+		return m, nil
+	}
+
+	m.edit = newEditForm(m.selectedTask)
+	m.currentView = viewEdit
+	return m, textinput.Blink
+}
+
+func (m model) closeEditForm() model {
+	m.edit = nil
+	m.currentView = viewDetail
+	return m
+}
+
+// updateEditForm handles keys while the edit form is open.
+func (m model) updateEditForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	form := m.edit
+
+	switch msg.String() {
+	case "esc":
+		return m.closeEditForm(), nil
+	case "ctrl+c":
+		return m, tea.Quit
+	case "ctrl+s":
+		return m.saveEditForm()
+	case "tab":
+		return m, form.setFocus(form.focus + 1)
+	case "shift+tab":
+		return m, form.setFocus(form.focus - 1)
+	}
+
+	if form.focus == editAssignee && len(form.suggestions) > 0 {
+		switch msg.String() {
+		case "up":
+			form.suggestion = (form.suggestion - 1 + len(form.suggestions)) % len(form.suggestions)
+			return m, nil
+		case "down":
+			form.suggestion = (form.suggestion + 1) % len(form.suggestions)
+			return m, nil
+		case "enter":
+			form.chosen = form.suggestions[form.suggestion]
+			form.assignee.SetValue(form.chosen.Name)
+			form.assignee.CursorEnd()
+			form.suggestions = nil
+			return m, nil
+		}
+	}
+
+	before := form.assignee.Value()
+	cmd := form.updateFocused(msg)
+	if form.focus == editAssignee && form.assignee.Value() != before {
+		form.chosen = nil
+		form.suggestions = nil
+		form.searchSeq++
+		seq := form.searchSeq
+		search := tea.Tick(userSearchDelay, func(time.Time) tea.Msg {
+			return userSearchTickMsg{seq: seq}
+		})
+		cmd = tea.Batch(cmd, search)
+	}
+
+	return m, cmd
+}
+
+// searchUsers runs the autocomplete query once typing has paused.
+func (m model) searchUsers(msg userSearchTickMsg) (tea.Model, tea.Cmd) {
+	form := m.edit
+	if form == nil || msg.seq != form.searchSeq || m.config == nil {
+		return m, nil
+	}
+
+	query := strings.TrimSpace(form.assignee.Value())
+	if len(query) < 2 {
+		return m, nil
+	}
+
+	platformName := string(form.task.Platform)
+	platform, exists := m.config.GetPlatform(platformName)
+	if !exists || !platform.Enabled {
+		return m, nil
+	}
+
+	seq := msg.seq
+	return m, func() tea.Msg {
+		client, err := service.NewClient(platformName, platform)
+		if err != nil {
+			return usersFoundMsg{seq: seq, err: err}
+		}
+
+		ctx, cancel := service.WithRequestTimeout(context.Background())
+		defer cancel()
+
+		users, err := client.SearchUsers(ctx, query)
+		return usersFoundMsg{seq: seq, users: users, err: err}
+	}
+}
+
+func (m model) handleUsersFound(msg usersFoundMsg) (tea.Model, tea.Cmd) {
+	form := m.edit
+	if form == nil || msg.seq != form.searchSeq {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		form.err = fmt.Sprintf("User search failed: %v", msg.err)
+		return m, nil
+	}
+
+	form.err = ""
+	form.suggestions = msg.users
+	if len(form.suggestions) > 5 {
+		form.suggestions = form.suggestions[:5]
+	}
+	form.suggestion = 0
+	return m, nil
+}
+
+func (m model) saveEditForm() (tea.Model, tea.Cmd) {
+	form := m.edit
+
+	updated, err := form.result()
+	if err != nil {
+		form.err = err.Error()
+		return m, nil
+	}
+
+	if m.config == nil {
+		return m, nil
+	}
+
+	task := form.task
+	platformName := string(task.Platform)
+	platform, exists := m.config.GetPlatform(platformName)
+	if !exists || !platform.Enabled {
+		form.err = fmt.Sprintf("Platform %s not found or not enabled", platformName)
+		return m, nil
+	}
+
+	save := func() tea.Msg {
+		client, err := service.NewClient(platformName, platform)
+		if err != nil {
+			return taskUpdatedMsg{task: task, err: err}
+		}
+
+		ctx, cancel := service.WithRequestTimeout(context.Background())
+		defer cancel()
+
+		result, err := client.UpdateTask(ctx, updated)
+		logAudit(store.AuditUpdate, store.NewAuditUpdate(task, updated, err))
+		if err != nil {
+			return taskUpdatedMsg{task: task, err: err}
+		}
+		return taskUpdatedMsg{task: result}
+	}
+
+	m = m.closeEditForm()
+	return m.startOperation(fmt.Sprintf("Saving %s...", task.ID), save)
+}
+
+func (m model) renderEditForm() string {
+	form := m.edit
+	if form == nil {
+		return "No task selected"
+	}
+
+	header := styles.Title().
+		MarginBottom(1).
+		Render(fmt.Sprintf("Edit Task: %s", form.task.ID))
+
+	theme := styles.Current()
+	labelStyle := lipgloss.NewStyle().Width(12).Foreground(theme.Muted)
+	focusLabelStyle := labelStyle.Foreground(theme.Accent).Bold(true)
+	suggestionStyle := lipgloss.NewStyle().PaddingLeft(14).Foreground(theme.Muted)
+	suggestionSelStyle := suggestionStyle.Foreground(theme.Selected)
+
+	field := func(index int, label, view string) string {
+		style := labelStyle
+		if form.focus == index {
+			style = focusLabelStyle
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, style.Render(label), view)
+	}
+
+	rows := []string{
+		field(editTitle, "Title", form.title.View()),
+		field(editDescription, "Description", form.description.View()),
+		field(editPriority, "Priority", form.priority.View()),
+		field(editLabels, "Labels", form.labels.View()),
+		field(editAssignee, "Assignee", form.assignee.View()),
+	}
+
+	for i, user := range form.suggestions {
+		line := user.Name
+		if user.Email != "" {
+			line += " <" + user.Email + ">"
+		}
+		if i == form.suggestion {
+			rows = append(rows, suggestionSelStyle.Render("› "+line))
+		} else {
+			rows = append(rows, suggestionStyle.Render("  "+line))
+		}
+	}
+
+	if form.err != "" {
+		rows = append(rows, "", toastErrorStyle().Render(form.err))
+	}
+
+	footer := styles.Help().
+		MarginTop(1).
+		Render("tab/shift+tab: next/prev field • ↑↓ enter: pick assignee • ctrl+s: save • ESC cancel")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		detailStyle().Render(strings.Join(rows, "\n")),
+		footer,
+		m.statusLine(),
+	)
+}
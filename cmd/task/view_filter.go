@@ -0,0 +1,155 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"opentask/pkg/models"
+	"opentask/pkg/styles"
+)
+
+// statusFilterCycle is the order the status toggle steps through; the empty
+// status means no status filter.
+var statusFilterCycle = []models.TaskStatus{
+	"",
+	models.StatusOpen,
+	models.StatusInProgress,
+	models.StatusDone,
+	models.StatusCancelled,
+}
+
+// visibleTasks returns the tasks that pass the current query and toggles.
+func (m model) visibleTasks() []*models.Task {
+	if !m.filterActive() {
+		return m.tasks
+	}
+
+	query := m.filterInput.Value()
+	var visible []*models.Task
+	for _, task := range m.tasks {
+		if m.statusFilter != "" && task.Status != m.statusFilter {
+			continue
+		}
+		if m.platformFilter != "" && task.Platform != m.platformFilter {
+			continue
+		}
+		if !matchesQuery(task, query) {
+			continue
+		}
+		visible = append(visible, task)
+	}
+	return visible
+}
+
+func (m model) filterActive() bool {
+	return strings.TrimSpace(m.filterInput.Value()) != "" || m.statusFilter != "" || m.platformFilter != ""
+}
+
+func (m model) clearFilters() model {
+	m.filterInput.Reset()
+	m.statusFilter = ""
+	m.platformFilter = ""
+	return m.refreshTable()
+}
+
+func (m model) cycleStatusFilter() model {
+	for i, status := range statusFilterCycle {
+		if status == m.statusFilter {
+			m.statusFilter = statusFilterCycle[(i+1)%len(statusFilterCycle)]
+			break
+		}
+	}
+	return m.refreshTable()
+}
+
+// cyclePlatformFilter steps through the platforms present in the task list.
+func (m model) cyclePlatformFilter() model {
+	seen := make(map[models.Platform]bool)
+	cycle := []models.Platform{""}
+	for _, task := range m.tasks {
+		if !seen[task.Platform] {
+			seen[task.Platform] = true
+			cycle = append(cycle, task.Platform)
+		}
+	}
+	sort.Slice(cycle[1:], func(i, j int) bool { return cycle[1+i] < cycle[1+j] })
+
+	next := models.Platform("")
+	for i, platform := range cycle {
+		if platform == m.platformFilter {
+			next = cycle[(i+1)%len(cycle)]
+			break
+		}
+	}
+	m.platformFilter = next
+	return m.refreshTable()
+}
+
+// renderFilterBar shows the query prompt and active toggles above the table.
+func (m model) renderFilterBar() string {
+	if !m.filtering && !m.filterActive() {
+		return ""
+	}
+
+	var parts []string
+	if m.filtering {
+		parts = append(parts, m.filterInput.View())
+	} else if query := m.filterInput.Value(); query != "" {
+		parts = append(parts, "/"+query)
+	}
+	if m.statusFilter != "" {
+		parts = append(parts, "status:"+m.statusFilter.String())
+	}
+	if m.platformFilter != "" {
+		parts = append(parts, "platform:"+m.platformFilter.String())
+	}
+	parts = append(parts, styles.Help().Render(fmt.Sprintf("(%d/%d)", len(m.visibleTasks()), len(m.tasks))))
+
+	return strings.Join(parts, "  ")
+}
+
+// matchesQuery reports whether every whitespace-separated term of query
+// fuzzily matches the task's title, labels, assignee or platform.
+func matchesQuery(task *models.Task, query string) bool {
+	fields := []string{task.ID, task.Title, task.Platform.String()}
+	fields = append(fields, task.Labels...)
+	if task.Assignee != nil {
+		fields = append(fields, task.Assignee.Name, task.Assignee.Email, task.Assignee.Username)
+	}
+
+	for _, term := range strings.Fields(query) {
+		matched := false
+		for _, field := range fields {
+			if fuzzyMatch(term, field) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// fuzzyMatch reports whether the runes of pattern appear in text in order,
+// ignoring case.
+func fuzzyMatch(pattern, text string) bool {
+	patternRunes := []rune(strings.ToLower(pattern))
+	if len(patternRunes) == 0 {
+		return true
+	}
+
+	i := 0
+	for _, r := range text {
+		if unicode.ToLower(r) == patternRunes[i] {
+			i++
+			if i == len(patternRunes) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package task
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// minTitleWidth is the narrowest the title column shrinks to in small
+	// terminals.
+	minTitleWidth    = 15
+	minViewportWidth = 20
+	minTableRows     = 3
+
+	// Lines and columns taken by the list chrome: the table border, and
+	// room for the filter bar and status line.
+	listChromeHeight = 4
+	tableBorderWidth = 2
+	cellPadding      = 2
+
+	// Lines and columns taken by the detail chrome around the viewport:
+	// header, border, padding, footer and status line.
+	detailChromeHeight = 9
+	detailChromeWidth  = 6
+)
+
+// layout sizes the table and detail viewport to the terminal.
+func (m model) layout() model {
+	if m.width <= 0 || m.height <= 0 {
+		return m
+	}
+
+	m.table.SetColumns(m.tableColumns())
+	helpHeight := lipgloss.Height(m.wrap(listHelp))
+	m.table.SetHeight(max(minTableRows, m.height-listChromeHeight-helpHeight))
+
+	m.viewport.Width = max(minViewportWidth, m.width-detailChromeWidth)
+	m.viewport.Height = max(minTableRows, m.height-detailChromeHeight)
+	if m.selectedTask != nil {
+		m = m.setDetailContent()
+	}
+	return m
+}
+
+// titleWidth returns the width that lets the title column fill the terminal,
+// given the total width of the other columns including padding.
+func (m model) titleWidth(othersWidth int) int {
+	return max(minTitleWidth, m.width-tableBorderWidth-othersWidth-cellPadding)
+}
+
+// setDetailContent fills the detail viewport, wrapping long lines to its
+// width.
+func (m model) setDetailContent() model {
+	content := m.formatTaskDetail()
+	if m.viewport.Width > 0 {
+		content = lipgloss.NewStyle().Width(m.viewport.Width).Render(content)
+	}
+	m.viewport.SetContent(content)
+	return m
+}
+
+// wrap fits text to the terminal width once it is known.
+func (m model) wrap(text string) string {
+	if m.width <= 0 {
+		return text
+	}
+	return lipgloss.NewStyle().Width(m.width).Render(text)
+}
@@ -0,0 +1,69 @@
+package task
+
+import (
+	"strings"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms/jira"
+	"opentask/pkg/styles"
+
+	"github.com/charmbracelet/glamour"
+	glamourstyles "github.com/charmbracelet/glamour/styles"
+)
+
+// markdownStyle picks the glamour style matching the theme.
+func markdownStyle(plain bool) string {
+	switch {
+	case plain || styles.ColorDisabled():
+		return glamourstyles.NoTTYStyle
+	case styles.Current().Name == styles.Light.Name:
+		return glamourstyles.LightStyle
+	default:
+		return glamourstyles.DarkStyle
+	}
+}
+
+// descriptionMarkdown returns the task description as Markdown, converting
+// Jira wiki markup and ADF. Descriptions read through the v3 API are
+// already Markdown.
+func descriptionMarkdown(task *models.Task) string {
+	if _, converted := task.GetMetadata("jira_adf"); converted {
+		return task.Description
+	}
+	if task.Platform == models.PlatformJira {
+		return jira.DescriptionMarkdown(task.Description)
+	}
+	return task.Description
+}
+
+// renderDescription returns the description shown in the detail view:
+// rendered Markdown, or the raw source when toggled with 's'. The source is
+// also shown if rendering fails.
+func (m model) renderDescription() string {
+	task := m.selectedTask
+	if m.showSource || strings.TrimSpace(task.Description) == "" {
+		return task.Description
+	}
+
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(m.markdownStyle),
+		glamour.WithWordWrap(m.viewport.Width),
+	)
+	if err != nil {
+		return task.Description
+	}
+
+	rendered, err := renderer.Render(descriptionMarkdown(task))
+	if err != nil {
+		return task.Description
+	}
+	return strings.Trim(rendered, "\n")
+}
+
+// sourceToggleLabel names what 's' switches the description to.
+func (m model) sourceToggleLabel() string {
+	if m.showSource {
+		return "rendered"
+	}
+	return "source"
+}
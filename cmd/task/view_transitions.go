@@ -0,0 +1,105 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// statusKeys are the keys that change a task's status, in key order.
+var statusKeys = []struct {
+	key    string
+	status models.TaskStatus
+}{
+	{"1", models.StatusOpen},
+	{"2", models.StatusInProgress},
+	{"3", models.StatusDone},
+	{"4", models.StatusCancelled},
+}
+
+type transitionsLoadedMsg struct {
+	taskID      string
+	transitions []platforms.Transition
+	err         error
+}
+
+// loadTransitions fetches the status changes the task's workflow allows.
+// Platforms without workflow restrictions produce no message.
+func (m model) loadTransitions(task *models.Task) tea.Cmd {
+	if m.config == nil {
+		return nil
+	}
+
+	platformName := string(task.Platform)
+	platform, exists := m.config.GetPlatform(platformName)
+	if !exists || !platform.Enabled {
+		return nil
+	}
+
+	return func() tea.Msg {
+		client, err := service.NewClient(platformName, platform)
+		if err != nil {
+			return transitionsLoadedMsg{taskID: task.ID, err: err}
+		}
+
+		provider, ok := client.(platforms.TransitionProvider)
+		if !ok {
+			return nil
+		}
+
+		ctx, cancel := service.WithRequestTimeout(context.Background())
+		defer cancel()
+
+		transitions, err := provider.GetAvailableTransitions(ctx, task.ID)
+		return transitionsLoadedMsg{taskID: task.ID, transitions: transitions, err: err}
+	}
+}
+
+func (m model) handleTransitionsLoaded(msg transitionsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		// Status changes are still checked by the platform when applied.
+		return m, nil
+	}
+	m.transitions[msg.taskID] = msg.transitions
+	return m, nil
+}
+
+// checkTransition rejects a status change the task's workflow is known not
+// to allow.
+func (m model) checkTransition(task *models.Task, status models.TaskStatus) error {
+	transitions, known := m.transitions[task.ID]
+	if !known {
+		return nil
+	}
+	if _, ok := platforms.FindTransition(transitions, status); ok {
+		return nil
+	}
+	return &platforms.InvalidTransitionError{TaskID: task.ID, From: task.Status, To: status, Allowed: transitions}
+}
+
+// statusKeyHelp lists the status keys for the detail footer, limited to
+// the allowed transitions once they are known.
+func (m model) statusKeyHelp(task *models.Task) string {
+	transitions, known := m.transitions[task.ID]
+
+	var keys []string
+	for _, sk := range statusKeys {
+		if known {
+			if _, ok := platforms.FindTransition(transitions, sk.status); !ok {
+				continue
+			}
+		}
+		keys = append(keys, fmt.Sprintf("%s:%s", sk.key, sk.status))
+	}
+
+	if len(keys) == 0 {
+		return "no status changes allowed"
+	}
+	return strings.Join(keys, " ")
+}
@@ -0,0 +1,87 @@
+package task
+
+import (
+	"fmt"
+	"time"
+
+	"opentask/pkg/activity"
+	"opentask/pkg/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// watchTickMsg starts a watch refresh. Only the tick matching the model's
+// current id is acted on, so a manual refresh does not start a second
+// chain of ticks.
+type watchTickMsg struct {
+	id int
+}
+
+// scheduleWatch arms the next watch refresh, cancelling any previously
+// scheduled one.
+func (m model) scheduleWatch() (model, tea.Cmd) {
+	m.watchID++
+	return m, m.watchTick()
+}
+
+// watchTick arms a refresh for the current watch id.
+func (m model) watchTick() tea.Cmd {
+	id := m.watchID
+	return tea.Tick(m.watch, func(time.Time) tea.Msg {
+		return watchTickMsg{id: id}
+	})
+}
+
+func (m model) handleWatchTick(msg watchTickMsg) (tea.Model, tea.Cmd) {
+	if msg.id != m.watchID {
+		return m, nil
+	}
+	if m.refreshing {
+		return m.scheduleWatch()
+	}
+	return m.refreshTasks()
+}
+
+// markChanges records which of tasks are new or changed since the tasks
+// shown, so their rows are marked until the next refresh.
+func (m model) markChanges(tasks []*models.Task) model {
+	m.changes = taskChanges(m.tasks, tasks)
+	return m
+}
+
+// taskChanges returns the kind of change of each new or changed task,
+// keyed by activity.Key.
+func taskChanges(previous, tasks []*models.Task) map[string]activity.Kind {
+	changes := make(map[string]activity.Kind)
+	for _, event := range activity.Diff(activity.States(previous), tasks, time.Now()) {
+		changes[string(event.Platform)+"/"+event.TaskID] = event.Kind
+	}
+	return changes
+}
+
+// changeMarker marks the row of a new task with + and of a changed task
+// with ~.
+func (m model) changeMarker(task *models.Task) string {
+	kind, ok := m.changes[activity.Key(task)]
+	switch {
+	case !ok:
+		return " "
+	case kind == activity.KindCreated:
+		return "+"
+	}
+	return "~"
+}
+
+// changeSummary describes the marked rows, such as "1 new, 2 changed".
+func changeSummary(changes map[string]activity.Kind) string {
+	created := 0
+	for _, kind := range changes {
+		if kind == activity.KindCreated {
+			created++
+		}
+	}
+	if len(changes) == 0 {
+		return "no changes"
+	}
+	return fmt.Sprintf("%d new, %d changed", created, len(changes)-created)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/telemetry"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingShutdownTimeout bounds how long exiting waits for spans to be
+// exported.
+const tracingShutdownTimeout = 5 * time.Second
+
+var (
+	commandSpan     trace.Span
+	shutdownTracing = func(context.Context) error { return nil }
+)
+
+// startTracing sets up the exporter configured under telemetry and opens a
+// span for the command. Tracing problems never stop the command.
+func startTracing(cmd *cobra.Command, cfg *config.Config) {
+	shutdown, err := telemetry.Setup(cmd.Context(), cfg.Telemetry, cmd.Root().Version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Tracing disabled: %v\n", err)
+		return
+	}
+	shutdownTracing = shutdown
+
+	ctx, span := telemetry.StartCommand(cmd.Context(), cmd.CommandPath())
+	cmd.SetContext(ctx)
+	commandSpan = span
+}
+
+// finishTracing ends the command span, recording err, and flushes spans.
+func finishTracing(err error) {
+	if commandSpan != nil {
+		if err != nil {
+			telemetry.RecordError(commandSpan, err)
+		}
+		commandSpan.End()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	if err := shutdownTracing(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Failed to export traces: %v\n", err)
+	}
+}
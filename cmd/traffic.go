@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/record"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	traffic    *record.Traffic
+	trafficDir string
+)
+
+// startTrafficRecording records the platform API requests of this run when
+// --record is given. Credentials are masked as they are recorded.
+func startTrafficRecording(cmd *cobra.Command, cfg *config.Config) {
+	dir, _ := cmd.Flags().GetString("record")
+	if dir == "" {
+		return
+	}
+
+	redactor := record.NewRedactor()
+	redactor.AddSecrets(knownSecrets(cfg)...)
+	traffic = record.NewTraffic(redactor)
+	trafficDir = dir
+	record.SetTraffic(traffic)
+}
+
+// finishTrafficRecording writes the recorded requests to a HAR file in the
+// --record directory.
+func finishTrafficRecording() {
+	if traffic == nil {
+		return
+	}
+	record.SetTraffic(nil)
+
+	if err := os.MkdirAll(trafficDir, 0700); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Failed to save recorded requests: %v\n", err)
+		return
+	}
+	path := filepath.Join(trafficDir, fmt.Sprintf("opentask-%s.har", time.Now().Format("20060102-150405")))
+	if err := traffic.HAR(rootCmd.Version).Save(path); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Failed to save recorded requests: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "✓ Recorded %d request(s) to %s\n", traffic.Len(), path)
+}
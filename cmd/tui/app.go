@@ -0,0 +1,239 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"opentask/cmd/task"
+	"opentask/pkg/config"
+	"opentask/pkg/live"
+	"opentask/pkg/models"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+	"opentask/pkg/styles"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type tab int
+
+const (
+	tabTasks tab = iota
+	tabProjects
+	tabSprints
+	tabNotifications
+)
+
+var tabs = []string{"Tasks", "Projects", "Sprints", "Notifications"}
+
+// headerHeight is the tab bar and the blank line below it.
+const headerHeight = 2
+
+// app is the full-screen model. The Tasks tab is the interactive task list
+// from "task list"; the other tabs are drawn from the data loaded here.
+type app struct {
+	config   *config.Config
+	service  *service.Service
+	store    *store.Store
+	state    *store.TUIState
+	interval time.Duration
+	// updates are the task changes passed on by a running daemon.
+	updates <-chan live.Update
+
+	tab      tab
+	taskList tea.Model
+	tasks    []*models.Task
+	projects []*models.Project
+
+	projectTable table.Model
+	notifyCursor int
+	sprintOffset int
+
+	palette *palette
+
+	loading bool
+	tickID  int
+	failed  []string
+	err     error
+
+	width  int
+	height int
+}
+
+func newApp(cfg *config.Config, configPath string, s *store.Store, state *store.TUIState, interval time.Duration, updates <-chan live.Update) app {
+	svc := service.New(cfg)
+
+	a := app{
+		config:       cfg,
+		service:      svc,
+		store:        s,
+		state:        state,
+		interval:     interval,
+		updates:      updates,
+		taskList:     task.NewTaskListModel(nil, false, cfg).WithConfigPath(configPath),
+		projectTable: newProjectTable(),
+		// Init starts the first load.
+		loading: true,
+	}
+
+	for i, name := range tabs {
+		if strings.EqualFold(state.Tab, name) {
+			a.tab = tab(i)
+		}
+	}
+
+	return a
+}
+
+func (a app) Init() tea.Cmd {
+	return tea.Batch(a.taskList.Init(), a.load(), waitForUpdate(a.updates))
+}
+
+func (a app) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case refreshTickMsg:
+		if msg.id != a.tickID {
+			return a, nil
+		}
+		return a.refresh()
+	case refreshedMsg:
+		return a.handleRefreshed(msg)
+	case liveUpdateMsg:
+		return a.handleLiveUpdate(msg)
+	case tea.WindowSizeMsg:
+		a.width, a.height = msg.Width, msg.Height
+		a.projectTable.SetHeight(max(3, msg.Height-headerHeight-3))
+		var cmd tea.Cmd
+		a.taskList, cmd = a.taskList.Update(tea.WindowSizeMsg{Width: msg.Width, Height: msg.Height - headerHeight})
+		return a, cmd
+	case tea.KeyMsg:
+		return a.handleKey(msg)
+	}
+
+	// Spinner ticks and operation results belong to the task list.
+	var cmd tea.Cmd
+	a.taskList, cmd = a.taskList.Update(msg)
+	return a, cmd
+}
+
+func (a app) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if a.palette != nil {
+		return a.updatePalette(msg)
+	}
+
+	switch key := msg.String(); key {
+	case "ctrl+c":
+		return a, tea.Quit
+	case "ctrl+p":
+		a.palette = newPalette()
+		return a, nil
+	case "ctrl+t":
+		return a.switchTab((a.tab + 1) % tab(len(tabs))), nil
+	case "alt+1", "alt+2", "alt+3", "alt+4":
+		return a.switchTab(tab(key[len(key)-1] - '1')), nil
+	}
+
+	if a.tab == tabTasks {
+		var cmd tea.Cmd
+		a.taskList, cmd = a.taskList.Update(msg)
+		return a, cmd
+	}
+
+	switch key := msg.String(); key {
+	case "q":
+		return a, tea.Quit
+	case "1", "2", "3", "4":
+		return a.switchTab(tab(key[0] - '1')), nil
+	case "tab":
+		return a.switchTab((a.tab + 1) % tab(len(tabs))), nil
+	case "r":
+		return a.refresh()
+	}
+
+	switch a.tab {
+	case tabProjects:
+		var cmd tea.Cmd
+		a.projectTable, cmd = a.projectTable.Update(msg)
+		return a, cmd
+	case tabSprints:
+		return a.updateSprints(msg), nil
+	case tabNotifications:
+		return a.updateNotifications(msg), nil
+	}
+	return a, nil
+}
+
+// switchTab shows t and remembers it for the next run.
+func (a app) switchTab(t tab) app {
+	a.tab = t
+	a.state.Tab = strings.ToLower(tabs[t])
+	if err := a.store.SaveTUIState(a.state); err != nil {
+		a.err = err
+	}
+	return a
+}
+
+func (a app) View() string {
+	if a.palette != nil {
+		return a.renderTabBar() + "\n\n" + a.renderPalette()
+	}
+
+	var body string
+	switch a.tab {
+	case tabProjects:
+		body = a.renderProjects()
+	case tabSprints:
+		body = a.renderSprints()
+	case tabNotifications:
+		body = a.renderNotifications()
+	default:
+		return a.renderTabBar() + "\n\n" + a.taskList.View()
+	}
+
+	return a.renderTabBar() + "\n\n" + body + "\n" + a.statusLine()
+}
+
+func (a app) renderTabBar() string {
+	active := styles.SelectedRow().Bold(true).Padding(0, 1)
+	inactive := lipgloss.NewStyle().Padding(0, 1)
+
+	parts := make([]string, 0, len(tabs))
+	for i, name := range tabs {
+		label := fmt.Sprintf("%d %s", i+1, name)
+		if tab(i) == tabNotifications {
+			if unread := a.state.Unread(); unread > 0 {
+				label += fmt.Sprintf(" (%d)", unread)
+			}
+		}
+
+		if tab(i) == a.tab {
+			parts = append(parts, active.Render(label))
+		} else {
+			parts = append(parts, inactive.Render(label))
+		}
+	}
+
+	bar := styles.Title().Render("OpenTask") + "  " + strings.Join(parts, " ")
+	return bar + "  " + styles.Help().Render("ctrl+p: commands")
+}
+
+// statusLine reports when data was last loaded and any failures.
+func (a app) statusLine() string {
+	var parts []string
+	switch {
+	case a.loading:
+		parts = append(parts, "Refreshing...")
+	case !a.state.RefreshedAt.IsZero():
+		parts = append(parts, "Updated "+a.state.RefreshedAt.Local().Format("15:04"))
+	}
+	if len(a.failed) > 0 {
+		parts = append(parts, "⚠ refresh failed for "+strings.Join(a.failed, ", "))
+	}
+	if a.err != nil {
+		parts = append(parts, fmt.Sprintf("✗ %v", a.err))
+	}
+	return styles.Help().Render(strings.Join(parts, " • "))
+}
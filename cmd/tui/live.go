@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"opentask/cmd/task"
+	"opentask/pkg/activity"
+	"opentask/pkg/live"
+	"opentask/pkg/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// liveRetry is how often the app tries to reach a daemon that is not
+// running, or has gone away.
+const liveRetry = 30 * time.Second
+
+// liveUpdateMsg is a task change passed on by 'opentask serve'.
+type liveUpdateMsg live.Update
+
+// subscribeLive passes on the updates from the daemon's socket at path
+// until ctx is cancelled, reconnecting whenever no daemon is listening.
+func subscribeLive(ctx context.Context, path string) <-chan live.Update {
+	updates := make(chan live.Update)
+	go func() {
+		for {
+			live.Subscribe(ctx, path, func(update live.Update) {
+				select {
+				case updates <- update:
+				case <-ctx.Done():
+				}
+			})
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(liveRetry):
+			}
+		}
+	}()
+	return updates
+}
+
+// waitForUpdate waits for the next update from the daemon.
+func waitForUpdate(updates <-chan live.Update) tea.Cmd {
+	return func() tea.Msg {
+		return liveUpdateMsg(<-updates)
+	}
+}
+
+// handleLiveUpdate applies a change to one task without reloading the
+// others, and notifies about it as a refresh would.
+func (a app) handleLiveUpdate(msg liveUpdateMsg) (tea.Model, tea.Cmd) {
+	platform := models.Platform(msg.Platform)
+	if msg.Task != nil {
+		platform = msg.Task.Platform
+	} else if configured, ok := a.config.GetPlatform(msg.Platform); ok {
+		platform = models.Platform(configured.Type)
+	}
+
+	a.tasks = slices.DeleteFunc(slices.Clone(a.tasks), func(t *models.Task) bool {
+		return t.Platform == platform && t.ID == msg.TaskID
+	})
+	if msg.Task != nil {
+		a.tasks = append(a.tasks, msg.Task)
+
+		// Before the first load there is nothing to compare with.
+		if a.state.Tasks != nil {
+			a.state.AddNotifications(activity.Diff(a.state.Tasks, []*models.Task{msg.Task}, msg.At))
+			maps.Copy(a.state.Tasks, activity.States([]*models.Task{msg.Task}))
+		}
+	} else {
+		delete(a.state.Tasks, activity.Key(&models.Task{Platform: platform, ID: msg.TaskID}))
+	}
+
+	if err := a.store.SaveTUIState(a.state); err != nil {
+		a.err = err
+	}
+
+	var cmd tea.Cmd
+	a.taskList, cmd = a.taskList.Update(task.SetTasksMsg{Tasks: a.tasks})
+	return a, tea.Batch(cmd, waitForUpdate(a.updates))
+}
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"strings"
+
+	"opentask/pkg/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// command is an entry in the command palette.
+type command struct {
+	title string
+	run   func(a app) (app, tea.Cmd)
+}
+
+func commands() []command {
+	cmds := make([]command, 0, len(tabs)+5)
+	for i, t := range tabs {
+		target := tab(i)
+		cmds = append(cmds, command{
+			title: "Go to " + t,
+			run:   func(a app) (app, tea.Cmd) { return a.switchTab(target), nil },
+		})
+	}
+
+	return append(cmds,
+		command{title: "Refresh now", run: func(a app) (app, tea.Cmd) { return a.refresh() }},
+		command{title: "Mark all notifications read", run: func(a app) (app, tea.Cmd) { return a.markAllRead(), nil }},
+		command{title: "Clear notifications", run: func(a app) (app, tea.Cmd) { return a.clearNotifications(), nil }},
+		command{title: "Current sprint", run: func(a app) (app, tea.Cmd) {
+			a.sprintOffset = 0
+			return a.switchTab(tabSprints), nil
+		}},
+		command{title: "Quit", run: func(a app) (app, tea.Cmd) { return a, tea.Quit }},
+	)
+}
+
+// palette filters commands by the text typed into it.
+type palette struct {
+	input  textinput.Model
+	cursor int
+}
+
+func newPalette() *palette {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "type a command"
+	input.CharLimit = 60
+	input.Focus()
+	return &palette{input: input}
+}
+
+func (p *palette) matches() []command {
+	query := strings.ToLower(strings.TrimSpace(p.input.Value()))
+
+	var matched []command
+	for _, c := range commands() {
+		if strings.Contains(strings.ToLower(c.title), query) {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
+
+func (a app) updatePalette(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := a.palette
+	switch msg.String() {
+	case "esc", "ctrl+p":
+		a.palette = nil
+		return a, nil
+	case "ctrl+c":
+		return a, tea.Quit
+	case "up", "ctrl+k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return a, nil
+	case "down", "ctrl+j":
+		if p.cursor < len(p.matches())-1 {
+			p.cursor++
+		}
+		return a, nil
+	case "enter":
+		matches := p.matches()
+		a.palette = nil
+		if p.cursor >= len(matches) {
+			return a, nil
+		}
+		return matches[p.cursor].run(a)
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	p.cursor = 0
+	return a, cmd
+}
+
+func (a app) renderPalette() string {
+	p := a.palette
+
+	var b strings.Builder
+	b.WriteString(styles.Title().Render("Commands") + "\n\n")
+	b.WriteString(p.input.View() + "\n\n")
+
+	matches := p.matches()
+	if len(matches) == 0 {
+		b.WriteString(styles.Help().Render("No matching commands") + "\n")
+	}
+	for i, c := range matches {
+		if i == p.cursor {
+			b.WriteString(styles.SelectedRow().Render("▸ "+c.title) + "\n")
+		} else {
+			b.WriteString("  " + c.title + "\n")
+		}
+	}
+
+	b.WriteString("\n" + styles.Help().Render("↑/↓: select • enter: run • esc: close"))
+	return b.String()
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"opentask/cmd/task"
+	"opentask/pkg/activity"
+	"opentask/pkg/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type (
+	// refreshTickMsg starts a background refresh. Only the tick matching the
+	// app's current id is acted on, so a manual refresh replaces the
+	// scheduled one instead of adding a second chain.
+	refreshTickMsg struct {
+		id int
+	}
+
+	refreshedMsg struct {
+		at       time.Time
+		tasks    []*models.Task
+		projects []*models.Project
+		failed   []string
+	}
+)
+
+// refresh starts loading tasks and projects unless a load is running.
+func (a app) refresh() (app, tea.Cmd) {
+	if a.loading {
+		return a, nil
+	}
+	a.loading = true
+	return a, a.load()
+}
+
+// load fetches tasks and projects from every enabled platform.
+func (a app) load() tea.Cmd {
+	svc := a.service
+	return func() tea.Msg {
+		ctx := context.Background()
+		msg := refreshedMsg{at: time.Now()}
+
+		tasks := svc.Tasks.List(ctx, nil, &models.TaskFilter{Limit: 100})
+		msg.tasks = tasks.Tasks
+		msg.failed = tasks.Failures.Platforms()
+
+		// Projects only label the Projects tab, so a failure here is not
+		// fatal.
+		msg.projects = svc.Projects.List(ctx, nil).Projects
+
+		return msg
+	}
+}
+
+// scheduleRefresh arms the next background refresh, cancelling any
+// previously scheduled one.
+func (a app) scheduleRefresh() (app, tea.Cmd) {
+	a.tickID++
+	id := a.tickID
+	return a, tea.Tick(a.interval, func(time.Time) tea.Msg {
+		return refreshTickMsg{id: id}
+	})
+}
+
+func (a app) handleRefreshed(msg refreshedMsg) (tea.Model, tea.Cmd) {
+	a.loading = false
+	a.failed = msg.failed
+
+	var cmds []tea.Cmd
+	if len(msg.failed) == 0 || len(msg.tasks) > 0 {
+		events := activity.Diff(a.state.Tasks, msg.tasks, msg.at)
+		a.state.AddNotifications(events)
+
+		states := activity.States(msg.tasks)
+		if len(msg.failed) > 0 {
+			// Keep what is known about tasks on platforms that failed to
+			// load, so they are not reported as new next time.
+			for key, state := range a.state.Tasks {
+				if _, ok := states[key]; !ok && failedPlatform(key, msg.failed) {
+					states[key] = state
+				}
+			}
+		}
+
+		a.state.Tasks = states
+		a.state.RefreshedAt = msg.at
+		a.tasks = msg.tasks
+		a.projects = msg.projects
+		a = a.refreshProjects()
+
+		var cmd tea.Cmd
+		a.taskList, cmd = a.taskList.Update(task.SetTasksMsg{Tasks: msg.tasks})
+		cmds = append(cmds, cmd)
+
+		if err := a.store.SaveTUIState(a.state); err != nil {
+			a.err = err
+		}
+	}
+
+	a, cmd := a.scheduleRefresh()
+	cmds = append(cmds, cmd)
+	return a, tea.Batch(cmds...)
+}
+
+func failedPlatform(key string, failed []string) bool {
+	for _, name := range failed {
+		if strings.HasPrefix(key, name+"/") {
+			return true
+		}
+	}
+	return false
+}
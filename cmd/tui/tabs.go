@@ -0,0 +1,268 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"opentask/pkg/humanize"
+	"opentask/pkg/models"
+	"opentask/pkg/report"
+	"opentask/pkg/styles"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	tabHelpProjects      = "↑/↓: move • r: refresh • 1-4/tab: switch tab • q: quit"
+	tabHelpSprints       = "←/→: previous/next sprint • .: current sprint • r: refresh • 1-4/tab: switch tab • q: quit"
+	tabHelpNotifications = "↑/↓: move • enter: toggle read • R: mark all read • x: clear • 1-4/tab: switch tab • q: quit"
+)
+
+func newProjectTable() table.Model {
+	t := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "Platform", Width: 10},
+			{Title: "Key", Width: 12},
+			{Title: "Name", Width: 36},
+			{Title: "Open", Width: 6},
+			{Title: "In Progress", Width: 11},
+			{Title: "Done", Width: 6},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(styles.Current().Border).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(styles.Current().Selected).
+		Background(styles.Current().SelectedBackground).
+		Bold(false)
+	t.SetStyles(s)
+
+	return t
+}
+
+// refreshProjects fills the project table with task counts from the
+// loaded tasks.
+func (a app) refreshProjects() app {
+	counts := make(map[string]map[models.TaskStatus]int)
+	for _, task := range a.tasks {
+		key := string(task.Platform) + "/" + task.ProjectID
+		if counts[key] == nil {
+			counts[key] = make(map[models.TaskStatus]int)
+		}
+		counts[key][task.Status]++
+	}
+
+	projects := append([]*models.Project(nil), a.projects...)
+	sort.SliceStable(projects, func(i, j int) bool {
+		if projects[i].Platform != projects[j].Platform {
+			return projects[i].Platform < projects[j].Platform
+		}
+		return projects[i].DisplayName() < projects[j].DisplayName()
+	})
+
+	rows := make([]table.Row, 0, len(projects))
+	for _, p := range projects {
+		c := counts[string(p.Platform)+"/"+p.ID]
+		if c == nil {
+			c = counts[string(p.Platform)+"/"+p.Key]
+		}
+		rows = append(rows, table.Row{
+			string(p.Platform),
+			p.Key,
+			p.Name,
+			fmt.Sprint(c[models.StatusOpen]),
+			fmt.Sprint(c[models.StatusInProgress]),
+			fmt.Sprint(c[models.StatusDone]),
+		})
+	}
+
+	a.projectTable.SetRows(rows)
+	if len(rows) > 0 && a.projectTable.Cursor() >= len(rows) {
+		a.projectTable.SetCursor(len(rows) - 1)
+	}
+	return a
+}
+
+func (a app) renderProjects() string {
+	if len(a.projects) == 0 {
+		return "No projects loaded.\n\n" + styles.Help().Render(tabHelpProjects)
+	}
+	return a.projectTable.View() + "\n" + styles.Help().Render(tabHelpProjects)
+}
+
+func (a app) updateSprints(msg tea.KeyMsg) app {
+	switch msg.String() {
+	case "left", "h":
+		a.sprintOffset--
+	case "right", "l":
+		a.sprintOffset++
+	case ".":
+		a.sprintOffset = 0
+	}
+	return a
+}
+
+// sprintWindow returns the sprint sprintOffset sprints away from the
+// current one.
+func (a app) sprintWindow(now time.Time) (report.Window, error) {
+	window, err := report.ResolveSprint("current", a.config.Reports, now)
+	if err != nil {
+		return report.Window{}, err
+	}
+	if a.sprintOffset == 0 {
+		return window, nil
+	}
+
+	length := len(window.Days())
+	window.Start = window.Start.AddDate(0, 0, a.sprintOffset*length)
+	window.End = window.End.AddDate(0, 0, a.sprintOffset*length)
+	window.Name = fmt.Sprintf("%+d", a.sprintOffset)
+	return window, nil
+}
+
+func (a app) renderSprints() string {
+	now := time.Now()
+	window, err := a.sprintWindow(now)
+	if err != nil {
+		return fmt.Sprintf("✗ %v\n\n", err) + styles.Help().Render(tabHelpSprints)
+	}
+
+	var b strings.Builder
+
+	snapshots, err := a.store.LoadSnapshots(window.Start, window.End)
+	if err != nil {
+		b.WriteString(fmt.Sprintf("✗ %v\n", err))
+	}
+
+	filter := &models.TaskFilter{DefaultProjects: a.config.DefaultProjectsByType()}
+
+	points := report.Burndown(snapshots, window, filter, now)
+	title := fmt.Sprintf("Sprint %s (%s)", window.Name, window)
+	b.WriteString(report.RenderBurndown(title, points, max(a.width, 60)))
+
+	due := a.dueIn(window)
+	b.WriteString("\n" + styles.Title().Render(fmt.Sprintf("Due this sprint (%d)", len(due))) + "\n")
+	for _, task := range due {
+		status := lipgloss.NewStyle().Foreground(styles.Current().StatusColor(task.Status)).Render(string(task.Status))
+		dueText := fmt.Sprintf("%-12s", humanize.Due(*task.DueDate))
+		if humanize.Overdue(task) {
+			dueText = lipgloss.NewStyle().Foreground(styles.Current().Error).Render(dueText)
+		}
+		b.WriteString(fmt.Sprintf("  %s  %-12s %s  %s\n", dueText, task.ID, task.Title, status))
+	}
+
+	b.WriteString("\n" + styles.Help().Render(tabHelpSprints))
+	return b.String()
+}
+
+// dueIn returns loaded tasks due within window, soonest first.
+func (a app) dueIn(window report.Window) []*models.Task {
+	end := window.End.AddDate(0, 0, 1)
+
+	var due []*models.Task
+	for _, task := range a.tasks {
+		if task.DueDate == nil {
+			continue
+		}
+		if !task.DueDate.Before(window.Start) && task.DueDate.Before(end) {
+			due = append(due, task)
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].DueDate.Before(*due[j].DueDate)
+	})
+	return due
+}
+
+func (a app) updateNotifications(msg tea.KeyMsg) app {
+	notifications := a.state.Notifications
+	switch msg.String() {
+	case "up", "k":
+		if a.notifyCursor > 0 {
+			a.notifyCursor--
+		}
+	case "down", "j":
+		if a.notifyCursor < len(notifications)-1 {
+			a.notifyCursor++
+		}
+	case "enter", " ":
+		if a.notifyCursor < len(notifications) {
+			notifications[a.notifyCursor].Read = !notifications[a.notifyCursor].Read
+			a = a.saveState()
+		}
+	case "R":
+		return a.markAllRead()
+	case "x":
+		return a.clearNotifications()
+	}
+	return a
+}
+
+func (a app) markAllRead() app {
+	for i := range a.state.Notifications {
+		a.state.Notifications[i].Read = true
+	}
+	return a.saveState()
+}
+
+func (a app) clearNotifications() app {
+	a.state.Notifications = nil
+	a.notifyCursor = 0
+	return a.saveState()
+}
+
+func (a app) saveState() app {
+	if err := a.store.SaveTUIState(a.state); err != nil {
+		a.err = err
+	}
+	return a
+}
+
+func (a app) renderNotifications() string {
+	notifications := a.state.Notifications
+	if len(notifications) == 0 {
+		return "No notifications. Changes to tasks appear here after each refresh.\n\n" +
+			styles.Help().Render(tabHelpNotifications)
+	}
+
+	// Keep the cursor on screen by scrolling the list.
+	rows := max(3, a.height-headerHeight-4)
+	start := 0
+	if a.notifyCursor >= rows {
+		start = a.notifyCursor - rows + 1
+	}
+	end := min(len(notifications), start+rows)
+
+	var b strings.Builder
+	for i := start; i < end; i++ {
+		n := notifications[i]
+		marker := "●"
+		if n.Read {
+			marker = " "
+		}
+
+		line := fmt.Sprintf("%s %s  %-12s %s — %s", marker, n.At.Local().Format("01/02 15:04"), n.TaskID, n.Title, n.Message)
+		switch {
+		case i == a.notifyCursor:
+			line = styles.SelectedRow().Render(line)
+		case n.Read:
+			line = styles.Help().Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n" + styles.Help().Render(tabHelpNotifications))
+	return b.String()
+}
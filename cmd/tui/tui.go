@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/live"
+	"opentask/pkg/store"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+// DefaultRefreshInterval is used when ui.refresh_interval is not set.
+const DefaultRefreshInterval = 5 * time.Minute
+
+var TUICmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Open the full-screen task app",
+	Long: `Open OpenTask as a full-screen terminal app.
+
+The app has tabs for tasks, projects, sprints and notifications. Tasks and
+projects are reloaded in the background every ui.refresh_interval (default
+5m), and changes since the last load are listed as notifications. The open
+tab and the notification history are kept between runs. While 'opentask
+serve' receives webhook deliveries, the tasks they change are updated right
+away.
+
+Keys:
+  alt+1..alt+4, ctrl+t   switch tabs (1-4 and tab also work outside Tasks)
+  ctrl+p                 command palette
+  ctrl+c                 quit`,
+	RunE: runTUI,
+}
+
+func runTUI(cmd *cobra.Command, args []string) error {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := manager.GetConfig()
+
+	interval, err := refreshInterval(cfg.UI)
+	if err != nil {
+		return err
+	}
+
+	s, err := store.Open()
+	if err != nil {
+		return err
+	}
+
+	state, err := s.LoadTUIState()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := subscribeLive(ctx, live.SocketPath(s.Dir()))
+
+	p := tea.NewProgram(newApp(cfg, config.FlagPath(cmd.Flags()), s, state, interval, updates), tea.WithAltScreen())
+	_, err = p.Run()
+	return err
+}
+
+func refreshInterval(ui config.UI) (time.Duration, error) {
+	if ui.RefreshInterval == "" {
+		return DefaultRefreshInterval, nil
+	}
+
+	interval, err := time.ParseDuration(ui.RefreshInterval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ui.refresh_interval %q: %w", ui.RefreshInterval, err)
+	}
+	if interval < 10*time.Second {
+		return 0, fmt.Errorf("ui.refresh_interval %q is too short; use at least 10s", ui.RefreshInterval)
+	}
+	return interval, nil
+}
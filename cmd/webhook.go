@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"opentask/pkg/config"
+	"opentask/pkg/exitcode"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Manage platform webhooks",
+	Long: `Create and remove the webhook subscriptions used for webhook-based sync.
+
+OpenTask registers the webhook through the platform API and records its ID
+in the platform settings, so 'unregister' can remove it later. Jira requires
+an administrator account; Linear requires an API key with the admin scope.
+
+Point the webhook at /webhooks/<platform> on 'opentask serve' to update
+its tasks, and any running 'opentask tui', as soon as they change. The
+--secret is kept in the platform settings so the daemon can check the
+signature of every delivery.`,
+}
+
+var webhookRegisterCmd = &cobra.Command{
+	Use:   "register",
+	Short: "Create a webhook subscription on a platform",
+	Long: `Create a webhook subscription for task events on a platform.
+
+Examples:
+  opentask webhook register --platform linear --url https://opentask.example.com/webhooks/linear
+  opentask webhook register --platform jira --url https://opentask.example.com/webhooks/jira --project TEST`,
+	RunE: runWebhookRegister,
+}
+
+var webhookUnregisterCmd = &cobra.Command{
+	Use:   "unregister",
+	Short: "Remove the webhook subscription from a platform",
+	Long: `Remove the webhook created by 'opentask webhook register'.
+
+Examples:
+  opentask webhook unregister --platform linear
+  opentask webhook unregister --platform jira --id 42`,
+	RunE: runWebhookUnregister,
+}
+
+var (
+	webhookPlatform string
+	webhookURL      string
+	webhookSecret   string
+	webhookProject  string
+	webhookID       string
+	webhookForce    bool
+)
+
+func init() {
+	rootCmd.AddCommand(webhookCmd)
+	webhookCmd.AddCommand(webhookRegisterCmd)
+	webhookCmd.AddCommand(webhookUnregisterCmd)
+
+	webhookCmd.PersistentFlags().StringVarP(&webhookPlatform, "platform", "p", "", "platform to manage the webhook on")
+	webhookCmd.MarkPersistentFlagRequired("platform")
+
+	webhookRegisterCmd.Flags().StringVar(&webhookURL, "url", "", "HTTPS URL that receives the events")
+	webhookRegisterCmd.Flags().StringVar(&webhookSecret, "secret", "", "secret used to sign webhook payloads")
+	webhookRegisterCmd.Flags().StringVar(&webhookProject, "project", "", "only send events for this project (Jira project key, Linear team ID)")
+	webhookRegisterCmd.Flags().BoolVar(&webhookForce, "force", false, "register even if a webhook is already recorded")
+	webhookRegisterCmd.MarkFlagRequired("url")
+
+	webhookUnregisterCmd.Flags().StringVar(&webhookID, "id", "", "webhook ID to remove (defaults to the recorded webhook)")
+}
+
+func runWebhookRegister(cmd *cobra.Command, args []string) error {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return exitcode.Wrap(exitcode.Usage, fmt.Errorf("invalid --url %q, expected an absolute http(s) URL", webhookURL))
+	}
+
+	manager, platform, registrar, err := webhookRegistrar(cmd.Flags(), webhookPlatform)
+	if err != nil {
+		return err
+	}
+
+	if existing, ok := platforms.RegisteredWebhook(platform.Settings); ok && !webhookForce {
+		return fmt.Errorf("webhook %s is already registered on %s for %s. Run 'opentask webhook unregister --platform %s' first or use --force",
+			existing.ID, webhookPlatform, existing.URL, webhookPlatform)
+	}
+
+	ctx, cancel := service.WithRequestTimeout(context.Background())
+	defer cancel()
+
+	webhook, err := registrar.RegisterWebhook(ctx, platforms.WebhookOptions{
+		URL:     webhookURL,
+		Secret:  webhookSecret,
+		Project: manager.GetConfig().ResolveProject(webhookProject),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register webhook: %w", err)
+	}
+
+	if platform.Settings == nil {
+		platform.Settings = make(map[string]any)
+	}
+	platform.Settings[platforms.WebhookKey] = platforms.WebhookSettings(webhook)
+	if webhookSecret != "" {
+		platform.Settings[platforms.WebhookSecretKey] = webhookSecret
+	} else {
+		delete(platform.Settings, platforms.WebhookSecretKey)
+	}
+	manager.GetConfig().AddPlatform(webhookPlatform, platform)
+
+	if err := manager.Save(); err != nil {
+		return fmt.Errorf("webhook %s was created but the configuration could not be saved: %w", webhook.ID, err)
+	}
+
+	fmt.Printf("✓ Registered webhook %s on %s\n", webhook.ID, webhookPlatform)
+	fmt.Printf("  URL: %s\n", webhook.URL)
+	return nil
+}
+
+func runWebhookUnregister(cmd *cobra.Command, args []string) error {
+	manager, platform, registrar, err := webhookRegistrar(cmd.Flags(), webhookPlatform)
+	if err != nil {
+		return err
+	}
+
+	id := webhookID
+	if id == "" {
+		existing, ok := platforms.RegisteredWebhook(platform.Settings)
+		if !ok {
+			return fmt.Errorf("no webhook recorded for %s. Use --id to remove one by ID", webhookPlatform)
+		}
+		id = existing.ID
+	}
+
+	ctx, cancel := service.WithRequestTimeout(context.Background())
+	defer cancel()
+
+	if err := registrar.UnregisterWebhook(ctx, id); err != nil {
+		if !platforms.IsNotFoundError(err) {
+			return fmt.Errorf("failed to unregister webhook: %w", err)
+		}
+		fmt.Printf("⚠ Webhook %s no longer exists on %s\n", id, webhookPlatform)
+	}
+
+	if existing, ok := platforms.RegisteredWebhook(platform.Settings); ok && existing.ID == id {
+		delete(platform.Settings, platforms.WebhookKey)
+		delete(platform.Settings, platforms.WebhookSecretKey)
+		manager.GetConfig().AddPlatform(webhookPlatform, platform)
+
+		if err := manager.Save(); err != nil {
+			return fmt.Errorf("failed to save configuration: %w", err)
+		}
+	}
+
+	fmt.Printf("✓ Unregistered webhook %s from %s\n", id, webhookPlatform)
+	return nil
+}
+
+// webhookRegistrar loads the configuration and returns the named platform
+// together with its client, if the platform supports webhook management.
+func webhookRegistrar(flags *pflag.FlagSet, name string) (*config.Manager, config.Platform, platforms.WebhookRegistrar, error) {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(flags); err != nil {
+		return nil, config.Platform{}, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	platform, exists := manager.GetConfig().GetPlatform(name)
+	if !exists {
+		return nil, config.Platform{}, nil, fmt.Errorf("platform %s not configured. Use 'opentask connect %s' first", name, name)
+	}
+
+	client, err := service.NewClient(name, platform)
+	if err != nil {
+		return nil, config.Platform{}, nil, err
+	}
+
+	registrar, ok := client.(platforms.WebhookRegistrar)
+	if !ok {
+		return nil, config.Platform{}, nil, fmt.Errorf("%s does not support webhook registration", name)
+	}
+
+	return manager, platform, registrar, nil
+}
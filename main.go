@@ -6,6 +6,7 @@ import (
 	_ "opentask/pkg/platforms/jira"
 	// Import platform implementations to register them
 	_ "opentask/pkg/platforms/linear"
+	_ "opentask/pkg/platforms/mock"
 )
 
 func main() {
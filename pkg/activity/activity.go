@@ -0,0 +1,119 @@
+// Package activity detects what changed between two loads of the task list,
+// so the terminal UI can show notifications for new, moved and reassigned
+// tasks.
+package activity
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"opentask/pkg/models"
+)
+
+// Kind is the type of change an Event describes.
+type Kind string
+
+const (
+	KindCreated  Kind = "created"
+	KindStatus   Kind = "status"
+	KindAssignee Kind = "assignee"
+	KindUpdated  Kind = "updated"
+)
+
+// TaskState is what is remembered about a task between loads.
+type TaskState struct {
+	Title     string            `json:"title"`
+	Status    models.TaskStatus `json:"status"`
+	Assignee  string            `json:"assignee,omitempty"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// Event is a change to one task.
+type Event struct {
+	At       time.Time       `json:"at"`
+	Kind     Kind            `json:"kind"`
+	TaskID   string          `json:"task_id"`
+	Platform models.Platform `json:"platform"`
+	Title    string          `json:"title"`
+	Message  string          `json:"message"`
+	Read     bool            `json:"read,omitempty"`
+}
+
+// Key identifies a task across platforms.
+func Key(task *models.Task) string {
+	return string(task.Platform) + "/" + task.ID
+}
+
+// States records the current state of each task, keyed by Key.
+func States(tasks []*models.Task) map[string]TaskState {
+	states := make(map[string]TaskState, len(tasks))
+	for _, task := range tasks {
+		states[Key(task)] = stateOf(task)
+	}
+	return states
+}
+
+func stateOf(task *models.Task) TaskState {
+	state := TaskState{
+		Title:     task.Title,
+		Status:    task.Status,
+		UpdatedAt: task.UpdatedAt,
+	}
+	if task.Assignee != nil {
+		state.Assignee = task.Assignee.Name
+	}
+	return state
+}
+
+// Diff returns an event for each task that is new or changed compared to
+// previous, ordered by task key. A nil previous means nothing was known
+// yet, so no events are reported. Tasks missing from tasks are ignored, as
+// the list is usually filtered or truncated.
+func Diff(previous map[string]TaskState, tasks []*models.Task, now time.Time) []Event {
+	if previous == nil {
+		return nil
+	}
+
+	var events []Event
+	for _, task := range tasks {
+		current := stateOf(task)
+		event := Event{At: now, TaskID: task.ID, Platform: task.Platform, Title: task.Title}
+
+		before, known := previous[Key(task)]
+		switch {
+		case !known:
+			event.Kind = KindCreated
+			event.Message = fmt.Sprintf("new task (%s)", current.Status)
+		case before.Status != current.Status:
+			event.Kind = KindStatus
+			event.Message = fmt.Sprintf("%s → %s", before.Status, current.Status)
+		case before.Assignee != current.Assignee:
+			event.Kind = KindAssignee
+			event.Message = fmt.Sprintf("assigned to %s", assigneeName(current.Assignee))
+		case !current.UpdatedAt.IsZero() && current.UpdatedAt.After(before.UpdatedAt):
+			event.Kind = KindUpdated
+			event.Message = "updated"
+		default:
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Platform != events[j].Platform {
+			return events[i].Platform < events[j].Platform
+		}
+		return events[i].TaskID < events[j].TaskID
+	})
+
+	return events
+}
+
+func assigneeName(name string) string {
+	if name == "" {
+		return "nobody"
+	}
+	return name
+}
@@ -0,0 +1,65 @@
+package activity
+
+import (
+	"testing"
+	"time"
+
+	"opentask/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	yesterday := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	now := yesterday.Add(24 * time.Hour)
+
+	task := func(id string, status models.TaskStatus, assignee string, updated time.Time) *models.Task {
+		task := &models.Task{ID: id, Title: "Task " + id, Platform: models.PlatformJira, Status: status, UpdatedAt: updated}
+		if assignee != "" {
+			task.Assignee = &models.User{Name: assignee}
+		}
+		return task
+	}
+
+	previous := States([]*models.Task{
+		task("API-1", models.StatusOpen, "", yesterday),
+		task("API-2", models.StatusOpen, "alice", yesterday),
+		task("API-3", models.StatusInProgress, "alice", yesterday),
+		task("API-4", models.StatusOpen, "", yesterday),
+		task("API-9", models.StatusOpen, "", yesterday),
+	})
+
+	tasks := []*models.Task{
+		task("API-5", models.StatusOpen, "", now),
+		task("API-1", models.StatusDone, "", now),
+		task("API-2", models.StatusOpen, "bob", now),
+		task("API-3", models.StatusInProgress, "", yesterday),
+		task("API-4", models.StatusOpen, "", now),
+	}
+
+	events := Diff(previous, tasks, now)
+	require.Len(t, events, 5)
+
+	expected := []struct {
+		id      string
+		kind    Kind
+		message string
+	}{
+		{"API-1", KindStatus, "open → done"},
+		{"API-2", KindAssignee, "assigned to bob"},
+		{"API-3", KindAssignee, "assigned to nobody"},
+		{"API-4", KindUpdated, "updated"},
+		{"API-5", KindCreated, "new task (open)"},
+	}
+	for i, want := range expected {
+		assert.Equal(t, want.id, events[i].TaskID)
+		assert.Equal(t, want.kind, events[i].Kind)
+		assert.Equal(t, want.message, events[i].Message)
+		assert.Equal(t, now, events[i].At)
+		assert.False(t, events[i].Read)
+	}
+
+	assert.Empty(t, Diff(nil, tasks, now), "first load has nothing to compare with")
+	assert.Empty(t, Diff(States(tasks), tasks, now))
+}
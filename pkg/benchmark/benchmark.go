@@ -0,0 +1,87 @@
+// Package benchmark measures the latency of repeated platform calls.
+package benchmark
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// Stats summarizes the latency of one operation. Failed calls count
+// towards Errors and are left out of the latencies.
+type Stats struct {
+	Count  int
+	Errors int
+	Min    time.Duration
+	P50    time.Duration
+	P90    time.Duration
+	P99    time.Duration
+	Max    time.Duration
+	// Err is the last error returned, if any.
+	Err error
+}
+
+// Run calls fn iterations times and summarizes how long the calls took.
+func Run(ctx context.Context, iterations int, fn func(ctx context.Context) error) Stats {
+	return run(ctx, iterations, fn, time.Now)
+}
+
+func run(ctx context.Context, iterations int, fn func(ctx context.Context) error, now func() time.Time) Stats {
+	var samples []time.Duration
+	var errs int
+	var lastErr error
+	for i := 0; i < iterations; i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		start := now()
+		err := fn(ctx)
+		elapsed := now().Sub(start)
+		if err != nil {
+			errs++
+			lastErr = err
+			continue
+		}
+		samples = append(samples, elapsed)
+	}
+
+	stats := Summarize(samples)
+	stats.Errors = errs
+	stats.Err = lastErr
+	return stats
+}
+
+// Summarize computes the percentiles of samples.
+func Summarize(samples []time.Duration) Stats {
+	stats := Stats{Count: len(samples)}
+	if len(samples) == 0 {
+		return stats
+	}
+
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	stats.Min = sorted[0]
+	stats.Max = sorted[len(sorted)-1]
+	stats.P50 = Percentile(sorted, 50)
+	stats.P90 = Percentile(sorted, 90)
+	stats.P99 = Percentile(sorted, 99)
+	return stats
+}
+
+// Percentile returns the p-th percentile of sorted samples using the
+// nearest-rank method.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,71 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercentile(t *testing.T) {
+	samples := make([]time.Duration, 0, 10)
+	for i := 1; i <= 10; i++ {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+
+	tests := []struct {
+		name    string
+		samples []time.Duration
+		p       float64
+		want    time.Duration
+	}{
+		{name: "empty", p: 50, want: 0},
+		{name: "single", samples: samples[:1], p: 99, want: time.Millisecond},
+		{name: "median", samples: samples, p: 50, want: 5 * time.Millisecond},
+		{name: "p90", samples: samples, p: 90, want: 9 * time.Millisecond},
+		{name: "p99", samples: samples, p: 99, want: 10 * time.Millisecond},
+		{name: "zero", samples: samples, p: 0, want: time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Percentile(tt.samples, tt.p))
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	stats := Summarize([]time.Duration{30 * time.Millisecond, 10 * time.Millisecond, 20 * time.Millisecond})
+
+	assert.Equal(t, 3, stats.Count)
+	assert.Equal(t, 10*time.Millisecond, stats.Min)
+	assert.Equal(t, 20*time.Millisecond, stats.P50)
+	assert.Equal(t, 30*time.Millisecond, stats.P99)
+	assert.Equal(t, 30*time.Millisecond, stats.Max)
+}
+
+func TestRun(t *testing.T) {
+	clock := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	now := func() time.Time { return clock }
+
+	call := 0
+	failure := errors.New("rate limited")
+	stats := run(context.Background(), 4, func(ctx context.Context) error {
+		call++
+		clock = clock.Add(time.Duration(call) * 100 * time.Millisecond)
+		if call == 2 {
+			return failure
+		}
+		return nil
+	}, now)
+
+	assert.Equal(t, 4, call)
+	assert.Equal(t, 3, stats.Count)
+	assert.Equal(t, 1, stats.Errors)
+	assert.Equal(t, failure, stats.Err)
+	assert.Equal(t, 100*time.Millisecond, stats.Min)
+	assert.Equal(t, 300*time.Millisecond, stats.P50)
+	assert.Equal(t, 400*time.Millisecond, stats.Max)
+}
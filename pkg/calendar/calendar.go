@@ -0,0 +1,161 @@
+// Package calendar writes task due dates as an iCalendar (RFC 5545) feed,
+// so deadlines show up in Google Calendar, Apple Calendar and other
+// calendar apps that subscribe to or import .ics files.
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"opentask/pkg/models"
+	"opentask/pkg/service"
+)
+
+// ContentType is the media type of a feed.
+const ContentType = "text/calendar; charset=utf-8"
+
+// dateLayout is the iCalendar DATE form.
+const dateLayout = "20060102"
+
+// maxLine is the longest line, in octets, before it is folded.
+const maxLine = 75
+
+// textEscaper escapes iCalendar TEXT values.
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// Options selects the tasks of a feed.
+type Options struct {
+	// Platforms are the configured platform names to list, or every
+	// enabled platform when empty.
+	Platforms []string
+	// Limit bounds the number of tasks listed per platform.
+	Limit int
+	// IncludeClosed keeps done and cancelled tasks in the feed.
+	IncludeClosed bool
+}
+
+// Tasks lists the tasks with a due date assigned to the user each platform
+// is connected as, soonest due first. Platforms that fail are returned and
+// left out.
+func Tasks(ctx context.Context, svc *service.Service, opts Options) ([]*models.Task, service.Failures) {
+	list := svc.Tasks.List(ctx, opts.Platforms, &models.TaskFilter{Assignee: models.AssigneeMe, Limit: opts.Limit})
+
+	var tasks []*models.Task
+	for _, task := range list.Tasks {
+		if task.DueDate == nil || (task.Status.IsClosed() && !opts.IncludeClosed) {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].DueDate.Before(*tasks[j].DueDate)
+	})
+	return tasks, list.Failures
+}
+
+// Write writes a calendar named name with an all-day event on the due date
+// of each task. Tasks without a due date are skipped. Events are
+// identified by task reference, so calendar apps update them in place
+// when a due date moves.
+func Write(w io.Writer, name string, tasks []*models.Task, now time.Time) error {
+	cw := &writer{w: w}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:-//OpenTask//OpenTask//EN")
+	cw.line("CALSCALE:GREGORIAN")
+	cw.line("METHOD:PUBLISH")
+	cw.line("X-WR-CALNAME:" + textEscaper.Replace(name))
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, task := range tasks {
+		if task.DueDate == nil {
+			continue
+		}
+		// Due dates are calendar days, so they are used as they are
+		// rather than moved to the local time zone.
+		due := *task.DueDate
+
+		cw.line("BEGIN:VEVENT")
+		cw.line("UID:" + textEscaper.Replace(task.Ref()) + "@opentask")
+		cw.line("DTSTAMP:" + stamp)
+		if !task.UpdatedAt.IsZero() {
+			cw.line("LAST-MODIFIED:" + task.UpdatedAt.UTC().Format("20060102T150405Z"))
+		}
+		cw.line("DTSTART;VALUE=DATE:" + due.Format(dateLayout))
+		cw.line("DTEND;VALUE=DATE:" + due.AddDate(0, 0, 1).Format(dateLayout))
+		cw.line("SUMMARY:" + textEscaper.Replace(task.ID+": "+task.Title))
+		cw.line("DESCRIPTION:" + textEscaper.Replace(description(task)))
+		if url := task.URL(); url != "" {
+			cw.line("URL:" + url)
+		}
+		if task.Status.IsClosed() {
+			cw.line("STATUS:CANCELLED")
+		}
+		cw.line("TRANSP:TRANSPARENT")
+		cw.line("END:VEVENT")
+	}
+
+	cw.line("END:VCALENDAR")
+	return cw.err
+}
+
+// description summarizes the task for the event's notes.
+func description(task *models.Task) string {
+	lines := []string{
+		fmt.Sprintf("Platform: %s", task.Platform),
+		fmt.Sprintf("Status: %s", task.Status),
+	}
+	if task.Priority != "" {
+		lines = append(lines, fmt.Sprintf("Priority: %s", task.Priority))
+	}
+	if task.ProjectID != "" {
+		lines = append(lines, fmt.Sprintf("Project: %s", task.ProjectID))
+	}
+	if url := task.URL(); url != "" {
+		lines = append(lines, url)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writer writes content lines ended by CRLF and folded at maxLine octets,
+// keeping the first error.
+type writer struct {
+	w   io.Writer
+	err error
+}
+
+func (cw *writer) line(s string) {
+	if cw.err != nil {
+		return
+	}
+	_, cw.err = io.WriteString(cw.w, fold(s)+"\r\n")
+}
+
+// fold splits s into lines of at most maxLine octets, continued lines
+// starting with a space, without splitting a UTF-8 sequence.
+func fold(s string) string {
+	if len(s) <= maxLine {
+		return s
+	}
+
+	var b strings.Builder
+	limit := maxLine
+	for len(s) > limit {
+		cut := limit
+		// Back up to the start of a rune.
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// The leading space counts toward the continued line.
+		limit = maxLine - 1
+	}
+	b.WriteString(s)
+	return b.String()
+}
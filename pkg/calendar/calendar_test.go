@@ -0,0 +1,82 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"opentask/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	due := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	tasks := []*models.Task{
+		{
+			ID:        "API-7",
+			Title:     "Ship v2; docs, too",
+			Platform:  models.PlatformJira,
+			Status:    models.StatusInProgress,
+			Priority:  models.PriorityHigh,
+			ProjectID: "API",
+			DueDate:   &due,
+			UpdatedAt: time.Date(2026, 2, 27, 15, 4, 5, 0, time.UTC),
+			Metadata:  map[string]any{"jira_self": "https://example.atlassian.net/rest/api/2/issue/10007"},
+		},
+		{ID: "API-8", Title: "No deadline", Platform: models.PlatformJira, Status: models.StatusOpen},
+	}
+
+	var b strings.Builder
+	require.NoError(t, Write(&b, "My tasks", tasks, now))
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//OpenTask//OpenTask//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:My tasks",
+		"BEGIN:VEVENT",
+		"UID:jira:API-7@opentask",
+		"DTSTAMP:20260301T093000Z",
+		"LAST-MODIFIED:20260227T150405Z",
+		"DTSTART;VALUE=DATE:20260331",
+		"DTEND;VALUE=DATE:20260401",
+		`SUMMARY:API-7: Ship v2\; docs\, too`,
+		`DESCRIPTION:Platform: jira\nStatus: in_progress\nPriority: high\nProject: A`,
+		` PI\nhttps://example.atlassian.net/browse/API-7`,
+		"URL:https://example.atlassian.net/browse/API-7",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	assert.Equal(t, want, b.String())
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{name: "short", in: "SUMMARY:short"},
+		{name: "ascii", in: "SUMMARY:" + strings.Repeat("a", 200)},
+		{name: "multibyte", in: "SUMMARY:" + strings.Repeat("日本", 60)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := fold(tt.in)
+			lines := strings.Split(folded, "\r\n")
+			for i, line := range lines {
+				assert.LessOrEqual(t, len(line), maxLine)
+				if i > 0 {
+					assert.True(t, strings.HasPrefix(line, " "))
+				}
+			}
+			assert.Equal(t, tt.in, strings.ReplaceAll(folded, "\r\n ", ""))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -10,6 +12,24 @@ type Config struct {
 	Platforms  map[string]Platform    `yaml:"platforms" json:"platforms"`
 	Defaults   Defaults               `yaml:"defaults" json:"defaults"`
 	RemoteSync *RemoteSync            `yaml:"remote_sync,omitempty" json:"remote_sync,omitempty"`
+	Reports    Reports                `yaml:"reports,omitempty" json:"reports,omitempty"`
+	Git        Git                    `yaml:"git,omitempty" json:"git,omitempty"`
+	UI         UI                     `yaml:"ui,omitempty" json:"ui,omitempty"`
+	Telemetry  Telemetry              `yaml:"telemetry,omitempty" json:"telemetry,omitempty"`
+	Log        Log                    `yaml:"log,omitempty" json:"log,omitempty"`
+	Rules      Rules                  `yaml:"rules,omitempty" json:"rules,omitempty"`
+
+	// Notifications configures where task events are posted.
+	Notifications Notifications `yaml:"notifications,omitempty" json:"notifications,omitempty"`
+
+	// ProjectAliases maps short names to project keys or IDs, such as
+	// backend: TEST, so aliases can be passed wherever a project is.
+	ProjectAliases map[string]string `yaml:"project_aliases,omitempty" json:"project_aliases,omitempty" mapstructure:"project_aliases"`
+
+	// Aliases maps names to the commands they stand for, such as
+	// mine: task list --assignee me --status open, so "opentask mine"
+	// runs that command.
+	Aliases map[string]string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
 }
 
 type Platform struct {
@@ -23,7 +43,19 @@ type Defaults struct {
 	Platform string `yaml:"platform" json:"platform"`
 	Assignee string `yaml:"assignee,omitempty" json:"assignee,omitempty"`
 	Priority string `yaml:"priority,omitempty" json:"priority,omitempty"`
-	Project  string `yaml:"project,omitempty" json:"project,omitempty"`
+	// Projects holds the default project of each platform by platform
+	// name, such as jira: TEST, since project IDs differ between
+	// platforms.
+	Projects map[string]string `yaml:"projects,omitempty" json:"projects,omitempty"`
+	// Project is the single default project of configurations written
+	// before Projects. Load moves it to the default platform; when no
+	// platform can be told, it applies to every platform.
+	Project string `yaml:"project,omitempty" json:"project,omitempty"`
+	// Labels are given to created tasks that are not given any.
+	Labels []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	// Timeout bounds each platform request, such as "30s" (the default).
+	// The --timeout flag overrides it.
+	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty"`
 }
 
 type RemoteSync struct {
@@ -34,6 +66,190 @@ type RemoteSync struct {
 	Interval string `yaml:"interval,omitempty" json:"interval,omitempty"`
 }
 
+// Reports configures sprint windows used by burndown and flow reports.
+type Reports struct {
+	SprintStart  string `yaml:"sprint_start,omitempty" json:"sprint_start,omitempty" mapstructure:"sprint_start"`
+	SprintLength int    `yaml:"sprint_length,omitempty" json:"sprint_length,omitempty" mapstructure:"sprint_length"`
+	Share        Share  `yaml:"share,omitempty" json:"share,omitempty" mapstructure:"share"`
+}
+
+// Share configures where 'opentask report share' uploads reports to S3.
+// Credentials come from the standard AWS environment variables.
+type Share struct {
+	Bucket   string `yaml:"bucket,omitempty" json:"bucket,omitempty" mapstructure:"bucket"`
+	Region   string `yaml:"region,omitempty" json:"region,omitempty" mapstructure:"region"`
+	Prefix   string `yaml:"prefix,omitempty" json:"prefix,omitempty" mapstructure:"prefix"`
+	Endpoint string `yaml:"endpoint,omitempty" json:"endpoint,omitempty" mapstructure:"endpoint"`
+	Expiry   string `yaml:"expiry,omitempty" json:"expiry,omitempty" mapstructure:"expiry"`
+}
+
+// Git configures task branch naming. BranchTemplate is a Go template with
+// .ID, .Slug, .Title, .Platform and .Type available.
+type Git struct {
+	BranchTemplate string `yaml:"branch_template,omitempty" json:"branch_template,omitempty" mapstructure:"branch_template"`
+}
+
+// UI configures the interactive task table and the terminal colors.
+// Interactive is "auto" (the default), "always" or "never"; in auto mode
+// list commands print plain output when stdout is not a terminal.
+// RefreshInterval is how often "opentask tui" reloads tasks, such as "5m".
+// ConfirmCreate is "auto" (the default: confirm when stdin is a terminal),
+// "always" or "never", and decides whether "task create" asks before
+// creating. BulkConfirmThreshold is how many tasks a bulk delete or close
+// may affect before a typed confirmation phrase (or --force) is required;
+// 0 means the default of 10.
+type UI struct {
+	Columns         []Column `yaml:"columns,omitempty" json:"columns,omitempty" mapstructure:"columns"`
+	Theme           Theme    `yaml:"theme,omitempty" json:"theme,omitempty" mapstructure:"theme"`
+	Interactive     string   `yaml:"interactive,omitempty" json:"interactive,omitempty" mapstructure:"interactive"`
+	RefreshInterval string   `yaml:"refresh_interval,omitempty" json:"refresh_interval,omitempty" mapstructure:"refresh_interval"`
+	ConfirmCreate   string   `yaml:"confirm_create,omitempty" json:"confirm_create,omitempty" mapstructure:"confirm_create"`
+
+	BulkConfirmThreshold int `yaml:"bulk_confirm_threshold,omitempty" json:"bulk_confirm_threshold,omitempty" mapstructure:"bulk_confirm_threshold"`
+}
+
+// Theme selects the terminal color scheme. Name is "dark" (the default) or
+// "light"; Colors override individual colors of it.
+type Theme struct {
+	Name   string      `yaml:"name,omitempty" json:"name,omitempty" mapstructure:"name"`
+	Colors ThemeColors `yaml:"colors,omitempty" json:"colors,omitempty" mapstructure:"colors"`
+}
+
+// ThemeColors are ANSI 256 color numbers ("62") or hex values ("#7D56F4").
+type ThemeColors struct {
+	Accent             string `yaml:"accent,omitempty" json:"accent,omitempty" mapstructure:"accent"`
+	Border             string `yaml:"border,omitempty" json:"border,omitempty" mapstructure:"border"`
+	Muted              string `yaml:"muted,omitempty" json:"muted,omitempty" mapstructure:"muted"`
+	Selected           string `yaml:"selected,omitempty" json:"selected,omitempty" mapstructure:"selected"`
+	SelectedBackground string `yaml:"selected_background,omitempty" json:"selected_background,omitempty" mapstructure:"selected_background"`
+	Info               string `yaml:"info,omitempty" json:"info,omitempty" mapstructure:"info"`
+	Success            string `yaml:"success,omitempty" json:"success,omitempty" mapstructure:"success"`
+	Warning            string `yaml:"warning,omitempty" json:"warning,omitempty" mapstructure:"warning"`
+	Error              string `yaml:"error,omitempty" json:"error,omitempty" mapstructure:"error"`
+}
+
+// Column is a task table column. A zero Width uses the column's default.
+type Column struct {
+	Name  string `yaml:"name" json:"name" mapstructure:"name"`
+	Width int    `yaml:"width,omitempty" json:"width,omitempty" mapstructure:"width"`
+}
+
+// Telemetry configures OpenTelemetry tracing of commands and platform API
+// calls. Spans are exported over OTLP/HTTP to Endpoint (for example
+// "http://localhost:4318"), or to the endpoint set in the standard
+// OTEL_EXPORTER_OTLP_* environment variables. SampleRatio defaults to 1.
+type Telemetry struct {
+	Endpoint    string  `yaml:"endpoint,omitempty" json:"endpoint,omitempty" mapstructure:"endpoint"`
+	ServiceName string  `yaml:"service_name,omitempty" json:"service_name,omitempty" mapstructure:"service_name"`
+	SampleRatio float64 `yaml:"sample_ratio,omitempty" json:"sample_ratio,omitempty" mapstructure:"sample_ratio"`
+}
+
+// Log configures the log written with --verbose and --debug.
+type Log struct {
+	// File receives the log as JSON lines instead of stderr. The --log-file
+	// flag overrides it.
+	File string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
+	// Level is "verbose" or "debug", for a log that is always written.
+	Level string `yaml:"level,omitempty" json:"level,omitempty" mapstructure:"level"`
+}
+
+// Rules configures the rules run by the daemon and 'opentask rules run'.
+type Rules struct {
+	Aging []AgingRule `yaml:"aging,omitempty" json:"aging,omitempty" mapstructure:"aging"`
+}
+
+// AgingRule escalates tasks that have been open longer than After. Each
+// rule acts on a task once.
+type AgingRule struct {
+	Name string `yaml:"name" json:"name" mapstructure:"name"`
+	// After is how long a task is open before the rule applies, such as
+	// "14d", "2w" or "72h".
+	After string `yaml:"after" json:"after" mapstructure:"after"`
+
+	// Platform, Project, Labels and Priorities narrow the rule to the
+	// tasks they match. Tasks must have all the labels and any of the
+	// priorities.
+	Platform   string   `yaml:"platform,omitempty" json:"platform,omitempty" mapstructure:"platform"`
+	Project    string   `yaml:"project,omitempty" json:"project,omitempty" mapstructure:"project"`
+	Labels     []string `yaml:"labels,omitempty" json:"labels,omitempty" mapstructure:"labels"`
+	Priorities []string `yaml:"priorities,omitempty" json:"priorities,omitempty" mapstructure:"priorities"`
+
+	// BumpPriority raises the priority one step, AddLabel adds a label and
+	// Comment adds a comment, a template with .Days, .ID and .Title.
+	BumpPriority bool   `yaml:"bump_priority,omitempty" json:"bump_priority,omitempty" mapstructure:"bump_priority"`
+	AddLabel     string `yaml:"add_label,omitempty" json:"add_label,omitempty" mapstructure:"add_label"`
+	Comment      string `yaml:"comment,omitempty" json:"comment,omitempty" mapstructure:"comment"`
+}
+
+// Notifications configures the notifiers task events are posted to.
+type Notifications struct {
+	Slack []SlackNotification `yaml:"slack,omitempty" json:"slack,omitempty" mapstructure:"slack"`
+	Email EmailNotifications  `yaml:"email,omitempty" json:"email,omitempty" mapstructure:"email"`
+}
+
+// NotificationFilter selects the events a notifier posts. Events lists
+// "created", "updated" and "synced" (changes the daemon picks up from the
+// platforms); the other fields narrow them to tasks on the named platforms,
+// in the named projects or with the named priorities. Empty fields match
+// every event.
+type NotificationFilter struct {
+	Events     []string `yaml:"events,omitempty" json:"events,omitempty" mapstructure:"events"`
+	Platforms  []string `yaml:"platforms,omitempty" json:"platforms,omitempty" mapstructure:"platforms"`
+	Projects   []string `yaml:"projects,omitempty" json:"projects,omitempty" mapstructure:"projects"`
+	Priorities []string `yaml:"priorities,omitempty" json:"priorities,omitempty" mapstructure:"priorities"`
+}
+
+// SlackNotification posts task events to a Slack channel.
+type SlackNotification struct {
+	Name    string `yaml:"name" json:"name" mapstructure:"name"`
+	Channel string `yaml:"channel" json:"channel" mapstructure:"channel"`
+	// Token is a bot token with the chat:write scope. When empty, the
+	// token of the configured Slack platform is used.
+	Token string `yaml:"token,omitempty" json:"token,omitempty" mapstructure:"token"`
+	// Mentions maps assignee emails or names to Slack user IDs, such as
+	// alice@example.com: U0123ABCD, so assignees are mentioned. Others are
+	// looked up by email, which needs the users:read.email scope.
+	Mentions map[string]string `yaml:"mentions,omitempty" json:"mentions,omitempty" mapstructure:"mentions"`
+
+	NotificationFilter `yaml:",inline" mapstructure:",squash"`
+}
+
+// EmailNotifications sends task events and digests by email through an
+// SMTP server. Port defaults to 587; the connection is upgraded with
+// STARTTLS when the server offers it.
+type EmailNotifications struct {
+	Host     string `yaml:"host,omitempty" json:"host,omitempty" mapstructure:"host"`
+	Port     int    `yaml:"port,omitempty" json:"port,omitempty" mapstructure:"port"`
+	Username string `yaml:"username,omitempty" json:"username,omitempty" mapstructure:"username"`
+	Password string `yaml:"password,omitempty" json:"password,omitempty" mapstructure:"password"`
+	From     string `yaml:"from,omitempty" json:"from,omitempty" mapstructure:"from"`
+
+	Rules []EmailRule `yaml:"rules,omitempty" json:"rules,omitempty" mapstructure:"rules"`
+}
+
+// EmailRule sends mail to To. Without a Schedule it is an alert, sent for
+// each event its filter matches; with one it is a digest of the open tasks
+// its filter matches, sent by the daemon when the schedule comes round.
+type EmailRule struct {
+	Name string   `yaml:"name" json:"name" mapstructure:"name"`
+	To   []string `yaml:"to" json:"to" mapstructure:"to"`
+
+	// Subject and Body are templates. Alerts have the event's .Kind,
+	// .Platform, .Task, .Previous and .Heading; digests have .Name, .At
+	// and .Tasks. Both default to a plain summary.
+	Subject string `yaml:"subject,omitempty" json:"subject,omitempty" mapstructure:"subject"`
+	Body    string `yaml:"body,omitempty" json:"body,omitempty" mapstructure:"body"`
+
+	// Schedule is a cron expression, such as "0 8 * * *", that makes the
+	// rule a digest.
+	Schedule string `yaml:"schedule,omitempty" json:"schedule,omitempty" mapstructure:"schedule"`
+	// DueWithin narrows a digest to tasks due within it, such as "1d" for
+	// the tasks due by tomorrow, overdue tasks included.
+	DueWithin string `yaml:"due_within,omitempty" json:"due_within,omitempty" mapstructure:"due_within"`
+
+	NotificationFilter `yaml:",inline" mapstructure:",squash"`
+}
+
 type Workspace struct {
 	Name        string    `yaml:"name" json:"name"`
 	Description string    `yaml:"description,omitempty" json:"description,omitempty"`
@@ -43,7 +259,7 @@ type Workspace struct {
 }
 
 const (
-	DefaultConfigVersion = "1.0"
+	DefaultConfigVersion = "1.1"
 	DefaultWorkspace     = "default"
 	DefaultConfigFile    = ".opentask.yaml"
 )
@@ -74,6 +290,7 @@ func (c *Config) AddPlatform(name string, platform Platform) {
 
 func (c *Config) RemovePlatform(name string) {
 	delete(c.Platforms, name)
+	delete(c.Defaults.Projects, name)
 }
 
 func (c *Config) GetEnabledPlatforms() []string {
@@ -84,4 +301,92 @@ func (c *Config) GetEnabledPlatforms() []string {
 		}
 	}
 	return enabled
-}
\ No newline at end of file
+}
+
+// DefaultProject returns the default project of the named platform, with
+// aliases resolved, or "" when it has none.
+func (c *Config) DefaultProject(platform string) string {
+	if project := c.Defaults.Projects[platform]; project != "" {
+		return c.ResolveProject(project)
+	}
+	return c.ResolveProject(c.Defaults.Project)
+}
+
+// DefaultProjectsByType returns the default projects of the enabled
+// platforms by platform type, for filtering tasks that only know their
+// platform's type. When two platforms of a type disagree, the one whose
+// name sorts first wins.
+func (c *Config) DefaultProjectsByType() map[string]string {
+	names := c.GetEnabledPlatforms()
+	sort.Strings(names)
+
+	projects := make(map[string]string)
+	for _, name := range names {
+		platformType := c.Platforms[name].Type
+		if _, ok := projects[platformType]; ok {
+			continue
+		}
+		if project := c.DefaultProject(name); project != "" {
+			projects[platformType] = project
+		}
+	}
+	return projects
+}
+
+// SetDefaultProject makes project the default project of the named
+// platform, or clears it when project is empty.
+func (c *Config) SetDefaultProject(platform, project string) {
+	if project == "" {
+		delete(c.Defaults.Projects, platform)
+		return
+	}
+	if c.Defaults.Projects == nil {
+		c.Defaults.Projects = make(map[string]string)
+	}
+	c.Defaults.Projects[platform] = project
+}
+
+// migrateDefaultProject moves a defaults.project written before default
+// projects were kept per platform to defaults.projects, under the default
+// platform or, failing that, the only configured platform. The next Save
+// writes the new form.
+func (c *Config) migrateDefaultProject() bool {
+	if c.Defaults.Project == "" {
+		return false
+	}
+	platform := c.soleDefaultPlatform()
+	if platform == "" {
+		return false
+	}
+	if c.Defaults.Projects[platform] == "" {
+		c.SetDefaultProject(platform, c.Defaults.Project)
+	}
+	c.Defaults.Project = ""
+	return true
+}
+
+// soleDefaultPlatform returns the default platform or, failing that, the
+// only configured platform.
+func (c *Config) soleDefaultPlatform() string {
+	if c.Defaults.Platform != "" || len(c.Platforms) != 1 {
+		return c.Defaults.Platform
+	}
+	for name := range c.Platforms {
+		return name
+	}
+	return ""
+}
+
+// ResolveProject returns the project an alias refers to, or project itself
+// if it is not an alias. Aliases are matched case-insensitively.
+func (c *Config) ResolveProject(project string) string {
+	if target, ok := c.ProjectAliases[project]; ok {
+		return target
+	}
+	for alias, target := range c.ProjectAliases {
+		if strings.EqualFold(alias, project) {
+			return target
+		}
+	}
+	return project
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LocalConfig is what a repository's own .opentask.yaml may set: the
+// defaults and project aliases for work in that repository. Platforms and
+// their credentials stay in the global configuration.
+type LocalConfig struct {
+	Defaults       Defaults          `yaml:"defaults"`
+	ProjectAliases map[string]string `yaml:"project_aliases"`
+}
+
+// FindLocalConfig returns the nearest .opentask.yaml in dir or one of its
+// parents, skipping the global configuration at global, or "" when there
+// is none.
+func FindLocalConfig(dir, global string) string {
+	global, _ = filepath.Abs(global)
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, DefaultConfigFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() && path != global {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func loadLocalConfig(path string) (*LocalConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var local LocalConfig
+	if err := yaml.Unmarshal(data, &local); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return &local, nil
+}
+
+// overlay applies local on top of c. A defaults.project in local is moved
+// to defaults.projects first, like one in the global configuration.
+func (c *Config) overlay(local *LocalConfig) {
+	if local.Defaults.Project != "" {
+		platform := local.Defaults.Platform
+		if platform == "" {
+			platform = c.soleDefaultPlatform()
+		}
+		if platform != "" {
+			if local.Defaults.Projects[platform] == "" {
+				local.Defaults.Projects = overlayMap(local.Defaults.Projects, map[string]string{platform: local.Defaults.Project})
+			}
+			local.Defaults.Project = ""
+		}
+	}
+
+	defaults := local.Defaults
+	overlayString(&c.Defaults.Platform, defaults.Platform)
+	overlayString(&c.Defaults.Assignee, defaults.Assignee)
+	overlayString(&c.Defaults.Priority, defaults.Priority)
+	overlayString(&c.Defaults.Project, defaults.Project)
+	overlayString(&c.Defaults.Timeout, defaults.Timeout)
+	if len(defaults.Labels) > 0 {
+		c.Defaults.Labels = defaults.Labels
+	}
+	c.Defaults.Projects = overlayMap(c.Defaults.Projects, defaults.Projects)
+	c.ProjectAliases = overlayMap(c.ProjectAliases, local.ProjectAliases)
+}
+
+// without returns a copy of c with the values local set replaced by those
+// of global, so saving the configuration does not copy a repository's
+// settings into the global file. Values a command changed since are kept.
+func (c *Config) without(local *LocalConfig, global *Config) *Config {
+	saved := *c
+	defaults := &saved.Defaults
+	restoreString(&defaults.Platform, local.Defaults.Platform, global.Defaults.Platform)
+	restoreString(&defaults.Assignee, local.Defaults.Assignee, global.Defaults.Assignee)
+	restoreString(&defaults.Priority, local.Defaults.Priority, global.Defaults.Priority)
+	restoreString(&defaults.Project, local.Defaults.Project, global.Defaults.Project)
+	restoreString(&defaults.Timeout, local.Defaults.Timeout, global.Defaults.Timeout)
+	if len(local.Defaults.Labels) > 0 && slices.Equal(defaults.Labels, local.Defaults.Labels) {
+		defaults.Labels = global.Defaults.Labels
+	}
+	defaults.Projects = restoreMap(defaults.Projects, local.Defaults.Projects, global.Defaults.Projects)
+	saved.ProjectAliases = restoreMap(saved.ProjectAliases, local.ProjectAliases, global.ProjectAliases)
+	return &saved
+}
+
+func overlayString(value *string, local string) {
+	if local != "" {
+		*value = local
+	}
+}
+
+func overlayMap(values, local map[string]string) map[string]string {
+	if len(local) == 0 {
+		return values
+	}
+	merged := maps.Clone(values)
+	if merged == nil {
+		merged = make(map[string]string)
+	}
+	maps.Copy(merged, local)
+	return merged
+}
+
+func restoreString(value *string, local, global string) {
+	if local != "" && *value == local {
+		*value = global
+	}
+}
+
+func restoreMap(values, local, global map[string]string) map[string]string {
+	if len(local) == 0 {
+		return values
+	}
+	restored := maps.Clone(values)
+	for key, value := range local {
+		if restored[key] != value {
+			continue
+		}
+		if original, ok := global[key]; ok {
+			restored[key] = original
+		} else {
+			delete(restored, key)
+		}
+	}
+	return restored
+}
@@ -2,15 +2,37 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
 type Manager struct {
 	config *Config
 	path   string
+	// viper holds the file as read, so Save keeps the settings it does not
+	// know about. Each Manager has its own, so loading one configuration
+	// never changes another.
+	viper *viper.Viper
+
+	// local is the repository configuration laid over config, and global
+	// the configuration as it was before, kept so Save writes only the
+	// global settings.
+	local     *LocalConfig
+	localPath string
+	global    Config
+
+	// migrated lists the changes Load made to an older configuration,
+	// and backupPath is where the file was copied before it was rewritten.
+	migrated   []string
+	backupPath string
+
+	// workspace overrides the file's workspace for this run, and is not
+	// saved.
+	workspace string
 }
 
 func NewManager() *Manager {
@@ -19,57 +41,176 @@ func NewManager() *Manager {
 	}
 }
 
+// ConfigFlag and WorkspaceFlag are the global flags that choose the
+// configuration file and, for one run, the workspace.
+const (
+	ConfigFlag    = "config"
+	WorkspaceFlag = "workspace"
+)
+
+// FlagPath returns the configuration file named by --config in flags, or
+// "" for DefaultPath.
+func FlagPath(flags *pflag.FlagSet) string {
+	path, _ := flags.GetString(ConfigFlag)
+	return path
+}
+
+// DefaultPath returns the configuration file used when none is given.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, DefaultConfigFile), nil
+}
+
+// LoadFlags loads the configuration file named by --config in flags, using
+// the workspace named by --workspace instead of the file's.
+func (m *Manager) LoadFlags(flags *pflag.FlagSet) error {
+	m.workspace, _ = flags.GetString(WorkspaceFlag)
+	return m.Load(FlagPath(flags))
+}
+
+// Load reads the configuration file at configPath, or at DefaultPath when
+// it is empty, and lays the repository's .opentask.yaml over it.
 func (m *Manager) Load(configPath string) error {
 	if configPath == "" {
-		home, err := os.UserHomeDir()
+		path, err := DefaultPath()
 		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
+			return err
 		}
-		configPath = filepath.Join(home, DefaultConfigFile)
+		configPath = path
 	}
 
 	m.path = configPath
+	m.viper = newViper()
 
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return nil
+	if _, err := os.Stat(configPath); err == nil {
+		m.viper.SetConfigFile(configPath)
+
+		if err := m.viper.ReadInConfig(); err != nil {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+
+		if err := m.viper.Unmarshal(m.config); err != nil {
+			return fmt.Errorf("failed to unmarshal config: %w", err)
+		}
+
+		from := m.viper.GetString("version")
+		if m.migrated = m.config.migrate(from); len(m.migrated) > 0 {
+			m.saveMigrated(from)
+		}
+	}
+	if m.workspace != "" {
+		m.config.Workspace = m.workspace
 	}
 
-	viper.SetConfigFile(configPath)
-	viper.SetConfigType("yaml")
+	return m.loadLocal(configPath)
+}
 
-	if err := viper.ReadInConfig(); err != nil {
-		return fmt.Errorf("failed to read config file: %w", err)
+// saveMigrated writes a configuration migrated from version from back to
+// its file, after copying the file as it was next to it. When either fails
+// the file is left alone and migrated again on the next load.
+func (m *Manager) saveMigrated(from string) {
+	m.backupPath = backupPath(m.path, from)
+	if err := backup(m.path, m.backupPath); err != nil {
+		m.backupPath = ""
+		return
+	}
+	if err := m.Save(); err != nil {
+		m.backupPath = ""
 	}
+}
+
+// Migrated returns the changes made to upgrade an older configuration
+// file on Load, and where the file was backed up before it was rewritten,
+// or "" when it could not be rewritten.
+func (m *Manager) Migrated() ([]string, string) {
+	return m.migrated, m.backupPath
+}
 
-	if err := viper.Unmarshal(m.config); err != nil {
-		return fmt.Errorf("failed to unmarshal config: %w", err)
+// loadLocal lays the nearest .opentask.yaml above the working directory
+// over the configuration, so commands run in a repository default to its
+// platform, project and labels.
+func (m *Manager) loadLocal(globalPath string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	path := FindLocalConfig(cwd, globalPath)
+	if home, err := os.UserHomeDir(); path == "" || err == nil && path == filepath.Join(home, DefaultConfigFile) {
+		return nil
+	}
+	local, err := loadLocalConfig(path)
+	if err != nil || local == nil {
+		return err
 	}
 
+	m.global = *m.config
+	m.global.Defaults.Projects = maps.Clone(m.config.Defaults.Projects)
+	m.global.ProjectAliases = maps.Clone(m.config.ProjectAliases)
+	m.config.overlay(local)
+	m.local, m.localPath = local, path
 	return nil
 }
 
+// LocalConfigPath returns the repository configuration laid over the
+// global one, or "" when there is none.
+func (m *Manager) LocalConfigPath() string {
+	return m.localPath
+}
+
 func (m *Manager) Save() error {
 	if m.path == "" {
-		home, err := os.UserHomeDir()
+		path, err := DefaultPath()
 		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
+			return err
 		}
-		m.path = filepath.Join(home, DefaultConfigFile)
+		m.path = path
 	}
+	if m.viper == nil {
+		m.viper = newViper()
+	}
+	v := m.viper
 
 	if err := os.MkdirAll(filepath.Dir(m.path), 0755); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	viper.Set("version", m.config.Version)
-	viper.Set("workspace", m.config.Workspace)
-	viper.Set("platforms", m.config.Platforms)
-	viper.Set("defaults", m.config.Defaults)
-	if m.config.RemoteSync != nil {
-		viper.Set("remote_sync", m.config.RemoteSync)
+	config := m.config
+	if m.local != nil {
+		config = m.config.without(m.local, &m.global)
 	}
 
-	if err := viper.WriteConfigAs(m.path); err != nil {
+	v.Set("version", config.Version)
+	if m.workspace == "" || config.Workspace != m.workspace {
+		v.Set("workspace", config.Workspace)
+	}
+	v.Set("platforms", config.Platforms)
+	v.Set("defaults", config.Defaults)
+	if config.RemoteSync != nil {
+		v.Set("remote_sync", config.RemoteSync)
+	}
+	if config.Reports != (Reports{}) {
+		v.Set("reports", config.Reports)
+	}
+	if config.Git != (Git{}) {
+		v.Set("git", config.Git)
+	}
+	if len(config.UI.Columns) > 0 || config.UI.Theme != (Theme{}) || config.UI.Interactive != "" || config.UI.RefreshInterval != "" {
+		v.Set("ui", config.UI)
+	}
+	if config.Telemetry != (Telemetry{}) {
+		v.Set("telemetry", config.Telemetry)
+	}
+	if len(config.ProjectAliases) > 0 {
+		v.Set("project_aliases", config.ProjectAliases)
+	}
+	if len(config.Aliases) > 0 {
+		v.Set("aliases", config.Aliases)
+	}
+
+	if err := v.WriteConfigAs(m.path); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
@@ -88,8 +229,18 @@ func (m *Manager) GetConfigPath() string {
 	return m.path
 }
 
+// newViper returns a viper instance for one configuration file, which is
+// always YAML whatever its name. Environment variables are not read: Save
+// writes what Load read, and would store an override in the file.
+func newViper() *viper.Viper {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	return v
+}
+
 func (m *Manager) Reset() {
 	m.config = NewConfig()
+	m.local, m.localPath = nil, ""
 }
 
 func (m *Manager) Validate() error {
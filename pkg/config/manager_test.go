@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "opentask.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func globalFlags(t *testing.T, args ...string) *pflag.FlagSet {
+	t.Helper()
+	flags := pflag.NewFlagSet("opentask", pflag.ContinueOnError)
+	flags.String(ConfigFlag, "", "")
+	flags.StringP(WorkspaceFlag, "w", "", "")
+	require.NoError(t, flags.Parse(args))
+	return flags
+}
+
+func TestManager_LoadFlags(t *testing.T) {
+	path := writeConfig(t, "version: \""+DefaultConfigVersion+"\"\nworkspace: home\n")
+
+	manager := NewManager()
+	require.NoError(t, manager.LoadFlags(globalFlags(t, "--config", path, "--workspace", "work")))
+	assert.Equal(t, path, manager.GetConfigPath())
+	assert.Equal(t, "work", manager.GetConfig().Workspace)
+
+	// The override lasts for this run only.
+	require.NoError(t, manager.Save())
+	saved := NewManager()
+	require.NoError(t, saved.Load(path))
+	assert.Equal(t, "home", saved.GetConfig().Workspace)
+}
+
+func TestManager_LoadFlags_Independent(t *testing.T) {
+	first := writeConfig(t, "version: \""+DefaultConfigVersion+"\"\nworkspace: first\n")
+	second := writeConfig(t, "version: \""+DefaultConfigVersion+"\"\nworkspace: second\n")
+
+	a := NewManager()
+	require.NoError(t, a.LoadFlags(globalFlags(t, "--config", first, "-w", "override")))
+	b := NewManager()
+	require.NoError(t, b.LoadFlags(globalFlags(t, "--config", second)))
+
+	assert.Equal(t, "override", a.GetConfig().Workspace)
+	assert.Equal(t, "second", b.GetConfig().Workspace)
+	assert.Equal(t, second, b.GetConfigPath())
+}
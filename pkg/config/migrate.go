@@ -0,0 +1,99 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// migration upgrades a configuration written before version to the layout
+// of that version. apply reports whether it changed anything.
+type migration struct {
+	version     string
+	description string
+	apply       func(c *Config) bool
+}
+
+// migrations are applied in order to configurations older than their
+// version. The last one's version is DefaultConfigVersion.
+//
+// Credentials stay in the file: opentask has no keyring to move them to,
+// so there is no migration to keyring references yet. It belongs here
+// once a credential store exists.
+var migrations = []migration{
+	{
+		version:     "1.1",
+		description: "default projects are kept per platform in defaults.projects",
+		apply:       (*Config).migrateDefaultProject,
+	},
+}
+
+// migrate upgrades c from version from to DefaultConfigVersion and returns
+// the changes made, leaving out migrations that found nothing to change. A
+// configuration without a version is taken to be 1.0. Configurations
+// written by a newer release are left as they are.
+func (c *Config) migrate(from string) []string {
+	if from == "" {
+		from = "1.0"
+	}
+	c.Version = from
+	var applied []string
+	for _, m := range migrations {
+		if compareVersions(from, m.version) >= 0 {
+			continue
+		}
+		if m.apply(c) {
+			applied = append(applied, m.description)
+		}
+		c.Version = DefaultConfigVersion
+	}
+	return applied
+}
+
+// compareVersions compares two "major.minor" versions like strings.Compare.
+// Missing or malformed parts count as zero.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// backupPath is where the configuration at path is kept before it is
+// migrated from version.
+func backupPath(path, version string) string {
+	if version == "" {
+		version = "1.0"
+	}
+	return fmt.Sprintf("%s.v%s.bak", path, version)
+}
+
+// backup copies the file at path to dst, unless a backup is already there.
+func backup(path, dst string) error {
+	if _, err := os.Stat(dst); err == nil {
+		return nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0600)
+}
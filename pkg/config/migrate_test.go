@@ -0,0 +1,162 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.0", b: "1.1", want: -1},
+		{a: "1.1", b: "1.1", want: 0},
+		{a: "1.10", b: "1.9", want: 1},
+		{a: "2", b: "1.9", want: 1},
+		{a: "1", b: "1.0", want: 0},
+		{a: "1.0.1", b: "1.0", want: 1},
+		{a: "1.x", b: "1.0", want: 0},
+		{a: "", b: "0.0", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, compareVersions(tt.a, tt.b))
+			assert.Equal(t, -tt.want, compareVersions(tt.b, tt.a))
+		})
+	}
+}
+
+func TestConfig_Migrate(t *testing.T) {
+	tests := []struct {
+		name         string
+		from         string
+		config       Config
+		wantChanges  int
+		wantVersion  string
+		wantDefaults Defaults
+	}{
+		{
+			name:         "default project moves to its platform",
+			from:         "1.0",
+			config:       Config{Defaults: Defaults{Platform: "jira", Project: "API"}},
+			wantChanges:  1,
+			wantVersion:  DefaultConfigVersion,
+			wantDefaults: Defaults{Platform: "jira", Projects: map[string]string{"jira": "API"}},
+		},
+		{
+			name:         "no version is 1.0",
+			config:       Config{Defaults: Defaults{Platform: "jira", Project: "API"}},
+			wantChanges:  1,
+			wantVersion:  DefaultConfigVersion,
+			wantDefaults: Defaults{Platform: "jira", Projects: map[string]string{"jira": "API"}},
+		},
+		{
+			name:         "nothing to move",
+			from:         "1.0",
+			config:       Config{Defaults: Defaults{Platform: "jira"}},
+			wantVersion:  DefaultConfigVersion,
+			wantDefaults: Defaults{Platform: "jira"},
+		},
+		{
+			name:         "no platform to move it to",
+			from:         "1.0",
+			config:       Config{Defaults: Defaults{Project: "API"}},
+			wantVersion:  DefaultConfigVersion,
+			wantDefaults: Defaults{Project: "API"},
+		},
+		{
+			name:         "current",
+			from:         DefaultConfigVersion,
+			config:       Config{Defaults: Defaults{Platform: "jira", Project: "API"}},
+			wantVersion:  DefaultConfigVersion,
+			wantDefaults: Defaults{Platform: "jira", Project: "API"},
+		},
+		{
+			name:         "newer release",
+			from:         "9.0",
+			config:       Config{Defaults: Defaults{Platform: "jira", Project: "API"}},
+			wantVersion:  "9.0",
+			wantDefaults: Defaults{Platform: "jira", Project: "API"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.config
+			changes := c.migrate(tt.from)
+			assert.Len(t, changes, tt.wantChanges)
+			assert.Equal(t, tt.wantVersion, c.Version)
+			assert.Equal(t, tt.wantDefaults, c.Defaults)
+		})
+	}
+}
+
+func TestBackup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".opentask.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("version: \"1.0\"\n"), 0600))
+
+	dst := backupPath(path, "")
+	assert.Equal(t, path+".v1.0.bak", dst)
+	require.NoError(t, backup(path, dst))
+	data, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "version: \"1.0\"\n", string(data))
+
+	// An existing backup is kept.
+	require.NoError(t, os.WriteFile(path, []byte("version: \"1.1\"\n"), 0600))
+	require.NoError(t, backup(path, dst))
+	data, err = os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "version: \"1.0\"\n", string(data))
+
+	assert.Error(t, backup(filepath.Join(dir, "missing.yaml"), filepath.Join(dir, "missing.bak")))
+}
+
+func TestManager_LoadMigrates(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantChanges int
+		wantBackup  bool
+	}{
+		{
+			name:        "rewrites a file that needed a change",
+			content:     "version: \"1.0\"\ndefaults:\n  platform: jira\n  project: API\n",
+			wantChanges: 1,
+			wantBackup:  true,
+		},
+		{
+			name:    "leaves a file with nothing to change",
+			content: "version: \"1.0\"\ndefaults:\n  platform: jira\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".opentask.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			manager := NewManager()
+			require.NoError(t, manager.Load(path))
+			changes, backup := manager.Migrated()
+			assert.Len(t, changes, tt.wantChanges)
+
+			_, err := os.Stat(path + ".v1.0.bak")
+			if !tt.wantBackup {
+				assert.Empty(t, backup)
+				assert.ErrorIs(t, err, os.ErrNotExist)
+				data, err := os.ReadFile(path)
+				require.NoError(t, err)
+				assert.Equal(t, tt.content, string(data))
+				return
+			}
+			assert.Equal(t, path+".v1.0.bak", backup)
+			assert.NoError(t, err)
+			assert.Equal(t, "API", manager.GetConfig().Defaults.Projects["jira"])
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// RedactedValue replaces credentials in configuration that is shown.
+const RedactedValue = "[redacted]"
+
+// sensitiveSettings are parts of setting names whose values are secret.
+var sensitiveSettings = []string{"token", "secret", "password"}
+
+// accountCredentials name the account rather than prove access to it, so
+// they are neither secret nor hidden.
+var accountCredentials = map[string]bool{"email": true, "username": true}
+
+// Secrets returns the tokens and passwords of every platform and notifier,
+// and settings that look like credentials, so they can be hidden from output and logs.
+func (c *Config) Secrets() []string {
+	var secrets []string
+	for _, platform := range c.Platforms {
+		for key, value := range platform.Credentials {
+			if value != "" && !accountCredentials[key] {
+				secrets = append(secrets, value)
+			}
+		}
+		for key, value := range platform.Settings {
+			if s, ok := value.(string); ok && s != "" && sensitiveSetting(key) {
+				secrets = append(secrets, s)
+			}
+		}
+	}
+	for _, slack := range c.Notifications.Slack {
+		if slack.Token != "" {
+			secrets = append(secrets, slack.Token)
+		}
+	}
+	if c.Notifications.Email.Password != "" {
+		secrets = append(secrets, c.Notifications.Email.Password)
+	}
+	return secrets
+}
+
+// Redacted returns a copy of c that can be shown: platform and notifier
+// tokens and passwords, and settings that look like credentials, are
+// replaced by RedactedValue.
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redacted.Platforms = make(map[string]Platform, len(c.Platforms))
+	for name, platform := range c.Platforms {
+		credentials := make(map[string]string, len(platform.Credentials))
+		for key, value := range platform.Credentials {
+			if !accountCredentials[key] {
+				value = RedactedValue
+			}
+			credentials[key] = value
+		}
+		platform.Credentials = credentials
+
+		settings := maps.Clone(platform.Settings)
+		for key, value := range settings {
+			if _, ok := value.(string); ok && sensitiveSetting(key) {
+				settings[key] = RedactedValue
+			}
+		}
+		platform.Settings = settings
+
+		redacted.Platforms[name] = platform
+	}
+
+	redacted.Notifications.Slack = slices.Clone(c.Notifications.Slack)
+	for i := range redacted.Notifications.Slack {
+		if redacted.Notifications.Slack[i].Token != "" {
+			redacted.Notifications.Slack[i].Token = RedactedValue
+		}
+	}
+	if redacted.Notifications.Email.Password != "" {
+		redacted.Notifications.Email.Password = RedactedValue
+	}
+	return &redacted
+}
+
+func sensitiveSetting(key string) bool {
+	lower := strings.ToLower(key)
+	for _, sensitive := range sensitiveSettings {
+		if strings.Contains(lower, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+// Package exitcode defines the exit codes opentask ends with, so scripts
+// can tell a typo in a flag from an expired token or a missing task, and
+// writes errors as JSON for --error-format json.
+package exitcode
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"opentask/pkg/logging"
+	"opentask/pkg/platforms"
+)
+
+// Exit codes. They are part of the command-line interface and do not
+// change between releases.
+const (
+	// OK means the command succeeded.
+	OK = 0
+	// Generic is any failure without a more specific code.
+	Generic = 1
+	// Usage means the command line was invalid: an unknown command or
+	// flag, a missing argument or conflicting flags.
+	Usage = 2
+	// Auth means a platform rejected the credentials, or the token lacks
+	// the permission the operation needs.
+	Auth = 3
+	// NotFound means a task, project or other resource does not exist.
+	NotFound = 4
+	// Partial means some of the platforms or tasks a command worked on
+	// failed while the others succeeded.
+	Partial = 5
+)
+
+// names are the codes of the JSON errors for each exit code.
+var names = map[int]string{
+	Generic:  "error",
+	Usage:    "usage",
+	Auth:     string(platforms.ErrAuthentication),
+	NotFound: string(platforms.ErrNotFound),
+	Partial:  "partial_failure",
+}
+
+// Error is an error with the exit code it ends the command with.
+type Error struct {
+	Code int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Wrap returns err with the exit code code, or nil if err is nil.
+func Wrap(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// WrapPartial returns err, reporting that failed of total items failed,
+// with the Partial exit code when some of them succeeded.
+func WrapPartial(failed, total int, err error) error {
+	if failed < total {
+		return Wrap(Partial, err)
+	}
+	return err
+}
+
+// Of returns the exit code for err: the code it was wrapped with, or else
+// the one its platform error code maps to.
+func Of(err error) int {
+	if err == nil {
+		return OK
+	}
+
+	var coded *Error
+	switch {
+	case errors.As(err, &coded):
+		return coded.Code
+	case platforms.IsAuthenticationError(err), platforms.IsPermissionError(err):
+		return Auth
+	case platforms.IsNotFoundError(err):
+		return NotFound
+	}
+	return Generic
+}
+
+// jsonError is the JSON form of an error.
+type jsonError struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	ExitCode int    `json:"exit_code"`
+	Platform string `json:"platform,omitempty"`
+	TaskID   string `json:"task_id,omitempty"`
+}
+
+// WriteJSON writes err to w as a JSON object on one line, such as
+// {"error":{"code":"not_found","message":"...","exit_code":4,"platform":"jira"}}.
+// The code is the platform error code when there is one, and otherwise
+// names the exit code: error, usage, partial_failure and so on.
+func WriteJSON(w io.Writer, err error) error {
+	code := Of(err)
+	out := jsonError{
+		Code:     names[code],
+		Message:  logging.Redact(err.Error()),
+		ExitCode: code,
+	}
+
+	var (
+		coded       *Error
+		platformErr *platforms.PlatformError
+	)
+	// Usage and partial failures keep their own code.
+	wrapped := errors.As(err, &coded)
+	if errors.As(err, &platformErr) {
+		out.Platform = platformErr.Platform
+		out.TaskID = platformErr.TaskID
+	}
+	switch {
+	case wrapped:
+	case platformErr != nil:
+		out.Code = string(platformErr.Code)
+	case platforms.IsPermissionError(err):
+		out.Code = string(platforms.ErrPermissionDenied)
+	}
+
+	data, err := json.Marshal(struct {
+		Error jsonError `json:"error"`
+	}{out})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
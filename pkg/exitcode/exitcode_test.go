@@ -0,0 +1,94 @@
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"opentask/pkg/platforms"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOf(t *testing.T) {
+	notFound := platforms.NewPlatformError(platforms.ErrNotFound, "jira", "API-1", errors.New("404"))
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: OK},
+		{name: "plain error", err: errors.New("boom"), want: Generic},
+		{name: "authentication", err: platforms.NewPlatformError(platforms.ErrAuthentication, "jira", "", nil), want: Auth},
+		{name: "permission denied", err: platforms.NewPlatformError(platforms.ErrPermissionDenied, "jira", "", nil), want: Auth},
+		{name: "missing scope", err: &platforms.PermissionError{Operation: "deleting issues", Required: "Delete issues"}, want: Auth},
+		{name: "not found", err: fmt.Errorf("failed to get task: %w", notFound), want: NotFound},
+		{name: "rate limited", err: platforms.NewPlatformError(platforms.ErrRateLimited, "jira", "", nil), want: Generic},
+		{name: "usage", err: Wrap(Usage, errors.New(`unknown flag: --bogus`)), want: Usage},
+		{name: "partial wrapping not found", err: Wrap(Partial, fmt.Errorf("1 of 2 failed: %w", notFound)), want: Partial},
+		{name: "wrapped authentication", err: fmt.Errorf("jira: %w", platforms.NewPlatformError(platforms.ErrAuthentication, "jira", "", nil)), want: Auth},
+		{name: "wrapped missing scope", err: fmt.Errorf("failed to delete task: %w", &platforms.PermissionError{Operation: "deleting issues"}), want: Auth},
+		{name: "joined not found", err: errors.Join(errors.New("linear: timeout"), notFound), want: NotFound},
+		{name: "wrapped usage", err: fmt.Errorf("task create: %w", Wrap(Usage, errors.New("--title is required"))), want: Usage},
+		{name: "usage wrapping not found", err: Wrap(Usage, notFound), want: Usage},
+		{name: "partial wrapping authentication", err: Wrap(Partial, platforms.NewPlatformError(platforms.ErrAuthentication, "linear", "", nil)), want: Partial},
+		{name: "invalid config", err: platforms.NewPlatformError(platforms.ErrInvalidConfig, "jira", "", nil), want: Generic},
+		{name: "network", err: fmt.Errorf("list: %w", platforms.NewPlatformError(platforms.ErrNetworkError, "jira", "", nil)), want: Generic},
+		{name: "sync conflict", err: platforms.NewPlatformError(platforms.ErrSyncConflict, "linear", "ENG-1", nil), want: Generic},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Of(tt.err))
+		})
+	}
+
+	assert.NoError(t, Wrap(Usage, nil))
+	assert.Equal(t, Partial, Of(WrapPartial(1, 3, errors.New("1 of 3 failed"))))
+	assert.Equal(t, Generic, Of(WrapPartial(3, 3, errors.New("3 of 3 failed"))))
+	assert.Equal(t, NotFound, Of(WrapPartial(2, 2, fmt.Errorf("2 of 2 failed: %w", notFound))))
+	assert.Equal(t, Partial, Of(WrapPartial(1, 2, fmt.Errorf("1 of 2 failed: %w", notFound))))
+}
+
+func TestWriteJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "plain error",
+			err:  errors.New("no platforms configured"),
+			want: `{"error":{"code":"error","message":"no platforms configured","exit_code":1}}`,
+		},
+		{
+			name: "platform error",
+			err:  fmt.Errorf("failed to get task: %w", platforms.NewPlatformError(platforms.ErrNotFound, "jira", "API-1", nil)),
+			want: `{"error":{"code":"not_found","message":"failed to get task: [not_found] Resource not found (platform: jira) (task: API-1)","exit_code":4,"platform":"jira","task_id":"API-1"}}`,
+		},
+		{
+			name: "rate limited",
+			err:  platforms.NewPlatformError(platforms.ErrRateLimited, "linear", "", nil),
+			want: `{"error":{"code":"rate_limited","message":"[rate_limited] Rate limit exceeded (platform: linear)","exit_code":1,"platform":"linear"}}`,
+		},
+		{
+			name: "missing scope",
+			err:  &platforms.PermissionError{Operation: "deleting issues", Required: "Delete issues"},
+			want: `{"error":{"code":"permission_denied","message":"deleting issues requires Delete issues","exit_code":3}}`,
+		},
+		{
+			name: "partial failure",
+			err:  Wrap(Partial, errors.New("1 of 3 task(s) could not be created")),
+			want: `{"error":{"code":"partial_failure","message":"1 of 3 task(s) could not be created","exit_code":5}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			require.NoError(t, WriteJSON(&b, tt.err))
+			assert.Equal(t, tt.want+"\n", b.String())
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+const (
+	DefaultBranchTemplate = "feat/{{.ID}}-{{.Slug}}"
+	maxSlugLength         = 40
+)
+
+// taskIDPattern matches issue keys such as TEST-123 or eng-45 inside branch names.
+var taskIDPattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])([a-z][a-z0-9]*-[0-9]+)(?:$|[^0-9])`)
+
+// BranchData is the data available to branch name templates.
+type BranchData struct {
+	ID       string
+	Slug     string
+	Title    string
+	Platform string
+	Type     string
+}
+
+// BranchName renders the branch template for the given task data.
+func BranchName(tmpl string, data BranchData) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultBranchTemplate
+	}
+
+	t, err := template.New("branch").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid branch template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render branch template: %w", err)
+	}
+
+	name := strings.Trim(buf.String(), "-/")
+	if name == "" {
+		return "", fmt.Errorf("branch template produced an empty name")
+	}
+
+	return name, nil
+}
+
+// Slugify lowercases title and replaces runs of non-alphanumerics with a
+// single dash, truncating to maxSlugLength characters on a word boundary.
+func Slugify(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	slug := strings.Trim(b.String(), "-")
+	if runes := []rune(slug); len(runes) > maxSlugLength {
+		// Cut runes rather than bytes so non-ASCII titles stay valid UTF-8.
+		slug = string(runes[:maxSlugLength])
+		if i := strings.LastIndex(slug, "-"); i > 0 {
+			slug = slug[:i]
+		}
+		slug = strings.TrimRight(slug, "-")
+	}
+
+	return slug
+}
+
+// ExtractTaskID finds the first issue key in a branch name, upper-cased.
+func ExtractTaskID(branch string) (string, bool) {
+	// Skip the conventional type prefix (feat/, fix/...) so it is not mistaken for a key.
+	if i := strings.LastIndex(branch, "/"); i >= 0 {
+		if match := taskIDPattern.FindStringSubmatch(branch[i+1:]); match != nil {
+			return strings.ToUpper(match[1]), true
+		}
+	}
+
+	match := taskIDPattern.FindStringSubmatch(branch)
+	if match == nil {
+		return "", false
+	}
+	return strings.ToUpper(match[1]), true
+}
+
+func CurrentBranch() (string, error) {
+	out, err := run("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if out == "HEAD" {
+		return "", fmt.Errorf("not on a branch (detached HEAD)")
+	}
+	return out, nil
+}
+
+func BranchExists(name string) bool {
+	_, err := run("rev-parse", "--verify", "--quiet", "refs/heads/"+name)
+	return err == nil
+}
+
+// CreateBranch creates name from the current HEAD, optionally checking it out.
+func CreateBranch(name string, checkout bool) error {
+	if _, err := run("check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("invalid branch name %q", name)
+	}
+
+	if checkout {
+		_, err := run("checkout", "-b", name)
+		return err
+	}
+
+	_, err := run("branch", name)
+	return err
+}
+
+func Checkout(name string) error {
+	_, err := run("checkout", name)
+	return err
+}
+
+func run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,73 @@
+package git
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "fix-login-bug-on-safari", Slugify("Fix login bug (on Safari!)"))
+	assert.Equal(t, "api-docs", Slugify("  API   docs  "))
+	assert.Equal(t, "a-very-long-title-that-keeps-going-and", Slugify("A very long title that keeps going and going forever"))
+}
+
+func TestSlugify_NonASCII(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "Überprüfung der Größenänderung für das Ansichtsfenster im Editor", want: "überprüfung-der-größenänderung-für-das"},
+		{title: strings.Repeat("로그인", 20), want: strings.Repeat("로그인", 13) + "로"},
+		{title: strings.Repeat("é", 39) + " " + strings.Repeat("é", 10), want: strings.Repeat("é", 39)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			slug := Slugify(tt.title)
+			assert.Equal(t, tt.want, slug)
+			assert.True(t, utf8.ValidString(slug))
+			assert.LessOrEqual(t, utf8.RuneCountInString(slug), maxSlugLength)
+			assert.False(t, strings.HasSuffix(slug, "-"))
+		})
+	}
+}
+
+func TestExtractTaskID(t *testing.T) {
+	tests := []struct {
+		branch string
+		id     string
+		found  bool
+	}{
+		{branch: "feat/TEST-123-fix-login", id: "TEST-123", found: true},
+		{branch: "eng-45-add-labels", id: "ENG-45", found: true},
+		{branch: "user/fix/ABC2-7", id: "ABC2-7", found: true},
+		{branch: "main", found: false},
+		{branch: "chore/v2-cleanup", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			id, found := ExtractTaskID(tt.branch)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.id, id)
+		})
+	}
+}
+
+func TestBranchName(t *testing.T) {
+	data := BranchData{ID: "TEST-123", Slug: "fix-login", Platform: "jira"}
+
+	name, err := BranchName("", data)
+	assert.NoError(t, err)
+	assert.Equal(t, "feat/TEST-123-fix-login", name)
+
+	name, err = BranchName("{{.Platform}}/{{.ID}}", data)
+	assert.NoError(t, err)
+	assert.Equal(t, "jira/TEST-123", name)
+
+	_, err = BranchName("{{.Missing}}", data)
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+// Package humanize formats timestamps for people, such as "2h ago" or
+// "due in 3d", the same way in the interactive views and plain output.
+package humanize
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"opentask/pkg/models"
+)
+
+const (
+	timeLayout = "2006-01-02 15:04"
+	dateLayout = "2006-01-02"
+)
+
+var absolute atomic.Bool
+
+// SetAbsolute makes Time and Due print timestamps and dates instead of
+// relative times, as --absolute-times asks.
+func SetAbsolute(enabled bool) {
+	absolute.Store(enabled)
+}
+
+// Absolute reports whether timestamps are printed as they are.
+func Absolute() bool {
+	return absolute.Load()
+}
+
+// Time formats t relative to now, such as "2h ago" or "in 3d", or as a
+// local timestamp when times are absolute. A zero time is empty.
+func Time(t time.Time) string {
+	return timeAt(t, time.Now())
+}
+
+// Due formats a due date relative to today, such as "due in 3d", "due
+// today" or "overdue 2d", or as a date when times are absolute.
+func Due(due time.Time) string {
+	return dueAt(due, time.Now())
+}
+
+// Overdue reports whether the task is still open past its due date.
+func Overdue(task *models.Task) bool {
+	return overdueAt(task, time.Now())
+}
+
+func timeAt(t, now time.Time) string {
+	switch {
+	case t.IsZero():
+		return ""
+	case Absolute():
+		return t.Local().Format(timeLayout)
+	}
+
+	elapsed := now.Sub(t)
+	switch {
+	case elapsed > -time.Minute && elapsed < time.Minute:
+		return "just now"
+	case elapsed < 0:
+		return "in " + span(-elapsed)
+	}
+	return span(elapsed) + " ago"
+}
+
+func dueAt(due, now time.Time) string {
+	if Absolute() {
+		return due.Local().Format(dateLayout)
+	}
+
+	days := calendarDays(now, due)
+	switch {
+	case days == 0:
+		return "due today"
+	case days == 1:
+		return "due tomorrow"
+	case days > 1:
+		return "due in " + span(time.Duration(days)*24*time.Hour)
+	}
+	return "overdue " + span(time.Duration(-days)*24*time.Hour)
+}
+
+func overdueAt(task *models.Task, now time.Time) bool {
+	return task.DueDate != nil && !task.Status.IsClosed() && task.DueDate.Before(now)
+}
+
+// calendarDays returns how many local calendar days to is after from.
+func calendarDays(from, to time.Time) int {
+	from, to = from.Local(), to.Local()
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start) / (24 * time.Hour))
+}
+
+// span formats a positive duration in its largest whole unit, such as
+// "45m", "3d" or "2mo".
+func span(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	case d < day:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d < 14*day:
+		return fmt.Sprintf("%dd", d/day)
+	case d < 60*day:
+		return fmt.Sprintf("%dw", d/(7*day))
+	case d < 365*day:
+		return fmt.Sprintf("%dmo", d/(30*day))
+	}
+	return fmt.Sprintf("%dy", d/(365*day))
+}
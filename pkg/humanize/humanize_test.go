@@ -0,0 +1,82 @@
+package humanize
+
+import (
+	"testing"
+	"time"
+
+	"opentask/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{name: "zero", t: time.Time{}, want: ""},
+		{name: "seconds", t: now.Add(-20 * time.Second), want: "just now"},
+		{name: "minutes", t: now.Add(-45 * time.Minute), want: "45m ago"},
+		{name: "hours", t: now.Add(-2 * time.Hour), want: "2h ago"},
+		{name: "days", t: now.AddDate(0, 0, -3), want: "3d ago"},
+		{name: "weeks", t: now.AddDate(0, 0, -21), want: "3w ago"},
+		{name: "months", t: now.AddDate(0, 0, -95), want: "3mo ago"},
+		{name: "years", t: now.AddDate(-2, 0, 0), want: "2y ago"},
+		{name: "future", t: now.Add(3 * time.Hour), want: "in 3h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, timeAt(tt.t, now))
+		})
+	}
+}
+
+func TestDue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+	endOfDay := func(days int) time.Time {
+		return time.Date(2026, 3, 10+days, 23, 59, 59, 0, time.Local)
+	}
+
+	tests := []struct {
+		name string
+		due  time.Time
+		want string
+	}{
+		{name: "today", due: endOfDay(0), want: "due today"},
+		{name: "tomorrow", due: endOfDay(1), want: "due tomorrow"},
+		{name: "days", due: endOfDay(3), want: "due in 3d"},
+		{name: "weeks", due: endOfDay(21), want: "due in 3w"},
+		{name: "yesterday", due: endOfDay(-1), want: "overdue 1d"},
+		{name: "overdue", due: endOfDay(-5), want: "overdue 5d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, dueAt(tt.due, now))
+		})
+	}
+}
+
+func TestAbsolute(t *testing.T) {
+	SetAbsolute(true)
+	defer SetAbsolute(false)
+
+	at := time.Date(2026, 3, 10, 9, 30, 0, 0, time.Local)
+	assert.Equal(t, "2026-03-10 09:30", timeAt(at, at.Add(time.Hour)))
+	assert.Equal(t, "2026-03-10", dueAt(at, at.AddDate(0, 0, -2)))
+}
+
+func TestOverdue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	assert.True(t, overdueAt(&models.Task{Status: models.StatusOpen, DueDate: &past}, now))
+	assert.False(t, overdueAt(&models.Task{Status: models.StatusDone, DueDate: &past}, now))
+	assert.False(t, overdueAt(&models.Task{Status: models.StatusOpen, DueDate: &future}, now))
+	assert.False(t, overdueAt(&models.Task{Status: models.StatusOpen}, now))
+}
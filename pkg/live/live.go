@@ -0,0 +1,162 @@
+// Package live passes task changes from the daemon to the terminal apps
+// running on the same machine, over a Unix socket in the data directory,
+// so they can show a change without reloading every task.
+package live
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+)
+
+// SocketFile is the name of the socket in the data directory.
+const SocketFile = "live.sock"
+
+// writeTimeout bounds how long a slow subscriber may hold up an update.
+const writeTimeout = time.Second
+
+// SocketPath returns the socket in the data directory dir.
+func SocketPath(dir string) string {
+	return filepath.Join(dir, SocketFile)
+}
+
+// Update is a change to one task, sent as a line of JSON.
+type Update struct {
+	// Platform is the configured platform name.
+	Platform string                  `json:"platform"`
+	Action   platforms.WebhookAction `json:"action"`
+	TaskID   string                  `json:"task_id"`
+	// Task is the task as it is now; it is absent for deleted tasks.
+	Task *models.Task `json:"task,omitempty"`
+	At   time.Time    `json:"at"`
+}
+
+// Broadcaster sends updates to every subscriber connected to its socket.
+type Broadcaster struct {
+	listener net.Listener
+	path     string
+
+	mu    sync.Mutex
+	conns map[net.Conn]bool
+}
+
+// Listen creates the socket at path. A socket left behind by a daemon that
+// did not stop cleanly is replaced; one a running daemon listens on is an
+// error.
+func Listen(path string) (*Broadcaster, error) {
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("%s is in use by another daemon", path)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	b := &Broadcaster{listener: listener, path: path, conns: make(map[net.Conn]bool)}
+	go b.accept()
+	return b, nil
+}
+
+func (b *Broadcaster) accept() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		b.mu.Lock()
+		b.conns[conn] = true
+		b.mu.Unlock()
+	}
+}
+
+// Publish sends update to every subscriber. Subscribers that cannot take
+// it are dropped.
+func (b *Broadcaster) Publish(update Update) {
+	data, err := json.Marshal(update)
+	if err != nil {
+		log.Printf("⚠ Failed to encode update for %s: %v", update.TaskID, err)
+		return
+	}
+	data = append(data, '\n')
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for conn := range b.conns {
+		conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if _, err := conn.Write(data); err != nil {
+			conn.Close()
+			delete(b.conns, conn)
+		}
+	}
+}
+
+// Subscribers returns the number of connected subscribers.
+func (b *Broadcaster) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.conns)
+}
+
+// Close disconnects the subscribers and removes the socket.
+func (b *Broadcaster) Close() error {
+	err := b.listener.Close()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for conn := range b.conns {
+		conn.Close()
+		delete(b.conns, conn)
+	}
+	os.Remove(b.path)
+	return err
+}
+
+// Subscribe connects to the socket at path and calls fn with every update
+// until ctx is cancelled or the daemon goes away. It fails at once when no
+// daemon is listening.
+func Subscribe(ctx context.Context, path string, fn func(Update)) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", path)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var update Update
+		if err := json.Unmarshal(scanner.Bytes(), &update); err != nil {
+			continue
+		}
+		fn(update)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return scanner.Err()
+}
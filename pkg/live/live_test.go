@@ -0,0 +1,73 @@
+package live
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcaster(t *testing.T) {
+	// Unix socket paths are short; t.TempDir() can be too long on macOS.
+	dir, err := os.MkdirTemp("", "live")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := SocketPath(dir)
+
+	b, err := Listen(path)
+	require.NoError(t, err)
+	defer b.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := make(chan Update, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- Subscribe(ctx, path, func(update Update) { updates <- update })
+	}()
+	require.Eventually(t, func() bool { return b.Subscribers() == 1 }, time.Second, 10*time.Millisecond)
+
+	b.Publish(Update{Platform: "jira", Action: platforms.WebhookUpdated, TaskID: "TEST-1", Task: &models.Task{ID: "TEST-1", Title: "Fix login"}})
+	select {
+	case update := <-updates:
+		assert.Equal(t, "jira", update.Platform)
+		assert.Equal(t, platforms.WebhookUpdated, update.Action)
+		require.NotNil(t, update.Task)
+		assert.Equal(t, "Fix login", update.Task.Title)
+	case <-time.After(time.Second):
+		t.Fatal("no update received")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("Subscribe did not return")
+	}
+
+	_, err = Listen(path)
+	assert.ErrorContains(t, err, "in use")
+}
+
+func TestListen_ReplacesStaleSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "live")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := SocketPath(dir)
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+
+	b, err := Listen(path)
+	require.NoError(t, err)
+	require.NoError(t, b.Close())
+	assert.NoFileExists(t, path)
+
+	err = Subscribe(context.Background(), path, func(Update) {})
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+// Package logging provides the logger behind --verbose and --debug.
+// Verbose output describes the operations a command runs against each
+// platform; debug output adds every HTTP request. Nothing is logged by
+// default.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync/atomic"
+)
+
+// Options decide what is logged and where to.
+type Options struct {
+	// Verbose logs platform operations; Debug adds HTTP requests.
+	Verbose bool
+	Debug   bool
+	// File receives the log, as JSON lines, instead of stderr. A log file
+	// without Verbose or Debug logs operations.
+	File string
+}
+
+var current atomic.Pointer[slog.Logger]
+
+func init() {
+	current.Store(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// Logger returns the logger commands and platform clients log to.
+func Logger() *slog.Logger {
+	return current.Load()
+}
+
+// SetLogger replaces the logger returned by Logger.
+func SetLogger(logger *slog.Logger) {
+	current.Store(logger)
+}
+
+// Setup installs the logger described by opts. The returned function
+// closes the log file, if any.
+func Setup(opts Options) (func() error, error) {
+	level := slog.LevelWarn
+	switch {
+	case opts.Debug:
+		level = slog.LevelDebug
+	case opts.Verbose || opts.File != "":
+		level = slog.LevelInfo
+	}
+	handlerOpts := &slog.HandlerOptions{Level: level, ReplaceAttr: redactAttr}
+
+	if opts.File == "" {
+		SetLogger(slog.New(slog.NewTextHandler(os.Stderr, handlerOpts)))
+		return func() error { return nil }, nil
+	}
+
+	f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	SetLogger(slog.New(slog.NewJSONHandler(f, handlerOpts)))
+	return f.Close, nil
+}
@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// minSecretLength is the shortest secret Redact hides; shorter values would
+// hide unrelated text.
+const minSecretLength = 4
+
+var (
+	secretsMu sync.RWMutex
+	secrets   []string
+)
+
+// AddSecrets makes Redact hide each secret, such as the credentials of the
+// configured platforms, wherever it appears.
+func AddSecrets(add ...string) {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+
+	seen := make(map[string]bool, len(secrets))
+	for _, secret := range secrets {
+		seen[secret] = true
+	}
+	for _, secret := range add {
+		secret = strings.TrimSpace(secret)
+		if len(secret) < minSecretLength || seen[secret] {
+			continue
+		}
+		seen[secret] = true
+		secrets = append(secrets, secret)
+	}
+	// Longest first, so a secret is hidden before any secret it contains.
+	sort.SliceStable(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+}
+
+// Redact returns s with every secret given to AddSecrets hidden.
+func Redact(s string) string {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+// RedactError returns err with its message passed through Redact. Errors
+// and Unwrap still see the original error.
+func RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &redactedError{err: err}
+}
+
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return Redact(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactAttr is the slog ReplaceAttr function of the loggers Setup
+// installs. It hides secrets in the message and in every string, error
+// and Stringer logged.
+func redactAttr(groups []string, attr slog.Attr) slog.Attr {
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		attr.Value = slog.StringValue(Redact(attr.Value.String()))
+	case slog.KindAny:
+		switch v := attr.Value.Any().(type) {
+		case error:
+			attr.Value = slog.StringValue(Redact(v.Error()))
+		case fmt.Stringer:
+			attr.Value = slog.StringValue(Redact(v.String()))
+		}
+	}
+	return attr
+}
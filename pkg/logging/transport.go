@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redacted replaces credentials in logged requests.
+const redacted = "[redacted]"
+
+// sensitiveParams are parts of the names of query parameters whose values
+// are never logged, and sensitiveNames whole names.
+var (
+	sensitiveParams = []string{"token", "secret", "password", "signature"}
+	sensitiveNames  = map[string]bool{"key": true, "apikey": true, "api_key": true, "code": true}
+)
+
+type transport struct {
+	logger   *slog.Logger
+	platform string
+	base     http.RoundTripper
+}
+
+// Transport wraps base so every request is logged at debug level with its
+// method, URL, status and duration. Credentials in the Authorization header
+// and the query string are redacted. A nil logger logs to Logger() and a
+// nil base uses http.DefaultTransport.
+func Transport(logger *slog.Logger, platform string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{logger: logger, platform: platform, base: base}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	logger := t.logger
+	if logger == nil {
+		logger = Logger()
+	}
+	if !logger.Enabled(req.Context(), slog.LevelDebug) {
+		return t.base.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	attrs := []slog.Attr{
+		slog.String("platform", t.platform),
+		slog.String("method", req.Method),
+		slog.String("url", RedactURL(req.URL)),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		attrs = append(attrs, slog.String("auth", RedactAuthorization(auth)))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	} else {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	logger.LogAttrs(req.Context(), slog.LevelDebug, "http request", attrs...)
+
+	return resp, err
+}
+
+// RedactAuthorization keeps the scheme of an Authorization header value,
+// such as "Basic", and hides the credentials.
+func RedactAuthorization(value string) string {
+	scheme, _, found := strings.Cut(value, " ")
+	if !found {
+		return redacted
+	}
+	return scheme + " " + redacted
+}
+
+// RedactURL returns u without a password and with the values of query
+// parameters that look like credentials hidden.
+func RedactURL(u *url.URL) string {
+	clean := *u
+	if clean.User != nil {
+		clean.User = url.User(clean.User.Username())
+	}
+
+	query := clean.Query()
+	changed := false
+	for name := range query {
+		if sensitiveParam(name) {
+			query[name] = []string{redacted}
+			changed = true
+		}
+	}
+	if changed {
+		clean.RawQuery = query.Encode()
+	}
+	return clean.String()
+}
+
+func sensitiveParam(name string) bool {
+	lower := strings.ToLower(name)
+	if sensitiveNames[lower] {
+		return true
+	}
+	for _, sensitive := range sensitiveParams {
+		if strings.Contains(lower, sensitive) {
+			return true
+		}
+	}
+	return false
+}
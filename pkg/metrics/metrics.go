@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"time"
+
+	"opentask/pkg/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "opentask"
+
+// TaskMetrics exposes backlog health gauges derived from the latest task refresh.
+type TaskMetrics struct {
+	openTasks     *prometheus.GaugeVec
+	overdueTasks  *prometheus.GaugeVec
+	lastRefresh   prometheus.Gauge
+	refreshErrors *prometheus.CounterVec
+}
+
+func NewTaskMetrics(reg prometheus.Registerer) *TaskMetrics {
+	m := &TaskMetrics{
+		openTasks: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "open_tasks",
+			Help:      "Number of tasks that are not done or cancelled.",
+		}, []string{"platform", "project", "priority"}),
+		overdueTasks: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "overdue_tasks",
+			Help:      "Number of open tasks whose due date has passed.",
+		}, []string{"platform", "project"}),
+		lastRefresh: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "last_refresh_timestamp_seconds",
+			Help:      "Unix time of the last successful task refresh.",
+		}),
+		refreshErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "refresh_errors_total",
+			Help:      "Number of failed task refreshes per platform.",
+		}, []string{"platform"}),
+	}
+
+	reg.MustRegister(m.openTasks, m.overdueTasks, m.lastRefresh, m.refreshErrors)
+	return m
+}
+
+// Observe replaces all gauge values with counts computed from tasks.
+func (m *TaskMetrics) Observe(tasks []*models.Task, now time.Time) {
+	m.openTasks.Reset()
+	m.overdueTasks.Reset()
+
+	for _, task := range tasks {
+		if task.Status.IsClosed() {
+			continue
+		}
+
+		platform := task.Platform.String()
+		m.openTasks.WithLabelValues(platform, task.ProjectID, task.Priority.String()).Inc()
+
+		if task.DueDate != nil && task.DueDate.Before(now) {
+			m.overdueTasks.WithLabelValues(platform, task.ProjectID).Inc()
+		}
+	}
+
+	m.lastRefresh.Set(float64(now.Unix()))
+}
+
+func (m *TaskMetrics) RefreshFailed(platform string) {
+	m.refreshErrors.WithLabelValues(platform).Inc()
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"opentask/pkg/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskMetrics_Observe(t *testing.T) {
+	m := NewTaskMetrics(prometheus.NewRegistry())
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	yesterday := now.AddDate(0, 0, -1)
+	tomorrow := now.AddDate(0, 0, 1)
+
+	task := func(platform models.Platform, project string, status models.TaskStatus, priority models.Priority, due *time.Time) *models.Task {
+		return &models.Task{Platform: platform, ProjectID: project, Status: status, Priority: priority, DueDate: due}
+	}
+
+	m.Observe([]*models.Task{
+		task(models.PlatformJira, "API", models.StatusOpen, models.PriorityHigh, &yesterday),
+		task(models.PlatformJira, "API", models.StatusInProgress, models.PriorityHigh, &tomorrow),
+		task(models.PlatformJira, "API", models.StatusDone, models.PriorityHigh, &yesterday),
+		task(models.PlatformLinear, "ENG", models.StatusOpen, models.PriorityLow, nil),
+		task(models.PlatformLinear, "ENG", models.StatusCancelled, models.PriorityLow, nil),
+	}, now)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.openTasks.WithLabelValues("jira", "API", "high")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.openTasks.WithLabelValues("linear", "ENG", "low")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.overdueTasks.WithLabelValues("jira", "API")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.openTasks))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.overdueTasks))
+	assert.Equal(t, float64(now.Unix()), testutil.ToFloat64(m.lastRefresh))
+
+	// A refresh replaces the counts: series without tasks are dropped
+	// rather than left at their last value.
+	later := now.Add(time.Hour)
+	m.Observe([]*models.Task{
+		task(models.PlatformLinear, "ENG", models.StatusOpen, models.PriorityUrgent, nil),
+	}, later)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(m.openTasks))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.openTasks.WithLabelValues("linear", "ENG", "urgent")))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.overdueTasks))
+	assert.Equal(t, float64(later.Unix()), testutil.ToFloat64(m.lastRefresh))
+}
+
+func TestTaskMetrics_RefreshFailed(t *testing.T) {
+	m := NewTaskMetrics(prometheus.NewRegistry())
+	m.RefreshFailed("jira")
+	m.RefreshFailed("jira")
+	m.Observe(nil, time.Now())
+
+	// Failures are counted across refreshes.
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.refreshErrors.WithLabelValues("jira")))
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RequestMetrics counts the operations the service layer runs against each
+// platform and the API requests its clients send, with their latencies and
+// the error codes they fail with.
+type RequestMetrics struct {
+	requests          *prometheus.CounterVec
+	requestDuration   *prometheus.HistogramVec
+	operations        *prometheus.CounterVec
+	operationDuration *prometheus.HistogramVec
+	operationErrors   *prometheus.CounterVec
+}
+
+func NewRequestMetrics(reg prometheus.Registerer) *RequestMetrics {
+	m := &RequestMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "platform_requests_total",
+			Help:      "Number of API requests sent to each platform, by method and HTTP status. Requests that got no response have status \"error\".",
+		}, []string{"platform", "method", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "platform_request_duration_seconds",
+			Help:      "Time until a platform answered an API request.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"platform", "method"}),
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "operations_total",
+			Help:      "Number of service operations, such as listing tasks, run against each platform.",
+		}, []string{"operation", "platform"}),
+		operationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "operation_duration_seconds",
+			Help:      "Time a service operation took on each platform, including every request it sent.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "platform"}),
+		operationErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "operation_errors_total",
+			Help:      "Number of failed service operations per platform, by error code.",
+		}, []string{"operation", "platform", "code"}),
+	}
+
+	reg.MustRegister(m.requests, m.requestDuration, m.operations, m.operationDuration, m.operationErrors)
+	return m
+}
+
+// installed holds the metrics the service layer and platform clients
+// record to. Nothing is recorded until Install is called, so commands other
+// than serve pay nothing for it.
+var installed atomic.Pointer[RequestMetrics]
+
+// Install makes m the metrics operations and requests are recorded to. A
+// nil m stops recording.
+func Install(m *RequestMetrics) {
+	installed.Store(m)
+}
+
+// ObserveOperation records an operation run against a platform. code is
+// the error code it failed with, or empty if it succeeded.
+func ObserveOperation(operation, platform string, elapsed time.Duration, code string) {
+	m := installed.Load()
+	if m == nil {
+		return
+	}
+	m.operations.WithLabelValues(operation, platform).Inc()
+	m.operationDuration.WithLabelValues(operation, platform).Observe(elapsed.Seconds())
+	if code != "" {
+		m.operationErrors.WithLabelValues(operation, platform, code).Inc()
+	}
+}
+
+// transport records each request to the installed metrics.
+type transport struct {
+	platform string
+	base     http.RoundTripper
+}
+
+// Transport wraps base so every platform API request is counted and timed
+// once metrics are installed. A nil base uses http.DefaultTransport.
+func Transport(platform string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{platform: platform, base: base}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	m := installed.Load()
+	if m == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	m.requestDuration.WithLabelValues(t.platform, req.Method).Observe(time.Since(start).Seconds())
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	m.requests.WithLabelValues(t.platform, req.Method, status).Inc()
+	return resp, err
+}
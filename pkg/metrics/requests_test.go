@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transport("jira", nil)}
+
+	// Nothing is recorded before metrics are installed.
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	m := NewRequestMetrics(prometheus.NewRegistry())
+	Install(m)
+	defer Install(nil)
+
+	for _, path := range []string{"/", "/", "/missing"} {
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	server.Close()
+	_, err = client.Get(server.URL)
+	require.Error(t, err)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.requests.WithLabelValues("jira", "GET", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("jira", "GET", "404")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("jira", "GET", "error")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.requestDuration))
+}
+
+func TestObserveOperation(t *testing.T) {
+	ObserveOperation("list tasks", "jira", time.Second, "")
+
+	m := NewRequestMetrics(prometheus.NewRegistry())
+	Install(m)
+	defer Install(nil)
+
+	ObserveOperation("list tasks", "jira", time.Second, "")
+	ObserveOperation("list tasks", "jira", 2*time.Second, "rate_limited")
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.operations.WithLabelValues("list tasks", "jira")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.operationErrors.WithLabelValues("list tasks", "jira", "rate_limited")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.operationErrors))
+}
@@ -10,6 +10,7 @@ type Project struct {
 	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
 	Key         string            `json:"key,omitempty" yaml:"key,omitempty"`
 	Platform    Platform          `json:"platform" yaml:"platform"`
+	Lead        *User             `json:"lead,omitempty" yaml:"lead,omitempty"`
 	Active      bool              `json:"active" yaml:"active"`
 	CreatedAt   time.Time         `json:"created_at" yaml:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at" yaml:"updated_at"`
@@ -0,0 +1,28 @@
+package models
+
+import "strings"
+
+// refSeparator separates the platform from the task ID in a reference.
+const refSeparator = ":"
+
+// Ref returns the canonical reference of the task, such as
+// "jira:TEST-123": its platform and ID. Commands accept it wherever they
+// take a task ID.
+func (t *Task) Ref() string {
+	return FormatRef(string(t.Platform), t.ID)
+}
+
+// FormatRef returns the reference of task id on platform.
+func FormatRef(platform, id string) string {
+	return platform + refSeparator + id
+}
+
+// ParseRef splits a reference such as "linear:ENG-45" into the platform
+// name and task ID. A bare ID is returned with an empty platform.
+func ParseRef(ref string) (platform, id string) {
+	platform, id, ok := strings.Cut(ref, refSeparator)
+	if !ok || platform == "" || id == "" {
+		return "", ref
+	}
+	return platform, id
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
@@ -42,6 +43,11 @@ func (ts TaskStatus) IsValid() bool {
 	}
 }
 
+// IsClosed reports whether the status represents finished work.
+func (ts TaskStatus) IsClosed() bool {
+	return ts == StatusDone || ts == StatusCancelled
+}
+
 type Priority string
 
 const (
@@ -71,6 +77,8 @@ const (
 	PlatformJira   Platform = "jira"
 	PlatformSlack  Platform = "slack"
 	PlatformGitHub Platform = "github"
+	// PlatformMock keeps sample tasks on this machine, for demos and tests.
+	PlatformMock Platform = "mock"
 )
 
 func (p Platform) String() string {
@@ -79,13 +87,17 @@ func (p Platform) String() string {
 
 func (p Platform) IsValid() bool {
 	switch p {
-	case PlatformLinear, PlatformJira, PlatformSlack, PlatformGitHub:
+	case PlatformLinear, PlatformJira, PlatformSlack, PlatformGitHub, PlatformMock:
 		return true
 	default:
 		return false
 	}
 }
 
+// AssigneeMe as TaskFilter.Assignee stands for the user each platform is
+// authenticated as.
+const AssigneeMe = "me"
+
 type TaskFilter struct {
 	Platform  *Platform   `json:"platform,omitempty"`
 	Status    *TaskStatus `json:"status,omitempty"`
@@ -96,6 +108,75 @@ type TaskFilter struct {
 	Query     string      `json:"query,omitempty"`
 	Limit     int         `json:"limit,omitempty"`
 	Offset    int         `json:"offset,omitempty"`
+	// UpdatedSince limits results to tasks updated at or after it.
+	UpdatedSince *time.Time `json:"updated_since,omitempty"`
+	// UpdatedBefore limits results to tasks last updated before it.
+	UpdatedBefore *time.Time `json:"updated_before,omitempty"`
+	// DefaultProjects narrows the tasks of each platform type, such as
+	// "jira", to a project when ProjectID is empty, so tasks of several
+	// platforms can be filtered locally by each one's default project.
+	DefaultProjects map[string]string `json:"default_projects,omitempty"`
+}
+
+// Matches reports whether the task satisfies the filter locally. Limit and
+// Offset are ignored; they apply to result sets, not individual tasks.
+func (f *TaskFilter) Matches(task *Task) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.Platform != nil && task.Platform != *f.Platform {
+		return false
+	}
+
+	if f.Status != nil && task.Status != *f.Status {
+		return false
+	}
+
+	if f.Priority != nil && task.Priority != *f.Priority {
+		return false
+	}
+
+	if f.ProjectID != "" && !strings.EqualFold(task.ProjectID, f.ProjectID) {
+		return false
+	}
+	if project, ok := f.DefaultProjects[string(task.Platform)]; ok && f.ProjectID == "" && !strings.EqualFold(task.ProjectID, project) {
+		return false
+	}
+
+	for _, label := range f.Labels {
+		if !task.HasLabel(label) {
+			return false
+		}
+	}
+
+	if f.UpdatedSince != nil && task.UpdatedAt.Before(*f.UpdatedSince) {
+		return false
+	}
+	if f.UpdatedBefore != nil && !task.UpdatedAt.Before(*f.UpdatedBefore) {
+		return false
+	}
+
+	if f.Assignee != "" {
+		if task.Assignee == nil {
+			return false
+		}
+		a := task.Assignee
+		if !strings.EqualFold(a.ID, f.Assignee) && !strings.EqualFold(a.Email, f.Assignee) &&
+			!strings.EqualFold(a.Name, f.Assignee) && !strings.EqualFold(a.Username, f.Assignee) {
+			return false
+		}
+	}
+
+	if f.Query != "" {
+		query := strings.ToLower(f.Query)
+		if !strings.Contains(strings.ToLower(task.Title), query) &&
+			!strings.Contains(strings.ToLower(task.Description), query) {
+			return false
+		}
+	}
+
+	return true
 }
 
 func NewTask(title string, platform Platform) *Task {
@@ -145,6 +226,15 @@ func (t *Task) AddLabel(label string) {
 	t.UpdatedAt = time.Now()
 }
 
+func (t *Task) HasLabel(label string) bool {
+	for _, existing := range t.Labels {
+		if strings.EqualFold(existing, label) {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *Task) RemoveLabel(label string) {
 	if t.Labels == nil {
 		return
@@ -173,4 +263,31 @@ func (t *Task) GetMetadata(key string) (any, bool) {
 	}
 	value, exists := t.Metadata[key]
 	return value, exists
+}
+
+// URL returns the web page of the task, if the platform exposes one.
+func (t *Task) URL() string {
+	if url, ok := t.GetMetadata("linear_url"); ok {
+		if s, ok := url.(string); ok && s != "" {
+			return s
+		}
+	}
+
+	// Sites connected through OAuth know their own URL.
+	if url, ok := t.GetMetadata("jira_url"); ok {
+		if s, ok := url.(string); ok && s != "" {
+			return s
+		}
+	}
+
+	// Jira only returns the REST self link; derive the browse URL from it.
+	if self, ok := t.GetMetadata("jira_self"); ok {
+		if s, ok := self.(string); ok {
+			if i := strings.Index(s, "/rest/api/"); i > 0 {
+				return s[:i] + "/browse/" + t.ID
+			}
+		}
+	}
+
+	return ""
 }
\ No newline at end of file
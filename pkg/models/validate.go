@@ -0,0 +1,150 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits checked by Validate. Jira allows 255 characters in a summary and
+// a label, which is also within what the other platforms accept.
+const (
+	MaxTitleLength = 255
+	MaxLabelLength = 255
+	MaxLabels      = 100
+)
+
+// FieldError is a field whose value is not valid, such as "title" or
+// "labels[2]".
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// ValidationError lists every field of a task, project or user that is
+// not valid, so they can be fixed at once instead of one platform error at
+// a time.
+type ValidationError struct {
+	Kind   string       `json:"kind"`
+	Fields []FieldError `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+	return fmt.Sprintf("invalid %s: %s", e.Kind, strings.Join(messages, "; "))
+}
+
+// validation collects the field errors of one value.
+type validation struct {
+	kind   string
+	fields []FieldError
+}
+
+func (v *validation) add(field, format string, args ...any) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validation) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Kind: v.kind, Fields: v.fields}
+}
+
+// Validate checks the task before it is created or updated: it needs a
+// title of at most MaxTitleLength characters, a known status and priority
+// when they are set, and labels that are non-empty, unique, free
+// of whitespace at either end and at most MaxLabelLength characters.
+func (t *Task) Validate() error {
+	v := &validation{kind: "task"}
+
+	title := strings.TrimSpace(t.Title)
+	switch {
+	case title == "":
+		v.add("title", "is required")
+	case utf8.RuneCountInString(t.Title) > MaxTitleLength:
+		v.add("title", "is %d characters long; the limit is %d", utf8.RuneCountInString(t.Title), MaxTitleLength)
+	}
+
+	if t.Status != "" && !t.Status.IsValid() {
+		v.add("status", "%q is not one of open, in_progress, done, cancelled", t.Status)
+	}
+	if t.Priority != "" && !t.Priority.IsValid() {
+		v.add("priority", "%q is not one of low, medium, high, urgent", t.Priority)
+	}
+	// The platform is not checked: tasks carry the configured platform
+	// name, such as "jira-acme" for a second Jira site, not its type.
+
+	if len(t.Labels) > MaxLabels {
+		v.add("labels", "has %d labels; the limit is %d", len(t.Labels), MaxLabels)
+	}
+	seen := make(map[string]bool, len(t.Labels))
+	for i, label := range t.Labels {
+		field := fmt.Sprintf("labels[%d]", i)
+		switch {
+		case strings.TrimSpace(label) == "":
+			v.add(field, "is empty")
+		case strings.TrimSpace(label) != label:
+			v.add(field, "%q starts or ends with whitespace", label)
+		case utf8.RuneCountInString(label) > MaxLabelLength:
+			v.add(field, "is %d characters long; the limit is %d", utf8.RuneCountInString(label), MaxLabelLength)
+		case seen[label]:
+			v.add(field, "%q is given more than once", label)
+		}
+		seen[label] = true
+	}
+
+	if t.Assignee != nil {
+		for _, field := range t.Assignee.fieldErrors() {
+			v.add("assignee."+field.Field, "%s", field.Message)
+		}
+	}
+	return v.err()
+}
+
+// Validate checks that the project has an ID, key or name and, when set, a
+// known platform.
+func (p *Project) Validate() error {
+	v := &validation{kind: "project"}
+	if p.ID == "" && p.Key == "" && strings.TrimSpace(p.Name) == "" {
+		v.add("id", "or key or name is required")
+	}
+	if p.Platform != "" && !p.Platform.IsValid() {
+		v.add("platform", "%q is not a known platform", p.Platform)
+	}
+	if p.Lead != nil {
+		for _, field := range p.Lead.fieldErrors() {
+			v.add("lead."+field.Field, "%s", field.Message)
+		}
+	}
+	return v.err()
+}
+
+// Validate checks that the user can be told apart by an ID, username,
+// email or name, that the email looks like one and that the platform, when
+// set, is known.
+func (u *User) Validate() error {
+	v := &validation{kind: "user", fields: u.fieldErrors()}
+	return v.err()
+}
+
+func (u *User) fieldErrors() []FieldError {
+	v := &validation{}
+	if u.ID == "" && u.Username == "" && u.Email == "" && strings.TrimSpace(u.Name) == "" {
+		v.add("id", "or username, email or name is required")
+	}
+	if at := strings.Index(u.Email, "@"); u.Email != "" && (at <= 0 || at == len(u.Email)-1) {
+		v.add("email", "%q is not an email address", u.Email)
+	}
+	if u.Platform != "" && !u.Platform.IsValid() {
+		v.add("platform", "%q is not a known platform", u.Platform)
+	}
+	return v.fields
+}
@@ -0,0 +1,12 @@
+package models
+
+// WorkflowState is a column or state in a project's workflow on its
+// platform, together with the unified status it maps to.
+type WorkflowState struct {
+	ID       string     `json:"id" yaml:"id"`
+	Name     string     `json:"name" yaml:"name"`
+	Category string     `json:"category,omitempty" yaml:"category,omitempty"`
+	Scope    string     `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Status   TaskStatus `json:"status" yaml:"status"`
+	Mapped   bool       `json:"mapped" yaml:"mapped"`
+}
@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"opentask/pkg/models"
+	"opentask/pkg/recurring"
+)
+
+// Digest is a summary of open tasks sent on a schedule.
+type Digest struct {
+	Name string
+	// At is the scheduled time the digest is sent for.
+	At    time.Time
+	Tasks []*models.Task
+}
+
+// DigestSender sends digests.
+type DigestSender interface {
+	Name() string
+	SendDigest(ctx context.Context, digest Digest) error
+}
+
+// DigestState remembers when each digest was last sent.
+type DigestState interface {
+	LastDigest(name string) time.Time
+	MarkDigest(name string, at time.Time)
+}
+
+// DigestRule sends a digest of the open tasks its filter matches whenever
+// its schedule comes round.
+type DigestRule struct {
+	Name     string
+	Schedule *recurring.Schedule
+	// DueWithin, when set, narrows the digest to tasks due within it of
+	// the scheduled time.
+	DueWithin time.Duration
+	Filter    Filter
+	Sender    DigestSender
+}
+
+// Select returns the open tasks of each platform, keyed by configured
+// name, that the rule lists at time at, soonest due first.
+func (r DigestRule) Select(tasks map[string][]*models.Task, at time.Time) []*models.Task {
+	var selected []*models.Task
+	for name, list := range tasks {
+		for _, task := range list {
+			if task.Status.IsClosed() || !r.Filter.MatchTask(name, task) {
+				continue
+			}
+			if r.DueWithin > 0 && (task.DueDate == nil || !task.DueDate.Before(at.Add(r.DueWithin))) {
+				continue
+			}
+			selected = append(selected, task)
+		}
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		a, b := selected[i], selected[j]
+		if (a.DueDate == nil) != (b.DueDate == nil) {
+			return a.DueDate != nil
+		}
+		if a.DueDate != nil && !a.DueDate.Equal(*b.DueDate) {
+			return a.DueDate.Before(*b.DueDate)
+		}
+		if a.Platform != b.Platform {
+			return a.Platform < b.Platform
+		}
+		return a.ID < b.ID
+	})
+	return selected
+}
+
+// HasDigests reports whether any digest is configured.
+func (d *Dispatcher) HasDigests() bool {
+	return len(d.digests) > 0
+}
+
+// SendDigests sends the digests whose schedule came round since they were
+// last sent, listing tasks, and records each in state. A digest seen for
+// the first time is only recorded, so starting the daemon does not send
+// every digest at once. Digests without tasks are not sent.
+func (d *Dispatcher) SendDigests(ctx context.Context, state DigestState, tasks map[string][]*models.Task, now time.Time) []Failure {
+	var failures []Failure
+	for _, rule := range d.digests {
+		last := state.LastDigest(rule.Name)
+		if last.IsZero() {
+			state.MarkDigest(rule.Name, now)
+			continue
+		}
+		at := rule.Schedule.Latest(last, now)
+		if at.IsZero() {
+			continue
+		}
+
+		digest := Digest{Name: rule.Name, At: at, Tasks: rule.Select(tasks, at)}
+		if len(digest.Tasks) > 0 {
+			if err := rule.Sender.SendDigest(ctx, digest); err != nil {
+				failures = append(failures, Failure{Notifier: rule.Sender.Name(), Digest: rule.Name, Err: err})
+				continue
+			}
+		}
+		state.MarkDigest(rule.Name, at)
+	}
+	return failures
+}
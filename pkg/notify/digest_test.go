@@ -0,0 +1,143 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+	"opentask/pkg/recurring"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type digestRecorder struct {
+	err     error
+	digests []Digest
+}
+
+func (d *digestRecorder) Name() string { return "digest" }
+
+func (d *digestRecorder) SendDigest(ctx context.Context, digest Digest) error {
+	d.digests = append(d.digests, digest)
+	return d.err
+}
+
+type digestState map[string]time.Time
+
+func (s digestState) LastDigest(name string) time.Time     { return s[name] }
+func (s digestState) MarkDigest(name string, at time.Time) { s[name] = at }
+
+func date(day, hour int) time.Time {
+	return time.Date(2024, 6, day, hour, 0, 0, 0, time.UTC)
+}
+
+func dueOn(day int) *time.Time {
+	due := date(day, 0)
+	return &due
+}
+
+func TestDigestRule_Select(t *testing.T) {
+	tasks := map[string][]*models.Task{
+		"jira": {
+			{ID: "TEST-1", Status: models.StatusOpen, ProjectID: "TEST", DueDate: dueOn(4)},
+			{ID: "TEST-2", Status: models.StatusOpen, ProjectID: "TEST", DueDate: dueOn(2)},
+			{ID: "TEST-3", Status: models.StatusDone, ProjectID: "TEST", DueDate: dueOn(2)},
+			{ID: "TEST-4", Status: models.StatusOpen, ProjectID: "TEST"},
+			{ID: "OPS-1", Status: models.StatusOpen, ProjectID: "OPS", DueDate: dueOn(1)},
+		},
+	}
+
+	ids := func(tasks []*models.Task) []string {
+		var ids []string
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	all := DigestRule{Name: "open"}
+	assert.Equal(t, []string{"OPS-1", "TEST-2", "TEST-1", "TEST-4"}, ids(all.Select(tasks, date(1, 8))))
+
+	dueTomorrow := DigestRule{Name: "due-tomorrow", DueWithin: 24 * time.Hour, Filter: Filter{Projects: []string{"TEST"}}}
+	assert.Equal(t, []string{"TEST-2"}, ids(dueTomorrow.Select(tasks, date(1, 8))))
+}
+
+func TestDispatcher_SendDigests(t *testing.T) {
+	schedule, err := recurring.Parse("0 8 * * *")
+	require.NoError(t, err)
+
+	sender := &digestRecorder{}
+	d := &Dispatcher{}
+	d.AddDigest(DigestRule{Name: "daily", Schedule: schedule, Sender: sender})
+	assert.True(t, d.HasDigests())
+
+	tasks := map[string][]*models.Task{"jira": {{ID: "TEST-1", Status: models.StatusOpen}}}
+	state := digestState{}
+
+	// The first run only records when the digest was first seen.
+	assert.Empty(t, d.SendDigests(context.Background(), state, tasks, date(1, 9)))
+	assert.Empty(t, sender.digests)
+	assert.Equal(t, date(1, 9), state["daily"])
+
+	assert.Empty(t, d.SendDigests(context.Background(), state, tasks, date(1, 20)))
+	assert.Empty(t, sender.digests)
+
+	// Missed runs send one digest, for the latest.
+	assert.Empty(t, d.SendDigests(context.Background(), state, tasks, date(3, 9)))
+	require.Len(t, sender.digests, 1)
+	assert.Equal(t, date(3, 8), sender.digests[0].At)
+	assert.Equal(t, date(3, 8), state["daily"])
+
+	// Failed digests are tried again on the next run.
+	sender.err = errors.New("connection refused")
+	failures := d.SendDigests(context.Background(), state, tasks, date(4, 9))
+	require.Len(t, failures, 1)
+	assert.Equal(t, "digest daily", failures[0].About())
+	assert.Equal(t, date(3, 8), state["daily"])
+
+	// Empty digests are not sent, but count as sent.
+	sender.err = nil
+	assert.Empty(t, d.SendDigests(context.Background(), state, nil, date(5, 9)))
+	assert.Len(t, sender.digests, 2)
+	assert.Equal(t, date(5, 8), state["daily"])
+}
+
+func TestFromConfig_Email(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Notifications.Email = config.EmailNotifications{
+		Host: "smtp.example.com",
+		From: "opentask@example.com",
+		Rules: []config.EmailRule{
+			{Name: "alerts", To: []string{"team@example.com"}, NotificationFilter: config.NotificationFilter{Events: []string{"created"}}},
+			{Name: "due-tomorrow", To: []string{"team@example.com"}, Schedule: "0 8 * * *", DueWithin: "1d"},
+		},
+	}
+
+	d, err := FromConfig(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 2, d.Len())
+	require.Len(t, d.digests, 1)
+	assert.Equal(t, 24*time.Hour, d.digests[0].DueWithin)
+
+	tests := []struct {
+		name string
+		rule config.EmailRule
+		want string
+	}{
+		{"due_within without schedule", config.EmailRule{Name: "x", To: []string{"a@example.com"}, DueWithin: "1d"}, "x: due_within only applies to digests"},
+		{"events on a digest", config.EmailRule{Name: "x", To: []string{"a@example.com"}, Schedule: "@daily", NotificationFilter: config.NotificationFilter{Events: []string{"created"}}}, "x: events only apply to alerts"},
+		{"bad schedule", config.EmailRule{Name: "x", To: []string{"a@example.com"}, Schedule: "daily"}, "x: invalid schedule"},
+		{"bad due_within", config.EmailRule{Name: "x", To: []string{"a@example.com"}, Schedule: "@daily", DueWithin: "soon"}, "x: invalid due_within"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.Notifications.Email.Rules = []config.EmailRule{tt.rule}
+			_, err := FromConfig(cfg)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"opentask/pkg/config"
+)
+
+// DefaultSMTPPort is the submission port used when none is configured.
+const DefaultSMTPPort = 587
+
+const (
+	defaultAlertSubject = `[OpenTask] {{.Heading}} {{.Task.ID}}: {{.Task.Title}}`
+	defaultAlertBody    = `{{.Heading}} {{.Task.ID}}: {{.Task.Title}}
+{{with .Task.URL}}{{.}}
+{{end}}
+Status: {{if .StatusChanged}}{{.Previous.Status}} → {{end}}{{.Task.Status}}
+Priority: {{or .Task.Priority "none"}}
+Assignee: {{with .Task.Assignee}}{{or .Name .Email}}{{else}}unassigned{{end}}
+`
+	defaultDigestSubject = `[OpenTask] {{.Name}}: {{len .Tasks}} task(s)`
+	defaultDigestBody    = `{{range .Tasks}}- {{.ID}} {{.Title}} ({{.Status}}{{with .DueDate}}, due {{.Format "2006-01-02"}}{{end}})
+{{with .URL}}  {{.}}
+{{end}}{{end}}`
+)
+
+// Email sends mail for one rule: an alert for each event, or a digest.
+type Email struct {
+	name    string
+	addr    string
+	auth    smtp.Auth
+	from    string
+	to      []string
+	subject *template.Template
+	body    *template.Template
+
+	// send delivers a message; tests replace smtp.SendMail.
+	send func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmail returns the sender of rule through server. Digests get the
+// digest templates as defaults, alerts the alert templates.
+func NewEmail(server config.EmailNotifications, rule config.EmailRule) (*Email, error) {
+	if server.Host == "" {
+		return nil, fmt.Errorf("no SMTP host configured")
+	}
+	from, err := mail.ParseAddress(server.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address %q: %w", server.From, err)
+	}
+	if len(rule.To) == 0 {
+		return nil, fmt.Errorf("no recipients configured")
+	}
+	for _, to := range rule.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return nil, fmt.Errorf("invalid recipient %q: %w", to, err)
+		}
+	}
+
+	subject, body := defaultAlertSubject, defaultAlertBody
+	if rule.Schedule != "" {
+		subject, body = defaultDigestSubject, defaultDigestBody
+	}
+	if rule.Subject != "" {
+		subject = rule.Subject
+	}
+	if rule.Body != "" {
+		body = rule.Body
+	}
+
+	e := &Email{
+		name: rule.Name,
+		from: from.Address,
+		to:   rule.To,
+		send: smtp.SendMail,
+	}
+	if e.subject, err = template.New("subject").Parse(subject); err != nil {
+		return nil, fmt.Errorf("invalid subject: %w", err)
+	}
+	if e.body, err = template.New("body").Parse(body); err != nil {
+		return nil, fmt.Errorf("invalid body: %w", err)
+	}
+
+	port := server.Port
+	if port == 0 {
+		port = DefaultSMTPPort
+	}
+	e.addr = net.JoinHostPort(server.Host, strconv.Itoa(port))
+	if server.Username != "" {
+		e.auth = smtp.PlainAuth("", server.Username, server.Password, server.Host)
+	}
+	return e, nil
+}
+
+func (e *Email) Name() string {
+	return e.name
+}
+
+// Notify sends the alert for event.
+func (e *Email) Notify(ctx context.Context, event Event) error {
+	return e.deliver(ctx, event)
+}
+
+// SendDigest sends digest.
+func (e *Email) SendDigest(ctx context.Context, digest Digest) error {
+	return e.deliver(ctx, digest)
+}
+
+// deliver renders the templates with data and sends the message. net/smtp
+// takes no context, so ctx is only checked before sending.
+func (e *Email) deliver(ctx context.Context, data any) error {
+	var subject, body bytes.Buffer
+	if err := e.subject.Execute(&subject, data); err != nil {
+		return fmt.Errorf("failed to render subject: %w", err)
+	}
+	if err := e.body.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to render body: %w", err)
+	}
+
+	msg, err := e.compose(subject.String(), body.String(), time.Now())
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return e.send(e.addr, e.auth, e.from, e.to, msg)
+}
+
+// compose builds a plain text message.
+func (e *Email) compose(subject, body string, date time.Time) ([]byte, error) {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", e.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.to, ", "))
+	// A subject is one line, however the template ends.
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", strings.Join(strings.Fields(subject), " ")))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&msg)
+	qp.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n")))
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sentMail struct {
+	addr string
+	from string
+	to   []string
+	msg  *mail.Message
+	body string
+}
+
+func newTestEmail(t *testing.T, rule config.EmailRule) (*Email, *[]sentMail) {
+	t.Helper()
+	server := config.EmailNotifications{Host: "smtp.example.com", Username: "bot", Password: "secret", From: "OpenTask <opentask@example.com>"}
+	email, err := NewEmail(server, rule)
+	require.NoError(t, err)
+
+	var sent []sentMail
+	email.send = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		m, err := mail.ReadMessage(strings.NewReader(string(msg)))
+		require.NoError(t, err)
+		body, err := io.ReadAll(quotedprintable.NewReader(m.Body))
+		require.NoError(t, err)
+		sent = append(sent, sentMail{addr: addr, from: from, to: to, msg: m, body: string(body)})
+		return nil
+	}
+	return email, &sent
+}
+
+func subject(t *testing.T, m sentMail) string {
+	t.Helper()
+	decoded, err := new(mime.WordDecoder).DecodeHeader(m.msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	return decoded
+}
+
+func TestEmail_Alert(t *testing.T) {
+	email, sent := newTestEmail(t, config.EmailRule{Name: "alerts", To: []string{"team@example.com"}})
+
+	event := Event{
+		Kind:     KindUpdated,
+		Platform: "jira",
+		Task: &models.Task{ID: "TEST-1", Title: "Fix login ✓", Status: models.StatusDone, Priority: models.PriorityHigh,
+			Assignee: &models.User{Name: "Alice"}, Metadata: map[string]any{"jira_url": "https://example.atlassian.net/browse/TEST-1"}},
+		Previous: &models.Task{ID: "TEST-1", Status: models.StatusInProgress},
+	}
+	require.NoError(t, email.Notify(context.Background(), event))
+
+	require.Len(t, *sent, 1)
+	m := (*sent)[0]
+	assert.Equal(t, "smtp.example.com:587", m.addr)
+	assert.Equal(t, "opentask@example.com", m.from)
+	assert.Equal(t, []string{"team@example.com"}, m.to)
+	assert.Equal(t, "[OpenTask] Updated TEST-1: Fix login ✓", subject(t, m))
+	assert.Equal(t, "Updated TEST-1: Fix login ✓\r\nhttps://example.atlassian.net/browse/TEST-1\r\n\r\nStatus: in_progress → done\r\nPriority: high\r\nAssignee: Alice\r\n", m.body)
+}
+
+func TestEmail_CustomTemplates(t *testing.T) {
+	email, sent := newTestEmail(t, config.EmailRule{
+		Name:    "alerts",
+		To:      []string{"a@example.com", "b@example.com"},
+		Subject: "{{.Task.ID}} is {{.Task.Status}}\n",
+		Body:    "{{.Task.Title}} on {{.Platform}}",
+	})
+
+	require.NoError(t, email.Notify(context.Background(), Event{Kind: KindSynced, Platform: "linear", Task: &models.Task{ID: "ENG-7", Title: "Docs", Status: models.StatusOpen}}))
+
+	m := (*sent)[0]
+	assert.Equal(t, "ENG-7 is open", subject(t, m))
+	assert.Equal(t, "a@example.com, b@example.com", m.msg.Header.Get("To"))
+	assert.Equal(t, "Docs on linear", m.body)
+}
+
+func TestEmail_Digest(t *testing.T) {
+	email, sent := newTestEmail(t, config.EmailRule{Name: "due-tomorrow", To: []string{"team@example.com"}, Schedule: "0 8 * * *"})
+
+	due := time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)
+	digest := Digest{Name: "due-tomorrow", Tasks: []*models.Task{
+		{ID: "TEST-1", Title: "Fix login", Status: models.StatusOpen, DueDate: &due, Metadata: map[string]any{"jira_url": "https://example.atlassian.net/browse/TEST-1"}},
+		{ID: "TEST-2", Title: "Docs", Status: models.StatusInProgress},
+	}}
+	require.NoError(t, email.SendDigest(context.Background(), digest))
+
+	m := (*sent)[0]
+	assert.Equal(t, "[OpenTask] due-tomorrow: 2 task(s)", subject(t, m))
+	assert.Equal(t, "- TEST-1 Fix login (open, due 2024-06-02)\r\n  https://example.atlassian.net/browse/TEST-1\r\n- TEST-2 Docs (in_progress)\r\n", m.body)
+}
+
+func TestNewEmail_Errors(t *testing.T) {
+	server := config.EmailNotifications{Host: "smtp.example.com", From: "opentask@example.com"}
+
+	tests := []struct {
+		name   string
+		server config.EmailNotifications
+		rule   config.EmailRule
+		want   string
+	}{
+		{"no host", config.EmailNotifications{From: "opentask@example.com"}, config.EmailRule{To: []string{"a@example.com"}}, "no SMTP host"},
+		{"bad from", config.EmailNotifications{Host: "smtp.example.com", From: "opentask"}, config.EmailRule{To: []string{"a@example.com"}}, "invalid from address"},
+		{"no recipients", server, config.EmailRule{}, "no recipients"},
+		{"bad recipient", server, config.EmailRule{To: []string{"team"}}, `invalid recipient "team"`},
+		{"bad template", server, config.EmailRule{To: []string{"a@example.com"}, Body: "{{.Task"}, "invalid body"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEmail(tt.server, tt.rule)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,315 @@
+// Package notify posts task events to the services configured under
+// notifications, such as a Slack channel or email, so a team hears about
+// tasks created and changed through OpenTask or picked up by the daemon,
+// and sends scheduled digests of open tasks.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+	"opentask/pkg/recurring"
+	"opentask/pkg/rules"
+)
+
+// Kind is what happened to the task an Event is about.
+type Kind string
+
+const (
+	// KindCreated is a task created with OpenTask.
+	KindCreated Kind = "created"
+	// KindUpdated is a task changed with OpenTask.
+	KindUpdated Kind = "updated"
+	// KindSynced is a change made on the platform itself, picked up by the
+	// daemon's refresh or a webhook delivery.
+	KindSynced Kind = "synced"
+)
+
+// Kinds are the kinds of event, as they are named in filters.
+var Kinds = []Kind{KindCreated, KindUpdated, KindSynced}
+
+// Event is something that happened to a task.
+type Event struct {
+	Kind Kind
+	// Platform is the configured platform name the task is on.
+	Platform string
+	Task     *models.Task
+	// Previous is the task before the change, or nil for a new task.
+	Previous *models.Task
+}
+
+// StatusChanged reports whether the event moved the task to another
+// status.
+func (e Event) StatusChanged() bool {
+	return e.Previous != nil && e.Previous.Status != e.Task.Status
+}
+
+// AssigneeChanged reports whether the event gave the task another
+// assignee.
+func (e Event) AssigneeChanged() bool {
+	return e.Previous != nil && assigneeID(e.Previous) != assigneeID(e.Task)
+}
+
+// Heading names the change, such as "Created" or "Changed on jira".
+func (e Event) Heading() string {
+	switch {
+	case e.Kind == KindCreated:
+		return "Created"
+	case e.Kind == KindUpdated:
+		return "Updated"
+	case e.Previous == nil:
+		return "New on " + e.Platform
+	default:
+		return "Changed on " + e.Platform
+	}
+}
+
+// Changed reports whether previous and task differ in a way worth
+// posting: status, assignee or a later update. It is how the daemon tells
+// which refreshed tasks to post as synced.
+func Changed(previous, task *models.Task) bool {
+	if previous == nil {
+		return true
+	}
+	return previous.Status != task.Status ||
+		assigneeID(previous) != assigneeID(task) ||
+		task.UpdatedAt.After(previous.UpdatedAt)
+}
+
+func assigneeID(task *models.Task) string {
+	if task.Assignee == nil {
+		return ""
+	}
+	if task.Assignee.ID != "" {
+		return task.Assignee.ID
+	}
+	return task.Assignee.Name
+}
+
+// Notifier posts events to one destination.
+type Notifier interface {
+	// Name identifies the notifier in errors.
+	Name() string
+	Notify(ctx context.Context, event Event) error
+}
+
+// Filter selects the events a notifier posts. Empty fields match every
+// event.
+type Filter struct {
+	Kinds      []Kind
+	Platforms  []string
+	Projects   []string
+	Priorities []models.Priority
+}
+
+// NewFilter checks and converts a configured filter.
+func NewFilter(cfg config.NotificationFilter) (Filter, error) {
+	filter := Filter{Platforms: cfg.Platforms, Projects: cfg.Projects}
+	for _, name := range cfg.Events {
+		kind := Kind(name)
+		if !slices.Contains(Kinds, kind) {
+			return Filter{}, fmt.Errorf("unknown event %q; use created, updated or synced", name)
+		}
+		filter.Kinds = append(filter.Kinds, kind)
+	}
+	for _, name := range cfg.Priorities {
+		priority := models.Priority(name)
+		if !priority.IsValid() {
+			return Filter{}, fmt.Errorf("unknown priority %q", name)
+		}
+		filter.Priorities = append(filter.Priorities, priority)
+	}
+	return filter, nil
+}
+
+// Match reports whether the filter selects event.
+func (f Filter) Match(event Event) bool {
+	return matches(f.Kinds, event.Kind) && f.MatchTask(event.Platform, event.Task)
+}
+
+// MatchTask reports whether the filter selects task, on the configured
+// platform, whatever the event.
+func (f Filter) MatchTask(platform string, task *models.Task) bool {
+	return matches(f.Platforms, platform) &&
+		matches(f.Projects, task.ProjectID) &&
+		matches(f.Priorities, task.Priority)
+}
+
+func matches[T comparable](allowed []T, value T) bool {
+	return len(allowed) == 0 || slices.Contains(allowed, value)
+}
+
+// Failure is an event or a digest a notifier could not send.
+type Failure struct {
+	Notifier string
+	Event    Event
+	// Digest names the digest or report that failed; it is empty for
+	// events.
+	Digest string
+	Err    error
+}
+
+// About names what failed to be sent: the task or the digest.
+func (f Failure) About() string {
+	if f.Digest != "" {
+		return "digest " + f.Digest
+	}
+	return f.Event.Task.ID
+}
+
+func (f Failure) Error() string {
+	if f.Digest != "" {
+		return fmt.Sprintf("failed to send digest %s: %v", f.Digest, f.Err)
+	}
+	return fmt.Sprintf("failed to post %s %s to %s: %v", f.Event.Kind, f.Event.Task.ID, f.Notifier, f.Err)
+}
+
+// Dispatcher posts events to the notifiers whose filters match them, and
+// sends the digests that are due.
+type Dispatcher struct {
+	routes  []route
+	digests []DigestRule
+}
+
+type route struct {
+	notifier Notifier
+	filter   Filter
+}
+
+// Add posts the events filter matches to notifier.
+func (d *Dispatcher) Add(notifier Notifier, filter Filter) {
+	d.routes = append(d.routes, route{notifier: notifier, filter: filter})
+}
+
+// AddDigest sends the digest rule describes when it is due.
+func (d *Dispatcher) AddDigest(rule DigestRule) {
+	d.digests = append(d.digests, rule)
+}
+
+// Len returns the number of notifiers and digests.
+func (d *Dispatcher) Len() int {
+	return len(d.routes) + len(d.digests)
+}
+
+// Notify posts every event to the notifiers it matches, each notifier
+// taking its events in order and at the same time as the others.
+func (d *Dispatcher) Notify(ctx context.Context, events ...Event) []Failure {
+	var (
+		mu       sync.Mutex
+		failures []Failure
+		wg       sync.WaitGroup
+	)
+	for _, r := range d.routes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, event := range events {
+				if !r.filter.Match(event) {
+					continue
+				}
+				if err := r.notifier.Notify(ctx, event); err != nil {
+					mu.Lock()
+					failures = append(failures, Failure{Notifier: r.notifier.Name(), Event: event, Err: err})
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return failures
+}
+
+// FromConfig returns a dispatcher for the notifiers configured under
+// notifications. It has no notifiers when none are configured.
+func FromConfig(cfg *config.Config) (*Dispatcher, error) {
+	d := &Dispatcher{}
+	seen := make(map[string]bool)
+	for i, c := range cfg.Notifications.Slack {
+		name := c.Name
+		if name == "" {
+			name = fmt.Sprintf("slack notifier %d", i+1)
+		} else if seen[name] {
+			return nil, fmt.Errorf("notifier %q is defined twice", name)
+		}
+		seen[name] = true
+
+		filter, err := NewFilter(c.NotificationFilter)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		slack, err := NewSlack(name, c, slackToken(cfg))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		d.Add(slack, filter)
+	}
+
+	email := cfg.Notifications.Email
+	for i, rule := range email.Rules {
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("email rule %d", i+1)
+			rule.Name = name
+		} else if seen[name] {
+			return nil, fmt.Errorf("notifier %q is defined twice", name)
+		}
+		seen[name] = true
+
+		if err := addEmailRule(d, email, rule); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return d, nil
+}
+
+// addEmailRule adds an email rule as an alert or, when it has a schedule,
+// a digest.
+func addEmailRule(d *Dispatcher, server config.EmailNotifications, rule config.EmailRule) error {
+	filter, err := NewFilter(rule.NotificationFilter)
+	if err != nil {
+		return err
+	}
+	email, err := NewEmail(server, rule)
+	if err != nil {
+		return err
+	}
+	if rule.Schedule == "" {
+		if rule.DueWithin != "" {
+			return fmt.Errorf("due_within only applies to digests; set schedule")
+		}
+		d.Add(email, filter)
+		return nil
+	}
+
+	if len(filter.Kinds) > 0 {
+		return fmt.Errorf("events only apply to alerts; digests list open tasks")
+	}
+	schedule, err := recurring.Parse(rule.Schedule)
+	if err != nil {
+		return fmt.Errorf("invalid schedule: %w", err)
+	}
+	digest := DigestRule{Name: rule.Name, Schedule: schedule, Filter: filter, Sender: email}
+	if rule.DueWithin != "" {
+		if digest.DueWithin, err = rules.ParseAge(rule.DueWithin); err != nil {
+			return fmt.Errorf("invalid due_within: %w", err)
+		}
+	}
+	d.AddDigest(digest)
+	return nil
+}
+
+// slackToken returns the bot token of the first enabled Slack platform,
+// which notifiers without a token of their own post with.
+func slackToken(cfg *config.Config) string {
+	for _, name := range cfg.GetEnabledPlatforms() {
+		platform := cfg.Platforms[name]
+		if platform.Type == string(models.PlatformSlack) {
+			return platform.Credentials["bot_token"]
+		}
+	}
+	return ""
+}
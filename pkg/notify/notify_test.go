@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	name string
+	err  error
+
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recorder) Name() string { return r.name }
+
+func (r *recorder) Notify(ctx context.Context, event Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return r.err
+}
+
+func TestFilter_Match(t *testing.T) {
+	task := &models.Task{ID: "TEST-1", ProjectID: "TEST", Priority: models.PriorityHigh}
+	event := Event{Kind: KindCreated, Platform: "jira", Task: task}
+
+	tests := []struct {
+		name   string
+		filter config.NotificationFilter
+		want   bool
+	}{
+		{"empty filter matches everything", config.NotificationFilter{}, true},
+		{"event kind", config.NotificationFilter{Events: []string{"created"}}, true},
+		{"other event kind", config.NotificationFilter{Events: []string{"updated", "synced"}}, false},
+		{"platform", config.NotificationFilter{Platforms: []string{"linear", "jira"}}, true},
+		{"other platform", config.NotificationFilter{Platforms: []string{"linear"}}, false},
+		{"project", config.NotificationFilter{Projects: []string{"TEST"}}, true},
+		{"other project", config.NotificationFilter{Projects: []string{"OPS"}}, false},
+		{"priority", config.NotificationFilter{Priorities: []string{"high", "urgent"}}, true},
+		{"other priority", config.NotificationFilter{Priorities: []string{"low"}}, false},
+		{"all fields must match", config.NotificationFilter{Events: []string{"created"}, Projects: []string{"OPS"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewFilter(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, filter.Match(event))
+		})
+	}
+}
+
+func TestNewFilter_RejectsUnknownValues(t *testing.T) {
+	_, err := NewFilter(config.NotificationFilter{Events: []string{"deleted"}})
+	assert.ErrorContains(t, err, `unknown event "deleted"`)
+
+	_, err = NewFilter(config.NotificationFilter{Priorities: []string{"highest"}})
+	assert.ErrorContains(t, err, `unknown priority "highest"`)
+}
+
+func TestChanged(t *testing.T) {
+	previous := &models.Task{ID: "TEST-1", Status: models.StatusOpen}
+
+	assert.True(t, Changed(nil, previous))
+	assert.False(t, Changed(previous, &models.Task{ID: "TEST-1", Status: models.StatusOpen}))
+	assert.True(t, Changed(previous, &models.Task{ID: "TEST-1", Status: models.StatusDone}))
+	assert.True(t, Changed(previous, &models.Task{ID: "TEST-1", Status: models.StatusOpen, Assignee: &models.User{ID: "u1"}}))
+}
+
+func TestDispatcher_Notify(t *testing.T) {
+	all := &recorder{name: "all"}
+	created := &recorder{name: "created"}
+	failing := &recorder{name: "failing", err: errors.New("channel_not_found")}
+
+	d := &Dispatcher{}
+	d.Add(all, Filter{})
+	d.Add(created, Filter{Kinds: []Kind{KindCreated}})
+	d.Add(failing, Filter{Kinds: []Kind{KindUpdated}})
+
+	events := []Event{
+		{Kind: KindCreated, Platform: "jira", Task: &models.Task{ID: "TEST-1"}},
+		{Kind: KindUpdated, Platform: "jira", Task: &models.Task{ID: "TEST-2"}},
+	}
+	failures := d.Notify(context.Background(), events...)
+
+	assert.Len(t, all.events, 2)
+	require.Len(t, created.events, 1)
+	assert.Equal(t, "TEST-1", created.events[0].Task.ID)
+	require.Len(t, failures, 1)
+	assert.Equal(t, "failing", failures[0].Notifier)
+	assert.EqualError(t, failures[0], "failed to post updated TEST-2 to failing: channel_not_found")
+}
+
+func TestFromConfig(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Platforms["slack"] = config.Platform{Type: "slack", Enabled: true, Credentials: map[string]string{"bot_token": "xoxb-platform"}}
+	cfg.Notifications.Slack = []config.SlackNotification{
+		{Name: "team", Channel: "#eng"},
+		{Name: "ops", Channel: "#ops", Token: "xoxb-ops", NotificationFilter: config.NotificationFilter{Events: []string{"synced"}}},
+	}
+
+	d, err := FromConfig(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 2, d.Len())
+	assert.Equal(t, "xoxb-platform", d.routes[0].notifier.(*Slack).token)
+	assert.Equal(t, "xoxb-ops", d.routes[1].notifier.(*Slack).token)
+	assert.Equal(t, []Kind{KindSynced}, d.routes[1].filter.Kinds)
+
+	cfg.Notifications.Slack = append(cfg.Notifications.Slack, config.SlackNotification{Name: "team", Channel: "#other"})
+	_, err = FromConfig(cfg)
+	assert.ErrorContains(t, err, `notifier "team" is defined twice`)
+
+	delete(cfg.Platforms, "slack")
+	cfg.Notifications.Slack = []config.SlackNotification{{Name: "team", Channel: "#eng"}}
+	_, err = FromConfig(cfg)
+	assert.ErrorContains(t, err, "team: no token configured")
+}
@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Report is a document, such as a weekly digest, sent to notifiers as it
+// is rather than through their templates.
+type Report struct {
+	Title string
+	// Body is Markdown.
+	Body string
+}
+
+// ReportSender is implemented by notifiers that can send a report.
+type ReportSender interface {
+	Name() string
+	SendReport(ctx context.Context, report Report) error
+}
+
+// SendReport sends report to each of the named notifiers.
+func (d *Dispatcher) SendReport(ctx context.Context, names []string, report Report) ([]Failure, error) {
+	senders := make([]ReportSender, 0, len(names))
+	for _, name := range names {
+		sender, ok := d.reportSender(name)
+		if !ok {
+			return nil, fmt.Errorf("no notifier named %q is configured", name)
+		}
+		senders = append(senders, sender)
+	}
+
+	var failures []Failure
+	for _, sender := range senders {
+		if err := sender.SendReport(ctx, report); err != nil {
+			failures = append(failures, Failure{Notifier: sender.Name(), Digest: report.Title, Err: err})
+		}
+	}
+	return failures, nil
+}
+
+func (d *Dispatcher) reportSender(name string) (ReportSender, bool) {
+	for _, r := range d.routes {
+		if sender, ok := r.notifier.(ReportSender); ok && sender.Name() == name {
+			return sender, true
+		}
+	}
+	for _, rule := range d.digests {
+		if sender, ok := rule.Sender.(ReportSender); ok && sender.Name() == name {
+			return sender, true
+		}
+	}
+	return nil, false
+}
+
+var (
+	markdownHeading = regexp.MustCompile(`(?m)^#+\s+(.*)$`)
+	markdownBold    = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	markdownLink    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// slackMarkdown converts the Markdown reports use to Slack's markup:
+// headings and bold text become bold, and links Slack links.
+func slackMarkdown(md string) string {
+	text := slackEscaper.Replace(md)
+	text = markdownHeading.ReplaceAllString(text, "*$1*")
+	text = markdownBold.ReplaceAllString(text, "*$1*")
+	return markdownLink.ReplaceAllString(text, "<$2|$1>")
+}
+
+// SendReport posts the report's Markdown, converted to Slack's markup.
+func (s *Slack) SendReport(ctx context.Context, report Report) error {
+	text := slackMarkdown(report.Body)
+	if !strings.HasPrefix(strings.TrimSpace(report.Body), "#") {
+		text = "*" + slackEscaper.Replace(report.Title) + "*\n" + text
+	}
+	return s.call(ctx, http.MethodPost, "chat.postMessage", map[string]any{
+		"channel":      s.channel,
+		"text":         text,
+		"unfurl_links": false,
+		"unfurl_media": false,
+	}, nil)
+}
+
+// SendReport mails the report with its title as the subject and its
+// Markdown as the text.
+func (e *Email) SendReport(ctx context.Context, report Report) error {
+	msg, err := e.compose(report.Title, report.Body, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return e.send(e.addr, e.auth, e.from, e.to, msg)
+}
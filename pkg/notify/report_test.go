@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"opentask/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackMarkdown(t *testing.T) {
+	md := "# Weekly digest\n\n## jira / TEST\n\n**2 created · 1 closed**\n\n- [TEST-1](https://example.com/browse/TEST-1) Fix <login> & logout (open)\n"
+	want := "*Weekly digest*\n\n*jira / TEST*\n\n*2 created · 1 closed*\n\n- <https://example.com/browse/TEST-1|TEST-1> Fix &lt;login&gt; &amp; logout (open)\n"
+	assert.Equal(t, want, slackMarkdown(md))
+}
+
+func TestDispatcher_SendReport(t *testing.T) {
+	var posted map[string]any
+	slack := newTestSlack(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+		w.Write([]byte(`{"ok": true}`))
+	})
+	email, sent := newTestEmail(t, config.EmailRule{Name: "weekly", To: []string{"team@example.com"}, Schedule: "0 8 * * 1"})
+
+	d := &Dispatcher{}
+	d.Add(slack, Filter{})
+	d.AddDigest(DigestRule{Name: "weekly", Sender: email})
+
+	report := Report{Title: "Weekly digest", Body: "# Weekly digest\n\n**1 created**\n"}
+	failures, err := d.SendReport(context.Background(), []string{"team", "weekly"}, report)
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+
+	assert.Equal(t, "#eng", posted["channel"])
+	assert.Equal(t, "*Weekly digest*\n\n*1 created*\n", posted["text"])
+	require.Len(t, *sent, 1)
+	assert.Equal(t, "Weekly digest", subject(t, (*sent)[0]))
+	assert.Equal(t, "# Weekly digest\r\n\r\n**1 created**\r\n", (*sent)[0].body)
+
+	_, err = d.SendReport(context.Background(), []string{"missing"}, report)
+	assert.ErrorContains(t, err, `no notifier named "missing"`)
+}
@@ -0,0 +1,204 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+)
+
+// DefaultSlackURL is the Slack Web API.
+const DefaultSlackURL = "https://slack.com/api"
+
+// slackEscaper escapes the characters Slack reads as markup.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Slack posts events to a channel with chat.postMessage: the task linked
+// to its page, what changed and the assignee, mentioned when their Slack
+// user is known.
+type Slack struct {
+	// BaseURL is the Web API to post to, DefaultSlackURL unless set.
+	BaseURL string
+	Client  *http.Client
+
+	name     string
+	channel  string
+	token    string
+	mentions map[string]string
+
+	mu sync.Mutex
+	// users caches the Slack user of each email looked up, "" when there
+	// is none.
+	users map[string]string
+}
+
+// NewSlack returns the notifier configured by cfg. token is used when cfg
+// has no token of its own.
+func NewSlack(name string, cfg config.SlackNotification, token string) (*Slack, error) {
+	if cfg.Channel == "" {
+		return nil, fmt.Errorf("no channel configured")
+	}
+	if cfg.Token != "" {
+		token = cfg.Token
+	}
+	if token == "" {
+		return nil, fmt.Errorf("no token configured; set token or run 'opentask connect slack'")
+	}
+	return &Slack{
+		BaseURL:  DefaultSlackURL,
+		Client:   http.DefaultClient,
+		name:     name,
+		channel:  cfg.Channel,
+		token:    token,
+		mentions: cfg.Mentions,
+		users:    make(map[string]string),
+	}, nil
+}
+
+func (s *Slack) Name() string {
+	return s.name
+}
+
+func (s *Slack) Notify(ctx context.Context, event Event) error {
+	return s.call(ctx, http.MethodPost, "chat.postMessage", map[string]any{
+		"channel":      s.channel,
+		"text":         s.message(ctx, event),
+		"unfurl_links": false,
+		"unfurl_media": false,
+	}, nil)
+}
+
+// message formats event in Slack's markup: a line naming the change and
+// linking the task, then its status and assignee as far as they are new.
+func (s *Slack) message(ctx context.Context, event Event) string {
+	task := event.Task
+
+	ref := slackEscaper.Replace(task.ID)
+	if url := task.URL(); url != "" {
+		ref = "<" + url + "|" + ref + ">"
+	}
+	text := fmt.Sprintf("*%s* %s: %s", event.Heading(), ref, slackEscaper.Replace(task.Title))
+
+	var details []string
+	switch {
+	case event.Previous == nil:
+		details = append(details, "Status: "+string(task.Status))
+		if task.Priority != "" {
+			details = append(details, "Priority: "+string(task.Priority))
+		}
+	case event.StatusChanged():
+		details = append(details, fmt.Sprintf("Status: %s → %s", event.Previous.Status, task.Status))
+	}
+	if (event.Previous == nil && task.Assignee != nil) || event.AssigneeChanged() {
+		details = append(details, "Assignee: "+s.mention(ctx, task.Assignee))
+	}
+
+	if len(details) > 0 {
+		text += "\n" + strings.Join(details, " · ")
+	}
+	return text
+}
+
+// mention returns the Slack mention of user when their Slack user is
+// configured or can be found by email, and their name otherwise.
+func (s *Slack) mention(ctx context.Context, user *models.User) string {
+	if user == nil {
+		return "unassigned"
+	}
+	for _, key := range []string{user.Email, user.Name, user.ID} {
+		if id, ok := s.mentions[key]; ok && key != "" {
+			return "<@" + id + ">"
+		}
+	}
+	if user.Email != "" {
+		if id := s.lookup(ctx, user.Email); id != "" {
+			return "<@" + id + ">"
+		}
+	}
+
+	name := user.Name
+	if name == "" {
+		name = user.Email
+	}
+	return slackEscaper.Replace(name)
+}
+
+// lookup finds the Slack user with email. Failures are remembered as no
+// user, so a token without the users:read.email scope is only tried once.
+func (s *Slack) lookup(ctx context.Context, email string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id, ok := s.users[email]; ok {
+		return id
+	}
+
+	var result struct {
+		User struct {
+			ID string `json:"id"`
+		} `json:"user"`
+	}
+	err := s.call(ctx, http.MethodGet, "users.lookupByEmail?email="+url.QueryEscape(email), nil, &result)
+	if err != nil {
+		result.User.ID = ""
+	}
+	s.users[email] = result.User.ID
+	return result.User.ID
+}
+
+// call makes a Web API request. Slack answers errors with 200 and
+// "ok": false, so both are checked.
+func (s *Slack) call(ctx context.Context, method, path string, body any, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(s.BaseURL, "/")+"/"+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("slack answered %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var status struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return fmt.Errorf("invalid slack response: %w", err)
+	}
+	if !status.OK {
+		return fmt.Errorf("slack: %s", status.Error)
+	}
+	if result != nil {
+		return json.Unmarshal(data, result)
+	}
+	return nil
+}
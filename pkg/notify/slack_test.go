@@ -0,0 +1,114 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSlack(t *testing.T, mentions map[string]string, handler http.HandlerFunc) *Slack {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	slack, err := NewSlack("team", config.SlackNotification{Channel: "#eng", Token: "xoxb-test", Mentions: mentions}, "")
+	require.NoError(t, err)
+	slack.BaseURL = server.URL
+	return slack
+}
+
+func TestSlack_Notify(t *testing.T) {
+	var posted map[string]any
+	lookups := 0
+	slack := newTestSlack(t, map[string]string{"alice@example.com": "U0ALICE"}, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer xoxb-test", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+			w.Write([]byte(`{"ok": true}`))
+		case "/users.lookupByEmail":
+			lookups++
+			assert.Equal(t, "bob@example.com", r.URL.Query().Get("email"))
+			w.Write([]byte(`{"ok": true, "user": {"id": "U0BOB"}}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	task := &models.Task{
+		ID:       "TEST-1",
+		Title:    "Fix <login> & logout",
+		Status:   models.StatusOpen,
+		Priority: models.PriorityHigh,
+		Assignee: &models.User{Name: "Alice", Email: "alice@example.com"},
+		Metadata: map[string]any{"jira_self": "https://example.atlassian.net/rest/api/2/issue/10001"},
+	}
+
+	tests := []struct {
+		name  string
+		event Event
+		want  string
+	}{
+		{
+			name:  "created",
+			event: Event{Kind: KindCreated, Platform: "jira", Task: task},
+			want:  "*Created* <https://example.atlassian.net/browse/TEST-1|TEST-1>: Fix &lt;login&gt; &amp; logout\nStatus: open · Priority: high · Assignee: <@U0ALICE>",
+		},
+		{
+			name: "status change",
+			event: Event{Kind: KindUpdated, Platform: "jira", Task: &models.Task{ID: "TEST-2", Title: "Docs", Status: models.StatusDone},
+				Previous: &models.Task{ID: "TEST-2", Title: "Docs", Status: models.StatusInProgress}},
+			want: "*Updated* TEST-2: Docs\nStatus: in_progress → done",
+		},
+		{
+			name: "synced reassignment",
+			event: Event{Kind: KindSynced, Platform: "jira", Task: &models.Task{ID: "TEST-3", Title: "Deploy", Status: models.StatusOpen, Assignee: &models.User{Name: "Bob", Email: "bob@example.com"}},
+				Previous: &models.Task{ID: "TEST-3", Title: "Deploy", Status: models.StatusOpen}},
+			want: "*Changed on jira* TEST-3: Deploy\nAssignee: <@U0BOB>",
+		},
+		{
+			name: "synced unassignment",
+			event: Event{Kind: KindSynced, Platform: "jira", Task: &models.Task{ID: "TEST-3", Title: "Deploy", Status: models.StatusOpen},
+				Previous: &models.Task{ID: "TEST-3", Title: "Deploy", Status: models.StatusOpen, Assignee: &models.User{Name: "Bob"}}},
+			want: "*Changed on jira* TEST-3: Deploy\nAssignee: unassigned",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, slack.Notify(context.Background(), tt.event))
+			assert.Equal(t, "#eng", posted["channel"])
+			assert.Equal(t, tt.want, posted["text"])
+		})
+	}
+
+	// Bob is looked up once and remembered.
+	require.NoError(t, slack.Notify(context.Background(), tests[2].event))
+	assert.Equal(t, 1, lookups)
+}
+
+func TestSlack_NotifyError(t *testing.T) {
+	slack := newTestSlack(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok": false, "error": "channel_not_found"}`))
+	})
+
+	err := slack.Notify(context.Background(), Event{Kind: KindCreated, Platform: "jira", Task: &models.Task{ID: "TEST-1"}})
+	assert.EqualError(t, err, "slack: channel_not_found")
+}
+
+func TestSlack_MentionFallsBackToName(t *testing.T) {
+	slack := newTestSlack(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok": false, "error": "missing_scope"}`))
+	})
+
+	assert.Equal(t, "Carol", slack.mention(context.Background(), &models.User{Name: "Carol", Email: "carol@example.com"}))
+	assert.Equal(t, "dave@example.com", slack.mention(context.Background(), &models.User{Email: "dave@example.com"}))
+}
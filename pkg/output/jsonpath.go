@@ -0,0 +1,196 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPath is a parsed JSONPath expression. The subset kubectl users reach
+// for is supported: the root $, child members as .name or ['name'], array
+// indexes as [n] (negative from the end), wildcards as .* or [*], and
+// recursive descent as ..name. kubectl's braces, as in {.items[*].id}, are
+// accepted too.
+type JSONPath struct {
+	expr  string
+	steps []step
+}
+
+// step selects values from a value.
+type step struct {
+	kind stepKind
+	// name is the member of stepMember and stepDescend.
+	name string
+	// index is the element of stepIndex.
+	index int
+}
+
+type stepKind int
+
+const (
+	stepMember stepKind = iota
+	stepIndex
+	stepWildcard
+	// stepDescend selects name in the value and everything below it.
+	stepDescend
+)
+
+// ParseJSONPath parses expr.
+func ParseJSONPath(expr string) (*JSONPath, error) {
+	s := strings.TrimSpace(expr)
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	s = strings.TrimPrefix(s, "$")
+
+	p := &JSONPath{expr: expr}
+	for len(s) > 0 {
+		switch {
+		case strings.HasPrefix(s, ".."):
+			name, rest := readName(s[2:])
+			if name == "" {
+				return nil, fmt.Errorf("invalid JSONPath %q: .. must be followed by a name", expr)
+			}
+			p.steps = append(p.steps, step{kind: stepDescend, name: name})
+			s = rest
+		case s[0] == '.':
+			s = s[1:]
+			if strings.HasPrefix(s, "[") {
+				// $.[*] is read as $[*].
+				continue
+			}
+			name, rest := readName(s)
+			switch name {
+			case "":
+				return nil, fmt.Errorf("invalid JSONPath %q: expected a name after .", expr)
+			case "*":
+				p.steps = append(p.steps, step{kind: stepWildcard})
+			default:
+				p.steps = append(p.steps, step{kind: stepMember, name: name})
+			}
+			s = rest
+		case s[0] == '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q: missing ]", expr)
+			}
+			st, err := parseBracket(strings.TrimSpace(s[1:end]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSONPath %q: %w", expr, err)
+			}
+			p.steps = append(p.steps, st)
+			s = s[end+1:]
+		default:
+			// A path without the leading $ or dot, such as id.
+			if len(p.steps) > 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q: unexpected %q", expr, s)
+			}
+			s = "." + s
+		}
+	}
+	return p, nil
+}
+
+// readName reads a member name up to the next . or [.
+func readName(s string) (string, string) {
+	end := strings.IndexAny(s, ".[")
+	if end < 0 {
+		end = len(s)
+	}
+	return s[:end], s[end:]
+}
+
+func parseBracket(s string) (step, error) {
+	switch {
+	case s == "*":
+		return step{kind: stepWildcard}, nil
+	case len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]:
+		return step{kind: stepMember, name: s[1 : len(s)-1]}, nil
+	}
+	index, err := strconv.Atoi(s)
+	if err != nil {
+		return step{}, fmt.Errorf("unsupported selector [%s]", s)
+	}
+	return step{kind: stepIndex, index: index}, nil
+}
+
+func (p *JSONPath) String() string {
+	return p.expr
+}
+
+// Find returns the values the path selects in data, which holds values as
+// encoding/json decodes them into any. Members that do not exist select
+// nothing, so a path into an optional field skips the items without it.
+func (p *JSONPath) Find(data any) []any {
+	values := []any{data}
+	for _, st := range p.steps {
+		var next []any
+		for _, value := range values {
+			next = st.apply(next, value)
+		}
+		values = next
+	}
+	return values
+}
+
+func (st step) apply(out []any, value any) []any {
+	switch st.kind {
+	case stepMember:
+		if object, ok := value.(map[string]any); ok {
+			if v, ok := object[st.name]; ok {
+				out = append(out, v)
+			}
+		}
+	case stepIndex:
+		if array, ok := value.([]any); ok {
+			i := st.index
+			if i < 0 {
+				i += len(array)
+			}
+			if i >= 0 && i < len(array) {
+				out = append(out, array[i])
+			}
+		}
+	case stepWildcard:
+		switch v := value.(type) {
+		case []any:
+			out = append(out, v...)
+		case map[string]any:
+			for _, key := range sortedKeys(v) {
+				out = append(out, v[key])
+			}
+		}
+	case stepDescend:
+		out = descend(out, value, st.name)
+	}
+	return out
+}
+
+// descend appends every value of member name in value and below it.
+func descend(out []any, value any, name string) []any {
+	switch v := value.(type) {
+	case map[string]any:
+		if found, ok := v[name]; ok {
+			out = append(out, found)
+		}
+		for _, key := range sortedKeys(v) {
+			out = descend(out, v[key], name)
+		}
+	case []any:
+		for _, item := range v {
+			out = descend(out, item, name)
+		}
+	}
+	return out
+}
+
+// sortedKeys keeps wildcard results in a stable order.
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
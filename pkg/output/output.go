@@ -0,0 +1,183 @@
+// Package output formats command results with a user's Go template or
+// JSONPath expression, as kubectl's -o template and -o jsonpath do, so
+// scripts can pick out fields without jq. The task and project commands
+// share it through the --template and --jsonpath flags.
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/pflag"
+)
+
+// Options are the values of the --template and --jsonpath flags.
+type Options struct {
+	Template string
+	JSONPath string
+}
+
+// AddFlags adds --template and --jsonpath to flags.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.Template, "template", "", "format each result with a Go template, such as '{{.ID}} {{.Title}}'")
+	flags.StringVar(&o.JSONPath, "jsonpath", "", "print the fields a JSONPath expression selects, such as '$[*].id'")
+}
+
+// IsSet reports whether either flag was given.
+func (o *Options) IsSet() bool {
+	return o.Template != "" || o.JSONPath != ""
+}
+
+// Formatter returns the formatter the flags describe, or nil when neither
+// was given.
+func (o *Options) Formatter() (*Formatter, error) {
+	switch {
+	case o.Template != "" && o.JSONPath != "":
+		return nil, fmt.Errorf("--template and --jsonpath cannot be used together")
+	case o.Template != "":
+		tmpl, err := template.New("output").Funcs(funcs).Option("missingkey=zero").Parse(o.Template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+		return &Formatter{template: tmpl}, nil
+	case o.JSONPath != "":
+		path, err := ParseJSONPath(o.JSONPath)
+		if err != nil {
+			return nil, err
+		}
+		return &Formatter{path: path}, nil
+	}
+	return nil, nil
+}
+
+// funcs are the functions templates can call besides the built-in ones.
+var funcs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join": strings.Join,
+	// upper and lower take any value, so named string types such as a
+	// task's status can be passed as they are.
+	"upper": func(v any) string { return strings.ToUpper(fmt.Sprint(v)) },
+	"lower": func(v any) string { return strings.ToLower(fmt.Sprint(v)) },
+}
+
+// Formatter writes values with a template or a JSONPath expression.
+//
+// A template sees the Go value, so its fields are named as in Go ({{.ID}})
+// and its methods can be called. A JSONPath expression sees the value's
+// JSON, so it names fields as the JSON output does ($.id).
+type Formatter struct {
+	template *template.Template
+	path     *JSONPath
+}
+
+// Write formats a single value.
+func (f *Formatter) Write(w io.Writer, v any) error {
+	if f.template != nil {
+		return f.execute(w, v)
+	}
+	data, err := toJSON(v)
+	if err != nil {
+		return err
+	}
+	return writeValues(w, f.path.Find(data))
+}
+
+// List returns a writer for a list of values. The template is applied to
+// each value as it is added; the JSONPath expression to the list as a
+// whole, so $[*].id selects the id of every value, once it is closed.
+func (f *Formatter) List(w io.Writer) *ListWriter {
+	return &ListWriter{f: f, w: w}
+}
+
+// ListWriter formats the values of a list as they are added.
+type ListWriter struct {
+	f     *Formatter
+	w     io.Writer
+	items []any
+}
+
+// Add formats v, or keeps it for the JSONPath expression.
+func (l *ListWriter) Add(v any) error {
+	if l.f.template != nil {
+		return l.f.execute(l.w, v)
+	}
+	data, err := toJSON(v)
+	if err != nil {
+		return err
+	}
+	l.items = append(l.items, data)
+	return nil
+}
+
+// Close evaluates the JSONPath expression on the values added.
+func (l *ListWriter) Close() error {
+	if l.f.path == nil {
+		return nil
+	}
+	items := l.items
+	if items == nil {
+		items = []any{}
+	}
+	return writeValues(l.w, l.f.path.Find(items))
+}
+
+// execute writes the template's output for v, ending it with a newline
+// unless the template does.
+func (f *Formatter) execute(w io.Writer, v any) error {
+	var buf bytes.Buffer
+	if err := f.template.Execute(&buf, v); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// toJSON converts v to the maps, slices and scalars encoding/json decodes
+// JSON into, keeping numbers as they were written.
+func toJSON(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var out any
+	if err := decoder.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// writeValues writes each value on its own line: strings as they are,
+// null as an empty line and everything else as compact JSON.
+func writeValues(w io.Writer, values []any) error {
+	var buf bytes.Buffer
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			buf.WriteString(v)
+		case nil:
+		case json.Number:
+			buf.WriteString(v.String())
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			buf.Write(data)
+		}
+		buf.WriteByte('\n')
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,135 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"opentask/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTasks() []*models.Task {
+	return []*models.Task{
+		{ID: "API-1", Title: "Fix login", Status: models.StatusOpen, Platform: models.PlatformJira,
+			Assignee: &models.User{Name: "Alice"}, Labels: []string{"bug", "auth"}},
+		{ID: "ENG-2", Title: "Write docs", Status: models.StatusDone, Platform: models.PlatformLinear},
+	}
+}
+
+func TestParseJSONPath(t *testing.T) {
+	var data any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"items": [
+			{"id": "a", "n": 1, "tags": ["x", "y"], "owner": {"name": "Alice"}},
+			{"id": "b", "n": 2, "tags": []}
+		],
+		"name": "root"
+	}`), &data))
+
+	tests := []struct {
+		expr string
+		want []any
+	}{
+		{expr: "$.name", want: []any{"root"}},
+		{expr: "name", want: []any{"root"}},
+		{expr: "{.items[*].id}", want: []any{"a", "b"}},
+		{expr: "$.items.*.id", want: []any{"a", "b"}},
+		{expr: "$['items'][0]['id']", want: []any{"a"}},
+		{expr: "$.items[-1].id", want: []any{"b"}},
+		{expr: "$.items[5].id", want: nil},
+		{expr: "$.items[*].owner.name", want: []any{"Alice"}},
+		{expr: "$..name", want: []any{"root", "Alice"}},
+		{expr: "$.items[0].tags[*]", want: []any{"x", "y"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			path, err := ParseJSONPath(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, path.Find(data))
+		})
+	}
+
+	for _, expr := range []string{"$.items[", "$.items[a:b]", "$..", "$."} {
+		_, err := ParseJSONPath(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestFormatter_List(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "template",
+			opts: Options{Template: "{{.ID}} {{.Title}}"},
+			want: "API-1 Fix login\nENG-2 Write docs\n",
+		},
+		{
+			name: "template with functions and methods",
+			opts: Options{Template: "{{.Ref}} {{upper .Status}} {{join .Labels \",\"}}{{with .Assignee}} @{{.Name}}{{end}}\n"},
+			want: "jira:API-1 OPEN bug,auth @Alice\nlinear:ENG-2 DONE \n",
+		},
+		{
+			name: "jsonpath ids",
+			opts: Options{JSONPath: "$.[*].id"},
+			want: "API-1\nENG-2\n",
+		},
+		{
+			name: "jsonpath skips missing fields",
+			opts: Options{JSONPath: "$[*].assignee.name"},
+			want: "Alice\n",
+		},
+		{
+			name: "jsonpath objects as JSON",
+			opts: Options{JSONPath: "$[0].labels"},
+			want: "[\"bug\",\"auth\"]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := tt.opts.Formatter()
+			require.NoError(t, err)
+
+			var b strings.Builder
+			list := f.List(&b)
+			for _, task := range testTasks() {
+				require.NoError(t, list.Add(task))
+			}
+			require.NoError(t, list.Close())
+			assert.Equal(t, tt.want, b.String())
+		})
+	}
+}
+
+func TestFormatter_Write(t *testing.T) {
+	task := testTasks()[0]
+
+	f, err := (&Options{JSONPath: "$.status"}).Formatter()
+	require.NoError(t, err)
+	var b strings.Builder
+	require.NoError(t, f.Write(&b, task))
+	assert.Equal(t, "open\n", b.String())
+
+	f, err = (&Options{Template: "{{.Title}}"}).Formatter()
+	require.NoError(t, err)
+	b.Reset()
+	require.NoError(t, f.Write(&b, task))
+	assert.Equal(t, "Fix login\n", b.String())
+}
+
+func TestOptions_Formatter(t *testing.T) {
+	f, err := (&Options{}).Formatter()
+	require.NoError(t, err)
+	assert.Nil(t, f)
+
+	_, err = (&Options{Template: "{{.ID}}", JSONPath: "$.id"}).Formatter()
+	assert.ErrorContains(t, err, "cannot be used together")
+
+	_, err = (&Options{Template: "{{.ID"}).Formatter()
+	assert.ErrorContains(t, err, "invalid template")
+}
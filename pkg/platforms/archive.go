@@ -0,0 +1,17 @@
+package platforms
+
+import (
+	"context"
+
+	"opentask/pkg/models"
+)
+
+// ArchivedLabel marks tasks archived on platforms without an archive of
+// their own.
+const ArchivedLabel = "archived"
+
+// Archiver is implemented by platforms that can archive a task, taking it
+// out of the way while keeping it recoverable, instead of deleting it.
+type Archiver interface {
+	ArchiveTask(ctx context.Context, task *models.Task) error
+}
@@ -0,0 +1,111 @@
+package platforms
+
+import (
+	"context"
+	"fmt"
+)
+
+// CapabilitiesKey is the platform setting holding the capabilities recorded
+// by the last 'opentask connect'.
+const CapabilitiesKey = "capabilities"
+
+// Capability is an OpenTask feature whose availability depends on the
+// scopes or permissions of the configured token.
+type Capability string
+
+const (
+	CapabilityListTasks      Capability = "list_tasks"
+	CapabilityListProjects   Capability = "list_projects"
+	CapabilitySearchUsers    Capability = "search_users"
+	CapabilityCreateTask     Capability = "create_task"
+	CapabilityUpdateTask     Capability = "update_task"
+	CapabilityTransitionTask Capability = "transition_task"
+	CapabilityAssignTask     Capability = "assign_task"
+	CapabilityDeleteTask     Capability = "delete_task"
+)
+
+// AllCapabilities lists every capability in the order they are reported.
+var AllCapabilities = []Capability{
+	CapabilityListTasks,
+	CapabilityListProjects,
+	CapabilitySearchUsers,
+	CapabilityCreateTask,
+	CapabilityUpdateTask,
+	CapabilityTransitionTask,
+	CapabilityAssignTask,
+	CapabilityDeleteTask,
+}
+
+var capabilityFeatures = map[Capability]string{
+	CapabilityListTasks:      "list and view tasks",
+	CapabilityListProjects:   "list projects",
+	CapabilitySearchUsers:    "search users (assignee autocomplete)",
+	CapabilityCreateTask:     "create tasks",
+	CapabilityUpdateTask:     "edit tasks",
+	CapabilityTransitionTask: "change task status",
+	CapabilityAssignTask:     "assign tasks",
+	CapabilityDeleteTask:     "delete tasks",
+}
+
+// Feature describes the OpenTask feature gated by the capability.
+func (c Capability) Feature() string {
+	if feature, ok := capabilityFeatures[c]; ok {
+		return feature
+	}
+	return string(c)
+}
+
+// CapabilityCheck is the result of probing one capability.
+type CapabilityCheck struct {
+	Capability Capability
+	Allowed    bool
+	// Required names the scope or permission that grants the capability.
+	Required string
+}
+
+// CapabilityProber is implemented by platforms that can report which
+// capabilities the configured token has without changing any data.
+type CapabilityProber interface {
+	ProbeCapabilities(ctx context.Context) ([]CapabilityCheck, error)
+}
+
+// CapabilitySettings converts probe results into the form stored under
+// CapabilitiesKey.
+func CapabilitySettings(checks []CapabilityCheck) map[string]any {
+	settings := make(map[string]any, len(checks))
+	for _, check := range checks {
+		settings[string(check.Capability)] = check.Allowed
+	}
+	return settings
+}
+
+// HasCapability reports whether the recorded capabilities allow c.
+// Capabilities that were never probed are assumed to be available.
+func HasCapability(config map[string]any, c Capability) bool {
+	recorded, ok := config[CapabilitiesKey].(map[string]any)
+	if !ok {
+		return true
+	}
+
+	allowed, ok := recorded[string(c)].(bool)
+	return !ok || allowed
+}
+
+// RequireCapability returns a PermissionError if the recorded capabilities
+// rule out c for the platform.
+func RequireCapability(platformName string, config map[string]any, c Capability) error {
+	if HasCapability(config, c) {
+		return nil
+	}
+
+	return NewPlatformError(
+		ErrPermissionDenied,
+		platformName,
+		"",
+		&PermissionError{
+			Operation:   c.Feature(),
+			Required:    "a permission the connected token lacks",
+			Remediation: fmt.Sprintf("grant the missing permission and run 'opentask connect %s' again to re-check", platformName),
+		},
+	)
+}
@@ -0,0 +1,33 @@
+package platforms
+
+import (
+	"context"
+
+	"opentask/pkg/models"
+)
+
+// Commenter is implemented by platforms that can add a comment to a task.
+// The body is Markdown.
+type Commenter interface {
+	AddComment(ctx context.Context, taskID, body string) error
+}
+
+// Mentioner is implemented by commenters that can address a user in a
+// comment body so the platform notifies them.
+type Mentioner interface {
+	Mention(user *models.User) string
+}
+
+// Mention returns the mention of user in a comment added through commenter,
+// or "@" and the user's name if the platform has no mentions.
+func Mention(commenter Commenter, user *models.User) string {
+	if mentioner, ok := commenter.(Mentioner); ok {
+		if mention := mentioner.Mention(user); mention != "" {
+			return mention
+		}
+	}
+	if user.Name != "" {
+		return "@" + user.Name
+	}
+	return "@" + user.Email
+}
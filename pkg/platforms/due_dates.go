@@ -0,0 +1,138 @@
+package platforms
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"opentask/pkg/models"
+)
+
+// DueDatesKey is the platform setting holding DueDateRules.
+const DueDatesKey = "due_dates"
+
+// DueDateMetadataKey holds a due date given as text, such as the --due
+// flag, until a platform sends it.
+const DueDateMetadataKey = "due_date_string"
+
+const dateLayout = "2006-01-02"
+
+// DueDateRules normalize due dates between platforms that store a calendar
+// date and ones that store a point in time. A date is read as EndOfDay in
+// Location, so a task due on the 30th stays due on the 30th wherever it is
+// compared, and a time is sent to a date-only platform as its date in
+// Location.
+type DueDateRules struct {
+	Location *time.Location
+	// EndOfDay is how long after midnight a date-only due date falls.
+	EndOfDay time.Duration
+}
+
+// DefaultDueDateRules reads dates as the end of the day in local time.
+func DefaultDueDateRules() DueDateRules {
+	return DueDateRules{Location: time.Local, EndOfDay: 24*time.Hour - time.Second}
+}
+
+// ParseDueDateRules reads the due_dates setting from a platform config:
+//
+//	due_dates:
+//	  timezone: Europe/Berlin
+//	  end_of_day: "18:00"
+//
+// Both entries are optional and default to local time and 23:59:59.
+func ParseDueDateRules(config map[string]any) (DueDateRules, error) {
+	rules := DefaultDueDateRules()
+
+	raw, ok := config[DueDatesKey]
+	if !ok || raw == nil {
+		return rules, nil
+	}
+
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		return rules, fmt.Errorf("%s must be a map with timezone and end_of_day", DueDatesKey)
+	}
+
+	if value, ok := entries["timezone"]; ok {
+		name, _ := value.(string)
+		location, err := time.LoadLocation(name)
+		if err != nil || name == "" {
+			return rules, fmt.Errorf("%s: invalid timezone %v", DueDatesKey, value)
+		}
+		rules.Location = location
+	}
+
+	if value, ok := entries["end_of_day"]; ok {
+		text, _ := value.(string)
+		endOfDay, err := parseClock(text)
+		if err != nil {
+			return rules, fmt.Errorf("%s: invalid end_of_day %v (use HH:MM or HH:MM:SS)", DueDatesKey, value)
+		}
+		rules.EndOfDay = endOfDay
+	}
+
+	return rules, nil
+}
+
+func parseClock(text string) (time.Duration, error) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(text)); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time of day %q", text)
+}
+
+// FromDate returns the due time for a calendar date.
+func (r DueDateRules) FromDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, r.location()).Add(r.EndOfDay)
+}
+
+// Parse reads a due date given either as a date (2006-01-02) or as an
+// RFC 3339 time.
+func (r DueDateRules) Parse(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if date, err := time.Parse(dateLayout, value); err == nil {
+		return r.FromDate(date.Date()), nil
+	}
+	if due, err := time.Parse(time.RFC3339, value); err == nil {
+		return due, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid due date %q, expected YYYY-MM-DD", value)
+}
+
+// Date returns the calendar date of due in Location, as date-only
+// platforms store it.
+func (r DueDateRules) Date(due time.Time) string {
+	return due.In(r.location()).Format(dateLayout)
+}
+
+// TaskDate returns the date to send for the task's due date, from DueDate
+// or a due date given as text. ok is false when the task has none.
+func (r DueDateRules) TaskDate(task *models.Task) (string, bool, error) {
+	if task.DueDate != nil {
+		return r.Date(*task.DueDate), true, nil
+	}
+
+	raw, ok := task.GetMetadata(DueDateMetadataKey)
+	if !ok {
+		return "", false, nil
+	}
+	text, _ := raw.(string)
+	if text == "" {
+		return "", false, nil
+	}
+
+	due, err := r.Parse(text)
+	if err != nil {
+		return "", false, err
+	}
+	return r.Date(due), true, nil
+}
+
+func (r DueDateRules) location() *time.Location {
+	if r.Location == nil {
+		return time.Local
+	}
+	return r.Location
+}
@@ -1,7 +1,13 @@
 package platforms
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"opentask/pkg/logging"
 )
 
 type ErrorCode string
@@ -52,7 +58,8 @@ func (e *PlatformError) Error() string {
 		msg += fmt.Sprintf(": %v", e.Cause)
 	}
 	
-	return msg
+	// Client errors may quote the request, credentials included.
+	return logging.Redact(msg)
 }
 
 func (e *PlatformError) Unwrap() error {
@@ -66,6 +73,71 @@ func (e *PlatformError) Is(target error) bool {
 	return false
 }
 
+// RequiredFieldsError lists fields a platform rejected as missing, keyed by
+// field ID with the platform's message as value.
+type RequiredFieldsError struct {
+	Fields map[string]string
+}
+
+func (e *RequiredFieldsError) Error() string {
+	return fmt.Sprintf("missing required fields: %s", strings.Join(e.FieldIDs(), ", "))
+}
+
+// FieldIDs returns the missing field IDs in stable order.
+func (e *RequiredFieldsError) FieldIDs() []string {
+	ids := make([]string, 0, len(e.Fields))
+	for id := range e.Fields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// PermissionError explains which platform permission or token scope an
+// operation needs and how to obtain it.
+type PermissionError struct {
+	Operation   string
+	Required    string
+	Remediation string
+	Message     string
+}
+
+func (e *PermissionError) Error() string {
+	msg := fmt.Sprintf("%s requires %s", e.Operation, e.Required)
+	if e.Message != "" {
+		msg += fmt.Sprintf(" (%s)", e.Message)
+	}
+	if e.Remediation != "" {
+		msg += "\n  hint: " + e.Remediation
+	}
+	return msg
+}
+
+// RateLimitError is the cause of a rate_limited error, with how long the
+// platform asked to wait before retrying when it said.
+type RateLimitError struct {
+	Operation  string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("the rate limit was reached while trying to %s", e.Operation)
+	if e.RetryAfter > 0 {
+		return msg + fmt.Sprintf("\n  hint: retry after %s", e.RetryAfter)
+	}
+	return msg + "\n  hint: wait a minute and retry"
+}
+
+// RetryAfter returns how long a rate_limited err asks to wait before
+// retrying, if the platform said.
+func RetryAfter(err error) (time.Duration, bool) {
+	var rateLimit *RateLimitError
+	if !errors.As(err, &rateLimit) || rateLimit.RetryAfter <= 0 {
+		return 0, false
+	}
+	return rateLimit.RetryAfter, true
+}
+
 func getErrorMessage(code ErrorCode) string {
 	switch code {
 	case ErrAuthentication:
@@ -93,20 +165,51 @@ func getErrorMessage(code ErrorCode) string {
 	}
 }
 
-func IsAuthenticationError(err error) bool {
+// CodeOf returns the code of the PlatformError in err's chain.
+func CodeOf(err error) (ErrorCode, bool) {
 	var pe *PlatformError
-	return err != nil && (err == &PlatformError{Code: ErrAuthentication} || 
-		(pe != nil && pe.Code == ErrAuthentication))
+	if !errors.As(err, &pe) {
+		return "", false
+	}
+	return pe.Code, true
+}
+
+func IsAuthenticationError(err error) bool {
+	return hasErrorCode(err, ErrAuthentication)
 }
 
 func IsNotFoundError(err error) bool {
-	var pe *PlatformError
-	return err != nil && (err == &PlatformError{Code: ErrNotFound} || 
-		(pe != nil && pe.Code == ErrNotFound))
+	return hasErrorCode(err, ErrNotFound)
+}
+
+// IsPermissionError reports whether err is a permission_denied error, or
+// a PermissionError naming the permission an operation needs.
+func IsPermissionError(err error) bool {
+	var permission *PermissionError
+	return hasErrorCode(err, ErrPermissionDenied) || errors.As(err, &permission)
 }
 
 func IsRateLimitError(err error) bool {
-	var pe *PlatformError
-	return err != nil && (err == &PlatformError{Code: ErrRateLimited} || 
-		(pe != nil && pe.Code == ErrRateLimited))
-}
\ No newline at end of file
+	return hasErrorCode(err, ErrRateLimited)
+}
+
+func IsInvalidInputError(err error) bool {
+	return hasErrorCode(err, ErrInvalidInput)
+}
+
+func IsInvalidConfigError(err error) bool {
+	return hasErrorCode(err, ErrInvalidConfig)
+}
+
+func IsNetworkError(err error) bool {
+	return hasErrorCode(err, ErrNetworkError)
+}
+
+func IsSyncConflictError(err error) bool {
+	return hasErrorCode(err, ErrSyncConflict)
+}
+
+func hasErrorCode(err error, code ErrorCode) bool {
+	actual, ok := CodeOf(err)
+	return ok && actual == code
+}
@@ -0,0 +1,70 @@
+package platforms
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeOf(t *testing.T) {
+	notFound := NewPlatformError(ErrNotFound, "jira", "API-1", errors.New("404"))
+
+	tests := []struct {
+		name   string
+		err    error
+		want   ErrorCode
+		wantOK bool
+	}{
+		{name: "nil", err: nil},
+		{name: "plain error", err: errors.New("boom")},
+		{name: "platform error", err: notFound, want: ErrNotFound, wantOK: true},
+		{name: "wrapped", err: fmt.Errorf("failed to get task: %w", notFound), want: ErrNotFound, wantOK: true},
+		{name: "joined", err: errors.Join(errors.New("linear: timeout"), notFound), want: ErrNotFound, wantOK: true},
+		{name: "permission error", err: &PermissionError{Operation: "deleting issues"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, ok := CodeOf(tt.err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, code)
+		})
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	helpers := map[ErrorCode]func(error) bool{
+		ErrAuthentication:   IsAuthenticationError,
+		ErrNotFound:         IsNotFoundError,
+		ErrPermissionDenied: IsPermissionError,
+		ErrRateLimited:      IsRateLimitError,
+		ErrInvalidInput:     IsInvalidInputError,
+		ErrInvalidConfig:    IsInvalidConfigError,
+		ErrNetworkError:     IsNetworkError,
+		ErrSyncConflict:     IsSyncConflictError,
+	}
+
+	for code, is := range helpers {
+		t.Run(string(code), func(t *testing.T) {
+			err := NewPlatformError(code, "jira", "API-1", nil)
+			assert.True(t, is(err))
+			assert.True(t, is(fmt.Errorf("failed: %w", err)), "wrapped")
+			assert.True(t, is(errors.Join(errors.New("other platform failed"), err)), "joined")
+			assert.False(t, is(nil))
+			assert.False(t, is(errors.New(string(code))))
+
+			for other, isOther := range helpers {
+				if other != code {
+					assert.False(t, isOther(err), "%s is not %s", code, other)
+				}
+			}
+		})
+	}
+}
+
+func TestIsPermissionError_PermissionError(t *testing.T) {
+	err := fmt.Errorf("failed to delete task: %w", &PermissionError{Operation: "deleting issues", Required: "Delete issues"})
+	assert.True(t, IsPermissionError(err))
+	assert.False(t, IsAuthenticationError(err))
+}
@@ -0,0 +1,135 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+const (
+	// SprintFieldKey is the setting naming the custom field that holds an
+	// issue's sprint, such as customfield_10020. It is looked up from the
+	// site's fields when not set.
+	SprintFieldKey = "sprint_field"
+
+	// sprintSchema is the custom field type of Jira Software's sprint field.
+	sprintSchema = "com.pyxis.greenhopper.jira:gh-sprint"
+
+	// Field names --field resolves before sending: reporter takes a user
+	// and sprint a sprint name, ID, "active" or "next".
+	reporterField = "reporter"
+	sprintField   = "sprint"
+)
+
+// reporterValue returns the reporter field for the user query names: "me",
+// an account ID, an email or a display name. Jira Cloud identifies users
+// by account ID and Server and Data Center by username.
+func (c *Client) reporterValue(ctx context.Context, query string) (any, error) {
+	var user *jira.User
+	if query == models.AssigneeMe {
+		self, resp, err := c.client.User.GetSelfWithContext(ctx)
+		if err != nil {
+			defer closeBody(resp)
+			return nil, c.apiError("get current user", "", resp, err)
+		}
+		user = self
+	} else {
+		found, resp, err := c.client.User.FindWithContext(ctx, url.QueryEscape(query), jira.WithMaxResults(20))
+		if err != nil {
+			defer closeBody(resp)
+			return nil, c.apiError("search users", "", resp, err)
+		}
+		for i := range found {
+			candidate := &found[i]
+			if candidate.AccountID == query || candidate.Name == query ||
+				strings.EqualFold(candidate.EmailAddress, query) || strings.EqualFold(candidate.DisplayName, query) {
+				user = candidate
+				break
+			}
+		}
+		switch {
+		case user != nil:
+		case len(found) == 1:
+			user = &found[0]
+		case len(found) == 0:
+			return nil, invalidField(fmt.Errorf("no user matches reporter %q", query))
+		default:
+			return nil, invalidField(fmt.Errorf("reporter %q matches %d users; use their email", query, len(found)))
+		}
+	}
+
+	if user.AccountID != "" {
+		return map[string]string{"accountId": user.AccountID}, nil
+	}
+	return map[string]string{"name": user.Name}, nil
+}
+
+// sprintFieldID returns the ID of the sprint custom field: the sprint_field
+// setting, or the field of the sprint type found on the site.
+func (c *Client) sprintFieldID(ctx context.Context) (string, error) {
+	c.sprintMu.Lock()
+	defer c.sprintMu.Unlock()
+	if c.sprintField != "" {
+		return c.sprintField, nil
+	}
+
+	fields, resp, err := c.client.Field.GetListWithContext(ctx)
+	if err != nil {
+		defer closeBody(resp)
+		return "", c.apiError("list fields", "", resp, err)
+	}
+	for _, field := range fields {
+		if field.Schema.Custom == sprintSchema {
+			c.sprintField = field.ID
+			return c.sprintField, nil
+		}
+	}
+	return "", invalidField(fmt.Errorf("no sprint field found; is Jira Software installed? Set %s to the field ID", SprintFieldKey))
+}
+
+// sprintID returns the ID of the sprint raw names on the project's scrum
+// boards: a sprint ID is used as is, "active" is the active sprint, "next"
+// the first future sprint, and anything else a sprint name.
+func (c *Client) sprintID(ctx context.Context, project, raw string) (int, error) {
+	name := strings.TrimSpace(raw)
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil
+	}
+	if project == "" {
+		return 0, invalidField(fmt.Errorf("a project is needed to find sprint %q", name))
+	}
+
+	boards, resp, err := c.client.Board.GetAllBoardsWithContext(ctx, &jira.BoardListOptions{BoardType: "scrum", ProjectKeyOrID: project})
+	if err != nil {
+		defer closeBody(resp)
+		return 0, c.apiError("list boards", "", resp, err)
+	}
+
+	for _, board := range boards.Values {
+		sprints, resp, err := c.client.Board.GetAllSprintsWithOptionsWithContext(ctx, board.ID, &jira.GetAllSprintsOptions{State: "active,future"})
+		if err != nil {
+			defer closeBody(resp)
+			return 0, c.apiError("list sprints", "", resp, err)
+		}
+		for _, sprint := range sprints.Values {
+			switch {
+			case strings.EqualFold(name, "active") && sprint.State == "active",
+				strings.EqualFold(name, "next") && sprint.State == "future",
+				strings.EqualFold(name, sprint.Name):
+				return sprint.ID, nil
+			}
+		}
+	}
+	return 0, invalidField(fmt.Errorf("no active or future sprint %q on the boards of %s", name, project))
+}
+
+func invalidField(err error) error {
+	return platforms.NewPlatformError(platforms.ErrInvalidInput, "jira", "", err)
+}
@@ -0,0 +1,79 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"opentask/pkg/platforms"
+)
+
+// capabilityPermissions maps each capability to the Jira permission that
+// grants it.
+var capabilityPermissions = map[platforms.Capability]jiraPermission{
+	platforms.CapabilityListTasks:      {Key: "BROWSE_PROJECTS", Name: "Browse Projects"},
+	platforms.CapabilityListProjects:   {Key: "BROWSE_PROJECTS", Name: "Browse Projects"},
+	platforms.CapabilitySearchUsers:    {Key: "USER_PICKER", Name: "Browse users and groups", Global: true},
+	platforms.CapabilityCreateTask:     {Key: "CREATE_ISSUES", Name: "Create Issues"},
+	platforms.CapabilityUpdateTask:     {Key: "EDIT_ISSUES", Name: "Edit Issues"},
+	platforms.CapabilityTransitionTask: {Key: "TRANSITION_ISSUES", Name: "Transition Issues"},
+	platforms.CapabilityAssignTask:     {Key: "ASSIGN_ISSUES", Name: "Assign Issues"},
+	platforms.CapabilityDeleteTask:     {Key: "DELETE_ISSUES", Name: "Delete Issues"},
+}
+
+// ProbeCapabilities asks Jira which of the permissions OpenTask relies on
+// the user holds. Project permissions count as held if any project grants
+// them. Nothing is modified.
+func (c *Client) ProbeCapabilities(ctx context.Context) ([]platforms.CapabilityCheck, error) {
+	keys := make([]string, 0, len(capabilityPermissions))
+	seen := make(map[string]bool)
+	for _, capability := range platforms.AllCapabilities {
+		key := capabilityPermissions[capability].Key
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	endpoint := "rest/api/2/mypermissions?permissions=" + url.QueryEscape(strings.Join(keys, ","))
+	req, err := c.client.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, platforms.NewPlatformError(
+			platforms.ErrPlatformAPI,
+			"jira",
+			"",
+			fmt.Errorf("failed to build request: %w", err),
+		)
+	}
+
+	var result struct {
+		Permissions map[string]struct {
+			HavePermission bool `json:"havePermission"`
+		} `json:"permissions"`
+	}
+	resp, err := c.client.Do(req, &result)
+	if err != nil {
+		defer closeBody(resp)
+		return nil, c.apiError("check permissions", "", resp, err)
+	}
+	defer resp.Body.Close()
+
+	checks := make([]platforms.CapabilityCheck, 0, len(platforms.AllCapabilities))
+	for _, capability := range platforms.AllCapabilities {
+		perm := capabilityPermissions[capability]
+		required := fmt.Sprintf("project permission %q", perm.Name)
+		if perm.Global {
+			required = fmt.Sprintf("global permission %q", perm.Name)
+		}
+
+		checks = append(checks, platforms.CapabilityCheck{
+			Capability: capability,
+			Allowed:    result.Permissions[perm.Key].HavePermission,
+			Required:   required,
+		})
+	}
+
+	return checks, nil
+}
@@ -3,25 +3,129 @@ package jira
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
+	"time"
 
+	"opentask/pkg/logging"
+	"opentask/pkg/metrics"
 	"opentask/pkg/models"
 	"opentask/pkg/platforms"
+	"opentask/pkg/record"
+	"opentask/pkg/telemetry"
+	"opentask/pkg/throttle"
 
 	"github.com/andygrunwald/go-jira"
+	"golang.org/x/sync/singleflight"
 )
 
 type Client struct {
-	client  *jira.Client
-	baseURL string
-	email   string
+	client    *jira.Client
+	baseURL   string
+	email       string
+	statusMap   platforms.StatusMap
+	statusNames platforms.StatusNames
+
+	priorityMap   platforms.PriorityMap
+	priorityNames platforms.PriorityNames
+
+	issueType string
+	fieldMap  map[string]string
+	labelMap  platforms.LabelMap
+	dueDates  platforms.DueDateRules
+
+	// sprintField is the sprint custom field, looked up on first use
+	// unless configured.
+	sprintMu    sync.Mutex
+	sprintField string
+
+	// createMetas caches the create metadata by project and issue type.
+	// createMetaFetch lets concurrent lookups of a key share one fetch.
+	createMetaMu    sync.Mutex
+	createMetas     map[string]*createMeta
+	createMetaFetch singleflight.Group
+
+	versions *apiVersion
+
+	// siteURL is the site's own URL when baseURL is the OAuth API gateway.
+	siteURL string
 }
 
+// AuthTypeKey is the setting choosing how Email and Token authenticate.
+const AuthTypeKey = "auth_type"
+
+const (
+	// AuthBasic sends Email (or a Server/Data Center username) and Token
+	// with basic auth. Token is an API token on Jira Cloud and a password
+	// on Server and Data Center.
+	AuthBasic = "basic"
+	// AuthPAT sends Token as a bearer Personal Access Token, as Jira Server
+	// and Data Center 8.14+ accept. Email is not needed.
+	AuthPAT = "pat"
+)
+
 type Config struct {
 	BaseURL string `json:"base_url" yaml:"base_url"`
 	Email   string `json:"email" yaml:"email"`
 	Token   string `json:"token" yaml:"token"`
+
+	// AuthType is AuthBasic (the default) or AuthPAT.
+	AuthType string `json:"auth_type,omitempty" yaml:"auth_type,omitempty"`
+
+	// AccessToken is an OAuth 2.0 access token used instead of Email and
+	// Token. BaseURL is then the site's API URL (see Site.APIURL) and
+	// SiteURL the site's own URL, used for browse links.
+	AccessToken string `json:"access_token,omitempty" yaml:"access_token,omitempty"`
+	SiteURL     string `json:"site_url,omitempty" yaml:"site_url,omitempty"`
+
+	// StatusMap overrides the status-category based mapping for named
+	// workflow states.
+	StatusMap platforms.StatusMap `json:"status_map,omitempty" yaml:"status_map,omitempty"`
+	// StatusNames overrides the status names used in JQL filters and
+	// transitions, for workflows that rename the default statuses.
+	StatusNames platforms.StatusNames `json:"-" yaml:"-"`
+
+	// PriorityMap and PriorityNames override the default priority names,
+	// for sites with custom priorities such as "P0"-"P4".
+	PriorityMap   platforms.PriorityMap   `json:"priority_map,omitempty" yaml:"priority_map,omitempty"`
+	PriorityNames platforms.PriorityNames `json:"-" yaml:"-"`
+
+	// IssueType is the issue type for new tasks that don't name one
+	// (default "Task").
+	IssueType string `json:"issue_type,omitempty" yaml:"issue_type,omitempty"`
+	// FieldMap gives custom fields friendly names, such as
+	// epic_name: customfield_10011, for use with --field.
+	FieldMap map[string]string `json:"field_map,omitempty" yaml:"field_map,omitempty"`
+	// SprintField is the ID of the sprint custom field; it is looked up
+	// when empty.
+	SprintField string `json:"sprint_field,omitempty" yaml:"sprint_field,omitempty"`
+	// LabelMap translates labels to and from the names used on other
+	// platforms.
+	LabelMap platforms.LabelMap `json:"-" yaml:"-"`
+	// DueDates decides the time of day Jira's date-only due dates fall at
+	// (default: the end of the day in local time).
+	DueDates platforms.DueDateRules `json:"-" yaml:"-"`
+	// Limits bound the requests sent to the server at once and per
+	// second, shared by every client of the same BaseURL.
+	Limits throttle.Limits `json:"-" yaml:"-"`
+
+	// APIVersion is the REST API version, 2 (the default) or 3. Under v3
+	// descriptions are read and written as Atlassian Document Format and
+	// converted to and from Markdown.
+	APIVersion int `json:"api_version,omitempty" yaml:"api_version,omitempty"`
+	// DetectAPIVersion asks the server which API version to use before the
+	// first request: v3 on Jira Cloud, v2 on Server and Data Center.
+	DetectAPIVersion bool `json:"-" yaml:"-"`
+
+	// Logger receives a debug record for every HTTP request (default: the
+	// logger from logging.Logger at the time of the request).
+	Logger *slog.Logger `json:"-" yaml:"-"`
+	// Transport sends the client's requests (default:
+	// http.DefaultTransport). Tests set it to a record.Cassette.
+	Transport http.RoundTripper `json:"-" yaml:"-"`
 }
 
 func NewClient(cfg Config) (*Client, error) {
@@ -34,7 +138,16 @@ func NewClient(cfg Config) (*Client, error) {
 		)
 	}
 
-	if cfg.Email == "" || cfg.Token == "" {
+	if cfg.AccessToken == "" && cfg.AuthType == AuthPAT && cfg.Token == "" {
+		return nil, platforms.NewPlatformError(
+			platforms.ErrInvalidConfig,
+			"jira",
+			"",
+			fmt.Errorf("a personal access token is required"),
+		)
+	}
+
+	if cfg.AccessToken == "" && cfg.AuthType != AuthPAT && (cfg.Email == "" || cfg.Token == "") {
 		return nil, platforms.NewPlatformError(
 			platforms.ErrInvalidConfig,
 			"jira",
@@ -43,14 +156,47 @@ func NewClient(cfg Config) (*Client, error) {
 		)
 	}
 
-	// Create basic auth transport
-	tp := jira.BasicAuthTransport{
-		Username: cfg.Email,
-		Password: cfg.Token,
+	if cfg.DueDates.Location == nil {
+		cfg.DueDates = platforms.DefaultDueDateRules()
+	}
+
+	versions := &apiVersion{version: cfg.APIVersion}
+	if !cfg.DetectAPIVersion && versions.version == 0 {
+		versions.version = 2
+	}
+
+	transport := throttle.Transport(throttle.Shared(cfg.BaseURL, cfg.Limits),
+		metrics.Transport("jira", telemetry.Transport("jira", logging.Transport(cfg.Logger, "jira", record.Transport("jira", cfg.Transport)))))
+	if versions.version != 2 {
+		transport = &v3Transport{base: transport, versions: versions}
+	}
+
+	// Create basic auth transport, or bearer auth for OAuth tokens and
+	// personal access tokens
+	var httpClient *http.Client
+	if cfg.AccessToken != "" {
+		tp := jira.BearerAuthTransport{
+			Token:     cfg.AccessToken,
+			Transport: transport,
+		}
+		httpClient = tp.Client()
+	} else if cfg.AuthType == AuthPAT {
+		tp := jira.BearerAuthTransport{
+			Token:     cfg.Token,
+			Transport: transport,
+		}
+		httpClient = tp.Client()
+	} else {
+		tp := jira.BasicAuthTransport{
+			Username:  cfg.Email,
+			Password:  cfg.Token,
+			Transport: transport,
+		}
+		httpClient = tp.Client()
 	}
 
 	// Create Jira client
-	jiraClient, err := jira.NewClient(tp.Client(), cfg.BaseURL)
+	jiraClient, err := jira.NewClient(httpClient, cfg.BaseURL)
 	if err != nil {
 		return nil, platforms.NewPlatformError(
 			platforms.ErrInvalidConfig,
@@ -60,29 +206,50 @@ func NewClient(cfg Config) (*Client, error) {
 		)
 	}
 
-	return &Client{
-		client:  jiraClient,
-		baseURL: cfg.BaseURL,
-		email:   cfg.Email,
-	}, nil
+	client := &Client{
+		client:    jiraClient,
+		baseURL:   cfg.BaseURL,
+		email:       cfg.Email,
+		statusMap:   cfg.StatusMap,
+		statusNames: cfg.StatusNames,
+
+		priorityMap:   cfg.PriorityMap,
+		priorityNames: cfg.PriorityNames,
+
+		issueType: cfg.IssueType,
+		fieldMap:  cfg.FieldMap,
+		labelMap:  cfg.LabelMap,
+		dueDates:  cfg.DueDates,
+
+		sprintField: cfg.SprintField,
+
+		versions: versions,
+
+		siteURL: strings.TrimSuffix(cfg.SiteURL, "/"),
+	}
+	versions.instance = cfg.BaseURL
+	versions.detect = client.detectVersion
+	return client, nil
 }
 
 // Implement PlatformClient interface
 func (c *Client) CreateTask(ctx context.Context, task *models.Task) (*models.Task, error) {
+	if err := task.Validate(); err != nil {
+		return nil, platforms.NewPlatformError(platforms.ErrInvalidInput, "jira", task.ID, err)
+	}
+
 	// Create issue fields
 	issueFields := &jira.IssueFields{
 		Summary:     task.Title,
-		Description: task.Description,
+		Description: c.description(ctx, task),
 		Type: jira.IssueType{
-			Name: "Task", // Default to Task type
+			Name: c.taskIssueType(task),
 		},
 	}
 
 	// Set project
 	if task.ProjectID != "" {
-		issueFields.Project = jira.Project{
-			ID: task.ProjectID,
-		}
+		issueFields.Project = projectRef(task.ProjectID)
 	} else {
 		return nil, platforms.NewPlatformError(
 			platforms.ErrInvalidInput,
@@ -95,7 +262,7 @@ func (c *Client) CreateTask(ctx context.Context, task *models.Task) (*models.Tas
 	// Set priority
 	if task.Priority != "" {
 		issueFields.Priority = &jira.Priority{
-			Name: convertToJiraPriority(task.Priority),
+			Name: c.jiraPriority(task.Priority),
 		}
 	}
 
@@ -112,7 +279,21 @@ func (c *Client) CreateTask(ctx context.Context, task *models.Task) (*models.Tas
 
 	// Set labels
 	if len(task.Labels) > 0 {
-		issueFields.Labels = task.Labels
+		issueFields.Labels = c.labelMap.Platform(task.Labels)
+	}
+
+	if err := c.setDueDate(issueFields, task); err != nil {
+		return nil, err
+	}
+
+	// Set custom fields
+	if err := c.applyCustomFields(ctx, issueFields, task); err != nil {
+		return nil, err
+	}
+
+	// Report missing required fields by name instead of Jira's 400
+	if err := c.checkRequiredFields(ctx, task.ProjectID, issueFields.Type.Name, issueFields); err != nil {
+		return nil, err
 	}
 
 	// Create the issue
@@ -122,34 +303,41 @@ func (c *Client) CreateTask(ctx context.Context, task *models.Task) (*models.Tas
 
 	createdIssue, resp, err := c.client.Issue.CreateWithContext(ctx, issue)
 	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"jira",
-			"",
-			fmt.Errorf("failed to create issue: %w", err),
-		)
+		defer closeBody(resp)
+		if resp != nil {
+			err = jira.NewJiraError(resp, err)
+			if missing := requiredFieldsError(err); missing != nil {
+				return nil, platforms.NewPlatformError(
+					platforms.ErrInvalidInput,
+					"jira",
+					"",
+					missing,
+				)
+			}
+		}
+		return nil, c.apiError("create issue", "", resp, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"jira",
-			"",
-			fmt.Errorf("create issue failed with status %d", resp.StatusCode),
-		)
+		return nil, c.apiError("create issue", "", resp, fmt.Errorf("create issue failed with status %d", resp.StatusCode))
+	}
+
+	// Jira only returns identifiers on create, so fill in what we sent
+	if createdIssue.Fields == nil {
+		createdIssue.Fields = issueFields
 	}
 
 	// Convert created issue back to our task format
-	jiraIssue := &JiraIssue{Issue: *createdIssue}
-	createdTask := jiraIssue.ToTask()
+	createdTask := c.toTask(createdIssue)
 
 	return createdTask, nil
 }
 
 func (c *Client) GetTask(ctx context.Context, id string) (*models.Task, error) {
-	issue, resp, err := c.client.Issue.Get(id, nil)
+	issue, resp, err := c.client.Issue.GetWithContext(ctx, id, nil)
 	if err != nil {
+		defer closeBody(resp)
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			return nil, platforms.NewPlatformError(
 				platforms.ErrNotFound,
@@ -158,22 +346,20 @@ func (c *Client) GetTask(ctx context.Context, id string) (*models.Task, error) {
 				fmt.Errorf("issue not found"),
 			)
 		}
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"jira",
-			id,
-			fmt.Errorf("failed to get issue: %w", err),
-		)
+		return nil, c.apiError("get issue", id, resp, err)
 	}
 	defer resp.Body.Close()
 
-	jiraIssue := &JiraIssue{Issue: *issue}
-	task := jiraIssue.ToTask()
+	task := c.toTask(issue)
 
 	return task, nil
 }
 
 func (c *Client) UpdateTask(ctx context.Context, task *models.Task) (*models.Task, error) {
+	if err := task.Validate(); err != nil {
+		return nil, platforms.NewPlatformError(platforms.ErrInvalidInput, "jira", task.ID, err)
+	}
+
 	jiraID, ok := task.GetMetadata("jira_id")
 	if !ok {
 		// If no jira_id, try using the task ID directly
@@ -191,20 +377,19 @@ func (c *Client) UpdateTask(ctx context.Context, task *models.Task) (*models.Tas
 	}
 
 	// Get current issue to compare status
-	currentIssue, _, err := c.client.Issue.Get(jiraIDStr, nil)
+	currentIssue, resp, err := c.client.Issue.GetWithContext(ctx, jiraIDStr, nil)
 	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"jira",
-			task.ID,
-			fmt.Errorf("failed to get current issue: %w", err),
-		)
+		defer closeBody(resp)
+		return nil, c.apiError("get issue", task.ID, resp, err)
 	}
 
 	// Update status via transition if needed
-	currentStatus := convertFromJiraStatus(currentIssue.Fields.Status.Name)
-	if currentStatus != task.Status {
-		err := c.transitionIssue(jiraIDStr, task.Status)
+	currentStatus := models.StatusOpen
+	if currentIssue.Fields != nil && currentIssue.Fields.Status != nil {
+		currentStatus = c.taskStatus(*currentIssue.Fields.Status)
+	}
+	if task.Status != "" && currentStatus != task.Status {
+		err := c.transitionIssue(ctx, jiraIDStr, currentStatus, task.Status)
 		if err != nil {
 			return nil, err
 		}
@@ -213,19 +398,37 @@ func (c *Client) UpdateTask(ctx context.Context, task *models.Task) (*models.Tas
 	// Create update fields for other properties
 	updateFields := &jira.IssueFields{
 		Summary:     task.Title,
-		Description: task.Description,
+		Description: c.description(ctx, task),
 	}
 
 	// Set priority
 	if task.Priority != "" {
 		updateFields.Priority = &jira.Priority{
-			Name: convertToJiraPriority(task.Priority),
+			Name: c.jiraPriority(task.Priority),
 		}
 	}
 
 	// Set labels
 	if len(task.Labels) > 0 {
-		updateFields.Labels = task.Labels
+		updateFields.Labels = c.labelMap.Platform(task.Labels)
+	}
+
+	if err := c.setDueDate(updateFields, task); err != nil {
+		return nil, err
+	}
+
+	// Set custom fields
+	if err := c.applyCustomFields(ctx, updateFields, task); err != nil {
+		return nil, err
+	}
+
+	// Set assignee
+	if task.Assignee != nil {
+		if accountID, ok := task.Assignee.GetMetadata("jira_account_id"); ok {
+			if accountIDStr, ok := accountID.(string); ok && accountIDStr != "" {
+				updateFields.Assignee = &jira.User{AccountID: accountIDStr}
+			}
+		}
 	}
 
 	// Update the issue fields
@@ -234,39 +437,31 @@ func (c *Client) UpdateTask(ctx context.Context, task *models.Task) (*models.Tas
 		Fields: updateFields,
 	}
 
-	updatedIssue, resp, err := c.client.Issue.Update(issue)
+	updatedIssue, resp, err := c.client.Issue.UpdateWithContext(ctx, issue)
 	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"jira",
-			task.ID,
-			fmt.Errorf("failed to update issue: %w", err),
-		)
+		defer closeBody(resp)
+		return nil, c.apiError("update issue", task.ID, resp, err)
 	}
 	defer resp.Body.Close()
 
 	// If update successful, get the updated issue
 	if updatedIssue == nil {
-		updatedIssue, _, err = c.client.Issue.Get(jiraIDStr, nil)
+		updatedIssue, resp, err = c.client.Issue.GetWithContext(ctx, jiraIDStr, nil)
 		if err != nil {
-			return nil, platforms.NewPlatformError(
-				platforms.ErrPlatformAPI,
-				"jira",
-				task.ID,
-				fmt.Errorf("failed to get updated issue: %w", err),
-			)
+			defer closeBody(resp)
+			return nil, c.apiError("get issue", task.ID, resp, err)
 		}
 	}
 
-	jiraIssue := &JiraIssue{Issue: *updatedIssue}
-	updatedTask := jiraIssue.ToTask()
+	updatedTask := c.toTask(updatedIssue)
 
 	return updatedTask, nil
 }
 
 func (c *Client) DeleteTask(ctx context.Context, id string) error {
-	resp, err := c.client.Issue.Delete(id)
+	resp, err := c.client.Issue.DeleteWithContext(ctx, id)
 	if err != nil {
+		defer closeBody(resp)
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			return platforms.NewPlatformError(
 				platforms.ErrNotFound,
@@ -275,73 +470,77 @@ func (c *Client) DeleteTask(ctx context.Context, id string) error {
 				fmt.Errorf("issue not found"),
 			)
 		}
-		return platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"jira",
-			id,
-			fmt.Errorf("failed to delete issue: %w", err),
-		)
+		return c.apiError("delete issue", id, resp, err)
 	}
 	defer resp.Body.Close()
 
 	return nil
 }
 
-func (c *Client) ListTasks(ctx context.Context, filter *models.TaskFilter) ([]*models.Task, error) {
-	// Build JQL query
-	jql := buildJQLQuery(filter)
+// Search page sizes: the number of issues listed without a limit, and the
+// most Jira Cloud returns in one search request.
+const (
+	defaultSearchResults = 50
+	maxSearchResults     = 100
+)
 
-	// Set options
-	options := &jira.SearchOptions{
-		StartAt:    0,
-		MaxResults: 50,
+func (c *Client) ListTasks(ctx context.Context, filter *models.TaskFilter) ([]*models.Task, error) {
+	if filter != nil && len(filter.Labels) > 0 {
+		translated := *filter
+		translated.Labels = c.labelMap.Platform(filter.Labels)
+		filter = &translated
 	}
 
+	// Build JQL query
+	jql := buildJQLQuery(filter, c.statusNames)
+
+	limit := defaultSearchResults
+	options := &jira.SearchOptions{}
 	if filter != nil {
 		if filter.Limit > 0 {
-			options.MaxResults = filter.Limit
-		}
-		if filter.Offset > 0 {
-			options.StartAt = filter.Offset
+			limit = filter.Limit
 		}
+		options.StartAt = filter.Offset
 	}
 
-	// Search issues
-	issues, resp, err := c.client.Issue.Search(jql, options)
-	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"jira",
-			"",
-			fmt.Errorf("failed to search issues: %w", err),
-		)
-	}
-	defer resp.Body.Close()
+	// Jira returns at most maxSearchResults issues a request, and fewer on
+	// some sites, so larger limits are fetched a page at a time.
+	tasks := make([]*models.Task, 0, min(limit, maxSearchResults))
+	for len(tasks) < limit {
+		options.MaxResults = min(limit-len(tasks), maxSearchResults)
+		issues, resp, err := c.client.Issue.SearchWithContext(ctx, jql, options)
+		if err != nil {
+			defer closeBody(resp)
+			return nil, c.apiError("search issues", "", resp, err)
+		}
+		resp.Body.Close()
 
-	// Convert to tasks
-	var tasks []*models.Task
-	for _, issue := range issues {
-		jiraIssue := &JiraIssue{Issue: issue}
-		tasks = append(tasks, jiraIssue.ToTask())
+		for i := range issues {
+			tasks = append(tasks, c.toTask(&issues[i]))
+		}
+		options.StartAt += len(issues)
+		if len(issues) == 0 || options.StartAt >= resp.Total {
+			break
+		}
 	}
 
 	return tasks, nil
 }
 
+// ListProjects lists the projects a page at a time from the project search
+// endpoint, so instances with thousands of projects are listed in full.
+// Jira Server versions without it list every project in one request.
 func (c *Client) ListProjects(ctx context.Context) ([]*models.Project, error) {
-	projects, resp, err := c.client.Project.GetList()
+	projects, status, err := c.searchProjects(ctx)
+	if status == http.StatusNotFound {
+		projects, err = c.listAllProjects(ctx)
+	}
 	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"jira",
-			"",
-			fmt.Errorf("failed to list projects: %w", err),
-		)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	var result []*models.Project
-	for _, project := range *projects {
+	for _, project := range projects {
 		convertedProject := &models.Project{
 			ID:       project.ID,
 			Name:     project.Name,
@@ -359,9 +558,58 @@ func (c *Client) ListProjects(ctx context.Context) ([]*models.Project, error) {
 	return result, nil
 }
 
+// projectPageSize is how many projects are asked for per page, Jira
+// Cloud's default.
+const projectPageSize = 50
+
+// searchProjects pages through the project search endpoint. The status
+// is that of a failed request.
+func (c *Client) searchProjects(ctx context.Context) (jira.ProjectList, int, error) {
+	var projects jira.ProjectList
+	for startAt := 0; ; {
+		req, err := c.client.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("rest/api/2/project/search?startAt=%d&maxResults=%d", startAt, projectPageSize), nil)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var page struct {
+			Values jira.ProjectList `json:"values"`
+			Total  int              `json:"total"`
+			IsLast bool             `json:"isLast"`
+		}
+		resp, err := c.client.Do(req, &page)
+		if err != nil {
+			defer closeBody(resp)
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			return nil, status, c.apiError("list projects", "", resp, err)
+		}
+		resp.Body.Close()
+
+		projects = append(projects, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 || startAt >= page.Total {
+			return projects, 0, nil
+		}
+	}
+}
+
+func (c *Client) listAllProjects(ctx context.Context) (jira.ProjectList, error) {
+	projects, resp, err := c.client.Project.GetListWithContext(ctx)
+	if err != nil {
+		defer closeBody(resp)
+		return nil, c.apiError("list projects", "", resp, err)
+	}
+	defer resp.Body.Close()
+	return *projects, nil
+}
+
 func (c *Client) GetProject(ctx context.Context, id string) (*models.Project, error) {
-	project, resp, err := c.client.Project.Get(id)
+	project, resp, err := c.client.Project.GetWithContext(ctx, id)
 	if err != nil {
+		defer closeBody(resp)
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			return nil, platforms.NewPlatformError(
 				platforms.ErrNotFound,
@@ -370,38 +618,34 @@ func (c *Client) GetProject(ctx context.Context, id string) (*models.Project, er
 				fmt.Errorf("project not found"),
 			)
 		}
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"jira",
-			"",
-			fmt.Errorf("failed to get project: %w", err),
-		)
+		return nil, c.apiError("get project", "", resp, err)
 	}
 	defer resp.Body.Close()
 
-	convertedProject := &models.Project{
-		ID:       project.ID,
-		Name:     project.Name,
-		Key:      project.Key,
-		Platform: models.PlatformJira,
-		Active:   true,
-		Metadata: map[string]any{
-			"jira_id":   project.ID,
-			"jira_self": project.Self,
-		},
+	convertedProject := (*JiraProject)(project).ToProject()
+	if url := c.browseURL(project.Self, project.Key); url != "" {
+		convertedProject.Metadata["jira_url"] = url
 	}
 	return convertedProject, nil
 }
 
+// browseURL returns the web page of a project or issue: under the site's
+// own URL when it is known, otherwise derived from the REST self link.
+func (c *Client) browseURL(self, key string) string {
+	if c.siteURL != "" {
+		return c.siteURL + "/browse/" + key
+	}
+	if i := strings.Index(self, "/rest/api/"); i > 0 {
+		return self[:i] + "/browse/" + key
+	}
+	return ""
+}
+
 func (c *Client) GetCurrentUser(ctx context.Context) (*models.User, error) {
 	user, resp, err := c.client.User.GetSelfWithContext(ctx)
 	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"jira",
-			"",
-			fmt.Errorf("failed to get current user: %w", err),
-		)
+		defer closeBody(resp)
+		return nil, c.apiError("get current user", "", resp, err)
 	}
 	defer resp.Body.Close()
 
@@ -421,9 +665,20 @@ func (c *Client) GetCurrentUser(ctx context.Context) (*models.User, error) {
 }
 
 func (c *Client) SearchUsers(ctx context.Context, query string) ([]*models.User, error) {
-	// For now, return empty result as user search API varies by Jira version
-	// In a real implementation, we'd use the appropriate API endpoint
-	return []*models.User{}, nil
+	found, resp, err := c.client.User.FindWithContext(ctx, url.QueryEscape(query), jira.WithMaxResults(20))
+	if err != nil {
+		defer closeBody(resp)
+		return nil, c.apiError("search users", "", resp, err)
+	}
+	defer resp.Body.Close()
+
+	users := make([]*models.User, 0, len(found))
+	for _, user := range found {
+		jiraUser := JiraUser(user)
+		users = append(users, jiraUser.ToUser())
+	}
+
+	return users, nil
 }
 
 func (c *Client) GetPlatformInfo() platforms.PlatformInfo {
@@ -441,73 +696,81 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 	return err
 }
 
-// convertFromJiraStatus converts Jira status name to our TaskStatus
-func convertFromJiraStatus(jiraStatus string) models.TaskStatus {
-	switch strings.ToLower(jiraStatus) {
-	case "to do", "open", "new", "created":
-		return models.StatusOpen
-	case "in progress", "in development", "doing":
-		return models.StatusInProgress
-	case "done", "closed", "resolved", "completed":
-		return models.StatusDone
-	case "cancelled", "canceled", "rejected":
-		return models.StatusCancelled
-	default:
-		return models.StatusOpen
+// GetAvailableTransitions returns the workflow transitions Jira allows from
+// the issue's current status.
+func (c *Client) GetAvailableTransitions(ctx context.Context, taskID string) ([]platforms.Transition, error) {
+	transitions, resp, err := c.client.Issue.GetTransitionsWithContext(ctx, taskID)
+	if err != nil {
+		defer closeBody(resp)
+		return nil, c.apiError("get transitions", taskID, resp, err)
+	}
+	defer resp.Body.Close()
+
+	result := make([]platforms.Transition, 0, len(transitions))
+	for _, transition := range transitions {
+		result = append(result, platforms.Transition{
+			ID:     transition.ID,
+			Name:   transition.Name,
+			To:     transition.To.Name,
+			Status: c.taskStatus(transition.To),
+		})
 	}
+
+	return result, nil
 }
 
 // transitionIssue transitions a Jira issue to the specified status
-func (c *Client) transitionIssue(issueID string, targetStatus models.TaskStatus) error {
-	// Get available transitions
-	transitions, resp, err := c.client.Issue.GetTransitions(issueID)
+func (c *Client) transitionIssue(ctx context.Context, issueID string, from, targetStatus models.TaskStatus) error {
+	transitions, err := c.GetAvailableTransitions(ctx, issueID)
 	if err != nil {
-		return platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"jira",
-			issueID,
-			fmt.Errorf("failed to get transitions: %w", err),
-		)
+		return err
 	}
-	defer resp.Body.Close()
-
-	// Find the transition that leads to the target status
-	targetJiraStatus := convertToJiraStatus(targetStatus)
-	var targetTransition *jira.Transition
 
+	// Prefer the transition to the default status name, then any status
+	// that maps to the target.
+	targetTransition, found := platforms.Transition{}, false
+	targetJiraStatus := jiraStatusName(targetStatus, c.statusNames)
 	for _, transition := range transitions {
-		if transition.To.Name == targetJiraStatus {
-			targetTransition = &transition
+		if transition.To == targetJiraStatus {
+			targetTransition, found = transition, true
 			break
 		}
 	}
+	if !found {
+		targetTransition, found = platforms.FindTransition(transitions, targetStatus)
+	}
 
-	if targetTransition == nil {
+	if !found {
 		return platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
+			platforms.ErrInvalidInput,
 			"jira",
 			issueID,
-			fmt.Errorf("no transition available to status: %s", targetJiraStatus),
+			&platforms.InvalidTransitionError{TaskID: issueID, From: from, To: targetStatus, Allowed: transitions},
 		)
 	}
 
 	// Perform the transition
-	resp, err = c.client.Issue.DoTransition(issueID, targetTransition.ID)
+	resp, err := c.client.Issue.DoTransitionWithContext(ctx, issueID, targetTransition.ID)
 	if err != nil {
-		return platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"jira",
-			issueID,
-			fmt.Errorf("failed to transition issue: %w", err),
-		)
+		defer closeBody(resp)
+		return c.apiError("transition issue", issueID, resp, err)
 	}
 	defer resp.Body.Close()
 
 	return nil
 }
 
+// jiraStatusName returns the Jira status name for status, preferring the
+// name configured in status_map.
+func jiraStatusName(status models.TaskStatus, names platforms.StatusNames) string {
+	if name, ok := names.Lookup(status); ok {
+		return name
+	}
+	return convertToJiraStatus(status)
+}
+
 // Helper function to build JQL query from filter
-func buildJQLQuery(filter *models.TaskFilter) string {
+func buildJQLQuery(filter *models.TaskFilter, statusNames platforms.StatusNames) string {
 	var conditions []string
 
 	if filter == nil {
@@ -516,13 +779,13 @@ func buildJQLQuery(filter *models.TaskFilter) string {
 
 	// Add status filter
 	if filter.Status != nil {
-		statusName := convertToJiraStatus(*filter.Status)
+		statusName := jiraStatusName(*filter.Status, statusNames)
 		conditions = append(conditions, fmt.Sprintf("status = \"%s\"", statusName))
 	}
 
 	// Add assignee filter
 	if filter.Assignee != "" {
-		if filter.Assignee == "me" {
+		if filter.Assignee == models.AssigneeMe {
 			conditions = append(conditions, "assignee = currentUser()")
 		} else {
 			conditions = append(conditions, fmt.Sprintf("assignee = \"%s\"", filter.Assignee))
@@ -543,17 +806,236 @@ func buildJQLQuery(filter *models.TaskFilter) string {
 		conditions = append(conditions, "("+strings.Join(labelConditions, " AND ")+")")
 	}
 
+	// Add updated filter. JQL has minute precision.
+	if filter.UpdatedSince != nil {
+		conditions = append(conditions, fmt.Sprintf("updated >= \"%s\"", filter.UpdatedSince.Local().Format("2006/01/02 15:04")))
+	}
+	if filter.UpdatedBefore != nil {
+		conditions = append(conditions, fmt.Sprintf("updated < \"%s\"", filter.UpdatedBefore.Local().Format("2006/01/02 15:04")))
+	}
+
 	// Add text search
 	if filter.Query != "" {
 		conditions = append(conditions, fmt.Sprintf("text ~ \"%s\"", filter.Query))
 	}
 
+	// Tasks updated before a time come least recently updated first, so
+	// a limited page holds the oldest of them.
+	order := "ORDER BY created DESC"
+	if filter.UpdatedBefore != nil {
+		order = "ORDER BY updated ASC"
+	}
+
 	query := strings.Join(conditions, " AND ")
 	if query == "" {
-		query = "ORDER BY created DESC"
+		query = order
 	} else {
-		query += " ORDER BY created DESC"
+		query += " " + order
 	}
 
 	return query
 }
+
+// toTask converts an issue, applies the configured status, priority and
+// label maps and due date rules, and converts v3 descriptions to Markdown.
+func (c *Client) toTask(issue *jira.Issue) *models.Task {
+	task := issueToTask(issue)
+
+	if issue.Fields != nil && issue.Fields.Status != nil {
+		task.Status = c.taskStatus(*issue.Fields.Status)
+	}
+	if issue.Fields != nil && issue.Fields.Priority != nil {
+		if mapped, ok := c.priorityMap.Lookup(issue.Fields.Priority.Name); ok {
+			task.Priority = mapped
+		}
+	}
+	task.Labels = c.labelMap.Shared(task.Labels)
+	if issue.Fields != nil && !time.Time(issue.Fields.Duedate).IsZero() {
+		due := c.dueDates.FromDate(time.Time(issue.Fields.Duedate).Date())
+		task.DueDate = &due
+	}
+	c.readDescription(task)
+	if c.siteURL != "" && issue.Key != "" {
+		task.Metadata["jira_url"] = c.siteURL + "/browse/" + issue.Key
+	}
+
+	return task
+}
+
+// setDueDate sets the issue's due date to the task's, as a date under the
+// configured due date rules.
+func (c *Client) setDueDate(fields *jira.IssueFields, task *models.Task) error {
+	date, ok, err := c.dueDates.TaskDate(task)
+	if err != nil {
+		return platforms.NewPlatformError(platforms.ErrInvalidInput, "jira", task.ID, err)
+	}
+	if !ok {
+		return nil
+	}
+
+	due, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return platforms.NewPlatformError(platforms.ErrInvalidInput, "jira", task.ID, err)
+	}
+	fields.Duedate = jira.Date(due)
+	return nil
+}
+
+// projectRef refers to a project by ID when project is numeric and by key
+// otherwise, as users usually pass keys like "TEST".
+func projectRef(project string) jira.Project {
+	for _, r := range project {
+		if r < '0' || r > '9' {
+			return jira.Project{Key: project}
+		}
+	}
+	return jira.Project{ID: project}
+}
+
+// jiraPriority returns the Jira priority name for a unified priority, using
+// the configured priority names before the defaults.
+func (c *Client) jiraPriority(priority models.Priority) string {
+	if name, ok := c.priorityNames.Lookup(priority); ok {
+		return name
+	}
+	return convertToJiraPriority(priority)
+}
+
+// ListPriorities returns the names of the priorities defined on the site.
+func (c *Client) ListPriorities(ctx context.Context) ([]string, error) {
+	priorities, resp, err := c.client.Priority.GetListWithContext(ctx)
+	if err != nil {
+		defer closeBody(resp)
+		return nil, c.apiError("list priorities", "", resp, err)
+	}
+	defer resp.Body.Close()
+
+	names := make([]string, 0, len(priorities))
+	for _, priority := range priorities {
+		names = append(names, priority.Name)
+	}
+	return names, nil
+}
+
+// taskStatus maps a Jira status to a unified status, using the configured
+// status map before the status category.
+func (c *Client) taskStatus(status jira.Status) models.TaskStatus {
+	if mapped, ok := c.statusMap.Lookup(status.Name); ok {
+		return mapped
+	}
+	return convertJiraStatus(status.StatusCategory.Key)
+}
+
+// ListWorkflowStates returns the statuses used by the project's issue
+// types, each with the unified status it maps to.
+func (c *Client) ListWorkflowStates(ctx context.Context, projectID string) ([]*models.WorkflowState, error) {
+	if projectID == "" {
+		return nil, platforms.NewPlatformError(
+			platforms.ErrInvalidInput,
+			"jira",
+			"",
+			fmt.Errorf("project key is required"),
+		)
+	}
+
+	req, err := c.client.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("rest/api/2/project/%s/statuses", url.PathEscape(projectID)), nil)
+	if err != nil {
+		return nil, platforms.NewPlatformError(
+			platforms.ErrPlatformAPI,
+			"jira",
+			"",
+			fmt.Errorf("failed to build request: %w", err),
+		)
+	}
+
+	var issueTypes []struct {
+		Name     string        `json:"name"`
+		Statuses []jira.Status `json:"statuses"`
+	}
+	resp, err := c.client.Do(req, &issueTypes)
+	if err != nil {
+		defer closeBody(resp)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, platforms.NewPlatformError(
+				platforms.ErrNotFound,
+				"jira",
+				"",
+				fmt.Errorf("project %s not found", projectID),
+			)
+		}
+		return nil, c.apiError("get project statuses", "", resp, err)
+	}
+	defer resp.Body.Close()
+
+	// The same status is usually shared by several issue types; list it once
+	// and record which issue types use it.
+	var states []*models.WorkflowState
+	byID := make(map[string]*models.WorkflowState)
+	for _, issueType := range issueTypes {
+		for _, status := range issueType.Statuses {
+			if state, ok := byID[status.ID]; ok {
+				state.Scope += ", " + issueType.Name
+				continue
+			}
+
+			state := &models.WorkflowState{
+				ID:       status.ID,
+				Name:     status.Name,
+				Category: status.StatusCategory.Key,
+				Scope:    issueType.Name,
+				Status:   convertJiraStatus(status.StatusCategory.Key),
+			}
+			if mapped, ok := c.statusMap.Lookup(status.Name); ok {
+				state.Status = mapped
+				state.Mapped = true
+			}
+
+			byID[status.ID] = state
+			states = append(states, state)
+		}
+	}
+
+	return states, nil
+}
+
+// ArchiveTask moves the issue to Done and labels it archived. Jira's own
+// archive needs a Premium plan, so archived issues stay searchable with
+// labels = archived.
+func (c *Client) ArchiveTask(ctx context.Context, task *models.Task) error {
+	archived := *task
+	archived.Labels = append([]string(nil), task.Labels...)
+	archived.AddLabel(platforms.ArchivedLabel)
+	archived.Status = models.StatusDone
+
+	_, err := c.UpdateTask(ctx, &archived)
+	return err
+}
+
+// SupportsTextSearch reports that ListTasks searches TaskFilter.Query with
+// JQL.
+func (c *Client) SupportsTextSearch() bool {
+	return true
+}
+
+// Mention returns the account ID mention of user, which Jira notifies the
+// user of. On the v3 API it becomes an ADF mention node.
+func (c *Client) Mention(user *models.User) string {
+	if accountID, ok := user.GetMetadata("jira_account_id"); ok {
+		if accountID, ok := accountID.(string); ok && accountID != "" {
+			return "[~accountid:" + accountID + "]"
+		}
+	}
+	return ""
+}
+
+// AddComment adds a comment to the issue. Under the v3 API the Markdown
+// body is sent as Atlassian Document Format.
+func (c *Client) AddComment(ctx context.Context, taskID, body string) error {
+	_, resp, err := c.client.Issue.AddCommentWithContext(ctx, taskID, &jira.Comment{Body: body})
+	if err != nil {
+		defer closeBody(resp)
+		return c.apiError("add comment", taskID, resp, err)
+	}
+	resp.Body.Close()
+	return nil
+}
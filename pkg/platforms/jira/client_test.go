@@ -2,15 +2,25 @@ package jira
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"opentask/pkg/models"
 	"opentask/pkg/platforms"
+	"opentask/pkg/record"
+	"opentask/pkg/throttle"
 
 	"github.com/andygrunwald/go-jira"
 	"github.com/stretchr/testify/assert"
@@ -110,6 +120,24 @@ func TestNewClient(t *testing.T) {
 			expectError: true,
 			errorCode:   platforms.ErrInvalidConfig,
 		},
+		{
+			name: "personal access token without email",
+			config: Config{
+				BaseURL:  "https://jira.example.com",
+				Token:    "pat123",
+				AuthType: AuthPAT,
+			},
+			expectError: false,
+		},
+		{
+			name: "personal access token missing",
+			config: Config{
+				BaseURL:  "https://jira.example.com",
+				AuthType: AuthPAT,
+			},
+			expectError: true,
+			errorCode:   platforms.ErrInvalidConfig,
+		},
 	}
 
 	for _, tt := range tests {
@@ -143,10 +171,19 @@ func TestClient_CreateTask(t *testing.T) {
 			switch r.URL.Path {
 			case "/rest/api/2/issue":
 				if r.Method == "POST" {
-					response := mockJiraIssue
+					// Jira only returns identifiers for newly created issues
+					response := map[string]string{
+						"id":   mockJiraIssue.ID,
+						"key":  mockJiraIssue.Key,
+						"self": "https://example.atlassian.net/rest/api/2/issue/12345",
+					}
 					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusCreated)
 					json.NewEncoder(w).Encode(response)
 				}
+			case "/rest/api/2/myself":
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(mockJiraUser)
 			default:
 				w.WriteHeader(http.StatusNotFound)
 			}
@@ -237,8 +274,8 @@ func TestClient_GetTask(t *testing.T) {
 	if os.Getenv("JIRA_TOKEN") == "" {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			switch r.URL.Path {
-			case "/rest/api/2/issue":
-				if r.Method == "POST" {
+			case "/rest/api/2/issue/TEST-123":
+				if r.Method == "GET" {
 					response := mockJiraIssue
 					w.Header().Set("Content-Type", "application/json")
 					json.NewEncoder(w).Encode(response)
@@ -596,12 +633,45 @@ func TestClient_ListProjects(t *testing.T) {
 	assert.Equal(t, models.PlatformJira, projects[0].Platform)
 }
 
+func TestClient_ListProjects_Paginated(t *testing.T) {
+	var starts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rest/api/2/project/search", r.URL.Path)
+		starts = append(starts, r.URL.Query().Get("startAt"))
+
+		start, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		var values []map[string]any
+		for i := start; i < start+projectPageSize && i < 120; i++ {
+			values = append(values, map[string]any{"id": strconv.Itoa(10000 + i), "key": fmt.Sprintf("P%d", i), "name": fmt.Sprintf("Project %d", i)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"startAt": start,
+			"total":   120,
+			"isLast":  start+len(values) >= 120,
+			"values":  values,
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Email: "test@example.com", Token: "token123"})
+	require.NoError(t, err)
+
+	projects, err := client.ListProjects(context.Background())
+	require.NoError(t, err)
+	require.Len(t, projects, 120)
+	assert.Equal(t, []string{"0", "50", "100"}, starts)
+	assert.Equal(t, "P0", projects[0].Key)
+	assert.Equal(t, "10119", projects[119].ID)
+}
+
 func TestClient_GetProject(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case "/rest/api/2/project/TEST":
 			if r.Method == "GET" {
 				response := mockJiraProject
+				response.Lead = mockJiraUser
 				w.Header().Set("Content-Type", "application/json")
 				json.NewEncoder(w).Encode(response)
 			}
@@ -658,6 +728,9 @@ func TestClient_GetProject(t *testing.T) {
 				assert.Equal(t, "proj1", project.ID)
 				assert.Equal(t, "TEST", project.Key)
 				assert.Equal(t, models.PlatformJira, project.Platform)
+				assert.Equal(t, "https://example.atlassian.net/browse/TEST", project.Metadata["jira_url"])
+				require.NotNil(t, project.Lead)
+				assert.Equal(t, "John Doe", project.Lead.Name)
 			}
 		})
 	}
@@ -699,8 +772,21 @@ func TestClient_GetCurrentUser(t *testing.T) {
 }
 
 func TestClient_SearchUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/user/search":
+			assert.Equal(t, "john doe", r.URL.Query().Get("query"))
+			response := []jira.User{mockJiraUser}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(response)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
 	config := Config{
-		BaseURL: "https://example.atlassian.net",
+		BaseURL: server.URL,
 		Email:   "test@example.com",
 		Token:   "token123",
 	}
@@ -708,11 +794,15 @@ func TestClient_SearchUsers(t *testing.T) {
 	client, err := NewClient(config)
 	require.NoError(t, err)
 
-	users, err := client.SearchUsers(context.Background(), "john")
+	users, err := client.SearchUsers(context.Background(), "john doe")
 
 	assert.NoError(t, err)
-	assert.NotNil(t, users)
-	assert.Len(t, users, 0) // Current implementation returns empty slice
+	require.Len(t, users, 1)
+	assert.Equal(t, "user123", users[0].ID)
+	assert.Equal(t, "John Doe", users[0].Name)
+	accountID, ok := users[0].GetMetadata("jira_account_id")
+	assert.True(t, ok)
+	assert.Equal(t, "user123", accountID)
 }
 
 func TestClient_GetPlatformInfo(t *testing.T) {
@@ -813,6 +903,21 @@ func TestBuildJQLQuery(t *testing.T) {
 			},
 			expected: `(labels = "bug" AND labels = "urgent") ORDER BY created DESC`,
 		},
+		{
+			name: "updated since filter",
+			filter: &models.TaskFilter{
+				UpdatedSince: func() *time.Time { t := time.Date(2024, 6, 30, 9, 5, 30, 0, time.Local); return &t }(),
+			},
+			expected: `updated >= "2024/06/30 09:05" ORDER BY created DESC`,
+		},
+		{
+			name: "updated before filter",
+			filter: &models.TaskFilter{
+				Status:        func() *models.TaskStatus { s := models.StatusOpen; return &s }(),
+				UpdatedBefore: func() *time.Time { t := time.Date(2024, 6, 30, 9, 5, 30, 0, time.Local); return &t }(),
+			},
+			expected: `status = "To Do" AND updated < "2024/06/30 09:05" ORDER BY updated ASC`,
+		},
 		{
 			name: "query filter",
 			filter: &models.TaskFilter{
@@ -835,7 +940,7 @@ func TestBuildJQLQuery(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := buildJQLQuery(tt.filter)
+			result := buildJQLQuery(tt.filter, nil)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -868,7 +973,55 @@ func BenchmarkBuildJQLQuery(b *testing.B) {
 	}
 
 	for i := 0; i < b.N; i++ {
-		_ = buildJQLQuery(filter)
+		_ = buildJQLQuery(filter, nil)
+	}
+}
+
+// BenchmarkClient_ListTasks lists a full page of issues, decoding
+// included.
+func BenchmarkClient_ListTasks(b *testing.B) {
+	issues := make([]jira.Issue, 500)
+	for i := range issues {
+		issues[i] = mockJiraIssue
+		issues[i].Key = fmt.Sprintf("TEST-%d", i+1)
+	}
+	body, err := json.Marshal(map[string]any{"issues": issues, "maxResults": len(issues), "total": len(issues)})
+	if err != nil {
+		b.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Email: "test@example.com", Token: "token123"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	ctx := context.Background()
+	filter := &models.TaskFilter{ProjectID: "TEST", Limit: len(issues)}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tasks, err := client.ListTasks(ctx, filter)
+		if err != nil || len(tasks) != len(issues) {
+			b.Fatalf("listed %d tasks: %v", len(tasks), err)
+		}
+	}
+}
+
+func BenchmarkClient_toTask(b *testing.B) {
+	client, err := NewClient(Config{BaseURL: "https://example.atlassian.net", Email: "test@example.com", Token: "token123"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	issue := mockJiraIssue
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = client.toTask(&issue)
 	}
 }
 
@@ -924,3 +1077,1643 @@ func TestIntegration(t *testing.T) {
 	// or when explicitly enabled for integration testing
 	t.Skip("Integration test requires real Jira instance")
 }
+
+func TestClient_CreateTask_RequiredFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue" || r.Method != "POST" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var body struct {
+			Fields map[string]any `json:"fields"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		if _, ok := body.Fields["customfield_10011"]; !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{
+				"errorMessages": []string{},
+				"errors": map[string]string{
+					"customfield_10011": "Epic Name is required.",
+					"components":        "Component/s is required.",
+				},
+			})
+			return
+		}
+
+		assert.Equal(t, []any{map[string]any{"name": "API"}}, body.Fields["components"])
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"id": "10001", "key": "TEST-124"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Email: "test@example.com", Token: "token123"})
+	require.NoError(t, err)
+
+	task := models.NewTask("Epic task", models.PlatformJira)
+	task.ProjectID = "10000"
+
+	_, err = client.CreateTask(context.Background(), task)
+	require.Error(t, err)
+
+	var missing *platforms.RequiredFieldsError
+	require.ErrorAs(t, err, &missing)
+	assert.Equal(t, []string{"components", "customfield_10011"}, missing.FieldIDs())
+
+	var platErr *platforms.PlatformError
+	require.ErrorAs(t, err, &platErr)
+	assert.Equal(t, platforms.ErrInvalidInput, platErr.Code)
+
+	task.SetMetadata(CustomFieldsKey, map[string]string{
+		"customfield_10011": "Checkout revamp",
+		"components":        "API",
+	})
+
+	created, err := client.CreateTask(context.Background(), task)
+	require.NoError(t, err)
+	assert.Equal(t, "TEST-124", created.ID)
+	assert.Equal(t, "Epic task", created.Title)
+}
+
+func TestClient_CreateTask_Invalid(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Email: "test@example.com", Token: "token123"})
+	require.NoError(t, err)
+
+	task := models.NewTask(" ", models.PlatformJira)
+	task.Priority = "critical"
+	task.Labels = []string{"backend", "", "backend"}
+
+	_, err = client.CreateTask(context.Background(), task)
+	require.Error(t, err)
+	assert.Zero(t, requests, "invalid tasks are not sent")
+
+	var platErr *platforms.PlatformError
+	require.ErrorAs(t, err, &platErr)
+	assert.Equal(t, platforms.ErrInvalidInput, platErr.Code)
+
+	var invalid *models.ValidationError
+	require.ErrorAs(t, err, &invalid)
+	fields := make([]string, 0, len(invalid.Fields))
+	for _, field := range invalid.Fields {
+		fields = append(fields, field.Field)
+	}
+	assert.Equal(t, []string{"title", "priority", "labels[1]", "labels[2]"}, fields)
+
+	_, err = client.UpdateTask(context.Background(), task)
+	require.ErrorAs(t, err, &invalid)
+	assert.Zero(t, requests)
+}
+
+func TestClient_CreateTask_CreateMeta(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/2/issue/createmeta/TEST/issuetypes":
+			json.NewEncoder(w).Encode(map[string]any{
+				"issueTypes": []map[string]any{{"id": "1", "name": "Task"}, {"id": "2", "name": "Bug"}},
+				"total":      2,
+			})
+		case "/rest/api/2/issue/createmeta/TEST/issuetypes/1":
+			json.NewEncoder(w).Encode(map[string]any{
+				"fields": []map[string]any{
+					{"fieldId": "summary", "name": "Summary", "required": true},
+					{"fieldId": "reporter", "name": "Reporter", "required": true, "hasDefaultValue": true},
+					{"fieldId": "customfield_10011", "name": "Epic Name", "required": true},
+					{"fieldId": "customfield_10050", "name": "Severity", "required": true, "allowedValues": []map[string]any{{"value": "S1"}, {"value": "S2"}}},
+					{"fieldId": "customfield_10060", "name": "Notes"},
+				},
+				"total": 5,
+			})
+		case "/rest/api/2/issue":
+			posts++
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]string{"id": "10001", "key": "TEST-124"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Email: "test@example.com", Token: "token123"})
+	require.NoError(t, err)
+
+	task := models.NewTask("Epic task", models.PlatformJira)
+	task.ProjectID = "TEST"
+	task.SetMetadata(CustomFieldsKey, map[string]string{"customfield_10011": "Checkout"})
+
+	_, err = client.CreateTask(context.Background(), task)
+	var missing *platforms.RequiredFieldsError
+	require.ErrorAs(t, err, &missing)
+	assert.Equal(t, map[string]string{"customfield_10050": "Severity is required (one of: S1, S2)."}, missing.Fields)
+	assert.Zero(t, posts)
+
+	task.SetMetadata(CustomFieldsKey, map[string]string{"customfield_10011": "Checkout", "customfield_10050": `{"value": "S2"}`})
+	_, err = client.CreateTask(context.Background(), task)
+	require.NoError(t, err)
+	assert.Equal(t, 1, posts)
+
+	task.SetMetadata(IssueTypeKey, "Story")
+	_, err = client.CreateTask(context.Background(), task)
+	assert.ErrorContains(t, err, `project TEST has no issue type "Story" (available: Task, Bug)`)
+	assert.Equal(t, 1, posts)
+}
+
+// createMetaHandler answers createmeta requests with a Task issue type that
+// has one required custom field.
+func createMetaHandler(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"issueTypes": []map[string]any{{"id": "1", "name": "Task"}},
+		"fields":     []map[string]any{{"fieldId": "customfield_10050", "name": "Severity", "required": true}},
+		"total":      1,
+	})
+}
+
+func TestClient_CreateMeta_FailuresAreNotCached(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		createMetaHandler(w)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Email: "test@example.com", Token: "token123"})
+	require.NoError(t, err)
+
+	meta, err := client.createMeta(context.Background(), "TEST", "Task")
+	require.NoError(t, err)
+	assert.Nil(t, meta, "a failed fetch leaves field discovery to Jira")
+
+	failing.Store(false)
+	meta, err = client.createMeta(context.Background(), "TEST", "Task")
+	require.NoError(t, err)
+	require.NotNil(t, meta)
+	assert.Contains(t, meta.required, "customfield_10050")
+
+	fetched := requests.Load()
+	_, err = client.createMeta(context.Background(), "TEST", "Task")
+	require.NoError(t, err)
+	assert.Equal(t, fetched, requests.Load(), "a successful fetch is cached")
+}
+
+func TestClient_CreateMeta_ProjectsFetchIndependently(t *testing.T) {
+	slowStarted := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/createmeta/SLOW/") {
+			once.Do(func() { close(slowStarted) })
+			<-release
+		}
+		createMetaHandler(w)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewClient(Config{BaseURL: server.URL, Email: "test@example.com", Token: "token123"})
+	require.NoError(t, err)
+
+	go client.createMeta(context.Background(), "SLOW", "Task")
+	<-slowStarted
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		meta, err := client.createMeta(context.Background(), "TEST", "Task")
+		assert.NoError(t, err)
+		assert.NotNil(t, meta)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fetching another project's create metadata waited for the slow one")
+	}
+}
+
+func TestClient_ListTasks_Pages(t *testing.T) {
+	const total = 250
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		pages = append(pages, fmt.Sprintf("%d+%d", startAt, maxResults))
+
+		var issues []map[string]any
+		for i := startAt; i < min(startAt+maxResults, total); i++ {
+			issues = append(issues, map[string]any{"id": strconv.Itoa(10000 + i), "key": fmt.Sprintf("TEST-%d", i+1), "fields": map[string]any{"summary": "Task"}})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"startAt": startAt, "maxResults": maxResults, "total": total, "issues": issues})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Email: "test@example.com", Token: "token123"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		limit int
+		want  int
+		pages []string
+	}{
+		{limit: 0, want: 50, pages: []string{"0+50"}},
+		{limit: 120, want: 120, pages: []string{"0+100", "100+20"}},
+		{limit: 500, want: total, pages: []string{"0+100", "100+100", "200+100"}},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.limit), func(t *testing.T) {
+			pages = nil
+			tasks, err := client.ListTasks(context.Background(), &models.TaskFilter{Limit: tt.limit})
+			require.NoError(t, err)
+			assert.Len(t, tasks, tt.want)
+			assert.Equal(t, tt.pages, pages)
+			assert.Equal(t, fmt.Sprintf("TEST-%d", tt.want), tasks[len(tasks)-1].ID)
+		})
+	}
+}
+
+func TestClient_ListWorkflowStates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/project/TEST/statuses":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[
+				{"name": "Bug", "statuses": [
+					{"id": "1", "name": "To Do", "statusCategory": {"key": "new"}},
+					{"id": "3", "name": "In Review", "statusCategory": {"key": "indeterminate"}},
+					{"id": "5", "name": "Won't Do", "statusCategory": {"key": "done"}}
+				]},
+				{"name": "Story", "statuses": [
+					{"id": "1", "name": "To Do", "statusCategory": {"key": "new"}}
+				]}
+			]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewFactory().Create(map[string]any{
+		"base_url": server.URL,
+		"email":    "test@example.com",
+		"token":    "token123",
+		"status_map": map[string]any{
+			"won't do": "cancelled",
+		},
+	})
+	require.NoError(t, err)
+
+	states, err := client.(*Client).ListWorkflowStates(context.Background(), "TEST")
+	require.NoError(t, err)
+	require.Len(t, states, 3)
+
+	assert.Equal(t, "To Do", states[0].Name)
+	assert.Equal(t, "Bug, Story", states[0].Scope)
+	assert.Equal(t, models.StatusOpen, states[0].Status)
+
+	assert.Equal(t, models.StatusInProgress, states[1].Status)
+	assert.False(t, states[1].Mapped)
+
+	assert.Equal(t, models.StatusCancelled, states[2].Status)
+	assert.True(t, states[2].Mapped)
+
+	_, err = client.(*Client).ListWorkflowStates(context.Background(), "MISSING")
+	assert.True(t, platforms.IsNotFoundError(err))
+}
+
+func TestParseConfig_InvalidStatusMap(t *testing.T) {
+	_, err := parseConfig(map[string]any{
+		"base_url":   "https://example.atlassian.net",
+		"email":      "test@example.com",
+		"token":      "token123",
+		"status_map": map[string]any{"In Review": "reviewing"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid status")
+}
+
+func TestParseConfig_StatusNames(t *testing.T) {
+	cfg, err := parseConfig(map[string]any{
+		"base_url": "https://example.atlassian.net",
+		"email":    "test@example.com",
+		"token":    "token123",
+		"status_map": map[string]any{
+			"done":        "Resolved",
+			"in_progress": "In Review",
+			"In Review":   "open",
+			"Won't Do":    "cancelled",
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, platforms.StatusNames{
+		models.StatusDone:       "Resolved",
+		models.StatusInProgress: "In Review",
+	}, cfg.StatusNames)
+
+	// A named status maps back unless the state has its own entry.
+	status, ok := cfg.StatusMap.Lookup("resolved")
+	require.True(t, ok)
+	assert.Equal(t, models.StatusDone, status)
+	status, _ = cfg.StatusMap.Lookup("In Review")
+	assert.Equal(t, models.StatusOpen, status)
+	status, _ = cfg.StatusMap.Lookup("Won't Do")
+	assert.Equal(t, models.StatusCancelled, status)
+
+	done := models.StatusDone
+	assert.Equal(t, `status = "Resolved" ORDER BY created DESC`, buildJQLQuery(&models.TaskFilter{Status: &done}, cfg.StatusNames))
+	cancelled := models.StatusCancelled
+	assert.Equal(t, `status = "Cancelled" ORDER BY created DESC`, buildJQLQuery(&models.TaskFilter{Status: &cancelled}, cfg.StatusNames))
+}
+
+func TestClient_PermissionErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/2/myself":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(mockJiraUser)
+		case r.URL.Path == "/rest/api/2/issue" && r.Method == http.MethodPost:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorMessages":[],"errors":{"project":"You do not have permission to create issues in this project."}}`))
+		case r.URL.Path == "/rest/api/2/issue/TEST-1" && r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusForbidden)
+		case r.URL.Path == "/rest/api/2/search":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "token123",
+	})
+	require.NoError(t, err)
+
+	task := models.NewTask("Test", models.PlatformJira)
+	task.ProjectID = "TEST"
+	_, err = client.CreateTask(context.Background(), task)
+	require.Error(t, err)
+	assert.True(t, platforms.IsPermissionError(err))
+	assert.Contains(t, err.Error(), "CREATE_ISSUES")
+	assert.Contains(t, err.Error(), "You do not have permission to create issues")
+
+	err = client.DeleteTask(context.Background(), "TEST-1")
+	require.Error(t, err)
+	assert.True(t, platforms.IsPermissionError(err))
+	assert.Contains(t, err.Error(), "DELETE_ISSUES")
+	assert.Contains(t, err.Error(), "test@example.com")
+
+	_, err = client.ListTasks(context.Background(), nil)
+	require.Error(t, err)
+	assert.True(t, platforms.IsAuthenticationError(err))
+	assert.Contains(t, err.Error(), "opentask connect jira")
+}
+
+func TestClient_StatusErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/rest/api/2/project/TEST":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/rest/api/2/issue/TEST-9/comment":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorMessages":["Issue does not exist or you do not have permission to see it."],"errors":{}}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+
+	client, err := NewClient(Config{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "token123",
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.ListTasks(ctx, nil)
+	assert.True(t, platforms.IsRateLimitError(err))
+	retry, ok := platforms.RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, retry)
+	assert.Contains(t, err.Error(), "retry after 30s")
+
+	_, err = client.GetProject(ctx, "TEST")
+	assert.True(t, platforms.IsNetworkError(err))
+
+	err = client.AddComment(ctx, "TEST-9", "ping")
+	assert.True(t, platforms.IsNotFoundError(err))
+
+	_, err = client.ListPriorities(ctx)
+	code, _ := platforms.CodeOf(err)
+	assert.Equal(t, platforms.ErrPlatformAPI, code)
+
+	server.Close()
+	_, err = client.GetCurrentUser(ctx)
+	assert.True(t, platforms.IsNetworkError(err))
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: 0},
+		{value: "120", want: 2 * time.Minute},
+		{value: "-5", want: 0},
+		{value: "Sun, 01 Mar 2026 12:00:45 GMT", want: 45 * time.Second},
+		{value: "Sun, 01 Mar 2026 11:59:00 GMT", want: 0},
+		{value: "soon", want: 0},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, retryAfter(tt.value, now), tt.value)
+	}
+}
+
+func TestClient_ProbeCapabilities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/mypermissions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Contains(t, r.URL.Query().Get("permissions"), "DELETE_ISSUES")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"permissions": {
+			"BROWSE_PROJECTS": {"havePermission": true},
+			"USER_PICKER": {"havePermission": true},
+			"CREATE_ISSUES": {"havePermission": true},
+			"EDIT_ISSUES": {"havePermission": true},
+			"TRANSITION_ISSUES": {"havePermission": true},
+			"ASSIGN_ISSUES": {"havePermission": false},
+			"DELETE_ISSUES": {"havePermission": false}
+		}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Email: "test@example.com", Token: "token123"})
+	require.NoError(t, err)
+
+	checks, err := client.ProbeCapabilities(context.Background())
+	require.NoError(t, err)
+	require.Len(t, checks, len(platforms.AllCapabilities))
+
+	settings := map[string]any{platforms.CapabilitiesKey: platforms.CapabilitySettings(checks)}
+	tests := []struct {
+		capability platforms.Capability
+		allowed    bool
+	}{
+		{platforms.CapabilityListTasks, true},
+		{platforms.CapabilityCreateTask, true},
+		{platforms.CapabilityTransitionTask, true},
+		{platforms.CapabilityAssignTask, false},
+		{platforms.CapabilityDeleteTask, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.capability), func(t *testing.T) {
+			assert.Equal(t, tt.allowed, platforms.HasCapability(settings, tt.capability))
+		})
+	}
+
+	err = platforms.RequireCapability("jira", settings, platforms.CapabilityDeleteTask)
+	assert.True(t, platforms.IsPermissionError(err))
+	assert.NoError(t, platforms.RequireCapability("jira", map[string]any{}, platforms.CapabilityDeleteTask))
+}
+
+func TestClient_Webhooks(t *testing.T) {
+	var registered map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/webhooks/1.0/webhook":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&registered))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{
+				"self": "` + "http://" + r.Host + `/rest/webhooks/1.0/webhook/42",
+				"name": "OpenTask",
+				"url": "https://hooks.example.com/jira",
+				"events": ["jira:issue_created", "jira:issue_updated", "jira:issue_deleted"]
+			}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/rest/webhooks/1.0/webhook/42":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Email: "test@example.com", Token: "token123"})
+	require.NoError(t, err)
+
+	webhook, err := client.RegisterWebhook(context.Background(), platforms.WebhookOptions{
+		URL:     "https://hooks.example.com/jira",
+		Project: "TEST",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "42", webhook.ID)
+	assert.Equal(t, "https://hooks.example.com/jira", webhook.URL)
+	assert.Len(t, webhook.Events, 3)
+	assert.Equal(t, map[string]any{"issue-related-events-section": `project = "TEST"`}, registered["filters"])
+
+	settings := map[string]any{platforms.WebhookKey: platforms.WebhookSettings(webhook)}
+	recorded, ok := platforms.RegisteredWebhook(settings)
+	require.True(t, ok)
+	assert.Equal(t, "42", recorded.ID)
+
+	require.NoError(t, client.UnregisterWebhook(context.Background(), "42"))
+
+	err = client.UnregisterWebhook(context.Background(), "7")
+	assert.True(t, platforms.IsNotFoundError(err))
+}
+
+func TestClient_ParseWebhook(t *testing.T) {
+	client, err := NewClient(Config{BaseURL: "https://example.atlassian.net", Email: "test@example.com", Token: "token123"})
+	require.NoError(t, err)
+
+	updated := []byte(`{"webhookEvent": "jira:issue_updated", "issue": {"id": "10001", "key": "TEST-1"}}`)
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(updated)
+	signed := http.Header{"X-Hub-Signature": {"sha256=" + hex.EncodeToString(mac.Sum(nil))}}
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		body    []byte
+		secret  string
+		want    *platforms.WebhookEvent
+		wantErr platforms.ErrorCode
+	}{
+		{name: "updated", body: updated, want: &platforms.WebhookEvent{Action: platforms.WebhookUpdated, TaskID: "TEST-1"}},
+		{name: "deleted", body: []byte(`{"webhookEvent": "jira:issue_deleted", "issue": {"key": "TEST-2"}}`), want: &platforms.WebhookEvent{Action: platforms.WebhookDeleted, TaskID: "TEST-2"}},
+		{name: "other event", body: []byte(`{"webhookEvent": "comment_created", "comment": {"id": "1"}}`)},
+		{name: "signed", header: signed, body: updated, secret: "s3cret", want: &platforms.WebhookEvent{Action: platforms.WebhookUpdated, TaskID: "TEST-1"}},
+		{name: "unsigned", body: updated, secret: "s3cret", wantErr: platforms.ErrAuthentication},
+		{name: "wrong secret", header: signed, body: updated, secret: "other", wantErr: platforms.ErrAuthentication},
+		{name: "not JSON", body: []byte("<html>"), wantErr: platforms.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := tt.header
+			if header == nil {
+				header = http.Header{}
+			}
+			event, err := client.ParseWebhook(header, tt.body, tt.secret)
+			if tt.wantErr != "" {
+				var platformErr *platforms.PlatformError
+				require.ErrorAs(t, err, &platformErr)
+				assert.Equal(t, tt.wantErr, platformErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, event)
+		})
+	}
+}
+
+func TestClient_Transitions(t *testing.T) {
+	var performed string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/2/issue/TEST-123/transitions" && r.Method == http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"transitions": [
+				{"id": "11", "name": "Start Progress", "to": {"name": "In Progress", "statusCategory": {"key": "indeterminate"}}},
+				{"id": "31", "name": "Won't Fix", "to": {"name": "Won't Do", "statusCategory": {"key": "done"}}}
+			]}`))
+		case r.URL.Path == "/rest/api/2/issue/TEST-123/transitions" && r.Method == http.MethodPost:
+			var body struct {
+				Transition struct {
+					ID string `json:"id"`
+				} `json:"transition"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			performed = body.Transition.ID
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(mockJiraIssue)
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewFactory().Create(map[string]any{
+		"base_url":   server.URL,
+		"email":      "test@example.com",
+		"token":      "token123",
+		"status_map": map[string]any{"won't do": "cancelled"},
+	})
+	require.NoError(t, err)
+
+	transitions, err := client.(*Client).GetAvailableTransitions(context.Background(), "TEST-123")
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+	assert.Equal(t, platforms.Transition{ID: "11", Name: "Start Progress", To: "In Progress", Status: models.StatusInProgress}, transitions[0])
+	assert.Equal(t, models.StatusCancelled, transitions[1].Status)
+	assert.Equal(t, []models.TaskStatus{models.StatusInProgress, models.StatusCancelled}, platforms.TransitionStatuses(transitions))
+
+	task := &models.Task{ID: "TEST-123", Title: "Test Issue", Status: models.StatusCancelled, Platform: models.PlatformJira}
+	_, err = client.UpdateTask(context.Background(), task)
+	require.NoError(t, err)
+	assert.Equal(t, "31", performed)
+
+	task.Status = models.StatusDone
+	_, err = client.UpdateTask(context.Background(), task)
+	var invalid *platforms.InvalidTransitionError
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, models.StatusOpen, invalid.From)
+	assert.Contains(t, err.Error(), `allowed: in_progress ("Start Progress"), cancelled ("Won't Fix")`)
+
+	err = platforms.ValidateTransition(context.Background(), client, &models.Task{ID: "TEST-123", Status: models.StatusOpen, Platform: models.PlatformJira}, models.StatusDone)
+	assert.ErrorAs(t, err, &invalid)
+	assert.NoError(t, platforms.ValidateTransition(context.Background(), client, &models.Task{ID: "TEST-123", Status: models.StatusOpen}, models.StatusInProgress))
+}
+
+func TestClient_ArchiveTask(t *testing.T) {
+	var performed string
+	var sentLabels []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/2/issue/TEST-123/transitions" && r.Method == http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"transitions": [
+				{"id": "11", "name": "Start Progress", "to": {"name": "In Progress", "statusCategory": {"key": "indeterminate"}}},
+				{"id": "41", "name": "Resolve", "to": {"name": "Done", "statusCategory": {"key": "done"}}}
+			]}`))
+		case r.URL.Path == "/rest/api/2/issue/TEST-123/transitions" && r.Method == http.MethodPost:
+			var body struct {
+				Transition struct {
+					ID string `json:"id"`
+				} `json:"transition"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			performed = body.Transition.ID
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(mockJiraIssue)
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodPut:
+			var body struct {
+				Fields struct {
+					Labels []string `json:"labels"`
+				} `json:"fields"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			sentLabels = body.Fields.Labels
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewFactory().Create(map[string]any{
+		"base_url": server.URL,
+		"email":    "test@example.com",
+		"token":    "token123",
+	})
+	require.NoError(t, err)
+
+	task := &models.Task{ID: "TEST-123", Title: "Test Issue", Status: models.StatusOpen, Labels: []string{"backend"}}
+	err = client.(platforms.Archiver).ArchiveTask(context.Background(), task)
+	require.NoError(t, err)
+
+	assert.Equal(t, "41", performed)
+	assert.Equal(t, []string{"backend", platforms.ArchivedLabel}, sentLabels)
+	assert.Equal(t, []string{"backend"}, task.Labels, "the task passed in is left unchanged")
+	assert.Equal(t, models.StatusOpen, task.Status)
+}
+
+func TestClient_Priorities(t *testing.T) {
+	var sentPriority string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/2/priority":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[{"id": "1", "name": "P0"}, {"id": "2", "name": "P1"}, {"id": "3", "name": "P2"}, {"id": "4", "name": "P3"}]`))
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodGet:
+			issue := mockJiraIssue
+			fields := *issue.Fields
+			fields.Priority = &jira.Priority{Name: "P1"}
+			issue.Fields = &fields
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(issue)
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodPut:
+			var body struct {
+				Fields struct {
+					Priority struct {
+						Name string `json:"name"`
+					} `json:"priority"`
+				} `json:"fields"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			sentPriority = body.Fields.Priority.Name
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	settings := map[string]any{
+		"base_url": server.URL,
+		"email":    "test@example.com",
+		"token":    "token123",
+		"priority_map": map[string]any{
+			"urgent": "P0",
+			"P1":     "high",
+			"medium": "P2",
+			"low":    "P3",
+		},
+	}
+	client, err := NewFactory().Create(settings)
+	require.NoError(t, err)
+
+	task, err := client.GetTask(context.Background(), "TEST-123")
+	require.NoError(t, err)
+	assert.Equal(t, models.PriorityHigh, task.Priority)
+
+	_, err = client.UpdateTask(context.Background(), &models.Task{ID: "TEST-123", Title: "Test Issue", Priority: models.PriorityUrgent})
+	require.NoError(t, err)
+	assert.Equal(t, "P0", sentPriority)
+
+	available, err := client.(*Client).ListPriorities(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"P0", "P1", "P2", "P3"}, available)
+	assert.NoError(t, platforms.ValidatePriorityMap(settings, available))
+
+	settings["priority_map"] = map[string]any{"urgent": "Blocker", "p9": "low", "p1": "high"}
+	err = platforms.ValidatePriorityMap(settings, available)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown priorities "Blocker", "p9"; the platform has P0, P1, P2, P3`)
+}
+
+func TestParseConfig_InvalidPriorityMap(t *testing.T) {
+	_, err := parseConfig(map[string]any{
+		"base_url":     "https://example.atlassian.net",
+		"email":        "test@example.com",
+		"token":        "token123",
+		"priority_map": map[string]any{"P0": "critical"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid priority")
+}
+
+func TestClient_LabelMap(t *testing.T) {
+	var sentLabels []string
+	var jql string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodGet:
+			issue := mockJiraIssue
+			fields := *issue.Fields
+			fields.Labels = []string{"p1", "backend", "Blocker"}
+			issue.Fields = &fields
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(issue)
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodPut:
+			var body struct {
+				Fields struct {
+					Labels []string `json:"labels"`
+				} `json:"fields"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			sentLabels = body.Fields.Labels
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/rest/api/2/search":
+			jql = r.URL.Query().Get("jql")
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"issues": [], "total": 0}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewFactory().Create(map[string]any{
+		"base_url": server.URL,
+		"email":    "test@example.com",
+		"token":    "token123",
+		"label_map": map[string]any{
+			"urgent": []any{"p1", "blocker"},
+			"bug":    "Defect",
+		},
+	})
+	require.NoError(t, err)
+
+	task, err := client.GetTask(context.Background(), "TEST-123")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"urgent", "backend"}, task.Labels)
+
+	_, err = client.UpdateTask(context.Background(), &models.Task{ID: "TEST-123", Title: "Test Issue", Labels: []string{"Bug", "urgent", "backend"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Defect", "p1", "backend"}, sentLabels)
+
+	_, err = client.ListTasks(context.Background(), &models.TaskFilter{Labels: []string{"urgent"}})
+	require.NoError(t, err)
+	assert.Contains(t, jql, `labels = "p1"`)
+}
+
+func TestClient_ListVocabulary(t *testing.T) {
+	var jql string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			jql = r.URL.Query().Get("jql")
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"issues": [
+				{"key": "TEST-1", "fields": {"labels": ["p1", "backend"]}},
+				{"key": "TEST-2", "fields": {"labels": ["backend", "docs"]}}
+			], "total": 2}`))
+		case "/rest/api/2/project/TEST":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "proj1", "key": "TEST", "components": [{"name": "Web"}, {"name": "API"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewFactory().Create(map[string]any{
+		"base_url":  server.URL,
+		"email":     "test@example.com",
+		"token":     "token123",
+		"label_map": map[string]any{"urgent": "p1"},
+	})
+	require.NoError(t, err)
+
+	vocabulary, err := client.(*Client).ListVocabulary(context.Background(), "TEST")
+	require.NoError(t, err)
+	assert.Contains(t, jql, `project = "TEST"`)
+	assert.Equal(t, []string{"backend", "docs", "urgent"}, vocabulary.Labels)
+	assert.Equal(t, []string{"API", "Web"}, vocabulary.Components)
+}
+
+func TestParseConfig_InvalidLabelMap(t *testing.T) {
+	_, err := parseConfig(map[string]any{
+		"base_url":  "https://example.atlassian.net",
+		"email":     "test@example.com",
+		"token":     "token123",
+		"label_map": map[string]any{"bug": 3},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid platform label")
+}
+
+func TestParseConfig_Limits(t *testing.T) {
+	cfg, err := parseConfig(map[string]any{
+		"base_url": "https://example.atlassian.net",
+		"email":    "test@example.com",
+		"token":    "token123",
+		"limits":   map[string]any{"max_in_flight": 4, "requests_per_second": 10},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, throttle.Limits{MaxInFlight: 4, RequestsPerSecond: 10}, cfg.Limits)
+
+	_, err = parseConfig(map[string]any{
+		"base_url": "https://example.atlassian.net",
+		"email":    "test@example.com",
+		"token":    "token123",
+		"limits":   map[string]any{"max_in_flight": -2},
+	})
+	assert.ErrorContains(t, err, "max_in_flight")
+}
+
+func TestClient_ErrorsReleaseInFlightSlot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errorMessages":["Issue does not exist or you do not have permission to see it."],"errors":{}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "token123",
+		Limits:  throttle.Limits{MaxInFlight: 1},
+	})
+	require.NoError(t, err)
+
+	// Each failed request must give its slot back, or the next one waits
+	// for good.
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for range 2 {
+		_, err = client.GetTask(ctx, "TEST-404")
+		assert.True(t, platforms.IsNotFoundError(err), "got %v", err)
+		err = client.UnregisterWebhook(ctx, "42")
+		assert.True(t, platforms.IsNotFoundError(err), "got %v", err)
+		_, err = client.ListWorkflowStates(ctx, "TEST")
+		assert.True(t, platforms.IsNotFoundError(err), "got %v", err)
+		_, err = client.GetProject(ctx, "TEST")
+		assert.True(t, platforms.IsNotFoundError(err), "got %v", err)
+		err = client.DeleteTask(ctx, "TEST-404")
+		assert.True(t, platforms.IsNotFoundError(err), "got %v", err)
+		_, err = client.ListPriorities(ctx)
+		assert.True(t, platforms.IsNotFoundError(err), "got %v", err)
+	}
+}
+
+func TestClient_IssueTypeAndFieldMap(t *testing.T) {
+	var created, updated map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Fields map[string]any `json:"fields"`
+		}
+
+		switch {
+		case r.URL.Path == "/rest/api/2/issue" && r.Method == http.MethodPost:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			created = body.Fields
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]string{"id": "10001", "key": "TEST-124"})
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(mockJiraIssue)
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodPut:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			updated = body.Fields
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewFactory().Create(map[string]any{
+		"base_url":   server.URL,
+		"email":      "test@example.com",
+		"token":      "token123",
+		"issue_type": "Story",
+		"field_map":  map[string]any{"Story_Points": "customfield_10016", "epic_name": "customfield_10011"},
+	})
+	require.NoError(t, err)
+
+	task := models.NewTask("Checkout revamp", models.PlatformJira)
+	task.ProjectID = "10000"
+	task.SetMetadata(CustomFieldsKey, map[string]string{"epic_name": "Checkout", "customfield_10020": "7"})
+
+	_, err = client.CreateTask(context.Background(), task)
+	require.NoError(t, err)
+	assert.Equal(t, "Story", created["issuetype"].(map[string]any)["name"])
+	assert.Equal(t, map[string]any{"id": "10000"}, created["project"])
+	assert.Equal(t, "Checkout", created["customfield_10011"])
+	assert.Equal(t, "7", created["customfield_10020"])
+	assert.NotContains(t, created, "epic_name")
+
+	task.SetMetadata(IssueTypeKey, "Epic")
+	_, err = client.CreateTask(context.Background(), task)
+	require.NoError(t, err)
+	assert.Equal(t, "Epic", created["issuetype"].(map[string]any)["name"])
+
+	update := &models.Task{ID: "TEST-123", Title: "Test Issue"}
+	update.SetMetadata(CustomFieldsKey, map[string]string{"story_points": "5"})
+	_, err = client.UpdateTask(context.Background(), update)
+	require.NoError(t, err)
+	assert.Equal(t, "5", updated["customfield_10016"])
+
+	_, err = parseConfig(map[string]any{
+		"base_url":  "https://example.atlassian.net",
+		"email":     "test@example.com",
+		"token":     "token123",
+		"field_map": map[string]any{"epic_name": 10011},
+	})
+	assert.ErrorContains(t, err, "invalid field ID")
+}
+
+func TestClient_ReporterAndSprint(t *testing.T) {
+	var created, updated map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Fields map[string]any `json:"fields"`
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/2/user/search":
+			assert.Equal(t, "jane@example.com", r.URL.Query().Get("query"))
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"accountId": "acc-1", "displayName": "Jane Roe", "emailAddress": "jane.roe@example.com"},
+				{"accountId": "acc-2", "displayName": "Jane Doe", "emailAddress": "jane@example.com"},
+			})
+		case r.URL.Path == "/rest/api/2/field":
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": "summary", "name": "Summary", "schema": map[string]any{"type": "string", "system": "summary"}},
+				{"id": "customfield_10020", "name": "Sprint", "schema": map[string]any{"type": "array", "custom": sprintSchema}},
+			})
+		case r.URL.Path == "/rest/agile/1.0/board":
+			assert.Equal(t, "TEST", r.URL.Query().Get("projectKeyOrId"))
+			json.NewEncoder(w).Encode(map[string]any{"values": []map[string]any{{"id": 7, "name": "TEST board", "type": "scrum"}}})
+		case r.URL.Path == "/rest/agile/1.0/board/7/sprint":
+			json.NewEncoder(w).Encode(map[string]any{"values": []map[string]any{
+				{"id": 41, "name": "Sprint 41", "state": "active"},
+				{"id": 42, "name": "Sprint 42", "state": "future"},
+			}})
+		case r.URL.Path == "/rest/api/2/issue" && r.Method == http.MethodPost:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			created = body.Fields
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]string{"id": "10001", "key": "TEST-124"})
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(mockJiraIssue)
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodPut:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			updated = body.Fields
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewFactory().Create(map[string]any{
+		"base_url": server.URL,
+		"email":    "test@example.com",
+		"token":    "token123",
+	})
+	require.NoError(t, err)
+
+	task := models.NewTask("Checkout revamp", models.PlatformJira)
+	task.ProjectID = "TEST"
+	task.SetMetadata(CustomFieldsKey, map[string]string{
+		"reporter":     "jane@example.com",
+		"sprint":       "next",
+		"fix_versions": "1.4, 1.5",
+		"components":   "API",
+	})
+
+	_, err = client.CreateTask(context.Background(), task)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"accountId": "acc-2"}, created["reporter"])
+	assert.Equal(t, float64(42), created["customfield_10020"])
+	assert.Equal(t, []any{map[string]any{"name": "1.4"}, map[string]any{"name": "1.5"}}, created["fixVersions"])
+	assert.Equal(t, []any{map[string]any{"name": "API"}}, created["components"])
+	assert.NotContains(t, created, "sprint")
+
+	update := &models.Task{ID: "TEST-123", Title: "Test Issue", ProjectID: "TEST"}
+	update.SetMetadata(CustomFieldsKey, map[string]string{"sprint": "Sprint 41"})
+	_, err = client.UpdateTask(context.Background(), update)
+	require.NoError(t, err)
+	assert.Equal(t, float64(41), updated["customfield_10020"])
+
+	update.SetMetadata(CustomFieldsKey, map[string]string{"sprint": "Sprint 99"})
+	_, err = client.UpdateTask(context.Background(), update)
+	assert.ErrorContains(t, err, `no active or future sprint "Sprint 99"`)
+}
+
+func TestListSites(t *testing.T) {
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/token/accessible-resources":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[
+				{"id": "cloud-1", "name": "acme", "url": "https://acme.atlassian.net", "scopes": ["read:jira-work", "write:jira-work"]},
+				{"id": "cloud-2", "name": "acme-wiki", "url": "https://acme-wiki.atlassian.net", "scopes": ["read:confluence-content.all"]}
+			]`))
+		case "/ex/jira/cloud-1/rest/api/3/issue/TEST-123":
+			// Sites reached through the OAuth gateway are Cloud, so use v3.
+			auth = r.Header.Get("Authorization")
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(mockJiraIssue)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	defaultURL := accessibleResourcesURL
+	accessibleResourcesURL = server.URL + "/oauth/token/accessible-resources"
+	defer func() { accessibleResourcesURL = defaultURL }()
+
+	sites, err := ListSites(context.Background(), "oauth-token")
+	require.NoError(t, err)
+	require.Len(t, sites, 1)
+	assert.Equal(t, "acme", sites[0].Name)
+	assert.Equal(t, "https://api.atlassian.com/ex/jira/cloud-1", sites[0].APIURL())
+
+	client, err := NewFactory().Create(map[string]any{
+		"base_url":     server.URL + "/ex/jira/cloud-1",
+		"site_url":     "https://acme.atlassian.net/",
+		"access_token": "oauth-token",
+	})
+	require.NoError(t, err)
+
+	task, err := client.GetTask(context.Background(), "TEST-123")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer oauth-token", auth)
+	url, _ := task.GetMetadata("jira_url")
+	assert.Equal(t, "https://acme.atlassian.net/browse/TEST-123", url)
+
+	accessibleResourcesURL = server.URL + "/expired"
+	_, err = ListSites(context.Background(), "expired-token")
+	assert.True(t, platforms.IsAuthenticationError(err))
+}
+
+func TestProjectRef(t *testing.T) {
+	tests := []struct {
+		project  string
+		expected jira.Project
+	}{
+		{"10000", jira.Project{ID: "10000"}},
+		{"TEST", jira.Project{Key: "TEST"}},
+		{"API2", jira.Project{Key: "API2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.project, func(t *testing.T) {
+			assert.Equal(t, tt.expected, projectRef(tt.project))
+		})
+	}
+}
+
+func TestClient_AddComment(t *testing.T) {
+	for _, version := range []string{"2", "3"} {
+		t.Run("v"+version, func(t *testing.T) {
+			var sent map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/"+version+"/issue/TEST-123/comment" || r.Method != http.MethodPost {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&sent))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				body, _ := json.Marshal(sent["body"])
+				fmt.Fprintf(w, `{"id": "10000", "body": %s}`, body)
+			}))
+			defer server.Close()
+
+			client, err := NewFactory().Create(map[string]any{
+				"base_url":    server.URL,
+				"email":       "test@example.com",
+				"token":       "token123",
+				"api_version": version,
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, client.(*Client).AddComment(context.Background(), "TEST-123", "Open for **30** days."))
+			if version == "2" {
+				assert.Equal(t, "Open for **30** days.", sent["body"])
+			} else {
+				assert.Equal(t, "doc", sent["body"].(map[string]any)["type"])
+			}
+		})
+	}
+}
+
+func TestClient_Mention(t *testing.T) {
+	client := &Client{}
+
+	assignee := &models.User{Name: "Jane Doe", Metadata: map[string]any{"jira_account_id": "5b10ac8d82e05b22cc7d4ef5"}}
+	assert.Equal(t, "[~accountid:5b10ac8d82e05b22cc7d4ef5]", client.Mention(assignee))
+	assert.Empty(t, client.Mention(&models.User{Name: "Jane Doe"}))
+}
+
+func TestClient_AddCommentMention(t *testing.T) {
+	for _, version := range []string{"2", "3"} {
+		t.Run("v"+version, func(t *testing.T) {
+			var sent map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&sent))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"id": "10000"}`)
+			}))
+			defer server.Close()
+
+			client, err := NewFactory().Create(map[string]any{
+				"base_url":    server.URL,
+				"email":       "test@example.com",
+				"token":       "token123",
+				"api_version": version,
+			})
+			require.NoError(t, err)
+
+			assignee := &models.User{Metadata: map[string]any{"jira_account_id": "5b10ac8d82e05b22cc7d4ef5"}}
+			body := client.(*Client).Mention(assignee) + " still needed?"
+			require.NoError(t, client.(*Client).AddComment(context.Background(), "TEST-123", body))
+
+			if version == "2" {
+				assert.Equal(t, "[~accountid:5b10ac8d82e05b22cc7d4ef5] still needed?", sent["body"])
+				return
+			}
+			doc, err := json.Marshal(sent["body"])
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[`+
+				`{"type":"mention","attrs":{"id":"5b10ac8d82e05b22cc7d4ef5"}},`+
+				`{"type":"text","text":" still needed?"}]}]}`, string(doc))
+		})
+	}
+}
+
+func TestClient_APIv3Descriptions(t *testing.T) {
+	adf := `{"type":"doc","version":1,"content":[` +
+		`{"type":"paragraph","content":[{"type":"text","text":"See "},{"type":"status","attrs":{"text":"BLOCKED"}}]},` +
+		`{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"step","marks":[{"type":"strong"}]}]}]}]}]}`
+
+	var paths []string
+	var sent []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/rest/api/3/issue/TEST-123" && r.Method == http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"id": "12345", "key": "TEST-123", "fields": {"summary": "Test Issue", "description": %s,
+				"status": {"name": "To Do", "statusCategory": {"key": "new"}}, "project": {"key": "TEST"}}}`, adf)
+		case r.URL.Path == "/rest/api/3/issue/TEST-123" && r.Method == http.MethodPut:
+			var body struct {
+				Fields map[string]any `json:"fields"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			sent = append(sent, body.Fields)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewFactory().Create(map[string]any{
+		"base_url":    server.URL,
+		"email":       "test@example.com",
+		"token":       "token123",
+		"api_version": "3",
+	})
+	require.NoError(t, err)
+
+	task, err := client.GetTask(context.Background(), "TEST-123")
+	require.NoError(t, err)
+	assert.Equal(t, "See \n\n- **step**", task.Description)
+
+	// An unchanged description goes back as the original document, keeping
+	// nodes Markdown cannot express.
+	task.Metadata["jira_id"] = "TEST-123"
+	_, err = client.UpdateTask(context.Background(), task)
+	require.NoError(t, err)
+
+	// An edited one is converted from Markdown.
+	task.Description = "Fixed in **v2**"
+	_, err = client.UpdateTask(context.Background(), task)
+	require.NoError(t, err)
+
+	require.Len(t, sent, 2)
+	var original map[string]any
+	require.NoError(t, json.Unmarshal([]byte(adf), &original))
+	assert.Equal(t, original, sent[0]["description"])
+	assert.Equal(t, map[string]any{
+		"version": float64(1),
+		"type":    "doc",
+		"content": []any{map[string]any{"type": "paragraph", "content": []any{
+			map[string]any{"type": "text", "text": "Fixed in "},
+			map[string]any{"type": "text", "text": "v2", "marks": []any{map[string]any{"type": "strong"}}},
+		}}},
+	}, sent[1]["description"])
+
+	for _, path := range paths {
+		assert.NotContains(t, path, "/rest/api/2/")
+	}
+}
+
+func TestParseConfig_InvalidAPIVersion(t *testing.T) {
+	_, err := parseConfig(map[string]any{
+		"base_url":    "https://example.atlassian.net",
+		"email":       "test@example.com",
+		"token":       "token123",
+		"api_version": 4,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api_version must be 2 or 3")
+}
+
+func TestClient_AuthTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]any
+		wantAuth string
+		wantErr  string
+	}{
+		{
+			name:     "personal access token",
+			settings: map[string]any{"auth_type": "pat", "token": "pat123"},
+			wantAuth: "Bearer pat123",
+		},
+		{
+			name:     "server username and password",
+			settings: map[string]any{"auth_type": "basic", "username": "jdoe", "token": "secret"},
+			wantAuth: "Basic amRvZTpzZWNyZXQ=",
+		},
+		{
+			name:     "cloud email and API token",
+			settings: map[string]any{"email": "test@example.com", "token": "token123"},
+			wantAuth: "Basic dGVzdEBleGFtcGxlLmNvbTp0b2tlbjEyMw==",
+		},
+		{
+			name:     "unknown auth type",
+			settings: map[string]any{"auth_type": "kerberos", "token": "token123"},
+			wantErr:  `auth_type must be "basic" or "pat"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var auth string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				auth = r.Header.Get("Authorization")
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"name": "jdoe", "displayName": "J Doe"}`))
+			}))
+			defer server.Close()
+
+			tt.settings["base_url"] = server.URL
+			client, err := NewFactory().Create(tt.settings)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = client.GetCurrentUser(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAuth, auth)
+		})
+	}
+}
+
+func TestClient_DueDates(t *testing.T) {
+	var sentDue []any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "12345", "key": "TEST-123", "fields": {"summary": "Test Issue", "duedate": "2024-06-30",
+				"status": {"name": "To Do", "statusCategory": {"key": "new"}}, "project": {"key": "TEST"}}}`))
+		case r.URL.Path == "/rest/api/2/issue/TEST-123" && r.Method == http.MethodPut,
+			r.URL.Path == "/rest/api/2/issue" && r.Method == http.MethodPost:
+			var body struct {
+				Fields map[string]any `json:"fields"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			sentDue = append(sentDue, body.Fields["duedate"])
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id": "12346", "key": "TEST-124"}`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewFactory().Create(map[string]any{
+		"base_url":  server.URL,
+		"email":     "test@example.com",
+		"token":     "token123",
+		"due_dates": map[string]any{"timezone": "America/Los_Angeles", "end_of_day": "18:00"},
+	})
+	require.NoError(t, err)
+
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	task, err := client.GetTask(context.Background(), "TEST-123")
+	require.NoError(t, err)
+	require.NotNil(t, task.DueDate)
+	assert.True(t, time.Date(2024, 6, 30, 18, 0, 0, 0, losAngeles).Equal(*task.DueDate), "got %s", task.DueDate)
+
+	// 03:00 UTC on July 1st is still June 30th in Los Angeles.
+	due := time.Date(2024, 7, 1, 3, 0, 0, 0, time.UTC)
+	_, err = client.UpdateTask(context.Background(), &models.Task{ID: "TEST-123", Title: "Test Issue", DueDate: &due})
+	require.NoError(t, err)
+
+	created := models.NewTask("New", models.PlatformJira)
+	created.ProjectID = "TEST"
+	created.SetMetadata(platforms.DueDateMetadataKey, "2024-07-04")
+	_, err = client.CreateTask(context.Background(), created)
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{"2024-06-30", "2024-07-04"}, sentDue)
+
+	bad := models.NewTask("New", models.PlatformJira)
+	bad.ProjectID = "TEST"
+	bad.SetMetadata(platforms.DueDateMetadataKey, "next friday")
+	_, err = client.CreateTask(context.Background(), bad)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected YYYY-MM-DD")
+}
+
+func TestParseConfig_InvalidDueDates(t *testing.T) {
+	tests := []struct {
+		name     string
+		dueDates map[string]any
+		wantErr  string
+	}{
+		{name: "unknown timezone", dueDates: map[string]any{"timezone": "Mars/Olympus"}, wantErr: "invalid timezone"},
+		{name: "bad end of day", dueDates: map[string]any{"end_of_day": "6pm"}, wantErr: "invalid end_of_day"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConfig(map[string]any{
+				"base_url":  "https://example.atlassian.net",
+				"email":     "test@example.com",
+				"token":     "token123",
+				"due_dates": tt.dueDates,
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestClient_DetectAPIVersion(t *testing.T) {
+	tests := []struct {
+		name           string
+		deployment     string
+		wantDeployment string
+		wantPath       string
+	}{
+		{name: "cloud", deployment: "Cloud", wantDeployment: "cloud", wantPath: "/rest/api/3/issue/TEST-123"},
+		{name: "data center", deployment: "Server", wantDeployment: "server", wantPath: "/rest/api/2/issue/TEST-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platforms.SetVersionCache(platforms.NewMemoryVersionCache())
+			defer platforms.SetVersionCache(platforms.NewMemoryVersionCache())
+
+			var paths []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/rest/api/2/serverInfo":
+					fmt.Fprintf(w, `{"deploymentType": %q, "version": "9.12.0"}`, tt.deployment)
+				case tt.wantPath:
+					json.NewEncoder(w).Encode(mockJiraIssue)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			settings := map[string]any{
+				"base_url": server.URL,
+				"email":    "test@example.com",
+				"token":    "token123",
+			}
+
+			// The second client reuses the version the first detected.
+			for range 2 {
+				client, err := NewFactory().Create(settings)
+				require.NoError(t, err)
+				_, err = client.GetTask(context.Background(), "TEST-123")
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, []string{"/rest/api/2/serverInfo", tt.wantPath, tt.wantPath}, paths)
+
+			version, ok := platforms.CachedVersion(server.URL)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantDeployment, version.Deployment)
+			assert.Equal(t, "9.12.0", version.Version)
+		})
+	}
+}
+
+func TestClient_HonorsContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewClient(Config{BaseURL: server.URL, Email: "test@example.com", Token: "token123"})
+	require.NoError(t, err)
+
+	calls := map[string]func(ctx context.Context) error{
+		"GetTask": func(ctx context.Context) error {
+			_, err := client.GetTask(ctx, "TEST-1")
+			return err
+		},
+		"UpdateTask": func(ctx context.Context) error {
+			_, err := client.UpdateTask(ctx, createTestTask())
+			return err
+		},
+		"DeleteTask": func(ctx context.Context) error {
+			return client.DeleteTask(ctx, "TEST-1")
+		},
+		"ListTasks": func(ctx context.Context) error {
+			_, err := client.ListTasks(ctx, &models.TaskFilter{})
+			return err
+		},
+		"ListProjects": func(ctx context.Context) error {
+			_, err := client.ListProjects(ctx)
+			return err
+		},
+		"GetProject": func(ctx context.Context) error {
+			_, err := client.GetProject(ctx, "TEST")
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			assert.Error(t, call(ctx))
+			assert.Less(t, time.Since(start), 2*time.Second)
+		})
+	}
+}
+
+// TestClient_Cassette replays answers recorded from Jira Cloud. To record
+// them again, run it against a site with a TEST project (and update the
+// expectations):
+//
+//	OPENTASK_RECORD=1 JIRA_SERVER=https://your-site.atlassian.net \
+//	JIRA_EMAIL=you@example.com JIRA_API_TOKEN=... \
+//	go test ./pkg/platforms/jira -run TestClient_Cassette
+func TestClient_Cassette(t *testing.T) {
+	cassette, err := record.NewCassette("testdata/cassettes/tasks.har", nil, os.Getenv("JIRA_API_TOKEN"), os.Getenv("JIRA_EMAIL"))
+	require.NoError(t, err)
+
+	cfg := Config{BaseURL: "https://example.atlassian.net", Email: "test@example.com", Token: "token123", Transport: cassette}
+	if cassette.Recording() {
+		cfg.BaseURL = os.Getenv("JIRA_SERVER")
+		cfg.Email = os.Getenv("JIRA_EMAIL")
+		cfg.Token = os.Getenv("JIRA_API_TOKEN")
+	}
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	user, err := client.GetCurrentUser(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", user.Name)
+
+	tasks, err := client.ListTasks(ctx, &models.TaskFilter{ProjectID: "TEST", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, tasks, 3)
+	assert.Equal(t, "TEST-3", tasks[0].ID)
+	assert.Equal(t, models.StatusInProgress, tasks[0].Status)
+	assert.Equal(t, models.PriorityHigh, tasks[0].Priority)
+	require.NotNil(t, tasks[0].Assignee)
+	assert.Equal(t, "Jane Doe", tasks[0].Assignee.Name)
+	// Unassigned, without a priority and in a done category.
+	assert.Nil(t, tasks[2].Assignee)
+	assert.Equal(t, models.StatusDone, tasks[2].Status)
+
+	task, err := client.GetTask(ctx, "TEST-2")
+	require.NoError(t, err)
+	assert.Equal(t, "Crash when the title has emoji 🐛", task.Title)
+	assert.Equal(t, []string{"bug", "ui"}, task.Labels)
+	require.NotNil(t, task.DueDate)
+	assert.Equal(t, "2024-06-14", task.DueDate.Format("2006-01-02"))
+
+	_, err = client.GetTask(ctx, "TEST-404")
+	assert.True(t, platforms.IsNotFoundError(err))
+
+	require.NoError(t, cassette.Close())
+	assert.Empty(t, cassette.Unused())
+}
@@ -0,0 +1,279 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"opentask/pkg/platforms"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// maxAllowedValues bounds how many allowed values of a missing field are
+// listed in its message.
+const maxAllowedValues = 10
+
+// createField is a field on an issue type's create screen, as described by
+// the create metadata.
+type createField struct {
+	FieldID         string `json:"fieldId"`
+	Key             string `json:"key"`
+	Name            string `json:"name"`
+	Required        bool   `json:"required"`
+	HasDefaultValue bool   `json:"hasDefaultValue"`
+	AllowedValues   []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"allowedValues"`
+}
+
+// createMeta is the create metadata of one project and issue type: the
+// fields Jira requires that have no default, keyed by field ID, with the
+// message to show when they are missing.
+type createMeta struct {
+	required map[string]string
+}
+
+// checkRequiredFields returns a RequiredFieldsError if fields lack any
+// field the create metadata of the project and issue type requires, so
+// the missing fields are reported by name before Jira rejects the issue.
+// The check is skipped when the metadata cannot be read.
+func (c *Client) checkRequiredFields(ctx context.Context, project, issueType string, fields *jira.IssueFields) error {
+	meta, err := c.createMeta(ctx, project, issueType)
+	if err != nil || meta == nil {
+		return err
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil
+	}
+	var present map[string]any
+	if err := json.Unmarshal(data, &present); err != nil {
+		return nil
+	}
+
+	missing := make(map[string]string)
+	for id, message := range meta.required {
+		if _, ok := present[id]; !ok {
+			missing[id] = message
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return platforms.NewPlatformError(platforms.ErrInvalidInput, "jira", "", &platforms.RequiredFieldsError{Fields: missing})
+}
+
+// createMeta returns the create metadata of the project and issue type,
+// fetched once per client. It returns nil when the site does not describe
+// them, and an error when the project has no such issue type. Failed
+// fetches are not cached, so the next call tries again.
+func (c *Client) createMeta(ctx context.Context, project, issueType string) (*createMeta, error) {
+	key := strings.ToUpper(project) + "/" + strings.ToLower(issueType)
+
+	c.createMetaMu.Lock()
+	meta, ok := c.createMetas[key]
+	c.createMetaMu.Unlock()
+	if ok {
+		return meta, nil
+	}
+
+	// The lock is not held while fetching so lookups of other projects
+	// are not held up; concurrent lookups of this key share the fetch.
+	fetched, err, _ := c.createMetaFetch.Do(key, func() (any, error) {
+		fields, err := c.createFields(ctx, project, issueType)
+		if err != nil {
+			return nil, err
+		}
+
+		var meta *createMeta
+		if fields != nil {
+			meta = &createMeta{required: make(map[string]string)}
+			for _, field := range fields {
+				id := field.FieldID
+				if id == "" {
+					id = field.Key
+				}
+				if field.Required && !field.HasDefaultValue && id != "" {
+					meta.required[id] = requiredMessage(field)
+				}
+			}
+		}
+
+		c.createMetaMu.Lock()
+		defer c.createMetaMu.Unlock()
+		if c.createMetas == nil {
+			c.createMetas = make(map[string]*createMeta)
+		}
+		c.createMetas[key] = meta
+		return meta, nil
+	})
+	if err != nil {
+		if platforms.IsInvalidInputError(err) {
+			return nil, err
+		}
+		// Not every site or token can read the create metadata; Jira
+		// still reports missing fields when the issue is created.
+		return nil, nil
+	}
+	return fetched.(*createMeta), nil
+}
+
+// createFields lists the create screen fields of the issue type, using the
+// per-project endpoints of Jira Cloud and Server 8.4+ and falling back to
+// the older expanded createmeta endpoint.
+func (c *Client) createFields(ctx context.Context, project, issueType string) ([]createField, error) {
+	base := fmt.Sprintf("rest/api/2/issue/createmeta/%s/issuetypes", url.PathEscape(project))
+
+	var types []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	status, err := c.createMetaPages(ctx, base, "issueTypes", func(raw json.RawMessage) error {
+		return json.Unmarshal(raw, &types)
+	})
+	if status == http.StatusNotFound {
+		return c.legacyCreateFields(ctx, project, issueType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	typeID := ""
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, t.Name)
+		if strings.EqualFold(t.Name, issueType) {
+			typeID = t.ID
+		}
+	}
+	if typeID == "" {
+		return nil, platforms.NewPlatformError(
+			platforms.ErrInvalidInput,
+			"jira",
+			"",
+			fmt.Errorf("project %s has no issue type %q (available: %s)", project, issueType, strings.Join(names, ", ")),
+		)
+	}
+
+	var fields []createField
+	_, err = c.createMetaPages(ctx, base+"/"+url.PathEscape(typeID), "fields", func(raw json.RawMessage) error {
+		return json.Unmarshal(raw, &fields)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// createMetaPages fetches every page of a paginated createmeta endpoint,
+// passing the list under listKey (or "values", which Server uses) of each
+// page to add. It returns the status of the first failed request.
+func (c *Client) createMetaPages(ctx context.Context, endpoint, listKey string, add func(json.RawMessage) error) (int, error) {
+	for startAt := 0; ; {
+		req, err := c.client.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?startAt=%d&maxResults=200", endpoint, startAt), nil)
+		if err != nil {
+			return 0, err
+		}
+
+		var page map[string]json.RawMessage
+		resp, err := c.client.Do(req, &page)
+		if err != nil {
+			defer closeBody(resp)
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			return status, c.apiError("get create metadata", "", resp, err)
+		}
+		resp.Body.Close()
+
+		raw, ok := page[listKey]
+		if !ok {
+			raw = page["values"]
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return 0, fmt.Errorf("failed to decode create metadata: %w", err)
+		}
+		if err := add(raw); err != nil {
+			return 0, fmt.Errorf("failed to decode create metadata: %w", err)
+		}
+
+		var total int
+		json.Unmarshal(page["total"], &total)
+		startAt += len(items)
+		if len(items) == 0 || startAt >= total {
+			return 0, nil
+		}
+	}
+}
+
+// legacyCreateFields reads the create screen from the expanded createmeta
+// endpoint of older Jira Server versions.
+func (c *Client) legacyCreateFields(ctx context.Context, project, issueType string) ([]createField, error) {
+	query := url.Values{"issuetypeNames": {issueType}, "expand": {"projects.issuetypes.fields"}}
+	if ref := projectRef(project); ref.ID != "" {
+		query.Set("projectIds", ref.ID)
+	} else {
+		query.Set("projectKeys", ref.Key)
+	}
+
+	req, err := c.client.NewRequestWithContext(ctx, http.MethodGet, "rest/api/2/issue/createmeta?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var meta struct {
+		Projects []struct {
+			IssueTypes []struct {
+				Fields map[string]createField `json:"fields"`
+			} `json:"issuetypes"`
+		} `json:"projects"`
+	}
+	resp, err := c.client.Do(req, &meta)
+	if err != nil {
+		defer closeBody(resp)
+		return nil, c.apiError("get create metadata", "", resp, err)
+	}
+	resp.Body.Close()
+
+	if len(meta.Projects) == 0 || len(meta.Projects[0].IssueTypes) == 0 {
+		return nil, nil
+	}
+	var fields []createField
+	for id, field := range meta.Projects[0].IssueTypes[0].Fields {
+		field.FieldID = id
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// requiredMessage describes a missing field in the words Jira uses, with
+// the values it accepts when it has a short list of them.
+func requiredMessage(field createField) string {
+	name := field.Name
+	if name == "" {
+		name = field.FieldID
+	}
+
+	var values []string
+	for _, allowed := range field.AllowedValues {
+		value := allowed.Name
+		if value == "" {
+			value = allowed.Value
+		}
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 || len(values) > maxAllowedValues {
+		return name + " is required."
+	}
+	return fmt.Sprintf("%s is required (one of: %s).", name, strings.Join(values, ", "))
+}
@@ -0,0 +1,217 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"opentask/pkg/platforms"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+const apiTokenURL = "https://id.atlassian.com/manage-profile/security/api-tokens"
+
+// jiraPermission is a Jira permission needed by an operation. Global
+// permissions are granted system-wide rather than per project.
+type jiraPermission struct {
+	Key    string
+	Name   string
+	Global bool
+}
+
+// operationPermissions maps the operations used in apiError to the Jira
+// permission they require.
+var operationPermissions = map[string]jiraPermission{
+	"create issue":         {Key: "CREATE_ISSUES", Name: "Create Issues"},
+	"get issue":            {Key: "BROWSE_PROJECTS", Name: "Browse Projects"},
+	"update issue":         {Key: "EDIT_ISSUES", Name: "Edit Issues"},
+	"delete issue":         {Key: "DELETE_ISSUES", Name: "Delete Issues"},
+	"search issues":        {Key: "BROWSE_PROJECTS", Name: "Browse Projects"},
+	"get transitions":      {Key: "TRANSITION_ISSUES", Name: "Transition Issues"},
+	"transition issue":     {Key: "TRANSITION_ISSUES", Name: "Transition Issues"},
+	"assign issue":         {Key: "ASSIGN_ISSUES", Name: "Assign Issues"},
+	"list projects":        {Key: "BROWSE_PROJECTS", Name: "Browse Projects"},
+	"get project":          {Key: "BROWSE_PROJECTS", Name: "Browse Projects"},
+	"get project statuses": {Key: "BROWSE_PROJECTS", Name: "Browse Projects"},
+	"search users":         {Key: "USER_PICKER", Name: "Browse users and groups", Global: true},
+	"register webhook":     {Key: "ADMINISTER", Name: "Administer Jira", Global: true},
+	"delete webhook":       {Key: "ADMINISTER", Name: "Administer Jira", Global: true},
+}
+
+// closeBody closes the body of a failed request's response. go-jira leaves
+// it open, and a throttled request keeps its in-flight slot until it is
+// closed.
+func closeBody(resp *jira.Response) {
+	if resp != nil && resp.Response != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+}
+
+// apiError converts a failed API call into a PlatformError, classified by
+// the response status: authentication_failed, permission_denied,
+// not_found, rate_limited (with the Retry-After the response gave) or
+// network_error, for failed connections and an unavailable Jira.
+// Authentication and permission failures get a remediation hint instead
+// of the raw API response.
+func (c *Client) apiError(operation, taskID string, resp *jira.Response, err error) error {
+	var jiraErr *jira.Error
+	if !errors.As(err, &jiraErr) && resp != nil {
+		err = jira.NewJiraError(resp, err)
+		errors.As(err, &jiraErr)
+	}
+
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	message := jiraErrorMessage(jiraErr)
+
+	var urlErr *url.Error
+	if resp == nil && errors.As(err, &urlErr) {
+		// The request did not get a response.
+		return platforms.NewPlatformError(
+			platforms.ErrNetworkError,
+			"jira",
+			taskID,
+			fmt.Errorf("failed to %s: %w", operation, err),
+		)
+	}
+
+	switch {
+	case status == http.StatusUnauthorized:
+		return platforms.NewPlatformError(
+			platforms.ErrAuthentication,
+			"jira",
+			taskID,
+			fmt.Errorf("Jira rejected the credentials for %s\n  hint: create a new API token at %s and run 'opentask connect jira' again", c.email, apiTokenURL),
+		)
+	case status == http.StatusForbidden && strings.Contains(resp.Header.Get("X-Seraph-LoginReason"), "CAPTCHA"):
+		return platforms.NewPlatformError(
+			platforms.ErrAuthentication,
+			"jira",
+			taskID,
+			fmt.Errorf("Jira requires a CAPTCHA for %s\n  hint: log in to %s in a browser once, then retry", c.email, c.baseURL),
+		)
+	case status == http.StatusNotFound:
+		// Jira says "does not exist or you do not have permission to see
+		// it" for missing issues, so this comes before the message check.
+		return platforms.NewPlatformError(
+			platforms.ErrNotFound,
+			"jira",
+			taskID,
+			fmt.Errorf("failed to %s: %w", operation, err),
+		)
+	case status == http.StatusForbidden || strings.Contains(strings.ToLower(message), "do not have permission"):
+		return platforms.NewPlatformError(
+			platforms.ErrPermissionDenied,
+			"jira",
+			taskID,
+			c.permissionError(operation, message),
+		)
+	case status == http.StatusTooManyRequests:
+		return platforms.NewPlatformError(
+			platforms.ErrRateLimited,
+			"jira",
+			taskID,
+			&platforms.RateLimitError{Operation: operation, RetryAfter: retryAfter(resp.Header.Get("Retry-After"), time.Now())},
+		)
+	}
+
+	return platforms.NewPlatformError(
+		statusErrorCode(status),
+		"jira",
+		taskID,
+		fmt.Errorf("failed to %s: %w", operation, err),
+	)
+}
+
+// statusErrorCode returns the error code for a failed response's status.
+func statusErrorCode(status int) platforms.ErrorCode {
+	switch status {
+	case http.StatusUnauthorized:
+		return platforms.ErrAuthentication
+	case http.StatusForbidden:
+		return platforms.ErrPermissionDenied
+	case http.StatusNotFound:
+		return platforms.ErrNotFound
+	case http.StatusTooManyRequests:
+		return platforms.ErrRateLimited
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		// Jira, or a proxy in front of it, is down or overloaded.
+		return platforms.ErrNetworkError
+	default:
+		return platforms.ErrPlatformAPI
+	}
+}
+
+// retryAfter parses a Retry-After header, given in seconds or as an HTTP
+// date, into how long to wait from now. It returns 0 when the header is
+// missing or invalid.
+func retryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now).Round(time.Second)
+	}
+	return 0
+}
+
+func (c *Client) permissionError(operation, message string) *platforms.PermissionError {
+	permErr := &platforms.PermissionError{
+		Operation: operation,
+		Required:  "additional Jira permissions",
+		Message:   message,
+		Remediation: fmt.Sprintf("ask a Jira administrator to check the permissions of %s for this project",
+			c.email),
+	}
+
+	perm, ok := operationPermissions[operation]
+	if !ok {
+		return permErr
+	}
+
+	if perm.Global {
+		permErr.Required = fmt.Sprintf("the Jira global permission %q", perm.Name)
+		permErr.Remediation = fmt.Sprintf("ask a Jira administrator to grant %q to %s (Settings → System → Global permissions)",
+			perm.Name, c.email)
+		return permErr
+	}
+
+	permErr.Required = fmt.Sprintf("the Jira project permission %q (%s)", perm.Name, perm.Key)
+	permErr.Remediation = fmt.Sprintf("ask a project administrator to grant %q to %s or one of their roles (Project settings → Permissions)",
+		perm.Name, c.email)
+	return permErr
+}
+
+// jiraErrorMessage joins the messages of a Jira error response.
+func jiraErrorMessage(jiraErr *jira.Error) string {
+	if jiraErr == nil {
+		return ""
+	}
+
+	messages := append([]string(nil), jiraErr.ErrorMessages...)
+	fields := make([]string, 0, len(jiraErr.Errors))
+	for field := range jiraErr.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, jiraErr.Errors[field])
+	}
+
+	return strings.Join(messages, "; ")
+}
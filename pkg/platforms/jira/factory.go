@@ -3,6 +3,7 @@ package jira
 import (
 	"fmt"
 	"opentask/pkg/platforms"
+	"opentask/pkg/throttle"
 	"strings"
 )
 
@@ -29,6 +30,10 @@ func (f *Factory) GetName() string {
 	return "Jira"
 }
 
+func (f *Factory) GetDescription() string {
+	return "Jira Cloud, Server and Data Center (https://www.atlassian.com/software/jira)"
+}
+
 func (f *Factory) ValidateConfig(config map[string]any) error {
 	_, err := parseConfig(config)
 	return err
@@ -44,30 +49,107 @@ func parseConfig(config map[string]any) (Config, error) {
 		return cfg, fmt.Errorf("base_url is required and must be a string")
 	}
 
-	// Extract email
-	if email, ok := config["email"].(string); ok {
-		cfg.Email = email
-	} else {
-		return cfg, fmt.Errorf("email is required and must be a string")
+	authType, err := parseAuthType(config)
+	if err != nil {
+		return cfg, err
 	}
+	cfg.AuthType = authType
 
-	// Extract token
-	if token, ok := config["token"].(string); ok {
-		cfg.Token = token
+	// An OAuth access token replaces the email and API token
+	if accessToken, ok := config["access_token"].(string); ok && accessToken != "" {
+		cfg.AccessToken = accessToken
+		cfg.SiteURL, _ = config["site_url"].(string)
 	} else {
-		return cfg, fmt.Errorf("token is required and must be a string")
+		// Extract email, or the username on Jira Server and Data Center.
+		// A personal access token needs neither.
+		if email, ok := config["email"].(string); ok {
+			cfg.Email = email
+		} else if username, ok := config["username"].(string); ok {
+			cfg.Email = username
+		} else if authType != AuthPAT {
+			return cfg, fmt.Errorf("email is required and must be a string")
+		}
+
+		// Extract token
+		if token, ok := config["token"].(string); ok {
+			cfg.Token = token
+		} else {
+			return cfg, fmt.Errorf("token is required and must be a string")
+		}
+	}
+
+	statusMap, err := platforms.ParseStatusMap(config)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.StatusMap = statusMap
+
+	statusNames, err := platforms.ParseStatusNames(config)
+	if err != nil {
+		return cfg, err
 	}
+	cfg.StatusNames = statusNames
+
+	priorityMap, err := platforms.ParsePriorityMap(config)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.PriorityMap = priorityMap
+
+	priorityNames, err := platforms.ParsePriorityNames(config)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.PriorityNames = priorityNames
+
+	if issueType, ok := config[IssueTypeKey].(string); ok {
+		cfg.IssueType = issueType
+	}
+	if sprintField, ok := config[SprintFieldKey].(string); ok {
+		cfg.SprintField = strings.TrimSpace(sprintField)
+	}
+
+	fieldMap, err := parseFieldMap(config)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.FieldMap = fieldMap
+
+	labelMap, err := platforms.ParseLabelMap(config)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.LabelMap = labelMap
+
+	dueDates, err := platforms.ParseDueDateRules(config)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.DueDates = dueDates
+
+	limits, err := throttle.ParseLimits(config)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Limits = limits
+
+	apiVersion, detect, err := parseAPIVersion(config)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.APIVersion = apiVersion
+	cfg.DetectAPIVersion = detect
 
 	// Validate required fields
 	if cfg.BaseURL == "" {
 		return cfg, fmt.Errorf("base_url cannot be empty")
 	}
 
-	if cfg.Email == "" {
+	if cfg.AccessToken == "" && cfg.AuthType != AuthPAT && cfg.Email == "" {
 		return cfg, fmt.Errorf("email cannot be empty")
 	}
 
-	if cfg.Token == "" {
+	if cfg.AccessToken == "" && cfg.Token == "" {
 		return cfg, fmt.Errorf("token cannot be empty")
 	}
 
@@ -79,6 +161,25 @@ func parseConfig(config map[string]any) (Config, error) {
 	return cfg, nil
 }
 
+// parseAuthType reads the auth_type setting, defaulting to basic auth.
+func parseAuthType(config map[string]any) (string, error) {
+	raw, ok := config[AuthTypeKey]
+	if !ok || raw == nil {
+		return AuthBasic, nil
+	}
+
+	authType, ok := raw.(string)
+	switch strings.ToLower(authType) {
+	case "", AuthBasic:
+		if ok {
+			return AuthBasic, nil
+		}
+	case AuthPAT:
+		return AuthPAT, nil
+	}
+	return "", fmt.Errorf("%s must be %q or %q, got %v", AuthTypeKey, AuthBasic, AuthPAT, raw)
+}
+
 // Register factory with the global registry
 func init() {
 	platforms.DefaultRegistry.Register(NewFactory())
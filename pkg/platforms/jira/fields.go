@@ -0,0 +1,194 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/trivago/tgo/tcontainer"
+)
+
+const (
+	// CustomFieldsKey is the task metadata key holding raw field values
+	// (field ID → string) to send with create and update requests.
+	CustomFieldsKey = "custom_fields"
+
+	// IssueTypeKey is the task metadata key holding the issue type name.
+	IssueTypeKey = "issue_type"
+
+	// FieldMapKey is the platform setting mapping field names to field IDs.
+	FieldMapKey = "field_map"
+
+	defaultIssueType = "Task"
+)
+
+// taskIssueType returns the issue type to create task as: the one set on
+// the task, then the configured default, then "Task".
+func (c *Client) taskIssueType(task *models.Task) string {
+	if value, ok := task.GetMetadata(IssueTypeKey); ok {
+		if issueType, ok := value.(string); ok && issueType != "" {
+			return issueType
+		}
+	}
+	if c.issueType != "" {
+		return c.issueType
+	}
+	return defaultIssueType
+}
+
+// IssueType returns the issue type task would be created as.
+func (c *Client) IssueType(task *models.Task) string {
+	return c.taskIssueType(task)
+}
+
+// applyCustomFields copies raw field values from task metadata onto the
+// issue fields, converting them to the JSON shape Jira expects. Field
+// names from the field_map setting are replaced by their field IDs, and
+// the reporter and sprint are looked up by name.
+func (c *Client) applyCustomFields(ctx context.Context, fields *jira.IssueFields, task *models.Task) error {
+	raw, ok := task.GetMetadata(CustomFieldsKey)
+	if !ok {
+		return nil
+	}
+
+	values, ok := raw.(map[string]string)
+	if !ok || len(values) == 0 {
+		return nil
+	}
+
+	if fields.Unknowns == nil {
+		fields.Unknowns = tcontainer.NewMarshalMap()
+	}
+
+	for key, value := range values {
+		if id, ok := c.fieldMap[strings.ToLower(key)]; ok {
+			key = id
+		}
+		key = canonicalField(key)
+
+		switch key {
+		case reporterField:
+			reporter, err := c.reporterValue(ctx, strings.TrimSpace(value))
+			if err != nil {
+				return err
+			}
+			fields.Unknowns[key] = reporter
+		case sprintField:
+			id, err := c.sprintFieldID(ctx)
+			if err != nil {
+				return err
+			}
+			sprint, err := c.sprintID(ctx, task.ProjectID, value)
+			if err != nil {
+				return err
+			}
+			fields.Unknowns[id] = sprint
+		default:
+			fields.Unknowns[key] = fieldValue(key, value)
+		}
+	}
+	return nil
+}
+
+// canonicalField returns the field ID Jira uses for the well-known fields
+// --field accepts under other spellings, such as fix_versions.
+func canonicalField(key string) string {
+	switch strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key)) {
+	case "fixversions", "fixversion":
+		return "fixVersions"
+	case "components", "component":
+		return "components"
+	case "reporter":
+		return reporterField
+	case "sprint":
+		return sprintField
+	}
+	return key
+}
+
+// parseFieldMap reads the field_map setting, keyed by lowercased name.
+func parseFieldMap(config map[string]any) (map[string]string, error) {
+	raw, ok := config[FieldMapKey]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a map of field name to field ID", FieldMapKey)
+	}
+
+	fieldMap := make(map[string]string, len(entries))
+	for name, value := range entries {
+		id, ok := value.(string)
+		if !ok || strings.TrimSpace(id) == "" {
+			return nil, fmt.Errorf("%s: invalid field ID %v for %q", FieldMapKey, value, name)
+		}
+		fieldMap[strings.ToLower(name)] = strings.TrimSpace(id)
+	}
+
+	return fieldMap, nil
+}
+
+// fieldValue converts a raw flag value into a Jira field value. JSON
+// objects and arrays are passed through so callers can set option fields
+// such as {"value": "High"}; well-known multi-value fields accept a
+// comma-separated list of names.
+func fieldValue(key, raw string) any {
+	trimmed := strings.TrimSpace(raw)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var decoded any
+		if err := json.Unmarshal([]byte(trimmed), &decoded); err == nil {
+			return decoded
+		}
+	}
+
+	switch key {
+	case "components", "fixVersions", "versions":
+		var named []map[string]string
+		for _, name := range strings.Split(raw, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				named = append(named, map[string]string{"name": name})
+			}
+		}
+		return named
+	case "labels":
+		var labels []string
+		for _, label := range strings.Split(raw, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				labels = append(labels, label)
+			}
+		}
+		return labels
+	}
+
+	return raw
+}
+
+// requiredFieldsError extracts "field is required" messages from a Jira
+// error response, returning nil if the error has a different cause.
+func requiredFieldsError(err error) *platforms.RequiredFieldsError {
+	var jiraErr *jira.Error
+	if !errors.As(err, &jiraErr) {
+		return nil
+	}
+
+	missing := make(map[string]string)
+	for field, message := range jiraErr.Errors {
+		if strings.Contains(strings.ToLower(message), "required") {
+			missing[field] = message
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return &platforms.RequiredFieldsError{Fields: missing}
+}
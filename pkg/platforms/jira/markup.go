@@ -0,0 +1,552 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DescriptionMarkdown converts a Jira issue description to Markdown. The
+// v2 API returns wiki markup; descriptions in Atlassian Document Format
+// (as returned by the v3 API) are recognised and converted as well.
+func DescriptionMarkdown(description string) string {
+	trimmed := strings.TrimSpace(description)
+	if strings.HasPrefix(trimmed, "{") && strings.Contains(trimmed, `"type"`) {
+		if markdown, err := ADFToMarkdown([]byte(trimmed)); err == nil {
+			return markdown
+		}
+	}
+	return WikiToMarkdown(description)
+}
+
+var (
+	wikiHeading   = regexp.MustCompile(`^h([1-6])\.\s+(.*)$`)
+	wikiList      = regexp.MustCompile(`^([*#]+|-)\s+(.*)$`)
+	wikiCodeStart = regexp.MustCompile(`^\{(code|noformat)(?::([^}]*))?\}(.*)$`)
+	wikiBold      = regexp.MustCompile(`(^|[\s(])\*([^*\s](?:[^*]*[^*\s])?)\*`)
+	wikiMonospace = regexp.MustCompile(`\{\{(.+?)\}\}`)
+	wikiLink      = regexp.MustCompile(`\[([^|\]]+)\|([^\]]+)\]`)
+	wikiBareLink  = regexp.MustCompile(`\[((?:https?|mailto):[^\]]+)\]`)
+)
+
+// WikiToMarkdown converts the Jira wiki markup used in issue descriptions
+// to Markdown. Headings, lists, code blocks, quotes, bold, monospace and
+// links are converted; anything else is passed through unchanged.
+func WikiToMarkdown(text string) string {
+	var out []string
+	var fence string // closing tag of the open code block
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if fence != "" {
+			if before, _, found := strings.Cut(line, fence); found {
+				if strings.TrimSpace(before) != "" {
+					out = append(out, before)
+				}
+				out = append(out, "```")
+				fence = ""
+				continue
+			}
+			out = append(out, line)
+			continue
+		}
+
+		if match := wikiCodeStart.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			tag, language, rest := match[1], codeLanguage(match[2]), match[3]
+			out = append(out, "```"+language)
+			closing := "{" + tag + "}"
+			if before, _, found := strings.Cut(rest, closing); found {
+				if before != "" {
+					out = append(out, before)
+				}
+				out = append(out, "```")
+				continue
+			}
+			if rest != "" {
+				out = append(out, rest)
+			}
+			fence = closing
+			continue
+		}
+
+		out = append(out, wikiLine(line))
+	}
+
+	if fence != "" {
+		out = append(out, "```")
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// codeLanguage extracts the language from {code} parameters such as
+// "java" or "title=Example.java|language=java".
+func codeLanguage(params string) string {
+	for _, param := range strings.Split(params, "|") {
+		key, value, found := strings.Cut(param, "=")
+		if !found {
+			if !strings.Contains(param, ".") {
+				return strings.TrimSpace(param)
+			}
+			continue
+		}
+		if strings.TrimSpace(key) == "language" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+func wikiLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+
+	if match := wikiHeading.FindStringSubmatch(trimmed); match != nil {
+		level := int(match[1][0] - '0')
+		return strings.Repeat("#", level) + " " + wikiInline(match[2])
+	}
+
+	if strings.HasPrefix(trimmed, "bq. ") {
+		return "> " + wikiInline(strings.TrimPrefix(trimmed, "bq. "))
+	}
+
+	if trimmed == "----" {
+		return "---"
+	}
+
+	if match := wikiList.FindStringSubmatch(trimmed); match != nil {
+		markers := match[1]
+		indent := strings.Repeat("  ", len(markers)-1)
+		bullet := "-"
+		if markers[len(markers)-1] == '#' {
+			bullet = "1."
+		}
+		return indent + bullet + " " + wikiInline(match[2])
+	}
+
+	return wikiInline(line)
+}
+
+func wikiInline(text string) string {
+	text = wikiMonospace.ReplaceAllString(text, "`$1`")
+	text = wikiLink.ReplaceAllString(text, "[$1]($2)")
+	text = wikiBareLink.ReplaceAllString(text, "<$1>")
+	text = wikiBold.ReplaceAllString(text, "$1**$2**")
+	return text
+}
+
+// adfNode is a node of an Atlassian Document Format document.
+type adfNode struct {
+	Type    string         `json:"type"`
+	Text    string         `json:"text,omitempty"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Marks   []adfMark      `json:"marks,omitempty"`
+	Content []adfNode      `json:"content,omitempty"`
+}
+
+type adfMark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+// ADFToMarkdown converts an Atlassian Document Format document to
+// Markdown. Unknown nodes are rendered through their content.
+func ADFToMarkdown(doc []byte) (string, error) {
+	var root adfNode
+	if err := json.Unmarshal(doc, &root); err != nil {
+		return "", fmt.Errorf("invalid ADF document: %w", err)
+	}
+	if root.Type != "doc" {
+		return "", fmt.Errorf("invalid ADF document: root node is %q", root.Type)
+	}
+
+	var b strings.Builder
+	writeADFBlocks(&b, root.Content, "")
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// writeADFBlocks writes block nodes separated by blank lines. prefix is
+// prepended to every line, for nesting inside lists and quotes.
+func writeADFBlocks(b *strings.Builder, nodes []adfNode, prefix string) {
+	for i, node := range nodes {
+		if i > 0 {
+			b.WriteString(strings.TrimRight(prefix, " ") + "\n")
+		}
+		writeADFBlock(b, node, prefix)
+	}
+}
+
+func writeADFBlock(b *strings.Builder, node adfNode, prefix string) {
+	switch node.Type {
+	case "paragraph":
+		writePrefixed(b, adfInline(node.Content), prefix)
+	case "heading":
+		level := 1
+		if l, ok := node.Attrs["level"].(float64); ok && l >= 1 && l <= 6 {
+			level = int(l)
+		}
+		writePrefixed(b, strings.Repeat("#", level)+" "+adfInline(node.Content), prefix)
+	case "bulletList", "orderedList":
+		for i, item := range node.Content {
+			marker := "- "
+			if node.Type == "orderedList" {
+				marker = fmt.Sprintf("%d. ", i+1)
+			}
+			writeADFListItem(b, item, prefix, marker)
+		}
+	case "codeBlock":
+		language, _ := node.Attrs["language"].(string)
+		writePrefixed(b, "```"+language+"\n"+adfText(node.Content)+"\n```", prefix)
+	case "blockquote":
+		writeADFBlocks(b, node.Content, prefix+"> ")
+	case "rule":
+		writePrefixed(b, "---", prefix)
+	case "panel":
+		writeADFBlocks(b, node.Content, prefix+"> ")
+	default:
+		if len(node.Content) > 0 {
+			writeADFBlocks(b, node.Content, prefix)
+		} else if text := adfInline([]adfNode{node}); text != "" {
+			writePrefixed(b, text, prefix)
+		}
+	}
+}
+
+// writeADFListItem writes a list item with marker on its first line and
+// its remaining blocks indented below it.
+func writeADFListItem(b *strings.Builder, item adfNode, prefix, marker string) {
+	indent := prefix + strings.Repeat(" ", len(marker))
+	for i, child := range item.Content {
+		var inner strings.Builder
+		writeADFBlock(&inner, child, "")
+		text := strings.TrimRight(inner.String(), "\n")
+
+		for j, line := range strings.Split(text, "\n") {
+			switch {
+			case i == 0 && j == 0:
+				b.WriteString(prefix + marker + line + "\n")
+			case line == "":
+				b.WriteString("\n")
+			default:
+				b.WriteString(indent + line + "\n")
+			}
+		}
+	}
+}
+
+func writePrefixed(b *strings.Builder, text, prefix string) {
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(prefix + line + "\n")
+	}
+}
+
+// adfInline renders inline nodes such as text, mentions and line breaks.
+func adfInline(nodes []adfNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case "text":
+			b.WriteString(adfMarks(node.Text, node.Marks))
+		case "hardBreak":
+			b.WriteString("  \n")
+		case "mention":
+			if text, ok := node.Attrs["text"].(string); ok && text != "" {
+				b.WriteString(text)
+			} else if id, ok := node.Attrs["id"].(string); ok {
+				b.WriteString("[~accountid:" + id + "]")
+			}
+		case "emoji":
+			if text, ok := node.Attrs["text"].(string); ok {
+				b.WriteString(text)
+			} else if name, ok := node.Attrs["shortName"].(string); ok {
+				b.WriteString(name)
+			}
+		case "inlineCard":
+			if url, ok := node.Attrs["url"].(string); ok {
+				b.WriteString("<" + url + ">")
+			}
+		default:
+			b.WriteString(adfInline(node.Content))
+		}
+	}
+	return b.String()
+}
+
+func adfMarks(text string, marks []adfMark) string {
+	for _, mark := range marks {
+		switch mark.Type {
+		case "strong":
+			text = "**" + text + "**"
+		case "em":
+			text = "_" + text + "_"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "code":
+			text = "`" + text + "`"
+		case "link":
+			if href, ok := mark.Attrs["href"].(string); ok {
+				text = "[" + text + "](" + href + ")"
+			}
+		}
+	}
+	return text
+}
+
+// adfText returns the plain text of nodes, as used inside code blocks.
+func adfText(nodes []adfNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		b.WriteString(node.Text)
+		b.WriteString(adfText(node.Content))
+	}
+	return b.String()
+}
+
+var (
+	mdHeading   = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	mdListItem  = regexp.MustCompile(`^([-*+]|\d+[.)])\s+(.*)$`)
+	mdRule      = regexp.MustCompile(`^(?:-{3,}|\*{3,}|_{3,})$`)
+	mdFence     = regexp.MustCompile("^(```+|~~~+)\\s*([\\w+-]*)")
+	mdInlineTok = regexp.MustCompile("`([^`]+)`" +
+		`|\*\*(.+?)\*\*` +
+		`|~~(.+?)~~` +
+		`|\[([^\]]+)\]\(([^)\s]+)\)` +
+		`|<((?:https?|mailto):[^>\s]+)>` +
+		`|(?:^|[\s(])_([^_\s](?:[^_]*[^_\s])?)_` +
+		`|\*([^*\s](?:[^*]*[^*\s])?)\*` +
+		`|\[~accountid:([^\]\s]+)\]`)
+)
+
+// adfDoc is the root of an Atlassian Document Format document.
+type adfDoc struct {
+	Version int       `json:"version"`
+	Type    string    `json:"type"`
+	Content []adfNode `json:"content"`
+}
+
+// MarkdownToADF converts Markdown to an Atlassian Document Format document,
+// as the v3 API expects for descriptions. Headings, lists, code blocks,
+// quotes, rules, emphasis, code spans, links and [~accountid:...] mentions
+// are converted; other text is kept as is.
+func MarkdownToADF(markdown string) ([]byte, error) {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	doc := adfDoc{Version: 1, Type: "doc", Content: markdownBlocks(lines)}
+	if doc.Content == nil {
+		doc.Content = []adfNode{}
+	}
+	return json.Marshal(doc)
+}
+
+func markdownBlocks(lines []string) []adfNode {
+	var blocks []adfNode
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, adfParagraph(paragraph))
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" {
+			flush()
+			continue
+		}
+
+		if match := mdFence.FindStringSubmatch(trimmed); match != nil {
+			flush()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), match[1]); i++ {
+				code = append(code, lines[i])
+			}
+			node := adfNode{Type: "codeBlock"}
+			if match[2] != "" {
+				node.Attrs = map[string]any{"language": match[2]}
+			}
+			if text := strings.Join(code, "\n"); text != "" {
+				node.Content = []adfNode{{Type: "text", Text: text}}
+			}
+			blocks = append(blocks, node)
+			continue
+		}
+
+		if match := mdHeading.FindStringSubmatch(trimmed); match != nil {
+			flush()
+			blocks = append(blocks, adfNode{
+				Type:    "heading",
+				Attrs:   map[string]any{"level": len(match[1])},
+				Content: adfInlineNodes(match[2], nil),
+			})
+			continue
+		}
+
+		if mdRule.MatchString(trimmed) {
+			flush()
+			blocks = append(blocks, adfNode{Type: "rule"})
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, ">") {
+			flush()
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				text := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(text, " "))
+			}
+			i--
+			blocks = append(blocks, adfNode{Type: "blockquote", Content: markdownBlocks(quoted)})
+			continue
+		}
+
+		if mdListItem.MatchString(trimmed) {
+			flush()
+			var list adfNode
+			list, i = markdownList(lines, i)
+			blocks = append(blocks, list)
+			continue
+		}
+
+		paragraph = append(paragraph, trimmed)
+	}
+
+	flush()
+	return blocks
+}
+
+// markdownList reads the list starting at lines[start] and returns it with
+// the index of its last line. Lines indented below an item belong to it,
+// so nested lists become part of the item.
+func markdownList(lines []string, start int) (adfNode, int) {
+	first := mdListItem.FindStringSubmatch(strings.TrimSpace(lines[start]))
+	ordered := first[1][0] >= '0' && first[1][0] <= '9'
+	list := adfNode{Type: "bulletList"}
+	if ordered {
+		list.Type = "orderedList"
+	}
+
+	indent := len(lines[start]) - len(strings.TrimLeft(lines[start], " \t"))
+
+	i := start
+	for i < len(lines) {
+		line := lines[i]
+		match := mdListItem.FindStringSubmatch(strings.TrimSpace(line))
+		lineIndent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if match == nil || lineIndent != indent || (match[1][0] >= '0' && match[1][0] <= '9') != ordered {
+			break
+		}
+
+		width := indent + len(match[1]) + 1
+		item := []string{match[2]}
+		for i++; i < len(lines); i++ {
+			next := lines[i]
+			nextIndent := len(next) - len(strings.TrimLeft(next, " \t"))
+			if strings.TrimSpace(next) == "" {
+				// A blank line continues the item only if more of it follows.
+				if i+1 < len(lines) && len(lines[i+1])-len(strings.TrimLeft(lines[i+1], " \t")) > indent && strings.TrimSpace(lines[i+1]) != "" {
+					item = append(item, "")
+					continue
+				}
+				break
+			}
+			if nextIndent <= indent {
+				break
+			}
+			item = append(item, next[min(width, nextIndent):])
+		}
+
+		list.Content = append(list.Content, adfNode{Type: "listItem", Content: markdownBlocks(item)})
+
+		// Skip blank lines between items of the same list.
+		j := i
+		for j < len(lines) && strings.TrimSpace(lines[j]) == "" {
+			j++
+		}
+		if j < len(lines) && j > i {
+			if next := mdListItem.FindStringSubmatch(strings.TrimSpace(lines[j])); next != nil &&
+				len(lines[j])-len(strings.TrimLeft(lines[j], " \t")) == indent {
+				i = j
+			}
+		}
+	}
+
+	return list, i - 1
+}
+
+// adfParagraph joins paragraph lines with hard breaks, as descriptions are
+// usually written with meaningful line breaks.
+func adfParagraph(lines []string) adfNode {
+	node := adfNode{Type: "paragraph"}
+	for i, line := range lines {
+		if i > 0 {
+			node.Content = append(node.Content, adfNode{Type: "hardBreak"})
+		}
+		node.Content = append(node.Content, adfInlineNodes(strings.TrimRight(line, " "), nil)...)
+	}
+	return node
+}
+
+// adfInlineNodes converts inline Markdown to text nodes carrying marks.
+func adfInlineNodes(text string, marks []adfMark) []adfNode {
+	var nodes []adfNode
+	plain := func(s string) {
+		if s != "" {
+			nodes = append(nodes, adfNode{Type: "text", Text: s, Marks: marks})
+		}
+	}
+	with := func(mark adfMark) []adfMark {
+		return append(append([]adfMark(nil), marks...), mark)
+	}
+
+	for text != "" {
+		loc := mdInlineTok.FindStringSubmatchIndex(text)
+		if loc == nil {
+			plain(text)
+			break
+		}
+
+		group := func(n int) string {
+			if loc[2*n] < 0 {
+				return ""
+			}
+			return text[loc[2*n]:loc[2*n+1]]
+		}
+
+		start := loc[0]
+		switch {
+		case loc[2] >= 0:
+			plain(text[:start])
+			nodes = append(nodes, adfNode{Type: "text", Text: group(1), Marks: with(adfMark{Type: "code"})})
+		case loc[4] >= 0:
+			plain(text[:start])
+			nodes = append(nodes, adfInlineNodes(group(2), with(adfMark{Type: "strong"}))...)
+		case loc[6] >= 0:
+			plain(text[:start])
+			nodes = append(nodes, adfInlineNodes(group(3), with(adfMark{Type: "strike"}))...)
+		case loc[8] >= 0:
+			plain(text[:start])
+			link := adfMark{Type: "link", Attrs: map[string]any{"href": group(5)}}
+			nodes = append(nodes, adfInlineNodes(group(4), with(link))...)
+		case loc[12] >= 0:
+			plain(text[:start])
+			link := adfMark{Type: "link", Attrs: map[string]any{"href": group(6)}}
+			nodes = append(nodes, adfNode{Type: "text", Text: group(6), Marks: with(link)})
+		case loc[14] >= 0:
+			// The match includes the character before the underscore.
+			plain(text[:loc[14]-1])
+			nodes = append(nodes, adfInlineNodes(group(7), with(adfMark{Type: "em"}))...)
+		case loc[18] >= 0:
+			plain(text[:start])
+			nodes = append(nodes, adfNode{Type: "mention", Attrs: map[string]any{"id": group(9)}})
+		default:
+			plain(text[:start])
+			nodes = append(nodes, adfInlineNodes(group(8), with(adfMark{Type: "em"}))...)
+		}
+
+		text = text[loc[1]:]
+	}
+
+	return nodes
+}
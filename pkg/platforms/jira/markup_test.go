@@ -0,0 +1,166 @@
+package jira
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWikiToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "headings",
+			input:    "h1. Overview\nh3. Details",
+			expected: "# Overview\n### Details",
+		},
+		{
+			name:     "nested lists",
+			input:    "* one\n** nested\n# first\n## second",
+			expected: "- one\n  - nested\n1. first\n  1. second",
+		},
+		{
+			name:     "inline formatting",
+			input:    "Run *make build* with {{--verbose}} see [docs|https://example.com] or [https://jira.example.com]",
+			expected: "Run **make build** with `--verbose` see [docs](https://example.com) or <https://jira.example.com>",
+		},
+		{
+			name:     "code block keeps contents",
+			input:    "{code:language=go}\nfunc main() {\n\t*ptr = 1\n}\n{code}\nafter",
+			expected: "```go\nfunc main() {\n\t*ptr = 1\n}\n```\nafter",
+		},
+		{
+			name:     "single line noformat",
+			input:    "{noformat}raw text{noformat}",
+			expected: "```\nraw text\n```",
+		},
+		{
+			name:     "quote and rule",
+			input:    "bq. quoted\n----",
+			expected: "> quoted\n---",
+		},
+		{
+			name:     "unterminated code block is closed",
+			input:    "{code:java}\nint x;",
+			expected: "```java\nint x;\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, WikiToMarkdown(tt.input))
+		})
+	}
+}
+
+func TestADFToMarkdown(t *testing.T) {
+	doc := `{"type": "doc", "version": 1, "content": [
+		{"type": "heading", "attrs": {"level": 2}, "content": [{"type": "text", "text": "Steps"}]},
+		{"type": "paragraph", "content": [
+			{"type": "text", "text": "Ask "},
+			{"type": "mention", "attrs": {"text": "@Jane"}},
+			{"type": "text", "text": " to check "},
+			{"type": "text", "text": "the logs", "marks": [{"type": "link", "attrs": {"href": "https://logs.example.com"}}]}
+		]},
+		{"type": "orderedList", "content": [
+			{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Build", "marks": [{"type": "strong"}]}]}]},
+			{"type": "listItem", "content": [
+				{"type": "paragraph", "content": [{"type": "text", "text": "Run"}]},
+				{"type": "bulletList", "content": [
+					{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "tests", "marks": [{"type": "code"}]}]}]}
+				]}
+			]}
+		]},
+		{"type": "codeBlock", "attrs": {"language": "sh"}, "content": [{"type": "text", "text": "make test"}]}
+	]}`
+
+	markdown, err := ADFToMarkdown([]byte(doc))
+	require.NoError(t, err)
+	assert.Equal(t, "## Steps\n\n"+
+		"Ask @Jane to check [the logs](https://logs.example.com)\n\n"+
+		"1. **Build**\n"+
+		"2. Run\n"+
+		"   - `tests`\n\n"+
+		"```sh\nmake test\n```", markdown)
+
+	assert.Equal(t, markdown, DescriptionMarkdown(doc))
+
+	_, err = ADFToMarkdown([]byte(`{"type": "paragraph"}`))
+	assert.Error(t, err)
+	assert.Equal(t, "## Title", DescriptionMarkdown("h2. Title"))
+}
+
+func TestMarkdownToADF(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{
+			name:     "paragraph with marks",
+			markdown: "Run **make build** with `--verbose`, see [docs](https://example.com)",
+			expected: `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[` +
+				`{"type":"text","text":"Run "},` +
+				`{"type":"text","text":"make build","marks":[{"type":"strong"}]},` +
+				`{"type":"text","text":" with "},` +
+				`{"type":"text","text":"--verbose","marks":[{"type":"code"}]},` +
+				`{"type":"text","text":", see "},` +
+				`{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://example.com"}}]}]}]}`,
+		},
+		{
+			name:     "line breaks",
+			markdown: "first\nsecond",
+			expected: `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[` +
+				`{"type":"text","text":"first"},{"type":"hardBreak"},{"type":"text","text":"second"}]}]}`,
+		},
+		{
+			name:     "mention",
+			markdown: "[~accountid:5b10ac8d82e05b22cc7d4ef5] is this still needed?",
+			expected: `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[` +
+				`{"type":"mention","attrs":{"id":"5b10ac8d82e05b22cc7d4ef5"}},` +
+				`{"type":"text","text":" is this still needed?"}]}]}`,
+		},
+		{
+			name:     "empty",
+			markdown: "",
+			expected: `{"version":1,"type":"doc","content":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := MarkdownToADF(tt.markdown)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(doc))
+		})
+	}
+}
+
+func TestMarkdownToADF_RoundTrip(t *testing.T) {
+	tests := []string{
+		"## Steps",
+		"Ask Jane to check [the logs](https://logs.example.com)",
+		"1. **Build**\n2. Run\n   - `tests`\n   - _lint_",
+		"- one\n- two\n\nafter",
+		"```sh\nmake test\n```",
+		"> quoted\n>\n> more",
+		"---",
+		"~~old~~ new",
+		"[~accountid:5b10ac8d82e05b22cc7d4ef5] please check",
+	}
+
+	for _, markdown := range tests {
+		t.Run(markdown, func(t *testing.T) {
+			doc, err := MarkdownToADF(markdown)
+			require.NoError(t, err)
+
+			back, err := ADFToMarkdown(doc)
+			require.NoError(t, err)
+			assert.Equal(t, markdown, back)
+		})
+	}
+}
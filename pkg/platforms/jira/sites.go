@@ -0,0 +1,81 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"opentask/pkg/metrics"
+	"opentask/pkg/platforms"
+	"opentask/pkg/telemetry"
+)
+
+const cloudAPIURL = "https://api.atlassian.com/ex/jira/"
+
+// accessibleResourcesURL lists the Atlassian sites an OAuth token can reach.
+// It is a variable so tests can point it at a local server.
+var accessibleResourcesURL = "https://api.atlassian.com/oauth/token/accessible-resources"
+
+// Site is a Jira Cloud site reachable with an OAuth 2.0 token.
+type Site struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	URL    string   `json:"url"`
+	Scopes []string `json:"scopes"`
+}
+
+// APIURL is the base URL for REST calls to the site with an OAuth token.
+// OAuth requests go through the Atlassian API gateway rather than the
+// site's own URL.
+func (s Site) APIURL() string {
+	return cloudAPIURL + s.ID
+}
+
+// ListSites returns the Jira sites accessToken has been granted. Other
+// Atlassian products the token can reach, such as Confluence, are left out.
+func ListSites(ctx context.Context, accessToken string) ([]Site, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, accessibleResourcesURL, nil)
+	if err != nil {
+		return nil, platforms.NewPlatformError(platforms.ErrPlatformAPI, "jira", "", fmt.Errorf("failed to build request: %w", err))
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{Transport: metrics.Transport("jira", telemetry.Transport("jira", nil))}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, platforms.NewPlatformError(platforms.ErrNetworkError, "jira", "", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, platforms.NewPlatformError(platforms.ErrAuthentication, "jira", "", fmt.Errorf("the OAuth access token is invalid or has expired"))
+	case resp.StatusCode >= 300:
+		return nil, platforms.NewPlatformError(statusErrorCode(resp.StatusCode), "jira", "", fmt.Errorf("failed to list accessible sites: %s", resp.Status))
+	}
+
+	var resources []Site
+	if err := json.NewDecoder(resp.Body).Decode(&resources); err != nil {
+		return nil, platforms.NewPlatformError(platforms.ErrPlatformAPI, "jira", "", fmt.Errorf("failed to decode accessible sites: %w", err))
+	}
+
+	var sites []Site
+	for _, site := range resources {
+		if hasJiraScope(site.Scopes) {
+			sites = append(sites, site)
+		}
+	}
+	return sites, nil
+}
+
+func hasJiraScope(scopes []string) bool {
+	for _, scope := range scopes {
+		if strings.HasSuffix(scope, ":jira") || strings.HasSuffix(scope, ":jira-work") || strings.HasSuffix(scope, ":jira-user") {
+			return true
+		}
+	}
+	return false
+}
@@ -20,11 +20,19 @@ type JiraUser jira.User
 
 // Conversion methods to unified models
 func (ji *JiraIssue) ToTask() *models.Task {
+	return issueToTask(&ji.Issue)
+}
+
+// issueMetadataSize is how many metadata keys an issue usually has, so the
+// map is allocated once.
+const issueMetadataSize = 8
 
+// issueToTask converts issue without copying it, as listings convert many.
+func issueToTask(ji *jira.Issue) *models.Task {
 	task := &models.Task{
 		ID:       ji.Key,
 		Platform: models.PlatformJira,
-		Metadata: make(map[string]any),
+		Metadata: make(map[string]any, issueMetadataSize),
 	}
 
 	if ji.Fields == nil {
@@ -93,17 +101,26 @@ func (ji *JiraIssue) ToTask() *models.Task {
 
 func (jp *JiraProject) ToProject() *models.Project {
 	project := jira.Project(*jp)
-	return &models.Project{
-		ID:       project.ID,
-		Name:     project.Name,
-		Key:      project.Key,
-		Platform: models.PlatformJira,
-		Active:   true,
+	converted := &models.Project{
+		ID:          project.ID,
+		Name:        project.Name,
+		Description: project.Description,
+		Key:         project.Key,
+		Platform:    models.PlatformJira,
+		Active:      true,
 		Metadata: map[string]any{
 			"jira_id":   project.ID,
 			"jira_self": project.Self,
 		},
 	}
+	if project.Lead.AccountID != "" || project.Lead.Name != "" {
+		lead := JiraUser(project.Lead)
+		converted.Lead = lead.ToUser()
+		if converted.Lead.ID == "" {
+			converted.Lead.ID = project.Lead.Name
+		}
+	}
+	return converted
 }
 
 func (ju *JiraUser) ToUser() *models.User {
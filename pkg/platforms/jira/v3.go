@@ -0,0 +1,247 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"opentask/pkg/models"
+)
+
+// APIVersionKey is the setting choosing the REST API version: 2, 3, or
+// "auto" (the default) to use v3 on Jira Cloud and v2 on Server and Data
+// Center. Version 3 exchanges descriptions as Atlassian Document Format.
+const APIVersionKey = "api_version"
+
+// APIVersionAuto is the api_version value that detects the version.
+const APIVersionAuto = "auto"
+
+// adfMetadataKey holds the description as fetched from the v3 API, so an
+// unchanged description is sent back as is instead of through Markdown.
+const adfMetadataKey = "jira_adf"
+
+// parseAPIVersion returns the configured API version, or detect set when
+// it should be detected.
+func parseAPIVersion(config map[string]any) (version int, detect bool, err error) {
+	switch v := config[APIVersionKey].(type) {
+	case nil:
+		return 0, true, nil
+	case int:
+		if v == 2 || v == 3 {
+			return v, false, nil
+		}
+	case float64:
+		if v == 2 || v == 3 {
+			return int(v), false, nil
+		}
+	case string:
+		switch strings.ToLower(strings.TrimPrefix(v, "v")) {
+		case "", APIVersionAuto:
+			return 0, true, nil
+		case "2":
+			return 2, false, nil
+		case "3":
+			return 3, false, nil
+		}
+	}
+	return 0, false, fmt.Errorf("%s must be 2 or 3 (or %s to detect it), got %v", APIVersionKey, APIVersionAuto, config[APIVersionKey])
+}
+
+// v3Transport sends go-jira's v2 requests to the v3 API when the client
+// uses v3. go-jira reads and writes descriptions as strings, so it converts
+// them: descriptions sent are converted from Markdown to ADF, and ADF
+// descriptions received are passed on as their JSON text.
+type v3Transport struct {
+	base     http.RoundTripper
+	versions *apiVersion
+}
+
+func (t *v3Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if detectingVersion(req.Context()) || t.versions.get(req.Context()) != 3 {
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	if path, ok := strings.CutPrefix(req.URL.Path, "/rest/api/2/"); ok {
+		req.URL.Path = "/rest/api/3/" + path
+		req.URL.RawPath = ""
+	} else if i := strings.Index(req.URL.Path, "/rest/api/2/"); i >= 0 {
+		// Sites reached through the OAuth gateway have a path prefix.
+		req.URL.Path = req.URL.Path[:i] + "/rest/api/3/" + req.URL.Path[i+len("/rest/api/2/"):]
+		req.URL.RawPath = ""
+	}
+
+	if req.Body != nil && (req.Method == http.MethodPost || req.Method == http.MethodPut) {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = convertBody(req, body, descriptionToADF)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode >= 300 || !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	body = convertBody(req, body, descriptionToString)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+// convertBody applies fn to the descriptions in body, or to the comment
+// body for requests to an issue's comments.
+func convertBody(req *http.Request, body []byte, fn func(any) any) []byte {
+	if strings.HasSuffix(req.URL.Path, "/comment") {
+		return convertCommentBody(body, fn)
+	}
+	return convertDescriptions(body, fn)
+}
+
+// convertCommentBody applies convert to the body of a comment.
+func convertCommentBody(body []byte, convert func(any) any) []byte {
+	var doc map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil || doc["body"] == nil {
+		return body
+	}
+
+	doc["body"] = convert(doc["body"])
+	converted, err := json.Marshal(doc)
+	if err != nil {
+		return body
+	}
+	return converted
+}
+
+// convertDescriptions applies convert to the description of the issue in
+// body, or of each issue in a search result. Bodies without one are
+// returned unchanged.
+func convertDescriptions(body []byte, convert func(any) any) []byte {
+	var doc map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return body
+	}
+
+	changed := convertFields(doc, convert)
+	if issues, ok := doc["issues"].([]any); ok {
+		for _, issue := range issues {
+			if issue, ok := issue.(map[string]any); ok && convertFields(issue, convert) {
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return body
+	}
+
+	converted, err := json.Marshal(doc)
+	if err != nil {
+		return body
+	}
+	return converted
+}
+
+func convertFields(issue map[string]any, convert func(any) any) bool {
+	fields, ok := issue["fields"].(map[string]any)
+	if !ok {
+		return false
+	}
+	description, ok := fields["description"]
+	if !ok || description == nil {
+		return false
+	}
+	fields["description"] = convert(description)
+	return true
+}
+
+// descriptionToADF converts a description sent by go-jira. A description
+// that already is an ADF document is sent unchanged.
+func descriptionToADF(description any) any {
+	text, ok := description.(string)
+	if !ok {
+		return description
+	}
+	if isADF(text) {
+		return json.RawMessage(text)
+	}
+	doc, err := MarkdownToADF(text)
+	if err != nil {
+		return description
+	}
+	return json.RawMessage(doc)
+}
+
+// descriptionToString turns an ADF description into its JSON text.
+func descriptionToString(description any) any {
+	if _, ok := description.(map[string]any); !ok {
+		return description
+	}
+	text, err := json.Marshal(description)
+	if err != nil {
+		return description
+	}
+	return string(text)
+}
+
+func isADF(text string) bool {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") {
+		return false
+	}
+	var root struct {
+		Type string `json:"type"`
+	}
+	return json.Unmarshal([]byte(trimmed), &root) == nil && root.Type == "doc"
+}
+
+// readDescription converts a v3 description to Markdown, keeping the ADF
+// so it can be sent back unchanged.
+func (c *Client) readDescription(task *models.Task) {
+	if c.versions.current() != 3 || !isADF(task.Description) {
+		return
+	}
+	markdown, err := ADFToMarkdown([]byte(task.Description))
+	if err != nil {
+		return
+	}
+	task.Metadata[adfMetadataKey] = task.Description
+	task.Description = markdown
+}
+
+// description returns the description to send for task. Under v3 an
+// unedited description is sent as the ADF it was read as, so formatting
+// Markdown cannot express survives updates.
+func (c *Client) description(ctx context.Context, task *models.Task) string {
+	if c.versions.get(ctx) != 3 {
+		return task.Description
+	}
+	if original, ok := task.GetMetadata(adfMetadataKey); ok {
+		if adf, ok := original.(string); ok {
+			if markdown, err := ADFToMarkdown([]byte(adf)); err == nil && markdown == task.Description {
+				return adf
+			}
+		}
+	}
+	return task.Description
+}
@@ -0,0 +1,111 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"opentask/pkg/platforms"
+)
+
+// fallbackAPIVersion is used when the version cannot be detected.
+const fallbackAPIVersion = 2
+
+// apiVersion is the REST API version a client uses, detected on first use
+// unless it was configured.
+type apiVersion struct {
+	mu      sync.Mutex
+	version int
+
+	// instance keys the detected version in the version cache.
+	instance string
+	detect   func(ctx context.Context) (platforms.InstanceVersion, error)
+}
+
+// get returns the API version, detecting it first if needed. A version
+// found before, by this client or an earlier run, is reused.
+func (v *apiVersion) get(ctx context.Context) int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.version != 0 {
+		return v.version
+	}
+
+	if cached, ok := platforms.CachedVersion(v.instance); ok && cached.API != 0 {
+		v.version = cached.API
+		return v.version
+	}
+
+	detected, err := v.detect(ctx)
+	if err != nil {
+		// Not cached, so the next run tries again.
+		v.version = fallbackAPIVersion
+		return v.version
+	}
+
+	platforms.CacheVersion(v.instance, detected)
+	v.version = detected.API
+	return v.version
+}
+
+// current returns the API version without detecting it. Responses are
+// only read after a request has settled it.
+func (v *apiVersion) current() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.version == 0 {
+		return fallbackAPIVersion
+	}
+	return v.version
+}
+
+type detectingVersionKey struct{}
+
+// detectingVersion reports whether ctx belongs to the request detecting
+// the version, which always goes to the v2 API.
+func detectingVersion(ctx context.Context) bool {
+	detecting, _ := ctx.Value(detectingVersionKey{}).(bool)
+	return detecting
+}
+
+// serverInfo is the part of /rest/api/2/serverInfo version detection uses.
+type serverInfo struct {
+	DeploymentType string `json:"deploymentType"`
+	Version        string `json:"version"`
+}
+
+// detectVersion asks the server what kind of install it is. Jira Cloud
+// gets the v3 API, and Server and Data Center, which have no v3, get v2.
+// Sites reached through the OAuth gateway are always Cloud.
+func (c *Client) detectVersion(ctx context.Context) (platforms.InstanceVersion, error) {
+	if c.siteURL != "" {
+		return platforms.InstanceVersion{Deployment: "cloud", API: 3, DetectedAt: time.Now()}, nil
+	}
+
+	ctx = context.WithValue(ctx, detectingVersionKey{}, true)
+	req, err := c.client.NewRequestWithContext(ctx, http.MethodGet, "rest/api/2/serverInfo", nil)
+	if err != nil {
+		return platforms.InstanceVersion{}, err
+	}
+
+	var info serverInfo
+	resp, err := c.client.Do(req, &info)
+	if err != nil {
+		defer closeBody(resp)
+		return platforms.InstanceVersion{}, c.apiError("detect the API version", "", resp, err)
+	}
+
+	version := platforms.InstanceVersion{
+		Deployment: strings.ToLower(info.DeploymentType),
+		Version:    info.Version,
+		API:        2,
+		DetectedAt: time.Now(),
+	}
+	if version.Deployment == "cloud" {
+		version.API = 3
+	}
+	return version, nil
+}
@@ -0,0 +1,67 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"opentask/pkg/platforms"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// vocabularyIssues is how many of the most recently updated issues labels
+// are collected from. Jira has no list of the labels used in a project.
+const vocabularyIssues = 500
+
+// ListVocabulary returns the labels used in the project's recently updated
+// issues, and the project's components.
+func (c *Client) ListVocabulary(ctx context.Context, projectID string) (*platforms.Vocabulary, error) {
+	jql := "labels is not EMPTY ORDER BY updated DESC"
+	if projectID != "" {
+		jql = fmt.Sprintf("project = \"%s\" AND %s", projectID, jql)
+	}
+
+	var labels []string
+	options := &jira.SearchOptions{MaxResults: 100, Fields: []string{"labels"}}
+	for options.StartAt < vocabularyIssues {
+		issues, resp, err := c.client.Issue.SearchWithContext(ctx, jql, options)
+		if err != nil {
+			defer closeBody(resp)
+			return nil, c.apiError("search issues", "", resp, err)
+		}
+		resp.Body.Close()
+
+		for _, issue := range issues {
+			if issue.Fields != nil {
+				labels = append(labels, issue.Fields.Labels...)
+			}
+		}
+		if len(issues) < options.MaxResults {
+			break
+		}
+		options.StartAt += len(issues)
+	}
+
+	vocabulary := &platforms.Vocabulary{
+		Labels:    platforms.SortedUnique(c.labelMap.Shared(labels)),
+		FetchedAt: time.Now(),
+	}
+
+	if projectID != "" {
+		project, resp, err := c.client.Project.GetWithContext(ctx, projectID)
+		if err != nil {
+			defer closeBody(resp)
+			return nil, c.apiError("get project", "", resp, err)
+		}
+		resp.Body.Close()
+
+		var components []string
+		for _, component := range project.Components {
+			components = append(components, component.Name)
+		}
+		vocabulary.Components = platforms.SortedUnique(components)
+	}
+
+	return vocabulary, nil
+}
@@ -0,0 +1,156 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"opentask/pkg/platforms"
+)
+
+const webhookEndpoint = "rest/webhooks/1.0/webhook"
+
+// webhookEvents are the issue events OpenTask needs to keep tasks in sync.
+var webhookEvents = []string{
+	"jira:issue_created",
+	"jira:issue_updated",
+	"jira:issue_deleted",
+}
+
+type jiraWebhook struct {
+	Self    string            `json:"self,omitempty"`
+	Name    string            `json:"name"`
+	URL     string            `json:"url"`
+	Events  []string          `json:"events"`
+	Filters map[string]string `json:"filters,omitempty"`
+	Secret  string            `json:"secret,omitempty"`
+}
+
+// RegisterWebhook creates a webhook for issue events. Jira only lets
+// administrators manage webhooks.
+func (c *Client) RegisterWebhook(ctx context.Context, opts platforms.WebhookOptions) (*platforms.Webhook, error) {
+	body := jiraWebhook{
+		Name:   "OpenTask",
+		URL:    opts.URL,
+		Events: webhookEvents,
+		Secret: opts.Secret,
+	}
+	if opts.Project != "" {
+		body.Filters = map[string]string{
+			"issue-related-events-section": fmt.Sprintf("project = %q", opts.Project),
+		}
+	}
+
+	req, err := c.client.NewRequestWithContext(ctx, http.MethodPost, webhookEndpoint, body)
+	if err != nil {
+		return nil, platforms.NewPlatformError(
+			platforms.ErrPlatformAPI,
+			"jira",
+			"",
+			fmt.Errorf("failed to build request: %w", err),
+		)
+	}
+
+	var created jiraWebhook
+	resp, err := c.client.Do(req, &created)
+	if err != nil {
+		defer closeBody(resp)
+		return nil, c.apiError("register webhook", "", resp, err)
+	}
+	defer resp.Body.Close()
+
+	// The webhook ID is only returned as the last segment of its self link.
+	id := path.Base(created.Self)
+	if created.Self == "" || id == "." || id == "/" {
+		return nil, platforms.NewPlatformError(
+			platforms.ErrPlatformAPI,
+			"jira",
+			"",
+			fmt.Errorf("webhook created but Jira returned no webhook ID"),
+		)
+	}
+
+	return &platforms.Webhook{
+		ID:     id,
+		URL:    created.URL,
+		Events: created.Events,
+	}, nil
+}
+
+// UnregisterWebhook deletes a webhook created by RegisterWebhook.
+func (c *Client) UnregisterWebhook(ctx context.Context, id string) error {
+	req, err := c.client.NewRequestWithContext(ctx, http.MethodDelete, webhookEndpoint+"/"+id, nil)
+	if err != nil {
+		return platforms.NewPlatformError(
+			platforms.ErrPlatformAPI,
+			"jira",
+			"",
+			fmt.Errorf("failed to build request: %w", err),
+		)
+	}
+
+	resp, err := c.client.Do(req, nil)
+	if err != nil {
+		defer closeBody(resp)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return platforms.NewPlatformError(
+				platforms.ErrNotFound,
+				"jira",
+				"",
+				fmt.Errorf("webhook %s not found", id),
+			)
+		}
+		return c.apiError("delete webhook", "", resp, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// webhookActions maps the webhook events OpenTask registers to what they
+// mean for the task.
+var webhookActions = map[string]platforms.WebhookAction{
+	"jira:issue_created": platforms.WebhookCreated,
+	"jira:issue_updated": platforms.WebhookUpdated,
+	"jira:issue_deleted": platforms.WebhookDeleted,
+}
+
+// ParseWebhook reads an issue event. Jira signs deliveries of webhooks
+// with a secret in the X-Hub-Signature header, as "sha256=<hex>".
+func (c *Client) ParseWebhook(header http.Header, body []byte, secret string) (*platforms.WebhookEvent, error) {
+	if secret != "" {
+		signature, ok := strings.CutPrefix(header.Get("X-Hub-Signature"), "sha256=")
+		if !ok || !platforms.ValidSignature(body, secret, signature) {
+			return nil, platforms.NewPlatformError(
+				platforms.ErrAuthentication,
+				"jira",
+				"",
+				fmt.Errorf("webhook signature does not match"),
+			)
+		}
+	}
+
+	var delivery struct {
+		WebhookEvent string `json:"webhookEvent"`
+		Issue        struct {
+			Key string `json:"key"`
+		} `json:"issue"`
+	}
+	if err := json.Unmarshal(body, &delivery); err != nil {
+		return nil, platforms.NewPlatformError(
+			platforms.ErrInvalidInput,
+			"jira",
+			"",
+			fmt.Errorf("failed to decode webhook: %w", err),
+		)
+	}
+
+	action, ok := webhookActions[delivery.WebhookEvent]
+	if !ok || delivery.Issue.Key == "" {
+		return nil, nil
+	}
+	return &platforms.WebhookEvent{Action: action, TaskID: delivery.Issue.Key}, nil
+}
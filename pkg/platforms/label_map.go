@@ -0,0 +1,131 @@
+package platforms
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LabelMapKey is the platform setting holding a LabelMap.
+const LabelMapKey = "label_map"
+
+// LabelMap translates between a platform's label names and the shared
+// label names used across platforms, so a task mirrored from one platform
+// to another follows the target's naming ("bug" on Jira, "Bug" on Linear).
+// Labels without an entry are passed through unchanged.
+type LabelMap struct {
+	// toShared maps lowercased platform labels to shared labels.
+	toShared map[string]string
+	// toPlatform maps shared labels to the platform label sent for them.
+	toPlatform map[string]string
+}
+
+// ParseLabelMap reads the label_map setting from a platform config.
+//
+// Entries are keyed by a shared label, lowercase since config keys are
+// case-insensitive, and name the platform label for it ("bug": "Bug").
+// A list of names ("urgent": ["p1", "blocker"]) maps each of them to the
+// shared label; the first is the one sent to the platform.
+func ParseLabelMap(config map[string]any) (LabelMap, error) {
+	raw, ok := config[LabelMapKey]
+	if !ok || raw == nil {
+		return LabelMap{}, nil
+	}
+
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		return LabelMap{}, fmt.Errorf("%s must be a map of shared label to platform label", LabelMapKey)
+	}
+
+	// Sorted so a platform label listed under two shared labels always
+	// maps back the same way.
+	shared := make([]string, 0, len(entries))
+	for key := range entries {
+		shared = append(shared, key)
+	}
+	sort.Strings(shared)
+
+	m := LabelMap{
+		toShared:   make(map[string]string),
+		toPlatform: make(map[string]string, len(entries)),
+	}
+	for _, key := range shared {
+		names, err := labelNames(entries[key])
+		if err != nil || len(names) == 0 {
+			return LabelMap{}, fmt.Errorf("%s: invalid platform label %v for %q", LabelMapKey, entries[key], key)
+		}
+
+		label := strings.ToLower(key)
+		m.toPlatform[label] = names[0]
+		for _, name := range names {
+			if _, ok := m.toShared[strings.ToLower(name)]; !ok {
+				m.toShared[strings.ToLower(name)] = label
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func labelNames(value any) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil, fmt.Errorf("empty label")
+		}
+		return []string{v}, nil
+	case []any:
+		names := make([]string, 0, len(v))
+		for _, item := range v {
+			name, ok := item.(string)
+			if !ok || name == "" {
+				return nil, fmt.Errorf("invalid label %v", item)
+			}
+			names = append(names, name)
+		}
+		return names, nil
+	case []string:
+		return v, nil
+	}
+	return nil, fmt.Errorf("invalid label %v", value)
+}
+
+// Shared translates labels read from the platform to shared labels.
+func (m LabelMap) Shared(labels []string) []string {
+	return translateLabels(labels, func(label string) string {
+		if shared, ok := m.toShared[strings.ToLower(label)]; ok {
+			return shared
+		}
+		return label
+	})
+}
+
+// Platform translates shared labels to the names sent to the platform.
+func (m LabelMap) Platform(labels []string) []string {
+	return translateLabels(labels, func(label string) string {
+		if name, ok := m.toPlatform[strings.ToLower(label)]; ok {
+			return name
+		}
+		return label
+	})
+}
+
+// translateLabels maps each label, dropping duplicates the mapping
+// creates while keeping the original order.
+func translateLabels(labels []string, translate func(string) string) []string {
+	if len(labels) == 0 {
+		return labels
+	}
+
+	seen := make(map[string]bool, len(labels))
+	translated := make([]string, 0, len(labels))
+	for _, label := range labels {
+		label = translate(label)
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+		translated = append(translated, label)
+	}
+	return translated
+}
@@ -0,0 +1,85 @@
+package linear
+
+import (
+	"context"
+	"fmt"
+
+	"opentask/pkg/platforms"
+)
+
+// probeID is a well-formed ID that matches no Linear entity. Write probes
+// target it so a permitted mutation fails with "not found" instead of
+// changing data.
+const probeID = "00000000-0000-0000-0000-000000000000"
+
+// capabilityProbe is a request whose outcome tells whether the API key has
+// the scope a capability needs.
+type capabilityProbe struct {
+	scope string
+	query string
+}
+
+var capabilityProbes = map[platforms.Capability]capabilityProbe{
+	platforms.CapabilityListTasks: {
+		scope: "read",
+		query: `query { issues(first: 1) { nodes { id } } }`,
+	},
+	platforms.CapabilityListProjects: {
+		scope: "read",
+		query: `query { projects(first: 1) { nodes { id } } }`,
+	},
+	platforms.CapabilitySearchUsers: {
+		scope: "read",
+		query: `query { users(first: 1) { nodes { id } } }`,
+	},
+	platforms.CapabilityCreateTask: {
+		scope: "issues:create",
+		query: `mutation { issueCreate(input: {teamId: "` + probeID + `", title: "opentask capability probe"}) { success } }`,
+	},
+	platforms.CapabilityUpdateTask: {
+		scope: "write",
+		query: `mutation { issueUpdate(id: "` + probeID + `", input: {}) { success } }`,
+	},
+	platforms.CapabilityTransitionTask: {
+		scope: "write",
+		query: `mutation { issueUpdate(id: "` + probeID + `", input: {stateId: "` + probeID + `"}) { success } }`,
+	},
+	platforms.CapabilityAssignTask: {
+		scope: "write",
+		query: `mutation { issueUpdate(id: "` + probeID + `", input: {assigneeId: "` + probeID + `"}) { success } }`,
+	},
+	platforms.CapabilityDeleteTask: {
+		scope: "write",
+		query: `mutation { issueDelete(id: "` + probeID + `") { success } }`,
+	},
+}
+
+// ProbeCapabilities runs one request per capability. Reads list a single
+// item; writes target a nonexistent issue, so only a scope error marks a
+// capability as missing. Nothing is modified.
+func (c *Client) ProbeCapabilities(ctx context.Context) ([]platforms.CapabilityCheck, error) {
+	if _, err := c.GetCurrentUser(ctx); err != nil {
+		return nil, err
+	}
+
+	checks := make([]platforms.CapabilityCheck, 0, len(platforms.AllCapabilities))
+	for _, capability := range platforms.AllCapabilities {
+		probe := capabilityProbes[capability]
+
+		var result map[string]any
+		err := c.graphql.Exec(ctx, probe.query, &result, nil)
+		if ctx.Err() != nil {
+			return nil, apiError("check permissions", "", ctx.Err())
+		}
+
+		// Write probes fail with "not found" when they are permitted.
+		kind, _ := classifyError(err)
+		checks = append(checks, platforms.CapabilityCheck{
+			Capability: capability,
+			Allowed:    kind != "authentication" && kind != "forbidden",
+			Required:   fmt.Sprintf("scope %q", probe.scope),
+		})
+	}
+
+	return checks, nil
+}
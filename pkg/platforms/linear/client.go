@@ -3,12 +3,21 @@ package linear
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hasura/go-graphql-client"
+	"opentask/pkg/logging"
+	"opentask/pkg/metrics"
 	"opentask/pkg/models"
 	"opentask/pkg/platforms"
+	"opentask/pkg/record"
+	"opentask/pkg/telemetry"
+	"opentask/pkg/throttle"
 )
 
 const (
@@ -16,14 +25,49 @@ const (
 )
 
 type Client struct {
-	graphql *graphql.Client
-	token   string
-	baseURL string
+	graphql   *graphql.Client
+	token     string
+	baseURL     string
+	statusMap   platforms.StatusMap
+	statusNames platforms.StatusNames
+	labelMap    platforms.LabelMap
+	dueDates    platforms.DueDateRules
+
+	createLabels bool
+
+	schema schema
+
+	viewerMu sync.Mutex
+	viewerID string
 }
 
 type Config struct {
 	Token   string `json:"token" yaml:"token"`
 	BaseURL string `json:"base_url,omitempty" yaml:"base_url,omitempty"`
+
+	// StatusMap overrides the state-type based mapping for named workflow
+	// states.
+	StatusMap platforms.StatusMap `json:"status_map,omitempty" yaml:"status_map,omitempty"`
+	// StatusNames filters statuses by a named workflow state instead of
+	// the state type.
+	StatusNames platforms.StatusNames `json:"-" yaml:"-"`
+	// LabelMap translates labels to the names used on other platforms.
+	LabelMap platforms.LabelMap `json:"-" yaml:"-"`
+	// DueDates decides the time of day due dates fall at (default: the end
+	// of the day in local time).
+	DueDates platforms.DueDateRules `json:"-" yaml:"-"`
+	// CreateLabels creates labels the team does not have yet instead of
+	// rejecting the task.
+	CreateLabels bool `json:"-" yaml:"-"`
+	// Limits bound the requests sent to Linear at once and per second,
+	// shared by every client of the same API URL.
+	Limits throttle.Limits `json:"-" yaml:"-"`
+	// Logger receives a debug record for every HTTP request (default: the
+	// logger from logging.Logger at the time of the request).
+	Logger *slog.Logger `json:"-" yaml:"-"`
+	// Transport sends the client's requests (default:
+	// http.DefaultTransport). Tests set it to a record.Cassette.
+	Transport http.RoundTripper `json:"-" yaml:"-"`
 }
 
 func NewClient(cfg Config) (*Client, error) {
@@ -36,25 +80,40 @@ func NewClient(cfg Config) (*Client, error) {
 		)
 	}
 
+	if cfg.DueDates.Location == nil {
+		cfg.DueDates = platforms.DefaultDueDateRules()
+	}
+
 	baseURL := cfg.BaseURL
 	if baseURL == "" {
 		baseURL = LinearAPIURL
 	}
 
+	base := cfg.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
 	httpClient := &http.Client{
 		Timeout: 30 * time.Second,
 		Transport: &authTransport{
 			token: cfg.Token,
-			base:  http.DefaultTransport,
+			base: throttle.Transport(throttle.Shared(baseURL, cfg.Limits),
+				metrics.Transport("linear", telemetry.Transport("linear", logging.Transport(cfg.Logger, "linear", record.Transport("linear", base))))),
 		},
 	}
 
 	graphqlClient := graphql.NewClient(baseURL, httpClient)
 
 	return &Client{
-		graphql: graphqlClient,
-		token:   cfg.Token,
-		baseURL: baseURL,
+		graphql:   graphqlClient,
+		token:     cfg.Token,
+		baseURL:     baseURL,
+		statusMap:   cfg.StatusMap,
+		statusNames: cfg.StatusNames,
+		labelMap:    cfg.LabelMap,
+		dueDates:    cfg.DueDates,
+
+		createLabels: cfg.CreateLabels,
 	}, nil
 }
 
@@ -72,6 +131,10 @@ func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 // Implement PlatformClient interface
 func (c *Client) CreateTask(ctx context.Context, task *models.Task) (*models.Task, error) {
+	if err := task.Validate(); err != nil {
+		return nil, platforms.NewPlatformError(platforms.ErrInvalidInput, "linear", task.ID, err)
+	}
+
 	var mutation struct {
 		IssueCreate struct {
 			Success bool `graphql:"success"`
@@ -88,7 +151,8 @@ func (c *Client) CreateTask(ctx context.Context, task *models.Task) (*models.Tas
 	}
 
 	// Add team ID if specified in metadata
-	if teamID, ok := task.GetMetadata("team_id"); ok {
+	teamID, _ := task.Metadata["team_id"].(string)
+	if teamID != "" {
 		input["teamId"] = teamID
 	}
 
@@ -104,18 +168,27 @@ func (c *Client) CreateTask(ctx context.Context, task *models.Task) (*models.Tas
 		input["projectId"] = task.ProjectID
 	}
 
+	if err := c.setDueDate(input, task); err != nil {
+		return nil, err
+	}
+
+	if err := c.setLabels(ctx, input, task, teamID); err != nil {
+		return nil, err
+	}
+
+	if err := c.setPlanningFields(input, task); err != nil {
+		return nil, err
+	}
+
+	c.dropUnsupportedFields(ctx, issueCreateInput, input)
+
 	variables := map[string]interface{}{
 		"input": input,
 	}
 
 	err := c.graphql.Mutate(ctx, &mutation, variables)
 	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"linear",
-			"",
-			fmt.Errorf("failed to create issue: %w", err),
-		)
+		return nil, apiError("create issue", "", err)
 	}
 
 	if !mutation.IssueCreate.Success {
@@ -127,11 +200,20 @@ func (c *Client) CreateTask(ctx context.Context, task *models.Task) (*models.Tas
 		)
 	}
 
-	createdTask := mutation.IssueCreate.Issue.LinearIssue.ToTask()
+	createdTask := c.toTask(&mutation.IssueCreate.Issue.LinearIssue)
 	return createdTask, nil
 }
 
+// GetTask fetches an issue by its identifier (ENG-123) or ID.
 func (c *Client) GetTask(ctx context.Context, id string) (*models.Task, error) {
+	if team, number, ok := parseIdentifier(id); ok {
+		issue, err := c.issueByIdentifier(ctx, id, team, number)
+		if err != nil {
+			return nil, err
+		}
+		return c.toTask(issue), nil
+	}
+
 	var query struct {
 		Issue LinearIssue `graphql:"issue(id: $id)"`
 	}
@@ -142,27 +224,33 @@ func (c *Client) GetTask(ctx context.Context, id string) (*models.Task, error) {
 
 	err := c.graphql.Query(ctx, &query, variables)
 	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"linear",
-			id,
-			fmt.Errorf("failed to get issue: %w", err),
-		)
+		return nil, apiError("get issue", id, err)
 	}
 
-	task := query.Issue.ToTask()
+	task := c.toTask(&query.Issue)
 	return task, nil
 }
 
 func (c *Client) UpdateTask(ctx context.Context, task *models.Task) (*models.Task, error) {
+	if err := task.Validate(); err != nil {
+		return nil, platforms.NewPlatformError(platforms.ErrInvalidInput, "linear", task.ID, err)
+	}
+
 	linearID, ok := task.GetMetadata("linear_id")
 	if !ok {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrInvalidInput,
-			"linear",
-			task.ID,
-			fmt.Errorf("linear_id not found in task metadata"),
-		)
+		if _, _, isIdentifier := parseIdentifier(task.ID); !isIdentifier {
+			return nil, platforms.NewPlatformError(
+				platforms.ErrInvalidInput,
+				"linear",
+				task.ID,
+				fmt.Errorf("linear_id not found in task metadata"),
+			)
+		}
+		id, err := c.issueID(ctx, task.ID)
+		if err != nil {
+			return nil, err
+		}
+		linearID = id
 	}
 
 	var mutation struct {
@@ -180,6 +268,37 @@ func (c *Client) UpdateTask(ctx context.Context, task *models.Task) (*models.Tas
 		"priority":    convertToLinearPriority(task.Priority),
 	}
 
+	if task.Assignee != nil {
+		if assigneeID, ok := task.Assignee.GetMetadata("linear_id"); ok {
+			input["assigneeId"] = assigneeID
+		}
+	}
+
+	if err := c.setDueDate(input, task); err != nil {
+		return nil, err
+	}
+
+	teamID, _ := task.Metadata["team_id"].(string)
+	if err := c.setLabels(ctx, input, task, teamID); err != nil {
+		return nil, err
+	}
+
+	if err := c.setPlanningFields(input, task); err != nil {
+		return nil, err
+	}
+
+	if task.Status != "" {
+		stateID, err := c.workflowStateID(ctx, task)
+		if err != nil {
+			return nil, err
+		}
+		if stateID != "" {
+			input["stateId"] = stateID
+		}
+	}
+
+	c.dropUnsupportedFields(ctx, issueUpdateInput, input)
+
 	variables := map[string]interface{}{
 		"id":    linearID,
 		"input": input,
@@ -187,12 +306,7 @@ func (c *Client) UpdateTask(ctx context.Context, task *models.Task) (*models.Tas
 
 	err := c.graphql.Mutate(ctx, &mutation, variables)
 	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"linear",
-			task.ID,
-			fmt.Errorf("failed to update issue: %w", err),
-		)
+		return nil, apiError("update issue", task.ID, err)
 	}
 
 	if !mutation.IssueUpdate.Success {
@@ -204,11 +318,16 @@ func (c *Client) UpdateTask(ctx context.Context, task *models.Task) (*models.Tas
 		)
 	}
 
-	updatedTask := mutation.IssueUpdate.Issue.LinearIssue.ToTask()
+	updatedTask := c.toTask(&mutation.IssueUpdate.Issue.LinearIssue)
 	return updatedTask, nil
 }
 
 func (c *Client) DeleteTask(ctx context.Context, id string) error {
+	issueID, err := c.issueID(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	var mutation struct {
 		IssueDelete struct {
 			Success bool `graphql:"success"`
@@ -216,17 +335,12 @@ func (c *Client) DeleteTask(ctx context.Context, id string) error {
 	}
 
 	variables := map[string]interface{}{
-		"id": id,
+		"id": issueID,
 	}
 
-	err := c.graphql.Mutate(ctx, &mutation, variables)
+	err = c.graphql.Mutate(ctx, &mutation, variables)
 	if err != nil {
-		return platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"linear",
-			id,
-			fmt.Errorf("failed to delete issue: %w", err),
-		)
+		return apiError("delete issue", id, err)
 	}
 
 	if !mutation.IssueDelete.Success {
@@ -241,16 +355,17 @@ func (c *Client) DeleteTask(ctx context.Context, id string) error {
 	return nil
 }
 
-func (c *Client) ListTasks(ctx context.Context, filter *models.TaskFilter) ([]*models.Task, error) {
-	var query struct {
-		Issues struct {
-			Nodes []LinearIssue `graphql:"nodes"`
-		} `graphql:"issues(first: $first, after: $after, filter: $filter)"`
-	}
+// Issue page sizes: the number of issues listed without a limit, and the
+// most Linear returns in one request.
+const (
+	defaultIssuesPage = 50
+	maxIssuesPage     = 250
+)
 
-	first := 50
+func (c *Client) ListTasks(ctx context.Context, filter *models.TaskFilter) ([]*models.Task, error) {
+	limit := defaultIssuesPage
 	if filter != nil && filter.Limit > 0 {
-		first = filter.Limit
+		limit = filter.Limit
 	}
 
 	var after *string
@@ -262,42 +377,77 @@ func (c *Client) ListTasks(ctx context.Context, filter *models.TaskFilter) ([]*m
 	linearFilter := map[string]interface{}{}
 	if filter != nil {
 		if filter.Status != nil {
-			linearFilter["state"] = map[string]interface{}{
-				"type": map[string]interface{}{
-					"eq": convertToLinearStateType(*filter.Status),
+			linearFilter["state"] = c.stateFilter(*filter.Status)
+		}
+		if filter.Assignee == models.AssigneeMe {
+			viewerID, err := c.viewer(ctx)
+			if err != nil {
+				return nil, err
+			}
+			linearFilter["assignee"] = map[string]interface{}{
+				"id": map[string]interface{}{
+					"eq": viewerID,
 				},
 			}
-		}
-		if filter.Assignee != "" {
+		} else if filter.Assignee != "" {
 			linearFilter["assignee"] = map[string]interface{}{
 				"email": map[string]interface{}{
 					"eq": filter.Assignee,
 				},
 			}
 		}
+		if len(filter.Labels) > 0 {
+			linearFilter["and"] = c.labelsFilter(filter.Labels)
+		}
+		updatedAt := map[string]interface{}{}
+		if filter.UpdatedSince != nil {
+			updatedAt["gte"] = filter.UpdatedSince.UTC().Format(time.RFC3339)
+		}
+		if filter.UpdatedBefore != nil {
+			updatedAt["lt"] = filter.UpdatedBefore.UTC().Format(time.RFC3339)
+		}
+		if len(updatedAt) > 0 {
+			linearFilter["updatedAt"] = updatedAt
+		}
 	}
 
-	variables := map[string]interface{}{
-		"first":  first,
-		"after":  after,
-		"filter": linearFilter,
-	}
+	// Linear returns at most maxIssuesPage issues a request, so larger
+	// limits are fetched a page at a time.
+	var tasks []*models.Task
+	for len(tasks) < limit {
+		var query struct {
+			Issues struct {
+				Nodes    []LinearIssue `graphql:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `graphql:"hasNextPage"`
+					EndCursor   string `graphql:"endCursor"`
+				} `graphql:"pageInfo"`
+			} `graphql:"issues(first: $first, after: $after, filter: $filter)"`
+		}
 
-	err := c.graphql.Query(ctx, &query, variables)
-	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"linear",
-			"",
-			fmt.Errorf("failed to list issues: %w", err),
-		)
-	}
+		variables := map[string]interface{}{
+			"first":  min(limit-len(tasks), maxIssuesPage),
+			"after":  after,
+			"filter": linearFilter,
+		}
 
-	var tasks []*models.Task
-	for _, issue := range query.Issues.Nodes {
-		tasks = append(tasks, issue.ToTask())
+		if err := c.graphql.Query(ctx, &query, variables); err != nil {
+			return nil, apiError("list issues", "", err)
+		}
+
+		for i := range query.Issues.Nodes {
+			tasks = append(tasks, c.toTask(&query.Issues.Nodes[i]))
+		}
+		if !query.Issues.PageInfo.HasNextPage || len(query.Issues.Nodes) == 0 {
+			break
+		}
+		cursor := query.Issues.PageInfo.EndCursor
+		after = &cursor
 	}
 
+	if tasks == nil {
+		tasks = []*models.Task{}
+	}
 	return tasks, nil
 }
 
@@ -310,12 +460,7 @@ func (c *Client) ListProjects(ctx context.Context) ([]*models.Project, error) {
 
 	err := c.graphql.Query(ctx, &query, nil)
 	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"linear",
-			"",
-			fmt.Errorf("failed to list projects: %w", err),
-		)
+		return nil, apiError("list projects", "", err)
 	}
 
 	var projects []*models.Project
@@ -337,12 +482,7 @@ func (c *Client) GetProject(ctx context.Context, id string) (*models.Project, er
 
 	err := c.graphql.Query(ctx, &query, variables)
 	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"linear",
-			"",
-			fmt.Errorf("failed to get project: %w", err),
-		)
+		return nil, apiError("get project", "", err)
 	}
 
 	project := query.Project.ToProject()
@@ -356,18 +496,30 @@ func (c *Client) GetCurrentUser(ctx context.Context) (*models.User, error) {
 
 	err := c.graphql.Query(ctx, &query, nil)
 	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"linear",
-			"",
-			fmt.Errorf("failed to get current user: %w", err),
-		)
+		return nil, apiError("get current user", "", err)
 	}
 
 	user := query.Viewer.ToUser()
 	return user, nil
 }
 
+// viewer returns the ID of the user the client is authenticated as, asking
+// Linear once per client.
+func (c *Client) viewer(ctx context.Context) (string, error) {
+	c.viewerMu.Lock()
+	defer c.viewerMu.Unlock()
+	if c.viewerID != "" {
+		return c.viewerID, nil
+	}
+
+	user, err := c.GetCurrentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.viewerID = user.ID
+	return c.viewerID, nil
+}
+
 func (c *Client) SearchUsers(ctx context.Context, query string) ([]*models.User, error) {
 	var gqlQuery struct {
 		Users struct {
@@ -387,12 +539,7 @@ func (c *Client) SearchUsers(ctx context.Context, query string) ([]*models.User,
 
 	err := c.graphql.Query(ctx, &gqlQuery, variables)
 	if err != nil {
-		return nil, platforms.NewPlatformError(
-			platforms.ErrPlatformAPI,
-			"linear",
-			"",
-			fmt.Errorf("failed to search users: %w", err),
-		)
+		return nil, apiError("search users", "", err)
 	}
 
 	var users []*models.User
@@ -418,6 +565,77 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 	return err
 }
 
+// stateFilter matches issues in status: by the state name configured in
+// status_map, or else by state type.
+func (c *Client) stateFilter(status models.TaskStatus) map[string]interface{} {
+	if name, ok := c.statusNames.Lookup(status); ok {
+		return map[string]interface{}{
+			"name": map[string]interface{}{
+				"eqIgnoreCase": name,
+			},
+		}
+	}
+	return map[string]interface{}{
+		"type": map[string]interface{}{
+			"eq": convertToLinearStateType(status),
+		},
+	}
+}
+
+// workflowStateID returns the workflow state to move the task's issue to
+// for its status, or "" when the issue's state already has that status.
+// The state named in status_map is preferred, then the team's first state
+// of the matching type, then any state mapped to the status.
+func (c *Client) workflowStateID(ctx context.Context, task *models.Task) (string, error) {
+	team, _ := task.Metadata["team"].(string)
+	currentID, _ := task.Metadata["state_id"].(string)
+	if team == "" {
+		current, err := c.GetTask(ctx, task.ID)
+		if err != nil {
+			return "", err
+		}
+		team, _ = current.Metadata["team"].(string)
+		currentID, _ = current.Metadata["state_id"].(string)
+	}
+
+	states, err := c.ListWorkflowStates(ctx, team)
+	if err != nil {
+		return "", err
+	}
+
+	for _, state := range states {
+		if state.ID == currentID && state.Status == task.Status {
+			return "", nil
+		}
+	}
+
+	if name, ok := c.statusNames.Lookup(task.Status); ok {
+		for _, state := range states {
+			if strings.EqualFold(state.Name, name) {
+				return state.ID, nil
+			}
+		}
+	}
+	stateType := convertToLinearStateType(task.Status)
+	for _, state := range states {
+		if state.Category == stateType && state.Status == task.Status {
+			return state.ID, nil
+		}
+	}
+	for _, state := range states {
+		if state.Status == task.Status {
+			return state.ID, nil
+		}
+	}
+
+	return "", platforms.NewPlatformError(
+		platforms.ErrInvalidInput,
+		"linear",
+		task.ID,
+		fmt.Errorf("team %s has no workflow state for status %s", team, task.Status),
+	)
+}
+
 // Helper function to convert task status to Linear state type
 func convertToLinearStateType(status models.TaskStatus) string {
 	switch status {
@@ -433,3 +651,188 @@ func convertToLinearStateType(status models.TaskStatus) string {
 		return "unstarted"
 	}
 }
+
+// toTask converts an issue and applies the configured status and label
+// maps and due date rules.
+func (c *Client) toTask(issue *LinearIssue) *models.Task {
+	task := issue.ToTask()
+	if status, ok := c.statusMap.Lookup(issue.State.Name); ok {
+		task.Status = status
+	}
+	task.Labels = c.labelMap.Shared(task.Labels)
+	if issue.DueDate != nil && *issue.DueDate != "" {
+		if due, err := c.dueDates.Parse(*issue.DueDate); err == nil {
+			task.DueDate = &due
+		}
+	}
+	return task
+}
+
+// setDueDate adds the task's due date to an issue input. Linear due dates
+// are dates, so a due time is sent as its date under the due date rules.
+func (c *Client) setDueDate(input map[string]interface{}, task *models.Task) error {
+	date, ok, err := c.dueDates.TaskDate(task)
+	if err != nil {
+		return platforms.NewPlatformError(platforms.ErrInvalidInput, "linear", task.ID, err)
+	}
+	if ok {
+		input["dueDate"] = date
+	}
+	return nil
+}
+
+// workflowStateFilter is sent as a typed variable so the query declares
+// $filter with its GraphQL input type.
+type workflowStateFilter map[string]interface{}
+
+func (workflowStateFilter) GetGraphQLType() string {
+	return "WorkflowStateFilter"
+}
+
+// ListWorkflowStates returns the workflow states of a team. projectID is
+// the team key (e.g. "ENG"); when empty, states of all teams are listed.
+func (c *Client) ListWorkflowStates(ctx context.Context, projectID string) ([]*models.WorkflowState, error) {
+	var query struct {
+		WorkflowStates struct {
+			Nodes []struct {
+				LinearWorkflowState
+				Position float64    `graphql:"position"`
+				Team     LinearTeam `graphql:"team"`
+			} `graphql:"nodes"`
+		} `graphql:"workflowStates(first: 250, filter: $filter)"`
+	}
+
+	filter := workflowStateFilter{}
+	if projectID != "" {
+		filter["team"] = map[string]interface{}{
+			"key": map[string]interface{}{
+				"eq": projectID,
+			},
+		}
+	}
+
+	variables := map[string]interface{}{
+		"filter": filter,
+	}
+
+	err := c.graphql.Query(ctx, &query, variables)
+	if err != nil {
+		return nil, apiError("list workflow states", "", err)
+	}
+
+	nodes := query.WorkflowStates.Nodes
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Team.Key != nodes[j].Team.Key {
+			return nodes[i].Team.Key < nodes[j].Team.Key
+		}
+		return nodes[i].Position < nodes[j].Position
+	})
+
+	states := make([]*models.WorkflowState, 0, len(nodes))
+	for _, node := range nodes {
+		state := &models.WorkflowState{
+			ID:       node.ID,
+			Name:     node.Name,
+			Category: node.Type,
+			Scope:    node.Team.Key,
+			Status:   convertLinearStatus(node.Type),
+		}
+		if mapped, ok := c.statusMap.Lookup(node.Name); ok {
+			state.Status = mapped
+			state.Mapped = true
+		}
+		states = append(states, state)
+	}
+
+	return states, nil
+}
+
+// ArchiveTask archives the issue. Archived issues are hidden from lists
+// and can be restored in Linear.
+func (c *Client) ArchiveTask(ctx context.Context, task *models.Task) error {
+	id, _ := task.Metadata["linear_id"].(string)
+	if id == "" {
+		var err error
+		if id, err = c.issueID(ctx, task.ID); err != nil {
+			return err
+		}
+	}
+
+	var mutation struct {
+		IssueArchive struct {
+			Success bool `graphql:"success"`
+		} `graphql:"issueArchive(id: $id)"`
+	}
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	err := c.graphql.Mutate(ctx, &mutation, variables)
+	if err != nil {
+		return apiError("archive issue", task.ID, err)
+	}
+
+	if !mutation.IssueArchive.Success {
+		return platforms.NewPlatformError(
+			platforms.ErrPlatformAPI,
+			"linear",
+			task.ID,
+			fmt.Errorf("issue archive failed"),
+		)
+	}
+
+	return nil
+}
+
+// commentCreateInput is sent as a typed variable so the mutation declares
+// $input with its GraphQL input type.
+type commentCreateInput map[string]interface{}
+
+func (commentCreateInput) GetGraphQLType() string {
+	return "CommentCreateInput"
+}
+
+// Mention returns the user's profile URL, which Linear shows as a mention
+// and uses to notify the user.
+func (c *Client) Mention(user *models.User) string {
+	if url, ok := user.GetMetadata("linear_url"); ok {
+		if url, ok := url.(string); ok {
+			return url
+		}
+	}
+	return ""
+}
+
+// AddComment adds a Markdown comment to the issue.
+func (c *Client) AddComment(ctx context.Context, taskID, body string) error {
+	id, err := c.issueID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	var mutation struct {
+		CommentCreate struct {
+			Success bool `graphql:"success"`
+		} `graphql:"commentCreate(input: $input)"`
+	}
+
+	variables := map[string]interface{}{
+		"input": commentCreateInput{"issueId": id, "body": body},
+	}
+
+	if err := c.graphql.Mutate(ctx, &mutation, variables); err != nil {
+		return apiError("add comment", taskID, err)
+	}
+
+	if !mutation.CommentCreate.Success {
+		return platforms.NewPlatformError(
+			platforms.ErrPlatformAPI,
+			"linear",
+			taskID,
+			fmt.Errorf("comment create failed"),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// graphqlRequest is the body of a request to the Linear API.
+type graphqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// newTestClient returns a client whose requests are answered by respond
+// with a status code and a JSON body.
+func newTestClient(t *testing.T, respond func(req graphqlRequest) (int, string)) (*Client, *httptest.Server) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		status, body := respond(req)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(Config{Token: "lin_api_test", BaseURL: server.URL})
+	require.NoError(t, err)
+	return client, server
+}
+
+func TestClient_StatusErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   platforms.ErrorCode
+	}{
+		{name: "unauthorized", status: http.StatusUnauthorized, body: `{}`, want: platforms.ErrAuthentication},
+		{name: "forbidden", status: http.StatusForbidden, body: `{}`, want: platforms.ErrPermissionDenied},
+		{name: "not found", status: http.StatusNotFound, body: `{}`, want: platforms.ErrNotFound},
+		{name: "too many requests", status: http.StatusTooManyRequests, body: `{}`, want: platforms.ErrRateLimited},
+		{name: "server error", status: http.StatusInternalServerError, body: `{}`, want: platforms.ErrPlatformAPI},
+		{
+			name:   "error body wins over status",
+			status: http.StatusBadRequest,
+			body:   `{"errors":[{"message":"Authentication required, not authenticated","extensions":{"code":"AUTHENTICATION_ERROR"}}]}`,
+			want:   platforms.ErrAuthentication,
+		},
+		{
+			name:   "rate limited",
+			status: http.StatusOK,
+			body:   `{"data":null,"errors":[{"message":"Rate limit exceeded","extensions":{"code":"RATELIMITED"}}]}`,
+			want:   platforms.ErrRateLimited,
+		},
+		{
+			name:   "entity not found",
+			status: http.StatusOK,
+			body:   `{"data":null,"errors":[{"message":"Entity not found: Issue","extensions":{"code":"INVALID_INPUT"}}]}`,
+			want:   platforms.ErrNotFound,
+		},
+		{
+			name:   "unclassified",
+			status: http.StatusOK,
+			body:   `{"data":null,"errors":[{"message":"Argument Validation Error","extensions":{"code":"INVALID_INPUT"}}]}`,
+			want:   platforms.ErrPlatformAPI,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, func(graphqlRequest) (int, string) { return tt.status, tt.body })
+
+			_, err := client.GetCurrentUser(context.Background())
+			code, ok := platforms.CodeOf(err)
+			require.True(t, ok, "not a platform error: %v", err)
+			assert.Equal(t, tt.want, code)
+		})
+	}
+}
+
+func TestClient_PermissionErrors(t *testing.T) {
+	client, _ := newTestClient(t, func(graphqlRequest) (int, string) {
+		return http.StatusOK, `{"data":null,"errors":[{"message":"Invalid scope: ` + "`write`" + ` required","extensions":{"code":"FORBIDDEN"}}]}`
+	})
+
+	err := client.DeleteTask(context.Background(), "9cfb482a-81e3-4154-b5b9-2c805e70a02d")
+	var permErr *platforms.PermissionError
+	require.ErrorAs(t, err, &permErr)
+	assert.Equal(t, `the Linear "write" scope`, permErr.Required)
+	assert.Contains(t, permErr.Remediation, "opentask connect linear")
+}
+
+func TestClient_NetworkError(t *testing.T) {
+	client, server := newTestClient(t, func(graphqlRequest) (int, string) { return http.StatusOK, `{}` })
+	server.Close()
+
+	_, err := client.GetCurrentUser(context.Background())
+	assert.True(t, platforms.IsNetworkError(err))
+}
+
+func TestClient_WorkflowStateID(t *testing.T) {
+	const states = `{"data":{"workflowStates":{"nodes":[
+		{"id":"s-backlog","name":"Backlog","type":"backlog","position":0,"team":{"key":"ENG"}},
+		{"id":"s-todo","name":"Todo","type":"unstarted","position":1,"team":{"key":"ENG"}},
+		{"id":"s-review","name":"In Review","type":"started","position":3,"team":{"key":"ENG"}},
+		{"id":"s-progress","name":"In Progress","type":"started","position":2,"team":{"key":"ENG"}},
+		{"id":"s-done","name":"Done","type":"completed","position":4,"team":{"key":"ENG"}}
+	]}}}`
+
+	tests := []struct {
+		name        string
+		statusNames platforms.StatusNames
+		status      models.TaskStatus
+		stateID     string
+		want        string
+		wantErr     string
+	}{
+		{name: "first state of the type", status: models.StatusInProgress, stateID: "s-todo", want: "s-progress"},
+		{name: "already in a state with the status", status: models.StatusInProgress, stateID: "s-review", want: ""},
+		{
+			name:        "named state matches case-insensitively",
+			statusNames: platforms.StatusNames{models.StatusInProgress: "in review"},
+			status:      models.StatusInProgress,
+			stateID:     "s-todo",
+			want:        "s-review",
+		},
+		{
+			name:        "unknown named state falls back to the type",
+			statusNames: platforms.StatusNames{models.StatusDone: "Shipped"},
+			status:      models.StatusDone,
+			stateID:     "s-todo",
+			want:        "s-done",
+		},
+		{name: "no state for the status", status: models.StatusCancelled, stateID: "s-todo", wantErr: "team ENG has no workflow state for status cancelled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filters []any
+			client, _ := newTestClient(t, func(req graphqlRequest) (int, string) {
+				filters = append(filters, req.Variables["filter"])
+				return http.StatusOK, states
+			})
+			client.statusNames = tt.statusNames
+
+			task := &models.Task{ID: "ENG-1", Status: tt.status, Metadata: map[string]interface{}{"team": "ENG", "state_id": tt.stateID}}
+			got, err := client.workflowStateID(context.Background(), task)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				code, _ := platforms.CodeOf(err)
+				assert.Equal(t, platforms.ErrInvalidInput, code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, []any{map[string]any{"team": map[string]any{"key": map[string]any{"eq": "ENG"}}}}, filters)
+		})
+	}
+}
+
+func TestClient_Mention(t *testing.T) {
+	var sent map[string]any
+	client, _ := newTestClient(t, func(req graphqlRequest) (int, string) {
+		sent, _ = req.Variables["input"].(map[string]any)
+		return http.StatusOK, `{"data":{"commentCreate":{"success":true}}}`
+	})
+
+	user := (&LinearUser{ID: "user-1", DisplayName: "jane", URL: "https://linear.app/acme/profiles/jane"}).ToUser()
+	mention := client.Mention(user)
+	assert.Equal(t, "https://linear.app/acme/profiles/jane", mention)
+	assert.Empty(t, client.Mention(&models.User{Name: "jane"}))
+
+	require.NoError(t, client.AddComment(context.Background(), "issue-uuid", mention+" still needed?"))
+	assert.Equal(t, "https://linear.app/acme/profiles/jane still needed?", sent["body"])
+}
+
+func TestClient_ListTasks_UpdatedFilter(t *testing.T) {
+	var filter map[string]any
+	client, _ := newTestClient(t, func(req graphqlRequest) (int, string) {
+		filter, _ = req.Variables["filter"].(map[string]any)
+		return http.StatusOK, `{"data":{"issues":{"nodes":[]}}}`
+	})
+
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 6, 30, 9, 5, 30, 0, time.UTC)
+	_, err := client.ListTasks(context.Background(), &models.TaskFilter{UpdatedSince: &since, UpdatedBefore: &before})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"gte": "2024-06-01T00:00:00Z", "lt": "2024-06-30T09:05:30Z"}, filter["updatedAt"])
+}
+
+func TestClient_ListTasks_Pages(t *testing.T) {
+	const total = 300
+	var requests []string
+	client, _ := newTestClient(t, func(req graphqlRequest) (int, string) {
+		first := int(req.Variables["first"].(float64))
+		start := 0
+		if after, ok := req.Variables["after"].(string); ok {
+			start, _ = strconv.Atoi(after)
+		}
+		requests = append(requests, fmt.Sprintf("%d+%d", start, first))
+
+		var nodes []string
+		end := min(start+first, total)
+		for i := start; i < end; i++ {
+			nodes = append(nodes, fmt.Sprintf(`{"id":"issue-%d","identifier":"ENG-%d","title":"Task","state":{"name":"Todo","type":"unstarted"},"team":{"key":"ENG"}}`, i+1, i+1))
+		}
+		return http.StatusOK, fmt.Sprintf(`{"data":{"issues":{"nodes":[%s],"pageInfo":{"hasNextPage":%t,"endCursor":"%d"}}}}`,
+			strings.Join(nodes, ","), end < total, end)
+	})
+
+	tasks, err := client.ListTasks(context.Background(), &models.TaskFilter{Limit: 280})
+	require.NoError(t, err)
+	assert.Len(t, tasks, 280)
+	assert.Equal(t, []string{"0+250", "250+30"}, requests)
+	assert.Equal(t, "ENG-280", tasks[len(tasks)-1].ID)
+
+	requests = nil
+	tasks, err = client.ListTasks(context.Background(), &models.TaskFilter{Limit: 1000})
+	require.NoError(t, err)
+	assert.Len(t, tasks, total)
+	assert.Equal(t, []string{"0+250", "250+250"}, requests)
+}
@@ -0,0 +1,195 @@
+package linear
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"opentask/pkg/platforms"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+const apiKeySettings = "Settings → Account → Security & access"
+
+// operationScopes maps the operations used in apiError to the OAuth scope
+// they need.
+var operationScopes = map[string]string{
+	"create issue":         "issues:create",
+	"update issue":         "write",
+	"delete issue":         "write",
+	"get issue":            "read",
+	"list issues":          "read",
+	"list projects":        "read",
+	"get project":          "read",
+	"get current user":     "read",
+	"search users":         "read",
+	"list workflow states": "read",
+	"list labels":          "read",
+	"create label":         "write",
+	"archive issue":        "write",
+	"register webhook":     "admin",
+	"delete webhook":       "admin",
+}
+
+// scopePattern extracts the scope named in messages such as
+// "Invalid scope: `write` required".
+var scopePattern = regexp.MustCompile("`([a-zA-Z:]+)`")
+
+// apiError converts a failed API call into a PlatformError whose code
+// tells authentication, permission, not found, rate limit and network
+// failures apart. Authentication and permission failures get a remediation
+// hint instead of the raw GraphQL response.
+func apiError(operation, taskID string, err error) error {
+	kind, message := classifyError(err)
+
+	switch kind {
+	case "authentication":
+		return platforms.NewPlatformError(
+			platforms.ErrAuthentication,
+			"linear",
+			taskID,
+			fmt.Errorf("Linear rejected the API key\n  hint: create a personal API key under %s and run 'opentask connect linear' again", apiKeySettings),
+		)
+	case "forbidden":
+		return platforms.NewPlatformError(
+			platforms.ErrPermissionDenied,
+			"linear",
+			taskID,
+			permissionError(operation, message),
+		)
+	case "not_found":
+		if message == "" {
+			message = "not found"
+		}
+		return platforms.NewPlatformError(
+			platforms.ErrNotFound,
+			"linear",
+			taskID,
+			fmt.Errorf("failed to %s: %s", operation, message),
+		)
+	case "rate_limited":
+		return platforms.NewPlatformError(
+			platforms.ErrRateLimited,
+			"linear",
+			taskID,
+			&platforms.RateLimitError{Operation: operation},
+		)
+	case "network":
+		return platforms.NewPlatformError(
+			platforms.ErrNetworkError,
+			"linear",
+			taskID,
+			fmt.Errorf("failed to %s: %w", operation, err),
+		)
+	}
+
+	return platforms.NewPlatformError(
+		platforms.ErrPlatformAPI,
+		"linear",
+		taskID,
+		fmt.Errorf("failed to %s: %w", operation, err),
+	)
+}
+
+func permissionError(operation, message string) *platforms.PermissionError {
+	scope := operationScopes[operation]
+	if match := scopePattern.FindStringSubmatch(message); match != nil {
+		scope = match[1]
+	}
+
+	permErr := &platforms.PermissionError{
+		Operation: operation,
+		Required:  "access to this team",
+		Message:   message,
+		Remediation: "ask a workspace admin to add you to the team, " +
+			"or use an API key created by a team member",
+	}
+
+	if strings.Contains(strings.ToLower(message), "scope") && scope != "" {
+		permErr.Required = fmt.Sprintf("the Linear %q scope", scope)
+		permErr.Remediation = fmt.Sprintf("create an API key with the %q scope under %s and run 'opentask connect linear' again",
+			scope, apiKeySettings)
+	}
+
+	return permErr
+}
+
+// classifyError returns "authentication", "forbidden", "not_found",
+// "rate_limited", "network" or "" for err, along with the most useful
+// message Linear returned.
+func classifyError(err error) (string, string) {
+	var gqlErrors graphql.Errors
+	if errors.As(err, &gqlErrors) {
+		for _, gqlErr := range gqlErrors {
+			if kind, message := classifyGraphQLError(gqlErr); kind != "" {
+				return kind, message
+			}
+		}
+	}
+
+	var netErr graphql.NetworkError
+	if errors.As(err, &netErr) {
+		var body struct {
+			Errors []graphql.Error `json:"errors"`
+		}
+		if json.Unmarshal([]byte(netErr.Body()), &body) == nil {
+			for _, gqlErr := range body.Errors {
+				if kind, message := classifyGraphQLError(gqlErr); kind != "" {
+					return kind, message
+				}
+			}
+		}
+
+		switch netErr.StatusCode() {
+		case http.StatusUnauthorized:
+			return "authentication", ""
+		case http.StatusForbidden:
+			return "forbidden", ""
+		case http.StatusNotFound:
+			return "not_found", ""
+		case http.StatusTooManyRequests:
+			return "rate_limited", ""
+		}
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return "network", ""
+	}
+
+	return "", ""
+}
+
+func classifyGraphQLError(gqlErr graphql.Error) (string, string) {
+	message := gqlErr.Message
+	if presentable, ok := gqlErr.Extensions["userPresentableMessage"].(string); ok && presentable != "" {
+		message = presentable
+	}
+
+	code, _ := gqlErr.Extensions["code"].(string)
+	errType, _ := gqlErr.Extensions["type"].(string)
+	signal := strings.ToLower(code + " " + errType + " " + gqlErr.Message)
+
+	switch {
+	case strings.Contains(signal, "authentication"):
+		return "authentication", message
+	case strings.Contains(signal, "ratelimited"),
+		strings.Contains(signal, "rate limit"):
+		return "rate_limited", message
+	case strings.Contains(signal, "forbidden"),
+		strings.Contains(signal, "scope"),
+		strings.Contains(signal, "permission"),
+		strings.Contains(signal, "not allowed"):
+		return "forbidden", message
+	case strings.Contains(signal, "entity not found"),
+		strings.Contains(signal, "could not find"):
+		return "not_found", message
+	}
+
+	return "", message
+}
@@ -0,0 +1,107 @@
+package linear
+
+import (
+	"testing"
+
+	"github.com/hasura/go-graphql-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyGraphQLError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         graphql.Error
+		wantKind    string
+		wantMessage string
+	}{
+		{
+			name:        "authentication code",
+			err:         graphql.Error{Message: "Authentication required", Extensions: map[string]any{"code": "AUTHENTICATION_ERROR"}},
+			wantKind:    "authentication",
+			wantMessage: "Authentication required",
+		},
+		{
+			name:        "authentication type",
+			err:         graphql.Error{Message: "not authenticated", Extensions: map[string]any{"type": "authentication error"}},
+			wantKind:    "authentication",
+			wantMessage: "not authenticated",
+		},
+		{
+			name:        "rate limited code",
+			err:         graphql.Error{Message: "Too many requests", Extensions: map[string]any{"code": "RATELIMITED"}},
+			wantKind:    "rate_limited",
+			wantMessage: "Too many requests",
+		},
+		{
+			name:        "rate limit message",
+			err:         graphql.Error{Message: "Rate limit exceeded"},
+			wantKind:    "rate_limited",
+			wantMessage: "Rate limit exceeded",
+		},
+		{
+			name:        "forbidden code",
+			err:         graphql.Error{Message: "Forbidden", Extensions: map[string]any{"code": "FORBIDDEN"}},
+			wantKind:    "forbidden",
+			wantMessage: "Forbidden",
+		},
+		{
+			name:        "missing scope",
+			err:         graphql.Error{Message: "Invalid scope: `write` required"},
+			wantKind:    "forbidden",
+			wantMessage: "Invalid scope: `write` required",
+		},
+		{
+			name:        "permission message",
+			err:         graphql.Error{Message: "You do not have permission to delete this issue"},
+			wantKind:    "forbidden",
+			wantMessage: "You do not have permission to delete this issue",
+		},
+		{
+			name:        "entity not found",
+			err:         graphql.Error{Message: "Entity not found: Issue", Extensions: map[string]any{"code": "INVALID_INPUT"}},
+			wantKind:    "not_found",
+			wantMessage: "Entity not found: Issue",
+		},
+		{
+			name:        "could not find",
+			err:         graphql.Error{Message: "Could not find referenced Team."},
+			wantKind:    "not_found",
+			wantMessage: "Could not find referenced Team.",
+		},
+		{
+			name: "presentable message",
+			err: graphql.Error{Message: "Forbidden", Extensions: map[string]any{
+				"code":                   "FORBIDDEN",
+				"userPresentableMessage": "You need to be a member of the team.",
+			}},
+			wantKind:    "forbidden",
+			wantMessage: "You need to be a member of the team.",
+		},
+		{
+			name:        "unclassified",
+			err:         graphql.Error{Message: "Argument Validation Error", Extensions: map[string]any{"code": "INVALID_INPUT"}},
+			wantKind:    "",
+			wantMessage: "Argument Validation Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, message := classifyGraphQLError(tt.err)
+			assert.Equal(t, tt.wantKind, kind)
+			assert.Equal(t, tt.wantMessage, message)
+		})
+	}
+}
+
+func TestClassifyError_Errors(t *testing.T) {
+	// The first error that classifies decides.
+	kind, message := classifyError(graphql.Errors{
+		{Message: "Argument Validation Error"},
+		{Message: "Entity not found: Project"},
+	})
+	assert.Equal(t, "not_found", kind)
+	assert.Equal(t, "Entity not found: Project", message)
+
+	kind, _ = classifyError(graphql.Errors{{Message: "Argument Validation Error"}})
+	assert.Equal(t, "", kind)
+}
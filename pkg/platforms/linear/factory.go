@@ -3,6 +3,7 @@ package linear
 import (
 	"fmt"
 	"opentask/pkg/platforms"
+	"opentask/pkg/throttle"
 )
 
 type Factory struct{}
@@ -28,6 +29,10 @@ func (f *Factory) GetName() string {
 	return "Linear"
 }
 
+func (f *Factory) GetDescription() string {
+	return "Linear (https://linear.app)"
+}
+
 func (f *Factory) ValidateConfig(config map[string]any) error {
 	_, err := parseConfig(config)
 	return err
@@ -48,6 +53,44 @@ func parseConfig(config map[string]any) (Config, error) {
 		cfg.BaseURL = baseURL
 	}
 
+	statusMap, err := platforms.ParseStatusMap(config)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.StatusMap = statusMap
+
+	statusNames, err := platforms.ParseStatusNames(config)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.StatusNames = statusNames
+
+	labelMap, err := platforms.ParseLabelMap(config)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.LabelMap = labelMap
+
+	dueDates, err := platforms.ParseDueDateRules(config)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.DueDates = dueDates
+
+	limits, err := throttle.ParseLimits(config)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Limits = limits
+
+	switch createLabels := config[CreateLabelsKey].(type) {
+	case nil:
+	case bool:
+		cfg.CreateLabels = createLabels
+	default:
+		return cfg, fmt.Errorf("%s must be true or false", CreateLabelsKey)
+	}
+
 	// Validate token is not empty
 	if cfg.Token == "" {
 		return cfg, fmt.Errorf("token cannot be empty")
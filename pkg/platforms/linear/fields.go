@@ -0,0 +1,79 @@
+package linear
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+)
+
+const (
+	// EstimateKey is the task metadata key holding the issue estimate in
+	// points, and the --field name that sets it.
+	EstimateKey = "estimate"
+
+	// CycleIDKey is the task metadata key holding the ID of the issue's
+	// cycle, and the --field name that sets it.
+	CycleIDKey = "cycle_id"
+
+	// customFieldsKey holds the values given with --field.
+	customFieldsKey = "custom_fields"
+)
+
+// setPlanningFields adds the task's estimate and cycle to an issue input.
+// Values given with --field take precedence over the ones read from the
+// issue, so an update keeps them unless they are changed.
+func (c *Client) setPlanningFields(input map[string]interface{}, task *models.Task) error {
+	fields, _ := task.Metadata[customFieldsKey].(map[string]string)
+
+	estimate, ok := fields[EstimateKey]
+	var raw any = estimate
+	if !ok {
+		raw, ok = task.Metadata[EstimateKey]
+	}
+	if ok && raw != nil {
+		points, err := parseEstimate(raw)
+		if err != nil {
+			return platforms.NewPlatformError(platforms.ErrInvalidInput, "linear", task.ID, err)
+		}
+		input["estimate"] = points
+	}
+
+	cycleID, ok := fields[CycleIDKey]
+	if !ok {
+		cycleID, _ = task.Metadata[CycleIDKey].(string)
+	}
+	if cycleID != "" {
+		input["cycleId"] = cycleID
+	}
+
+	return nil
+}
+
+// parseEstimate reads a whole number of points, as Linear's estimate
+// scales use.
+func parseEstimate(value any) (int, error) {
+	var points float64
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case float64:
+		points = v
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid estimate %q, expected a number of points", v)
+		}
+		points = parsed
+	default:
+		return 0, fmt.Errorf("invalid estimate %v, expected a number of points", value)
+	}
+
+	if points != math.Trunc(points) || points < 0 {
+		return 0, fmt.Errorf("invalid estimate %v, expected a whole number of points", points)
+	}
+	return int(points), nil
+}
@@ -0,0 +1,86 @@
+package linear
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"opentask/pkg/platforms"
+)
+
+// identifierPattern matches issue identifiers such as ENG-123: the team key
+// and the issue number within the team.
+var identifierPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_]*)-(\d+)$`)
+
+// parseIdentifier splits an issue identifier into its team key and number.
+// ok is false for anything else, such as an issue UUID.
+func parseIdentifier(id string) (team string, number int, ok bool) {
+	match := identifierPattern.FindStringSubmatch(strings.TrimSpace(id))
+	if match == nil {
+		return "", 0, false
+	}
+	number, err := strconv.Atoi(match[2])
+	if err != nil {
+		return "", 0, false
+	}
+	return strings.ToUpper(match[1]), number, true
+}
+
+// issueFilter is sent as a typed variable so the query declares $filter
+// with its GraphQL input type.
+type issueFilter map[string]interface{}
+
+func (issueFilter) GetGraphQLType() string {
+	return "IssueFilter"
+}
+
+// issueByIdentifier finds an issue by team key and number, as shown in
+// Linear's identifiers.
+func (c *Client) issueByIdentifier(ctx context.Context, id, team string, number int) (*LinearIssue, error) {
+	var query struct {
+		Issues struct {
+			Nodes []LinearIssue `graphql:"nodes"`
+		} `graphql:"issues(first: 1, filter: $filter)"`
+	}
+
+	variables := map[string]interface{}{
+		"filter": issueFilter{
+			"team": map[string]interface{}{
+				"key": map[string]interface{}{"eq": team},
+			},
+			"number": map[string]interface{}{"eq": number},
+		},
+	}
+
+	if err := c.graphql.Query(ctx, &query, variables); err != nil {
+		return nil, apiError("get issue", id, err)
+	}
+
+	if len(query.Issues.Nodes) == 0 {
+		return nil, platforms.NewPlatformError(
+			platforms.ErrNotFound,
+			"linear",
+			id,
+			fmt.Errorf("issue not found"),
+		)
+	}
+
+	return &query.Issues.Nodes[0], nil
+}
+
+// issueID returns the UUID mutations need for an issue identifier. Other
+// IDs are returned unchanged.
+func (c *Client) issueID(ctx context.Context, id string) (string, error) {
+	team, number, ok := parseIdentifier(id)
+	if !ok {
+		return id, nil
+	}
+
+	issue, err := c.issueByIdentifier(ctx, id, team, number)
+	if err != nil {
+		return "", err
+	}
+	return issue.ID, nil
+}
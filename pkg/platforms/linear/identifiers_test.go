@@ -0,0 +1,74 @@
+package linear
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"opentask/pkg/platforms"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		id         string
+		wantTeam   string
+		wantNumber int
+		wantOK     bool
+	}{
+		{id: "ENG-123", wantTeam: "ENG", wantNumber: 123, wantOK: true},
+		{id: "eng-7", wantTeam: "ENG", wantNumber: 7, wantOK: true},
+		{id: " MOB2_X-42 ", wantTeam: "MOB2_X", wantNumber: 42, wantOK: true},
+		{id: "ENG-"},
+		{id: "ENG"},
+		{id: "-12"},
+		{id: "2ENG-12"},
+		{id: "ENG-12a"},
+		{id: "ENG-99999999999999999999"},
+		{id: "9cfb482a-81e3-4154-b5b9-2c805e70a02d"},
+		{id: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			team, number, ok := parseIdentifier(tt.id)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantTeam, team)
+			assert.Equal(t, tt.wantNumber, number)
+		})
+	}
+}
+
+func TestClient_IssueID(t *testing.T) {
+	var requests []graphqlRequest
+	client, _ := newTestClient(t, func(req graphqlRequest) (int, string) {
+		requests = append(requests, req)
+		filter, _ := req.Variables["filter"].(map[string]any)
+		number, _ := filter["number"].(map[string]any)
+		if number["eq"] == float64(404) {
+			return http.StatusOK, `{"data":{"issues":{"nodes":[]}}}`
+		}
+		return http.StatusOK, `{"data":{"issues":{"nodes":[{"id":"9cfb482a-81e3-4154-b5b9-2c805e70a02d","identifier":"ENG-123"}]}}}`
+	})
+	ctx := context.Background()
+
+	id, err := client.issueID(ctx, "eng-123")
+	require.NoError(t, err)
+	assert.Equal(t, "9cfb482a-81e3-4154-b5b9-2c805e70a02d", id)
+	require.Len(t, requests, 1)
+	assert.Contains(t, requests[0].Query, "$filter:IssueFilter!")
+	assert.Equal(t, map[string]any{
+		"team":   map[string]any{"key": map[string]any{"eq": "ENG"}},
+		"number": map[string]any{"eq": float64(123)},
+	}, requests[0].Variables["filter"])
+
+	// UUIDs are used as they are.
+	id, err = client.issueID(ctx, "0b6c4b1c-6f43-4d7e-9a52-1ef2a8e0d0c3")
+	require.NoError(t, err)
+	assert.Equal(t, "0b6c4b1c-6f43-4d7e-9a52-1ef2a8e0d0c3", id)
+	assert.Len(t, requests, 1)
+
+	_, err = client.issueID(ctx, "ENG-404")
+	assert.True(t, platforms.IsNotFoundError(err))
+}
@@ -0,0 +1,183 @@
+package linear
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+)
+
+// CreateLabelsKey is the setting that creates labels missing from the
+// team when a task is created or updated with them, instead of failing.
+const CreateLabelsKey = "create_labels"
+
+// issueLabelFilter is sent as a typed variable so the query declares
+// $filter with its GraphQL input type.
+type issueLabelFilter map[string]interface{}
+
+func (issueLabelFilter) GetGraphQLType() string {
+	return "IssueLabelFilter"
+}
+
+// issueLabelCreateInput is sent as a typed variable so the mutation
+// declares $input with its GraphQL input type.
+type issueLabelCreateInput map[string]interface{}
+
+func (issueLabelCreateInput) GetGraphQLType() string {
+	return "IssueLabelCreateInput"
+}
+
+// setLabels adds the IDs of the task's labels to an issue input. Labels
+// are looked up by name among the team's and the workspace's labels.
+func (c *Client) setLabels(ctx context.Context, input map[string]interface{}, task *models.Task, teamID string) error {
+	if len(task.Labels) == 0 {
+		return nil
+	}
+
+	ids, err := c.labelIDs(ctx, c.labelMap.Platform(task.Labels), teamID)
+	if err != nil {
+		return err
+	}
+	input["labelIds"] = ids
+	return nil
+}
+
+func (c *Client) labelIDs(ctx context.Context, names []string, teamID string) ([]string, error) {
+	var query struct {
+		IssueLabels struct {
+			Nodes []struct {
+				LinearLabel
+				Team *struct {
+					ID string `graphql:"id"`
+				} `graphql:"team"`
+			} `graphql:"nodes"`
+		} `graphql:"issueLabels(first: 250, filter: $filter)"`
+	}
+
+	filter := issueLabelFilter{}
+	if teamID != "" {
+		filter["or"] = []map[string]interface{}{
+			{"team": map[string]interface{}{"id": map[string]interface{}{"eq": teamID}}},
+			{"team": map[string]interface{}{"null": true}},
+		}
+	}
+
+	variables := map[string]interface{}{
+		"filter": filter,
+	}
+
+	if err := c.graphql.Query(ctx, &query, variables); err != nil {
+		return nil, apiError("list labels", "", err)
+	}
+
+	// Team labels take precedence over workspace labels of the same name.
+	found := make(map[string]string)
+	for _, label := range query.IssueLabels.Nodes {
+		key := strings.ToLower(label.Name)
+		if _, ok := found[key]; !ok || label.Team != nil {
+			found[key] = label.ID
+		}
+	}
+
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		if id, ok := found[strings.ToLower(name)]; ok {
+			ids = append(ids, id)
+			continue
+		}
+
+		if !c.createLabels {
+			return nil, platforms.NewPlatformError(
+				platforms.ErrInvalidInput,
+				"linear",
+				"",
+				fmt.Errorf("label %q does not exist (set %s: true to create missing labels)", name, CreateLabelsKey),
+			)
+		}
+
+		id, err := c.createLabel(ctx, name, teamID)
+		if err != nil {
+			return nil, err
+		}
+		found[strings.ToLower(name)] = id
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (c *Client) createLabel(ctx context.Context, name, teamID string) (string, error) {
+	var mutation struct {
+		IssueLabelCreate struct {
+			Success    bool        `graphql:"success"`
+			IssueLabel LinearLabel `graphql:"issueLabel"`
+		} `graphql:"issueLabelCreate(input: $input)"`
+	}
+
+	input := issueLabelCreateInput{"name": name}
+	if teamID != "" {
+		input["teamId"] = teamID
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	if err := c.graphql.Mutate(ctx, &mutation, variables); err != nil {
+		return "", apiError("create label", "", err)
+	}
+	if !mutation.IssueLabelCreate.Success {
+		return "", platforms.NewPlatformError(
+			platforms.ErrPlatformAPI,
+			"linear",
+			"",
+			fmt.Errorf("creating label %q failed", name),
+		)
+	}
+
+	return mutation.IssueLabelCreate.IssueLabel.ID, nil
+}
+
+// labelsFilter matches issues that have every one of labels.
+func (c *Client) labelsFilter(labels []string) []map[string]interface{} {
+	conditions := make([]map[string]interface{}, 0, len(labels))
+	for _, label := range c.labelMap.Platform(labels) {
+		conditions = append(conditions, map[string]interface{}{
+			"labels": map[string]interface{}{
+				"some": map[string]interface{}{
+					"name": map[string]interface{}{
+						"eqIgnoreCase": label,
+					},
+				},
+			},
+		})
+	}
+	return conditions
+}
+
+// ListVocabulary returns the labels of every team and of the workspace.
+// Linear labels belong to teams rather than projects, so projectID is not
+// used.
+func (c *Client) ListVocabulary(ctx context.Context, projectID string) (*platforms.Vocabulary, error) {
+	var query struct {
+		IssueLabels struct {
+			Nodes []LinearLabel `graphql:"nodes"`
+		} `graphql:"issueLabels(first: 250)"`
+	}
+
+	if err := c.graphql.Query(ctx, &query, nil); err != nil {
+		return nil, apiError("list labels", "", err)
+	}
+
+	labels := make([]string, 0, len(query.IssueLabels.Nodes))
+	for _, label := range query.IssueLabels.Nodes {
+		labels = append(labels, label.Name)
+	}
+	return &platforms.Vocabulary{
+		Labels:    platforms.SortedUnique(c.labelMap.Shared(labels)),
+		FetchedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,83 @@
+package linear
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"opentask/pkg/platforms"
+)
+
+const (
+	issueCreateInput = "IssueCreateInput"
+	issueUpdateInput = "IssueUpdateInput"
+)
+
+// schema is what introspection found out about the API at baseURL: the
+// input fields issue mutations accept, recorded as features such as
+// "IssueCreateInput.estimate". Proxies and older self-hosted gateways may
+// lag behind api.linear.app.
+type schema struct {
+	mu       sync.Mutex
+	detected bool
+	version  platforms.InstanceVersion
+}
+
+// inputType is a GraphQL input object as returned by introspection.
+type inputType struct {
+	InputFields []struct {
+		Name string `graphql:"name"`
+	} `graphql:"inputFields"`
+}
+
+// schemaVersion returns the detected schema, introspecting it on first use
+// unless a recent run already did. ok is false when introspection failed,
+// in which case every field is assumed to be supported.
+func (c *Client) schemaVersion(ctx context.Context) (platforms.InstanceVersion, bool) {
+	c.schema.mu.Lock()
+	defer c.schema.mu.Unlock()
+
+	if c.schema.detected {
+		return c.schema.version, true
+	}
+
+	if cached, ok := platforms.CachedVersion(c.baseURL); ok {
+		c.schema.version, c.schema.detected = cached, true
+		return cached, true
+	}
+
+	var query struct {
+		Create inputType `graphql:"create: __type(name: \"IssueCreateInput\")"`
+		Update inputType `graphql:"update: __type(name: \"IssueUpdateInput\")"`
+	}
+	if err := c.graphql.Query(ctx, &query, nil); err != nil {
+		return platforms.InstanceVersion{}, false
+	}
+
+	version := platforms.InstanceVersion{Deployment: "cloud", DetectedAt: time.Now()}
+	for _, field := range query.Create.InputFields {
+		version.Features = append(version.Features, issueCreateInput+"."+field.Name)
+	}
+	for _, field := range query.Update.InputFields {
+		version.Features = append(version.Features, issueUpdateInput+"."+field.Name)
+	}
+
+	platforms.CacheVersion(c.baseURL, version)
+	c.schema.version, c.schema.detected = version, true
+	return version, true
+}
+
+// dropUnsupportedFields removes the fields of an issue input that the API
+// does not accept, such as estimate on an API without estimates, so the
+// rest of the change is still made rather than the whole mutation failing.
+func (c *Client) dropUnsupportedFields(ctx context.Context, typeName string, input map[string]interface{}) {
+	version, ok := c.schemaVersion(ctx)
+	if !ok || len(version.Features) == 0 {
+		return
+	}
+	for field := range input {
+		if !version.Has(typeName + "." + field) {
+			delete(input, field)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package linear
 
 import (
+	"fmt"
 	"opentask/pkg/models"
 	"time"
 )
@@ -16,10 +17,12 @@ type LinearIssue struct {
 	Assignee    *LinearUser      `json:"assignee"`
 	Team        LinearTeam       `json:"team"`
 	Project     *LinearProject   `json:"project"`
-	Labels      []LinearLabel    `json:"labels"`
+	Labels      LinearLabels     `json:"labels"`
 	CreatedAt   time.Time        `json:"createdAt"`
 	UpdatedAt   time.Time        `json:"updatedAt"`
-	DueDate     *time.Time       `json:"dueDate"`
+	DueDate     *string          `json:"dueDate"`
+	Estimate    *float64         `json:"estimate"`
+	Cycle       *LinearCycle     `json:"cycle"`
 	URL         string           `json:"url"`
 }
 
@@ -37,6 +40,7 @@ type LinearUser struct {
 	DisplayName string `json:"displayName"`
 	AvatarURL   string `json:"avatarUrl"`
 	Active      bool   `json:"active"`
+	URL         string `json:"url"`
 }
 
 type LinearTeam struct {
@@ -47,10 +51,17 @@ type LinearTeam struct {
 }
 
 type LinearProject struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	SlugID      string `json:"slugId"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	SlugID      string      `json:"slugId"`
+	URL         string      `json:"url"`
+	Lead        *LinearUser `json:"lead"`
+}
+
+// LinearLabels is the connection Linear returns an issue's labels in.
+type LinearLabels struct {
+	Nodes []LinearLabel `json:"nodes"`
 }
 
 type LinearLabel struct {
@@ -59,12 +70,22 @@ type LinearLabel struct {
 	Color string `json:"color"`
 }
 
+type LinearCycle struct {
+	ID     string  `json:"id"`
+	Number float64 `json:"number"`
+	Name   *string `json:"name"`
+}
+
 type LinearWorkflowState struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 	Type string `json:"type"`
 }
 
+// issueMetadataSize is how many metadata keys an issue can have, so the
+// map is allocated once.
+const issueMetadataSize = 9
+
 // Conversion methods to unified models
 func (li *LinearIssue) ToTask() *models.Task {
 	task := &models.Task{
@@ -76,8 +97,7 @@ func (li *LinearIssue) ToTask() *models.Task {
 		Platform:    models.PlatformLinear,
 		CreatedAt:   li.CreatedAt,
 		UpdatedAt:   li.UpdatedAt,
-		DueDate:     li.DueDate,
-		Metadata:    make(map[string]any),
+		Metadata:    make(map[string]any, issueMetadataSize),
 	}
 
 	// Set assignee
@@ -91,7 +111,10 @@ func (li *LinearIssue) ToTask() *models.Task {
 	}
 
 	// Set labels
-	for _, label := range li.Labels {
+	if len(li.Labels.Nodes) > 0 {
+		task.Labels = make([]string, 0, len(li.Labels.Nodes))
+	}
+	for _, label := range li.Labels.Nodes {
 		task.Labels = append(task.Labels, label.Name)
 	}
 
@@ -99,8 +122,16 @@ func (li *LinearIssue) ToTask() *models.Task {
 	task.Metadata["linear_id"] = li.ID
 	task.Metadata["linear_url"] = li.URL
 	task.Metadata["team"] = li.Team.Key
+	task.Metadata["team_id"] = li.Team.ID
 	task.Metadata["state_id"] = li.State.ID
 	task.Metadata["state_color"] = li.State.Color
+	if li.Estimate != nil {
+		task.Metadata[EstimateKey] = *li.Estimate
+	}
+	if li.Cycle != nil {
+		task.Metadata[CycleIDKey] = li.Cycle.ID
+		task.Metadata["cycle"] = li.Cycle.Label()
+	}
 
 	return task
 }
@@ -114,22 +145,29 @@ func (lu *LinearUser) ToUser() *models.User {
 		Platform: models.PlatformLinear,
 		Active:   lu.Active,
 		Metadata: map[string]any{
-			"linear_id": lu.ID,
+			"linear_id":  lu.ID,
+			"linear_url": lu.URL,
 		},
 	}
 }
 
 func (lp *LinearProject) ToProject() *models.Project {
-	return &models.Project{
-		ID:       lp.ID,
-		Name:     lp.Name,
-		Platform: models.PlatformLinear,
-		Active:   true,
+	project := &models.Project{
+		ID:          lp.ID,
+		Name:        lp.Name,
+		Description: lp.Description,
+		Platform:    models.PlatformLinear,
+		Active:      true,
 		Metadata: map[string]any{
-			"linear_id": lp.ID,
-			"slug_id":   lp.SlugID,
+			"linear_id":  lp.ID,
+			"slug_id":    lp.SlugID,
+			"linear_url": lp.URL,
 		},
 	}
+	if lp.Lead != nil {
+		project.Lead = lp.Lead.ToUser()
+	}
+	return project
 }
 
 // Helper functions for status/priority conversion
@@ -178,3 +216,11 @@ func convertToLinearPriority(priority models.Priority) float64 {
 		return 3
 	}
 }
+
+// Label returns the cycle's name, or "Cycle N" for unnamed cycles.
+func (lc *LinearCycle) Label() string {
+	if lc.Name != nil && *lc.Name != "" {
+		return *lc.Name
+	}
+	return fmt.Sprintf("Cycle %d", int(lc.Number))
+}
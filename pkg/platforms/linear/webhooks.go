@@ -0,0 +1,149 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"opentask/pkg/platforms"
+)
+
+// webhookResourceTypes are the Linear resources OpenTask needs events for
+// to keep tasks in sync.
+var webhookResourceTypes = []string{"Issue", "Comment", "IssueLabel"}
+
+// webhookCreateInput is sent as a typed variable so the mutation declares
+// $input with its GraphQL input type.
+type webhookCreateInput map[string]interface{}
+
+func (webhookCreateInput) GetGraphQLType() string {
+	return "WebhookCreateInput"
+}
+
+// RegisterWebhook creates a webhook for issue events. Creating webhooks
+// requires an API key with the admin scope.
+func (c *Client) RegisterWebhook(ctx context.Context, opts platforms.WebhookOptions) (*platforms.Webhook, error) {
+	var mutation struct {
+		WebhookCreate struct {
+			Success bool `graphql:"success"`
+			Webhook struct {
+				ID            string   `graphql:"id"`
+				URL           string   `graphql:"url"`
+				ResourceTypes []string `graphql:"resourceTypes"`
+			} `graphql:"webhook"`
+		} `graphql:"webhookCreate(input: $input)"`
+	}
+
+	input := webhookCreateInput{
+		"url":           opts.URL,
+		"label":         "OpenTask",
+		"resourceTypes": webhookResourceTypes,
+	}
+	if opts.Project != "" {
+		input["teamId"] = opts.Project
+	} else {
+		input["allPublicTeams"] = true
+	}
+	if opts.Secret != "" {
+		input["secret"] = opts.Secret
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	err := c.graphql.Mutate(ctx, &mutation, variables)
+	if err != nil {
+		return nil, apiError("register webhook", "", err)
+	}
+
+	if !mutation.WebhookCreate.Success {
+		return nil, platforms.NewPlatformError(
+			platforms.ErrPlatformAPI,
+			"linear",
+			"",
+			fmt.Errorf("webhook creation failed"),
+		)
+	}
+
+	webhook := mutation.WebhookCreate.Webhook
+	return &platforms.Webhook{
+		ID:     webhook.ID,
+		URL:    webhook.URL,
+		Events: webhook.ResourceTypes,
+	}, nil
+}
+
+// UnregisterWebhook deletes a webhook created by RegisterWebhook.
+func (c *Client) UnregisterWebhook(ctx context.Context, id string) error {
+	var mutation struct {
+		WebhookDelete struct {
+			Success bool `graphql:"success"`
+		} `graphql:"webhookDelete(id: $id)"`
+	}
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	err := c.graphql.Mutate(ctx, &mutation, variables)
+	if err != nil {
+		return apiError("delete webhook", "", err)
+	}
+
+	if !mutation.WebhookDelete.Success {
+		return platforms.NewPlatformError(
+			platforms.ErrPlatformAPI,
+			"linear",
+			"",
+			fmt.Errorf("webhook deletion failed"),
+		)
+	}
+
+	return nil
+}
+
+// webhookActions maps the actions of Issue deliveries to what they mean
+// for the task.
+var webhookActions = map[string]platforms.WebhookAction{
+	"create": platforms.WebhookCreated,
+	"update": platforms.WebhookUpdated,
+	"remove": platforms.WebhookDeleted,
+}
+
+// ParseWebhook reads an Issue delivery. Linear signs deliveries of
+// webhooks with a secret in the Linear-Signature header; comment and label
+// deliveries are not about a task and return nil.
+func (c *Client) ParseWebhook(header http.Header, body []byte, secret string) (*platforms.WebhookEvent, error) {
+	if secret != "" && !platforms.ValidSignature(body, secret, header.Get("Linear-Signature")) {
+		return nil, platforms.NewPlatformError(
+			platforms.ErrAuthentication,
+			"linear",
+			"",
+			fmt.Errorf("webhook signature does not match"),
+		)
+	}
+
+	var delivery struct {
+		Action string `json:"action"`
+		Type   string `json:"type"`
+		Data   struct {
+			Identifier string `json:"identifier"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &delivery); err != nil {
+		return nil, platforms.NewPlatformError(
+			platforms.ErrInvalidInput,
+			"linear",
+			"",
+			fmt.Errorf("failed to decode webhook: %w", err),
+		)
+	}
+
+	action, ok := webhookActions[delivery.Action]
+	if !ok || delivery.Type != "Issue" || delivery.Data.Identifier == "" {
+		return nil, nil
+	}
+	return &platforms.WebhookEvent{Action: action, TaskID: delivery.Data.Identifier}, nil
+}
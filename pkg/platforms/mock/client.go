@@ -0,0 +1,419 @@
+// Package mock is a platform that keeps its tasks in memory or in a local
+// JSON file. It needs no credentials, so it serves demos and tests that
+// must not reach a real platform.
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+)
+
+// Config configures a mock client.
+type Config struct {
+	// File keeps the tasks between runs. Empty keeps them in memory.
+	File string
+	// Empty starts without the sample data.
+	Empty bool
+}
+
+// Client is a platforms.PlatformClient over an in-memory store.
+type Client struct {
+	file string
+
+	mu    sync.Mutex
+	state *state
+}
+
+// state is everything the mock platform holds, as saved to Config.File.
+type state struct {
+	NextID   int               `json:"next_id"`
+	Projects []*models.Project `json:"projects"`
+	Users    []*models.User    `json:"users"`
+	Tasks    []*models.Task    `json:"tasks"`
+}
+
+// NewClient returns a client over the tasks in cfg.File, or over the
+// sample data when the file does not exist yet.
+func NewClient(cfg Config) (*Client, error) {
+	c := &Client{file: cfg.File}
+
+	if cfg.File != "" {
+		data, err := os.ReadFile(cfg.File)
+		switch {
+		case err == nil:
+			var saved state
+			if err := json.Unmarshal(data, &saved); err != nil {
+				return nil, fmt.Errorf("failed to read mock tasks from %s: %w", cfg.File, err)
+			}
+			c.state = &saved
+			return c, nil
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, fmt.Errorf("failed to read mock tasks: %w", err)
+		}
+	}
+
+	if cfg.Empty {
+		c.state = &state{NextID: 1, Users: []*models.User{currentUser()}}
+	} else {
+		c.state = sampleState(time.Now())
+	}
+	return c, nil
+}
+
+func (c *Client) CreateTask(ctx context.Context, task *models.Task) (*models.Task, error) {
+	if err := task.Validate(); err != nil {
+		return nil, platforms.NewPlatformError(platforms.ErrInvalidInput, "mock", task.ID, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project := task.ProjectID
+	if project == "" && len(c.state.Projects) > 0 {
+		project = c.state.Projects[0].Key
+	}
+	if project == "" {
+		project = "DEMO"
+	} else if p := c.project(project); p != nil {
+		project = p.Key
+	} else {
+		return nil, platforms.NewPlatformError(platforms.ErrNotFound, "mock", "", fmt.Errorf("project %s does not exist", task.ProjectID))
+	}
+
+	now := time.Now()
+	created := copyTask(task)
+	created.ID = fmt.Sprintf("%s-%d", project, c.state.NextID)
+	created.ProjectID = project
+	created.Platform = models.PlatformMock
+	if created.Status == "" {
+		created.Status = models.StatusOpen
+	}
+	created.CreatedAt, created.UpdatedAt = now, now
+	created.Assignee = c.user(task.Assignee)
+	c.state.NextID++
+	c.state.Tasks = append(c.state.Tasks, created)
+
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return copyTask(created), nil
+}
+
+func (c *Client) GetTask(ctx context.Context, id string) (*models.Task, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.task(id)
+	if i < 0 {
+		return nil, notFound(id)
+	}
+	return copyTask(c.state.Tasks[i]), nil
+}
+
+func (c *Client) UpdateTask(ctx context.Context, task *models.Task) (*models.Task, error) {
+	if err := task.Validate(); err != nil {
+		return nil, platforms.NewPlatformError(platforms.ErrInvalidInput, "mock", task.ID, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.task(task.ID)
+	if i < 0 {
+		return nil, notFound(task.ID)
+	}
+
+	current := c.state.Tasks[i]
+	updated := copyTask(task)
+	updated.ID = current.ID
+	updated.ProjectID = current.ProjectID
+	updated.Platform = current.Platform
+	updated.CreatedAt = current.CreatedAt
+	updated.UpdatedAt = time.Now()
+	if updated.Status == "" {
+		updated.Status = current.Status
+	}
+	updated.Assignee = c.user(task.Assignee)
+	c.state.Tasks[i] = updated
+
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return copyTask(updated), nil
+}
+
+func (c *Client) DeleteTask(ctx context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.task(id)
+	if i < 0 {
+		return notFound(id)
+	}
+	c.state.Tasks = slices.Delete(c.state.Tasks, i, i+1)
+	return c.save()
+}
+
+// ListTasks returns the tasks matching filter, most recently updated
+// first.
+func (c *Client) ListTasks(ctx context.Context, filter *models.TaskFilter) ([]*models.Task, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if filter != nil && strings.EqualFold(filter.Assignee, models.AssigneeMe) {
+		me := *filter
+		me.Assignee = currentUser().ID
+		filter = &me
+	}
+
+	var matched []*models.Task
+	for _, task := range c.state.Tasks {
+		if filter.Matches(task) {
+			matched = append(matched, task)
+		}
+	}
+	// Like Jira, tasks updated before a time come oldest first.
+	slices.SortStableFunc(matched, func(a, b *models.Task) int {
+		if filter != nil && filter.UpdatedBefore != nil {
+			return a.UpdatedAt.Compare(b.UpdatedAt)
+		}
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+
+	if filter != nil {
+		if filter.Offset > 0 {
+			matched = matched[min(filter.Offset, len(matched)):]
+		}
+		if filter.Limit > 0 && len(matched) > filter.Limit {
+			matched = matched[:filter.Limit]
+		}
+	}
+
+	// Only the tasks returned are copied.
+	var tasks []*models.Task
+	if len(matched) > 0 {
+		tasks = make([]*models.Task, len(matched))
+	}
+	for i, task := range matched {
+		tasks[i] = copyTask(task)
+	}
+	return tasks, nil
+}
+
+func (c *Client) ListProjects(ctx context.Context) ([]*models.Project, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	projects := make([]*models.Project, len(c.state.Projects))
+	for i, project := range c.state.Projects {
+		copied := *project
+		projects[i] = &copied
+	}
+	return projects, nil
+}
+
+func (c *Client) GetProject(ctx context.Context, id string) (*models.Project, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	project := c.project(id)
+	if project == nil {
+		return nil, platforms.NewPlatformError(platforms.ErrNotFound, "mock", "", fmt.Errorf("project %s does not exist", id))
+	}
+	copied := *project
+	return &copied, nil
+}
+
+func (c *Client) GetCurrentUser(ctx context.Context) (*models.User, error) {
+	return currentUser(), nil
+}
+
+func (c *Client) SearchUsers(ctx context.Context, query string) ([]*models.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	query = strings.ToLower(query)
+	var users []*models.User
+	for _, user := range c.state.Users {
+		if strings.Contains(strings.ToLower(user.Name), query) ||
+			strings.Contains(strings.ToLower(user.Email), query) ||
+			strings.Contains(strings.ToLower(user.Username), query) {
+			copied := *user
+			users = append(users, &copied)
+		}
+	}
+	return users, nil
+}
+
+func (c *Client) GetPlatformInfo() platforms.PlatformInfo {
+	return platforms.PlatformInfo{
+		Name:        "Mock",
+		Type:        "mock",
+		Version:     "1.0",
+		Description: "Sample tasks kept on this machine",
+	}
+}
+
+func (c *Client) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// ListWorkflowStates returns one state per unified status.
+func (c *Client) ListWorkflowStates(ctx context.Context, projectID string) ([]*models.WorkflowState, error) {
+	statuses := []models.TaskStatus{models.StatusOpen, models.StatusInProgress, models.StatusDone, models.StatusCancelled}
+	names := []string{"To Do", "In Progress", "Done", "Cancelled"}
+
+	states := make([]*models.WorkflowState, len(statuses))
+	for i, status := range statuses {
+		states[i] = &models.WorkflowState{ID: string(status), Name: names[i], Status: status, Mapped: true}
+	}
+	return states, nil
+}
+
+// task returns the index of the task with the given ID, or -1.
+func (c *Client) task(id string) int {
+	return slices.IndexFunc(c.state.Tasks, func(task *models.Task) bool {
+		return strings.EqualFold(task.ID, id)
+	})
+}
+
+func (c *Client) project(id string) *models.Project {
+	for _, project := range c.state.Projects {
+		if strings.EqualFold(project.ID, id) || strings.EqualFold(project.Key, id) {
+			return project
+		}
+	}
+	return nil
+}
+
+// user returns the known user assignee refers to, or assignee itself when
+// there is none.
+func (c *Client) user(assignee *models.User) *models.User {
+	if assignee == nil {
+		return nil
+	}
+	for _, user := range c.state.Users {
+		for _, ref := range []string{assignee.ID, assignee.Username, assignee.Email} {
+			if ref != "" && (strings.EqualFold(ref, user.ID) || strings.EqualFold(ref, user.Username) || strings.EqualFold(ref, user.Email)) {
+				copied := *user
+				return &copied
+			}
+		}
+	}
+	copied := *assignee
+	return &copied
+}
+
+// save writes the state to the file, if any, replacing it at once.
+func (c *Client) save() error {
+	if c.file == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(c.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.file), 0700); err != nil {
+		return fmt.Errorf("failed to save mock tasks: %w", err)
+	}
+	tmp := c.file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save mock tasks: %w", err)
+	}
+	return os.Rename(tmp, c.file)
+}
+
+func notFound(id string) error {
+	return platforms.NewPlatformError(platforms.ErrNotFound, "mock", id, fmt.Errorf("task %s does not exist", id))
+}
+
+// copyTask returns a copy of task that shares no labels, metadata or
+// assignee with it, so callers cannot change the stored task. Empty labels
+// and metadata are left nil rather than allocated.
+func copyTask(task *models.Task) *models.Task {
+	copied := *task
+	copied.Labels = nil
+	if len(task.Labels) > 0 {
+		copied.Labels = slices.Clone(task.Labels)
+	}
+	copied.Metadata = nil
+	if len(task.Metadata) > 0 {
+		copied.Metadata = maps.Clone(task.Metadata)
+	}
+	if task.Assignee != nil {
+		assignee := *task.Assignee
+		copied.Assignee = &assignee
+	}
+	if task.DueDate != nil {
+		due := *task.DueDate
+		copied.DueDate = &due
+	}
+	return &copied
+}
+
+func currentUser() *models.User {
+	return &models.User{ID: "demo", Name: "Demo User", Email: "demo@example.com", Username: "demo", Platform: models.PlatformMock, Active: true}
+}
+
+// sampleState returns the sample project, users and tasks, dated relative
+// to now.
+func sampleState(now time.Time) *state {
+	alex := &models.User{ID: "alex", Name: "Alex Kim", Email: "alex@example.com", Username: "alex", Platform: models.PlatformMock, Active: true}
+	me := currentUser()
+	project := &models.Project{ID: "1", Key: "DEMO", Name: "Demo project", Description: "Sample tasks to try opentask with", Platform: models.PlatformMock, Lead: me, Active: true, CreatedAt: now.AddDate(0, -1, 0), UpdatedAt: now}
+
+	samples := []struct {
+		title    string
+		status   models.TaskStatus
+		priority models.Priority
+		assignee *models.User
+		labels   []string
+		age      time.Duration
+		due      int
+	}{
+		{"Fix login redirect loop", models.StatusInProgress, models.PriorityHigh, me, []string{"bug", "auth"}, 2 * time.Hour, 1},
+		{"Add dark mode to settings page", models.StatusOpen, models.PriorityMedium, alex, []string{"frontend"}, 26 * time.Hour, 7},
+		{"Rate limit the public API", models.StatusOpen, models.PriorityUrgent, me, []string{"backend"}, 50 * time.Hour, -1},
+		{"Write release notes for 1.3", models.StatusOpen, models.PriorityLow, nil, []string{"docs"}, 75 * time.Hour, 0},
+		{"Upgrade the database driver", models.StatusDone, models.PriorityMedium, alex, []string{"backend"}, 120 * time.Hour, 0},
+		{"Drop support for the v1 API", models.StatusCancelled, models.PriorityLow, nil, nil, 240 * time.Hour, 0},
+	}
+
+	s := &state{Projects: []*models.Project{project}, Users: []*models.User{me, alex}}
+	for i, sample := range samples {
+		updated := now.Add(-sample.age)
+		task := &models.Task{
+			ID:        project.Key + "-" + strconv.Itoa(i+1),
+			Title:     sample.title,
+			Status:    sample.status,
+			Priority:  sample.priority,
+			Assignee:  sample.assignee,
+			Platform:  models.PlatformMock,
+			ProjectID: project.Key,
+			Labels:    sample.labels,
+			CreatedAt: updated.Add(-48 * time.Hour),
+			UpdatedAt: updated,
+		}
+		if sample.due != 0 {
+			due := now.AddDate(0, 0, sample.due).Truncate(24 * time.Hour)
+			task.DueDate = &due
+		}
+		s.Tasks = append(s.Tasks, task)
+	}
+	s.NextID = len(s.Tasks) + 1
+	return s
+}
@@ -0,0 +1,153 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SampleData(t *testing.T) {
+	client, err := NewClient(Config{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	tasks, err := client.ListTasks(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 6)
+	assert.Equal(t, "DEMO-1", tasks[0].ID, "most recently updated first")
+
+	projects, err := client.ListProjects(ctx)
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+	assert.Equal(t, "DEMO", projects[0].Key)
+
+	user, err := client.GetCurrentUser(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "demo", user.ID)
+	require.NoError(t, client.HealthCheck(ctx))
+}
+
+func TestClient_ListTasks_Filter(t *testing.T) {
+	client, err := NewClient(Config{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	open := models.StatusOpen
+	tests := []struct {
+		name   string
+		filter *models.TaskFilter
+		want   []string
+	}{
+		{"status", &models.TaskFilter{Status: &open}, []string{"DEMO-2", "DEMO-3", "DEMO-4"}},
+		{"assigned to me", &models.TaskFilter{Assignee: models.AssigneeMe}, []string{"DEMO-1", "DEMO-3"}},
+		{"label", &models.TaskFilter{Labels: []string{"backend"}}, []string{"DEMO-3", "DEMO-5"}},
+		{"query", &models.TaskFilter{Query: "login"}, []string{"DEMO-1"}},
+		{"limit and offset", &models.TaskFilter{Offset: 1, Limit: 2}, []string{"DEMO-2", "DEMO-3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := client.ListTasks(ctx, tt.filter)
+			require.NoError(t, err)
+			ids := make([]string, 0, len(tasks))
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestClient_TaskLifecycle(t *testing.T) {
+	client, err := NewClient(Config{Empty: true})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	task := models.NewTask("Write tests", models.PlatformMock)
+	task.Labels = []string{"qa"}
+	task.Assignee = &models.User{ID: "demo"}
+
+	created, err := client.CreateTask(ctx, task)
+	require.NoError(t, err)
+	assert.Equal(t, "DEMO-1", created.ID)
+	assert.Equal(t, "Demo User", created.Assignee.Name)
+
+	// Changing a returned task does not change the stored one.
+	created.Labels[0] = "changed"
+	fetched, err := client.GetTask(ctx, "demo-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"qa"}, fetched.Labels)
+
+	fetched.Status = models.StatusDone
+	updated, err := client.UpdateTask(ctx, fetched)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusDone, updated.Status)
+	assert.Equal(t, created.CreatedAt, updated.CreatedAt)
+
+	require.NoError(t, client.DeleteTask(ctx, "DEMO-1"))
+	_, err = client.GetTask(ctx, "DEMO-1")
+	assert.True(t, platforms.IsNotFoundError(err))
+	assert.True(t, platforms.IsNotFoundError(client.DeleteTask(ctx, "DEMO-1")))
+
+	_, err = client.CreateTask(ctx, models.NewTask("", models.PlatformMock))
+	var invalid *models.ValidationError
+	assert.ErrorAs(t, err, &invalid)
+}
+
+func TestClient_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mock.json")
+	ctx := context.Background()
+
+	client, err := NewClient(Config{File: path})
+	require.NoError(t, err)
+	created, err := client.CreateTask(ctx, models.NewTask("Kept between runs", models.PlatformMock))
+	require.NoError(t, err)
+	assert.Equal(t, "DEMO-7", created.ID)
+
+	reopened, err := NewClient(Config{File: path})
+	require.NoError(t, err)
+	fetched, err := reopened.GetTask(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Kept between runs", fetched.Title)
+
+	tasks, err := reopened.ListTasks(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 7)
+}
+
+func TestFactory(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, "mock", factory.GetType())
+	require.NoError(t, factory.ValidateConfig(map[string]any{}))
+	assert.Error(t, factory.ValidateConfig(map[string]any{FileKey: 42}))
+	assert.Error(t, factory.ValidateConfig(map[string]any{EmptyKey: "yes"}))
+}
+
+func BenchmarkClient_ListTasks(b *testing.B) {
+	client, err := NewClient(Config{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	ctx := context.Background()
+	for i := 0; i < 10000; i++ {
+		if _, err := client.CreateTask(ctx, &models.Task{Title: fmt.Sprintf("Task %d", i), ProjectID: "DEMO"}); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tasks, err := client.ListTasks(ctx, nil)
+		if err != nil || len(tasks) != 10006 {
+			b.Fatalf("listed %d tasks: %v", len(tasks), err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package mock
+
+import (
+	"fmt"
+
+	"opentask/pkg/platforms"
+)
+
+// FileKey is the setting naming the JSON file the mock platform keeps its
+// tasks in. Without it, tasks only live as long as the client.
+const FileKey = "file"
+
+// EmptyKey is the setting that starts the mock platform without the
+// sample project, users and tasks.
+const EmptyKey = "empty"
+
+type Factory struct{}
+
+func NewFactory() *Factory {
+	return &Factory{}
+}
+
+func (f *Factory) Create(config map[string]any) (platforms.PlatformClient, error) {
+	cfg, err := parseConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(cfg)
+}
+
+func (f *Factory) GetType() string {
+	return "mock"
+}
+
+func (f *Factory) GetName() string {
+	return "Mock"
+}
+
+func (f *Factory) GetDescription() string {
+	return "Sample tasks kept on this machine, for demos without credentials"
+}
+
+func (f *Factory) ValidateConfig(config map[string]any) error {
+	_, err := parseConfig(config)
+	return err
+}
+
+func parseConfig(config map[string]any) (Config, error) {
+	cfg := Config{}
+
+	switch file := config[FileKey].(type) {
+	case nil:
+	case string:
+		cfg.File = file
+	default:
+		return cfg, fmt.Errorf("%s must be a path", FileKey)
+	}
+
+	switch empty := config[EmptyKey].(type) {
+	case nil:
+	case bool:
+		cfg.Empty = empty
+	default:
+		return cfg, fmt.Errorf("%s must be true or false", EmptyKey)
+	}
+
+	return cfg, nil
+}
+
+// Register factory with the global registry
+func init() {
+	platforms.DefaultRegistry.Register(NewFactory())
+}
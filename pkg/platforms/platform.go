@@ -3,6 +3,7 @@ package platforms
 import (
 	"context"
 	"opentask/pkg/models"
+	"sort"
 )
 
 type PlatformClient interface {
@@ -26,6 +27,12 @@ type PlatformClient interface {
 	HealthCheck(ctx context.Context) error
 }
 
+// WorkflowProvider is implemented by platforms that can list the workflow
+// states configured for a project.
+type WorkflowProvider interface {
+	ListWorkflowStates(ctx context.Context, projectID string) ([]*models.WorkflowState, error)
+}
+
 type PlatformInfo struct {
 	Name        string `json:"name"`
 	Type        string `json:"type"`
@@ -56,6 +63,12 @@ type PlatformFactory interface {
 	ValidateConfig(config map[string]any) error
 }
 
+// Describer is implemented by factories that describe their platform for
+// 'opentask platform list'.
+type Describer interface {
+	GetDescription() string
+}
+
 type Registry struct {
 	factories map[string]PlatformFactory
 }
@@ -83,14 +96,22 @@ func (r *Registry) Create(platformType string, config map[string]any) (PlatformC
 	return factory.Create(config)
 }
 
+// GetSupportedPlatforms returns the registered platform types, sorted.
 func (r *Registry) GetSupportedPlatforms() []string {
 	platforms := make([]string, 0, len(r.factories))
 	for platformType := range r.factories {
 		platforms = append(platforms, platformType)
 	}
+	sort.Strings(platforms)
 	return platforms
 }
 
+// GetFactory returns the factory registered for a platform type.
+func (r *Registry) GetFactory(platformType string) (PlatformFactory, bool) {
+	factory, exists := r.factories[platformType]
+	return factory, exists
+}
+
 func (r *Registry) IsSupported(platformType string) bool {
 	_, exists := r.factories[platformType]
 	return exists
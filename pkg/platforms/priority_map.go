@@ -0,0 +1,136 @@
+package platforms
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"opentask/pkg/models"
+)
+
+// PriorityMapKey is the platform setting holding a PriorityMap.
+const PriorityMapKey = "priority_map"
+
+// PriorityMap overrides how platform priority names map to unified
+// priorities. Keys are priority names and are matched case-insensitively.
+type PriorityMap map[string]models.Priority
+
+// PriorityNames overrides the platform priority a unified priority is sent
+// as when creating or updating tasks.
+type PriorityNames map[models.Priority]string
+
+// PriorityLister is implemented by platforms with a configurable list of
+// priorities, so a priority map can be checked against it.
+type PriorityLister interface {
+	ListPriorities(ctx context.Context) ([]string, error)
+}
+
+// ParsePriorityMap reads the priority_map setting from a platform config.
+//
+// Entries map a priority name to a unified priority ("P1": high). An entry
+// keyed by a unified priority whose value is not one ("urgent": "P0")
+// names the priority used for it; that name is also mapped back unless
+// another entry maps it.
+func ParsePriorityMap(config map[string]any) (PriorityMap, error) {
+	priorityMap, _, err := parsePrioritySettings(config)
+	return priorityMap, err
+}
+
+// ParsePriorityNames reads the priority-to-name entries of the
+// priority_map setting. See ParsePriorityMap.
+func ParsePriorityNames(config map[string]any) (PriorityNames, error) {
+	_, names, err := parsePrioritySettings(config)
+	return names, err
+}
+
+func parsePrioritySettings(config map[string]any) (PriorityMap, PriorityNames, error) {
+	raw, ok := config[PriorityMapKey]
+	if !ok || raw == nil {
+		return nil, nil, nil
+	}
+
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s must be a map of priority name to priority", PriorityMapKey)
+	}
+
+	priorityMap := make(PriorityMap, len(entries))
+	var names PriorityNames
+	for key, value := range entries {
+		name, ok := value.(string)
+		if priority := models.Priority(strings.ToLower(name)); ok && priority.IsValid() {
+			priorityMap[strings.ToLower(key)] = priority
+			continue
+		}
+
+		if priority := models.Priority(strings.ToLower(key)); ok && name != "" && priority.IsValid() {
+			if names == nil {
+				names = make(PriorityNames)
+			}
+			names[priority] = name
+			continue
+		}
+
+		return nil, nil, fmt.Errorf("%s: invalid priority %v for %q (use low, medium, high, urgent)", PriorityMapKey, value, key)
+	}
+
+	// Explicit name entries take precedence over the reverse of a name.
+	for priority, name := range names {
+		if _, ok := priorityMap[strings.ToLower(name)]; !ok {
+			priorityMap[strings.ToLower(name)] = priority
+		}
+	}
+
+	return priorityMap, names, nil
+}
+
+// Lookup returns the configured priority for a platform priority name.
+func (m PriorityMap) Lookup(name string) (models.Priority, bool) {
+	priority, ok := m[strings.ToLower(name)]
+	return priority, ok
+}
+
+// Lookup returns the configured platform priority name for a priority.
+func (n PriorityNames) Lookup(priority models.Priority) (string, bool) {
+	name, ok := n[priority]
+	return name, ok
+}
+
+// ValidatePriorityMap checks that every priority name in the priority_map
+// setting exists on the platform, given the names it reports.
+func ValidatePriorityMap(config map[string]any, available []string) error {
+	if _, _, err := parsePrioritySettings(config); err != nil {
+		return err
+	}
+
+	entries, _ := config[PriorityMapKey].(map[string]any)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	known := make(map[string]bool, len(available))
+	for _, name := range available {
+		known[strings.ToLower(name)] = true
+	}
+
+	var unknown []string
+	for key, value := range entries {
+		// Entries are either "name: priority" or "priority: name".
+		name := key
+		if priority := models.Priority(strings.ToLower(value.(string))); !priority.IsValid() {
+			name = value.(string)
+		}
+		if !known[strings.ToLower(name)] {
+			unknown = append(unknown, fmt.Sprintf("%q", name))
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+
+	return fmt.Errorf("%s: unknown priorities %s; the platform has %s",
+		PriorityMapKey, strings.Join(unknown, ", "), strings.Join(available, ", "))
+}
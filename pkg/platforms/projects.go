@@ -0,0 +1,78 @@
+package platforms
+
+import (
+	"sync"
+	"time"
+
+	"opentask/pkg/models"
+)
+
+// ProjectCacheTTL is how long a platform's projects are used before they
+// are listed again.
+const ProjectCacheTTL = 24 * time.Hour
+
+// ProjectListing is the projects listed on a platform and when.
+type ProjectListing struct {
+	Projects  []*models.Project `json:"projects"`
+	FetchedAt time.Time         `json:"fetched_at"`
+}
+
+// ProjectCache remembers the projects of each platform by name, so
+// 'project list' and --project completion do not list them on every
+// command.
+type ProjectCache interface {
+	Get(platform string) (ProjectListing, bool)
+	Put(platform string, listing ProjectListing)
+}
+
+// MemoryProjectCache keeps project listings for the life of the process.
+type MemoryProjectCache struct {
+	mu       sync.Mutex
+	listings map[string]ProjectListing
+}
+
+func NewMemoryProjectCache() *MemoryProjectCache {
+	return &MemoryProjectCache{listings: make(map[string]ProjectListing)}
+}
+
+func (c *MemoryProjectCache) Get(platform string) (ProjectListing, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	listing, ok := c.listings[platform]
+	return listing, ok
+}
+
+func (c *MemoryProjectCache) Put(platform string, listing ProjectListing) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listings[platform] = listing
+}
+
+var (
+	projectCacheMu sync.RWMutex
+	projectCache   ProjectCache = NewMemoryProjectCache()
+)
+
+// SetProjectCache replaces the cache project listings are kept in. The CLI
+// uses one kept on disk.
+func SetProjectCache(cache ProjectCache) {
+	projectCacheMu.Lock()
+	defer projectCacheMu.Unlock()
+	projectCache = cache
+}
+
+// CachedProjects returns the projects kept for a platform, and whether
+// they are still fresh (younger than ProjectCacheTTL).
+func CachedProjects(platform string) (ProjectListing, bool, bool) {
+	projectCacheMu.RLock()
+	defer projectCacheMu.RUnlock()
+	listing, ok := projectCache.Get(platform)
+	return listing, ok, ok && time.Since(listing.FetchedAt) <= ProjectCacheTTL
+}
+
+// CacheProjects records the projects listed on a platform.
+func CacheProjects(platform string, listing ProjectListing) {
+	projectCacheMu.RLock()
+	defer projectCacheMu.RUnlock()
+	projectCache.Put(platform, listing)
+}
@@ -0,0 +1,109 @@
+package platforms
+
+import (
+	"context"
+	"fmt"
+
+	"opentask/pkg/models"
+)
+
+// FallbackSearchLimit is how many recent tasks are fetched and filtered
+// locally when a platform cannot search task text itself.
+const FallbackSearchLimit = 500
+
+// TextSearcher is implemented by clients whose ListTasks applies
+// TaskFilter.Query on the server.
+type TextSearcher interface {
+	SupportsTextSearch() bool
+}
+
+// SearchMode says where TaskFilter.Query was applied.
+type SearchMode string
+
+const (
+	// SearchNone means the filter had no query.
+	SearchNone SearchMode = ""
+	// SearchServer means the platform searched task text itself.
+	SearchServer SearchMode = "server"
+	// SearchFallback means recent tasks were fetched and filtered locally.
+	SearchFallback SearchMode = "fallback"
+)
+
+// SearchResult is the outcome of SearchTasks.
+type SearchResult struct {
+	Tasks []*models.Task
+	Mode  SearchMode
+
+	// Reason explains a fallback, such as the error the server search
+	// returned.
+	Reason string
+	// Scanned is how many tasks a fallback filtered. When it reaches
+	// FallbackSearchLimit older matches may have been missed.
+	Scanned int
+}
+
+// Explain describes how the query was run, for --explain output.
+func (r *SearchResult) Explain() string {
+	switch r.Mode {
+	case SearchServer:
+		return "text search ran on the platform"
+	case SearchFallback:
+		explanation := fmt.Sprintf("FALLBACK: %s; filtered the %d most recent tasks locally", r.Reason, r.Scanned)
+		if r.Scanned >= FallbackSearchLimit {
+			explanation += " (older tasks were not searched)"
+		}
+		return explanation
+	default:
+		return "no text query"
+	}
+}
+
+// SearchTasks lists the tasks matching filter. Platforms without server-side
+// text search, and searches the platform rejects, fall back to fetching the
+// most recent tasks without the query and matching it locally, so --query
+// behaves the same everywhere. Authentication, permission and rate limit
+// errors are returned rather than retried.
+func SearchTasks(ctx context.Context, client PlatformClient, filter *models.TaskFilter) (*SearchResult, error) {
+	if filter == nil || filter.Query == "" {
+		tasks, err := client.ListTasks(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		return &SearchResult{Tasks: tasks, Mode: SearchNone}, nil
+	}
+
+	reason := "the platform has no text search"
+	if searcher, ok := client.(TextSearcher); ok && searcher.SupportsTextSearch() {
+		tasks, err := client.ListTasks(ctx, filter)
+		if err == nil {
+			return &SearchResult{Tasks: tasks, Mode: SearchServer}, nil
+		}
+		if IsAuthenticationError(err) || IsPermissionError(err) || IsRateLimitError(err) || ctx.Err() != nil {
+			return nil, err
+		}
+		reason = fmt.Sprintf("the platform's text search failed (%v)", err)
+	}
+
+	recent := *filter
+	recent.Query = ""
+	recent.Limit = FallbackSearchLimit
+	recent.Offset = 0
+
+	tasks, err := client.ListTasks(ctx, &recent)
+	if err != nil {
+		return nil, err
+	}
+
+	query := models.TaskFilter{Query: filter.Query}
+	var matched []*models.Task
+	for _, task := range tasks {
+		if query.Matches(task) {
+			matched = append(matched, task)
+		}
+	}
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+
+	return &SearchResult{Tasks: matched, Mode: SearchFallback, Reason: reason, Scanned: len(tasks)}, nil
+}
@@ -0,0 +1,90 @@
+package platforms
+
+import (
+	"fmt"
+	"strings"
+
+	"opentask/pkg/models"
+)
+
+// StatusMapKey is the platform setting holding a StatusMap.
+const StatusMapKey = "status_map"
+
+// StatusMap overrides how platform workflow states map to unified statuses.
+// Keys are state names and are matched case-insensitively.
+type StatusMap map[string]models.TaskStatus
+
+// StatusNames overrides the workflow state a unified status is sent as when
+// filtering or changing status, for workflows with custom state names.
+type StatusNames map[models.TaskStatus]string
+
+// ParseStatusMap reads the status_map setting from a platform config.
+//
+// Entries map a state name to a unified status ("In Review": in_progress).
+// An entry keyed by a unified status whose value is not one ("done":
+// "Resolved") names the state used for that status; its state is also
+// mapped back to the status unless another entry maps it.
+func ParseStatusMap(config map[string]any) (StatusMap, error) {
+	statusMap, _, err := parseStatusSettings(config)
+	return statusMap, err
+}
+
+// ParseStatusNames reads the status-to-state entries of the status_map
+// setting. See ParseStatusMap.
+func ParseStatusNames(config map[string]any) (StatusNames, error) {
+	_, names, err := parseStatusSettings(config)
+	return names, err
+}
+
+func parseStatusSettings(config map[string]any) (StatusMap, StatusNames, error) {
+	raw, ok := config[StatusMapKey]
+	if !ok || raw == nil {
+		return nil, nil, nil
+	}
+
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s must be a map of state name to status", StatusMapKey)
+	}
+
+	statusMap := make(StatusMap, len(entries))
+	var names StatusNames
+	for key, value := range entries {
+		name, ok := value.(string)
+		if status := models.TaskStatus(strings.ToLower(name)); ok && status.IsValid() {
+			statusMap[strings.ToLower(key)] = status
+			continue
+		}
+
+		if status := models.TaskStatus(strings.ToLower(key)); ok && name != "" && status.IsValid() {
+			if names == nil {
+				names = make(StatusNames)
+			}
+			names[status] = name
+			continue
+		}
+
+		return nil, nil, fmt.Errorf("%s: invalid status %v for %q (use open, in_progress, done, cancelled)", StatusMapKey, value, key)
+	}
+
+	// Explicit state entries take precedence over the reverse of a name.
+	for status, name := range names {
+		if _, ok := statusMap[strings.ToLower(name)]; !ok {
+			statusMap[strings.ToLower(name)] = status
+		}
+	}
+
+	return statusMap, names, nil
+}
+
+// Lookup returns the configured status for a workflow state name.
+func (m StatusMap) Lookup(state string) (models.TaskStatus, bool) {
+	status, ok := m[strings.ToLower(state)]
+	return status, ok
+}
+
+// Lookup returns the configured state name for a unified status.
+func (n StatusNames) Lookup(status models.TaskStatus) (string, bool) {
+	name, ok := n[status]
+	return name, ok
+}
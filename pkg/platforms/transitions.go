@@ -0,0 +1,98 @@
+package platforms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"opentask/pkg/models"
+)
+
+// Transition is a status change a platform workflow allows from a task's
+// current status.
+type Transition struct {
+	ID   string
+	Name string
+	// To is the workflow state the transition leads to.
+	To string
+	// Status is the unified status of To.
+	Status models.TaskStatus
+}
+
+// TransitionProvider is implemented by platforms whose workflows restrict
+// which status changes are allowed. Platforms that do not implement it
+// accept any status change.
+type TransitionProvider interface {
+	GetAvailableTransitions(ctx context.Context, taskID string) ([]Transition, error)
+}
+
+// InvalidTransitionError is returned when a task's workflow does not allow
+// moving it to the requested status.
+type InvalidTransitionError struct {
+	TaskID  string
+	From    models.TaskStatus
+	To      models.TaskStatus
+	Allowed []Transition
+}
+
+func (e *InvalidTransitionError) Error() string {
+	msg := fmt.Sprintf("%s cannot move from %s to %s", e.TaskID, e.From, e.To)
+	if len(e.Allowed) == 0 {
+		return msg + "; its workflow allows no transitions from here"
+	}
+
+	targets := make([]string, 0, len(e.Allowed))
+	for _, t := range e.Allowed {
+		targets = append(targets, fmt.Sprintf("%s (%q)", t.Status, t.Name))
+	}
+	return msg + "; allowed: " + strings.Join(targets, ", ")
+}
+
+// FindTransition returns the first transition leading to status.
+func FindTransition(transitions []Transition, status models.TaskStatus) (Transition, bool) {
+	for _, t := range transitions {
+		if t.Status == status {
+			return t, true
+		}
+	}
+	return Transition{}, false
+}
+
+// TransitionStatuses returns the distinct statuses reachable through
+// transitions, in the order the platform listed them.
+func TransitionStatuses(transitions []Transition) []models.TaskStatus {
+	var statuses []models.TaskStatus
+	seen := make(map[models.TaskStatus]bool)
+	for _, t := range transitions {
+		if !seen[t.Status] {
+			seen[t.Status] = true
+			statuses = append(statuses, t.Status)
+		}
+	}
+	return statuses
+}
+
+// ValidateTransition checks that task can move to status, asking the
+// client for the allowed transitions when it is a TransitionProvider.
+func ValidateTransition(ctx context.Context, client PlatformClient, task *models.Task, status models.TaskStatus) error {
+	provider, ok := client.(TransitionProvider)
+	if !ok || task.Status == status {
+		return nil
+	}
+
+	transitions, err := provider.GetAvailableTransitions(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := FindTransition(transitions, status); ok {
+		return nil
+	}
+
+	return NewPlatformError(
+		ErrInvalidInput,
+		string(task.Platform),
+		task.ID,
+		&InvalidTransitionError{TaskID: task.ID, From: task.Status, To: status, Allowed: transitions},
+	)
+}
@@ -0,0 +1,94 @@
+package platforms
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// VersionCacheTTL is how long a detected version is trusted. Detecting again
+// after that picks up server upgrades and migrations.
+const VersionCacheTTL = 7 * 24 * time.Hour
+
+// InstanceVersion is what version detection found out about a platform
+// instance, such as a Jira site or a Linear API endpoint.
+type InstanceVersion struct {
+	// Deployment is the kind of install, such as "cloud" or "server".
+	Deployment string `json:"deployment,omitempty"`
+	// Version is the version the server reports, if any.
+	Version string `json:"version,omitempty"`
+	// API is the API version the client uses, such as 2 or 3 for Jira.
+	API int `json:"api,omitempty"`
+	// Features lists the optional parts of the API the instance has, such
+	// as the input fields found by GraphQL schema introspection.
+	Features []string `json:"features,omitempty"`
+
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// Has reports whether feature was detected.
+func (v InstanceVersion) Has(feature string) bool {
+	return slices.Contains(v.Features, feature)
+}
+
+// VersionCache remembers detected versions by instance, so detection runs
+// once per instance rather than on every command.
+type VersionCache interface {
+	Get(instance string) (InstanceVersion, bool)
+	Put(instance string, version InstanceVersion)
+}
+
+// MemoryVersionCache keeps versions for the life of the process.
+type MemoryVersionCache struct {
+	mu       sync.Mutex
+	versions map[string]InstanceVersion
+}
+
+func NewMemoryVersionCache() *MemoryVersionCache {
+	return &MemoryVersionCache{versions: make(map[string]InstanceVersion)}
+}
+
+func (c *MemoryVersionCache) Get(instance string) (InstanceVersion, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	version, ok := c.versions[instance]
+	return version, ok
+}
+
+func (c *MemoryVersionCache) Put(instance string, version InstanceVersion) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.versions[instance] = version
+}
+
+var (
+	versionCacheMu sync.RWMutex
+	versionCache   VersionCache = NewMemoryVersionCache()
+)
+
+// SetVersionCache replaces the cache clients keep detected versions in. The
+// CLI uses one stored on disk.
+func SetVersionCache(cache VersionCache) {
+	versionCacheMu.Lock()
+	defer versionCacheMu.Unlock()
+	versionCache = cache
+}
+
+// CachedVersion returns the version detected for instance, unless it is
+// older than VersionCacheTTL.
+func CachedVersion(instance string) (InstanceVersion, bool) {
+	versionCacheMu.RLock()
+	defer versionCacheMu.RUnlock()
+	version, ok := versionCache.Get(instance)
+	if !ok || time.Since(version.DetectedAt) > VersionCacheTTL {
+		return InstanceVersion{}, false
+	}
+	return version, true
+}
+
+// CacheVersion records the version detected for instance.
+func CacheVersion(instance string, version InstanceVersion) {
+	versionCacheMu.RLock()
+	defer versionCacheMu.RUnlock()
+	versionCache.Put(instance, version)
+}
@@ -0,0 +1,156 @@
+package platforms
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// VocabularyCacheTTL is how long a project's labels and components are
+// used before they are listed again.
+const VocabularyCacheTTL = 24 * time.Hour
+
+// Vocabulary is what a project's tasks can be tagged with: the labels in
+// use and, on Jira, the project's components.
+type Vocabulary struct {
+	Labels     []string  `json:"labels,omitempty"`
+	Components []string  `json:"components,omitempty"`
+	FetchedAt  time.Time `json:"fetched_at"`
+}
+
+// VocabularyLister is implemented by platforms that can list the labels
+// and components of a project.
+type VocabularyLister interface {
+	ListVocabulary(ctx context.Context, projectID string) (*Vocabulary, error)
+}
+
+// VocabularyCache remembers vocabularies by platform and project, so shell
+// completion and validation do not list them on every command.
+type VocabularyCache interface {
+	Get(key string) (Vocabulary, bool)
+	Put(key string, vocabulary Vocabulary)
+}
+
+// MemoryVocabularyCache keeps vocabularies for the life of the process.
+type MemoryVocabularyCache struct {
+	mu           sync.Mutex
+	vocabularies map[string]Vocabulary
+}
+
+func NewMemoryVocabularyCache() *MemoryVocabularyCache {
+	return &MemoryVocabularyCache{vocabularies: make(map[string]Vocabulary)}
+}
+
+func (c *MemoryVocabularyCache) Get(key string) (Vocabulary, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	vocabulary, ok := c.vocabularies[key]
+	return vocabulary, ok
+}
+
+func (c *MemoryVocabularyCache) Put(key string, vocabulary Vocabulary) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.vocabularies[key] = vocabulary
+}
+
+var (
+	vocabularyCacheMu sync.RWMutex
+	vocabularyCache   VocabularyCache = NewMemoryVocabularyCache()
+)
+
+// SetVocabularyCache replaces the cache vocabularies are kept in. The CLI
+// uses one stored on disk.
+func SetVocabularyCache(cache VocabularyCache) {
+	vocabularyCacheMu.Lock()
+	defer vocabularyCacheMu.Unlock()
+	vocabularyCache = cache
+}
+
+// CachedVocabulary returns the vocabulary kept for key, and whether it is
+// still fresh (younger than VocabularyCacheTTL).
+func CachedVocabulary(key string) (Vocabulary, bool, bool) {
+	vocabularyCacheMu.RLock()
+	defer vocabularyCacheMu.RUnlock()
+	vocabulary, ok := vocabularyCache.Get(key)
+	return vocabulary, ok, ok && time.Since(vocabulary.FetchedAt) <= VocabularyCacheTTL
+}
+
+// CacheVocabulary records the vocabulary listed for key.
+func CacheVocabulary(key string, vocabulary Vocabulary) {
+	vocabularyCacheMu.RLock()
+	defer vocabularyCacheMu.RUnlock()
+	vocabularyCache.Put(key, vocabulary)
+}
+
+// HasLabel reports whether label is known, ignoring case.
+func (v *Vocabulary) HasLabel(label string) bool {
+	for _, known := range v.Labels {
+		if strings.EqualFold(known, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClosestLabel returns the known label most like label, if one is close
+// enough to be a typo of it.
+func (v *Vocabulary) ClosestLabel(label string) (string, bool) {
+	best, bestDistance := "", 0
+	for _, known := range v.Labels {
+		distance := editDistance(strings.ToLower(known), strings.ToLower(label))
+		if best == "" || distance < bestDistance {
+			best, bestDistance = known, distance
+		}
+	}
+
+	// Allow one edit for short labels and two for longer ones.
+	allowed := 1
+	if len([]rune(label)) > 5 {
+		allowed = 2
+	}
+	if best == "" || bestDistance > allowed {
+		return "", false
+	}
+	return best, true
+}
+
+// SortedUnique returns names sorted, without duplicates or empty names.
+func SortedUnique(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	unique := make([]string, 0, len(names))
+	for _, name := range names {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		unique = append(unique, name)
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,102 @@
+package platforms
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+)
+
+const (
+	// WebhookKey is the platform setting holding the webhook registered by
+	// 'opentask webhook register'.
+	WebhookKey = "webhook"
+	// WebhookSecretKey is the platform setting holding the secret the
+	// webhook's deliveries are signed with, so 'opentask serve' can check
+	// them.
+	WebhookSecretKey = "webhook_secret"
+)
+
+// Webhook is a webhook subscription created on a platform.
+type Webhook struct {
+	ID     string
+	URL    string
+	Events []string
+}
+
+// WebhookOptions describes the subscription to create. Project limits the
+// events to one project (Jira project key, Linear team ID); when empty the
+// platform sends events for every project the token can see.
+type WebhookOptions struct {
+	URL     string
+	Secret  string
+	Project string
+}
+
+// WebhookRegistrar is implemented by platforms that can create and remove
+// webhook subscriptions through their API.
+type WebhookRegistrar interface {
+	RegisterWebhook(ctx context.Context, opts WebhookOptions) (*Webhook, error)
+	UnregisterWebhook(ctx context.Context, id string) error
+}
+
+// WebhookSettings converts a webhook into the form stored under WebhookKey.
+func WebhookSettings(webhook *Webhook) map[string]any {
+	return map[string]any{
+		"id":  webhook.ID,
+		"url": webhook.URL,
+	}
+}
+
+// RegisteredWebhook returns the webhook recorded in the platform settings.
+func RegisteredWebhook(config map[string]any) (*Webhook, bool) {
+	recorded, ok := config[WebhookKey].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	id, _ := recorded["id"].(string)
+	if id == "" {
+		return nil, false
+	}
+	url, _ := recorded["url"].(string)
+
+	return &Webhook{ID: id, URL: url}, true
+}
+
+// WebhookAction is what happened to the task a webhook delivery reports.
+type WebhookAction string
+
+const (
+	WebhookCreated WebhookAction = "created"
+	WebhookUpdated WebhookAction = "updated"
+	WebhookDeleted WebhookAction = "deleted"
+)
+
+// WebhookEvent is a change to a task reported by a webhook delivery.
+type WebhookEvent struct {
+	Action WebhookAction
+	TaskID string
+}
+
+// WebhookReceiver is implemented by platforms whose webhook deliveries
+// 'opentask serve' can read.
+type WebhookReceiver interface {
+	// ParseWebhook reads a delivery, first checking its signature when
+	// secret is set. Deliveries about something other than a task return
+	// a nil event.
+	ParseWebhook(header http.Header, body []byte, secret string) (*WebhookEvent, error)
+}
+
+// ValidSignature reports whether signature is the hex HMAC-SHA256 of body
+// under secret, as Jira and Linear sign webhook deliveries.
+func ValidSignature(body []byte, secret, signature string) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
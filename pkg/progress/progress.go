@@ -0,0 +1,169 @@
+// Package progress reports how far long-running commands such as apply and
+// scan have got: a progress bar with an ETA on a terminal, or a status line
+// every few seconds when output is redirected. Items that fail are
+// collected and listed when the operation finishes.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"opentask/pkg/styles"
+
+	bubbleprogress "github.com/charmbracelet/bubbles/progress"
+	"github.com/muesli/termenv"
+)
+
+// DefaultInterval is how often a status line is printed when output is not
+// a terminal.
+const DefaultInterval = 5 * time.Second
+
+// clearLine returns the cursor to the start of the line and erases it.
+const clearLine = "\r\033[K"
+
+// Failure is an item that could not be processed.
+type Failure struct {
+	Item string
+	Err  error
+}
+
+// Tracker follows an operation over a known number of items.
+type Tracker struct {
+	out         io.Writer
+	label       string
+	total       int
+	interactive bool
+
+	// Interval is how often a status line is printed when not interactive.
+	Interval time.Duration
+
+	done      int
+	failures  []Failure
+	start     time.Time
+	lastPrint time.Time
+	barShown  bool
+	bar       bubbleprogress.Model
+
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+// New starts tracking an operation over total items. With interactive set
+// a progress bar is redrawn on out after every item.
+func New(out io.Writer, label string, total int, interactive bool) *Tracker {
+	opts := []bubbleprogress.Option{
+		bubbleprogress.WithSolidFill(string(styles.Current().Accent)),
+		bubbleprogress.WithWidth(30),
+		bubbleprogress.WithoutPercentage(),
+	}
+	if styles.ColorDisabled() {
+		opts = append(opts, bubbleprogress.WithColorProfile(termenv.Ascii))
+	}
+
+	t := &Tracker{
+		out:         out,
+		label:       label,
+		total:       total,
+		interactive: interactive,
+		Interval:    DefaultInterval,
+		bar:         bubbleprogress.New(opts...),
+		now:         time.Now,
+	}
+	t.start = t.now()
+	t.lastPrint = t.start
+	t.draw()
+	return t
+}
+
+// Printf prints a line for an item, keeping the progress bar below it.
+func (t *Tracker) Printf(format string, args ...any) {
+	if t.barShown {
+		fmt.Fprint(t.out, clearLine)
+	}
+	fmt.Fprintf(t.out, format, args...)
+	t.draw()
+}
+
+// Done records that item has been processed, and failed if err is not nil.
+func (t *Tracker) Done(item string, err error) {
+	t.done++
+	if err != nil {
+		t.failures = append(t.failures, Failure{Item: item, Err: err})
+	}
+
+	if t.interactive {
+		t.draw()
+		return
+	}
+
+	if now := t.now(); t.done < t.total && now.Sub(t.lastPrint) >= t.Interval {
+		t.lastPrint = now
+		fmt.Fprintln(t.out, t.Status())
+	}
+}
+
+// Status describes the progress so far, such as "Applying: 30/100 (30%),
+// ETA 1m20s".
+func (t *Tracker) Status() string {
+	percent := 0
+	if t.total > 0 {
+		percent = t.done * 100 / t.total
+	}
+
+	status := fmt.Sprintf("%s: %d/%d (%d%%)", t.label, t.done, t.total, percent)
+	if eta, ok := t.ETA(); ok {
+		status += ", ETA " + eta.String()
+	}
+	if len(t.failures) > 0 {
+		status += fmt.Sprintf(", %d failed", len(t.failures))
+	}
+	return status
+}
+
+// ETA estimates the time left from the average time per item so far. ok is
+// false until the first item is done.
+func (t *Tracker) ETA() (time.Duration, bool) {
+	if t.done == 0 || t.done >= t.total {
+		return 0, false
+	}
+	perItem := t.now().Sub(t.start) / time.Duration(t.done)
+	return (perItem * time.Duration(t.total-t.done)).Round(time.Second), true
+}
+
+// Failures returns the items that failed, in the order they finished.
+func (t *Tracker) Failures() []Failure {
+	return t.failures
+}
+
+// Finish removes the progress bar and lists every failed item.
+func (t *Tracker) Finish() {
+	if t.barShown {
+		fmt.Fprint(t.out, clearLine)
+		t.barShown = false
+	}
+
+	if len(t.failures) == 0 {
+		return
+	}
+
+	fmt.Fprintf(t.out, "\n%d of %d failed:\n", len(t.failures), t.total)
+	for _, failure := range t.failures {
+		fmt.Fprintf(t.out, "✗ %s: %v\n", failure.Item, failure.Err)
+	}
+}
+
+func (t *Tracker) draw() {
+	if !t.interactive || t.total == 0 {
+		return
+	}
+
+	percent := float64(t.done) / float64(t.total)
+	line := fmt.Sprintf("%s %s %d/%d", t.label, t.bar.ViewAs(percent), t.done, t.total)
+	if eta, ok := t.ETA(); ok {
+		line += styles.Help().Render(" • ETA " + eta.String())
+	}
+
+	fmt.Fprint(t.out, clearLine+line)
+	t.barShown = true
+}
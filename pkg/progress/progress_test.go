@@ -0,0 +1,85 @@
+package progress
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker_Plain(t *testing.T) {
+	var out bytes.Buffer
+	tracker := New(&out, "Applying", 4, false)
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+	tracker.start = now
+	tracker.lastPrint = now
+
+	// Only the second item finishes after the interval has passed.
+	tracker.Printf("+ a: created API-1\n")
+	now = now.Add(time.Second)
+	tracker.Done("a", nil)
+	now = now.Add(5 * time.Second)
+	tracker.Done("b", errors.New("permission denied"))
+	now = now.Add(time.Second)
+	tracker.Done("c", nil)
+	now = now.Add(time.Second)
+	tracker.Done("d", nil)
+	tracker.Finish()
+
+	assert.Equal(t, "+ a: created API-1\n"+
+		"Applying: 2/4 (50%), ETA 6s, 1 failed\n"+
+		"\n1 of 4 failed:\n"+
+		"✗ b: permission denied\n", out.String())
+
+	require.Len(t, tracker.Failures(), 1)
+	assert.Equal(t, "b", tracker.Failures()[0].Item)
+}
+
+func TestTracker_Interactive(t *testing.T) {
+	var out bytes.Buffer
+	tracker := New(&out, "Creating", 2, true)
+
+	tracker.Printf("+ one\n")
+	tracker.Done("one", nil)
+	tracker.Done("two", nil)
+	tracker.Finish()
+
+	text := out.String()
+	assert.Contains(t, text, "+ one\n")
+	assert.Contains(t, text, "Creating")
+	assert.Contains(t, text, "2/2")
+	assert.True(t, strings.HasSuffix(text, clearLine), "the bar is cleared when done: %q", text)
+	assert.NotContains(t, text, "failed")
+}
+
+func TestTracker_ETA(t *testing.T) {
+	tests := []struct {
+		name  string
+		done  int
+		total int
+		want  time.Duration
+		ok    bool
+	}{
+		{name: "nothing done", done: 0, total: 10},
+		{name: "halfway", done: 5, total: 10, want: 10 * time.Second, ok: true},
+		{name: "finished", done: 10, total: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := New(&bytes.Buffer{}, "x", tt.total, false)
+			tracker.start = time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+			tracker.now = func() time.Time { return tracker.start.Add(10 * time.Second) }
+			tracker.done = tt.done
+
+			eta, ok := tracker.ETA()
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, eta)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+var reader = bufio.NewReader(os.Stdin)
+
+// IsInteractive reports whether stdin is attached to a terminal.
+func IsInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// Line prints label and reads a full line from stdin, so values containing
+// spaces are preserved.
+func Line(label string) (string, error) {
+	fmt.Print(label)
+
+	line, err := reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// Secret reads a line like Line, without echoing it when stdin is a
+// terminal, for tokens and passwords.
+func Secret(label string) (string, error) {
+	if !IsInteractive() {
+		return Line(label)
+	}
+
+	fmt.Print(label)
+	secret, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
+
+// Confirm asks a yes/no question. An empty answer returns def.
+func Confirm(label string, def bool) bool {
+	suffix := " [y/N]: "
+	if def {
+		suffix = " [Y/n]: "
+	}
+
+	answer, err := Line(label + suffix)
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "":
+		return def
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
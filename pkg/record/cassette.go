@@ -0,0 +1,83 @@
+package record
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// RecordEnv names the environment variable that makes cassettes record:
+// with OPENTASK_RECORD=1 a test talks to the real platform and saves what
+// it answered, otherwise it replays the saved answers.
+const RecordEnv = "OPENTASK_RECORD"
+
+// Cassette is an http.RoundTripper for platform client tests. It replays
+// the requests saved in a HAR fixture, so a test covers the platform's real
+// answers without credentials. While RecordEnv is set it sends the
+// requests on to the platform instead and Close saves them to the fixture,
+// with credentials masked as --record masks them.
+type Cassette struct {
+	path      string
+	replayer  *Replayer
+	traffic   *Traffic
+	transport http.RoundTripper
+}
+
+// NewCassette returns a cassette for the fixture at path. When recording,
+// secrets are masked wherever they appear in the fixture, in addition to
+// Authorization headers and credential fields; requests then go through
+// base (http.DefaultTransport when nil).
+func NewCassette(path string, base http.RoundTripper, secrets ...string) (*Cassette, error) {
+	if os.Getenv(RecordEnv) != "" {
+		redactor := NewRedactor()
+		redactor.AddSecrets(secrets...)
+		traffic := NewTraffic(redactor)
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		return &Cassette{
+			path:      path,
+			traffic:   traffic,
+			transport: &trafficTransport{base: base, traffic: traffic},
+		}, nil
+	}
+
+	har, err := LoadHAR(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cassette (record it with %s=1): %w", RecordEnv, err)
+	}
+	replayer := NewReplayer(har)
+	return &Cassette{path: path, replayer: replayer, transport: replayer}, nil
+}
+
+// Recording reports whether the cassette talks to the platform, so a test
+// knows to use real credentials.
+func (c *Cassette) Recording() bool {
+	return c.traffic != nil
+}
+
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	return c.transport.RoundTrip(req)
+}
+
+// Unused returns the saved requests a replay has not made, as
+// Replayer.Unused does. It is always empty while recording.
+func (c *Cassette) Unused() []string {
+	if c.replayer == nil {
+		return nil
+	}
+	return c.replayer.Unused()
+}
+
+// Close saves the recorded requests to the fixture. It does nothing when
+// replaying.
+func (c *Cassette) Close() error {
+	if c.traffic == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	return c.traffic.HAR("test").Save(c.path)
+}
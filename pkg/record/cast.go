@@ -0,0 +1,109 @@
+// Package record records terminal sessions in the asciicast v2 format used
+// by asciinema, so a reproduction or demo can be replayed with
+// "asciinema play" or embedded in a web page.
+package record
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Header is the first line of an asciicast v2 recording.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Writer writes a recording: the header, then one event per line with the
+// seconds since the recording started.
+type Writer struct {
+	mu      sync.Mutex
+	w       io.Writer
+	start   time.Time
+	now     func() time.Time
+	pending []byte
+}
+
+// NewWriter writes header to w and starts the recording clock.
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	return newWriter(w, header, time.Now)
+}
+
+func newWriter(w io.Writer, header Header, now func() time.Time) (*Writer, error) {
+	header.Version = 2
+	data, err := json.Marshal(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode recording header: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to write recording: %w", err)
+	}
+	return &Writer{w: w, start: now(), now: now}, nil
+}
+
+// Output records data written to the terminal. A multi-byte character
+// split across two writes is kept until the rest of it arrives, since each
+// event has to be valid UTF-8.
+func (w *Writer) Output(data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data = append(w.pending, data...)
+	w.pending = nil
+	if cut := incompleteSuffix(data); cut > 0 {
+		w.pending = append([]byte(nil), data[len(data)-cut:]...)
+		data = data[:len(data)-cut]
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return w.event("o", string(data))
+}
+
+// Input records keys typed into the terminal.
+func (w *Writer) Input(data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.event("i", string(data))
+}
+
+// Resize records the terminal changing size.
+func (w *Writer) Resize(width, height int) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.event("r", fmt.Sprintf("%dx%d", width, height))
+}
+
+func (w *Writer) event(kind, data string) error {
+	elapsed := w.now().Sub(w.start).Seconds()
+	line, err := json.Marshal([]any{float64(int64(elapsed*1e6)) / 1e6, kind, data})
+	if err != nil {
+		return fmt.Errorf("failed to encode recording event: %w", err)
+	}
+	if _, err := w.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
+
+// incompleteSuffix returns how many bytes at the end of data start a
+// character that is not complete yet.
+func incompleteSuffix(data []byte) int {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if utf8.FullRune(data[i:]) {
+				return 0
+			}
+			return len(data) - i
+		}
+	}
+	return 0
+}
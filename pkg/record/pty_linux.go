@@ -0,0 +1,85 @@
+//go:build linux
+
+package record
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// startOnPTY starts cmd with a new pseudo-terminal as its stdin, stdout,
+// stderr and controlling terminal, and returns the other side of it.
+func startOnPTY(cmd *exec.Cmd, width, height int) (*os.File, error) {
+	tty, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fd := int(tty.Fd())
+	if err := unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); err != nil {
+		tty.Close()
+		return nil, fmt.Errorf("failed to unlock pseudo-terminal: %w", err)
+	}
+	n, err := unix.IoctlGetInt(fd, unix.TIOCGPTN)
+	if err != nil {
+		tty.Close()
+		return nil, fmt.Errorf("failed to find pseudo-terminal: %w", err)
+	}
+
+	pts, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		tty.Close()
+		return nil, err
+	}
+	// The command has its own copy once it runs.
+	defer pts.Close()
+
+	if err := resizePTY(tty, width, height); err != nil {
+		tty.Close()
+		return nil, err
+	}
+
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = pts, pts, pts
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true}
+	if err := cmd.Start(); err != nil {
+		tty.Close()
+		return nil, err
+	}
+	return tty, nil
+}
+
+func resizePTY(tty *os.File, width, height int) error {
+	return unix.IoctlSetWinsize(int(tty.Fd()), unix.TIOCSWINSZ, &unix.Winsize{
+		Col: uint16(width),
+		Row: uint16(height),
+	})
+}
+
+// watchResize calls fn whenever the terminal changes size, until the
+// returned function is called.
+func watchResize(fn func()) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGWINCH)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-signals:
+				fn()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
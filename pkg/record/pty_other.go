@@ -0,0 +1,23 @@
+//go:build !linux
+
+package record
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+var errUnsupported = errors.New("recording sessions is only supported on Linux")
+
+func startOnPTY(cmd *exec.Cmd, width, height int) (*os.File, error) {
+	return nil, errUnsupported
+}
+
+func resizePTY(tty *os.File, width, height int) error {
+	return errUnsupported
+}
+
+func watchResize(fn func()) func() {
+	return func() {}
+}
@@ -0,0 +1,241 @@
+package record
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var out bytes.Buffer
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	w, err := newWriter(&out, Header{Width: 100, Height: 30, Title: "opentask task list"}, clock)
+	require.NoError(t, err)
+
+	now = now.Add(1500 * time.Millisecond)
+	require.NoError(t, w.Output([]byte("hello \xe2\x9c")))
+	require.NoError(t, w.Output([]byte("\x93 done\r\n")))
+	require.NoError(t, w.Input([]byte("q")))
+	require.NoError(t, w.Resize(120, 40))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, []string{
+		`{"version":2,"width":100,"height":30,"title":"opentask task list"}`,
+		`[1.5,"o","hello "]`,
+		`[1.5,"o","✓ done\r\n"]`,
+		`[1.5,"i","q"]`,
+		`[1.5,"r","120x40"]`,
+	}, lines)
+}
+
+func TestRedactor(t *testing.T) {
+	tests := []struct {
+		name    string
+		secrets []string
+		titles  []string
+		home    string
+		input   string
+		want    string
+	}{
+		{
+			name:    "secrets keep their length",
+			secrets: []string{"s3cr3t-token"},
+			input:   "token: s3cr3t-token\n",
+			want:    "token: ************\n",
+		},
+		{
+			name:    "short values are left alone",
+			secrets: []string{"ab"},
+			input:   "about ab",
+			want:    "about ab",
+		},
+		{
+			name:   "titles cut short in a table",
+			titles: []string{"Fix the login timeout on slow networks"},
+			input:  "│ Fix the login timeo… │ open │",
+			want:   "│ *******************… │ open │",
+		},
+		{
+			name:   "longer titles are masked first",
+			titles: []string{"Fix login", "Fix login on Safari"},
+			input:  "Fix login on Safari, Fix login",
+			want:   "*******************, *********",
+		},
+		{
+			name:  "home directory",
+			home:  "/home/alice",
+			input: "wrote /home/alice/.opentask/audit.log",
+			want:  "wrote ~/.opentask/audit.log",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRedactor()
+			r.AddSecrets(tt.secrets...)
+			r.AddTitles(tt.titles...)
+			r.HideHome(tt.home)
+
+			assert.Equal(t, tt.want, r.RedactString(tt.input))
+		})
+	}
+}
+
+func TestRedactor_WatchTitles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "titles")
+	t.Setenv(TitlesEnv, path)
+
+	r := NewRedactor()
+	r.WatchTitles(path)
+	assert.Equal(t, "Write docs", r.RedactString("Write docs"))
+
+	require.NoError(t, AppendTitles([]string{"Write docs"}))
+	assert.Equal(t, "**********", r.RedactString("Write docs"))
+
+	require.NoError(t, AppendTitles([]string{"Ship release"}))
+	assert.Equal(t, "************ **********", r.RedactString("Ship release Write docs"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Write docs\nShip release\n", string(data))
+}
+
+func TestTraffic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc123")
+		w.Write([]byte(`{"key": "TEST-1", "id": 10001, "access_token": "tok-123456", "note": "uses s3cr3t-value"}`))
+	}))
+	defer server.Close()
+
+	redactor := NewRedactor()
+	redactor.AddSecrets("s3cr3t-value")
+	traffic := NewTraffic(redactor)
+	SetTraffic(traffic)
+	defer SetTraffic(nil)
+
+	client := &http.Client{Transport: Transport("jira", nil)}
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/rest/api/2/issue?token=abc", strings.NewReader(`{"fields": {"summary": "Fix login"}, "password": "hunter2"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth("jane@example.com", "s3cr3t-value")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Contains(t, string(body), "tok-123456", "the caller still gets the real response")
+
+	har := traffic.HAR("test")
+	require.Len(t, har.Log.Entries, 1)
+	entry := har.Log.Entries[0]
+	assert.Equal(t, "jira", entry.Platform)
+	assert.Equal(t, http.MethodPost, entry.Request.Method)
+	assert.Contains(t, entry.Request.URL, "token=%5Bredacted%5D")
+	assert.JSONEq(t, `{"fields": {"summary": "Fix login"}, "password": "[redacted]"}`, entry.Request.PostData.Text)
+	assert.JSONEq(t, `{"key": "TEST-1", "id": 10001, "access_token": "[redacted]", "note": "uses ************"}`, entry.Response.Content.Text)
+	assert.Equal(t, http.StatusOK, entry.Response.Status)
+
+	data, err := json.Marshal(har)
+	require.NoError(t, err)
+	for _, secret := range []string{"s3cr3t-value", "hunter2", "tok-123456", "abc123", "amFuZUBleGFtcGxlLmNvbTpzM2NyM3QtdmFsdWU="} {
+		assert.NotContains(t, string(data), secret)
+	}
+	assert.Contains(t, string(data), "Basic [redacted]")
+}
+
+func TestReplayer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issue.har")
+	har := &HAR{Log: HARLog{Version: "1.2", Entries: []HAREntry{
+		{
+			Request: HARRequest{Method: http.MethodGet, URL: "https://example.atlassian.net/rest/api/2/search?maxResults=50&jql=project+%3D+TEST"},
+			Response: HARResponse{
+				Status:  http.StatusOK,
+				Headers: []HARNameValue{{Name: "Content-Type", Value: "application/json"}},
+				Content: HARContent{Text: `{"issues": []}`},
+			},
+		},
+		{
+			Request:  HARRequest{Method: http.MethodGet, URL: "https://example.atlassian.net/rest/api/2/issue/TEST-1"},
+			Response: HARResponse{Status: http.StatusNotFound, Content: HARContent{Text: `{"errorMessages": ["Issue does not exist"]}`}},
+		},
+	}}}
+	require.NoError(t, har.Save(path))
+
+	loaded, err := LoadHAR(path)
+	require.NoError(t, err)
+	replayer := NewReplayer(loaded)
+	client := &http.Client{Transport: replayer}
+
+	resp, err := client.Get("http://127.0.0.1:1/rest/api/2/search?jql=project+%3D+TEST&maxResults=50")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `{"issues": []}`, string(body))
+
+	assert.Equal(t, []string{"GET https://example.atlassian.net/rest/api/2/issue/TEST-1"}, replayer.Unused())
+
+	resp, err = client.Get("http://127.0.0.1:1/rest/api/2/issue/TEST-1")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Each entry answers once.
+	_, err = client.Get("http://127.0.0.1:1/rest/api/2/issue/TEST-1")
+	assert.ErrorContains(t, err, "no recorded response for GET /rest/api/2/issue/TEST-1")
+}
+
+func TestCassette(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"key": "TEST-1", "reporter": "tok-123456"}`))
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "cassettes", "issue.har")
+
+	_, err := NewCassette(path, nil)
+	assert.ErrorContains(t, err, "record it with OPENTASK_RECORD=1")
+
+	t.Setenv(RecordEnv, "1")
+	cassette, err := NewCassette(path, nil, "tok-123456")
+	require.NoError(t, err)
+	assert.True(t, cassette.Recording())
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/rest/api/2/issue/TEST-1", nil)
+	req.Header.Set("Authorization", "Bearer tok-123456")
+	resp, err := (&http.Client{Transport: cassette}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.NoError(t, cassette.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "tok-123456")
+
+	t.Setenv(RecordEnv, "")
+	cassette, err = NewCassette(path, nil)
+	require.NoError(t, err)
+	assert.False(t, cassette.Recording())
+	assert.Len(t, cassette.Unused(), 1)
+	resp, err = (&http.Client{Transport: cassette}).Get("http://127.0.0.1:1/rest/api/2/issue/TEST-1")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Contains(t, string(body), `"key":"TEST-1"`)
+	assert.Empty(t, cassette.Unused())
+	require.NoError(t, cassette.Close())
+}
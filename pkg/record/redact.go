@@ -0,0 +1,170 @@
+package record
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// minRedactLength is the shortest secret or title that is redacted; shorter
+// strings would mask unrelated text.
+const minRedactLength = 4
+
+// titleAnchor is how many characters of a title have to match before the
+// rest is masked too, so titles cut short with "…" in narrow table columns
+// are still caught.
+const titleAnchor = 12
+
+// Redactor masks secrets and task titles in recorded output. Masks keep
+// the length of what they hide, so tables and the terminal app keep their
+// layout.
+type Redactor struct {
+	mu        sync.Mutex
+	secrets   []string
+	titles    []string
+	home      string
+	titleFile string
+	titleSize int64
+}
+
+// NewRedactor returns a redactor that masks nothing yet.
+func NewRedactor() *Redactor {
+	return &Redactor{}
+}
+
+// AddSecrets masks each secret wherever it appears.
+func (r *Redactor) AddSecrets(secrets ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secrets = appendLongest(r.secrets, secrets)
+}
+
+// AddTitles masks each task title wherever it appears, including when it
+// is cut short.
+func (r *Redactor) AddTitles(titles ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.titles = appendLongest(r.titles, titles)
+}
+
+// HideHome replaces the home directory with "~", so paths do not give away
+// the user name.
+func (r *Redactor) HideHome(home string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.home = home
+}
+
+// WatchTitles reads titles from path, one per line, as it grows. The
+// recorded command appends the titles of the tasks it loads there (see
+// TitlesEnv).
+func (r *Redactor) WatchTitles(path string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.titleFile = path
+}
+
+// Redact returns data with every secret and title masked.
+func (r *Redactor) Redact(data []byte) []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.loadTitles()
+	for _, secret := range r.secrets {
+		data = bytes.ReplaceAll(data, []byte(secret), mask(secret))
+	}
+	for _, title := range r.titles {
+		data = maskTitle(data, title)
+	}
+	if r.home != "" {
+		data = bytes.ReplaceAll(data, []byte(r.home), []byte("~"))
+	}
+	return data
+}
+
+// RedactString is Redact for strings.
+func (r *Redactor) RedactString(s string) string {
+	return string(r.Redact([]byte(s)))
+}
+
+func (r *Redactor) loadTitles() {
+	if r.titleFile == "" {
+		return
+	}
+	info, err := os.Stat(r.titleFile)
+	if err != nil || info.Size() == r.titleSize {
+		return
+	}
+
+	f, err := os.Open(r.titleFile)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	var titles []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		titles = append(titles, scanner.Text())
+	}
+	r.titles = appendLongest(r.titles, titles)
+	r.titleSize = info.Size()
+}
+
+// appendLongest adds the new strings that are long enough and not known
+// yet, keeping the longest first so a string is masked before any string
+// it contains.
+func appendLongest(known, add []string) []string {
+	seen := make(map[string]bool, len(known))
+	for _, s := range known {
+		seen[s] = true
+	}
+	for _, s := range add {
+		s = strings.TrimSpace(s)
+		if utf8.RuneCountInString(s) < minRedactLength || seen[s] {
+			continue
+		}
+		seen[s] = true
+		known = append(known, s)
+	}
+	sort.SliceStable(known, func(i, j int) bool { return len(known[i]) > len(known[j]) })
+	return known
+}
+
+// maskTitle masks title in data, or as much of it as follows its first
+// titleAnchor characters.
+func maskTitle(data []byte, title string) []byte {
+	anchor := title
+	if runes := []rune(title); len(runes) > titleAnchor {
+		anchor = string(runes[:titleAnchor])
+	}
+
+	var out []byte
+	for {
+		i := bytes.Index(data, []byte(anchor))
+		if i < 0 {
+			return append(out, data...)
+		}
+
+		n := len(anchor)
+		for n < len(title) && i+n < len(data) && data[i+n] == title[n] {
+			n++
+		}
+		// Do not stop inside a multi-byte character.
+		for n > len(anchor) && !utf8.Valid(data[i:i+n]) {
+			n--
+		}
+
+		out = append(out, data[:i]...)
+		out = append(out, mask(string(data[i:i+n]))...)
+		data = data[i+n:]
+	}
+}
+
+func mask(s string) []byte {
+	return bytes.Repeat([]byte("*"), utf8.RuneCountInString(s))
+}
@@ -0,0 +1,86 @@
+package record
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"opentask/pkg/logging"
+)
+
+// Replayer is an http.RoundTripper that answers requests with the
+// responses recorded in a HAR document, so a reported problem can be
+// reproduced in a test without the platform. Requests match an entry by
+// method, path and query, whatever the host; each entry answers once, in
+// the order they were recorded.
+type Replayer struct {
+	mu      sync.Mutex
+	entries []HAREntry
+	used    []bool
+}
+
+// NewReplayer returns a replayer for the entries of har.
+func NewReplayer(har *HAR) *Replayer {
+	return &Replayer{entries: har.Log.Entries, used: make([]bool, len(har.Log.Entries))}
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	key := replayKey(req.Method, logging.RedactURL(req.URL))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, entry := range r.entries {
+		if r.used[i] || replayKey(entry.Request.Method, entry.Request.URL) != key {
+			continue
+		}
+		r.used[i] = true
+
+		if entry.Error != "" {
+			return nil, fmt.Errorf("recorded error: %s", entry.Error)
+		}
+		header := make(http.Header)
+		for _, h := range entry.Response.Headers {
+			header.Add(h.Name, h.Value)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", entry.Response.Status, entry.Response.StatusText),
+			StatusCode:    entry.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(entry.Response.Content.Text)),
+			ContentLength: int64(len(entry.Response.Content.Text)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL.Path)
+}
+
+// Unused returns the entries that have not answered a request yet, as
+// "METHOD URL", so a test can check it replayed the whole recording.
+func (r *Replayer) Unused() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []string
+	for i, entry := range r.entries {
+		if !r.used[i] {
+			unused = append(unused, entry.Request.Method+" "+entry.Request.URL)
+		}
+	}
+	return unused
+}
+
+func replayKey(method, rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return method + " " + rawURL
+	}
+	return method + " " + u.Path + "?" + u.Query().Encode()
+}
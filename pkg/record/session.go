@@ -0,0 +1,127 @@
+package record
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// TitlesEnv names the file a recorded opentask appends the titles of the
+// tasks it loads to, one per line, so the recorder can mask them.
+const TitlesEnv = "OPENTASK_RECORD_TITLES"
+
+// AppendTitles adds titles to the file named by TitlesEnv. It does nothing
+// when the variable is not set.
+func AppendTitles(titles []string) error {
+	path := os.Getenv(TitlesEnv)
+	if path == "" || len(titles) == 0 {
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var b strings.Builder
+	for _, title := range titles {
+		b.WriteString(strings.ReplaceAll(title, "\n", " "))
+		b.WriteByte('\n')
+	}
+	_, err = f.WriteString(b.String())
+	return err
+}
+
+// Session runs a command on a pseudo-terminal, shows it on the user's
+// terminal as usual and records what it prints.
+type Session struct {
+	Cast     *Writer
+	Redactor *Redactor
+	// Input also records the keys typed, which can include passwords
+	// typed into prompts.
+	Input bool
+}
+
+// Size returns the size of the terminal on stdout, or 80x24 when stdout is
+// not a terminal.
+func Size() (width, height int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width == 0 || height == 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// Run starts cmd and records it until it exits. The user's terminal is in
+// raw mode meanwhile, so keys reach the command unchanged.
+func (s *Session) Run(cmd *exec.Cmd) error {
+	width, height := Size()
+	tty, err := startOnPTY(cmd, width, height)
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", cmd.Path, err)
+	}
+	defer tty.Close()
+
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("failed to set up the terminal: %w", err)
+		}
+		defer term.Restore(fd, state)
+	}
+
+	stop := watchResize(func() {
+		width, height := Size()
+		if err := resizePTY(tty, width, height); err == nil {
+			s.Cast.Resize(width, height)
+		}
+	})
+	defer stop()
+
+	// The copy from stdin stops when the program exits.
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				tty.Write(buf[:n])
+				if s.Input {
+					s.Cast.Input(s.Redactor.Redact(buf[:n]))
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var recordErr error
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := tty.Read(buf)
+		if n > 0 {
+			os.Stdout.Write(buf[:n])
+			if err := s.Cast.Output(s.Redactor.Redact(buf[:n])); err != nil && recordErr == nil {
+				recordErr = err
+			}
+		}
+		if err != nil {
+			// Reading fails once the command exits and its side of the
+			// terminal is closed.
+			break
+		}
+	}
+
+	err = cmd.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// The command has already shown its error.
+		err = fmt.Errorf("recorded command exited with status %d", exitErr.ExitCode())
+	}
+	return errors.Join(err, recordErr)
+}
@@ -0,0 +1,277 @@
+package record
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"opentask/pkg/logging"
+)
+
+// redactedValue replaces credentials in recorded traffic.
+const redactedValue = "[redacted]"
+
+// sensitiveHeaders are headers whose values are never recorded. The scheme
+// of an Authorization header is kept.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+}
+
+// Traffic records the HTTP requests platform clients make, with
+// credentials scrubbed, so they can be attached to a bug report and
+// replayed in tests (see Replayer).
+type Traffic struct {
+	redactor *Redactor
+
+	mu      sync.Mutex
+	entries []HAREntry
+}
+
+// NewTraffic returns a recorder that also masks everything redactor masks,
+// such as the configured credentials, wherever it appears.
+func NewTraffic(redactor *Redactor) *Traffic {
+	if redactor == nil {
+		redactor = NewRedactor()
+	}
+	return &Traffic{redactor: redactor}
+}
+
+var currentTraffic atomic.Pointer[Traffic]
+
+// SetTraffic makes every Transport record to t, or stops recording when t
+// is nil.
+func SetTraffic(t *Traffic) {
+	currentTraffic.Store(t)
+}
+
+// Len returns the number of requests recorded so far.
+func (t *Traffic) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.entries)
+}
+
+// HAR returns the recorded requests as a HAR document.
+func (t *Traffic) HAR(version string) *HAR {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return &HAR{Log: HARLog{
+		Version: "1.2",
+		Creator: HARCreator{Name: "opentask", Version: version},
+		Entries: append([]HAREntry{}, t.entries...),
+	}}
+}
+
+type trafficTransport struct {
+	platform string
+	base     http.RoundTripper
+	// traffic, when set, is recorded to instead of the one SetTraffic
+	// installed.
+	traffic *Traffic
+}
+
+// Transport wraps base so requests are recorded while SetTraffic has a
+// recorder installed. A nil base uses http.DefaultTransport.
+func Transport(platform string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &trafficTransport{platform: platform, base: base}
+}
+
+func (t *trafficTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	traffic := t.traffic
+	if traffic == nil {
+		traffic = currentTraffic.Load()
+	}
+	if traffic == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	var responseBody []byte
+	if err == nil {
+		responseBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	}
+
+	traffic.add(t.platform, req, requestBody, resp, responseBody, err, start, elapsed)
+	return resp, err
+}
+
+func (t *Traffic) add(platform string, req *http.Request, requestBody []byte, resp *http.Response, responseBody []byte, err error, start time.Time, elapsed time.Duration) {
+	ms := float64(elapsed.Microseconds()) / 1000
+	entry := HAREntry{
+		Platform:        platform,
+		StartedDateTime: start,
+		Time:            ms,
+		Request: HARRequest{
+			Method:      req.Method,
+			URL:         t.redactor.RedactString(logging.RedactURL(req.URL)),
+			HTTPVersion: req.Proto,
+			Headers:     t.headers(req.Header),
+			QueryString: t.query(req.URL),
+			HeadersSize: -1,
+			BodySize:    len(requestBody),
+		},
+		Timings: HARTimings{Wait: ms},
+	}
+	if entry.Request.HTTPVersion == "" {
+		entry.Request.HTTPVersion = "HTTP/1.1"
+	}
+	if len(requestBody) > 0 {
+		entry.Request.PostData = &HARPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     t.body(requestBody, req.Header.Get("Content-Type")),
+		}
+	}
+
+	if err != nil {
+		entry.Error = t.redactor.RedactString(err.Error())
+	} else {
+		entry.Response = HARResponse{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: resp.Proto,
+			Headers:     t.headers(resp.Header),
+			Content: HARContent{
+				Size:     len(responseBody),
+				MimeType: resp.Header.Get("Content-Type"),
+				Text:     t.body(responseBody, resp.Header.Get("Content-Type")),
+			},
+			HeadersSize: -1,
+			BodySize:    len(responseBody),
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, entry)
+}
+
+func (t *Traffic) headers(header http.Header) []HARNameValue {
+	values := []HARNameValue{}
+	for _, name := range sortedKeys(header) {
+		for _, value := range header[name] {
+			switch {
+			case name == "Authorization" || name == "Proxy-Authorization":
+				value = logging.RedactAuthorization(value)
+			case sensitiveHeaders[name]:
+				value = redactedValue
+			default:
+				value = t.redactor.RedactString(value)
+			}
+			values = append(values, HARNameValue{Name: name, Value: value})
+		}
+	}
+	return values
+}
+
+func (t *Traffic) query(u *url.URL) []HARNameValue {
+	redacted, err := url.Parse(logging.RedactURL(u))
+	if err != nil {
+		return []HARNameValue{}
+	}
+	query := redacted.Query()
+	values := []HARNameValue{}
+	for _, name := range sortedKeys(query) {
+		for _, value := range query[name] {
+			values = append(values, HARNameValue{Name: name, Value: t.redactor.RedactString(value)})
+		}
+	}
+	return values
+}
+
+// body returns a request or response body with credential fields of JSON
+// and form bodies, and anything the redactor masks, hidden.
+func (t *Traffic) body(data []byte, contentType string) string {
+	switch {
+	case strings.Contains(contentType, "json"):
+		var doc any
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err == nil {
+			if scrubbed, err := json.Marshal(scrubJSON(doc)); err == nil {
+				data = scrubbed
+			}
+		}
+	case strings.Contains(contentType, "x-www-form-urlencoded"):
+		if form, err := url.ParseQuery(string(data)); err == nil {
+			for name := range form {
+				if sensitiveKey(name) {
+					form[name] = []string{redactedValue}
+				}
+			}
+			data = []byte(form.Encode())
+		}
+	}
+	return t.redactor.RedactString(string(data))
+}
+
+// scrubJSON replaces the values of credential fields, such as
+// access_token, anywhere in doc.
+func scrubJSON(doc any) any {
+	switch v := doc.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if sensitiveKey(key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = scrubJSON(value)
+			}
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = scrubJSON(value)
+		}
+	}
+	return doc
+}
+
+func sensitiveKey(name string) bool {
+	lower := strings.ToLower(name)
+	switch lower {
+	case "apikey", "api_key", "authorization":
+		return true
+	}
+	return strings.Contains(lower, "token") || strings.Contains(lower, "secret") || strings.Contains(lower, "password")
+}
+
+func sortedKeys(values map[string][]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,163 @@
+// Package recurring creates tasks on cron schedules, such as a weekly
+// report task every Monday at 9:00. Each recurring task remembers the
+// scheduled time it was last created for, so running the schedule again,
+// from cron and the daemon alike, does not create duplicates.
+package recurring
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"opentask/pkg/models"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+)
+
+// Store keeps the recurring tasks.
+type Store interface {
+	LoadRecurring() ([]store.RecurringTask, error)
+	SaveRecurring(tasks []store.RecurringTask) error
+}
+
+// Occurrence is a scheduled time a recurring task is due to be created
+// for.
+type Occurrence struct {
+	Recurring store.RecurringTask
+	At        time.Time
+}
+
+// Result is the outcome of creating the task for an Occurrence.
+type Result struct {
+	Occurrence Occurrence
+	Task       *models.Task
+	Err        error
+}
+
+// Due returns an occurrence for each recurring task scheduled to run after
+// its last run and at or before now. A task that missed several runs, such
+// as while the machine was off, is created once, for the latest.
+func Due(tasks []store.RecurringTask, now time.Time) ([]Occurrence, error) {
+	var due []Occurrence
+	for _, task := range tasks {
+		schedule, err := Parse(task.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("recurring task %q: %w", task.ID, err)
+		}
+
+		since := task.LastRun
+		if since.IsZero() {
+			since = task.CreatedAt
+		}
+		if at := schedule.Latest(since, now); !at.IsZero() {
+			due = append(due, Occurrence{Recurring: task, At: at})
+		}
+	}
+	return due, nil
+}
+
+// Run creates the tasks that are due and records each run in st as soon as
+// its task is created.
+func Run(ctx context.Context, svc *service.Service, st Store, now time.Time) ([]Result, error) {
+	tasks, err := st.LoadRecurring()
+	if err != nil {
+		return nil, err
+	}
+	due, err := Due(tasks, now)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(due))
+	for _, occurrence := range due {
+		created, err := Create(ctx, svc, occurrence.Recurring)
+		results = append(results, Result{Occurrence: occurrence, Task: created, Err: err})
+		if err != nil {
+			continue
+		}
+
+		for i := range tasks {
+			if tasks[i].ID == occurrence.Recurring.ID {
+				tasks[i].LastRun = occurrence.At
+				tasks[i].LastTaskID = created.ID
+			}
+		}
+		if err := st.SaveRecurring(tasks); err != nil {
+			return results, fmt.Errorf("created %s but could not record it: %w", created.ID, err)
+		}
+	}
+	return results, nil
+}
+
+// Create creates the task a recurring task describes on its platform.
+func Create(ctx context.Context, svc *service.Service, recurring store.RecurringTask) (*models.Task, error) {
+	client, err := svc.Client(recurring.Platform)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := service.WithRequestTimeout(ctx)
+	defer cancel()
+	return client.CreateTask(ctx, NewTask(recurring))
+}
+
+// Audit describes the tasks results created for the audit log, with the
+// tasks that could not be created.
+func Audit(results []Result) []store.AuditTask {
+	audit := make([]store.AuditTask, 0, len(results))
+	for _, result := range results {
+		if result.Err != nil {
+			audit = append(audit, store.NewAuditTask(NewTask(result.Occurrence.Recurring), result.Err))
+			continue
+		}
+		audit = append(audit, store.NewAuditTask(result.Task, nil))
+	}
+	return audit
+}
+
+// NewTask returns the task to create for a recurring task.
+func NewTask(recurring store.RecurringTask) *models.Task {
+	task := models.NewTask(recurring.Title, models.Platform(recurring.Platform))
+	task.Description = recurring.Description
+	task.ProjectID = recurring.Project
+	if recurring.Priority != "" {
+		task.SetPriority(models.Priority(recurring.Priority))
+	}
+	for _, label := range recurring.Labels {
+		task.AddLabel(label)
+	}
+	return task
+}
+
+// NewID derives an ID for a recurring task from its title, such as
+// weekly-report, numbering it when taken.
+func NewID(title string, tasks []store.RecurringTask) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	base := strings.TrimSuffix(b.String(), "-")
+	if base == "" {
+		base = "task"
+	}
+
+	taken := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		taken[task.ID] = true
+	}
+	id := base
+	for n := 2; taken[id]; n++ {
+		id = base + "-" + strconv.Itoa(n)
+	}
+	return id
+}
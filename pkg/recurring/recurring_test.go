@@ -0,0 +1,126 @@
+package recurring
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient numbers the tasks it is asked to create.
+type fakeClient struct {
+	platforms.PlatformClient
+
+	created []*models.Task
+}
+
+func (c *fakeClient) CreateTask(ctx context.Context, task *models.Task) (*models.Task, error) {
+	c.created = append(c.created, task)
+	created := *task
+	created.ID = "OPS-" + string(rune('0'+len(c.created)))
+	return &created, nil
+}
+
+type fakeFactory struct {
+	client *fakeClient
+}
+
+func (f *fakeFactory) Create(settings map[string]any) (platforms.PlatformClient, error) {
+	return f.client, nil
+}
+
+func (f *fakeFactory) GetType() string                              { return "jira" }
+func (f *fakeFactory) GetName() string                              { return "Fake" }
+func (f *fakeFactory) ValidateConfig(settings map[string]any) error { return nil }
+
+// memoryStore keeps the recurring tasks in memory.
+type memoryStore struct {
+	tasks []store.RecurringTask
+	saves int
+}
+
+func (s *memoryStore) LoadRecurring() ([]store.RecurringTask, error) {
+	return append([]store.RecurringTask(nil), s.tasks...), nil
+}
+
+func (s *memoryStore) SaveRecurring(tasks []store.RecurringTask) error {
+	s.tasks = tasks
+	s.saves++
+	return nil
+}
+
+func TestDue(t *testing.T) {
+	added := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tasks := []store.RecurringTask{
+		{ID: "weekly", Cron: "0 9 * * MON", CreatedAt: added},
+		{ID: "done-this-week", Cron: "0 9 * * MON", CreatedAt: added, LastRun: time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)},
+		{ID: "monthly", Cron: "0 9 15 * *", CreatedAt: added},
+	}
+
+	due, err := Due(tasks, now)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, "weekly", due[0].Recurring.ID)
+	assert.Equal(t, time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC), due[0].At)
+
+	_, err = Due([]store.RecurringTask{{ID: "bad", Cron: "every monday"}}, now)
+	assert.Error(t, err)
+}
+
+func TestRun(t *testing.T) {
+	client := &fakeClient{}
+	registry := platforms.NewRegistry()
+	registry.Register(&fakeFactory{client: client})
+	svc := service.NewWithRegistry(&config.Config{Platforms: map[string]config.Platform{
+		"jira": {Type: "jira", Enabled: true},
+	}}, registry)
+
+	st := &memoryStore{tasks: []store.RecurringTask{{
+		ID:        "weekly-report",
+		Title:     "Weekly report",
+		Cron:      "0 9 * * MON",
+		Platform:  "jira",
+		Project:   "OPS",
+		Priority:  "high",
+		Labels:    []string{"report"},
+		CreatedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}}}
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	results, err := Run(context.Background(), svc, st, now)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, "OPS-1", results[0].Task.ID)
+
+	require.Len(t, client.created, 1)
+	assert.Equal(t, "Weekly report", client.created[0].Title)
+	assert.Equal(t, "OPS", client.created[0].ProjectID)
+	assert.Equal(t, models.PriorityHigh, client.created[0].Priority)
+	assert.Equal(t, []string{"report"}, client.created[0].Labels)
+
+	assert.Equal(t, time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC), st.tasks[0].LastRun)
+	assert.Equal(t, "OPS-1", st.tasks[0].LastTaskID)
+
+	// Running again before the next Monday creates nothing.
+	results, err = Run(context.Background(), svc, st, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Len(t, client.created, 1)
+}
+
+func TestNewID(t *testing.T) {
+	tasks := []store.RecurringTask{{ID: "weekly-report"}}
+	assert.Equal(t, "weekly-report-2", NewID("Weekly report", tasks))
+	assert.Equal(t, "on-call-handover", NewID("On-call: handover!", tasks))
+	assert.Equal(t, "task", NewID("!!!", nil))
+}
@@ -0,0 +1,71 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: "0 9 * *", wantErr: "want 5 fields"},
+		{expr: "60 9 * * *", wantErr: `invalid minute "60"`},
+		{expr: "0 9 * * FUN", wantErr: `invalid day of week "FUN"`},
+		{expr: "0 9 10-1 * *", wantErr: "invalid day of month range"},
+		{expr: "*/0 * * * *", wantErr: "invalid minute step"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	// Tuesday
+	from := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "0 9 * * MON", want: time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", want: time.Date(2026, 3, 10, 9, 45, 0, 0, time.UTC)},
+		{expr: "0 9-17 * * 1-5", want: time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 * *", want: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "30 8 1,15 JUN *", want: time.Date(2026, 6, 1, 8, 30, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", want: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		// Either day field matches when both are set.
+		{expr: "0 12 13 * FRI", want: time.Date(2026, 3, 13, 12, 0, 0, 0, time.UTC)},
+		{expr: "@daily", want: time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 30 2 *", want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(from))
+		})
+	}
+}
+
+func TestSchedule_Latest(t *testing.T) {
+	schedule, err := Parse("0 9 * * MON")
+	require.NoError(t, err)
+
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC), schedule.Latest(since, now))
+
+	// Nothing is due again until the next Monday.
+	assert.True(t, schedule.Latest(time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC), now).IsZero())
+}
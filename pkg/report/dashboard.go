@@ -0,0 +1,192 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	"opentask/pkg/models"
+)
+
+// Dashboard summarizes a snapshot for the static dashboard site.
+type Dashboard struct {
+	Title       string          `json:"title"`
+	GeneratedAt time.Time       `json:"generated_at"`
+	SnapshotAt  time.Time       `json:"snapshot_at"`
+	Stats       []Stat          `json:"stats"`
+	Projects    []ProjectCounts `json:"projects"`
+	Overdue     []*models.Task  `json:"overdue"`
+	Completed   []*models.Task  `json:"completed"`
+	// CompletedDays is the look-back window for Completed.
+	CompletedDays int `json:"completed_days"`
+}
+
+// ProjectCounts holds the open work of one project.
+type ProjectCounts struct {
+	Project    string `json:"project"`
+	Open       int    `json:"open"`
+	InProgress int    `json:"in_progress"`
+	Overdue    int    `json:"overdue"`
+}
+
+// Total returns the number of unfinished tasks in the project.
+func (p ProjectCounts) Total() int {
+	return p.Open + p.InProgress
+}
+
+// BuildDashboard computes dashboard sections from the tasks of a snapshot.
+// Completed lists tasks finished within the last completedDays days.
+func BuildDashboard(title string, tasks []*models.Task, snapshotAt, now time.Time, completedDays int) *Dashboard {
+	d := &Dashboard{
+		Title:         title,
+		GeneratedAt:   now,
+		SnapshotAt:    snapshotAt,
+		Stats:         Summarize(tasks, now),
+		CompletedDays: completedDays,
+	}
+
+	since := truncateDay(now).AddDate(0, 0, -completedDays)
+	byProject := make(map[string]*ProjectCounts)
+	for _, task := range tasks {
+		if task.Status == models.StatusDone && !task.UpdatedAt.Before(since) {
+			d.Completed = append(d.Completed, task)
+		}
+		if task.Status.IsClosed() {
+			continue
+		}
+
+		project := task.ProjectID
+		if project == "" {
+			project = "(no project)"
+		}
+		counts, ok := byProject[project]
+		if !ok {
+			counts = &ProjectCounts{Project: project}
+			byProject[project] = counts
+		}
+
+		if task.Status == models.StatusInProgress {
+			counts.InProgress++
+		} else {
+			counts.Open++
+		}
+		if isOverdue(task, now) {
+			counts.Overdue++
+			d.Overdue = append(d.Overdue, task)
+		}
+	}
+
+	for _, counts := range byProject {
+		d.Projects = append(d.Projects, *counts)
+	}
+	sort.Slice(d.Projects, func(i, j int) bool {
+		if d.Projects[i].Total() != d.Projects[j].Total() {
+			return d.Projects[i].Total() > d.Projects[j].Total()
+		}
+		return d.Projects[i].Project < d.Projects[j].Project
+	})
+	sort.SliceStable(d.Overdue, func(i, j int) bool {
+		return d.Overdue[i].DueDate.Before(*d.Overdue[j].DueDate)
+	})
+	sort.SliceStable(d.Completed, func(i, j int) bool {
+		return d.Completed[i].UpdatedAt.After(d.Completed[j].UpdatedAt)
+	})
+
+	return d
+}
+
+// RenderDashboard writes the dashboard as a standalone HTML page.
+func RenderDashboard(w io.Writer, d *Dashboard) error {
+	if err := dashboardTemplate.Execute(w, d); err != nil {
+		return fmt.Errorf("failed to render dashboard: %w", err)
+	}
+	return nil
+}
+
+const dashboardStyle = `
+.bar { background: #e3f0fb; height: 0.8rem; border-radius: 3px; }
+.overdue { color: #c62828; }
+`
+
+var dashboardTemplate = template.Must(template.New("dashboard").Funcs(htmlFuncs).Funcs(template.FuncMap{
+	"percent": func(part, whole int) int {
+		if whole == 0 {
+			return 0
+		}
+		return part * 100 / whole
+	},
+	"maxTotal": func(projects []ProjectCounts) int {
+		peak := 0
+		for _, p := range projects {
+			peak = max(peak, p.Total())
+		}
+		return peak
+	},
+	"day": func(t time.Time) string {
+		return t.Local().Format(dateLayout)
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>` + htmlStyle + dashboardStyle + `</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="meta">snapshot of {{timestamp .SnapshotAt}} · generated {{timestamp .GeneratedAt}}</div>
+<div class="stats">
+{{- range .Stats}}
+<div class="stat"><div class="value">{{.Value}}</div><div class="label">{{.Label}}</div></div>
+{{- end}}
+</div>
+
+<h2>Open tasks by project</h2>
+{{- if .Projects}}
+{{- $peak := maxTotal .Projects}}
+<table>
+<thead><tr><th>Project</th><th>Open</th><th>In progress</th><th>Overdue</th><th></th></tr></thead>
+<tbody>
+{{- range .Projects}}
+<tr><td>{{.Project}}</td><td>{{.Open}}</td><td>{{.InProgress}}</td><td{{if .Overdue}} class="overdue"{{end}}>{{.Overdue}}</td><td style="width: 40%"><div class="bar" style="width: {{percent .Total $peak}}%"></div></td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No open tasks.</p>
+{{- end}}
+
+<h2>Overdue</h2>
+{{- if .Overdue}}
+<table>
+<thead><tr><th>ID</th><th>Title</th><th>Project</th><th>Assignee</th><th>Due</th></tr></thead>
+<tbody>
+{{- range .Overdue}}
+<tr><td>{{.ID}}</td><td>{{.Title}}</td><td>{{.ProjectID}}</td><td>{{assignee .}}</td><td class="overdue">{{date .DueDate}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>Nothing is overdue.</p>
+{{- end}}
+
+<h2>Completed in the last {{.CompletedDays}} days</h2>
+{{- if .Completed}}
+<table>
+<thead><tr><th>ID</th><th>Title</th><th>Project</th><th>Assignee</th><th>Completed</th></tr></thead>
+<tbody>
+{{- range .Completed}}
+<tr><td>{{.ID}}</td><td>{{.Title}}</td><td>{{.ProjectID}}</td><td>{{assignee .}}</td><td>{{day .UpdatedAt}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No tasks completed recently.</p>
+{{- end}}
+<footer>Generated by OpenTask from the local snapshot history.</footer>
+</body>
+</html>
+`))
@@ -0,0 +1,154 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"opentask/pkg/models"
+)
+
+// Digest summarizes what happened to the tasks of each platform and
+// project over a period: the tasks created and closed in it, and the open
+// tasks that are overdue or stale at its end.
+type Digest struct {
+	Title string
+	From  time.Time
+	To    time.Time
+	// StaleDays is how long an open task goes without an update before it
+	// is stale.
+	StaleDays int
+	Groups    []DigestGroup
+}
+
+// DigestGroup is the part of a digest about one project of a platform.
+type DigestGroup struct {
+	Platform string
+	Project  string
+	Created  []*models.Task
+	Closed   []*models.Task
+	Overdue  []*models.Task
+	Stale    []*models.Task
+}
+
+func (g *DigestGroup) empty() bool {
+	return len(g.Created)+len(g.Closed)+len(g.Overdue)+len(g.Stale) == 0
+}
+
+// BuildDigest summarizes tasks, keyed by configured platform name, for the
+// period from from to to. Tasks have no closing time, so a closed task
+// counts as closed in the period it was last updated in.
+func BuildDigest(title string, tasks map[string][]*models.Task, from, to time.Time, staleDays int) *Digest {
+	d := &Digest{Title: title, From: from, To: to, StaleDays: staleDays}
+	staleBefore := to.AddDate(0, 0, -staleDays)
+	inPeriod := func(t time.Time) bool {
+		return !t.Before(from) && t.Before(to)
+	}
+
+	groups := make(map[[2]string]*DigestGroup)
+	for platform, list := range tasks {
+		for _, task := range list {
+			project := task.ProjectID
+			if project == "" {
+				project = "(no project)"
+			}
+			key := [2]string{platform, project}
+			group, ok := groups[key]
+			if !ok {
+				group = &DigestGroup{Platform: platform, Project: project}
+				groups[key] = group
+			}
+
+			if inPeriod(task.CreatedAt) {
+				group.Created = append(group.Created, task)
+			}
+			if task.Status.IsClosed() {
+				if inPeriod(task.UpdatedAt) {
+					group.Closed = append(group.Closed, task)
+				}
+				continue
+			}
+			if task.DueDate != nil && task.DueDate.Before(to) {
+				group.Overdue = append(group.Overdue, task)
+			}
+			if IsStale(task, staleBefore) {
+				group.Stale = append(group.Stale, task)
+			}
+		}
+	}
+
+	for _, group := range groups {
+		if group.empty() {
+			continue
+		}
+		sortByID(group.Created)
+		sortByID(group.Closed)
+		sort.SliceStable(group.Overdue, func(i, j int) bool {
+			return group.Overdue[i].DueDate.Before(*group.Overdue[j].DueDate)
+		})
+		sortByUpdated(group.Stale)
+		d.Groups = append(d.Groups, *group)
+	}
+	sort.Slice(d.Groups, func(i, j int) bool {
+		if d.Groups[i].Platform != d.Groups[j].Platform {
+			return d.Groups[i].Platform < d.Groups[j].Platform
+		}
+		return d.Groups[i].Project < d.Groups[j].Project
+	})
+	return d
+}
+
+func sortByID(tasks []*models.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
+// RenderDigestMarkdown writes the digest as Markdown, a section per
+// platform and project.
+func RenderDigestMarkdown(w io.Writer, d *Digest) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", d.Title)
+	fmt.Fprintf(&b, "_%s – %s_\n", d.From.Local().Format(dateLayout), d.To.Local().Format(dateLayout))
+	if len(d.Groups) == 0 {
+		b.WriteString("\nNothing was created or closed, and no open task is overdue or stale.\n")
+	}
+
+	for _, group := range d.Groups {
+		fmt.Fprintf(&b, "\n## %s / %s\n\n", group.Platform, group.Project)
+		fmt.Fprintf(&b, "**%d created · %d closed · %d overdue · %d stale**\n",
+			len(group.Created), len(group.Closed), len(group.Overdue), len(group.Stale))
+
+		writeDigestSection(&b, "Created", group.Created, func(task *models.Task) string {
+			return string(task.Status)
+		})
+		writeDigestSection(&b, "Closed", group.Closed, func(task *models.Task) string {
+			return string(task.Status)
+		})
+		writeDigestSection(&b, "Overdue", group.Overdue, func(task *models.Task) string {
+			return "due " + task.DueDate.Local().Format(dateLayout)
+		})
+		writeDigestSection(&b, fmt.Sprintf("Stale (no update in %d days)", d.StaleDays), group.Stale, func(task *models.Task) string {
+			return "updated " + task.UpdatedAt.Local().Format(dateLayout)
+		})
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeDigestSection(b *strings.Builder, title string, tasks []*models.Task, detail func(*models.Task) string) {
+	if len(tasks) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n### %s\n\n", title)
+	for _, task := range tasks {
+		ref := task.ID
+		if url := task.URL(); url != "" {
+			ref = fmt.Sprintf("[%s](%s)", task.ID, url)
+		}
+		fmt.Fprintf(b, "- %s %s (%s)\n", ref, task.Title, detail(task))
+	}
+}
@@ -0,0 +1,312 @@
+package report
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"opentask/pkg/models"
+)
+
+const (
+	chartWidth   = 800
+	chartHeight  = 400
+	chartMargin  = 50
+	chartTopPad  = 40
+	chartXLabels = 6
+)
+
+var (
+	exportBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	exportAxis       = color.RGBA{0x88, 0x88, 0x88, 0xff}
+	exportActual     = color.RGBA{0x1f, 0x77, 0xb4, 0xff}
+	exportIdeal      = color.RGBA{0xd6, 0x27, 0x28, 0xff}
+)
+
+var exportStatusColors = map[models.TaskStatus]color.RGBA{
+	models.StatusDone:       {0x2c, 0xa0, 0x2c, 0xff},
+	models.StatusCancelled:  {0x9e, 0x9e, 0x9e, 0xff},
+	models.StatusInProgress: {0xff, 0x9f, 0x1c, 0xff},
+	models.StatusOpen:       {0x1f, 0x77, 0xb4, 0xff},
+}
+
+// plotArea maps data coordinates (day index, value) to pixels.
+type plotArea struct {
+	days int
+	peak float64
+}
+
+func (p plotArea) x(i float64) float64 {
+	if p.days <= 1 {
+		return chartMargin
+	}
+	return chartMargin + i*float64(chartWidth-2*chartMargin)/float64(p.days-1)
+}
+
+func (p plotArea) y(v float64) float64 {
+	plotHeight := float64(chartHeight - chartMargin - chartTopPad)
+	return chartTopPad + plotHeight*(1-v/p.peak)
+}
+
+// ExportBurndown writes the burndown chart to path as SVG or PNG depending
+// on the file extension.
+func ExportBurndown(path, title string, points []BurndownPoint) error {
+	if len(points) == 0 {
+		return fmt.Errorf("no data to export")
+	}
+
+	area, actual, ideal := burndownLines(points)
+	labels := dayLabels(len(points), func(i int) string { return points[i].Day.Format("01/02") })
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		return os.WriteFile(path, []byte(burndownSVG(title, area, labels, actual, ideal)), 0644)
+	case ".png":
+		img := newCanvas()
+		drawAxes(img)
+		drawPolyline(img, ideal, exportIdeal, true)
+		drawPolyline(img, actual, exportActual, false)
+		return writePNG(path, img)
+	default:
+		return fmt.Errorf("unsupported export format %q (use .svg or .png)", filepath.Ext(path))
+	}
+}
+
+// BurndownSVG renders the burndown chart as an SVG document, for embedding
+// in HTML reports.
+func BurndownSVG(title string, points []BurndownPoint) (string, error) {
+	if len(points) == 0 {
+		return "", fmt.Errorf("no data to export")
+	}
+
+	area, actual, ideal := burndownLines(points)
+	labels := dayLabels(len(points), func(i int) string { return points[i].Day.Format("01/02") })
+	return burndownSVG(title, area, labels, actual, ideal), nil
+}
+
+func burndownLines(points []BurndownPoint) (plotArea, [][2]float64, [][2]float64) {
+	area := plotArea{days: len(points), peak: 1}
+	for _, p := range points {
+		area.peak = math.Max(area.peak, math.Max(float64(p.Remaining), p.Ideal))
+	}
+
+	actual := make([][2]float64, len(points))
+	ideal := make([][2]float64, len(points))
+	for i, p := range points {
+		actual[i] = [2]float64{area.x(float64(i)), area.y(float64(p.Remaining))}
+		ideal[i] = [2]float64{area.x(float64(i)), area.y(p.Ideal)}
+	}
+	return area, actual, ideal
+}
+
+func burndownSVG(title string, area plotArea, labels map[int]string, actual, ideal [][2]float64) string {
+	var b strings.Builder
+	writeSVGHeader(&b, title, area, labels)
+	fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2" stroke-dasharray="6,4" points="%s"/>`+"\n",
+		hexColor(exportIdeal), svgPoints(ideal))
+	fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="3" points="%s"/>`+"\n",
+		hexColor(exportActual), svgPoints(actual))
+	writeSVGLegend(&b, []string{"remaining", "ideal"}, []color.RGBA{exportActual, exportIdeal})
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// ExportCumulativeFlow writes the cumulative flow diagram to path as SVG or
+// PNG depending on the file extension.
+func ExportCumulativeFlow(path, title string, points []FlowPoint) error {
+	if len(points) == 0 {
+		return fmt.Errorf("no data to export")
+	}
+
+	area := plotArea{days: len(points), peak: 1}
+	for _, p := range points {
+		area.peak = math.Max(area.peak, float64(p.Total()))
+	}
+
+	// bands[s][i] holds the cumulative top of status s on day i.
+	bands := make([][]float64, len(FlowStatuses))
+	for s := range FlowStatuses {
+		bands[s] = make([]float64, len(points))
+		for i, p := range points {
+			below := 0.0
+			if s > 0 {
+				below = bands[s-1][i]
+			}
+			bands[s][i] = below + float64(p.Counts[FlowStatuses[s]])
+		}
+	}
+
+	labels := dayLabels(len(points), func(i int) string { return points[i].Day.Format("01/02") })
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		var b strings.Builder
+		writeSVGHeader(&b, title, area, labels)
+		for s := len(FlowStatuses) - 1; s >= 0; s-- {
+			var poly [][2]float64
+			for i := range points {
+				poly = append(poly, [2]float64{area.x(float64(i)), area.y(bands[s][i])})
+			}
+			for i := len(points) - 1; i >= 0; i-- {
+				below := 0.0
+				if s > 0 {
+					below = bands[s-1][i]
+				}
+				poly = append(poly, [2]float64{area.x(float64(i)), area.y(below)})
+			}
+			fmt.Fprintf(&b, `<polygon fill="%s" fill-opacity="0.85" stroke="none" points="%s"/>`+"\n",
+				hexColor(exportStatusColors[FlowStatuses[s]]), svgPoints(poly))
+		}
+		var names []string
+		var colors []color.RGBA
+		for _, status := range FlowStatuses {
+			names = append(names, status.String())
+			colors = append(colors, exportStatusColors[status])
+		}
+		writeSVGLegend(&b, names, colors)
+		b.WriteString("</svg>\n")
+		return os.WriteFile(path, []byte(b.String()), 0644)
+	case ".png":
+		img := newCanvas()
+		x0, x1 := int(area.x(0)), int(area.x(float64(len(points)-1)))
+		for px := x0; px <= x1; px++ {
+			t := 0.0
+			if x1 > x0 {
+				t = float64(px-x0) / float64(x1-x0) * float64(len(points)-1)
+			}
+			lo := int(math.Floor(t))
+			hi := min(lo+1, len(points)-1)
+			frac := t - float64(lo)
+			below := 0.0
+			for s, status := range FlowStatuses {
+				top := bands[s][lo] + (bands[s][hi]-bands[s][lo])*frac
+				for py := int(area.y(top)); py <= int(area.y(below)); py++ {
+					img.Set(px, py, exportStatusColors[status])
+				}
+				below = top
+			}
+		}
+		drawAxes(img)
+		return writePNG(path, img)
+	default:
+		return fmt.Errorf("unsupported export format %q (use .svg or .png)", filepath.Ext(path))
+	}
+}
+
+// dayLabels picks evenly spaced x-axis labels.
+func dayLabels(n int, label func(int) string) map[int]string {
+	labels := make(map[int]string)
+	step := max(1, (n+chartXLabels-1)/chartXLabels)
+	for i := 0; i < n; i += step {
+		labels[i] = label(i)
+	}
+	labels[n-1] = label(n - 1)
+	return labels
+}
+
+func writeSVGHeader(b *strings.Builder, title string, area plotArea, labels map[int]string) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		chartWidth, chartHeight, chartWidth, chartHeight)
+	fmt.Fprintf(b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hexColor(exportBackground))
+	fmt.Fprintf(b, `<text x="%d" y="24" font-size="16" font-weight="bold">%s</text>`+"\n", chartMargin, escapeXML(title))
+
+	bottom := chartHeight - chartMargin
+	fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s"/>`+"\n",
+		chartMargin, chartTopPad, chartMargin, bottom, hexColor(exportAxis))
+	fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s"/>`+"\n",
+		chartMargin, bottom, chartWidth-chartMargin, bottom, hexColor(exportAxis))
+	fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="end">%d</text>`+"\n", chartMargin-6, chartTopPad+4, int(area.peak))
+	fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="end">0</text>`+"\n", chartMargin-6, bottom+4)
+
+	for i, label := range labels {
+		fmt.Fprintf(b, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`+"\n", area.x(float64(i)), bottom+18, label)
+	}
+}
+
+func writeSVGLegend(b *strings.Builder, names []string, colors []color.RGBA) {
+	x := chartWidth - chartMargin - 110*len(names)
+	for i, name := range names {
+		fmt.Fprintf(b, `<rect x="%d" y="14" width="12" height="12" fill="%s"/>`+"\n", x, hexColor(colors[i]))
+		fmt.Fprintf(b, `<text x="%d" y="24">%s</text>`+"\n", x+16, name)
+		x += 110
+	}
+}
+
+func svgPoints(points [][2]float64) string {
+	parts := make([]string, len(points))
+	for i, p := range points {
+		parts[i] = fmt.Sprintf("%.1f,%.1f", p[0], p[1])
+	}
+	return strings.Join(parts, " ")
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func escapeXML(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
+
+func newCanvas() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	for y := 0; y < chartHeight; y++ {
+		for x := 0; x < chartWidth; x++ {
+			img.Set(x, y, exportBackground)
+		}
+	}
+	return img
+}
+
+func drawAxes(img *image.RGBA) {
+	bottom := chartHeight - chartMargin
+	for y := chartTopPad; y <= bottom; y++ {
+		img.Set(chartMargin, y, exportAxis)
+	}
+	for x := chartMargin; x <= chartWidth-chartMargin; x++ {
+		img.Set(x, bottom, exportAxis)
+	}
+}
+
+// drawPolyline rasterizes connected segments with a 2px pen.
+func drawPolyline(img *image.RGBA, points [][2]float64, c color.RGBA, dashed bool) {
+	step := 0
+	for i := 1; i < len(points); i++ {
+		x0, y0 := points[i-1][0], points[i-1][1]
+		x1, y1 := points[i][0], points[i][1]
+		steps := int(math.Max(math.Abs(x1-x0), math.Abs(y1-y0)))
+		for s := 0; s <= steps; s++ {
+			step++
+			if dashed && (step/6)%2 == 1 {
+				continue
+			}
+			t := 0.0
+			if steps > 0 {
+				t = float64(s) / float64(steps)
+			}
+			x := int(math.Round(x0 + (x1-x0)*t))
+			y := int(math.Round(y0 + (y1-y0)*t))
+			img.Set(x, y, c)
+			img.Set(x+1, y, c)
+			img.Set(x, y+1, c)
+		}
+	}
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		return fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return nil
+}
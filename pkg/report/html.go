@@ -0,0 +1,139 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+
+	"opentask/pkg/models"
+)
+
+// Stat is a labelled count shown in the summary of an HTML report.
+type Stat struct {
+	Label string `json:"label"`
+	Value int    `json:"value"`
+}
+
+// HTMLReport is a self-contained, read-only report page.
+type HTMLReport struct {
+	Title       string
+	Scope       string
+	GeneratedAt time.Time
+	Stats       []Stat
+	// Chart is an inline SVG document, for example from BurndownSVG.
+	Chart template.HTML
+	Tasks []*models.Task
+}
+
+// Summarize counts tasks by status and how many open tasks are overdue.
+func Summarize(tasks []*models.Task, now time.Time) []Stat {
+	counts := make(map[models.TaskStatus]int)
+	overdue := 0
+	for _, task := range tasks {
+		counts[task.Status]++
+		if isOverdue(task, now) {
+			overdue++
+		}
+	}
+
+	return []Stat{
+		{Label: "Tasks", Value: len(tasks)},
+		{Label: "Open", Value: counts[models.StatusOpen]},
+		{Label: "In progress", Value: counts[models.StatusInProgress]},
+		{Label: "Done", Value: counts[models.StatusDone]},
+		{Label: "Cancelled", Value: counts[models.StatusCancelled]},
+		{Label: "Overdue", Value: overdue},
+	}
+}
+
+func isOverdue(task *models.Task, now time.Time) bool {
+	return task.DueDate != nil && !task.Status.IsClosed() && task.DueDate.Before(truncateDay(now))
+}
+
+// RenderHTML writes r as a standalone HTML page with inline styles, so it
+// can be opened from disk or served from any static host.
+func RenderHTML(w io.Writer, r *HTMLReport) error {
+	if err := htmlTemplate.Execute(w, r); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return nil
+}
+
+var htmlFuncs = template.FuncMap{
+	"date": func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(dateLayout)
+	},
+	"timestamp": func(t time.Time) string {
+		return t.Local().Format("2006-01-02 15:04 MST")
+	},
+	"assignee": func(task *models.Task) string {
+		if task.Assignee == nil {
+			return ""
+		}
+		return task.Assignee.Name
+	},
+}
+
+// htmlStyle is shared by every generated page.
+const htmlStyle = `
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 2rem auto; max-width: 1100px; color: #222; padding: 0 1rem; }
+h1 { margin-bottom: 0.2rem; }
+h2 { margin-top: 2rem; }
+.meta { color: #777; margin-bottom: 1.5rem; }
+.stats { display: flex; flex-wrap: wrap; gap: 1rem; }
+.stat { border: 1px solid #ddd; border-radius: 6px; padding: 0.8rem 1.2rem; min-width: 110px; }
+.stat .value { font-size: 1.8rem; font-weight: bold; }
+.stat .label { color: #777; }
+.chart svg { max-width: 100%; height: auto; }
+table { border-collapse: collapse; width: 100%; margin-top: 1rem; }
+th, td { text-align: left; padding: 0.4rem 0.6rem; border-bottom: 1px solid #eee; }
+th { background: #f6f6f6; }
+.status { border-radius: 4px; padding: 0.1rem 0.4rem; font-size: 0.85rem; }
+.status-open { background: #e3f0fb; }
+.status-in_progress { background: #fff1dc; }
+.status-done { background: #e2f5e2; }
+.status-cancelled { background: #eee; color: #777; }
+footer { margin-top: 2rem; color: #999; font-size: 0.85rem; }
+`
+
+var htmlTemplate = template.Must(template.New("report").Funcs(htmlFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>` + htmlStyle + `</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="meta">{{.Scope}} · generated {{timestamp .GeneratedAt}}</div>
+<div class="stats">
+{{- range .Stats}}
+<div class="stat"><div class="value">{{.Value}}</div><div class="label">{{.Label}}</div></div>
+{{- end}}
+</div>
+{{- if .Chart}}
+<h2>Burndown</h2>
+<div class="chart">{{.Chart}}</div>
+{{- end}}
+<h2>Tasks</h2>
+{{- if .Tasks}}
+<table>
+<thead><tr><th>ID</th><th>Title</th><th>Status</th><th>Priority</th><th>Assignee</th><th>Due</th></tr></thead>
+<tbody>
+{{- range .Tasks}}
+<tr><td>{{.ID}}</td><td>{{.Title}}</td><td><span class="status status-{{.Status}}">{{.Status}}</span></td><td>{{.Priority}}</td><td>{{assignee .}}</td><td>{{date .DueDate}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No tasks.</p>
+{{- end}}
+<footer>Generated by OpenTask. This is a read-only snapshot.</footer>
+</body>
+</html>
+`))
@@ -0,0 +1,193 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+	"opentask/pkg/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(s string) time.Time {
+	t, _ := time.ParseInLocation(dateLayout, s, time.Local)
+	return t
+}
+
+func TestResolveSprint(t *testing.T) {
+	cfg := config.Reports{SprintStart: "2026-01-05", SprintLength: 14}
+	now := day("2026-01-21")
+
+	tests := []struct {
+		name      string
+		spec      string
+		start     string
+		end       string
+		expectErr bool
+	}{
+		{name: "current", spec: "current", start: "2026-01-19", end: "2026-02-01"},
+		{name: "previous", spec: "previous", start: "2026-01-05", end: "2026-01-18"},
+		{name: "next", spec: "next", start: "2026-02-02", end: "2026-02-15"},
+		{name: "explicit range", spec: "2026-03-01..2026-03-10", start: "2026-03-01", end: "2026-03-10"},
+		{name: "reversed range", spec: "2026-03-10..2026-03-01", expectErr: true},
+		{name: "unknown", spec: "someday", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window, err := ResolveSprint(tt.spec, cfg, now)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, day(tt.start), window.Start)
+			assert.Equal(t, day(tt.end), window.End)
+		})
+	}
+}
+
+func TestBurndownCarriesForwardMissingDays(t *testing.T) {
+	snapshot := func(d string, statuses ...models.TaskStatus) *store.Snapshot {
+		s := &store.Snapshot{TakenAt: day(d).Add(12 * time.Hour)}
+		for _, status := range statuses {
+			s.Tasks = append(s.Tasks, &models.Task{Status: status, Platform: models.PlatformJira})
+		}
+		return s
+	}
+
+	snapshots := []*store.Snapshot{
+		snapshot("2026-01-05", models.StatusOpen, models.StatusOpen, models.StatusInProgress),
+		snapshot("2026-01-07", models.StatusDone, models.StatusOpen, models.StatusCancelled),
+	}
+	window := Window{Start: day("2026-01-05"), End: day("2026-01-08")}
+
+	points := Burndown(snapshots, window, nil, day("2026-01-08"))
+
+	require.Len(t, points, 4)
+	assert.Equal(t, []int{3, 3, 1, 1}, []int{points[0].Remaining, points[1].Remaining, points[2].Remaining, points[3].Remaining})
+	assert.True(t, points[0].Known)
+	assert.False(t, points[1].Known)
+	assert.InDelta(t, 3.0, points[0].Ideal, 0.001)
+	assert.InDelta(t, 0.0, points[3].Ideal, 0.001)
+
+	flow := CumulativeFlow(snapshots, window, nil, day("2026-01-08"))
+	require.Len(t, flow, 4)
+	assert.Equal(t, 1, flow[2].Counts[models.StatusDone])
+	assert.Equal(t, 3, flow[3].Total())
+}
+
+func TestRenderHTML(t *testing.T) {
+	now := day("2026-01-10")
+	due := day("2026-01-05")
+	tasks := []*models.Task{
+		{ID: "T-1", Title: "Fix <login>", Status: models.StatusOpen, DueDate: &due},
+		{ID: "T-2", Title: "Ship", Status: models.StatusDone, DueDate: &due},
+	}
+
+	stats := Summarize(tasks, now)
+	assert.Contains(t, stats, Stat{Label: "Overdue", Value: 1})
+	assert.Contains(t, stats, Stat{Label: "Done", Value: 1})
+
+	var b strings.Builder
+	require.NoError(t, RenderHTML(&b, &HTMLReport{Title: "Weekly", GeneratedAt: now, Stats: stats, Tasks: tasks}))
+
+	html := b.String()
+	assert.Contains(t, html, "<title>Weekly</title>")
+	assert.Contains(t, html, "Fix &lt;login&gt;")
+	assert.Contains(t, html, "2026-01-05")
+	assert.NotContains(t, html, `class="chart"`)
+}
+
+func TestBuildDashboard(t *testing.T) {
+	now := day("2026-01-10")
+	due := day("2026-01-05")
+	tasks := []*models.Task{
+		{ID: "A-1", ProjectID: "API", Status: models.StatusOpen, DueDate: &due},
+		{ID: "A-2", ProjectID: "API", Status: models.StatusInProgress},
+		{ID: "W-1", ProjectID: "WEB", Status: models.StatusOpen},
+		{ID: "A-3", ProjectID: "API", Status: models.StatusDone, UpdatedAt: day("2026-01-08")},
+		{ID: "A-4", ProjectID: "API", Status: models.StatusDone, UpdatedAt: day("2025-12-01")},
+	}
+
+	d := BuildDashboard("Team", tasks, now, now, 7)
+
+	require.Len(t, d.Projects, 2)
+	assert.Equal(t, ProjectCounts{Project: "API", Open: 1, InProgress: 1, Overdue: 1}, d.Projects[0])
+	assert.Equal(t, "WEB", d.Projects[1].Project)
+
+	require.Len(t, d.Overdue, 1)
+	assert.Equal(t, "A-1", d.Overdue[0].ID)
+	require.Len(t, d.Completed, 1)
+	assert.Equal(t, "A-3", d.Completed[0].ID)
+
+	var b strings.Builder
+	require.NoError(t, RenderDashboard(&b, d))
+	assert.Contains(t, b.String(), "Completed in the last 7 days")
+}
+
+func TestBuildDigest(t *testing.T) {
+	from, to := day("2026-01-05"), day("2026-01-12")
+	due := day("2026-01-09")
+	tasks := map[string][]*models.Task{
+		"jira": {
+			{ID: "A-2", Title: "New bug", ProjectID: "API", Status: models.StatusOpen, CreatedAt: day("2026-01-06"), UpdatedAt: day("2026-01-06")},
+			{ID: "A-1", Title: "Login", ProjectID: "API", Status: models.StatusDone, CreatedAt: day("2025-12-01"), UpdatedAt: day("2026-01-07")},
+			{ID: "A-3", Title: "Docs", ProjectID: "API", Status: models.StatusOpen, CreatedAt: day("2025-11-01"), UpdatedAt: day("2025-11-20"), DueDate: &due},
+			{ID: "A-4", Title: "Old done", ProjectID: "API", Status: models.StatusDone, CreatedAt: day("2025-11-01"), UpdatedAt: day("2025-11-02")},
+		},
+		"linear": {
+			{ID: "ENG-1", Title: "Quiet", ProjectID: "ENG", Status: models.StatusInProgress, CreatedAt: day("2026-01-01"), UpdatedAt: day("2026-01-10")},
+		},
+	}
+
+	d := BuildDigest("Weekly digest", tasks, from, to, 14)
+
+	require.Len(t, d.Groups, 1)
+	group := d.Groups[0]
+	assert.Equal(t, "jira", group.Platform)
+	assert.Equal(t, "API", group.Project)
+	ids := func(tasks []*models.Task) []string {
+		var ids []string
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"A-2"}, ids(group.Created))
+	assert.Equal(t, []string{"A-1"}, ids(group.Closed))
+	assert.Equal(t, []string{"A-3"}, ids(group.Overdue))
+	assert.Equal(t, []string{"A-3"}, ids(group.Stale))
+
+	var b strings.Builder
+	require.NoError(t, RenderDigestMarkdown(&b, d))
+	assert.Contains(t, b.String(), "# Weekly digest\n\n_2026-01-05 – 2026-01-12_\n")
+	assert.Contains(t, b.String(), "## jira / API\n\n**1 created · 1 closed · 1 overdue · 1 stale**\n")
+	assert.Contains(t, b.String(), "### Overdue\n\n- A-3 Docs (due 2026-01-09)\n")
+	assert.Contains(t, b.String(), "### Stale (no update in 14 days)\n\n- A-3 Docs (updated 2025-11-20)\n")
+
+	b.Reset()
+	require.NoError(t, RenderDigestMarkdown(&b, BuildDigest("Daily digest", nil, from, to, 14)))
+	assert.Contains(t, b.String(), "Nothing was created or closed")
+}
+
+func TestStale(t *testing.T) {
+	before := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tasks := []*models.Task{
+		{ID: "A-1", Status: models.StatusOpen, UpdatedAt: before.AddDate(0, 0, -3)},
+		{ID: "A-2", Status: models.StatusInProgress, UpdatedAt: before.AddDate(0, 0, -10)},
+		{ID: "A-3", Status: models.StatusDone, UpdatedAt: before.AddDate(0, 0, -10)},
+		{ID: "A-4", Status: models.StatusOpen, UpdatedAt: before.AddDate(0, 0, 1)},
+		{ID: "A-5", Status: models.StatusOpen},
+	}
+
+	var ids []string
+	for _, task := range Stale(tasks, before) {
+		ids = append(ids, task.ID)
+	}
+	assert.Equal(t, []string{"A-2", "A-1"}, ids)
+}
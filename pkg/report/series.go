@@ -0,0 +1,142 @@
+package report
+
+import (
+	"time"
+
+	"opentask/pkg/models"
+	"opentask/pkg/store"
+)
+
+// FlowStatuses is the stacking order used by cumulative flow charts,
+// bottom band first.
+var FlowStatuses = []models.TaskStatus{
+	models.StatusDone,
+	models.StatusCancelled,
+	models.StatusInProgress,
+	models.StatusOpen,
+}
+
+// BurndownPoint is the remaining work on a single day. Known is false for
+// days without snapshot data, in which case Remaining carries forward the
+// previous known value.
+type BurndownPoint struct {
+	Day       time.Time
+	Remaining int
+	Ideal     float64
+	Known     bool
+}
+
+// FlowPoint holds task counts per status for a single day.
+type FlowPoint struct {
+	Day    time.Time
+	Counts map[models.TaskStatus]int
+	Known  bool
+}
+
+func (p FlowPoint) Total() int {
+	total := 0
+	for _, count := range p.Counts {
+		total += count
+	}
+	return total
+}
+
+// Burndown computes remaining open work for each day of the window from
+// snapshot history. Only tasks matching filter are counted.
+func Burndown(snapshots []*store.Snapshot, window Window, filter *models.TaskFilter, now time.Time) []BurndownPoint {
+	byDay := indexByDay(snapshots)
+	days := window.Days()
+	today := truncateDay(now)
+
+	points := make([]BurndownPoint, 0, len(days))
+	last, haveLast := 0, false
+	for _, day := range days {
+		if day.After(today) {
+			break
+		}
+
+		point := BurndownPoint{Day: day}
+		if snapshot, ok := byDay[day]; ok {
+			last, haveLast = countRemaining(snapshot, filter), true
+			point.Known = true
+		}
+		if !haveLast {
+			continue
+		}
+		point.Remaining = last
+		points = append(points, point)
+	}
+
+	if len(points) == 0 {
+		return points
+	}
+
+	// Ideal line runs from the first observed value to zero at window end.
+	start := float64(points[0].Remaining)
+	span := window.End.Sub(points[0].Day).Hours() / 24
+	for i := range points {
+		if span <= 0 {
+			points[i].Ideal = 0
+			continue
+		}
+		elapsed := points[i].Day.Sub(points[0].Day).Hours() / 24
+		points[i].Ideal = start * (1 - elapsed/span)
+	}
+
+	return points
+}
+
+// CumulativeFlow computes per-status task counts for each day of the window.
+func CumulativeFlow(snapshots []*store.Snapshot, window Window, filter *models.TaskFilter, now time.Time) []FlowPoint {
+	byDay := indexByDay(snapshots)
+	today := truncateDay(now)
+
+	var points []FlowPoint
+	var last map[models.TaskStatus]int
+	for _, day := range window.Days() {
+		if day.After(today) {
+			break
+		}
+
+		point := FlowPoint{Day: day}
+		if snapshot, ok := byDay[day]; ok {
+			last = countByStatus(snapshot, filter)
+			point.Known = true
+		}
+		if last == nil {
+			continue
+		}
+		point.Counts = last
+		points = append(points, point)
+	}
+
+	return points
+}
+
+func indexByDay(snapshots []*store.Snapshot) map[time.Time]*store.Snapshot {
+	byDay := make(map[time.Time]*store.Snapshot, len(snapshots))
+	for _, snapshot := range snapshots {
+		byDay[snapshot.Day()] = snapshot
+	}
+	return byDay
+}
+
+func countRemaining(snapshot *store.Snapshot, filter *models.TaskFilter) int {
+	remaining := 0
+	for _, task := range snapshot.Tasks {
+		if filter.Matches(task) && !task.Status.IsClosed() {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+func countByStatus(snapshot *store.Snapshot, filter *models.TaskFilter) map[models.TaskStatus]int {
+	counts := make(map[models.TaskStatus]int, len(FlowStatuses))
+	for _, task := range snapshot.Tasks {
+		if filter.Matches(task) {
+			counts[task.Status]++
+		}
+	}
+	return counts
+}
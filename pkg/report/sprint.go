@@ -0,0 +1,114 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"opentask/pkg/config"
+)
+
+const (
+	DefaultSprintLength = 14
+	dateLayout          = "2006-01-02"
+)
+
+// defaultSprintAnchor is a Monday used when no sprint_start is configured.
+var defaultSprintAnchor = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.Local)
+
+// Window is an inclusive range of calendar days.
+type Window struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+// Days returns every calendar day in the window.
+func (w Window) Days() []time.Time {
+	var days []time.Time
+	for d := w.Start; !d.After(w.End); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+	return days
+}
+
+func (w Window) String() string {
+	return fmt.Sprintf("%s → %s", w.Start.Format(dateLayout), w.End.Format(dateLayout))
+}
+
+// ResolveSprint turns a sprint spec into a window. Accepted specs are
+// "current", "previous", "next", or an explicit "YYYY-MM-DD..YYYY-MM-DD" range.
+func ResolveSprint(spec string, cfg config.Reports, now time.Time) (Window, error) {
+	if from, to, ok := strings.Cut(spec, ".."); ok {
+		start, err := time.ParseInLocation(dateLayout, from, time.Local)
+		if err != nil {
+			return Window{}, fmt.Errorf("invalid sprint start %q: %w", from, err)
+		}
+		end, err := time.ParseInLocation(dateLayout, to, time.Local)
+		if err != nil {
+			return Window{}, fmt.Errorf("invalid sprint end %q: %w", to, err)
+		}
+		if end.Before(start) {
+			return Window{}, fmt.Errorf("sprint end %s is before start %s", to, from)
+		}
+		return Window{Name: "custom", Start: start, End: end}, nil
+	}
+
+	length := cfg.SprintLength
+	if length <= 0 {
+		length = DefaultSprintLength
+	}
+
+	anchor := defaultSprintAnchor
+	if cfg.SprintStart != "" {
+		parsed, err := time.ParseInLocation(dateLayout, cfg.SprintStart, time.Local)
+		if err != nil {
+			return Window{}, fmt.Errorf("invalid reports.sprint_start %q: %w", cfg.SprintStart, err)
+		}
+		anchor = parsed
+	}
+
+	offset := 0
+	switch spec {
+	case "", "current":
+	case "previous", "last":
+		offset = -1
+	case "next":
+		offset = 1
+	default:
+		return Window{}, fmt.Errorf("unknown sprint %q. Use current, previous, next or YYYY-MM-DD..YYYY-MM-DD", spec)
+	}
+
+	today := truncateDay(now)
+	elapsed := int(today.Sub(anchor).Hours() / 24)
+	index := elapsed / length
+	if elapsed < 0 && elapsed%length != 0 {
+		index--
+	}
+	index += offset
+
+	start := anchor.AddDate(0, 0, index*length)
+	end := start.AddDate(0, 0, length-1)
+
+	name := spec
+	if name == "" {
+		name = "current"
+	}
+
+	return Window{Name: name, Start: start, End: end}, nil
+}
+
+// LastDays returns a window covering the n days ending today.
+func LastDays(n int, now time.Time) Window {
+	end := truncateDay(now)
+	return Window{
+		Name:  fmt.Sprintf("last %d days", n),
+		Start: end.AddDate(0, 0, -(n - 1)),
+		End:   end,
+	}
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Local().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,32 @@
+package report
+
+import (
+	"sort"
+	"time"
+
+	"opentask/pkg/models"
+)
+
+// IsStale reports whether task is open and was last updated before before.
+// Tasks without an update time are never stale.
+func IsStale(task *models.Task, before time.Time) bool {
+	return !task.Status.IsClosed() && !task.UpdatedAt.IsZero() && task.UpdatedAt.Before(before)
+}
+
+// Stale returns the stale tasks among tasks, least recently updated first.
+func Stale(tasks []*models.Task, before time.Time) []*models.Task {
+	var stale []*models.Task
+	for _, task := range tasks {
+		if IsStale(task, before) {
+			stale = append(stale, task)
+		}
+	}
+	sortByUpdated(stale)
+	return stale
+}
+
+func sortByUpdated(tasks []*models.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].UpdatedAt.Before(tasks[j].UpdatedAt)
+	})
+}
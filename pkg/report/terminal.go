@@ -0,0 +1,123 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"opentask/pkg/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const labelWidth = 14
+
+// RenderBurndown draws a horizontal bar chart of remaining work per day with
+// the ideal burn marked by a dotted guide.
+func RenderBurndown(title string, points []BurndownPoint, width int) string {
+	theme := styles.Current()
+	actualStyle := lipgloss.NewStyle().Foreground(theme.Info)
+	idealStyle := lipgloss.NewStyle().Foreground(theme.Error)
+
+	var b strings.Builder
+	b.WriteString(styles.Title().Render(title) + "\n\n")
+
+	if len(points) == 0 {
+		b.WriteString("No snapshot data in this window. Run 'opentask report snapshot' daily to build history.\n")
+		return b.String()
+	}
+
+	barWidth := max(width-labelWidth-8, 10)
+
+	peak := 0.0
+	for _, p := range points {
+		peak = math.Max(peak, math.Max(float64(p.Remaining), p.Ideal))
+	}
+	if peak == 0 {
+		peak = 1
+	}
+
+	for _, p := range points {
+		filled := int(math.Round(float64(p.Remaining) / peak * float64(barWidth)))
+		ideal := int(math.Round(p.Ideal / peak * float64(barWidth)))
+		ideal = min(ideal, barWidth-1)
+
+		var bar strings.Builder
+		for i := 0; i < barWidth; i++ {
+			switch {
+			case i == ideal:
+				bar.WriteString(idealStyle.Render("┆"))
+			case i < filled:
+				bar.WriteString(actualStyle.Render("█"))
+			default:
+				bar.WriteString(" ")
+			}
+		}
+
+		day := p.Day.Format("Mon 01/02")
+		if !p.Known {
+			day = styles.Help().Render(fmt.Sprintf("%-*s", labelWidth-1, day+" ~"))
+		} else {
+			day = fmt.Sprintf("%-*s", labelWidth-1, day)
+		}
+
+		fmt.Fprintf(&b, "%s %s %3d\n", day, bar.String(), p.Remaining)
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.Help().Render(fmt.Sprintf("%s remaining  %s ideal  ~ no snapshot (carried forward)",
+		actualStyle.Render("█"), idealStyle.Render("┆"))))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// RenderCumulativeFlow draws one stacked bar per day, scaled to the largest
+// daily total, with bands ordered as in FlowStatuses.
+func RenderCumulativeFlow(title string, points []FlowPoint, width int) string {
+	theme := styles.Current()
+
+	var b strings.Builder
+	b.WriteString(styles.Title().Render(title) + "\n\n")
+
+	if len(points) == 0 {
+		b.WriteString("No snapshot data in this window. Run 'opentask report snapshot' daily to build history.\n")
+		return b.String()
+	}
+
+	barWidth := max(width-labelWidth-8, 10)
+
+	peak := 1
+	for _, p := range points {
+		peak = max(peak, p.Total())
+	}
+
+	for _, p := range points {
+		var bar strings.Builder
+		used := 0
+		for _, status := range FlowStatuses {
+			cells := int(math.Round(float64(p.Counts[status]) / float64(peak) * float64(barWidth)))
+			cells = min(cells, barWidth-used)
+			bar.WriteString(lipgloss.NewStyle().Foreground(theme.StatusColor(status)).Render(strings.Repeat("█", cells)))
+			used += cells
+		}
+		bar.WriteString(strings.Repeat(" ", barWidth-used))
+
+		day := fmt.Sprintf("%-*s", labelWidth-1, p.Day.Format("Mon 01/02"))
+		if !p.Known {
+			day = styles.Help().Render(fmt.Sprintf("%-*s", labelWidth-1, p.Day.Format("Mon 01/02")+" ~"))
+		}
+
+		fmt.Fprintf(&b, "%s %s %3d\n", day, bar.String(), p.Total())
+	}
+
+	b.WriteString("\n")
+	var legend []string
+	for _, status := range FlowStatuses {
+		legend = append(legend, lipgloss.NewStyle().Foreground(theme.StatusColor(status)).Render("█")+" "+status.String())
+	}
+	b.WriteString(styles.Help().Render(strings.Join(legend, "  ")))
+	b.WriteString("\n")
+
+	return b.String()
+}
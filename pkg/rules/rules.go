@@ -0,0 +1,351 @@
+// Package rules escalates tasks that stay open too long, so old tickets
+// don't rot silently. Aging rules raise a task's priority, label it or
+// comment on it once it has been open past a threshold. Each rule acts on
+// a task once; a Ledger remembers where it has.
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+)
+
+// Rule escalates the open tasks it matches once they are older than After.
+type Rule struct {
+	Name  string
+	After time.Duration
+
+	Platform   string
+	Project    string
+	Labels     []string
+	Priorities []models.Priority
+
+	BumpPriority bool
+	AddLabel     string
+	Comment      *template.Template
+}
+
+// Ledger remembers the tasks each rule has acted on.
+type Ledger interface {
+	WasApplied(rule, task string) bool
+	MarkApplied(rule, task string, at time.Time)
+}
+
+// Action is what the rules due on a task do to it.
+type Action struct {
+	Task  *models.Task
+	Rules []string
+	// Priority is the task's new priority, or empty to keep it.
+	Priority models.Priority
+	Labels   []string
+	Comments []string
+}
+
+// Result is the outcome of applying an Action.
+type Result struct {
+	Action Action
+	Err    error
+}
+
+// priorities are the priorities BumpPriority steps through.
+var priorities = []models.Priority{models.PriorityLow, models.PriorityMedium, models.PriorityHigh, models.PriorityUrgent}
+
+// FromConfig builds the rules configured under rules.aging.
+func FromConfig(cfg config.Rules) ([]Rule, error) {
+	rules := make([]Rule, 0, len(cfg.Aging))
+	seen := make(map[string]bool)
+	for i, c := range cfg.Aging {
+		if c.Name == "" {
+			return nil, fmt.Errorf("aging rule %d has no name", i+1)
+		}
+		if seen[c.Name] {
+			return nil, fmt.Errorf("aging rule %q is defined twice", c.Name)
+		}
+		seen[c.Name] = true
+
+		after, err := ParseAge(c.After)
+		if err != nil {
+			return nil, fmt.Errorf("aging rule %q: %w", c.Name, err)
+		}
+		if !c.BumpPriority && c.AddLabel == "" && c.Comment == "" {
+			return nil, fmt.Errorf("aging rule %q does nothing; set bump_priority, add_label or comment", c.Name)
+		}
+
+		rule := Rule{
+			Name:         c.Name,
+			After:        after,
+			Platform:     c.Platform,
+			Project:      c.Project,
+			Labels:       c.Labels,
+			BumpPriority: c.BumpPriority,
+			AddLabel:     c.AddLabel,
+		}
+		for _, p := range c.Priorities {
+			priority := models.Priority(strings.ToLower(p))
+			if !priority.IsValid() {
+				return nil, fmt.Errorf("aging rule %q: unknown priority %q", c.Name, p)
+			}
+			rule.Priorities = append(rule.Priorities, priority)
+		}
+		if c.Comment != "" {
+			rule.Comment, err = template.New(c.Name).Option("missingkey=error").Parse(c.Comment)
+			if err != nil {
+				return nil, fmt.Errorf("aging rule %q: invalid comment: %w", c.Name, err)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// ParseAge parses a duration such as "72h", with "d" for days and "w" for
+// weeks as well.
+func ParseAge(s string) (time.Duration, error) {
+	var unit time.Duration
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+
+	var age time.Duration
+	if unit != 0 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid age %q", s)
+		}
+		age = time.Duration(n) * unit
+	} else {
+		var err error
+		if age, err = time.ParseDuration(s); err != nil {
+			return 0, fmt.Errorf("invalid age %q; use a duration such as 14d, 2w or 72h", s)
+		}
+	}
+	if age <= 0 {
+		return 0, fmt.Errorf("age %q must be positive", s)
+	}
+	return age, nil
+}
+
+// TaskKey identifies a task across platforms in a Ledger.
+func TaskKey(task *models.Task) string {
+	return task.Ref()
+}
+
+// Matches reports whether the rule applies to task at now: the task is
+// open, older than After and matches the rule's filters.
+func (r *Rule) Matches(task *models.Task, now time.Time) bool {
+	if task.Status.IsClosed() || task.CreatedAt.IsZero() || now.Sub(task.CreatedAt) < r.After {
+		return false
+	}
+	if r.Platform != "" && !strings.EqualFold(r.Platform, string(task.Platform)) {
+		return false
+	}
+	if r.Project != "" && !strings.EqualFold(r.Project, task.ProjectID) {
+		return false
+	}
+	for _, label := range r.Labels {
+		if !task.HasLabel(label) {
+			return false
+		}
+	}
+	if len(r.Priorities) == 0 {
+		return true
+	}
+	for _, priority := range r.Priorities {
+		if task.Priority == priority {
+			return true
+		}
+	}
+	return false
+}
+
+// Plan returns what the rules that are due and have not acted yet do to
+// each task. Rules are applied in order, so two rules due on a task at once
+// both raise its priority.
+func Plan(rules []Rule, tasks []*models.Task, ledger Ledger, now time.Time) ([]Action, error) {
+	var actions []Action
+	for _, task := range tasks {
+		action := Action{Task: task}
+		priority := task.Priority
+		for i := range rules {
+			rule := &rules[i]
+			if ledger.WasApplied(rule.Name, TaskKey(task)) || !rule.Matches(task, now) {
+				continue
+			}
+			action.Rules = append(action.Rules, rule.Name)
+
+			if rule.BumpPriority {
+				priority = bump(priority)
+			}
+			if rule.AddLabel != "" && !task.HasLabel(rule.AddLabel) && !containsFold(action.Labels, rule.AddLabel) {
+				action.Labels = append(action.Labels, rule.AddLabel)
+			}
+			if rule.Comment != nil {
+				body, err := comment(rule, task, now)
+				if err != nil {
+					return nil, err
+				}
+				action.Comments = append(action.Comments, body)
+			}
+		}
+		if len(action.Rules) == 0 {
+			continue
+		}
+		if priority != task.Priority {
+			action.Priority = priority
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+// Apply carries out actions, each with its task's platform client, and
+// records the rules that acted in ledger. Actions with comments fail on
+// platforms that cannot add them.
+func Apply(ctx context.Context, svc *service.Service, actions []Action, ledger Ledger, now time.Time) []Result {
+	results := make([]Result, 0, len(actions))
+	for _, action := range actions {
+		err := apply(ctx, svc, action)
+		if err == nil {
+			for _, rule := range action.Rules {
+				ledger.MarkApplied(rule, TaskKey(action.Task), now)
+			}
+		}
+		results = append(results, Result{Action: action, Err: err})
+	}
+	return results
+}
+
+func apply(ctx context.Context, svc *service.Service, action Action) error {
+	client, err := svc.Client(string(action.Task.Platform))
+	if err != nil {
+		return err
+	}
+
+	var commenter platforms.Commenter
+	if len(action.Comments) > 0 {
+		var ok bool
+		if commenter, ok = client.(platforms.Commenter); !ok {
+			return fmt.Errorf("%s cannot add comments", action.Task.Platform)
+		}
+	}
+
+	ctx, cancel := service.WithRequestTimeout(ctx)
+	defer cancel()
+
+	if updated := action.Updated(); updated != nil {
+		if _, err := client.UpdateTask(ctx, updated); err != nil {
+			return err
+		}
+	}
+
+	for _, comment := range action.Comments {
+		if err := commenter.AddComment(ctx, action.Task.ID, comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Updated returns the task with the action's priority and labels, or nil
+// if the action only comments on it.
+func (a Action) Updated() *models.Task {
+	if a.Priority == "" && len(a.Labels) == 0 {
+		return nil
+	}
+	updated := *a.Task
+	updated.Labels = append([]string(nil), a.Task.Labels...)
+	if a.Priority != "" {
+		updated.SetPriority(a.Priority)
+	}
+	for _, label := range a.Labels {
+		updated.AddLabel(label)
+	}
+	return &updated
+}
+
+// Audit describes the tasks results updated for the audit log. Actions
+// that only comment are left out.
+func Audit(results []Result) []store.AuditTask {
+	var audit []store.AuditTask
+	for _, result := range results {
+		if updated := result.Action.Updated(); updated != nil {
+			audit = append(audit, store.NewAuditUpdate(result.Action.Task, updated, result.Err))
+		}
+	}
+	return audit
+}
+
+// Describe summarizes what the action does, such as "priority medium →
+// high, label aging, 1 comment".
+func (a Action) Describe() string {
+	var parts []string
+	if a.Priority != "" {
+		from := a.Task.Priority
+		if from == "" {
+			from = "none"
+		}
+		parts = append(parts, fmt.Sprintf("priority %s → %s", from, a.Priority))
+	}
+	if len(a.Labels) > 0 {
+		parts = append(parts, "label "+strings.Join(a.Labels, ", "))
+	}
+	switch n := len(a.Comments); n {
+	case 0:
+	case 1:
+		parts = append(parts, "1 comment")
+	default:
+		parts = append(parts, fmt.Sprintf("%d comments", n))
+	}
+	if len(parts) == 0 {
+		return "nothing to change"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// bump returns the priority one step above p. Tasks without a priority
+// count as low.
+func bump(p models.Priority) models.Priority {
+	for i, priority := range priorities {
+		if priority == p && i+1 < len(priorities) {
+			return priorities[i+1]
+		}
+	}
+	if p == "" {
+		return models.PriorityMedium
+	}
+	return p
+}
+
+func comment(rule *Rule, task *models.Task, now time.Time) (string, error) {
+	var b strings.Builder
+	err := rule.Comment.Execute(&b, map[string]any{
+		"Days":  int(now.Sub(task.CreatedAt).Hours() / 24),
+		"ID":    task.ID,
+		"Title": task.Title,
+	})
+	if err != nil {
+		return "", fmt.Errorf("aging rule %q: failed to write comment: %w", rule.Name, err)
+	}
+	return b.String(), nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+package rules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient records the updates and comments it is sent.
+type fakeClient struct {
+	platforms.PlatformClient
+
+	updated  []*models.Task
+	comments map[string][]string
+}
+
+func (c *fakeClient) UpdateTask(ctx context.Context, task *models.Task) (*models.Task, error) {
+	c.updated = append(c.updated, task)
+	return task, nil
+}
+
+func (c *fakeClient) AddComment(ctx context.Context, taskID, body string) error {
+	c.comments[taskID] = append(c.comments[taskID], body)
+	return nil
+}
+
+type fakeFactory struct {
+	client *fakeClient
+}
+
+func (f *fakeFactory) Create(settings map[string]any) (platforms.PlatformClient, error) {
+	return f.client, nil
+}
+
+func (f *fakeFactory) GetType() string                              { return "jira" }
+func (f *fakeFactory) GetName() string                              { return "Fake" }
+func (f *fakeFactory) ValidateConfig(settings map[string]any) error { return nil }
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "14d", want: 14 * 24 * time.Hour},
+		{input: "2w", want: 14 * 24 * time.Hour},
+		{input: "72h", want: 72 * time.Hour},
+		{input: "0d", wantErr: true},
+		{input: "xd", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAge(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFromConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []config.AgingRule
+		wantErr string
+	}{
+		{
+			name:    "no name",
+			rules:   []config.AgingRule{{After: "14d", BumpPriority: true}},
+			wantErr: "has no name",
+		},
+		{
+			name:    "does nothing",
+			rules:   []config.AgingRule{{Name: "stale", After: "14d"}},
+			wantErr: "does nothing",
+		},
+		{
+			name:    "unknown priority",
+			rules:   []config.AgingRule{{Name: "stale", After: "14d", BumpPriority: true, Priorities: []string{"p1"}}},
+			wantErr: `unknown priority "p1"`,
+		},
+		{
+			name: "duplicate",
+			rules: []config.AgingRule{
+				{Name: "stale", After: "14d", BumpPriority: true},
+				{Name: "stale", After: "30d", AddLabel: "aging"},
+			},
+			wantErr: "defined twice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromConfig(config.Rules{Aging: tt.rules})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ruleSet, err := FromConfig(config.Rules{Aging: []config.AgingRule{
+		{Name: "two-weeks", After: "14d", BumpPriority: true, AddLabel: "aging"},
+		{Name: "old-bugs", After: "30d", Labels: []string{"bug"}, BumpPriority: true, Comment: "Open for {{.Days}} days."},
+	}})
+	require.NoError(t, err)
+
+	tasks := []*models.Task{
+		{ID: "TEST-1", Platform: models.PlatformJira, Status: models.StatusOpen, Priority: models.PriorityMedium, CreatedAt: now.AddDate(0, 0, -20)},
+		{ID: "TEST-2", Platform: models.PlatformJira, Status: models.StatusInProgress, Priority: models.PriorityMedium, Labels: []string{"bug"}, CreatedAt: now.AddDate(0, 0, -40)},
+		{ID: "TEST-3", Platform: models.PlatformJira, Status: models.StatusDone, CreatedAt: now.AddDate(0, 0, -40)},
+		{ID: "TEST-4", Platform: models.PlatformJira, Status: models.StatusOpen, CreatedAt: now.AddDate(0, 0, -3)},
+		{ID: "TEST-5", Platform: models.PlatformJira, Status: models.StatusOpen, Priority: models.PriorityUrgent, Labels: []string{"Aging"}, CreatedAt: now.AddDate(0, 0, -20)},
+	}
+
+	state := &store.RulesState{}
+	state.MarkApplied("two-weeks", "jira:TEST-2", now.AddDate(0, 0, -10))
+
+	actions, err := Plan(ruleSet, tasks, state, now)
+	require.NoError(t, err)
+	require.Len(t, actions, 3)
+
+	assert.Equal(t, "TEST-1", actions[0].Task.ID)
+	assert.Equal(t, []string{"two-weeks"}, actions[0].Rules)
+	assert.Equal(t, models.PriorityHigh, actions[0].Priority)
+	assert.Equal(t, []string{"aging"}, actions[0].Labels)
+	assert.Equal(t, "priority medium → high, label aging", actions[0].Describe())
+
+	assert.Equal(t, "TEST-2", actions[1].Task.ID)
+	assert.Equal(t, []string{"old-bugs"}, actions[1].Rules)
+	assert.Equal(t, models.PriorityHigh, actions[1].Priority)
+	assert.Equal(t, []string{"Open for 40 days."}, actions[1].Comments)
+
+	// Urgent tasks stay urgent and keep their label.
+	assert.Equal(t, "TEST-5", actions[2].Task.ID)
+	assert.Empty(t, actions[2].Priority)
+	assert.Empty(t, actions[2].Labels)
+	assert.Equal(t, "nothing to change", actions[2].Describe())
+}
+
+func TestApply(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	client := &fakeClient{comments: make(map[string][]string)}
+	registry := platforms.NewRegistry()
+	registry.Register(&fakeFactory{client: client})
+	svc := service.NewWithRegistry(&config.Config{Platforms: map[string]config.Platform{
+		"jira": {Type: "jira", Enabled: true},
+	}}, registry)
+
+	ruleSet, err := FromConfig(config.Rules{Aging: []config.AgingRule{
+		{Name: "stale", After: "14d", BumpPriority: true, AddLabel: "aging", Comment: "{{.ID}} is {{.Days}} days old."},
+	}})
+	require.NoError(t, err)
+
+	task := &models.Task{ID: "TEST-1", Platform: models.PlatformJira, Status: models.StatusOpen, Priority: models.PriorityLow, Labels: []string{"api"}, CreatedAt: now.AddDate(0, 0, -15)}
+	state := &store.RulesState{}
+
+	actions, err := Plan(ruleSet, []*models.Task{task}, state, now)
+	require.NoError(t, err)
+	results := Apply(context.Background(), svc, actions, state, now)
+	require.Len(t, results, 1)
+	require.NoError(t, results[0].Err)
+
+	require.Len(t, client.updated, 1)
+	assert.Equal(t, models.PriorityMedium, client.updated[0].Priority)
+	assert.Equal(t, []string{"api", "aging"}, client.updated[0].Labels)
+	assert.Equal(t, []string{"api"}, task.Labels)
+	assert.Equal(t, []string{"TEST-1 is 15 days old."}, client.comments["TEST-1"])
+
+	assert.Equal(t, []store.AuditTask{{
+		Platform: "jira",
+		ID:       "TEST-1",
+		Status:   models.StatusOpen,
+		Changes: []store.AuditChange{
+			{Field: "priority", From: "low", To: "medium"},
+			{Field: "labels", From: "api", To: "api,aging"},
+		},
+	}}, Audit(results))
+	assert.Empty(t, Audit([]Result{{Action: Action{Task: task, Comments: []string{"ping"}}}}))
+
+	// A rule acts on a task once.
+	assert.True(t, state.WasApplied("stale", "jira:TEST-1"))
+	actions, err = Plan(ruleSet, []*models.Task{task}, state, now.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Empty(t, actions)
+}
@@ -0,0 +1,211 @@
+// Package scan finds TODO and FIXME comments in source files and the task
+// references they carry, such as "// TODO(API-42): retry on timeout".
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxFileSize skips generated and data files that are unlikely to hold
+// hand-written comments.
+const maxFileSize = 2 << 20
+
+// skipDirs are dependency and build directories that are never scanned.
+var skipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+}
+
+var (
+	// commentPattern matches a TODO or FIXME keyword that follows a comment
+	// marker, with an optional parenthesized reference and colon.
+	commentPattern = regexp.MustCompile(`(?://|#|/\*|--|;|\*|<!--)\s*\b(TODO|FIXME)\b(\([^)]*\))?:?[ \t]*(.*)$`)
+
+	// taskIDPattern matches issue keys such as API-42 or ENG-7.
+	taskIDPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]*-[0-9]+$`)
+
+	// leadingIDPattern matches a key written after the keyword, as in
+	// "TODO API-42: retry on timeout".
+	leadingIDPattern = regexp.MustCompile(`^([A-Z][A-Z0-9]*-[0-9]+)\b:?[ \t]*`)
+)
+
+// Item is a TODO or FIXME comment.
+type Item struct {
+	Path    string
+	Line    int
+	Keyword string
+	// TaskID is the referenced task, or empty if the comment is untracked.
+	TaskID string
+	Text   string
+}
+
+// Tracked reports whether the comment references a task.
+func (i Item) Tracked() bool {
+	return i.TaskID != ""
+}
+
+// Location formats the item position as path:line.
+func (i Item) Location() string {
+	return fmt.Sprintf("%s:%d", i.Path, i.Line)
+}
+
+// ParseLine extracts the TODO or FIXME comment on line, if any.
+func ParseLine(line string) (Item, bool) {
+	match := commentPattern.FindStringSubmatch(line)
+	if match == nil {
+		return Item{}, false
+	}
+
+	item := Item{Keyword: match[1]}
+	text := strings.TrimSpace(match[3])
+
+	if ref := strings.Trim(match[2], "()"); taskIDPattern.MatchString(ref) {
+		item.TaskID = ref
+	} else if m := leadingIDPattern.FindStringSubmatch(text); m != nil {
+		item.TaskID = m[1]
+		text = text[len(m[0]):]
+	}
+
+	text = strings.TrimSuffix(text, "-->")
+	text = strings.TrimSuffix(text, "*/")
+	item.Text = strings.TrimSpace(text)
+
+	return item, true
+}
+
+// Reader returns the TODO and FIXME comments read from r, reporting path
+// as their location.
+func Reader(path string, r io.Reader) ([]Item, error) {
+	var items []Item
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxFileSize)
+	for n := 1; scanner.Scan(); n++ {
+		item, ok := ParseLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		item.Path = path
+		item.Line = n
+		items = append(items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return items, nil
+}
+
+// Dir returns the TODO and FIXME comments in the text files under root.
+// Hidden files, dependency directories and binary files are skipped.
+func Dir(root string) ([]Item, error) {
+	var items []Item
+
+	err := filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root && (strings.HasPrefix(entry.Name(), ".") || skipDirs[entry.Name()]) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() > maxFileSize {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if isBinary(data) {
+			return nil
+		}
+
+		found, err := Reader(path, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		items = append(items, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// isBinary treats files with a NUL byte near the start as binary.
+func isBinary(data []byte) bool {
+	if len(data) > 8000 {
+		data = data[:8000]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+// AddReference writes taskID into the untracked comment described by item,
+// turning "TODO: text" into "TODO(ID): text". It fails if the file changed
+// since it was scanned.
+func AddReference(item Item, taskID string) error {
+	info, err := os.Stat(item.Path)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(item.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", item.Path, err)
+	}
+
+	lines := strings.SplitAfter(string(data), "\n")
+	if item.Line < 1 || item.Line > len(lines) {
+		return fmt.Errorf("%s no longer has a line %d", item.Path, item.Line)
+	}
+
+	line := lines[item.Line-1]
+	body := strings.TrimRight(line, "\r\n")
+	ending := line[len(body):]
+	current, ok := ParseLine(body)
+	if !ok || current.Tracked() || current.Keyword != item.Keyword || current.Text != item.Text {
+		return fmt.Errorf("%s changed since it was scanned", item.Location())
+	}
+
+	loc := commentPattern.FindStringSubmatchIndex(body)
+	keywordEnd, refStart, textStart := loc[3], loc[4], loc[6]
+	switch {
+	case refStart < 0:
+		body = body[:keywordEnd] + "(" + taskID + ")" + body[keywordEnd:]
+	case textStart == len(body):
+		body += " " + taskID
+	default:
+		// Keep an existing reference such as an owner name.
+		body = body[:textStart] + taskID + " " + body[textStart:]
+	}
+	lines[item.Line-1] = body + ending
+
+	if err := os.WriteFile(item.Path, []byte(strings.Join(lines, "")), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", item.Path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		want  Item
+		found bool
+	}{
+		{
+			name:  "untracked",
+			line:  "\t// TODO: retry on timeout",
+			want:  Item{Keyword: "TODO", Text: "retry on timeout"},
+			found: true,
+		},
+		{
+			name:  "reference in parentheses",
+			line:  "# FIXME(API-42): handle empty pages",
+			want:  Item{Keyword: "FIXME", TaskID: "API-42", Text: "handle empty pages"},
+			found: true,
+		},
+		{
+			name:  "reference after keyword",
+			line:  "-- TODO ENG-7 drop legacy column",
+			want:  Item{Keyword: "TODO", TaskID: "ENG-7", Text: "drop legacy column"},
+			found: true,
+		},
+		{
+			name:  "owner is not a reference",
+			line:  "// TODO(alice): clean up",
+			want:  Item{Keyword: "TODO", Text: "clean up"},
+			found: true,
+		},
+		{
+			name:  "block comment",
+			line:  "/* TODO: remove */",
+			want:  Item{Keyword: "TODO", Text: "remove"},
+			found: true,
+		},
+		{
+			name: "keyword outside a comment",
+			line: `todos := []string{"TODO"}`,
+		},
+		{
+			name: "part of a word",
+			line: "// TODOS are tracked elsewhere",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, found := ParseLine(tt.line)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.want, item)
+		})
+	}
+}
+
+func TestDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	write("main.go", "package main\n\n// TODO(API-1): wire config\nfunc main() {}\n")
+	write("scripts/deploy.sh", "#!/bin/sh\n# FIXME: use the new host\n")
+	write("node_modules/lib/index.js", "// TODO: ignored\n")
+	write(".git/config", "# TODO: ignored\n")
+	write("logo.png", "\x89PNG\x00// TODO: ignored\n")
+
+	items, err := Dir(dir)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, filepath.Join(dir, "main.go"), items[0].Path)
+	assert.Equal(t, 3, items[0].Line)
+	assert.Equal(t, "API-1", items[0].TaskID)
+
+	assert.Equal(t, filepath.Join(dir, "scripts", "deploy.sh"), items[1].Path)
+	assert.Equal(t, 2, items[1].Line)
+	assert.False(t, items[1].Tracked())
+}
+
+func TestAddReference(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{name: "colon", line: "\t// TODO: retry", expected: "\t// TODO(API-9): retry"},
+		{name: "no colon", line: "# FIXME use the new host", expected: "# FIXME(API-9) use the new host"},
+		{name: "owner", line: "// TODO(alice): clean up", expected: "// TODO(alice): API-9 clean up"},
+		{name: "no text", line: "// TODO", expected: "// TODO(API-9)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "file.txt")
+			require.NoError(t, os.WriteFile(path, []byte("first\r\n"+tt.line+"\r\nlast\n"), 0o600))
+
+			items, err := Dir(filepath.Dir(path))
+			require.NoError(t, err)
+			require.Len(t, items, 1)
+
+			require.NoError(t, AddReference(items[0], "API-9"))
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, "first\r\n"+tt.expected+"\r\nlast\n", string(data))
+
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+			item, ok := ParseLine(tt.expected)
+			require.True(t, ok)
+			assert.Equal(t, "API-9", item.TaskID)
+		})
+	}
+
+	t.Run("file changed", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "file.go")
+		require.NoError(t, os.WriteFile(path, []byte("// TODO: retry\n"), 0o644))
+
+		items, err := Dir(filepath.Dir(path))
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+
+		require.NoError(t, os.WriteFile(path, []byte("// nothing to do\n"), 0o644))
+		assert.Error(t, AddReference(items[0], "API-9"))
+	})
+}
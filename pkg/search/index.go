@@ -0,0 +1,200 @@
+// Package search keeps a local full-text index of tasks from every
+// platform, so tasks can be searched without a round trip to each one.
+package search
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"opentask/pkg/models"
+)
+
+// titleWeight is how much more a term counts in a title than elsewhere.
+const titleWeight = 3
+
+// Index maps terms to the tasks containing them. Tasks are keyed by the
+// configured platform name they came from and their ID; the postings are
+// rebuilt from them when the index is loaded, so only the tasks are stored.
+type Index struct {
+	BuiltAt time.Time `json:"built_at"`
+	// Synced is the latest task update seen from each platform, where the
+	// next incremental update resumes.
+	Synced map[string]time.Time    `json:"synced,omitempty"`
+	Tasks  map[string]*models.Task `json:"tasks"`
+
+	postings map[string]map[string]int
+}
+
+// Result is a task matching a search, with its relevance.
+type Result struct {
+	Task  *models.Task
+	Score float64
+}
+
+// New returns an empty index.
+func New() *Index {
+	return &Index{
+		Synced:   make(map[string]time.Time),
+		Tasks:    make(map[string]*models.Task),
+		postings: make(map[string]map[string]int),
+	}
+}
+
+// UnmarshalJSON restores an index and rebuilds its postings.
+func (i *Index) UnmarshalJSON(data []byte) error {
+	type stored Index
+	var s stored
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	*i = *New()
+	i.BuiltAt = s.BuiltAt
+	for platform, synced := range s.Synced {
+		i.Synced[platform] = synced
+	}
+	for key, task := range s.Tasks {
+		i.add(key, task)
+	}
+	return nil
+}
+
+// Len returns the number of indexed tasks.
+func (i *Index) Len() int {
+	return len(i.Tasks)
+}
+
+// Add indexes a task from the named platform, replacing an earlier version
+// of it, and moves the platform's sync point forward to the task's update
+// time.
+func (i *Index) Add(platform string, task *models.Task) {
+	i.add(platform+"/"+task.ID, task)
+	if task.UpdatedAt.After(i.Synced[platform]) {
+		i.Synced[platform] = task.UpdatedAt
+	}
+}
+
+func (i *Index) add(key string, task *models.Task) {
+	i.remove(key)
+
+	i.Tasks[key] = task
+	for term, count := range terms(task) {
+		if i.postings[term] == nil {
+			i.postings[term] = make(map[string]int)
+		}
+		i.postings[term][key] = count
+	}
+}
+
+// Remove drops a task of the named platform from the index.
+func (i *Index) Remove(platform, id string) {
+	i.remove(platform + "/" + id)
+}
+
+// ResetPlatform drops every task of a platform and its sync point, before
+// the platform is indexed again from scratch.
+func (i *Index) ResetPlatform(platform string) {
+	for key := range i.Tasks {
+		if strings.HasPrefix(key, platform+"/") {
+			i.remove(key)
+		}
+	}
+	delete(i.Synced, platform)
+}
+
+func (i *Index) remove(key string) {
+	task, ok := i.Tasks[key]
+	if !ok {
+		return
+	}
+	for term := range terms(task) {
+		delete(i.postings[term], key)
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+	delete(i.Tasks, key)
+}
+
+// Search returns the tasks containing every word of query, best matches
+// first. The last word also matches as a prefix, so results can follow
+// typing. A limit of 0 returns all matches.
+func (i *Index) Search(query string, limit int) []Result {
+	words := Tokenize(query)
+	if len(words) == 0 {
+		return nil
+	}
+
+	scores := make(map[string]float64)
+	for n, word := range words {
+		matched := make(map[string]float64)
+		for term, docs := range i.postings {
+			if term != word && (n < len(words)-1 || !strings.HasPrefix(term, word)) {
+				continue
+			}
+			idf := math.Log(1 + float64(len(i.Tasks))/float64(len(docs)))
+			for key, count := range docs {
+				matched[key] += float64(count) * idf
+			}
+		}
+
+		if n == 0 {
+			scores = matched
+			continue
+		}
+		for key := range scores {
+			if score, ok := matched[key]; ok {
+				scores[key] += score
+			} else {
+				delete(scores, key)
+			}
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for key, score := range scores {
+		results = append(results, Result{Task: i.Tasks[key], Score: score})
+	}
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		return results[a].Task.UpdatedAt.After(results[b].Task.UpdatedAt)
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// Tokenize splits text into lowercase words of letters and digits.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// terms counts the indexed words of a task. Words of the title and ID
+// count extra.
+func terms(task *models.Task) map[string]int {
+	counts := make(map[string]int)
+	for _, word := range Tokenize(task.Title + " " + task.ID) {
+		counts[word] += titleWeight
+	}
+	for _, text := range append([]string{task.Description}, task.Labels...) {
+		for _, word := range Tokenize(text) {
+			counts[word]++
+		}
+	}
+	if task.Assignee != nil {
+		for _, word := range Tokenize(task.Assignee.Name) {
+			counts[word]++
+		}
+	}
+	return counts
+}
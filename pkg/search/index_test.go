@@ -0,0 +1,108 @@
+package search
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"opentask/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTasks() []*models.Task {
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	return []*models.Task{
+		{ID: "API-42", Title: "Add rate limiting", Description: "Limit requests per token", Platform: models.PlatformJira, UpdatedAt: base},
+		{ID: "API-43", Title: "Document the API", Description: "Mention rate limits in the guide", Platform: models.PlatformJira, UpdatedAt: base.Add(time.Hour)},
+		{ID: "ENG-7", Title: "Fix login redirect", Labels: []string{"auth"}, Platform: models.PlatformLinear, UpdatedAt: base.Add(2 * time.Hour),
+			Assignee: &models.User{Name: "Jane Doe"}},
+	}
+}
+
+func ids(results []Result) []string {
+	var found []string
+	for _, result := range results {
+		found = append(found, result.Task.ID)
+	}
+	return found
+}
+
+func TestIndex_Search(t *testing.T) {
+	index := New()
+	for _, task := range testTasks() {
+		index.Add(string(task.Platform), task)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "title ranks first", query: "rate", want: []string{"API-42", "API-43"}},
+		{name: "every word must match", query: "rate guide", want: []string{"API-43"}},
+		{name: "last word is a prefix", query: "redir", want: []string{"ENG-7"}},
+		{name: "earlier words are whole", query: "redir login", want: nil},
+		{name: "by id", query: "api-42", want: []string{"API-42"}},
+		{name: "by label", query: "auth", want: []string{"ENG-7"}},
+		{name: "by assignee", query: "jane", want: []string{"ENG-7"}},
+		{name: "case insensitive", query: "LOGIN", want: []string{"ENG-7"}},
+		{name: "no match", query: "billing", want: nil},
+		{name: "empty", query: "  ", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ids(index.Search(tt.query, 0)))
+		})
+	}
+
+	assert.Len(t, index.Search("rate", 1), 1)
+}
+
+func TestIndex_Updates(t *testing.T) {
+	index := New()
+	tasks := testTasks()
+	for _, task := range tasks {
+		index.Add(string(task.Platform), task)
+	}
+	assert.Equal(t, tasks[1].UpdatedAt, index.Synced["jira"])
+
+	renamed := *tasks[0]
+	renamed.Title = "Add request quotas"
+	renamed.Description = ""
+	renamed.UpdatedAt = tasks[0].UpdatedAt.Add(24 * time.Hour)
+	index.Add("jira", &renamed)
+
+	assert.Equal(t, 3, index.Len())
+	assert.Equal(t, []string{"API-43"}, ids(index.Search("rate", 0)))
+	assert.Equal(t, []string{"API-42"}, ids(index.Search("quotas", 0)))
+	assert.Equal(t, renamed.UpdatedAt, index.Synced["jira"])
+
+	index.Remove("jira", "API-43")
+	assert.Equal(t, 2, index.Len())
+	assert.Empty(t, index.Search("rate", 0))
+
+	index.ResetPlatform("jira")
+	assert.Equal(t, 1, index.Len())
+	assert.Empty(t, index.Search("quotas", 0))
+	_, ok := index.Synced["jira"]
+	assert.False(t, ok)
+}
+
+func TestIndex_JSON(t *testing.T) {
+	index := New()
+	for _, task := range testTasks() {
+		index.Add(string(task.Platform), task)
+	}
+
+	data, err := json.Marshal(index)
+	require.NoError(t, err)
+
+	var loaded Index
+	require.NoError(t, json.Unmarshal(data, &loaded))
+	assert.Equal(t, 3, loaded.Len())
+	assert.Equal(t, []string{"ENG-7"}, ids(loaded.Search("login", 0)))
+	assert.Equal(t, index.Synced["linear"], loaded.Synced["linear"])
+}
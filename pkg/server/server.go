@@ -0,0 +1,531 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"opentask/pkg/activity"
+	"opentask/pkg/calendar"
+	"opentask/pkg/config"
+	"opentask/pkg/live"
+	"opentask/pkg/metrics"
+	"opentask/pkg/models"
+	"opentask/pkg/notify"
+	"opentask/pkg/platforms"
+	"opentask/pkg/recurring"
+	"opentask/pkg/rules"
+	"opentask/pkg/service"
+	"opentask/pkg/store"
+	"opentask/pkg/telemetry"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxWebhookBody bounds the size of a webhook delivery.
+const maxWebhookBody = 1 << 20
+
+type Options struct {
+	Addr     string
+	Interval time.Duration
+	Limit    int
+	Snapshot bool
+	// Rules are applied to the tasks of every refresh.
+	Rules []rules.Rule
+	// Recurring creates the recurring tasks that are due on every refresh.
+	Recurring bool
+	// Notifier is told about the tasks that changed since the previous
+	// refresh or that a webhook delivery reports. Nil posts nothing.
+	Notifier *notify.Dispatcher
+	// CalendarToken, when set, must be given as the token query parameter
+	// of /calendar.ics.
+	CalendarToken string
+}
+
+// Server is the long-running OpenTask daemon. It periodically refreshes
+// tasks from all enabled platforms and serves HTTP endpoints built on top
+// of the latest data.
+type Server struct {
+	cfg     *config.Config
+	opts    Options
+	mux     *http.ServeMux
+	metrics *metrics.TaskMetrics
+	store   *store.Store
+	service *service.Service
+	// live tells the running terminal apps about webhook deliveries; it
+	// is nil when the socket could not be created.
+	live *live.Broadcaster
+
+	mu          sync.RWMutex
+	tasks       []*models.Task
+	lastRefresh time.Time
+	// answered are the platforms the latest refresh listed, whose tasks the
+	// next refresh can tell changes in.
+	answered map[string]bool
+}
+
+func New(cfg *config.Config, opts Options) (*Server, error) {
+	if opts.Interval <= 0 {
+		return nil, fmt.Errorf("refresh interval must be positive")
+	}
+
+	s := &Server{
+		cfg:     cfg,
+		opts:    opts,
+		mux:     http.NewServeMux(),
+		service: service.New(cfg),
+	}
+
+	st, err := store.Open()
+	if err != nil {
+		return nil, err
+	}
+	s.store = st
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	s.metrics = metrics.NewTaskMetrics(reg)
+	metrics.Install(metrics.NewRequestMetrics(reg))
+
+	s.mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	s.mux.HandleFunc("/healthz", s.handleHealth)
+	s.mux.HandleFunc("POST /webhooks/{platform}", traced("webhook", s.handleWebhook))
+	s.mux.HandleFunc("GET /calendar.ics", traced("calendar", s.handleCalendar))
+
+	return s, nil
+}
+
+// traced records each request h serves as the root span of its own trace,
+// with the platform requests it makes as children. Scrapes of /metrics and
+// /healthz are left out so they do not drown the traces that matter.
+func traced(name string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := telemetry.Tracer().Start(r.Context(), name,
+			trace.WithNewRoot(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("http.route", r.Pattern)),
+		)
+		defer span.End()
+		h(w, r.WithContext(ctx))
+	}
+}
+
+// Handle registers an additional HTTP handler on the daemon.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Tasks returns the tasks from the most recent refresh.
+func (s *Server) Tasks() []*models.Task {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tasks
+}
+
+// Run refreshes immediately, then on every interval, while serving HTTP
+// until ctx is cancelled.
+func (s *Server) Run(ctx context.Context) error {
+	httpServer := &http.Server{
+		Addr:              s.opts.Addr,
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if b, err := live.Listen(live.SocketPath(s.store.Dir())); err != nil {
+		log.Printf("⚠ Running terminal apps will not see webhook deliveries: %v", err)
+	} else {
+		s.live = b
+		defer b.Close()
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+	}()
+
+	s.Refresh(ctx)
+
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			return httpServer.Shutdown(shutdownCtx)
+		case err := <-errCh:
+			return fmt.Errorf("http server failed: %w", err)
+		case <-ticker.C:
+			s.Refresh(ctx)
+		}
+	}
+}
+
+// Refresh fetches tasks from every enabled platform and updates metrics.
+// Platforms that fail keep contributing nothing until the next refresh.
+func (s *Server) Refresh(ctx context.Context) {
+	// Each refresh is its own trace rather than part of the long-running
+	// serve command.
+	ctx, span := telemetry.Tracer().Start(ctx, "refresh", trace.WithNewRoot())
+	defer span.End()
+
+	list := s.service.Tasks.List(ctx, nil, &models.TaskFilter{Limit: s.opts.Limit})
+	for _, failure := range list.Failures {
+		log.Printf("⚠ failed to list tasks from %s: %v", failure.Platform, failure.Err)
+		s.metrics.RefreshFailed(failure.Platform)
+	}
+
+	// The gauges count only the tasks listed, so say when a platform
+	// may have more than the limit.
+	for name, result := range list.Searches {
+		if s.opts.Limit > 0 && len(result.Tasks) >= s.opts.Limit {
+			log.Printf("⚠ %s has at least %d tasks (--limit); metrics count only those", name, len(result.Tasks))
+		}
+	}
+
+	allTasks := list.Tasks
+	span.SetAttributes(attribute.Int("opentask.tasks", len(allTasks)))
+
+	now := time.Now()
+	s.metrics.Observe(allTasks, now)
+
+	s.mu.Lock()
+	events := s.syncedEvents(list)
+	s.tasks = allTasks
+	s.lastRefresh = now
+	s.answered = make(map[string]bool, len(list.Searches))
+	for name := range list.Searches {
+		s.answered[name] = true
+	}
+	s.mu.Unlock()
+
+	if s.opts.Snapshot {
+		if err := s.store.SaveSnapshot(store.NewSnapshot(allTasks)); err != nil {
+			log.Printf("⚠ Failed to save snapshot: %v", err)
+		}
+	}
+
+	log.Printf("Refreshed %d task(s)", len(allTasks))
+
+	if len(s.opts.Rules) > 0 {
+		s.applyRules(ctx, allTasks, now)
+	}
+	if s.opts.Recurring {
+		s.runRecurring(ctx, now)
+	}
+	s.notify(ctx, events)
+	s.sendDigests(ctx, list, now)
+}
+
+// syncedEvents returns the tasks of list that are new or changed since the
+// previous refresh, on the platforms that answered both. A task older than
+// the previous refresh is not new, only newly within --limit. s.mu must be
+// held.
+func (s *Server) syncedEvents(list *service.TaskList) []notify.Event {
+	if s.opts.Notifier == nil || s.lastRefresh.IsZero() {
+		return nil
+	}
+
+	previous := make(map[string]*models.Task, len(s.tasks))
+	for _, task := range s.tasks {
+		previous[activity.Key(task)] = task
+	}
+
+	names := make([]string, 0, len(list.Searches))
+	for name := range list.Searches {
+		if s.answered[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var events []notify.Event
+	for _, name := range names {
+		for _, task := range list.Searches[name].Tasks {
+			before := previous[activity.Key(task)]
+			if before == nil && task.CreatedAt.Before(s.lastRefresh) {
+				continue
+			}
+			if notify.Changed(before, task) {
+				events = append(events, notify.Event{Kind: notify.KindSynced, Platform: name, Task: task, Previous: before})
+			}
+		}
+	}
+	return events
+}
+
+// sendDigests sends the digests that are due, listing the refreshed tasks.
+func (s *Server) sendDigests(ctx context.Context, list *service.TaskList, now time.Time) {
+	if s.opts.Notifier == nil || !s.opts.Notifier.HasDigests() {
+		return
+	}
+	state, err := s.store.LoadNotifyState()
+	if err != nil {
+		log.Printf("⚠ Failed to load notification state: %v", err)
+		return
+	}
+
+	tasks := make(map[string][]*models.Task, len(list.Searches))
+	for name, result := range list.Searches {
+		tasks[name] = result.Tasks
+	}
+	for _, failure := range s.opts.Notifier.SendDigests(ctx, state, tasks, now) {
+		log.Printf("⚠ Failed to send %s: %v", failure.About(), failure.Err)
+	}
+
+	if err := s.store.SaveNotifyState(state); err != nil {
+		log.Printf("⚠ Failed to save notification state: %v", err)
+	}
+}
+
+// notify posts events to the notifier, logging the posts that fail.
+func (s *Server) notify(ctx context.Context, events []notify.Event) {
+	if s.opts.Notifier == nil || len(events) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.service.Timeout)
+	defer cancel()
+	for _, failure := range s.opts.Notifier.Notify(ctx, events...) {
+		log.Printf("⚠ Failed to notify %s about %s: %v", failure.Notifier, failure.About(), failure.Err)
+	}
+}
+
+// runRecurring creates the recurring tasks that are due.
+func (s *Server) runRecurring(ctx context.Context, now time.Time) {
+	results, err := recurring.Run(ctx, s.service, s.store, now)
+	s.audit(store.AuditCreate, recurring.Audit(results))
+	for _, result := range results {
+		if result.Err != nil {
+			log.Printf("⚠ Failed to create recurring task %s: %v", result.Occurrence.Recurring.ID, result.Err)
+			continue
+		}
+		log.Printf("Created %s from recurring task %s", result.Task.ID, result.Occurrence.Recurring.ID)
+	}
+	if err != nil {
+		log.Printf("⚠ Failed to run recurring tasks: %v", err)
+	}
+}
+
+// audit records the changes the daemon made in the audit log.
+func (s *Server) audit(action string, tasks []store.AuditTask) {
+	if err := s.store.Audit(action, "serve", tasks); err != nil {
+		log.Printf("⚠ Failed to write the audit log: %v", err)
+	}
+}
+
+// applyRules escalates the refreshed tasks the rules are due on.
+func (s *Server) applyRules(ctx context.Context, tasks []*models.Task, now time.Time) {
+	state, err := s.store.LoadRulesState()
+	if err != nil {
+		log.Printf("⚠ Failed to load rules state: %v", err)
+		return
+	}
+
+	actions, err := rules.Plan(s.opts.Rules, tasks, state, now)
+	if err != nil {
+		log.Printf("⚠ Failed to run rules: %v", err)
+		return
+	}
+	if len(actions) == 0 {
+		return
+	}
+
+	results := rules.Apply(ctx, s.service, actions, state, now)
+	s.audit(store.AuditUpdate, rules.Audit(results))
+	for _, result := range results {
+		if result.Err != nil {
+			log.Printf("⚠ Failed to escalate %s: %v", result.Action.Task.ID, result.Err)
+			continue
+		}
+		log.Printf("Escalated %s: %s", result.Action.Task.ID, result.Action.Describe())
+	}
+
+	if err := s.store.SaveRulesState(state); err != nil {
+		log.Printf("⚠ Failed to save rules state: %v", err)
+	}
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	last := s.lastRefresh
+	s.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "text/plain")
+	if last.IsZero() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(w, "waiting for first refresh")
+		return
+	}
+	fmt.Fprintf(w, "ok (last refresh %s)\n", last.Format(time.RFC3339))
+}
+
+// handleCalendar serves the due dates of the tasks assigned to the
+// connected users as an iCalendar feed. The refresh is not narrowed to
+// them, so the platforms are asked on each request; calendar apps only
+// poll a feed every few hours.
+func (s *Server) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if s.opts.CalendarToken != "" && subtle.ConstantTimeCompare([]byte(query.Get("token")), []byte(s.opts.CalendarToken)) != 1 {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	opts := calendar.Options{Platforms: query["platform"], Limit: s.opts.Limit}
+	for _, name := range opts.Platforms {
+		if platform, ok := s.cfg.GetPlatform(name); !ok || !platform.Enabled {
+			http.Error(w, fmt.Sprintf("platform %s is not configured", name), http.StatusNotFound)
+			return
+		}
+	}
+	tasks, failures := calendar.Tasks(r.Context(), s.service, opts)
+	for _, failure := range failures {
+		log.Printf("⚠ Failed to list calendar tasks from %s: %v", failure.Platform, failure.Err)
+	}
+	if len(failures) > 0 && len(failures) >= len(s.service.Platforms(opts.Platforms)) {
+		http.Error(w, "no platform answered", http.StatusBadGateway)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := calendar.Write(&buf, "OpenTask", tasks, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", calendar.ContentType)
+	w.Header().Set("Content-Disposition", `inline; filename="opentask.ics"`)
+	w.Write(buf.Bytes())
+}
+
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("platform")
+	platform, ok := s.cfg.GetPlatform(name)
+	if !ok || !platform.Enabled {
+		http.Error(w, fmt.Sprintf("platform %s is not configured", name), http.StatusNotFound)
+		return
+	}
+
+	client, err := s.service.Client(name)
+	if err != nil {
+		log.Printf("⚠ Failed to create client for %s: %v", name, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	receiver, ok := client.(platforms.WebhookReceiver)
+	if !ok {
+		http.Error(w, fmt.Sprintf("%s does not support webhooks", platform.Type), http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	secret, _ := platform.Settings[platforms.WebhookSecretKey].(string)
+	event, err := receiver.ParseWebhook(r.Header, body, secret)
+	if err != nil {
+		log.Printf("⚠ Rejected webhook delivery for %s: %v", name, err)
+		status := http.StatusBadRequest
+		if platforms.IsAuthenticationError(err) {
+			status = http.StatusUnauthorized
+		}
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	if event != nil {
+		s.applyWebhook(r.Context(), name, models.Platform(platform.Type), client, event)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applyWebhook brings the task a webhook delivery reports up to date in the
+// latest tasks and the search index, and tells the running terminal apps.
+// The delivery only names the task, so created and updated tasks are
+// fetched again.
+func (s *Server) applyWebhook(ctx context.Context, name string, platformType models.Platform, client platforms.PlatformClient, event *platforms.WebhookEvent) {
+	update := live.Update{Platform: name, Action: event.Action, TaskID: event.TaskID, At: time.Now()}
+	if event.Action != platforms.WebhookDeleted {
+		ctx, cancel := context.WithTimeout(ctx, s.service.Timeout)
+		defer cancel()
+		task, err := client.GetTask(ctx, event.TaskID)
+		switch {
+		case platforms.IsNotFoundError(err):
+			// Deleted again before we got to it.
+			update.Action = platforms.WebhookDeleted
+		case err != nil:
+			log.Printf("⚠ Failed to fetch %s from %s: %v", event.TaskID, name, err)
+			return
+		default:
+			update.Task = task
+		}
+	}
+
+	var previous *models.Task
+	s.mu.Lock()
+	tasks := make([]*models.Task, 0, len(s.tasks)+1)
+	for _, task := range s.tasks {
+		if task.Platform != platformType || task.ID != update.TaskID {
+			tasks = append(tasks, task)
+		} else {
+			previous = task
+		}
+	}
+	if update.Task != nil {
+		tasks = append(tasks, update.Task)
+	}
+	s.tasks = tasks
+	s.mu.Unlock()
+	s.metrics.Observe(tasks, update.At)
+
+	s.updateSearchIndex(name, update)
+	if s.live != nil {
+		s.live.Publish(update)
+	}
+	if update.Task != nil && notify.Changed(previous, update.Task) {
+		// Posting must not hold up the answer to the delivery.
+		go s.notify(context.Background(), []notify.Event{{Kind: notify.KindSynced, Platform: name, Task: update.Task, Previous: previous}})
+	}
+	log.Printf("Webhook: %s %s on %s", update.TaskID, update.Action, name)
+}
+
+// updateSearchIndex applies a webhook delivery to the search index of a
+// platform that has been indexed.
+func (s *Server) updateSearchIndex(name string, update live.Update) {
+	index, err := s.store.LoadSearchIndex()
+	if err != nil {
+		log.Printf("⚠ Failed to load search index: %v", err)
+		return
+	}
+	synced, ok := index.Synced[name]
+	if !ok {
+		return
+	}
+
+	if update.Task == nil {
+		index.Remove(name, update.TaskID)
+	} else {
+		index.Add(name, update.Task)
+		// Other tasks may have changed since the last sync without a
+		// delivery; the next 'opentask index' still has to pick them up.
+		index.Synced[name] = synced
+	}
+	if err := s.store.SaveSearchIndex(index); err != nil {
+		log.Printf("⚠ Failed to save search index: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"opentask/pkg/platforms"
+)
+
+// clientPool keeps the clients created in this process, so every service
+// and every command reaching a platform with the same configuration share
+// one client, with its transport, open connections and auth state.
+type clientPool struct {
+	mu      sync.Mutex
+	clients map[poolKey]platforms.PlatformClient
+}
+
+// poolKey identifies a client: the registry that created it and a hash of
+// the platform type and the settings it was created from.
+type poolKey struct {
+	registry *platforms.Registry
+	hash     string
+}
+
+var pool = &clientPool{clients: make(map[poolKey]platforms.PlatformClient)}
+
+// get returns the pooled client for the settings, creating it with create
+// on first use. Settings that cannot be hashed get a client of their own.
+func (p *clientPool) get(registry *platforms.Registry, platformType string, settings map[string]any, create func() (platforms.PlatformClient, error)) (platforms.PlatformClient, error) {
+	hash, ok := configHash(platformType, settings)
+	if !ok {
+		return create()
+	}
+	key := poolKey{registry: registry, hash: hash}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if client, ok := p.clients[key]; ok {
+		return client, nil
+	}
+	client, err := create()
+	if err != nil {
+		return nil, err
+	}
+	p.clients[key] = client
+	return client, nil
+}
+
+// configHash hashes the platform type and settings. Map keys are encoded
+// in order, so equal settings hash the same.
+func configHash(platformType string, settings map[string]any) (string, bool) {
+	data, err := json.Marshal(struct {
+		Type     string         `json:"type"`
+		Settings map[string]any `json:"settings"`
+	}{platformType, settings})
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), true
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+)
+
+// ProjectService lists and looks up projects across platforms.
+type ProjectService struct {
+	svc *Service
+}
+
+// ProjectList is the combined result of listing projects on several
+// platforms.
+type ProjectList struct {
+	// Projects holds each platform's projects in turn, in the order the
+	// platforms were given.
+	Projects []*models.Project
+	Failures Failures
+}
+
+// List lists the projects on the named platforms, or on every enabled
+// platform when names is empty. Projects listed less than
+// platforms.ProjectCacheTTL ago are taken from the project cache. When
+// listing a platform again fails, its stale projects are used and the
+// failure is still reported.
+func (p *ProjectService) List(ctx context.Context, names []string) *ProjectList {
+	return p.list(ctx, names, false)
+}
+
+// Refresh lists the projects like List, but always asks the platforms.
+func (p *ProjectService) Refresh(ctx context.Context, names []string) *ProjectList {
+	return p.list(ctx, names, true)
+}
+
+func (p *ProjectService) list(ctx context.Context, names []string, refresh bool) *ProjectList {
+	names = p.svc.Platforms(names)
+	results := make([][]*models.Project, len(names))
+
+	var stale []string
+	var index []int
+	for i, name := range names {
+		if listing, _, fresh := platforms.CachedProjects(name); fresh && !refresh {
+			results[i] = listing.Projects
+			continue
+		}
+		stale = append(stale, name)
+		index = append(index, i)
+	}
+
+	failures := p.svc.each(ctx, "list projects", stale, func(ctx context.Context, i int, client platforms.PlatformClient) error {
+		projects, err := client.ListProjects(ctx)
+		if err != nil {
+			return err
+		}
+		results[index[i]] = projects
+		platforms.CacheProjects(stale[i], platforms.ProjectListing{Projects: projects, FetchedAt: time.Now()})
+		return nil
+	})
+	for _, failure := range failures {
+		if listing, ok, _ := platforms.CachedProjects(failure.Platform); ok {
+			results[slices.Index(names, failure.Platform)] = listing.Projects
+		}
+	}
+
+	list := &ProjectList{Failures: failures}
+	for _, projects := range results {
+		list.Projects = append(list.Projects, projects...)
+	}
+	return list
+}
+
+// Find looks id up on the named platforms, or on every enabled platform
+// when names is empty, and returns the first platform that has it.
+// Platforms that fail for reasons other than not finding the project are
+// returned as failures.
+func (p *ProjectService) Find(ctx context.Context, names []string, id string) (string, *models.Project, Failures, error) {
+	names = p.svc.Platforms(names)
+	if len(names) == 0 {
+		return "", nil, nil, fmt.Errorf("no platforms configured or enabled")
+	}
+
+	var failures Failures
+	for _, name := range names {
+		var project *models.Project
+		err := p.svc.call(ctx, "get project", name, func(ctx context.Context, client platforms.PlatformClient) error {
+			var err error
+			project, err = client.GetProject(ctx, id)
+			return err
+		})
+		switch {
+		case err == nil && project != nil:
+			return name, project, failures, nil
+		case err != nil && !platforms.IsNotFoundError(err):
+			failures = append(failures, Failure{Platform: name, Err: err})
+		}
+	}
+
+	return "", nil, failures, fmt.Errorf("project '%s' not found in any configured platform", id)
+}
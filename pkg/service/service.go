@@ -0,0 +1,289 @@
+// Package service is the layer between the configured platforms and the
+// programs that show their tasks: the CLI, the terminal app and the
+// daemon. It creates and reuses platform clients, runs requests against
+// several platforms at once, and collects the platforms that failed
+// instead of stopping at the first error.
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/logging"
+	"opentask/pkg/metrics"
+	"opentask/pkg/platforms"
+	"opentask/pkg/telemetry"
+
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultTimeout bounds each platform's part of a request unless the
+// defaults.timeout setting or the --timeout flag change it.
+const DefaultTimeout = 30 * time.Second
+
+var (
+	timeoutMu sync.RWMutex
+	timeout   = DefaultTimeout
+)
+
+// SetRequestTimeout changes how long platform requests may take for the
+// rest of the program. Services created afterwards use it too. Durations
+// that are not positive restore DefaultTimeout.
+func SetRequestTimeout(d time.Duration) {
+	if d <= 0 {
+		d = DefaultTimeout
+	}
+	timeoutMu.Lock()
+	defer timeoutMu.Unlock()
+	timeout = d
+}
+
+// RequestTimeout returns how long platform requests may take.
+func RequestTimeout() time.Duration {
+	timeoutMu.RLock()
+	defer timeoutMu.RUnlock()
+	return timeout
+}
+
+// WithRequestTimeout returns a context that is cancelled after
+// RequestTimeout, for calling a platform directly.
+func WithRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, RequestTimeout())
+}
+
+// Service reaches the platforms in a configuration.
+type Service struct {
+	cfg      *config.Config
+	registry *platforms.Registry
+
+	// Timeout bounds each platform's part of a request.
+	Timeout time.Duration
+
+	Tasks      *TaskService
+	Projects   *ProjectService
+	Vocabulary *VocabularyService
+
+	mu      sync.Mutex
+	clients map[string]platforms.PlatformClient
+}
+
+// New returns a service for the platforms in cfg.
+func New(cfg *config.Config) *Service {
+	return NewWithRegistry(cfg, platforms.DefaultRegistry)
+}
+
+// NewWithRegistry returns a service creating clients from registry.
+func NewWithRegistry(cfg *config.Config, registry *platforms.Registry) *Service {
+	s := &Service{
+		cfg:      cfg,
+		registry: registry,
+		Timeout:  RequestTimeout(),
+		clients:  make(map[string]platforms.PlatformClient),
+	}
+	s.Tasks = &TaskService{svc: s}
+	s.Projects = &ProjectService{svc: s}
+	s.Vocabulary = &VocabularyService{svc: s}
+	return s
+}
+
+// Load reads the configuration chosen by the --config and --workspace
+// flags in flags and returns a service for it.
+func Load(flags *pflag.FlagSet) (*Service, error) {
+	manager := config.NewManager()
+	if err := manager.LoadFlags(flags); err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return New(manager.GetConfig()), nil
+}
+
+func (s *Service) Config() *config.Config {
+	return s.cfg
+}
+
+// Platforms returns the names a request goes to: the enabled platforms
+// when names is empty, otherwise the given ones that are configured and
+// enabled.
+func (s *Service) Platforms(names []string) []string {
+	if len(names) == 0 {
+		return s.cfg.GetEnabledPlatforms()
+	}
+
+	var enabled []string
+	for _, name := range names {
+		if platform, ok := s.cfg.GetPlatform(name); ok && platform.Enabled {
+			enabled = append(enabled, name)
+		}
+	}
+	return enabled
+}
+
+// Client returns the client for a configured platform, creating it on
+// first use or taking it from the process's pool (see NewClient).
+func (s *Service) Client(name string) (platforms.PlatformClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if client, ok := s.clients[name]; ok {
+		return client, nil
+	}
+
+	platform, ok := s.cfg.GetPlatform(name)
+	if !ok {
+		return nil, fmt.Errorf("platform %s is not configured", name)
+	}
+	if !platform.Enabled {
+		return nil, fmt.Errorf("platform %s is disabled", name)
+	}
+
+	client, err := newClient(s.registry, name, platform)
+	if err != nil {
+		return nil, err
+	}
+	s.clients[name] = client
+	return client, nil
+}
+
+// NewClient returns a client for a configured platform from its
+// credentials and settings. Clients are pooled for the life of the
+// process: a platform configured the same way gets the same client.
+func NewClient(name string, platform config.Platform) (platforms.PlatformClient, error) {
+	return newClient(platforms.DefaultRegistry, name, platform)
+}
+
+func newClient(registry *platforms.Registry, name string, platform config.Platform) (platforms.PlatformClient, error) {
+	clientConfig := make(map[string]any)
+
+	for key, value := range platform.Credentials {
+		clientConfig[key] = value
+	}
+
+	for key, value := range platform.Settings {
+		clientConfig[key] = value
+	}
+
+	client, err := pool.get(registry, platform.Type, clientConfig, func() (platforms.PlatformClient, error) {
+		return registry.Create(platform.Type, clientConfig)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s client: %w", name, err)
+	}
+
+	return client, nil
+}
+
+// Failure is a platform whose part of a request failed.
+type Failure struct {
+	Platform string
+	Err      error
+}
+
+// Code classifies the failure: network_error for timeouts, the code of a
+// platforms.PlatformError, and platform_api_error otherwise.
+func (f Failure) Code() platforms.ErrorCode {
+	if errors.Is(f.Err, context.DeadlineExceeded) {
+		return platforms.ErrNetworkError
+	}
+	if code, ok := platforms.CodeOf(f.Err); ok {
+		return code
+	}
+	return platforms.ErrPlatformAPI
+}
+
+// MarshalJSON encodes the failure as {"platform", "code", "message"} so
+// it can be reported next to the results of a request.
+func (f Failure) MarshalJSON() ([]byte, error) {
+	message := ""
+	if f.Err != nil {
+		message = logging.Redact(f.Err.Error())
+	}
+	return json.Marshal(struct {
+		Platform string              `json:"platform"`
+		Code     platforms.ErrorCode `json:"code"`
+		Message  string              `json:"message"`
+	}{f.Platform, f.Code(), message})
+}
+
+// Failures are the platforms that failed during a request, in the order
+// the request went to them.
+type Failures []Failure
+
+// Platforms returns the names of the platforms that failed.
+func (f Failures) Platforms() []string {
+	names := make([]string, 0, len(f))
+	for _, failure := range f {
+		names = append(names, failure.Platform)
+	}
+	return names
+}
+
+// Report prints a warning for each failure, such as "⚠ Failed to list
+// tasks from jira: ...".
+func (f Failures) Report(w io.Writer, action string) {
+	for _, failure := range f {
+		fmt.Fprintf(w, "⚠ Failed to %s from %s: %v\n", action, failure.Platform, logging.RedactError(failure.Err))
+	}
+}
+
+// each calls fn for every platform in names at the same time, each in its
+// own span and with its own timeout. Results are put in place by fn, so
+// callers can keep them in platform order.
+func (s *Service) each(ctx context.Context, operation string, names []string, fn func(ctx context.Context, i int, client platforms.PlatformClient) error) Failures {
+	errs := make([]error, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.call(ctx, operation, name, func(ctx context.Context, client platforms.PlatformClient) error {
+				return fn(ctx, i, client)
+			})
+		}()
+	}
+	wg.Wait()
+
+	var failures Failures
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, Failure{Platform: names[i], Err: err})
+		}
+	}
+	return failures
+}
+
+func (s *Service) call(ctx context.Context, operation, name string, fn func(ctx context.Context, client platforms.PlatformClient) error) error {
+	ctx, span := telemetry.Tracer().Start(ctx, operation,
+		trace.WithAttributes(telemetry.PlatformKey.String(name)))
+	defer span.End()
+
+	start := time.Now()
+	client, err := s.Client(name)
+	if err == nil {
+		ctx, cancel := context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+		err = fn(ctx, client)
+	}
+
+	elapsed := time.Since(start)
+	attrs := []slog.Attr{
+		slog.String("platform", name),
+		slog.Duration("duration", elapsed),
+	}
+	code := ""
+	if err != nil {
+		telemetry.RecordError(span, err)
+		attrs = append(attrs, slog.String("error", err.Error()))
+		code = string(Failure{Platform: name, Err: err}.Code())
+	}
+	metrics.ObserveOperation(operation, name, elapsed, code)
+	logging.Logger().LogAttrs(ctx, slog.LevelInfo, operation, attrs...)
+	return err
+}
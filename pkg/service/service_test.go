@@ -0,0 +1,469 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"opentask/pkg/config"
+	"opentask/pkg/logging"
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// fakeClient serves the tasks and projects it was created with. Settings
+// named "fail" make every call fail with that message.
+type fakeClient struct {
+	platforms.PlatformClient
+
+	name     string
+	fail     string
+	projects []*models.Project
+
+	vocabularyCalls int
+	listFilters     []*models.TaskFilter
+}
+
+func (c *fakeClient) ListTasks(ctx context.Context, filter *models.TaskFilter) ([]*models.Task, error) {
+	c.listFilters = append(c.listFilters, filter)
+	if c.fail != "" {
+		return nil, errors.New(c.fail)
+	}
+	return []*models.Task{{ID: c.name + "-1", Title: "Fix login"}, {ID: c.name + "-2", Title: "Write docs"}}, nil
+}
+
+func (c *fakeClient) ListProjects(ctx context.Context) ([]*models.Project, error) {
+	if c.fail != "" {
+		return nil, errors.New(c.fail)
+	}
+	return c.projects, nil
+}
+
+func (c *fakeClient) GetProject(ctx context.Context, id string) (*models.Project, error) {
+	if c.fail != "" {
+		return nil, errors.New(c.fail)
+	}
+	for _, project := range c.projects {
+		if project.Key == id {
+			return project, nil
+		}
+	}
+	return nil, platforms.NewPlatformError(platforms.ErrNotFound, c.name, "", fmt.Errorf("project not found"))
+}
+
+func (c *fakeClient) ListVocabulary(ctx context.Context, projectID string) (*platforms.Vocabulary, error) {
+	c.vocabularyCalls++
+	if c.fail != "" {
+		return nil, errors.New(c.fail)
+	}
+	return &platforms.Vocabulary{
+		Labels:     []string{"backend", "bug", "frontend"},
+		Components: []string{"API"},
+		FetchedAt:  time.Now(),
+	}, nil
+}
+
+type fakeFactory struct {
+	created int
+}
+
+func (f *fakeFactory) Create(settings map[string]any) (platforms.PlatformClient, error) {
+	f.created++
+	client := &fakeClient{name: settings["name"].(string)}
+	client.fail, _ = settings["fail"].(string)
+	if key, ok := settings["project"].(string); ok {
+		client.projects = []*models.Project{{ID: key, Key: key}}
+	}
+	return client, nil
+}
+
+func (f *fakeFactory) GetType() string                              { return "fake" }
+func (f *fakeFactory) GetName() string                              { return "Fake" }
+func (f *fakeFactory) ValidateConfig(settings map[string]any) error { return nil }
+
+func newTestService(t *testing.T) (*Service, *fakeFactory) {
+	t.Helper()
+	factory := &fakeFactory{}
+	registry := platforms.NewRegistry()
+	registry.Register(factory)
+
+	cfg := &config.Config{Platforms: map[string]config.Platform{
+		"alpha":    {Type: "fake", Enabled: true, Settings: map[string]any{"name": "alpha", "project": "API"}},
+		"beta":     {Type: "fake", Enabled: true, Settings: map[string]any{"name": "beta", "fail": "connection refused"}},
+		"disabled": {Type: "fake", Settings: map[string]any{"name": "disabled"}},
+	}}
+	return NewWithRegistry(cfg, registry), factory
+}
+
+func TestTaskService_List(t *testing.T) {
+	tests := []struct {
+		name       string
+		platforms  []string
+		filter     *models.TaskFilter
+		wantIDs    []string
+		wantFailed []string
+		wantMode   platforms.SearchMode
+	}{
+		{
+			name:       "every enabled platform",
+			filter:     &models.TaskFilter{},
+			wantIDs:    []string{"alpha-1", "alpha-2"},
+			wantFailed: []string{"beta"},
+		},
+		{
+			name:       "named platforms skip disabled ones",
+			platforms:  []string{"alpha", "disabled"},
+			filter:     &models.TaskFilter{},
+			wantIDs:    []string{"alpha-1", "alpha-2"},
+			wantFailed: []string{},
+		},
+		{
+			name:       "query falls back to a local search",
+			platforms:  []string{"alpha"},
+			filter:     &models.TaskFilter{Query: "login"},
+			wantIDs:    []string{"alpha-1"},
+			wantFailed: []string{},
+			wantMode:   platforms.SearchFallback,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+
+			list := svc.Tasks.List(context.Background(), tt.platforms, tt.filter)
+
+			var ids []string
+			for _, task := range list.Tasks {
+				ids = append(ids, task.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantFailed, list.Failures.Platforms())
+			require.Contains(t, list.Searches, "alpha")
+			assert.Equal(t, tt.wantMode, list.Searches["alpha"].Mode)
+		})
+	}
+}
+
+func TestTaskService_ListInDefaultProjects(t *testing.T) {
+	svc, _ := newTestService(t)
+	svc.Config().Defaults.Projects = map[string]string{"alpha": "backend"}
+	svc.Config().ProjectAliases = map[string]string{"backend": "API"}
+	client, err := svc.Client("alpha")
+	require.NoError(t, err)
+	fake := client.(*fakeClient)
+
+	filter := &models.TaskFilter{Limit: 10}
+	svc.Tasks.ListInDefaultProjects(context.Background(), []string{"alpha"}, filter)
+	require.Len(t, fake.listFilters, 1)
+	assert.Equal(t, "API", fake.listFilters[0].ProjectID)
+	assert.Equal(t, 10, fake.listFilters[0].Limit)
+	assert.Empty(t, filter.ProjectID)
+
+	// A project in the filter wins over the default.
+	svc.Tasks.ListInDefaultProjects(context.Background(), []string{"alpha"}, &models.TaskFilter{ProjectID: "WEB"})
+	assert.Equal(t, "WEB", fake.listFilters[1].ProjectID)
+}
+
+func TestTaskService_Stream(t *testing.T) {
+	svc, _ := newTestService(t)
+	svc.Config().Platforms["gamma"] = config.Platform{Type: "fake", Enabled: true, Settings: map[string]any{"name": "gamma"}}
+
+	var observed []string
+	ObserveTasks(func(list *TaskList) {
+		for name := range list.Searches {
+			observed = append(observed, name)
+		}
+	})
+	defer func() { observers = nil }()
+
+	streamed := make(map[string][]string)
+	failures := svc.Tasks.Stream(context.Background(), nil, &models.TaskFilter{}, func(name string, result *platforms.SearchResult) error {
+		for _, task := range result.Tasks {
+			streamed[name] = append(streamed[name], task.ID)
+		}
+		if name == "gamma" {
+			return errors.New("broken pipe")
+		}
+		return nil
+	})
+
+	assert.Equal(t, map[string][]string{"alpha": {"alpha-1", "alpha-2"}, "gamma": {"gamma-1", "gamma-2"}}, streamed)
+	assert.ElementsMatch(t, []string{"alpha", "gamma"}, observed)
+	assert.ElementsMatch(t, []string{"beta", "gamma"}, failures.Platforms())
+}
+
+func TestService_ClientIsReused(t *testing.T) {
+	svc, factory := newTestService(t)
+
+	svc.Tasks.List(context.Background(), []string{"alpha"}, nil)
+	svc.Projects.List(context.Background(), []string{"alpha"})
+	assert.Equal(t, 1, factory.created)
+
+	_, err := svc.Client("disabled")
+	assert.ErrorContains(t, err, "disabled")
+	_, err = svc.Client("missing")
+	assert.ErrorContains(t, err, "not configured")
+}
+
+func TestService_ClientIsPooled(t *testing.T) {
+	svc, factory := newTestService(t)
+	first, err := svc.Client("alpha")
+	require.NoError(t, err)
+
+	// Another service for the same configuration, as the next command or
+	// TUI action would create, gets the same client.
+	other := NewWithRegistry(svc.Config(), svc.registry)
+	second, err := other.Client("alpha")
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, factory.created)
+
+	// Changed settings get a new client.
+	changed := svc.Config().Platforms["alpha"]
+	changed.Settings = map[string]any{"name": "alpha", "project": "WEB"}
+	third, err := newClient(svc.registry, "alpha", changed)
+	require.NoError(t, err)
+	assert.NotSame(t, first, third)
+	assert.Equal(t, 2, factory.created)
+}
+
+func TestProjectService_Find(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	name, project, failures, err := svc.Projects.Find(context.Background(), []string{"beta", "alpha"}, "API")
+	require.NoError(t, err)
+	assert.Equal(t, "alpha", name)
+	assert.Equal(t, "API", project.Key)
+	assert.Equal(t, []string{"beta"}, failures.Platforms())
+
+	_, _, failures, err = svc.Projects.Find(context.Background(), []string{"alpha"}, "WEB")
+	assert.ErrorContains(t, err, "not found")
+	assert.Empty(t, failures)
+}
+
+func TestFailures_Report(t *testing.T) {
+	var out bytes.Buffer
+	Failures{{Platform: "jira", Err: errors.New("timeout")}}.Report(&out, "list tasks")
+	assert.Equal(t, "⚠ Failed to list tasks from jira: timeout\n", out.String())
+}
+
+func TestFailures_RedactSecrets(t *testing.T) {
+	logging.AddSecrets("svc-secret-token")
+	failures := Failures{{Platform: "jira", Err: errors.New(`401: Authorization "Bearer svc-secret-token" rejected`)}}
+
+	var out bytes.Buffer
+	failures.Report(&out, "list tasks")
+	assert.NotContains(t, out.String(), "svc-secret-token")
+
+	data, err := json.Marshal(failures)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "svc-secret-token")
+	assert.Contains(t, string(data), "[redacted]")
+}
+
+func TestService_SpanErrorsAreRedacted(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+	logging.AddSecrets("svc-span-token")
+	svc, _ := newTestService(t)
+	svc.cfg.Platforms["beta"].Settings["fail"] = "401: token svc-span-token rejected"
+
+	list := svc.Tasks.List(context.Background(), []string{"beta"}, &models.TaskFilter{})
+	require.Equal(t, []string{"beta"}, list.Failures.Platforms())
+
+	spans := exporter.GetSpans()
+	require.NotEmpty(t, spans)
+	for _, span := range spans {
+		assert.NotContains(t, span.Status.Description, "svc-span-token")
+		for _, event := range span.Events {
+			for _, kv := range event.Attributes {
+				assert.NotContains(t, kv.Value.Emit(), "svc-span-token")
+			}
+		}
+	}
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
+
+func TestFailures_MarshalJSON(t *testing.T) {
+	failures := Failures{
+		{Platform: "jira", Err: platforms.NewPlatformError(platforms.ErrAuthentication, "jira", "", errors.New("401"))},
+		{Platform: "linear", Err: fmt.Errorf("list issues: %w", context.DeadlineExceeded)},
+		{Platform: "github", Err: errors.New("boom")},
+	}
+
+	data, err := json.Marshal(failures)
+	require.NoError(t, err)
+
+	var got []map[string]string
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Len(t, got, 3)
+	assert.Equal(t, "jira", got[0]["platform"])
+	assert.Equal(t, string(platforms.ErrAuthentication), got[0]["code"])
+	assert.Contains(t, got[0]["message"], "401")
+	assert.Equal(t, string(platforms.ErrNetworkError), got[1]["code"])
+	assert.Equal(t, string(platforms.ErrPlatformAPI), got[2]["code"])
+	assert.Equal(t, "boom", got[2]["message"])
+}
+
+func TestSetRequestTimeout(t *testing.T) {
+	defer SetRequestTimeout(DefaultTimeout)
+
+	SetRequestTimeout(5 * time.Second)
+	assert.Equal(t, 5*time.Second, RequestTimeout())
+	svc, _ := newTestService(t)
+	assert.Equal(t, 5*time.Second, svc.Timeout)
+
+	SetRequestTimeout(0)
+	assert.Equal(t, DefaultTimeout, RequestTimeout())
+}
+
+func TestVocabularyService_CheckLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		labels   []string
+		want     []UnknownLabel
+	}{
+		{
+			name:     "known labels, any case",
+			platform: "alpha",
+			labels:   []string{"backend", "Bug"},
+		},
+		{
+			name:     "typo of a known label",
+			platform: "alpha",
+			labels:   []string{"backedn"},
+			want:     []UnknownLabel{{Label: "backedn", Suggestion: "backend"}},
+		},
+		{
+			name:     "new label",
+			platform: "alpha",
+			labels:   []string{"security"},
+			want:     []UnknownLabel{{Label: "security"}},
+		},
+		{
+			name:     "short labels allow one edit",
+			platform: "alpha",
+			labels:   []string{"bag", "bxx"},
+			want:     []UnknownLabel{{Label: "bag", Suggestion: "bug"}, {Label: "bxx"}},
+		},
+		{
+			name:     "failing platform does not block",
+			platform: "beta",
+			labels:   []string{"anything"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platforms.SetVocabularyCache(platforms.NewMemoryVocabularyCache())
+			defer platforms.SetVocabularyCache(platforms.NewMemoryVocabularyCache())
+
+			svc, _ := newTestService(t)
+			got := svc.Vocabulary.CheckLabels(context.Background(), tt.platform, "API", tt.labels)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVocabularyService_Get(t *testing.T) {
+	platforms.SetVocabularyCache(platforms.NewMemoryVocabularyCache())
+	defer platforms.SetVocabularyCache(platforms.NewMemoryVocabularyCache())
+
+	svc, _ := newTestService(t)
+	client, err := svc.Client("alpha")
+	require.NoError(t, err)
+	fake := client.(*fakeClient)
+
+	vocabulary, err := svc.Vocabulary.Get(context.Background(), "alpha", "API")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"API"}, vocabulary.Components)
+
+	// A fresh vocabulary comes from the cache.
+	_, err = svc.Vocabulary.Get(context.Background(), "alpha", "API")
+	require.NoError(t, err)
+	assert.Equal(t, 1, fake.vocabularyCalls)
+
+	svc.Vocabulary.Learn("alpha", "API", []string{"security"})
+	vocabulary, err = svc.Vocabulary.Get(context.Background(), "alpha", "API")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backend", "bug", "frontend", "security"}, vocabulary.Labels)
+
+	// A stale vocabulary is listed again.
+	stale := *vocabulary
+	stale.FetchedAt = time.Now().Add(-2 * platforms.VocabularyCacheTTL)
+	platforms.CacheVocabulary(vocabularyKey("alpha", "API"), stale)
+	_, err = svc.Vocabulary.Get(context.Background(), "alpha", "API")
+	require.NoError(t, err)
+	assert.Equal(t, 2, fake.vocabularyCalls)
+}
+
+// benchClient lists the same tasks on every call, like a platform whose
+// answers are already cached.
+type benchClient struct {
+	platforms.PlatformClient
+	tasks []*models.Task
+}
+
+func (c *benchClient) ListTasks(ctx context.Context, filter *models.TaskFilter) ([]*models.Task, error) {
+	return c.tasks, nil
+}
+
+type benchFactory struct {
+	tasks int
+}
+
+func (f *benchFactory) Create(settings map[string]any) (platforms.PlatformClient, error) {
+	name := settings["name"].(string)
+	tasks := make([]*models.Task, f.tasks)
+	for i := range tasks {
+		tasks[i] = &models.Task{ID: fmt.Sprintf("%s-%d", name, i+1), Title: "Fix login", Status: models.StatusOpen}
+	}
+	return &benchClient{tasks: tasks}, nil
+}
+
+func (f *benchFactory) GetType() string                              { return "bench" }
+func (f *benchFactory) GetName() string                              { return "Bench" }
+func (f *benchFactory) ValidateConfig(settings map[string]any) error { return nil }
+
+// BenchmarkTaskService_List lists platforms × tasks through the whole
+// listing pipeline. Listing 10k cached tasks should stay well under 100ms.
+func BenchmarkTaskService_List(b *testing.B) {
+	sizes := []struct{ platforms, tasks int }{{1, 100}, {4, 2500}, {10, 1000}}
+	for _, size := range sizes {
+		b.Run(fmt.Sprintf("%dx%d", size.platforms, size.tasks), func(b *testing.B) {
+			registry := platforms.NewRegistry()
+			registry.Register(&benchFactory{tasks: size.tasks})
+			cfg := &config.Config{Platforms: make(map[string]config.Platform)}
+			for i := 0; i < size.platforms; i++ {
+				name := fmt.Sprintf("p%d", i)
+				cfg.Platforms[name] = config.Platform{Type: "bench", Enabled: true, Settings: map[string]any{"name": name}}
+			}
+			svc := NewWithRegistry(cfg, registry)
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				list := svc.Tasks.List(ctx, nil, nil)
+				if len(list.Tasks) != size.platforms*size.tasks {
+					b.Fatalf("listed %d tasks", len(list.Tasks))
+				}
+			}
+		})
+	}
+}
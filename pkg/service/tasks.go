@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"opentask/pkg/models"
+	"opentask/pkg/platforms"
+)
+
+var (
+	observersMu sync.Mutex
+	observers   []func(list *TaskList)
+)
+
+// ObserveTasks calls fn with every list TaskService.List returns, and with
+// each platform's tasks TaskService.Stream hands out, such as to mask the
+// task titles in a recording.
+func ObserveTasks(fn func(list *TaskList)) {
+	observersMu.Lock()
+	defer observersMu.Unlock()
+	observers = append(observers, fn)
+}
+
+// TaskService lists tasks across platforms.
+type TaskService struct {
+	svc *Service
+}
+
+// TaskList is the combined result of listing tasks on several platforms.
+type TaskList struct {
+	// Tasks holds each platform's tasks in turn, in the order the
+	// platforms were given.
+	Tasks []*models.Task
+	// Searches says how each platform that answered ran the filter's
+	// query, keyed by platform name.
+	Searches map[string]*platforms.SearchResult
+	Failures Failures
+}
+
+// List lists the tasks matching filter on the named platforms, or on every
+// enabled platform when names is empty. Platforms that cannot search text
+// themselves fall back to a local search (see platforms.SearchTasks).
+func (t *TaskService) List(ctx context.Context, names []string, filter *models.TaskFilter) *TaskList {
+	return t.list(ctx, names, func(string) *models.TaskFilter { return filter })
+}
+
+// ListInDefaultProjects lists tasks like List, but a filter without a
+// project is narrowed to each platform's default project, since project
+// IDs differ between platforms.
+func (t *TaskService) ListInDefaultProjects(ctx context.Context, names []string, filter *models.TaskFilter) *TaskList {
+	return t.list(ctx, names, t.inDefaultProject(filter))
+}
+
+// Stream lists tasks like List, but hands each platform's result to fn as
+// soon as the platform answers instead of collecting them, so large
+// exports can be written out as they arrive. fn is called for one platform
+// at a time, in the order they answer. The platforms that failed are
+// returned.
+func (t *TaskService) Stream(ctx context.Context, names []string, filter *models.TaskFilter, fn func(name string, result *platforms.SearchResult) error) Failures {
+	return t.stream(ctx, names, func(string) *models.TaskFilter { return filter }, fn)
+}
+
+// StreamInDefaultProjects streams tasks like Stream, narrowing a filter
+// without a project to each platform's default project like
+// ListInDefaultProjects.
+func (t *TaskService) StreamInDefaultProjects(ctx context.Context, names []string, filter *models.TaskFilter, fn func(name string, result *platforms.SearchResult) error) Failures {
+	return t.stream(ctx, names, t.inDefaultProject(filter), fn)
+}
+
+// inDefaultProject returns the filter to use on each platform: filter, or
+// a copy narrowed to the platform's default project when filter names no
+// project.
+func (t *TaskService) inDefaultProject(filter *models.TaskFilter) func(name string) *models.TaskFilter {
+	if filter == nil {
+		filter = &models.TaskFilter{}
+	}
+	return func(name string) *models.TaskFilter {
+		project := t.svc.cfg.DefaultProject(name)
+		if filter.ProjectID != "" || project == "" {
+			return filter
+		}
+		narrowed := *filter
+		narrowed.ProjectID = project
+		return &narrowed
+	}
+}
+
+func (t *TaskService) list(ctx context.Context, names []string, filterFor func(name string) *models.TaskFilter) *TaskList {
+	names = t.svc.Platforms(names)
+	results := make([]*platforms.SearchResult, len(names))
+
+	failures := t.svc.each(ctx, "list tasks", names, func(ctx context.Context, i int, client platforms.PlatformClient) error {
+		result, err := platforms.SearchTasks(ctx, client, filterFor(names[i]))
+		results[i] = result
+		return err
+	})
+
+	list := &TaskList{Searches: make(map[string]*platforms.SearchResult, len(names)), Failures: failures}
+	total := 0
+	for _, result := range results {
+		if result != nil {
+			total += len(result.Tasks)
+		}
+	}
+	if total > 0 {
+		list.Tasks = make([]*models.Task, 0, total)
+	}
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		list.Tasks = append(list.Tasks, result.Tasks...)
+		list.Searches[names[i]] = result
+	}
+
+	notify(list)
+	return list
+}
+
+func (t *TaskService) stream(ctx context.Context, names []string, filterFor func(name string) *models.TaskFilter, fn func(name string, result *platforms.SearchResult) error) Failures {
+	names = t.svc.Platforms(names)
+
+	var mu sync.Mutex
+	return t.svc.each(ctx, "list tasks", names, func(ctx context.Context, i int, client platforms.PlatformClient) error {
+		result, err := platforms.SearchTasks(ctx, client, filterFor(names[i]))
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		notify(&TaskList{Tasks: result.Tasks, Searches: map[string]*platforms.SearchResult{names[i]: result}})
+		return fn(names[i], result)
+	})
+}
+
+// notify hands list to the ObserveTasks observers.
+func notify(list *TaskList) {
+	observersMu.Lock()
+	defer observersMu.Unlock()
+	for _, observe := range observers {
+		observe(list)
+	}
+}
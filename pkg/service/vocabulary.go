@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+
+	"opentask/pkg/platforms"
+)
+
+// VocabularyService lists the labels and components of projects, from a
+// cache that is refreshed lazily once it is older than
+// platforms.VocabularyCacheTTL.
+type VocabularyService struct {
+	svc *Service
+}
+
+// UnknownLabel is a label a project does not use yet, with the known
+// label it is probably a typo of, if any.
+type UnknownLabel struct {
+	Label      string
+	Suggestion string
+}
+
+func vocabularyKey(name, project string) string {
+	return name + "/" + project
+}
+
+// Get returns the vocabulary of project on the named platform. When
+// listing it again fails, the stale cached vocabulary is returned instead.
+// Platforms that cannot list labels return nil and no error.
+func (v *VocabularyService) Get(ctx context.Context, name, project string) (*platforms.Vocabulary, error) {
+	key := vocabularyKey(name, project)
+	cached, ok, fresh := platforms.CachedVocabulary(key)
+	if fresh {
+		return &cached, nil
+	}
+
+	var vocabulary *platforms.Vocabulary
+	err := v.svc.call(ctx, "list vocabulary", name, func(ctx context.Context, client platforms.PlatformClient) error {
+		lister, ok := client.(platforms.VocabularyLister)
+		if !ok {
+			return nil
+		}
+		var err error
+		vocabulary, err = lister.ListVocabulary(ctx, project)
+		return err
+	})
+	switch {
+	case err != nil && ok:
+		return &cached, nil
+	case err != nil:
+		return nil, err
+	case vocabulary == nil:
+		return nil, nil
+	}
+
+	platforms.CacheVocabulary(key, *vocabulary)
+	return vocabulary, nil
+}
+
+// CheckLabels returns the labels project does not use yet. It returns
+// nothing when the vocabulary cannot be listed, so a platform that is down
+// or cannot list labels does not block creating tasks.
+func (v *VocabularyService) CheckLabels(ctx context.Context, name, project string, labels []string) []UnknownLabel {
+	vocabulary, err := v.Get(ctx, name, project)
+	if err != nil || vocabulary == nil {
+		return nil
+	}
+
+	var unknown []UnknownLabel
+	for _, label := range labels {
+		if vocabulary.HasLabel(label) {
+			continue
+		}
+		suggestion, _ := vocabulary.ClosestLabel(label)
+		unknown = append(unknown, UnknownLabel{Label: label, Suggestion: suggestion})
+	}
+	return unknown
+}
+
+// Learn adds labels a task was just given to the cached vocabulary, so
+// they are known before the cache is next refreshed.
+func (v *VocabularyService) Learn(name, project string, labels []string) {
+	key := vocabularyKey(name, project)
+	cached, ok, _ := platforms.CachedVocabulary(key)
+	if !ok {
+		return
+	}
+
+	added := false
+	for _, label := range labels {
+		if !cached.HasLabel(label) {
+			cached.Labels = append(cached.Labels, label)
+			added = true
+		}
+	}
+	if added {
+		cached.Labels = platforms.SortedUnique(cached.Labels)
+		platforms.CacheVocabulary(key, cached)
+	}
+}
@@ -0,0 +1,90 @@
+package share
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	GitHubAPIURL = "https://api.github.com"
+
+	// htmlPreviewURL renders a raw HTML file from a gist in the browser.
+	htmlPreviewURL = "https://htmlpreview.github.io/?"
+)
+
+// GistUploader stores reports as secret GitHub gists. Secret gists are not
+// listed publicly but anyone with the link can view them.
+type GistUploader struct {
+	Token       string
+	Description string
+	BaseURL     string
+	Client      *http.Client
+}
+
+func (g *GistUploader) Upload(ctx context.Context, name string, content []byte) (string, error) {
+	if g.Token == "" {
+		return "", fmt.Errorf("a GitHub token with the gist scope is required")
+	}
+
+	baseURL := g.BaseURL
+	if baseURL == "" {
+		baseURL = GitHubAPIURL
+	}
+	client := g.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"description": g.Description,
+		"public":      false,
+		"files": map[string]any{
+			name: map[string]string{"content": string(content)},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/gists", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+g.Token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create gist: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("failed to create gist: %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+
+	var gist struct {
+		HTMLURL string `json:"html_url"`
+		Files   map[string]struct {
+			RawURL string `json:"raw_url"`
+		} `json:"files"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&gist); err != nil {
+		return "", fmt.Errorf("failed to decode gist response: %w", err)
+	}
+
+	// Gists serve raw files as text/plain; link to a renderer instead so the
+	// report opens as a page.
+	if file, ok := gist.Files[name]; ok && file.RawURL != "" {
+		return htmlPreviewURL + url.QueryEscape(file.RawURL), nil
+	}
+	return gist.HTMLURL, nil
+}
@@ -0,0 +1,239 @@
+package share
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxLinkExpiry is the longest validity S3 accepts for a presigned link.
+	MaxLinkExpiry = 7 * 24 * time.Hour
+
+	amzDateLayout   = "20060102T150405Z"
+	amzDayLayout    = "20060102"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// S3Uploader stores reports in an S3 (or S3-compatible) bucket and returns a
+// presigned GET link, so the bucket itself can stay private.
+type S3Uploader struct {
+	Bucket string
+	Region string
+	Prefix string
+	// Endpoint overrides the AWS endpoint for S3-compatible stores. Objects
+	// are then addressed path-style.
+	Endpoint string
+
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	Expiry time.Duration
+	Client *http.Client
+
+	now func() time.Time
+}
+
+func (s *S3Uploader) Upload(ctx context.Context, name string, content []byte) (string, error) {
+	if s.Bucket == "" || s.Region == "" {
+		return "", fmt.Errorf("reports.share.bucket and reports.share.region are required for S3 uploads")
+	}
+	if s.AccessKeyID == "" || s.SecretAccessKey == "" {
+		return "", fmt.Errorf("AWS credentials are required (AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY)")
+	}
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	objectURL, err := s.objectURL(strings.TrimPrefix(s.Prefix+name, "/"))
+	if err != nil {
+		return "", err
+	}
+
+	now := s.clock()
+	payloadHash := sha256Hex(content)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL.String(), bytes.NewReader(content))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "text/html; charset=utf-8")
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("X-Amz-Date", now.Format(amzDateLayout))
+	if s.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.SessionToken)
+	}
+
+	headers := map[string]string{
+		"content-type":         req.Header.Get("Content-Type"),
+		"host":                 objectURL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           req.Header.Get("X-Amz-Date"),
+	}
+	signedHeaders := []string{"content-type", "host", "x-amz-content-sha256", "x-amz-date"}
+	if s.SessionToken != "" {
+		headers["x-amz-security-token"] = s.SessionToken
+		signedHeaders = append(signedHeaders, "x-amz-security-token")
+	}
+
+	canonical := canonicalRequest(http.MethodPut, objectURL.EscapedPath(), "", headers, signedHeaders, payloadHash)
+	signature := s.sign(now, canonical)
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKeyID, s.scope(now), strings.Join(signedHeaders, ";"), signature))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload to s3://%s: %w", s.Bucket, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("failed to upload to s3://%s: %s: %s", s.Bucket, resp.Status, bytes.TrimSpace(message))
+	}
+
+	return s.presign(objectURL, now), nil
+}
+
+// presign returns a GET link for the object valid for Expiry.
+func (s *S3Uploader) presign(objectURL *url.URL, now time.Time) string {
+	expiry := s.Expiry
+	if expiry <= 0 || expiry > MaxLinkExpiry {
+		expiry = MaxLinkExpiry
+	}
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", s.AccessKeyID+"/"+s.scope(now))
+	query.Set("X-Amz-Date", now.Format(amzDateLayout))
+	query.Set("X-Amz-Expires", fmt.Sprintf("%d", int(expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	if s.SessionToken != "" {
+		query.Set("X-Amz-Security-Token", s.SessionToken)
+	}
+
+	canonicalQuery := canonicalQueryString(query)
+	canonical := canonicalRequest(http.MethodGet, objectURL.EscapedPath(), canonicalQuery,
+		map[string]string{"host": objectURL.Host}, []string{"host"}, unsignedPayload)
+
+	link := *objectURL
+	link.RawQuery = canonicalQuery + "&X-Amz-Signature=" + s.sign(now, canonical)
+	return link.String()
+}
+
+func (s *S3Uploader) objectURL(key string) (*url.URL, error) {
+	escapedKey := escapePath(key)
+
+	if s.Endpoint != "" {
+		endpoint, err := url.Parse(strings.TrimSuffix(s.Endpoint, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid reports.share.endpoint: %w", err)
+		}
+		return url.Parse(fmt.Sprintf("%s/%s/%s", endpoint, escapePath(s.Bucket), escapedKey))
+	}
+
+	return url.Parse(fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.Bucket, s.Region, escapedKey))
+}
+
+func (s *S3Uploader) clock() time.Time {
+	if s.now != nil {
+		return s.now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+func (s *S3Uploader) scope(now time.Time) string {
+	return fmt.Sprintf("%s/%s/s3/aws4_request", now.Format(amzDayLayout), s.Region)
+}
+
+// sign computes the AWS Signature Version 4 signature of a canonical request.
+func (s *S3Uploader) sign(now time.Time, canonical string) string {
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format(amzDateLayout),
+		s.scope(now),
+		sha256Hex([]byte(canonical)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretAccessKey), now.Format(amzDayLayout))
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func canonicalRequest(method, path, query string, headers map[string]string, signedHeaders []string, payloadHash string) string {
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+
+	return strings.Join([]string{
+		method,
+		path,
+		query,
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+}
+
+func canonicalQueryString(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, escape(key, true)+"="+escape(query.Get(key), true))
+	}
+	return strings.Join(parts, "&")
+}
+
+func escapePath(path string) string {
+	return escape(path, false)
+}
+
+// escape percent-encodes everything except unreserved characters, as SigV4
+// requires. Slashes are kept unless encodeSlash is set.
+func escape(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,12 @@
+// Package share publishes rendered reports so they can be viewed by people
+// without access to the task trackers.
+package share
+
+import (
+	"context"
+)
+
+// Uploader publishes a file and returns a read-only link to it.
+type Uploader interface {
+	Upload(ctx context.Context, name string, content []byte) (string, error)
+}
@@ -0,0 +1,216 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"slices"
+	"strings"
+	"time"
+
+	"opentask/pkg/logging"
+	"opentask/pkg/models"
+)
+
+const auditLogFile = "audit.log"
+
+// Audit actions.
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditClose   = "close"
+	AuditDelete  = "delete"
+	AuditArchive = "archive"
+	// AuditSync is a copy of a task created on another platform, such as
+	// with --sync-to.
+	AuditSync = "sync"
+)
+
+// AuditEntry records an operation that changed tasks, such as a bulk
+// delete, with enough about each task to review or undo it.
+type AuditEntry struct {
+	Time time.Time `json:"time"`
+	// Action is what was done, such as "create", "update" or "delete".
+	Action string `json:"action"`
+	// Source is where it was done from, such as "cli", "tui" or "serve".
+	Source string `json:"source"`
+	// Actor is the local user who ran opentask.
+	Actor string      `json:"actor,omitempty"`
+	Tasks []AuditTask `json:"tasks"`
+}
+
+// AuditTask is a task an audited operation affected.
+type AuditTask struct {
+	Platform string `json:"platform"`
+	ID       string `json:"id"`
+	Title    string `json:"title,omitempty"`
+	// Status is the task's status before the operation.
+	Status models.TaskStatus `json:"status,omitempty"`
+	// Changes are the fields an update changed.
+	Changes []AuditChange `json:"changes,omitempty"`
+	// Error is set when the operation failed for this task.
+	Error string `json:"error,omitempty"`
+}
+
+// NewAuditTask describes task for the audit log, with the error the
+// operation failed with, if any.
+func NewAuditTask(task *models.Task, err error) AuditTask {
+	entry := AuditTask{
+		Platform: string(task.Platform),
+		ID:       task.ID,
+		Title:    task.Title,
+		Status:   task.Status,
+	}
+	if err != nil {
+		entry.Error = logging.Redact(err.Error())
+	}
+	return entry
+}
+
+// NewAuditUpdate describes an update for the audit log: the task as it
+// was, the fields the update changed to give after, and the error it failed
+// with, if any. A nil after records no changes.
+func NewAuditUpdate(before, after *models.Task, err error) AuditTask {
+	entry := NewAuditTask(before, err)
+	if after != nil {
+		entry.Changes = TaskChanges(before, after)
+	}
+	return entry
+}
+
+// AuditChange is a field an operation changed, with its values before and
+// after. The description is recorded without its values.
+type AuditChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// TaskChanges returns the fields that differ between before and after.
+func TaskChanges(before, after *models.Task) []AuditChange {
+	var changes []AuditChange
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, AuditChange{Field: field, From: from, To: to})
+		}
+	}
+
+	add("title", before.Title, after.Title)
+	if before.Description != after.Description {
+		changes = append(changes, AuditChange{Field: "description"})
+	}
+	add("status", string(before.Status), string(after.Status))
+	add("priority", string(before.Priority), string(after.Priority))
+	add("assignee", auditUser(before.Assignee), auditUser(after.Assignee))
+	add("project", before.ProjectID, after.ProjectID)
+	if !slices.Equal(before.Labels, after.Labels) {
+		add("labels", strings.Join(before.Labels, ","), strings.Join(after.Labels, ","))
+	}
+	add("due", auditDate(before.DueDate), auditDate(after.DueDate))
+	return changes
+}
+
+func auditUser(u *models.User) string {
+	switch {
+	case u == nil:
+		return ""
+	case u.Email != "":
+		return u.Email
+	}
+	return u.Name
+}
+
+func auditDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// Audit appends an entry for action on tasks to the audit log in the
+// default data directory, stamped with the time and the local user.
+func Audit(action, source string, tasks []AuditTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	st, err := Open()
+	if err != nil {
+		return err
+	}
+	return st.Audit(action, source, tasks)
+}
+
+// Audit appends an entry for action on tasks to the audit log, stamped with
+// the time and the local user. It does nothing without tasks.
+func (s *Store) Audit(action, source string, tasks []AuditTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	return s.AppendAudit(AuditEntry{
+		Time:   time.Now(),
+		Action: action,
+		Source: source,
+		Actor:  actor(),
+		Tasks:  tasks,
+	})
+}
+
+// actor names the local user, or is empty if it cannot be told.
+func actor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// AppendAudit adds entry to the audit log, one JSON object per line.
+func (s *Store) AppendAudit(entry AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	f, err := os.OpenFile(s.path(auditLogFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// ReadAudit returns the entries of the audit log, oldest first. Lines that
+// cannot be decoded, such as one cut short when opentask was killed while
+// writing it, are skipped.
+func (s *Store) ReadAudit() ([]AuditEntry, error) {
+	f, err := os.Open(s.path(auditLogFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
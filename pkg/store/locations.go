@@ -0,0 +1,101 @@
+package store
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"sort"
+	"time"
+)
+
+const (
+	taskLocationsFile = "locations.json"
+
+	// MaxTaskLocations bounds how many task IDs are kept in the location
+	// cache.
+	MaxTaskLocations = 5000
+)
+
+// TaskLocation records the platforms a task ID was last seen on, so
+// commands given a bare ID can ask those platforms first instead of all of
+// them. An ID is on more than one platform only when they each have a task
+// with it.
+type TaskLocation struct {
+	Platforms []string  `json:"platforms"`
+	SeenAt    time.Time `json:"seen_at"`
+}
+
+// TaskLocations returns the location cache, keyed by task ID.
+func (s *Store) TaskLocations() (map[string]TaskLocation, error) {
+	locations := make(map[string]TaskLocation)
+	if err := s.readJSON(taskLocationsFile, &locations); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return locations, nil
+		}
+		return nil, err
+	}
+	return locations, nil
+}
+
+// LocateTask returns the platforms task id was last seen on, or nil.
+func (s *Store) LocateTask(id string) []string {
+	locations, err := s.TaskLocations()
+	if err != nil {
+		return nil
+	}
+	return locations[id].Platforms
+}
+
+// RememberLocations records that the tasks ids are on platform, dropping
+// the IDs seen longest ago beyond MaxTaskLocations.
+func (s *Store) RememberLocations(platform string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	locations, err := s.TaskLocations()
+	if err != nil {
+		locations = make(map[string]TaskLocation)
+	}
+
+	now := time.Now()
+	for _, id := range ids {
+		location := locations[id]
+		if !slices.Contains(location.Platforms, platform) {
+			location.Platforms = append(location.Platforms, platform)
+		}
+		location.SeenAt = now
+		locations[id] = location
+	}
+
+	if len(locations) > MaxTaskLocations {
+		ids := make([]string, 0, len(locations))
+		for id := range locations {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return locations[ids[i]].SeenAt.After(locations[ids[j]].SeenAt) })
+		for _, id := range ids[MaxTaskLocations:] {
+			delete(locations, id)
+		}
+	}
+	return s.writeJSON(taskLocationsFile, locations)
+}
+
+// ForgetLocation removes platform from the platforms task id was seen on,
+// after the platform reported it does not have the task.
+func (s *Store) ForgetLocation(id, platform string) error {
+	locations, err := s.TaskLocations()
+	if err != nil {
+		return err
+	}
+	location, ok := locations[id]
+	if !ok {
+		return nil
+	}
+	location.Platforms = slices.DeleteFunc(location.Platforms, func(name string) bool { return name == platform })
+	if len(location.Platforms) == 0 {
+		delete(locations, id)
+	} else {
+		locations[id] = location
+	}
+	return s.writeJSON(taskLocationsFile, locations)
+}
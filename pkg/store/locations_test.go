@@ -0,0 +1,50 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_TaskLocations(t *testing.T) {
+	st, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	assert.Nil(t, st.LocateTask("API-1"))
+
+	require.NoError(t, st.RememberLocations("jira", []string{"API-1", "API-2"}))
+	require.NoError(t, st.RememberLocations("jira", []string{"API-1"}))
+	require.NoError(t, st.RememberLocations("jira-cloud", []string{"API-1"}))
+	assert.Equal(t, []string{"jira", "jira-cloud"}, st.LocateTask("API-1"))
+	assert.Equal(t, []string{"jira"}, st.LocateTask("API-2"))
+
+	require.NoError(t, st.ForgetLocation("API-1", "jira"))
+	assert.Equal(t, []string{"jira-cloud"}, st.LocateTask("API-1"))
+	require.NoError(t, st.ForgetLocation("API-2", "jira"))
+	assert.Nil(t, st.LocateTask("API-2"))
+	require.NoError(t, st.ForgetLocation("API-3", "jira"))
+
+	locations, err := st.TaskLocations()
+	require.NoError(t, err)
+	assert.Len(t, locations, 1)
+}
+
+func TestStore_RememberLocationsBounded(t *testing.T) {
+	st, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, st.RememberLocations("jira", []string{"OLD-1"}))
+	ids := make([]string, MaxTaskLocations)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("API-%d", i+1)
+	}
+	require.NoError(t, st.RememberLocations("jira", ids))
+
+	locations, err := st.TaskLocations()
+	require.NoError(t, err)
+	assert.Len(t, locations, MaxTaskLocations)
+	assert.Nil(t, st.LocateTask("OLD-1"))
+	assert.Equal(t, []string{"jira"}, st.LocateTask("API-1"))
+}
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"errors"
+	"io/fs"
+	"time"
+)
+
+const notifyStateFile = "notifications.json"
+
+// NotifyState records when each digest was last sent, so the daemon sends
+// it once per scheduled time.
+type NotifyState struct {
+	Digests map[string]time.Time `json:"digests,omitempty"`
+}
+
+// LastDigest returns when the digest was last sent, or the zero time.
+func (n *NotifyState) LastDigest(name string) time.Time {
+	return n.Digests[name]
+}
+
+// MarkDigest records that the digest was sent for the given time.
+func (n *NotifyState) MarkDigest(name string, at time.Time) {
+	if n.Digests == nil {
+		n.Digests = make(map[string]time.Time)
+	}
+	n.Digests[name] = at
+}
+
+// LoadNotifyState returns the saved notification state, or an empty state
+// before any digest was sent.
+func (s *Store) LoadNotifyState() (*NotifyState, error) {
+	var state NotifyState
+	if err := s.readJSON(notifyStateFile, &state); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &NotifyState{}, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (s *Store) SaveNotifyState(state *NotifyState) error {
+	return s.writeJSON(notifyStateFile, state)
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"sync"
+
+	"opentask/pkg/platforms"
+)
+
+const projectsFile = "projects.json"
+
+// ProjectCache keeps the projects listed on each platform between runs. It
+// implements platforms.ProjectCache.
+type ProjectCache struct {
+	store *Store
+
+	mu       sync.Mutex
+	loaded   bool
+	listings map[string]platforms.ProjectListing
+}
+
+// ProjectCache returns a project cache kept in the store.
+func (s *Store) ProjectCache() *ProjectCache {
+	return &ProjectCache{store: s}
+}
+
+func (c *ProjectCache) Get(platform string) (platforms.ProjectListing, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	listing, ok := c.listings[platform]
+	return listing, ok
+}
+
+// Put records a project listing. Failing to save it only means the
+// projects are listed again next time, so write errors are ignored.
+func (c *ProjectCache) Put(platform string, listing platforms.ProjectListing) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	c.listings[platform] = listing
+	_ = c.store.writeJSON(projectsFile, c.listings)
+}
+
+// load reads the saved listings once. A missing or unreadable file starts
+// an empty cache. Listings saved by earlier versions only kept project
+// keys for completion; they are dropped so the projects are listed again.
+func (c *ProjectCache) load() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+	if err := c.store.readJSON(projectsFile, &c.listings); err != nil || c.listings == nil {
+		c.listings = make(map[string]platforms.ProjectListing)
+	}
+	for platform, listing := range c.listings {
+		for _, project := range listing.Projects {
+			if project == nil || project.Platform == "" {
+				delete(c.listings, platform)
+				break
+			}
+		}
+	}
+}
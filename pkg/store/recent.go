@@ -0,0 +1,71 @@
+package store
+
+import (
+	"errors"
+	"io/fs"
+	"time"
+
+	"opentask/pkg/models"
+)
+
+const (
+	recentTasksFile = "recent.json"
+
+	// MaxRecentTasks bounds how many recently seen tasks are kept.
+	MaxRecentTasks = 200
+)
+
+// RecentTask is a task a command listed, kept so shell completion can
+// offer its ID.
+type RecentTask struct {
+	ID       string    `json:"id"`
+	Platform string    `json:"platform"`
+	Title    string    `json:"title,omitempty"`
+	SeenAt   time.Time `json:"seen_at"`
+}
+
+// RecentTasks returns the recently seen tasks, most recent first.
+func (s *Store) RecentTasks() ([]RecentTask, error) {
+	var tasks []RecentTask
+	if err := s.readJSON(recentTasksFile, &tasks); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// RememberTasks puts tasks ahead of the recently seen tasks, dropping the
+// oldest beyond MaxRecentTasks.
+func (s *Store) RememberTasks(tasks []*models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	previous, err := s.RecentTasks()
+	if err != nil {
+		previous = nil
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool)
+	recent := make([]RecentTask, 0, len(tasks)+len(previous))
+	for _, task := range tasks {
+		key := task.Ref()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		recent = append(recent, RecentTask{ID: task.ID, Platform: string(task.Platform), Title: task.Title, SeenAt: now})
+	}
+	for _, task := range previous {
+		if key := models.FormatRef(task.Platform, task.ID); !seen[key] {
+			seen[key] = true
+			recent = append(recent, task)
+		}
+	}
+	if len(recent) > MaxRecentTasks {
+		recent = recent[:MaxRecentTasks]
+	}
+	return s.writeJSON(recentTasksFile, recent)
+}
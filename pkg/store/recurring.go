@@ -0,0 +1,48 @@
+package store
+
+import (
+	"errors"
+	"io/fs"
+	"time"
+)
+
+const recurringFile = "recurring.json"
+
+// RecurringTask is a task created on a cron schedule by
+// 'opentask recurring run' or the daemon.
+type RecurringTask struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Cron        string   `json:"cron"`
+	Platform    string   `json:"platform"`
+	Project     string   `json:"project,omitempty"`
+	Priority    string   `json:"priority,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	// LastRun is the scheduled time the task was last created for, so
+	// each occurrence creates one task.
+	LastRun time.Time `json:"last_run"`
+	// LastTaskID is the ID of the task created last.
+	LastTaskID string `json:"last_task_id,omitempty"`
+}
+
+// LoadRecurring returns the recurring tasks, in the order they were added.
+func (s *Store) LoadRecurring() ([]RecurringTask, error) {
+	var tasks []RecurringTask
+	if err := s.readJSON(recurringFile, &tasks); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (s *Store) SaveRecurring(tasks []RecurringTask) error {
+	if tasks == nil {
+		tasks = []RecurringTask{}
+	}
+	return s.writeJSON(recurringFile, tasks)
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"errors"
+	"io/fs"
+	"time"
+)
+
+const rulesStateFile = "rules.json"
+
+// RulesState records the tasks each rule has acted on, so a rule acts on
+// a task once.
+type RulesState struct {
+	// Applied maps rule names to the tasks, as platform:ID, the rule was
+	// applied to and when.
+	Applied map[string]map[string]time.Time `json:"applied,omitempty"`
+}
+
+// WasApplied reports whether the rule already acted on the task.
+func (r *RulesState) WasApplied(rule, task string) bool {
+	_, ok := r.Applied[rule][task]
+	return ok
+}
+
+// MarkApplied records that the rule acted on the task at the given time.
+func (r *RulesState) MarkApplied(rule, task string, at time.Time) {
+	if r.Applied == nil {
+		r.Applied = make(map[string]map[string]time.Time)
+	}
+	if r.Applied[rule] == nil {
+		r.Applied[rule] = make(map[string]time.Time)
+	}
+	r.Applied[rule][task] = at
+}
+
+// LoadRulesState returns the saved rules state, or an empty state when
+// no rule has run yet.
+func (s *Store) LoadRulesState() (*RulesState, error) {
+	var state RulesState
+	if err := s.readJSON(rulesStateFile, &state); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &RulesState{}, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (s *Store) SaveRulesState(state *RulesState) error {
+	return s.writeJSON(rulesStateFile, state)
+}
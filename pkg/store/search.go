@@ -0,0 +1,27 @@
+package store
+
+import (
+	"errors"
+	"io/fs"
+
+	"opentask/pkg/search"
+)
+
+const searchIndexFile = "search-index.json"
+
+// LoadSearchIndex returns the saved search index, or an empty index before
+// the first rebuild.
+func (s *Store) LoadSearchIndex() (*search.Index, error) {
+	index := search.New()
+	if err := s.readJSON(searchIndexFile, index); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return search.New(), nil
+		}
+		return nil, err
+	}
+	return index, nil
+}
+
+func (s *Store) SaveSearchIndex(index *search.Index) error {
+	return s.writeJSON(searchIndexFile, index)
+}
@@ -0,0 +1,88 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"opentask/pkg/models"
+)
+
+const (
+	snapshotDir        = "snapshots"
+	snapshotDateLayout = "2006-01-02"
+)
+
+// Snapshot is a point-in-time copy of all tasks known to OpenTask.
+// At most one snapshot is kept per day; later snapshots replace earlier ones.
+type Snapshot struct {
+	TakenAt time.Time      `json:"taken_at"`
+	Tasks   []*models.Task `json:"tasks"`
+}
+
+func NewSnapshot(tasks []*models.Task) *Snapshot {
+	return &Snapshot{
+		TakenAt: time.Now(),
+		Tasks:   tasks,
+	}
+}
+
+// Day returns the local calendar day the snapshot belongs to.
+func (s *Snapshot) Day() time.Time {
+	y, m, d := s.TakenAt.Local().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+func (s *Store) SaveSnapshot(snapshot *Snapshot) error {
+	name := snapshotDir + "/" + snapshot.Day().Format(snapshotDateLayout) + ".json"
+	return s.writeJSON(name, snapshot)
+}
+
+// LoadSnapshots returns snapshots taken on days within [from, to], oldest first.
+func (s *Store) LoadSnapshots(from, to time.Time) ([]*Snapshot, error) {
+	entries, err := os.ReadDir(s.path(snapshotDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read snapshot history: %w", err)
+	}
+
+	fromDay := from.Format(snapshotDateLayout)
+	toDay := to.Format(snapshotDateLayout)
+
+	var names []string
+	for _, entry := range entries {
+		day, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if day < fromDay || day > toDay {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	snapshots := make([]*Snapshot, 0, len(names))
+	for _, name := range names {
+		snapshot := &Snapshot{}
+		if err := s.readJSON(snapshotDir+"/"+name, snapshot); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// LatestSnapshot returns the most recent snapshot, or nil if none exist.
+func (s *Store) LatestSnapshot() (*Snapshot, error) {
+	snapshots, err := s.LoadSnapshots(time.Time{}, time.Now().AddDate(0, 0, 1))
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+	return snapshots[len(snapshots)-1], nil
+}
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"errors"
+	"io/fs"
+	"time"
+
+	"opentask/pkg/activity"
+)
+
+const (
+	tuiStateFile = "tui.json"
+
+	// MaxNotifications bounds the notification history kept between runs.
+	MaxNotifications = 200
+)
+
+// TUIState is what the terminal app remembers between runs.
+type TUIState struct {
+	Tab           string                        `json:"tab,omitempty"`
+	RefreshedAt   time.Time                     `json:"refreshed_at,omitempty"`
+	Tasks         map[string]activity.TaskState `json:"tasks,omitempty"`
+	Notifications []activity.Event              `json:"notifications,omitempty"`
+}
+
+// AddNotifications puts events ahead of older notifications, dropping the
+// oldest beyond MaxNotifications.
+func (t *TUIState) AddNotifications(events []activity.Event) {
+	t.Notifications = append(append([]activity.Event{}, events...), t.Notifications...)
+	if len(t.Notifications) > MaxNotifications {
+		t.Notifications = t.Notifications[:MaxNotifications]
+	}
+}
+
+// Unread returns the number of notifications not yet marked read.
+func (t *TUIState) Unread() int {
+	unread := 0
+	for _, n := range t.Notifications {
+		if !n.Read {
+			unread++
+		}
+	}
+	return unread
+}
+
+// LoadTUIState returns the saved app state, or an empty state on first run.
+func (s *Store) LoadTUIState() (*TUIState, error) {
+	var state TUIState
+	if err := s.readJSON(tuiStateFile, &state); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &TUIState{}, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (s *Store) SaveTUIState(state *TUIState) error {
+	return s.writeJSON(tuiStateFile, state)
+}
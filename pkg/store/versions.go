@@ -0,0 +1,54 @@
+package store
+
+import (
+	"sync"
+
+	"opentask/pkg/platforms"
+)
+
+const versionsFile = "versions.json"
+
+// VersionCache keeps the versions detected for platform instances between
+// runs. It implements platforms.VersionCache.
+type VersionCache struct {
+	store *Store
+
+	mu       sync.Mutex
+	loaded   bool
+	versions map[string]platforms.InstanceVersion
+}
+
+// VersionCache returns a version cache kept in the store.
+func (s *Store) VersionCache() *VersionCache {
+	return &VersionCache{store: s}
+}
+
+func (c *VersionCache) Get(instance string) (platforms.InstanceVersion, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	version, ok := c.versions[instance]
+	return version, ok
+}
+
+// Put records a version. Failing to save it only means it is detected
+// again next time, so write errors are ignored.
+func (c *VersionCache) Put(instance string, version platforms.InstanceVersion) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	c.versions[instance] = version
+	_ = c.store.writeJSON(versionsFile, c.versions)
+}
+
+// load reads the saved versions once. A missing or unreadable file starts
+// an empty cache.
+func (c *VersionCache) load() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+	if err := c.store.readJSON(versionsFile, &c.versions); err != nil || c.versions == nil {
+		c.versions = make(map[string]platforms.InstanceVersion)
+	}
+}
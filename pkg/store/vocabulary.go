@@ -0,0 +1,54 @@
+package store
+
+import (
+	"sync"
+
+	"opentask/pkg/platforms"
+)
+
+const vocabularyFile = "vocabulary.json"
+
+// VocabularyCache keeps the labels and components listed for projects
+// between runs. It implements platforms.VocabularyCache.
+type VocabularyCache struct {
+	store *Store
+
+	mu           sync.Mutex
+	loaded       bool
+	vocabularies map[string]platforms.Vocabulary
+}
+
+// VocabularyCache returns a vocabulary cache kept in the store.
+func (s *Store) VocabularyCache() *VocabularyCache {
+	return &VocabularyCache{store: s}
+}
+
+func (c *VocabularyCache) Get(key string) (platforms.Vocabulary, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	vocabulary, ok := c.vocabularies[key]
+	return vocabulary, ok
+}
+
+// Put records a vocabulary. Failing to save it only means it is listed
+// again next time, so write errors are ignored.
+func (c *VocabularyCache) Put(key string, vocabulary platforms.Vocabulary) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	c.vocabularies[key] = vocabulary
+	_ = c.store.writeJSON(vocabularyFile, c.vocabularies)
+}
+
+// load reads the saved vocabularies once. A missing or unreadable file
+// starts an empty cache.
+func (c *VocabularyCache) load() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+	if err := c.store.readJSON(vocabularyFile, &c.vocabularies); err != nil || c.vocabularies == nil {
+		c.vocabularies = make(map[string]platforms.Vocabulary)
+	}
+}
@@ -0,0 +1,175 @@
+// Package styles holds the color theme shared by the terminal UI. Views
+// build their lipgloss styles from Current when rendering, so the theme
+// configured under ui.theme applies everywhere.
+package styles
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Theme is the set of colors used by the terminal UI.
+type Theme struct {
+	Name string
+
+	// Accent marks titles, focused borders and the spinner.
+	Accent lipgloss.Color
+	// Border is used for unfocused borders and separators.
+	Border lipgloss.Color
+	// Muted is used for help text and secondary details.
+	Muted lipgloss.Color
+	// Selected and SelectedBackground color the highlighted row.
+	Selected           lipgloss.Color
+	SelectedBackground lipgloss.Color
+
+	Info    lipgloss.Color
+	Success lipgloss.Color
+	Warning lipgloss.Color
+	Error   lipgloss.Color
+}
+
+// Dark is the default theme, tuned for dark terminal backgrounds.
+var Dark = Theme{
+	Name:               "dark",
+	Accent:             lipgloss.Color("62"),
+	Border:             lipgloss.Color("240"),
+	Muted:              lipgloss.Color("241"),
+	Selected:           lipgloss.Color("229"),
+	SelectedBackground: lipgloss.Color("57"),
+	Info:               lipgloss.Color("39"),
+	Success:            lipgloss.Color("42"),
+	Warning:            lipgloss.Color("214"),
+	Error:              lipgloss.Color("196"),
+}
+
+// Light is tuned for light terminal backgrounds.
+var Light = Theme{
+	Name:               "light",
+	Accent:             lipgloss.Color("57"),
+	Border:             lipgloss.Color("250"),
+	Muted:              lipgloss.Color("244"),
+	Selected:           lipgloss.Color("231"),
+	SelectedBackground: lipgloss.Color("62"),
+	Info:               lipgloss.Color("25"),
+	Success:            lipgloss.Color("28"),
+	Warning:            lipgloss.Color("166"),
+	Error:              lipgloss.Color("160"),
+}
+
+var themes = map[string]Theme{
+	Dark.Name:  Dark,
+	Light.Name: Light,
+}
+
+var (
+	current = Dark
+	noColor bool
+)
+
+// Current returns the active theme.
+func Current() Theme {
+	return current
+}
+
+// Use makes t the active theme.
+func Use(t Theme) {
+	current = t
+}
+
+// DisableColor turns off all colors, as requested by NO_COLOR or
+// --no-color. Bold, borders and layout are kept.
+func DisableColor() {
+	noColor = true
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// ColorDisabled reports whether DisableColor was called.
+func ColorDisabled() bool {
+	return noColor
+}
+
+var hexColor = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// FromConfig builds the theme described by the ui.theme configuration.
+func FromConfig(cfg config.Theme) (Theme, error) {
+	name := cfg.Name
+	if name == "" {
+		name = Dark.Name
+	}
+
+	theme, ok := themes[name]
+	if !ok {
+		return Dark, fmt.Errorf("unknown theme %q, expected dark or light", cfg.Name)
+	}
+
+	overrides := []struct {
+		key   string
+		value string
+		color *lipgloss.Color
+	}{
+		{"accent", cfg.Colors.Accent, &theme.Accent},
+		{"border", cfg.Colors.Border, &theme.Border},
+		{"muted", cfg.Colors.Muted, &theme.Muted},
+		{"selected", cfg.Colors.Selected, &theme.Selected},
+		{"selected_background", cfg.Colors.SelectedBackground, &theme.SelectedBackground},
+		{"info", cfg.Colors.Info, &theme.Info},
+		{"success", cfg.Colors.Success, &theme.Success},
+		{"warning", cfg.Colors.Warning, &theme.Warning},
+		{"error", cfg.Colors.Error, &theme.Error},
+	}
+	for _, o := range overrides {
+		if o.value == "" {
+			continue
+		}
+		if !validColor(o.value) {
+			return Dark, fmt.Errorf("invalid color %q for ui.theme.colors.%s, expected an ANSI number (0-255) or #rrggbb", o.value, o.key)
+		}
+		*o.color = lipgloss.Color(o.value)
+	}
+
+	return theme, nil
+}
+
+func validColor(value string) bool {
+	if hexColor.MatchString(value) {
+		return true
+	}
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// StatusColor returns the color used for tasks with the given status.
+func (t Theme) StatusColor(status models.TaskStatus) lipgloss.Color {
+	switch status {
+	case models.StatusOpen:
+		return t.Info
+	case models.StatusInProgress:
+		return t.Warning
+	case models.StatusDone:
+		return t.Success
+	default:
+		return t.Muted
+	}
+}
+
+// Title is the style for view headers.
+func Title() lipgloss.Style {
+	return lipgloss.NewStyle().Bold(true).Foreground(current.Accent)
+}
+
+// Help is the style for key hints and secondary text.
+func Help() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(current.Muted)
+}
+
+// SelectedRow is the style for the highlighted row of a list or table.
+func SelectedRow() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(current.Selected).Background(current.SelectedBackground)
+}
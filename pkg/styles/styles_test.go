@@ -0,0 +1,78 @@
+package styles
+
+import (
+	"testing"
+
+	"opentask/pkg/config"
+	"opentask/pkg/models"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.Theme
+		check   func(t *testing.T, theme Theme)
+		wantErr string
+	}{
+		{
+			name: "default is dark",
+			cfg:  config.Theme{},
+			check: func(t *testing.T, theme Theme) {
+				assert.Equal(t, Dark, theme)
+			},
+		},
+		{
+			name: "light",
+			cfg:  config.Theme{Name: "light"},
+			check: func(t *testing.T, theme Theme) {
+				assert.Equal(t, Light, theme)
+			},
+		},
+		{
+			name: "custom colors override the base theme",
+			cfg: config.Theme{
+				Name:   "light",
+				Colors: config.ThemeColors{Accent: "#7D56F4", Success: "34"},
+			},
+			check: func(t *testing.T, theme Theme) {
+				assert.Equal(t, lipgloss.Color("#7D56F4"), theme.Accent)
+				assert.Equal(t, lipgloss.Color("34"), theme.Success)
+				assert.Equal(t, Light.Border, theme.Border)
+				assert.Equal(t, lipgloss.Color("34"), theme.StatusColor(models.StatusDone))
+			},
+		},
+		{
+			name:    "unknown theme",
+			cfg:     config.Theme{Name: "solarized"},
+			wantErr: `unknown theme "solarized"`,
+		},
+		{
+			name:    "invalid color",
+			cfg:     config.Theme{Colors: config.ThemeColors{SelectedBackground: "purple"}},
+			wantErr: "ui.theme.colors.selected_background",
+		},
+		{
+			name:    "out of range ANSI color",
+			cfg:     config.Theme{Colors: config.ThemeColors{Error: "300"}},
+			wantErr: "ui.theme.colors.error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := FromConfig(tt.cfg)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Equal(t, Dark, theme)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, theme)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package taskfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StateFile is the default name of the file, kept next to the definitions,
+// that maps definition IDs to the tasks created for them.
+const StateFile = ".opentask-state.json"
+
+// State records which platform task each definition was applied to.
+type State struct {
+	Tasks map[string]StateEntry `json:"tasks"`
+}
+
+// StateEntry links a definition to a task on a platform.
+type StateEntry struct {
+	Platform  string    `json:"platform"`
+	TaskID    string    `json:"task_id"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// LoadState reads the state file at path. A missing file yields an empty
+// state.
+func LoadState(path string) (*State, error) {
+	state := &State{Tasks: make(map[string]StateEntry)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if state.Tasks == nil {
+		state.Tasks = make(map[string]StateEntry)
+	}
+
+	return state, nil
+}
+
+// Save writes the state to path atomically.
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".opentask-state-*")
+	if err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,285 @@
+// Package taskfile parses task definitions stored as Markdown files with
+// YAML front-matter or as plain YAML, so tasks can be kept under version
+// control alongside the code they describe.
+package taskfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"opentask/pkg/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+const frontMatterDelimiter = "---"
+
+// Definition is a task described by a file. Front-matter keys map to the
+// fields below; the Markdown body becomes Description. ID is a stable,
+// platform-independent identifier and defaults to the file name.
+type Definition struct {
+	ID       string            `yaml:"id,omitempty"`
+	Title    string            `yaml:"title"`
+	Labels   []string          `yaml:"labels,omitempty"`
+	Priority string            `yaml:"priority,omitempty"`
+	Assignee string            `yaml:"assignee,omitempty"`
+	Project  string            `yaml:"project,omitempty"`
+	Status   string            `yaml:"status,omitempty"`
+	Due      string            `yaml:"due,omitempty"`
+	Fields   map[string]string `yaml:"fields,omitempty"`
+
+	Description string `yaml:"description,omitempty"`
+	Path        string `yaml:"-"`
+}
+
+// Load reads and parses the task definition at path.
+func Load(path string) (*Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task file: %w", err)
+	}
+
+	var def *Definition
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		def, err = ParseYAML(data)
+	default:
+		def, err = Parse(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	def.Path = path
+	if def.ID == "" {
+		base := filepath.Base(path)
+		def.ID = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+
+	return def, nil
+}
+
+// LoadDir loads every task definition (*.md, *.yaml, *.yml) under dir,
+// skipping hidden files and directories. IDs must be unique.
+func LoadDir(dir string) ([]*Definition, error) {
+	var defs []*Definition
+	seen := make(map[string]string)
+
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".md", ".markdown", ".yaml", ".yml":
+		default:
+			return nil
+		}
+
+		def, err := Load(path)
+		if err != nil {
+			return err
+		}
+		if other, ok := seen[def.ID]; ok {
+			return fmt.Errorf("duplicate task id %q in %s and %s", def.ID, other, path)
+		}
+		seen[def.ID] = path
+		defs = append(defs, def)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return defs, nil
+}
+
+// ParseYAML parses a definition written entirely in YAML, with the
+// description given under the "description" key.
+func ParseYAML(data []byte) (*Definition, error) {
+	def := &Definition{}
+	if err := yaml.Unmarshal(data, def); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	def.normalize()
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	return def, nil
+}
+
+// Parse splits data into YAML front-matter and a Markdown body. A file
+// without front-matter is treated as body only, in which case the first
+// "# heading" line is used as the title.
+func Parse(data []byte) (*Definition, error) {
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	def := &Definition{}
+	body := lines
+
+	if strings.TrimSpace(lines[0]) == frontMatterDelimiter {
+		end := -1
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == frontMatterDelimiter {
+				end = i
+				break
+			}
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("front-matter is not terminated by %q", frontMatterDelimiter)
+		}
+
+		if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end], "\n")), def); err != nil {
+			return nil, fmt.Errorf("invalid front-matter: %w", err)
+		}
+		body = lines[end+1:]
+	}
+
+	description := strings.TrimSpace(strings.Join(body, "\n"))
+	if def.Title == "" {
+		def.Title, description = titleFromHeading(description)
+	}
+	if description != "" {
+		def.Description = description
+	}
+	def.normalize()
+
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	return def, nil
+}
+
+// Validate checks the definition for missing or malformed values.
+func (d *Definition) Validate() error {
+	if d.Title == "" {
+		return fmt.Errorf("task title is required (set 'title' in front-matter or start the body with '# Title')")
+	}
+
+	if d.Priority != "" && !models.Priority(d.Priority).IsValid() {
+		return fmt.Errorf("invalid priority %q (use low, medium, high, urgent)", d.Priority)
+	}
+
+	if d.Status != "" && !models.TaskStatus(d.Status).IsValid() {
+		return fmt.Errorf("invalid status %q (use open, in_progress, done, cancelled)", d.Status)
+	}
+
+	if d.Due != "" {
+		if _, err := time.Parse("2006-01-02", d.Due); err != nil {
+			return fmt.Errorf("invalid due date %q, expected YYYY-MM-DD", d.Due)
+		}
+	}
+
+	return nil
+}
+
+// ApplyTo copies the definition onto task. Fields left empty in the
+// definition are not touched.
+func (d *Definition) ApplyTo(task *models.Task) {
+	task.Title = d.Title
+	task.Description = d.Description
+
+	if d.Priority != "" {
+		task.SetPriority(models.Priority(d.Priority))
+	}
+	if d.Status != "" {
+		task.SetStatus(models.TaskStatus(d.Status))
+	}
+	if len(d.Labels) > 0 {
+		task.Labels = append([]string(nil), d.Labels...)
+	}
+	if d.Project != "" {
+		task.ProjectID = d.Project
+	}
+	if d.Assignee != "" {
+		task.SetMetadata("assignee_query", d.Assignee)
+	}
+	if d.Due != "" {
+		task.SetMetadata("due_date_string", d.Due)
+	}
+	if len(d.Fields) > 0 {
+		fields := make(map[string]string, len(d.Fields))
+		for key, value := range d.Fields {
+			fields[key] = value
+		}
+		task.SetMetadata("custom_fields", fields)
+	}
+
+	task.UpdatedAt = time.Now()
+}
+
+// Diff reports which managed fields of task differ from the definition.
+// Priority, status and labels are only compared when the definition sets
+// them.
+func (d *Definition) Diff(task *models.Task) []string {
+	var drift []string
+
+	if task.Title != d.Title {
+		drift = append(drift, "title")
+	}
+	if strings.TrimSpace(task.Description) != d.Description {
+		drift = append(drift, "description")
+	}
+	if d.Priority != "" && string(task.Priority) != d.Priority {
+		drift = append(drift, "priority")
+	}
+	if d.Status != "" && string(task.Status) != d.Status {
+		drift = append(drift, "status")
+	}
+	if len(d.Labels) > 0 && !sameLabels(task.Labels, d.Labels) {
+		drift = append(drift, "labels")
+	}
+
+	return drift
+}
+
+func (d *Definition) normalize() {
+	d.Title = strings.TrimSpace(d.Title)
+	d.Description = strings.TrimSpace(d.Description)
+	d.Priority = strings.ToLower(d.Priority)
+	d.Status = strings.ToLower(d.Status)
+}
+
+func sameLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, label := range a {
+		counts[label]++
+	}
+	for _, label := range b {
+		counts[label]--
+		if counts[label] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// titleFromHeading returns the text of a leading "# " heading and the body
+// with that heading removed.
+func titleFromHeading(body string) (string, string) {
+	first, rest, _ := strings.Cut(body, "\n")
+	if !strings.HasPrefix(first, "# ") {
+		return "", body
+	}
+	return strings.TrimSpace(first[2:]), strings.TrimSpace(rest)
+}
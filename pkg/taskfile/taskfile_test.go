@@ -0,0 +1,158 @@
+package taskfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"opentask/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *Definition
+		wantErr string
+	}{
+		{
+			name: "front-matter and body",
+			input: `---
+title: Add rate limiting
+labels: [api, backend]
+priority: High
+assignee: jane@example.com
+project: API
+due: 2024-06-30
+fields:
+  customfield_10011: Platform
+---
+
+Limit requests per token.
+
+- 100 req/min default
+`,
+			want: &Definition{
+				Title:       "Add rate limiting",
+				Labels:      []string{"api", "backend"},
+				Priority:    "high",
+				Assignee:    "jane@example.com",
+				Project:     "API",
+				Due:         "2024-06-30",
+				Fields:      map[string]string{"customfield_10011": "Platform"},
+				Description: "Limit requests per token.\n\n- 100 req/min default",
+			},
+		},
+		{
+			name:  "heading as title",
+			input: "# Fix flaky test\n\nRetries hide the real failure.\n",
+			want: &Definition{
+				Title:       "Fix flaky test",
+				Description: "Retries hide the real failure.",
+			},
+		},
+		{
+			name:  "front-matter without title falls back to heading",
+			input: "---\nlabels: [docs]\n---\n# Write guide\nBody\n",
+			want: &Definition{
+				Title:       "Write guide",
+				Labels:      []string{"docs"},
+				Description: "Body",
+			},
+		},
+		{
+			name:    "missing title",
+			input:   "---\nlabels: [docs]\n---\nJust a body\n",
+			wantErr: "title is required",
+		},
+		{
+			name:    "unterminated front-matter",
+			input:   "---\ntitle: Oops\n",
+			wantErr: "not terminated",
+		},
+		{
+			name:    "invalid priority",
+			input:   "---\ntitle: A\npriority: critical\n---\n",
+			wantErr: "invalid priority",
+		},
+		{
+			name:    "invalid due date",
+			input:   "---\ntitle: A\ndue: 30/06/2024\n---\n",
+			wantErr: "invalid due date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.input))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"rate-limit.md":        "---\ntitle: Add rate limiting\n---\nBody\n",
+		"cleanup.yaml":         "id: cleanup-2024\ntitle: Remove dead code\ndescription: Old handlers\nstatus: In_Progress\n",
+		"notes.txt":            "ignored",
+		".hidden/skip.md":      "# Hidden",
+		"nested/onboarding.md": "# Onboarding docs\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	defs, err := LoadDir(dir)
+	require.NoError(t, err)
+
+	byID := make(map[string]*Definition)
+	for _, def := range defs {
+		byID[def.ID] = def
+	}
+	require.Len(t, byID, 3)
+	assert.Equal(t, "Add rate limiting", byID["rate-limit"].Title)
+	assert.Equal(t, "Old handlers", byID["cleanup-2024"].Description)
+	assert.Equal(t, "in_progress", byID["cleanup-2024"].Status)
+	assert.Equal(t, "Onboarding docs", byID["onboarding"].Title)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "rate-limit.md"), []byte("# Duplicate\n"), 0644))
+	_, err = LoadDir(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate task id")
+}
+
+func TestDefinition_Diff(t *testing.T) {
+	def := &Definition{
+		Title:       "Add rate limiting",
+		Description: "Limit requests",
+		Priority:    "high",
+		Labels:      []string{"api", "backend"},
+	}
+
+	task := models.NewTask("Add rate limiting", models.PlatformJira)
+	task.Description = "Limit requests\n"
+	task.Priority = models.PriorityHigh
+	task.Labels = []string{"backend", "api"}
+	task.Status = models.StatusInProgress
+
+	assert.Empty(t, def.Diff(task), "status is unmanaged and label order is ignored")
+
+	task.Title = "Rate limiting"
+	task.Labels = []string{"api"}
+	assert.Equal(t, []string{"title", "labels"}, def.Diff(task))
+
+	def.ApplyTo(task)
+	assert.Empty(t, def.Diff(task))
+}
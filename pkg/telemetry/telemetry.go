@@ -0,0 +1,153 @@
+// Package telemetry provides optional OpenTelemetry tracing. Commands and
+// platform API calls are recorded as spans and exported over OTLP/HTTP when
+// an endpoint is configured; otherwise all tracing is a no-op.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"opentask/pkg/config"
+	"opentask/pkg/logging"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "opentask"
+	defaultServiceName  = "opentask"
+	tracesPath          = "/v1/traces"
+)
+
+var (
+	mu sync.RWMutex
+	// commandCtx carries the span of the running command. Requests made
+	// without a span in their context are attached to it.
+	commandCtx context.Context
+)
+
+// Enabled reports whether spans should be exported, either because an
+// endpoint is configured or the standard OTLP environment variables are set.
+func Enabled(cfg config.Telemetry) bool {
+	return cfg.Endpoint != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup installs a tracer provider that exports spans over OTLP/HTTP. If
+// tracing is not enabled it does nothing. The returned function flushes
+// pending spans and must be called before the process exits.
+func Setup(ctx context.Context, cfg config.Telemetry, version string) (func(context.Context) error, error) {
+	if !Enabled(cfg) {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var opts []otlptracehttp.Option
+	if cfg.Endpoint != "" {
+		endpoint, err := endpointURL(cfg.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+	}
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(version),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	providerOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	}
+	if cfg.SampleRatio > 0 {
+		providerOpts = append(providerOpts, sdktrace.WithSampler(
+			sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio)),
+		))
+	}
+
+	provider := sdktrace.NewTracerProvider(providerOpts...)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// endpointURL validates the configured endpoint and adds the OTLP traces
+// path when only the collector address is given.
+func endpointURL(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("invalid telemetry.endpoint %q, expected a URL such as http://localhost:4318", endpoint)
+	}
+	if strings.Trim(u.Path, "/") == "" {
+		u.Path = tracesPath
+	}
+	return u.String(), nil
+}
+
+// Tracer returns the tracer used for OpenTask spans.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// StartCommand starts the span covering a CLI command. Platform requests
+// made without a span in their context become its children.
+func StartCommand(ctx context.Context, name string) (context.Context, trace.Span) {
+	ctx, span := Tracer().Start(ctx, name)
+
+	mu.Lock()
+	commandCtx = ctx
+	mu.Unlock()
+
+	return ctx, span
+}
+
+// RecordError marks span as failed with err. The message is redacted
+// first, since errors can hold credentials and spans leave the machine.
+func RecordError(span trace.Span, err error) {
+	err = logging.RedactError(err)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// parentContext returns ctx, or the running command's context if ctx
+// carries no span.
+func parentContext(ctx context.Context) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	if commandCtx == nil {
+		return ctx
+	}
+	return trace.ContextWithSpan(ctx, trace.SpanFromContext(commandCtx))
+}
@@ -0,0 +1,126 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"opentask/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEndpointURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+		wantErr  bool
+	}{
+		{endpoint: "http://localhost:4318", expected: "http://localhost:4318/v1/traces"},
+		{endpoint: "https://otel.example.com/", expected: "https://otel.example.com/v1/traces"},
+		{endpoint: "https://otel.example.com/custom/traces", expected: "https://otel.example.com/custom/traces"},
+		{endpoint: "localhost:4318", wantErr: true},
+		{endpoint: "grpc://localhost:4317", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			got, err := endpointURL(tt.endpoint)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(nil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, command := StartCommand(context.Background(), "opentask task list")
+	client := &http.Client{Transport: Transport("jira", nil)}
+
+	// Requests without a span in their context join the command span.
+	resp, err := client.Get(server.URL + "/rest/api/2/search?jql=assignee%3Dme")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	resp, err = client.Get(server.URL + "/missing")
+	require.NoError(t, err)
+	resp.Body.Close()
+	command.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	ok, missing := spans[0], spans[1]
+	assert.Equal(t, "jira GET", ok.Name())
+	assert.Equal(t, command.SpanContext().TraceID(), ok.SpanContext().TraceID())
+	assert.Equal(t, command.SpanContext().SpanID(), ok.Parent().SpanID())
+	assert.Equal(t, codes.Unset, ok.Status().Code)
+
+	attrs := map[string]string{}
+	for _, kv := range ok.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	assert.Equal(t, "jira", attrs[string(PlatformKey)])
+	assert.Equal(t, "/rest/api/2/search", attrs["url.path"])
+	assert.Equal(t, "200", attrs["http.response.status_code"])
+	for _, value := range attrs {
+		assert.NotContains(t, value, "assignee")
+	}
+
+	assert.Equal(t, codes.Error, missing.Status().Code)
+	assert.Equal(t, "opentask task list", spans[2].Name())
+}
+
+type failingTransport struct{ err error }
+
+func (t failingTransport) RoundTrip(*http.Request) (*http.Response, error) { return nil, t.err }
+
+func TestTransport_RedactsErrors(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+	logging.AddSecrets("span-secret-token")
+	client := &http.Client{Transport: Transport("jira", failingTransport{
+		err: errors.New(`dial https://jira.example.com/?token=span-secret-token: connection refused`),
+	})}
+	_, err := client.Get("https://jira.example.com/rest/api/2/myself")
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, codes.Error, span.Status.Code)
+	assert.NotContains(t, span.Status.Description, "span-secret-token")
+	assert.Contains(t, span.Status.Description, "connection refused")
+	require.NotEmpty(t, span.Events)
+	for _, event := range span.Events {
+		for _, kv := range event.Attributes {
+			assert.NotContains(t, kv.Value.Emit(), "span-secret-token")
+		}
+	}
+}
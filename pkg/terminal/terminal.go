@@ -0,0 +1,41 @@
+// Package terminal decides whether commands run their interactive views or
+// print plain output, based on whether stdout is a terminal and the
+// ui.interactive setting.
+package terminal
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// Values of the ui.interactive setting.
+const (
+	// ModeAuto runs interactive views only when stdout is a terminal.
+	ModeAuto = "auto"
+	// ModeAlways runs interactive views even when output is redirected.
+	ModeAlways = "always"
+	// ModeNever always prints plain output.
+	ModeNever = "never"
+)
+
+// isTerminal is replaced in tests.
+var isTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Interactive reports whether interactive views should run for the given
+// ui.interactive mode. An empty mode is treated as ModeAuto.
+func Interactive(mode string) (bool, error) {
+	switch mode {
+	case "", ModeAuto:
+		return isTerminal(), nil
+	case ModeAlways:
+		return true, nil
+	case ModeNever:
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid ui.interactive %q, expected auto, always or never", mode)
+	}
+}
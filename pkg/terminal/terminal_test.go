@@ -0,0 +1,41 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInteractive(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		terminal bool
+		expected bool
+		wantErr  bool
+	}{
+		{name: "auto on a terminal", mode: "", terminal: true, expected: true},
+		{name: "auto when piped", mode: ModeAuto, terminal: false, expected: false},
+		{name: "always when piped", mode: ModeAlways, terminal: false, expected: true},
+		{name: "never on a terminal", mode: ModeNever, terminal: true, expected: false},
+		{name: "invalid mode", mode: "sometimes", terminal: true, wantErr: true},
+	}
+
+	original := isTerminal
+	defer func() { isTerminal = original }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isTerminal = func() bool { return tt.terminal }
+
+			got, err := Interactive(tt.mode)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Package throttle bounds how many requests opentask sends to a platform
+// at once and how many it starts per second, so bulk operations and
+// concurrent list fetches stay under Jira's and Linear's rate limits.
+package throttle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LimitsKey is the platform setting holding Limits:
+//
+//	limits:
+//	  max_in_flight: 4
+//	  requests_per_second: 10
+const LimitsKey = "limits"
+
+// Limits bound the requests sent to one platform. Zero means unlimited.
+type Limits struct {
+	// MaxInFlight is how many requests may be waiting for a response at
+	// once.
+	MaxInFlight int
+	// RequestsPerSecond is how many requests may be started per second.
+	RequestsPerSecond float64
+}
+
+// IsZero reports whether l limits nothing.
+func (l Limits) IsZero() bool {
+	return l.MaxInFlight <= 0 && l.RequestsPerSecond <= 0
+}
+
+// ParseLimits reads the limits setting from a platform config. Both
+// entries are optional.
+func ParseLimits(config map[string]any) (Limits, error) {
+	var limits Limits
+
+	raw, ok := config[LimitsKey]
+	if !ok || raw == nil {
+		return limits, nil
+	}
+
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		return limits, fmt.Errorf("%s must be a map with max_in_flight and requests_per_second", LimitsKey)
+	}
+
+	if value, ok := entries["max_in_flight"]; ok {
+		n, ok := number(value)
+		if !ok || n < 0 || n != math.Trunc(n) {
+			return limits, fmt.Errorf("%s: max_in_flight must be a whole number of at least 0, got %v", LimitsKey, value)
+		}
+		limits.MaxInFlight = int(n)
+	}
+	if value, ok := entries["requests_per_second"]; ok {
+		n, ok := number(value)
+		if !ok || n < 0 {
+			return limits, fmt.Errorf("%s: requests_per_second must be a number of at least 0, got %v", LimitsKey, value)
+		}
+		limits.RequestsPerSecond = n
+	}
+	for key := range entries {
+		if key != "max_in_flight" && key != "requests_per_second" {
+			return limits, fmt.Errorf("%s: unknown entry %q (use max_in_flight or requests_per_second)", LimitsKey, key)
+		}
+	}
+	return limits, nil
+}
+
+// number converts the numbers YAML and JSON decode to float64.
+func number(value any) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// Limiter enforces Limits on the requests that pass through it.
+type Limiter struct {
+	limits Limits
+	slots  chan struct{}
+
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// New returns a limiter enforcing limits.
+func New(limits Limits) *Limiter {
+	l := &Limiter{limits: limits}
+	if limits.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, limits.MaxInFlight)
+	}
+	if limits.RequestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / limits.RequestsPerSecond)
+	}
+	return l
+}
+
+// Wait blocks until a request may be sent, or ctx is done. The request
+// holds its in-flight slot until release is called.
+func (l *Limiter) Wait(ctx context.Context) (release func(), err error) {
+	release = func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			var once sync.Once
+			release = func() { once.Do(func() { <-l.slots }) }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if delay := l.reserve(time.Now()); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+// reserve takes the next start time and returns how long to wait for it.
+// Requests are spread evenly over each second rather than sent in bursts.
+func (l *Limiter) reserve(now time.Time) time.Duration {
+	if l.interval == 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	return delay
+}
+
+var (
+	sharedMu sync.Mutex
+	shared   = make(map[string]*Limiter)
+)
+
+// Shared returns the limiter for key, such as a platform's server URL, so
+// every client of the server shares its limits. A limiter made with other
+// limits is replaced. It returns nil when limits limit nothing.
+func Shared(key string, limits Limits) *Limiter {
+	if limits.IsZero() {
+		return nil
+	}
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	if l, ok := shared[key]; ok && l.limits == limits {
+		return l
+	}
+	l := New(limits)
+	shared[key] = l
+	return l
+}
+
+type transport struct {
+	limiter *Limiter
+	base    http.RoundTripper
+}
+
+// Transport wraps base so requests wait for limiter before they are sent.
+// A request holds its in-flight slot until its response body is closed. A
+// nil limiter returns base unchanged, and a nil base uses
+// http.DefaultTransport.
+func Transport(limiter *Limiter, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if limiter == nil {
+		return base
+	}
+	return &transport{limiter: limiter, base: base}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.limiter.Wait(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseBody releases a request's in-flight slot when its body is closed.
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
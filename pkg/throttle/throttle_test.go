@@ -0,0 +1,128 @@
+package throttle
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    Limits
+		wantErr string
+	}{
+		{name: "unset", config: map[string]any{}, want: Limits{}},
+		{
+			name:   "both",
+			config: map[string]any{"limits": map[string]any{"max_in_flight": 4, "requests_per_second": 2.5}},
+			want:   Limits{MaxInFlight: 4, RequestsPerSecond: 2.5},
+		},
+		{
+			name:   "whole float from JSON",
+			config: map[string]any{"limits": map[string]any{"max_in_flight": float64(3)}},
+			want:   Limits{MaxInFlight: 3},
+		},
+		{name: "not a map", config: map[string]any{"limits": 5}, wantErr: "must be a map"},
+		{name: "fractional in flight", config: map[string]any{"limits": map[string]any{"max_in_flight": 1.5}}, wantErr: "whole number"},
+		{name: "negative rate", config: map[string]any{"limits": map[string]any{"requests_per_second": -1}}, wantErr: "at least 0"},
+		{name: "text", config: map[string]any{"limits": map[string]any{"requests_per_second": "fast"}}, wantErr: "must be a number"},
+		{name: "unknown entry", config: map[string]any{"limits": map[string]any{"burst": 3}}, wantErr: `unknown entry "burst"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLimits(tt.config)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLimiter_Reserve(t *testing.T) {
+	l := New(Limits{RequestsPerSecond: 4})
+	now := time.Now()
+
+	assert.Equal(t, time.Duration(0), l.reserve(now))
+	assert.Equal(t, 250*time.Millisecond, l.reserve(now))
+	assert.Equal(t, 500*time.Millisecond, l.reserve(now))
+	// After an idle second the next request goes out at once.
+	assert.Equal(t, time.Duration(0), l.reserve(now.Add(2*time.Second)))
+}
+
+func TestLimiter_WaitCancelled(t *testing.T) {
+	l := New(Limits{MaxInFlight: 1})
+	release, err := l.Wait(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	release()
+	release, err = l.Wait(context.Background())
+	require.NoError(t, err)
+	release()
+}
+
+func TestTransport_MaxInFlight(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transport(New(Limits{MaxInFlight: 2}), nil)}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if !assert.NoError(t, err) {
+				return
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func TestShared(t *testing.T) {
+	assert.Nil(t, Shared("https://a.example.com", Limits{}))
+
+	a := Shared("https://a.example.com", Limits{MaxInFlight: 2})
+	assert.Same(t, a, Shared("https://a.example.com", Limits{MaxInFlight: 2}))
+	assert.NotSame(t, a, Shared("https://b.example.com", Limits{MaxInFlight: 2}))
+	assert.NotSame(t, a, Shared("https://a.example.com", Limits{MaxInFlight: 3}))
+
+	base := http.DefaultTransport
+	assert.Equal(t, base, Transport(nil, base))
+}
@@ -0,0 +1,116 @@
+// Package viewtoken encodes a saved task filter as a token a teammate can
+// pass to "task list --view". A token carries the filter, the platform's
+// non-secret settings and the names of environment variables holding
+// read-only credentials, never the credentials themselves.
+package viewtoken
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"opentask/pkg/models"
+)
+
+// Prefix marks a view token and the version of its format.
+const Prefix = "otv1."
+
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// View is a saved task filter on one platform.
+type View struct {
+	Name     string         `json:"name,omitempty"`
+	Platform string         `json:"platform"`
+	Settings map[string]any `json:"settings,omitempty"`
+
+	Project  string   `json:"project,omitempty"`
+	Status   string   `json:"status,omitempty"`
+	Assignee string   `json:"assignee,omitempty"`
+	Labels   []string `json:"labels,omitempty"`
+	Limit    int      `json:"limit,omitempty"`
+
+	// Credentials maps a credential key, such as "token", to the
+	// environment variable the guest keeps its read-only value in.
+	Credentials map[string]string `json:"credentials,omitempty"`
+}
+
+// Encode returns the token for v.
+func Encode(v View) (string, error) {
+	if err := v.Validate(); err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode view: %w", err)
+	}
+	return Prefix + base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decode parses a token made by Encode.
+func Decode(token string) (View, error) {
+	payload, ok := strings.CutPrefix(strings.TrimSpace(token), Prefix)
+	if !ok {
+		return View{}, fmt.Errorf("not a view token (expected the %s prefix)", Prefix)
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return View{}, fmt.Errorf("invalid view token: %w", err)
+	}
+
+	var v View
+	if err := json.Unmarshal(data, &v); err != nil {
+		return View{}, fmt.Errorf("invalid view token: %w", err)
+	}
+	if err := v.Validate(); err != nil {
+		return View{}, fmt.Errorf("invalid view token: %w", err)
+	}
+	return v, nil
+}
+
+// Validate checks that v names a platform and that every credential
+// reference is an environment variable name rather than a value.
+func (v View) Validate() error {
+	if v.Platform == "" {
+		return fmt.Errorf("view has no platform")
+	}
+	if v.Status != "" && !models.TaskStatus(v.Status).IsValid() {
+		return fmt.Errorf("invalid status %q", v.Status)
+	}
+
+	for _, key := range v.CredentialKeys() {
+		if !envName.MatchString(v.Credentials[key]) {
+			return fmt.Errorf("credential %q must name an environment variable, got %q", key, v.Credentials[key])
+		}
+	}
+	return nil
+}
+
+// CredentialKeys returns the referenced credential keys in sorted order.
+func (v View) CredentialKeys() []string {
+	keys := make([]string, 0, len(v.Credentials))
+	for key := range v.Credentials {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Filter returns the task filter the view selects.
+func (v View) Filter() *models.TaskFilter {
+	filter := &models.TaskFilter{
+		ProjectID: v.Project,
+		Assignee:  v.Assignee,
+		Labels:    v.Labels,
+		Limit:     v.Limit,
+	}
+	if v.Status != "" {
+		status := models.TaskStatus(v.Status)
+		filter.Status = &status
+	}
+	return filter
+}
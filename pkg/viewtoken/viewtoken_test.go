@@ -0,0 +1,101 @@
+package viewtoken
+
+import (
+	"strings"
+	"testing"
+
+	"opentask/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	view := View{
+		Name:        "Release blockers",
+		Platform:    "jira",
+		Settings:    map[string]any{"base_url": "https://example.atlassian.net"},
+		Project:     "API",
+		Status:      "open",
+		Labels:      []string{"blocker"},
+		Limit:       50,
+		Credentials: map[string]string{"token": "JIRA_READONLY_TOKEN", "email": "JIRA_READONLY_EMAIL"},
+	}
+
+	token, err := Encode(view)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, Prefix))
+
+	decoded, err := Decode(" " + token + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, view, decoded)
+	assert.Equal(t, []string{"email", "token"}, decoded.CredentialKeys())
+
+	filter := decoded.Filter()
+	assert.Equal(t, "API", filter.ProjectID)
+	assert.Equal(t, []string{"blocker"}, filter.Labels)
+	assert.Equal(t, 50, filter.Limit)
+	require.NotNil(t, filter.Status)
+	assert.Equal(t, models.StatusOpen, *filter.Status)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		view    View
+		wantErr string
+	}{
+		{
+			name: "valid",
+			view: View{Platform: "linear", Credentials: map[string]string{"token": "LINEAR_TOKEN"}},
+		},
+		{
+			name:    "missing platform",
+			view:    View{Project: "API"},
+			wantErr: "no platform",
+		},
+		{
+			name:    "invalid status",
+			view:    View{Platform: "jira", Status: "blocked"},
+			wantErr: `invalid status "blocked"`,
+		},
+		{
+			name:    "credential value instead of variable name",
+			view:    View{Platform: "jira", Credentials: map[string]string{"token": "ATATT3x-secret"}},
+			wantErr: `credential "token" must name an environment variable`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.view.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{name: "missing prefix", token: "abc", wantErr: "not a view token"},
+		{name: "bad encoding", token: Prefix + "!!!", wantErr: "invalid view token"},
+		{name: "bad json", token: Prefix + "bm90IGpzb24", wantErr: "invalid view token"},
+		{name: "no platform", token: Prefix + "e30", wantErr: "no platform"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.token)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}